@@ -10,7 +10,9 @@ import (
 	"time"
 
 	"github.com/your-org/ecommerce-backend/internal/config"
-	_ "github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/inventory"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/payment"
 	"github.com/your-org/ecommerce-backend/internal/infrastructure/database/postgres"
 	"github.com/your-org/ecommerce-backend/internal/infrastructure/database/redis"
 	"github.com/your-org/ecommerce-backend/internal/interfaces/http"
@@ -59,6 +61,10 @@ func main() {
 		log.Printf("Warning: Index creation failed: %v", err)
 	}
 
+	if err := migration.CreateSearchTriggers(); err != nil {
+		log.Printf("Warning: Search trigger creation failed: %v", err)
+	}
+
 	// Seed initial data in development
 	if cfg.IsDevelopment() {
 		if err := migration.SeedInitialData(); err != nil {
@@ -79,6 +85,13 @@ func main() {
 		}
 	}()
 
+	// Start background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go order.NewExportService(db.GetDB(), cfg).StartScheduler(jobsCtx)
+	go payment.NewStuckPaymentSweeper(db.GetDB(), cfg).StartSweeper(jobsCtx)
+	go inventory.NewService(db.GetDB(), cfg).StartReservationExpiry(jobsCtx)
+
 	// Wait for interrupt signal to gracefully shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
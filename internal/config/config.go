@@ -7,20 +7,34 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	_ "time/tzdata" // Embed timezone database for minimal container images
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	App      AppConfig
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	Security SecurityConfig
-	External ExternalConfig
-	Upload   UploadConfig
-	Logging  LoggingConfig
+	App       AppConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Redis     RedisConfig
+	JWT       JWTConfig
+	Security  SecurityConfig
+	Password  PasswordConfig
+	External  ExternalConfig
+	Upload    UploadConfig
+	Product   ProductConfig
+	Review    ReviewConfig
+	Order     OrderConfig
+	Payment   PaymentConfig
+	Compare   CompareConfig
+	Recent    RecentlyViewedConfig
+	Cart      CartConfig
+	Checkout  CheckoutConfig
+	Download  DownloadConfig
+	Badge     BadgeConfig
+	Inventory InventoryConfig
+	Logging   LoggingConfig
+	Metrics   MetricsConfig
 }
 
 // ExternalConfig contains external service configurations
@@ -29,6 +43,7 @@ type ExternalConfig struct {
 	Razorpay RazorpayConfig // Added Razorpay config
 	Email    EmailConfig
 	Storage  StorageConfig
+	Tracking TrackingConfig
 }
 
 // RazorpayConfig contains Razorpay payment configuration
@@ -37,6 +52,10 @@ type RazorpayConfig struct {
 	KeySecret     string
 	WebhookSecret string
 	Environment   string // "test" or "live"
+
+	MaxConcurrentCalls int           // Process-wide cap on in-flight API calls
+	MaxRetries         int           // Retries after a 429 response; 0 disables
+	RetryBaseDelay     time.Duration // Backoff before the first retry, doubled on each attempt
 }
 
 // StripeConfig contains Stripe payment configuration
@@ -47,6 +66,16 @@ type StripeConfig struct {
 	Environment    string
 }
 
+// TrackingConfig contains carrier shipment tracking configuration. Without a
+// provider, order tracking shows only the stored shipping details.
+type TrackingConfig struct {
+	Provider string // "aftership", or empty to disable live tracking
+	APIKey   string
+	BaseURL  string
+	Timeout  time.Duration
+	CacheTTL time.Duration // How long a carrier lookup is reused
+}
+
 // AppConfig contains application-level configuration
 type AppConfig struct {
 	Name        string
@@ -61,6 +90,17 @@ type AppConfig struct {
 	CompanyEmail   string
 	CompanyWebsite string
 	FrontendURL    string
+
+	// Store timezone (IANA name) used for day/week/month reporting boundaries
+	Timezone string
+
+	// State the store ships from. GST on deliveries to the same state is split into
+	// CGST and SGST, elsewhere it is IGST; empty treats every delivery as same-state.
+	StoreState string
+
+	// Response localization; the locale comes from the lang query param or Accept-Language
+	DefaultLocale    string
+	SupportedLocales []string
 }
 
 // ServerConfig contains HTTP server configuration
@@ -101,6 +141,8 @@ type JWTConfig struct {
 	AccessTokenExpiry    time.Duration
 	RefreshTokenExpiry   time.Duration
 	RefreshTokenRotation bool
+
+	ImpersonationTokenExpiry time.Duration // Lifetime of support impersonation tokens
 }
 
 // SecurityConfig contains security-related configuration
@@ -112,6 +154,52 @@ type SecurityConfig struct {
 	CORSAllowedMethods []string
 	CORSAllowedHeaders []string
 	TrustedProxies     []string
+
+	// Token-bucket rate limits: the bucket holds Burst requests and refills at
+	// PerMinute. RateLimitPerMinute/Burst apply per IP; signed-in users get their
+	// own, larger bucket. Auth and search routes add a stricter bucket on top.
+	// A PerMinute of 0 disables that bucket.
+	RateLimitUserPerMinute   int
+	RateLimitUserBurst       int
+	RateLimitAuthPerMinute   int
+	RateLimitAuthBurst       int
+	RateLimitSearchPerMinute int
+	RateLimitSearchBurst     int
+
+	// Key used to encrypt two-factor secrets at rest; falls back to the JWT secret
+	TwoFactorEncryptionKey string
+
+	// Brute-force protection: LoginMaxAttempts failures from one IP within
+	// LoginAttemptWindow lock the account for that IP for LoginLockoutDuration,
+	// doubling with each repeated lockout up to LoginMaxLockout. LoginAccountMaxAttempts
+	// failures from any IPs lock the account everywhere, so spreading guesses across
+	// addresses doesn't get around the limit. 0 attempts disables either.
+	LoginMaxAttempts        int
+	LoginAccountMaxAttempts int
+	LoginAttemptWindow      time.Duration
+	LoginLockoutDuration    time.Duration
+	LoginMaxLockout         time.Duration
+
+	// Whether tokens are accepted when revocation can't be checked because Redis is
+	// unavailable. Off by default, so a Redis outage rejects every token rather than
+	// letting revoked ones back in.
+	TokenRevocationFailOpen bool
+}
+
+// PasswordConfig contains the password policy enforced on registration, reset and change
+type PasswordConfig struct {
+	MinLength      int
+	MaxLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+
+	// Reject runs like "abc", "123" and characters repeated three or more times
+	RejectSequences bool
+
+	// Passwords containing any of these (case-insensitive) are rejected as too common
+	Blocklist []string
 }
 
 // EmailConfig contains email service configuration
@@ -153,6 +241,170 @@ type UploadConfig struct {
 	ImageMaxHeight    int
 	ThumbnailWidth    int
 	ThumbnailHeight   int
+	StripEXIF         bool // Remove Exif/XMP metadata (e.g. GPS location) from uploaded JPEGs
+
+	// Background processing of derived images (thumbnails, optimized copies)
+	AsyncProcessing     bool
+	ProcessingWorkers   int
+	ProcessingQueueSize int
+
+	// Asynchronous bulk uploads
+	BulkMaxFiles int
+	BulkWorkers  int
+	BulkJobTTL   time.Duration // How long job status is kept in Redis
+}
+
+// ProductConfig contains product catalog configuration
+type ProductConfig struct {
+	BulkUpdateMax int // Maximum products changed by one bulk update call
+
+	// Price floor: prices below cost plus MinMarginPercent are rejected or only
+	// warned about; "off" disables the check
+	PriceFloorMode   string // "reject", "warn" or "off"
+	MinMarginPercent float64
+
+	// "Frequently bought together": co-purchases need at least RelatedMinOrders shared
+	// orders to count; short lists are topped up with same-category products
+	RelatedMinOrders int
+	RelatedCacheTTL  time.Duration
+}
+
+// ReviewConfig contains product review configuration
+type ReviewConfig struct {
+	ReviewerNameFormat string // "full", "first_only", "first_initial", "anonymous"
+
+	// "Most helpful" ranking: "weighted" decays helpful votes with age and boosts
+	// verified purchases; "raw" sorts by helpful_count alone
+	HelpfulRanking  string
+	HelpfulHalfLife time.Duration // Age at which a review's helpful score halves
+	VerifiedWeight  float64       // Score multiplier for verified purchase reviews
+
+	// Accounts younger than this cannot post reviews (admins are exempt); 0 disables it
+	MinAccountAge time.Duration
+
+	// Publish verified purchase reviews without waiting for moderation
+	AutoApproveVerified bool
+
+	SummaryCacheTTL time.Duration // Redis lifetime of per-product rating summaries
+}
+
+// OrderConfig contains order placement configuration
+type OrderConfig struct {
+	MaxOrdersPerWindow int           // 0 disables the per-customer limit
+	LimitWindow        time.Duration // Rolling window for MaxOrdersPerWindow
+
+	// Cap on the combined discount as a percentage of the subtotal; 0 disables it
+	MaxDiscountPercent int
+
+	// Address quality rules; orders tripping them are held for verification
+	AddressVerificationEnabled bool
+	RequirePostalCode          bool
+	RequirePhone               bool
+	AllowPOBox                 bool
+
+	// Split orders mixing digital/physical or dropship/warehouse items into
+	// separately tracked fulfillment groups when they are confirmed
+	SplitFulfillment bool
+
+	// Reject marking an order shipped without a tracking number and carrier
+	RequireTrackingToShip bool
+
+	// Statuses customers are not emailed about, e.g. "processing" to stay quiet
+	// about internal steps
+	StatusEmailsDisabled []string
+
+	// Days after delivery a customer can request a return; 0 disables returns
+	ReturnWindowDays int
+
+	// Count shipping method lead times in business days, skipping weekends, when
+	// estimating delivery dates
+	DeliveryBusinessDays bool
+
+	// Scheduled order exports
+	ExportDir           string        // Directory generated export files are stored in
+	ExportCheckInterval time.Duration // How often due export schedules are checked; 0 disables the scheduler
+}
+
+// PaymentConfig contains payment method availability rules
+type PaymentConfig struct {
+	CODMinOrderAmount      int64  // In cents, 0 for no minimum
+	CODMaxOrderAmount      int64  // In cents, 0 for no maximum
+	PrepaidOnlyCategoryIDs []uint // Carts containing these categories cannot use COD
+
+	// Payment link resends allowed per order within the window
+	LinkResendLimit  int
+	LinkResendWindow time.Duration
+
+	// How long processed webhook event IDs are remembered for deduplication
+	WebhookEventTTL time.Duration
+
+	// Payment attempts allowed per order before it needs admin review; 0 for no limit
+	MaxAttemptsPerOrder int
+
+	// Processing payments older than StuckTimeout are treated as abandoned and failed
+	StuckTimeout       time.Duration
+	StuckSweepInterval time.Duration // How often stuck payments are swept; 0 disables the sweeper
+}
+
+// CompareConfig contains product comparison configuration
+type CompareConfig struct {
+	MaxItems   int
+	SessionTTL time.Duration // Lifetime of guest comparison lists
+	CacheTTL   time.Duration // Redis cache lifetime for persisted user lists
+}
+
+// RecentlyViewedConfig contains recently viewed products configuration
+type RecentlyViewedConfig struct {
+	MaxItems int           // Oldest entries are trimmed beyond this
+	TTL      time.Duration // Lifetime of an idle list
+}
+
+// CartConfig contains shopping cart configuration
+type CartConfig struct {
+	AutoRemoveUnavailable bool // Drop deleted or deactivated products from carts when they are loaded
+	MaxItemQuantity       int  // Most units of one item a cart line can hold; products may override it, 0 disables it
+}
+
+// CheckoutConfig contains optional checkout add-on configuration
+type CheckoutConfig struct {
+	// Shipping insurance, charged as a percentage of the order subtotal
+	InsuranceEnabled bool
+	InsurancePercent float64
+	InsuranceMinFee  int64 // In cents; floor for small orders
+
+	// Signature on delivery, charged as a flat fee
+	SignatureEnabled bool
+	SignatureFee     int64 // In cents
+
+	// Gift wrapping, charged as a flat fee; the gift message is printed on the packing slip
+	GiftWrapEnabled      bool
+	GiftWrapFee          int64 // In cents
+	GiftMessageMaxLength int   // In characters
+}
+
+// DownloadConfig contains digital product download link configuration
+type DownloadConfig struct {
+	BaseURL      string        // Public API URL that download links point at
+	LinkTTL      time.Duration // How long an emailed download link stays valid
+	MaxDownloads int           // Downloads allowed per link; 0 means unlimited
+}
+
+// BadgeConfig contains product badge rules
+type BadgeConfig struct {
+	NewDays          int           // Products created within this many days get "new"; 0 disables
+	SaleMinPercent   int           // Minimum discount off compare price for "sale"
+	BestsellerTopN   int           // Top N products by units sold get "bestseller"; 0 disables
+	BestsellerWindow time.Duration // Sales window used to rank bestsellers
+	BestsellerTTL    time.Duration // How long the bestseller ranking is cached
+}
+
+// InventoryConfig contains stock alert settings
+type InventoryConfig struct {
+	AlertEmails   []string      // Recipients of low-stock alerts; active admins when empty
+	AlertDebounce time.Duration // Minimum time between alerts for the same product
+
+	ReservationTTL           time.Duration // How long reserved stock is held for an unpaid order
+	ReservationSweepInterval time.Duration // How often expired reservations are released; 0 disables the sweeper
 }
 
 // LoggingConfig contains logging configuration
@@ -162,6 +414,13 @@ type LoggingConfig struct {
 	File   string
 }
 
+// MetricsConfig contains the Prometheus metrics endpoint configuration
+type MetricsConfig struct {
+	Enabled   bool
+	Path      string
+	Namespace string // Prefixed to every metric name, e.g. ecommerce_http_requests_total
+}
+
 // Load loads configuration from environment variables and .env file
 func Load() (*Config, error) {
 	// Load .env file if it exists
@@ -183,6 +442,11 @@ func Load() (*Config, error) {
 			CompanyEmail:   getEnv("COMPANY_EMAIL", "info@yourcompany.com"),
 			CompanyWebsite: getEnv("COMPANY_WEBSITE", "https://yourcompany.com"),
 			FrontendURL:    getEnv("FRONTEND_URL", "http://localhost:3000"),
+			Timezone:       getEnv("STORE_TIMEZONE", "UTC"),
+			StoreState:     getEnv("STORE_STATE", ""),
+
+			DefaultLocale:    getEnv("APP_DEFAULT_LOCALE", "en"),
+			SupportedLocales: getEnvAsSlice("APP_SUPPORTED_LOCALES", []string{"en", "es", "fr", "hi"}),
 		},
 		Server: ServerConfig{
 			Port:         getEnv("APP_PORT", "8080"),
@@ -215,6 +479,8 @@ func Load() (*Config, error) {
 			AccessTokenExpiry:    getEnvAsDuration("JWT_ACCESS_EXPIRE", 24*time.Hour),
 			RefreshTokenExpiry:   getEnvAsDuration("JWT_REFRESH_EXPIRE", 7*24*time.Hour),
 			RefreshTokenRotation: getEnvAsBool("JWT_REFRESH_ROTATION", true),
+
+			ImpersonationTokenExpiry: getEnvAsDuration("JWT_IMPERSONATION_EXPIRE", 15*time.Minute),
 		},
 		Security: SecurityConfig{
 			BcryptCost:         getEnvAsInt("BCRYPT_COST", 12),
@@ -224,6 +490,36 @@ func Load() (*Config, error) {
 			CORSAllowedMethods: getEnvAsSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
 			CORSAllowedHeaders: getEnvAsSlice("CORS_ALLOWED_HEADERS", []string{"Origin", "Content-Type", "Accept", "Authorization"}),
 			TrustedProxies:     getEnvAsSlice("TRUSTED_PROXIES", []string{}),
+
+			RateLimitUserPerMinute:   getEnvAsInt("RATE_LIMIT_USER_PER_MINUTE", 300),
+			RateLimitUserBurst:       getEnvAsInt("RATE_LIMIT_USER_BURST", 100),
+			RateLimitAuthPerMinute:   getEnvAsInt("RATE_LIMIT_AUTH_PER_MINUTE", 10),
+			RateLimitAuthBurst:       getEnvAsInt("RATE_LIMIT_AUTH_BURST", 5),
+			RateLimitSearchPerMinute: getEnvAsInt("RATE_LIMIT_SEARCH_PER_MINUTE", 60),
+			RateLimitSearchBurst:     getEnvAsInt("RATE_LIMIT_SEARCH_BURST", 20),
+
+			TwoFactorEncryptionKey: getEnv("TWO_FACTOR_ENCRYPTION_KEY", ""),
+
+			LoginMaxAttempts:        getEnvAsInt("LOGIN_MAX_ATTEMPTS", 5),
+			LoginAccountMaxAttempts: getEnvAsInt("LOGIN_ACCOUNT_MAX_ATTEMPTS", 20),
+			LoginAttemptWindow:      getEnvAsDuration("LOGIN_ATTEMPT_WINDOW", 15*time.Minute),
+			LoginLockoutDuration:    getEnvAsDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+			LoginMaxLockout:         getEnvAsDuration("LOGIN_MAX_LOCKOUT", 24*time.Hour),
+
+			TokenRevocationFailOpen: getEnvAsBool("TOKEN_REVOCATION_FAIL_OPEN", false),
+		},
+		Password: PasswordConfig{
+			MinLength:       getEnvAsInt("PASSWORD_MIN_LENGTH", 8),
+			MaxLength:       getEnvAsInt("PASSWORD_MAX_LENGTH", 128),
+			RequireUpper:    getEnvAsBool("PASSWORD_REQUIRE_UPPER", true),
+			RequireLower:    getEnvAsBool("PASSWORD_REQUIRE_LOWER", true),
+			RequireDigit:    getEnvAsBool("PASSWORD_REQUIRE_DIGIT", true),
+			RequireSpecial:  getEnvAsBool("PASSWORD_REQUIRE_SPECIAL", true),
+			RejectSequences: getEnvAsBool("PASSWORD_REJECT_SEQUENCES", true),
+			Blocklist: getEnvAsSlice("PASSWORD_BLOCKLIST", []string{
+				"password", "123456", "password123", "admin", "qwerty", "letmein",
+				"welcome", "monkey", "dragon", "password1", "123456789", "football",
+			}),
 		},
 		External: ExternalConfig{
 			Stripe: StripeConfig{
@@ -237,6 +533,10 @@ func Load() (*Config, error) {
 				KeySecret:     getEnv("RAZORPAY_KEY_SECRET", ""),
 				WebhookSecret: getEnv("RAZORPAY_WEBHOOK_SECRET", ""),
 				Environment:   getEnv("RAZORPAY_ENVIRONMENT", "test"),
+
+				MaxConcurrentCalls: getEnvAsInt("RAZORPAY_MAX_CONCURRENT_CALLS", 10),
+				MaxRetries:         getEnvAsInt("RAZORPAY_MAX_RETRIES", 3),
+				RetryBaseDelay:     getEnvAsDuration("RAZORPAY_RETRY_BASE_DELAY", 500*time.Millisecond),
 			},
 			Email: EmailConfig{
 				Provider:     getEnv("EMAIL_PROVIDER", "smtp"),
@@ -261,6 +561,13 @@ func Load() (*Config, error) {
 				S3SecretKey: getEnv("S3_SECRET_KEY", ""),
 				CDNBaseURL:  getEnv("CDN_BASE_URL", ""),
 			},
+			Tracking: TrackingConfig{
+				Provider: getEnv("TRACKING_PROVIDER", ""),
+				APIKey:   getEnv("TRACKING_API_KEY", ""),
+				BaseURL:  getEnv("TRACKING_BASE_URL", "https://api.aftership.com/v4"),
+				Timeout:  getEnvAsDuration("TRACKING_TIMEOUT", 10*time.Second),
+				CacheTTL: getEnvAsDuration("TRACKING_CACHE_TTL", 15*time.Minute),
+			},
 		},
 		Upload: UploadConfig{
 			MaxSize:           getEnvAsInt64("UPLOAD_MAX_SIZE", 10485760), // 10MB
@@ -269,12 +576,124 @@ func Load() (*Config, error) {
 			ImageMaxHeight:    getEnvAsInt("IMAGE_MAX_HEIGHT", 2048),
 			ThumbnailWidth:    getEnvAsInt("THUMBNAIL_WIDTH", 300),
 			ThumbnailHeight:   getEnvAsInt("THUMBNAIL_HEIGHT", 300),
+			StripEXIF:         getEnvAsBool("UPLOAD_STRIP_EXIF", true),
+
+			AsyncProcessing:     getEnvAsBool("UPLOAD_ASYNC_PROCESSING", true),
+			ProcessingWorkers:   getEnvAsInt("UPLOAD_PROCESSING_WORKERS", 2),
+			ProcessingQueueSize: getEnvAsInt("UPLOAD_PROCESSING_QUEUE_SIZE", 100),
+
+			BulkMaxFiles: getEnvAsInt("UPLOAD_BULK_MAX_FILES", 200),
+			BulkWorkers:  getEnvAsInt("UPLOAD_BULK_WORKERS", 4),
+			BulkJobTTL:   getEnvAsDuration("UPLOAD_BULK_JOB_TTL", 24*time.Hour),
+		},
+		Product: ProductConfig{
+			BulkUpdateMax: getEnvAsInt("PRODUCT_BULK_UPDATE_MAX", 500),
+
+			PriceFloorMode:   getEnv("PRODUCT_PRICE_FLOOR_MODE", "reject"),
+			MinMarginPercent: getEnvAsFloat("PRODUCT_MIN_MARGIN_PERCENT", 0),
+
+			RelatedMinOrders: getEnvAsInt("PRODUCT_RELATED_MIN_ORDERS", 2),
+			RelatedCacheTTL:  getEnvAsDuration("PRODUCT_RELATED_CACHE_TTL", 6*time.Hour),
+		},
+		Review: ReviewConfig{
+			ReviewerNameFormat: getEnv("REVIEW_NAME_FORMAT", "full"),
+			HelpfulRanking:     getEnv("REVIEW_HELPFUL_RANKING", "weighted"),
+			HelpfulHalfLife:    getEnvAsDuration("REVIEW_HELPFUL_HALF_LIFE", 90*24*time.Hour),
+			VerifiedWeight:     getEnvAsFloat("REVIEW_VERIFIED_WEIGHT", 1.5),
+			MinAccountAge:      getEnvAsDuration("REVIEW_MIN_ACCOUNT_AGE", 0),
+
+			AutoApproveVerified: getEnvAsBool("REVIEW_AUTO_APPROVE_VERIFIED", false),
+			SummaryCacheTTL:     getEnvAsDuration("REVIEW_SUMMARY_CACHE_TTL", 10*time.Minute),
+		},
+		Order: OrderConfig{
+			MaxOrdersPerWindow: getEnvAsInt("ORDER_RATE_LIMIT", 10),
+			LimitWindow:        getEnvAsDuration("ORDER_RATE_LIMIT_WINDOW", time.Hour),
+			MaxDiscountPercent: getEnvAsInt("ORDER_MAX_DISCOUNT_PERCENT", 0),
+
+			AddressVerificationEnabled: getEnvAsBool("ORDER_ADDRESS_VERIFICATION", true),
+			RequirePostalCode:          getEnvAsBool("ORDER_ADDRESS_REQUIRE_POSTAL_CODE", true),
+			RequirePhone:               getEnvAsBool("ORDER_ADDRESS_REQUIRE_PHONE", false),
+			AllowPOBox:                 getEnvAsBool("ORDER_ADDRESS_ALLOW_PO_BOX", true),
+
+			SplitFulfillment: getEnvAsBool("ORDER_SPLIT_FULFILLMENT", false),
+
+			RequireTrackingToShip: getEnvAsBool("ORDER_REQUIRE_TRACKING_TO_SHIP", true),
+
+			StatusEmailsDisabled: getEnvAsSlice("ORDER_STATUS_EMAILS_DISABLED", []string{}),
+			ReturnWindowDays:     getEnvAsInt("ORDER_RETURN_WINDOW_DAYS", 30),
+			DeliveryBusinessDays: getEnvAsBool("ORDER_DELIVERY_BUSINESS_DAYS", true),
+
+			ExportDir:           getEnv("ORDER_EXPORT_DIR", "./storage/exports"),
+			ExportCheckInterval: getEnvAsDuration("ORDER_EXPORT_CHECK_INTERVAL", 10*time.Minute),
+		},
+		Payment: PaymentConfig{
+			CODMinOrderAmount:      getEnvAsInt64("PAYMENT_COD_MIN_AMOUNT", 0),
+			CODMaxOrderAmount:      getEnvAsInt64("PAYMENT_COD_MAX_AMOUNT", 0),
+			PrepaidOnlyCategoryIDs: getEnvAsUintSlice("PAYMENT_PREPAID_ONLY_CATEGORIES", nil),
+
+			LinkResendLimit:  getEnvAsInt("PAYMENT_LINK_RESEND_LIMIT", 3),
+			LinkResendWindow: getEnvAsDuration("PAYMENT_LINK_RESEND_WINDOW", time.Hour),
+
+			WebhookEventTTL: getEnvAsDuration("PAYMENT_WEBHOOK_EVENT_TTL", 72*time.Hour),
+
+			MaxAttemptsPerOrder: getEnvAsInt("PAYMENT_MAX_ATTEMPTS_PER_ORDER", 5),
+
+			StuckTimeout:       getEnvAsDuration("PAYMENT_STUCK_TIMEOUT", 15*time.Minute),
+			StuckSweepInterval: getEnvAsDuration("PAYMENT_STUCK_SWEEP_INTERVAL", 5*time.Minute),
+		},
+		Compare: CompareConfig{
+			MaxItems:   getEnvAsInt("COMPARE_MAX_ITEMS", 4),
+			SessionTTL: getEnvAsDuration("COMPARE_SESSION_TTL", 7*24*time.Hour),
+			CacheTTL:   getEnvAsDuration("COMPARE_CACHE_TTL", time.Hour),
+		},
+		Recent: RecentlyViewedConfig{
+			MaxItems: getEnvAsInt("RECENTLY_VIEWED_MAX_ITEMS", 10),
+			TTL:      getEnvAsDuration("RECENTLY_VIEWED_TTL", 30*24*time.Hour),
+		},
+		Cart: CartConfig{
+			AutoRemoveUnavailable: getEnvAsBool("CART_AUTO_REMOVE_UNAVAILABLE", false),
+			MaxItemQuantity:       getEnvAsInt("CART_MAX_ITEM_QUANTITY", 99),
+		},
+		Checkout: CheckoutConfig{
+			InsuranceEnabled: getEnvAsBool("CHECKOUT_INSURANCE_ENABLED", true),
+			InsurancePercent: getEnvAsFloat("CHECKOUT_INSURANCE_PERCENT", 1.5),
+			InsuranceMinFee:  getEnvAsInt64("CHECKOUT_INSURANCE_MIN_FEE", 100),
+			SignatureEnabled: getEnvAsBool("CHECKOUT_SIGNATURE_ENABLED", true),
+			SignatureFee:     getEnvAsInt64("CHECKOUT_SIGNATURE_FEE", 300),
+
+			GiftWrapEnabled:      getEnvAsBool("CHECKOUT_GIFT_WRAP_ENABLED", true),
+			GiftWrapFee:          getEnvAsInt64("CHECKOUT_GIFT_WRAP_FEE", 500),
+			GiftMessageMaxLength: getEnvAsInt("CHECKOUT_GIFT_MESSAGE_MAX_LENGTH", 250),
+		},
+		Download: DownloadConfig{
+			BaseURL:      getEnv("DIGITAL_DOWNLOAD_BASE_URL", "http://localhost:8080/api/v1"),
+			LinkTTL:      getEnvAsDuration("DIGITAL_DOWNLOAD_LINK_TTL", 72*time.Hour),
+			MaxDownloads: getEnvAsInt("DIGITAL_DOWNLOAD_MAX", 5),
+		},
+		Badge: BadgeConfig{
+			NewDays:          getEnvAsInt("BADGE_NEW_DAYS", 30),
+			SaleMinPercent:   getEnvAsInt("BADGE_SALE_MIN_PERCENT", 1),
+			BestsellerTopN:   getEnvAsInt("BADGE_BESTSELLER_TOP_N", 10),
+			BestsellerWindow: getEnvAsDuration("BADGE_BESTSELLER_WINDOW", 30*24*time.Hour),
+			BestsellerTTL:    getEnvAsDuration("BADGE_BESTSELLER_CACHE_TTL", 15*time.Minute),
+		},
+		Inventory: InventoryConfig{
+			AlertEmails:   getEnvAsSlice("INVENTORY_ALERT_EMAILS", []string{}),
+			AlertDebounce: getEnvAsDuration("INVENTORY_ALERT_DEBOUNCE", 24*time.Hour),
+
+			ReservationTTL:           getEnvAsDuration("INVENTORY_RESERVATION_TTL", time.Hour),
+			ReservationSweepInterval: getEnvAsDuration("INVENTORY_RESERVATION_SWEEP_INTERVAL", 5*time.Minute),
 		},
 		Logging: LoggingConfig{
 			Level:  getEnv("LOG_LEVEL", "debug"),
 			Format: getEnv("LOG_FORMAT", "json"),
 			File:   getEnv("LOG_FILE", "logs/app.log"),
 		},
+		Metrics: MetricsConfig{
+			Enabled:   getEnvAsBool("METRICS_ENABLED", true),
+			Path:      getEnv("METRICS_PATH", "/metrics"),
+			Namespace: getEnv("METRICS_NAMESPACE", "ecommerce"),
+		},
 	}
 
 	// Validate configuration
@@ -313,6 +732,36 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("APP_PORT is required")
 	}
 
+	// Validate store timezone
+	if _, err := time.LoadLocation(c.App.Timezone); err != nil {
+		return fmt.Errorf("STORE_TIMEZONE %q is not a valid IANA timezone: %w", c.App.Timezone, err)
+	}
+
+	// Validate discount cap
+	if c.Order.MaxDiscountPercent < 0 || c.Order.MaxDiscountPercent > 100 {
+		return fmt.Errorf("ORDER_MAX_DISCOUNT_PERCENT must be between 0 and 100")
+	}
+
+	// Razorpay credentials are only optional in development, where the provider is disabled without them
+	if !c.IsDevelopment() && (c.External.Razorpay.KeyID == "" || c.External.Razorpay.KeySecret == "") {
+		return fmt.Errorf("RAZORPAY_KEY_ID and RAZORPAY_KEY_SECRET are required when APP_ENV is %q", c.App.Environment)
+	}
+
+	// Validate price floor
+	switch c.Product.PriceFloorMode {
+	case "reject", "warn", "off":
+	default:
+		return fmt.Errorf("PRODUCT_PRICE_FLOOR_MODE must be one of reject, warn or off")
+	}
+	if c.Product.MinMarginPercent < 0 {
+		return fmt.Errorf("PRODUCT_MIN_MARGIN_PERCENT cannot be negative")
+	}
+
+	// Validate order rate limit
+	if c.Order.MaxOrdersPerWindow > 0 && c.Order.LimitWindow <= 0 {
+		return fmt.Errorf("ORDER_RATE_LIMIT_WINDOW must be positive when ORDER_RATE_LIMIT is set")
+	}
+
 	return nil
 }
 
@@ -338,6 +787,18 @@ func (c *Config) GetDatabaseDSN() string {
 	)
 }
 
+// GetLocation returns the store timezone, falling back to UTC
+func (c *Config) GetLocation() *time.Location {
+	if c.App.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(c.App.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 // GetRedisAddr returns the Redis address
 func (c *Config) GetRedisAddr() string {
 	return fmt.Sprintf("%s:%s", c.Redis.Host, c.Redis.Port)
@@ -370,6 +831,15 @@ func getEnvAsInt64(key string, defaultValue int64) int64 {
 	return defaultValue
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvAsBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if boolValue, err := strconv.ParseBool(value); err == nil {
@@ -394,3 +864,18 @@ func getEnvAsSlice(key string, defaultValue []string) []string {
 	}
 	return defaultValue
 }
+
+func getEnvAsUintSlice(key string, defaultValue []uint) []uint {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []uint
+	for _, part := range strings.Split(value, ",") {
+		if id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32); err == nil {
+			result = append(result, uint(id))
+		}
+	}
+	return result
+}
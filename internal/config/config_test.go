@@ -0,0 +1,56 @@
+// internal/config/config_test.go
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// validConfig returns a configuration that passes Validate
+func validConfig(environment string) *Config {
+	c := &Config{}
+	c.App.Environment = environment
+	c.App.Timezone = "Asia/Kolkata"
+	c.JWT.Secret = strings.Repeat("s", 32)
+	c.Database.Host = "localhost"
+	c.Database.Name = "ecommerce"
+	c.Database.User = "ecommerce"
+	c.Redis.Host = "localhost"
+	c.Server.Port = "8080"
+	c.Product.PriceFloorMode = "warn"
+	c.External.Razorpay.KeyID = "rzp_test_key"
+	c.External.Razorpay.KeySecret = "rzp_test_secret"
+	return c
+}
+
+func TestValidateRazorpayCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		keyID       string
+		keySecret   string
+		wantErr     bool
+	}{
+		{"production with credentials", "production", "rzp_live_key", "secret", false},
+		{"production without credentials", "production", "", "", true},
+		{"production without a secret", "production", "rzp_live_key", "", true},
+		{"staging without credentials", "staging", "", "", true},
+		{"development without credentials", "development", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validConfig(tt.environment)
+			c.External.Razorpay.KeyID = tt.keyID
+			c.External.Razorpay.KeySecret = tt.keySecret
+
+			err := c.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "RAZORPAY_KEY_ID") {
+				t.Errorf("Validate() error = %v, want it to name the missing keys", err)
+			}
+		})
+	}
+}
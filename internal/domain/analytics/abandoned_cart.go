@@ -0,0 +1,183 @@
+// internal/domain/analytics/abandoned_cart.go
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/cart"
+)
+
+// AbandonedCartData represents a cart that was never converted to an order
+type AbandonedCartData struct {
+	CartType       string    `json:"cart_type"` // user, guest
+	UserID         *uint     `json:"user_id,omitempty"`
+	SessionID      string    `json:"session_id,omitempty"`
+	Email          string    `json:"email,omitempty"`
+	CustomerName   string    `json:"customer_name,omitempty"`
+	ItemCount      int64     `json:"item_count"`
+	TotalQuantity  int64     `json:"total_quantity"`
+	EstimatedValue int64     `json:"estimated_value"` // In cents
+	LastActivity   time.Time `json:"last_activity"`
+	ExpiresIn      int64     `json:"expires_in,omitempty"` // Seconds until a guest cart expires
+}
+
+// AbandonedCartSummary represents abandoned cart analytics
+type AbandonedCartSummary struct {
+	TotalCarts       int64               `json:"total_carts"`
+	UserCarts        int64               `json:"user_carts"`
+	GuestCarts       int64               `json:"guest_carts"`
+	TotalValue       int64               `json:"total_value"` // In cents
+	AbandonedCarts   []AbandonedCartData `json:"abandoned_carts"`
+	OlderThanSeconds int64               `json:"older_than_seconds"`
+}
+
+// GetAbandonedCarts returns user and guest carts idle for longer than olderThan
+// that have not been converted to an order since their last activity
+func (s *Service) GetAbandonedCarts(olderThan time.Duration) (*AbandonedCartSummary, error) {
+	cutoff := time.Now().Add(-olderThan)
+
+	userCarts, err := s.getAbandonedUserCarts(cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	guestCarts, err := s.getAbandonedGuestCarts(cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &AbandonedCartSummary{
+		UserCarts:        int64(len(userCarts)),
+		GuestCarts:       int64(len(guestCarts)),
+		AbandonedCarts:   append(userCarts, guestCarts...),
+		OlderThanSeconds: int64(olderThan.Seconds()),
+	}
+	summary.TotalCarts = summary.UserCarts + summary.GuestCarts
+
+	for _, c := range summary.AbandonedCarts {
+		summary.TotalValue += c.EstimatedValue
+	}
+
+	// Most valuable carts first for recovery outreach
+	sort.Slice(summary.AbandonedCarts, func(i, j int) bool {
+		return summary.AbandonedCarts[i].EstimatedValue > summary.AbandonedCarts[j].EstimatedValue
+	})
+
+	return summary, nil
+}
+
+// getAbandonedUserCarts finds stale database carts with no order placed after the last cart activity
+func (s *Service) getAbandonedUserCarts(cutoff time.Time) ([]AbandonedCartData, error) {
+	// last_activity is read back from the latest cart row rather than taken from
+	// MAX() so it keeps the column's type on drivers that type results by column
+	var carts []AbandonedCartData
+	err := s.db.Raw(`
+		SELECT DISTINCT
+			carts.user_id,
+			carts.email,
+			carts.customer_name,
+			carts.item_count,
+			carts.total_quantity,
+			carts.estimated_value,
+			latest.updated_at as last_activity
+		FROM (
+			SELECT
+				ci.user_id,
+				u.email,
+				CONCAT(u.first_name, ' ', u.last_name) as customer_name,
+				COUNT(*) as item_count,
+				COALESCE(SUM(ci.quantity), 0) as total_quantity,
+				COALESCE(SUM(ci.quantity * ci.price), 0) as estimated_value,
+				MAX(ci.updated_at) as updated_at
+			FROM cart_items ci
+			JOIN users u ON ci.user_id = u.id
+			WHERE ci.deleted_at IS NULL AND ci.user_id IS NOT NULL
+			GROUP BY ci.user_id, u.email, u.first_name, u.last_name
+		) carts
+		JOIN cart_items latest ON latest.user_id = carts.user_id
+			AND latest.deleted_at IS NULL AND latest.updated_at = carts.updated_at
+		WHERE carts.updated_at < ?
+			AND NOT EXISTS (
+				SELECT 1 FROM orders o
+				WHERE o.user_id = carts.user_id AND o.created_at >= carts.updated_at
+			)
+		ORDER BY carts.estimated_value DESC
+	`, cutoff).Scan(&carts).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get abandoned user carts: %w", err)
+	}
+
+	for i := range carts {
+		carts[i].CartType = "user"
+		carts[i].CustomerName = strings.TrimSpace(carts[i].CustomerName)
+	}
+
+	return carts, nil
+}
+
+// getAbandonedGuestCarts scans Redis session carts, skipping carts that have already expired
+func (s *Service) getAbandonedGuestCarts(cutoff time.Time) ([]AbandonedCartData, error) {
+	if s.redisClient == nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var carts []AbandonedCartData
+	iter := s.redisClient.Scan(ctx, 0, "cart:session:*", 100).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+
+		// TTL of -2 means the key expired between SCAN and now
+		ttl, err := s.redisClient.TTL(ctx, key).Result()
+		if err != nil || ttl == -2 {
+			continue
+		}
+
+		cartData, err := s.redisClient.Get(ctx, key).Result()
+		if err != nil {
+			continue
+		}
+
+		var sessionCart cart.SessionCart
+		if err := json.Unmarshal([]byte(cartData), &sessionCart); err != nil {
+			continue
+		}
+
+		if len(sessionCart.Items) == 0 || !sessionCart.UpdatedAt.Before(cutoff) {
+			continue
+		}
+		if !sessionCart.ExpiresAt.IsZero() && sessionCart.ExpiresAt.Before(time.Now()) {
+			continue
+		}
+
+		data := AbandonedCartData{
+			CartType:     "guest",
+			SessionID:    strings.TrimPrefix(key, "cart:session:"),
+			ItemCount:    int64(len(sessionCart.Items)),
+			LastActivity: sessionCart.UpdatedAt,
+		}
+		if ttl > 0 {
+			data.ExpiresIn = int64(ttl.Seconds())
+		}
+		for _, item := range sessionCart.Items {
+			data.TotalQuantity += int64(item.Quantity)
+			data.EstimatedValue += int64(item.Quantity) * item.Price
+		}
+
+		carts = append(carts, data)
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan guest carts: %w", err)
+	}
+
+	return carts, nil
+}
@@ -0,0 +1,68 @@
+// internal/domain/analytics/abandoned_cart_test.go
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/cart"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/user"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+func TestGetAbandonedCarts(t *testing.T) {
+	db := testutil.NewDB(t, &user.User{}, &cart.CartItem{}, &order.Order{})
+	redisClient, _ := testutil.NewRedis(t)
+	s := NewService(db, redisClient, &config.Config{})
+	ctx := context.Background()
+	stale := time.Now().Add(-3 * time.Hour)
+
+	// User carts: one idle for hours, one touched just now, and one idle whose
+	// owner has since ordered
+	idle := user.User{Email: "idle@example.com", Password: "hash", FirstName: "Idle", LastName: "Shopper"}
+	active := user.User{Email: "active@example.com", Password: "hash", FirstName: "Active", LastName: "Shopper"}
+	ordered := user.User{Email: "ordered@example.com", Password: "hash", FirstName: "Ordered", LastName: "Shopper"}
+	for _, u := range []*user.User{&idle, &active, &ordered} {
+		db.Create(u)
+	}
+	db.Create(&cart.CartItem{UserID: &idle.ID, ProductID: 1, Quantity: 2, Price: 1500, CreatedAt: stale, UpdatedAt: stale})
+	db.Create(&cart.CartItem{UserID: &active.ID, ProductID: 1, Quantity: 1, Price: 1500})
+	db.Create(&cart.CartItem{UserID: &ordered.ID, ProductID: 1, Quantity: 1, Price: 1500, CreatedAt: stale, UpdatedAt: stale})
+	db.Create(&order.Order{OrderNumber: "ORD-1", UserID: &ordered.ID, Email: ordered.Email, Status: order.OrderStatusPending,
+		PaymentStatus: order.PaymentStatusPending, CreatedAt: stale.Add(time.Hour)})
+
+	// Guest carts in Redis: one idle, one fresh
+	guestCart := func(sessionID string, updatedAt time.Time) {
+		data, _ := json.Marshal(cart.SessionCart{SessionID: sessionID, UpdatedAt: updatedAt, ExpiresAt: time.Now().Add(24 * time.Hour),
+			Items: []cart.SessionCartItem{{ProductID: 1, Quantity: 1, Price: 4000}}})
+		redisClient.Set(ctx, "cart:session:"+sessionID, data, 24*time.Hour)
+	}
+	guestCart("idle-guest", stale)
+	guestCart("fresh-guest", time.Now())
+
+	summary, err := s.GetAbandonedCarts(time.Hour)
+	if err != nil {
+		t.Fatalf("GetAbandonedCarts() error = %v", err)
+	}
+
+	if summary.UserCarts != 1 || summary.GuestCarts != 1 {
+		t.Fatalf("carts = %d user, %d guest, want 1 and 1: %+v", summary.UserCarts, summary.GuestCarts, summary.AbandonedCarts)
+	}
+	if summary.TotalValue != 7000 {
+		t.Errorf("TotalValue = %d, want 7000", summary.TotalValue)
+	}
+
+	// Most valuable first
+	guest, member := summary.AbandonedCarts[0], summary.AbandonedCarts[1]
+	if guest.CartType != "guest" || guest.SessionID != "idle-guest" || guest.EstimatedValue != 4000 || guest.ExpiresIn <= 0 {
+		t.Errorf("guest cart = %+v, want idle-guest worth 4000 with its expiry", guest)
+	}
+	if member.CartType != "user" || member.UserID == nil || *member.UserID != idle.ID || member.Email != idle.Email ||
+		member.CustomerName != "Idle Shopper" || member.TotalQuantity != 2 || member.EstimatedValue != 3000 {
+		t.Errorf("user cart = %+v, want %s's cart of 2 worth 3000", member, idle.Email)
+	}
+}
@@ -0,0 +1,40 @@
+// internal/domain/analytics/entity.go
+package analytics
+
+import (
+	"time"
+)
+
+// RevenueTarget represents revenue goals effective from a given date
+type RevenueTarget struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	DailyTarget   int64     `gorm:"not null;default:0" json:"daily_target"`   // In cents
+	WeeklyTarget  int64     `gorm:"not null;default:0" json:"weekly_target"`  // In cents
+	MonthlyTarget int64     `gorm:"not null;default:0" json:"monthly_target"` // In cents
+	YearlyTarget  int64     `gorm:"not null;default:0" json:"yearly_target"`  // In cents
+	EffectiveFrom time.Time `gorm:"not null;index" json:"effective_from"`
+	Notes         string    `gorm:"size:500" json:"notes"`
+	CreatedBy     uint      `gorm:"index" json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name for RevenueTarget
+func (RevenueTarget) TableName() string {
+	return "revenue_targets"
+}
+
+// SearchQuery records a product search made by a customer or guest
+type SearchQuery struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Term        string    `gorm:"not null;size:255;index" json:"term"` // Normalized: lowercased, single-spaced
+	ResultCount int64     `gorm:"not null;default:0" json:"result_count"`
+	UserID      *uint     `gorm:"index" json:"user_id"`
+	SessionID   string    `gorm:"size:255" json:"session_id"`
+	CreatedAt   time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName overrides the table name for SearchQuery
+func (SearchQuery) TableName() string {
+	return "search_queries"
+}
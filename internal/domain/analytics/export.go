@@ -0,0 +1,350 @@
+// internal/domain/analytics/export.go
+package analytics
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReportExportRequest represents analytics report export parameters
+type ReportExportRequest struct {
+	Format   string `form:"format,default=csv"` // csv, xlsx
+	Days     int    `form:"days,default=30"`
+	DateFrom string `form:"date_from"` // YYYY-MM-DD, overrides days when set
+	DateTo   string `form:"date_to"`   // YYYY-MM-DD, inclusive
+}
+
+// reportTable is a single table of an exported report (a CSV section or an XLSX sheet)
+type reportTable struct {
+	Name    string
+	Headers []string
+	Rows    [][]string
+	Numeric map[int]bool // Column indexes written as numbers in XLSX
+}
+
+// ExportSalesReport exports sales analytics as CSV or XLSX
+func (s *Service) ExportSalesReport(req *ReportExportRequest) ([]byte, string, error) {
+	startDate, endDate, err := req.dateRange(s.now())
+	if err != nil {
+		return nil, "", err
+	}
+
+	sales, err := s.GetSalesAnalyticsForRange(startDate, endDate)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tables := []reportTable{
+		timeSeriesTable("Daily Revenue", sales.DailyRevenue),
+		productSalesTable("Top Products", sales.TopProducts),
+		statusTable("Sales By Status", sales.SalesByStatus),
+	}
+
+	return exportTables(tables, "sales_report", req.Format, s.now())
+}
+
+// ExportRevenueReport exports revenue analytics as CSV or XLSX
+func (s *Service) ExportRevenueReport(req *ReportExportRequest) ([]byte, string, error) {
+	startDate, endDate, err := req.dateRange(s.now())
+	if err != nil {
+		return nil, "", err
+	}
+
+	revenue, err := s.GetRevenueAnalyticsForRange(startDate, endDate)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tables := []reportTable{
+		timeSeriesTable("Revenue By Period", revenue.RevenueByPeriod),
+		productSalesTable("Top Products", revenue.RevenueByProduct),
+		categoryTable("Revenue By Category", revenue.RevenueByCategory),
+	}
+
+	return exportTables(tables, "revenue_report", req.Format, s.now())
+}
+
+// dateRange resolves the export period from explicit dates or the days window in now's timezone
+func (r *ReportExportRequest) dateRange(now time.Time) (time.Time, time.Time, error) {
+	if r.DateFrom == "" && r.DateTo == "" {
+		days := r.Days
+		if days <= 0 {
+			days = 30
+		}
+		if days > 365 {
+			days = 365
+		}
+		return now.AddDate(0, 0, -days), now, nil
+	}
+
+	endDate := now
+	if r.DateTo != "" {
+		dateTo, err := time.ParseInLocation("2006-01-02", r.DateTo, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid date_to, expected YYYY-MM-DD")
+		}
+		endDate = dateTo.AddDate(0, 0, 1)
+	}
+
+	startDate := endDate.AddDate(0, 0, -30)
+	if r.DateFrom != "" {
+		dateFrom, err := time.ParseInLocation("2006-01-02", r.DateFrom, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid date_from, expected YYYY-MM-DD")
+		}
+		startDate = dateFrom
+	}
+
+	if !startDate.Before(endDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("date_from must be before date_to")
+	}
+	if endDate.Sub(startDate) > 366*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range cannot exceed one year")
+	}
+
+	return startDate, endDate, nil
+}
+
+// exportTables renders report tables in the requested format
+func exportTables(tables []reportTable, baseName, format string, now time.Time) ([]byte, string, error) {
+	timestamp := now.Format("2006-01-02_15-04-05")
+
+	switch format {
+	case "", "csv":
+		data, err := generateCSVReport(tables)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, fmt.Sprintf("%s_%s.csv", baseName, timestamp), nil
+	case "xlsx":
+		data, err := generateXLSXReport(tables)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, fmt.Sprintf("%s_%s.xlsx", baseName, timestamp), nil
+	default:
+		return nil, "", fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+func timeSeriesTable(name string, data []TimeSeriesData) reportTable {
+	table := reportTable{
+		Name:    name,
+		Headers: []string{"Date", "Revenue", "Orders"},
+		Numeric: map[int]bool{1: true, 2: true},
+	}
+	for _, item := range data {
+		table.Rows = append(table.Rows, []string{
+			formatReportDate(item.Date),
+			majorUnits(item.Value),
+			strconv.FormatInt(item.Count, 10),
+		})
+	}
+	return table
+}
+
+func productSalesTable(name string, data []ProductSalesData) reportTable {
+	table := reportTable{
+		Name:    name,
+		Headers: []string{"Product ID", "Product Name", "SKU", "Units Sold", "Revenue", "Orders"},
+		Numeric: map[int]bool{0: true, 3: true, 4: true, 5: true},
+	}
+	for _, item := range data {
+		table.Rows = append(table.Rows, []string{
+			strconv.FormatUint(uint64(item.ProductID), 10),
+			item.ProductName,
+			item.SKU,
+			strconv.FormatInt(item.TotalSold, 10),
+			majorUnits(item.Revenue),
+			strconv.FormatInt(item.OrderCount, 10),
+		})
+	}
+	return table
+}
+
+func statusTable(name string, data []StatusData) reportTable {
+	table := reportTable{
+		Name:    name,
+		Headers: []string{"Status", "Orders", "Value"},
+		Numeric: map[int]bool{1: true, 2: true},
+	}
+	for _, item := range data {
+		table.Rows = append(table.Rows, []string{
+			item.Status,
+			strconv.FormatInt(item.Count, 10),
+			majorUnits(item.Value),
+		})
+	}
+	return table
+}
+
+func categoryTable(name string, data []CategoryData) reportTable {
+	table := reportTable{
+		Name:    name,
+		Headers: []string{"Category ID", "Category Name", "Revenue", "Orders", "Products"},
+		Numeric: map[int]bool{0: true, 2: true, 3: true, 4: true},
+	}
+	for _, item := range data {
+		table.Rows = append(table.Rows, []string{
+			strconv.FormatUint(uint64(item.CategoryID), 10),
+			item.CategoryName,
+			majorUnits(item.Revenue),
+			strconv.FormatInt(item.OrderCount, 10),
+			strconv.FormatInt(item.ProductCount, 10),
+		})
+	}
+	return table
+}
+
+// majorUnits converts cents to a major-unit amount string
+func majorUnits(cents int64) string {
+	return strconv.FormatFloat(float64(cents)/100, 'f', 2, 64)
+}
+
+// formatReportDate trims timestamps returned for DATE() columns down to the day
+func formatReportDate(date string) string {
+	if len(date) >= 10 {
+		return date[:10]
+	}
+	return date
+}
+
+// generateCSVReport writes each table as a titled section separated by a blank line
+func generateCSVReport(tables []reportTable) ([]byte, error) {
+	var csvData strings.Builder
+	writer := csv.NewWriter(&csvData)
+
+	for i, table := range tables {
+		if i > 0 {
+			writer.Write([]string{})
+		}
+		records := append([][]string{{table.Name}, table.Headers}, table.Rows...)
+		for _, record := range records {
+			if err := writer.Write(record); err != nil {
+				return nil, fmt.Errorf("failed to write CSV record: %w", err)
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return []byte(csvData.String()), nil
+}
+
+// generateXLSXReport builds a minimal Office Open XML workbook with one sheet per table
+func generateXLSXReport(tables []reportTable) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	var contentTypes, workbookSheets, workbookRels strings.Builder
+	for i, table := range tables {
+		sheetID := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, sheetID)
+		fmt.Fprintf(&workbookSheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(sheetName(table.Name)), sheetID, sheetID)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, sheetID, sheetID)
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			contentTypes.String() + `</Types>`},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + workbookSheets.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			workbookRels.String() + `</Relationships>`},
+	}
+
+	for i, table := range tables {
+		files = append(files, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheetXML(table)})
+	}
+
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create XLSX part %s: %w", file.name, err)
+		}
+		if _, err := w.Write([]byte(file.content)); err != nil {
+			return nil, fmt.Errorf("failed to write XLSX part %s: %w", file.name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write XLSX: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// worksheetXML renders a table as sheet XML using inline strings
+func worksheetXML(table reportTable) string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	writeRow := func(rowNum int, values []string, numeric map[int]bool) {
+		fmt.Fprintf(&sb, `<row r="%d">`, rowNum)
+		for col, value := range values {
+			ref := columnName(col) + strconv.Itoa(rowNum)
+			if numeric[col] {
+				if _, err := strconv.ParseFloat(value, 64); err == nil {
+					fmt.Fprintf(&sb, `<c r="%s"><v>%s</v></c>`, ref, value)
+					continue
+				}
+			}
+			fmt.Fprintf(&sb, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, xmlEscape(value))
+		}
+		sb.WriteString(`</row>`)
+	}
+
+	writeRow(1, table.Headers, nil)
+	for i, row := range table.Rows {
+		writeRow(i+2, row, table.Numeric)
+	}
+
+	sb.WriteString(`</sheetData></worksheet>`)
+	return sb.String()
+}
+
+// columnName converts a zero-based column index to a spreadsheet column name (A, B, ..., AA)
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// sheetName truncates sheet names to the 31 character limit
+func sheetName(name string) string {
+	if len(name) > 31 {
+		return name[:31]
+	}
+	return name
+}
+
+func xmlEscape(value string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(value))
+	return sb.String()
+}
@@ -0,0 +1,76 @@
+// internal/domain/analytics/export_test.go
+package analytics
+
+import (
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateCSVReport(t *testing.T) {
+	tables := []reportTable{
+		timeSeriesTable("Daily Revenue", []TimeSeriesData{
+			{Date: "2026-10-01T00:00:00Z", Value: 123456, Count: 3},
+		}),
+		productSalesTable("Top Products", []ProductSalesData{
+			{ProductID: 7, ProductName: `Mug, "Large"`, SKU: "MUG-L", TotalSold: 2, Revenue: 1999, OrderCount: 1},
+			{ProductID: 8, ProductName: "Two\nLines", SKU: "TL-1", TotalSold: 1, Revenue: 50, OrderCount: 1},
+		}),
+	}
+
+	data, err := generateCSVReport(tables)
+	if err != nil {
+		t.Fatalf("generateCSVReport() error = %v", err)
+	}
+
+	// Commas, quotes and newlines in names are quoted rather than breaking columns
+	if !strings.Contains(string(data), `"Mug, ""Large"""`) {
+		t.Errorf("product name not quoted:\n%s", data)
+	}
+
+	reader := csv.NewReader(strings.NewReader(string(data)))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("report does not parse as CSV: %v\n%s", err, data)
+	}
+
+	// The blank line between sections is skipped by the reader
+	want := [][]string{
+		{"Daily Revenue"},
+		{"Date", "Revenue", "Orders"},
+		{"2026-10-01", "1234.56", "3"},
+		{"Top Products"},
+		{"Product ID", "Product Name", "SKU", "Units Sold", "Revenue", "Orders"},
+		{"7", `Mug, "Large"`, "MUG-L", "2", "19.99", "1"},
+		{"8", "Two\nLines", "TL-1", "1", "0.50", "1"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %q\nwant %q", records, want)
+	}
+}
+
+func TestExportTablesFormats(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 9, 30, 0, 0, time.UTC)
+	tables := []reportTable{statusTable("Orders by Status", nil)}
+
+	data, filename, err := exportTables(tables, "sales_report", "", now)
+	if err != nil {
+		t.Fatalf("exportTables() error = %v", err)
+	}
+	if filename != "sales_report_2026-10-16_09-30-00.csv" {
+		t.Errorf("filename = %q", filename)
+	}
+	if string(data) != "Orders by Status\nStatus,Orders,Value\n" {
+		t.Errorf("empty table = %q, want its title and header", data)
+	}
+
+	if _, filename, err := exportTables(tables, "sales_report", "xlsx", now); err != nil || !strings.HasSuffix(filename, ".xlsx") {
+		t.Errorf("xlsx export = %q, %v", filename, err)
+	}
+	if _, _, err := exportTables(tables, "sales_report", "pdf", now); err == nil {
+		t.Error("exportTables() accepted an unsupported format")
+	}
+}
@@ -0,0 +1,125 @@
+// internal/domain/analytics/peak_times.go
+package analytics
+
+import (
+	"fmt"
+	"time"
+)
+
+// weekdayLabels are indexed by ISO day of week minus one (Monday first)
+var weekdayLabels = [7]string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+// PeakTimesRequest represents peak shopping time query parameters
+type PeakTimesRequest struct {
+	Days     int    `form:"days,default=30"`
+	DateFrom string `form:"date_from"` // YYYY-MM-DD, overrides days when set
+	DateTo   string `form:"date_to"`   // YYYY-MM-DD, inclusive
+}
+
+// SalesTimeBucket represents order volume and revenue for one hour or weekday
+type SalesTimeBucket struct {
+	Bucket        int    `json:"bucket"` // Hour 0-23, or ISO weekday 1 (Monday) - 7 (Sunday)
+	Label         string `json:"label"`
+	OrderCount    int64  `json:"order_count"`
+	Revenue       int64  `json:"revenue"` // In cents
+	AvgOrderValue int64  `json:"avg_order_value"`
+}
+
+// PeakTimesAnalytics represents sales bucketed by hour of day and day of week
+// in the store timezone
+type PeakTimesAnalytics struct {
+	Timezone    string            `json:"timezone"`
+	StartDate   time.Time         `json:"start_date"`
+	EndDate     time.Time         `json:"end_date"`
+	TotalOrders int64             `json:"total_orders"`
+	ByHour      []SalesTimeBucket `json:"by_hour"`
+	ByDayOfWeek []SalesTimeBucket `json:"by_day_of_week"`
+	Heatmap     [7][24]int64      `json:"heatmap"` // Order counts, [weekday Monday first][hour]
+	PeakHour    *SalesTimeBucket  `json:"peak_hour,omitempty"`
+	PeakDay     *SalesTimeBucket  `json:"peak_day,omitempty"`
+}
+
+// GetPeakTimes aggregates orders in the requested range by local hour of day and day of week
+func (s *Service) GetPeakTimes(req *PeakTimesRequest) (*PeakTimesAnalytics, error) {
+	now := s.now()
+	period := ReportExportRequest{Days: req.Days, DateFrom: req.DateFrom, DateTo: req.DateTo}
+	startDate, endDate, err := period.dateRange(now)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PeakTimesAnalytics{
+		Timezone:    now.Location().String(),
+		StartDate:   startDate,
+		EndDate:     endDate,
+		ByHour:      make([]SalesTimeBucket, 24),
+		ByDayOfWeek: make([]SalesTimeBucket, 7),
+	}
+	for hour := range result.ByHour {
+		result.ByHour[hour] = SalesTimeBucket{Bucket: hour, Label: fmt.Sprintf("%02d:00", hour)}
+	}
+	for day := range result.ByDayOfWeek {
+		result.ByDayOfWeek[day] = SalesTimeBucket{Bucket: day + 1, Label: weekdayLabels[day]}
+	}
+
+	local := s.localTimestamp("created_at")
+	rows, err := s.db.Raw(fmt.Sprintf(`
+		SELECT
+			EXTRACT(ISODOW FROM %s)::int as day_of_week,
+			EXTRACT(HOUR FROM %s)::int as hour,
+			COUNT(*) as order_count,
+			COALESCE(SUM(total_amount), 0) as revenue
+		FROM orders
+		WHERE created_at >= ? AND created_at < ? AND status NOT IN ('cancelled', 'failed')
+		GROUP BY 1, 2
+	`, local, local), startDate, endDate).Rows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales by time: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dayOfWeek, hour int
+		var orderCount, revenue int64
+		if err := rows.Scan(&dayOfWeek, &hour, &orderCount, &revenue); err != nil {
+			return nil, fmt.Errorf("failed to scan sales by time: %w", err)
+		}
+		if dayOfWeek < 1 || dayOfWeek > 7 || hour < 0 || hour > 23 {
+			continue
+		}
+
+		result.ByHour[hour].OrderCount += orderCount
+		result.ByHour[hour].Revenue += revenue
+		result.ByDayOfWeek[dayOfWeek-1].OrderCount += orderCount
+		result.ByDayOfWeek[dayOfWeek-1].Revenue += revenue
+		result.Heatmap[dayOfWeek-1][hour] += orderCount
+		result.TotalOrders += orderCount
+	}
+
+	result.PeakHour = fillAverages(result.ByHour)
+	result.PeakDay = fillAverages(result.ByDayOfWeek)
+
+	return result, nil
+}
+
+// fillAverages computes per-bucket average order values and returns the bucket
+// with the most revenue, or nil when there were no orders
+func fillAverages(buckets []SalesTimeBucket) *SalesTimeBucket {
+	var peak *SalesTimeBucket
+	for i := range buckets {
+		bucket := &buckets[i]
+		if bucket.OrderCount == 0 {
+			continue
+		}
+		bucket.AvgOrderValue = bucket.Revenue / bucket.OrderCount
+		if peak == nil || bucket.Revenue > peak.Revenue {
+			peak = bucket
+		}
+	}
+
+	if peak == nil {
+		return nil
+	}
+	copied := *peak
+	return &copied
+}
@@ -0,0 +1,153 @@
+// internal/domain/analytics/search_terms.go
+package analytics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"gorm.io/gorm"
+)
+
+// maxSearchTermLength is the longest search term stored, in characters
+const maxSearchTermLength = 255
+
+// SearchLogService records product searches for search term analytics
+type SearchLogService struct {
+	db     *gorm.DB
+	config *config.Config
+}
+
+// NewSearchLogService creates a new search log service
+func NewSearchLogService(db *gorm.DB, cfg *config.Config) *SearchLogService {
+	return &SearchLogService{
+		db:     db,
+		config: cfg,
+	}
+}
+
+// LogSearch records a search term with the number of products it returned
+func (s *SearchLogService) LogSearch(term string, resultCount int64, userID *uint, sessionID string) error {
+	term = NormalizeSearchTerm(term)
+	if term == "" {
+		return nil
+	}
+
+	query := SearchQuery{
+		Term:        term,
+		ResultCount: resultCount,
+		UserID:      userID,
+		SessionID:   sessionID,
+	}
+	if err := s.db.Create(&query).Error; err != nil {
+		return fmt.Errorf("failed to log search: %w", err)
+	}
+	return nil
+}
+
+// NormalizeSearchTerm lowercases a term and collapses whitespace so variants of
+// the same search are counted together
+func NormalizeSearchTerm(term string) string {
+	term = strings.ToLower(strings.Join(strings.Fields(term), " "))
+	if runes := []rune(term); len(runes) > maxSearchTermLength {
+		term = string(runes[:maxSearchTermLength])
+	}
+	return term
+}
+
+// SearchTermsRequest represents search term report query parameters
+type SearchTermsRequest struct {
+	Days     int    `form:"days,default=30"`
+	DateFrom string `form:"date_from"` // YYYY-MM-DD, overrides days when set
+	DateTo   string `form:"date_to"`   // YYYY-MM-DD, inclusive
+	Limit    int    `form:"limit,default=20"`
+}
+
+// SearchTermStat represents how often a term was searched
+type SearchTermStat struct {
+	Term           string    `json:"term"`
+	Searches       int64     `json:"searches"`
+	UniqueSearches int64     `json:"unique_searches"` // Distinct users or guest sessions
+	AvgResults     float64   `json:"avg_results"`
+	LastSearchedAt time.Time `json:"last_searched_at"`
+}
+
+// SearchTermsReport represents the most searched terms in a date range
+type SearchTermsReport struct {
+	StartDate          time.Time        `json:"start_date"`
+	EndDate            time.Time        `json:"end_date"`
+	TotalSearches      int64            `json:"total_searches"`
+	ZeroResultSearches int64            `json:"zero_result_searches"`
+	ZeroResultRate     float64          `json:"zero_result_rate"` // Percentage of searches with no results
+	TopTerms           []SearchTermStat `json:"top_terms"`
+	TopZeroResultTerms []SearchTermStat `json:"top_zero_result_terms"`
+}
+
+// GetTopSearchTerms returns the most searched terms, and the most searched terms
+// that returned no products, in the requested range
+func (s *Service) GetTopSearchTerms(req *SearchTermsRequest) (*SearchTermsReport, error) {
+	period := ReportExportRequest{Days: req.Days, DateFrom: req.DateFrom, DateTo: req.DateTo}
+	startDate, endDate, err := period.dateRange(s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	report := &SearchTermsReport{
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+
+	inRange := func() *gorm.DB {
+		return s.db.Model(&SearchQuery{}).Where("created_at >= ? AND created_at < ?", startDate, endDate)
+	}
+
+	var totals struct {
+		Total      int64
+		ZeroResult int64
+	}
+	err = inRange().
+		Select("COUNT(*) AS total, COUNT(*) FILTER (WHERE result_count = 0) AS zero_result").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count searches: %w", err)
+	}
+	report.TotalSearches = totals.Total
+	report.ZeroResultSearches = totals.ZeroResult
+	if totals.Total > 0 {
+		report.ZeroResultRate = float64(totals.ZeroResult) / float64(totals.Total) * 100
+	}
+
+	if report.TopTerms, err = topSearchTerms(inRange(), limit); err != nil {
+		return nil, err
+	}
+	if report.TopZeroResultTerms, err = topSearchTerms(inRange().Where("result_count = 0"), limit); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// topSearchTerms groups the searches in query by term, most searched first
+func topSearchTerms(query *gorm.DB, limit int) ([]SearchTermStat, error) {
+	terms := []SearchTermStat{}
+	err := query.
+		Select(`term,
+			COUNT(*) AS searches,
+			COUNT(DISTINCT COALESCE(CAST(user_id AS TEXT), 'session:' || session_id)) AS unique_searches,
+			AVG(result_count) AS avg_results,
+			MAX(created_at) AS last_searched_at`).
+		Group("term").
+		Order("searches DESC, last_searched_at DESC").
+		Limit(limit).
+		Scan(&terms).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top search terms: %w", err)
+	}
+	return terms, nil
+}
@@ -3,26 +3,46 @@ package analytics
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"gorm.io/gorm"
 )
 
 // Service handles analytics business logic
 type Service struct {
-	db     *gorm.DB
-	config *config.Config
+	db          *gorm.DB
+	redisClient *redis.Client
+	config      *config.Config
 }
 
 // NewService creates a new analytics service
-func NewService(db *gorm.DB, cfg *config.Config) *Service {
+func NewService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *Service {
 	return &Service{
-		db:     db,
-		config: cfg,
+		db:          db,
+		redisClient: redisClient,
+		config:      cfg,
 	}
 }
 
+// now returns the current time in the store timezone
+func (s *Service) now() time.Time {
+	return time.Now().In(s.config.GetLocation())
+}
+
+// localDate returns a SQL expression truncating a timestamptz column to the store-local date
+func (s *Service) localDate(column string) string {
+	return fmt.Sprintf("DATE(%s)", s.localTimestamp(column))
+}
+
+// localTimestamp returns a SQL expression converting a timestamptz column to store-local wall time
+func (s *Service) localTimestamp(column string) string {
+	tz := strings.ReplaceAll(s.config.GetLocation().String(), "'", "''")
+	return fmt.Sprintf("(%s AT TIME ZONE '%s')", column, tz)
+}
+
 // DashboardStats represents overall dashboard statistics
 type DashboardStats struct {
 	// Sales metrics
@@ -186,7 +206,7 @@ type RevenueTargets struct {
 // GetDashboardStats retrieves overall dashboard statistics
 func (s *Service) GetDashboardStats() (*DashboardStats, error) {
 	stats := &DashboardStats{}
-	now := time.Now()
+	now := s.now()
 
 	// Define time periods
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
@@ -263,26 +283,31 @@ func (s *Service) GetDashboardStats() (*DashboardStats, error) {
 
 // GetSalesAnalytics retrieves sales analytics data
 func (s *Service) GetSalesAnalytics(days int) (*SalesAnalytics, error) {
-	analytics := &SalesAnalytics{}
-
 	// Default to 30 days if not specified
 	if days <= 0 {
 		days = 30
 	}
 
-	startDate := time.Now().AddDate(0, 0, -days)
+	now := s.now()
+	return s.GetSalesAnalyticsForRange(now.AddDate(0, 0, -days), now)
+}
+
+// GetSalesAnalyticsForRange retrieves sales analytics data for orders created in [startDate, endDate)
+func (s *Service) GetSalesAnalyticsForRange(startDate, endDate time.Time) (*SalesAnalytics, error) {
+	analytics := &SalesAnalytics{}
 
 	// Get daily revenue for the period
-	rows, err := s.db.Raw(`
+	dateExpr := s.localDate("created_at")
+	rows, err := s.db.Raw(fmt.Sprintf(`
 		SELECT 
-			DATE(created_at) as date,
+			%s as date,
 			COALESCE(SUM(total_amount), 0) as revenue,
 			COUNT(*) as order_count
 		FROM orders 
-		WHERE created_at >= ? AND status NOT IN ('cancelled', 'failed')
-		GROUP BY DATE(created_at)
+		WHERE created_at >= ? AND created_at < ? AND status NOT IN ('cancelled', 'failed')
+		GROUP BY %s
 		ORDER BY date
-	`, startDate).Rows()
+	`, dateExpr, dateExpr), startDate, endDate).Rows()
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get daily revenue: %w", err)
@@ -298,8 +323,8 @@ func (s *Service) GetSalesAnalytics(days int) (*SalesAnalytics, error) {
 	}
 
 	// Get summary metrics
-	s.db.Raw("SELECT COUNT(*) FROM orders WHERE created_at >= ? AND status NOT IN ('cancelled', 'failed')", startDate).Scan(&analytics.TotalSales)
-	s.db.Raw("SELECT COALESCE(SUM(total_amount), 0) FROM orders WHERE created_at >= ? AND status NOT IN ('cancelled', 'failed')", startDate).Scan(&analytics.TotalRevenue)
+	s.db.Raw("SELECT COUNT(*) FROM orders WHERE created_at >= ? AND created_at < ? AND status NOT IN ('cancelled', 'failed')", startDate, endDate).Scan(&analytics.TotalSales)
+	s.db.Raw("SELECT COALESCE(SUM(total_amount), 0) FROM orders WHERE created_at >= ? AND created_at < ? AND status NOT IN ('cancelled', 'failed')", startDate, endDate).Scan(&analytics.TotalRevenue)
 
 	if analytics.TotalSales > 0 {
 		analytics.AvgOrderValue = analytics.TotalRevenue / analytics.TotalSales
@@ -317,11 +342,11 @@ func (s *Service) GetSalesAnalytics(days int) (*SalesAnalytics, error) {
 		FROM products p
 		LEFT JOIN order_items oi ON p.id = oi.product_id
 		LEFT JOIN orders o ON oi.order_id = o.id
-		WHERE o.created_at >= ? AND o.status NOT IN ('cancelled', 'failed')
+		WHERE o.created_at >= ? AND o.created_at < ? AND o.status NOT IN ('cancelled', 'failed')
 		GROUP BY p.id, p.name, p.sku
 		ORDER BY revenue DESC
 		LIMIT 10
-	`, startDate).Rows()
+	`, startDate, endDate).Rows()
 
 	if err == nil {
 		defer productRows.Close()
@@ -341,10 +366,10 @@ func (s *Service) GetSalesAnalytics(days int) (*SalesAnalytics, error) {
 			COUNT(*) as count,
 			COALESCE(SUM(total_amount), 0) as value
 		FROM orders 
-		WHERE created_at >= ?
+		WHERE created_at >= ? AND created_at < ?
 		GROUP BY status
 		ORDER BY count DESC
-	`, startDate).Rows()
+	`, startDate, endDate).Rows()
 
 	if err == nil {
 		defer statusRows.Close()
@@ -386,7 +411,7 @@ func (s *Service) GetProductAnalytics() (*ProductAnalytics, error) {
 		GROUP BY p.id, p.name, p.sku
 		ORDER BY total_sold DESC
 		LIMIT 10
-	`, time.Now().AddDate(0, 0, -30)).Rows()
+	`, s.now().AddDate(0, 0, -30)).Rows()
 
 	if err == nil {
 		defer productRows.Close()
@@ -414,7 +439,7 @@ func (s *Service) GetProductAnalytics() (*ProductAnalytics, error) {
 		WHERE o.created_at >= ? AND o.status NOT IN ('cancelled', 'failed')
 		GROUP BY c.id, c.name
 		ORDER BY revenue DESC
-	`, time.Now().AddDate(0, 0, -30)).Rows()
+	`, s.now().AddDate(0, 0, -30)).Rows()
 
 	if err == nil {
 		defer categoryRows.Close()
@@ -464,7 +489,7 @@ func (s *Service) GetProductAnalytics() (*ProductAnalytics, error) {
 // GetCustomerAnalytics retrieves customer analytics data
 func (s *Service) GetCustomerAnalytics() (*CustomerAnalytics, error) {
 	analytics := &CustomerAnalytics{}
-	now := time.Now()
+	now := s.now()
 	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
 	lastMonth := thisMonth.AddDate(0, -1, 0)
 
@@ -549,15 +574,20 @@ func (s *Service) GetCustomerAnalytics() (*CustomerAnalytics, error) {
 
 // GetRevenueAnalytics retrieves revenue analytics data
 func (s *Service) GetRevenueAnalytics(days int) (*RevenueAnalytics, error) {
-	analytics := &RevenueAnalytics{}
-
 	// Default to 30 days if not specified
 	if days <= 0 {
 		days = 30
 	}
 
-	startDate := time.Now().AddDate(0, 0, -days)
-	thisMonth := time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.Now().Location())
+	now := s.now()
+	return s.GetRevenueAnalyticsForRange(now.AddDate(0, 0, -days), now)
+}
+
+// GetRevenueAnalyticsForRange retrieves revenue analytics data for orders created in [startDate, endDate)
+func (s *Service) GetRevenueAnalyticsForRange(startDate, endDate time.Time) (*RevenueAnalytics, error) {
+	analytics := &RevenueAnalytics{}
+	now := s.now()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
 	lastMonth := thisMonth.AddDate(0, -1, 0)
 
 	// Total revenue
@@ -573,15 +603,16 @@ func (s *Service) GetRevenueAnalytics(days int) (*RevenueAnalytics, error) {
 	}
 
 	// Revenue by period (daily for the specified period)
-	revenueRows, err := s.db.Raw(`
+	dateExpr := s.localDate("created_at")
+	revenueRows, err := s.db.Raw(fmt.Sprintf(`
 		SELECT 
-			DATE(created_at) as date,
+			%s as date,
 			COALESCE(SUM(total_amount), 0) as value
 		FROM orders 
-		WHERE created_at >= ? AND status NOT IN ('cancelled', 'failed')
-		GROUP BY DATE(created_at)
+		WHERE created_at >= ? AND created_at < ? AND status NOT IN ('cancelled', 'failed')
+		GROUP BY %s
 		ORDER BY date
-	`, startDate).Rows()
+	`, dateExpr, dateExpr), startDate, endDate).Rows()
 
 	if err == nil {
 		defer revenueRows.Close()
@@ -606,10 +637,10 @@ func (s *Service) GetRevenueAnalytics(days int) (*RevenueAnalytics, error) {
 		LEFT JOIN products p ON c.id = p.category_id
 		LEFT JOIN order_items oi ON p.id = oi.product_id
 		LEFT JOIN orders o ON oi.order_id = o.id
-		WHERE o.created_at >= ? AND o.status NOT IN ('cancelled', 'failed')
+		WHERE o.created_at >= ? AND o.created_at < ? AND o.status NOT IN ('cancelled', 'failed')
 		GROUP BY c.id, c.name
 		ORDER BY revenue DESC
-	`, startDate).Rows()
+	`, startDate, endDate).Rows()
 
 	if err == nil {
 		defer categoryRows.Close()
@@ -634,11 +665,11 @@ func (s *Service) GetRevenueAnalytics(days int) (*RevenueAnalytics, error) {
 		FROM products p
 		LEFT JOIN order_items oi ON p.id = oi.product_id
 		LEFT JOIN orders o ON oi.order_id = o.id
-		WHERE o.created_at >= ? AND o.status NOT IN ('cancelled', 'failed')
+		WHERE o.created_at >= ? AND o.created_at < ? AND o.status NOT IN ('cancelled', 'failed')
 		GROUP BY p.id, p.name, p.sku
 		ORDER BY revenue DESC
 		LIMIT 10
-	`, startDate).Rows()
+	`, startDate, endDate).Rows()
 
 	if err == nil {
 		defer productRows.Close()
@@ -653,20 +684,25 @@ func (s *Service) GetRevenueAnalytics(days int) (*RevenueAnalytics, error) {
 
 	// Average order value
 	var totalOrders int64
-	s.db.Raw("SELECT COUNT(*) FROM orders WHERE created_at >= ? AND status NOT IN ('cancelled', 'failed')", startDate).Scan(&totalOrders)
+	s.db.Raw("SELECT COUNT(*) FROM orders WHERE created_at >= ? AND created_at < ? AND status NOT IN ('cancelled', 'failed')", startDate, endDate).Scan(&totalOrders)
 	var periodRevenue int64
-	s.db.Raw("SELECT COALESCE(SUM(total_amount), 0) FROM orders WHERE created_at >= ? AND status NOT IN ('cancelled', 'failed')", startDate).Scan(&periodRevenue)
+	s.db.Raw("SELECT COALESCE(SUM(total_amount), 0) FROM orders WHERE created_at >= ? AND created_at < ? AND status NOT IN ('cancelled', 'failed')", startDate, endDate).Scan(&periodRevenue)
 
 	if totalOrders > 0 {
 		analytics.AvgOrderValue = periodRevenue / totalOrders
 	}
 
-	// Revenue targets (you can customize these based on your business goals)
+	// Revenue targets active at the end of the queried period
+	target, err := s.GetRevenueTargetAt(endDate)
+	if err != nil {
+		return nil, err
+	}
+
 	analytics.RevenueTargets = RevenueTargets{
-		DailyTarget:   100000,   // $1000 in cents
-		WeeklyTarget:  700000,   // $7000 in cents
-		MonthlyTarget: 3000000,  // $30000 in cents
-		YearlyTarget:  36000000, // $360000 in cents
+		DailyTarget:   target.DailyTarget,
+		WeeklyTarget:  target.WeeklyTarget,
+		MonthlyTarget: target.MonthlyTarget,
+		YearlyTarget:  target.YearlyTarget,
 	}
 
 	// Calculate achievement percentage (current month vs monthly target)
@@ -0,0 +1,96 @@
+// internal/domain/analytics/targets.go
+package analytics
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultRevenueTargets are used until an admin configures targets
+var defaultRevenueTargets = RevenueTarget{
+	DailyTarget:   100000,   // $1000 in cents
+	WeeklyTarget:  700000,   // $7000 in cents
+	MonthlyTarget: 3000000,  // $30000 in cents
+	YearlyTarget:  36000000, // $360000 in cents
+}
+
+// RevenueTargetRequest represents revenue target update data
+type RevenueTargetRequest struct {
+	DailyTarget   int64  `json:"daily_target" binding:"min=0"`
+	WeeklyTarget  int64  `json:"weekly_target" binding:"min=0"`
+	MonthlyTarget int64  `json:"monthly_target" binding:"min=0"`
+	YearlyTarget  int64  `json:"yearly_target" binding:"min=0"`
+	EffectiveFrom string `json:"effective_from"` // YYYY-MM-DD, defaults to today
+	Notes         string `json:"notes"`
+}
+
+// GetRevenueTargetAt returns the revenue targets active at the given time
+func (s *Service) GetRevenueTargetAt(at time.Time) (*RevenueTarget, error) {
+	var target RevenueTarget
+	err := s.db.Where("effective_from <= ?", at).
+		Order("effective_from DESC, id DESC").
+		First(&target).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			defaults := defaultRevenueTargets
+			return &defaults, nil
+		}
+		return nil, fmt.Errorf("failed to get revenue targets: %w", err)
+	}
+
+	return &target, nil
+}
+
+// GetRevenueTargetHistory returns all configured revenue targets, newest first
+func (s *Service) GetRevenueTargetHistory() ([]RevenueTarget, error) {
+	var targets []RevenueTarget
+	if err := s.db.Order("effective_from DESC, id DESC").Find(&targets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get revenue target history: %w", err)
+	}
+	return targets, nil
+}
+
+// SetRevenueTargets stores new revenue targets effective from the requested date.
+// Targets for an existing effective date are replaced; earlier targets are kept as history.
+func (s *Service) SetRevenueTargets(req *RevenueTargetRequest, adminID uint) (*RevenueTarget, error) {
+	now := s.now()
+	effectiveFrom := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if req.EffectiveFrom != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", req.EffectiveFrom, now.Location())
+		if err != nil {
+			return nil, fmt.Errorf("invalid effective_from, expected YYYY-MM-DD")
+		}
+		effectiveFrom = parsed
+	}
+
+	target := RevenueTarget{
+		DailyTarget:   req.DailyTarget,
+		WeeklyTarget:  req.WeeklyTarget,
+		MonthlyTarget: req.MonthlyTarget,
+		YearlyTarget:  req.YearlyTarget,
+		EffectiveFrom: effectiveFrom,
+		Notes:         req.Notes,
+		CreatedBy:     adminID,
+	}
+
+	var existing RevenueTarget
+	err := s.db.Where("effective_from = ?", effectiveFrom).First(&existing).Error
+	switch {
+	case err == nil:
+		target.ID = existing.ID
+		target.CreatedAt = existing.CreatedAt
+		if err := s.db.Save(&target).Error; err != nil {
+			return nil, fmt.Errorf("failed to update revenue targets: %w", err)
+		}
+	case err == gorm.ErrRecordNotFound:
+		if err := s.db.Create(&target).Error; err != nil {
+			return nil, fmt.Errorf("failed to create revenue targets: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("failed to check existing revenue targets: %w", err)
+	}
+
+	return &target, nil
+}
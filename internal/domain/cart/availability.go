@@ -0,0 +1,61 @@
+// internal/domain/cart/availability.go
+package cart
+
+import (
+	"log"
+)
+
+// Cart item availability statuses
+const (
+	ItemStatusAvailable   = "available"
+	ItemStatusUnavailable = "unavailable"
+)
+
+// Reasons a cart item is unavailable
+const (
+	UnavailableProductRemoved  = "product_removed"
+	UnavailableProductInactive = "product_inactive"
+	UnavailableVariantRemoved  = "variant_removed"
+	UnavailableVariantInactive = "variant_inactive"
+)
+
+// IsAvailable reports whether the item's product (and variant) can still be bought
+func (i *CartItemResponse) IsAvailable() bool {
+	return i.Status != ItemStatusUnavailable
+}
+
+// markUnavailable flags the item as unavailable for the given reason
+func (i *CartItemResponse) markUnavailable(reason string) {
+	i.Status = ItemStatusUnavailable
+	i.UnavailableReason = reason
+}
+
+// removeUnavailableItems deletes unavailable items from the stored cart and returns
+// the remaining items and the removed ones
+func (s *Service) removeUnavailableItems(userID *uint, sessionID string, items []CartItemResponse) ([]CartItemResponse, []CartItemResponse) {
+	kept := make([]CartItemResponse, 0, len(items))
+	var removed []CartItemResponse
+
+	for _, item := range items {
+		if item.IsAvailable() {
+			kept = append(kept, item)
+			continue
+		}
+
+		var err error
+		if userID != nil {
+			err = s.updateUserCartItem(*userID, item.ProductID, item.ProductVariantID, 0)
+		} else {
+			err = s.updateGuestCartItem(sessionID, item.ProductID, item.ProductVariantID, 0)
+		}
+		if err != nil {
+			// Leave it flagged in the cart rather than hide it
+			log.Printf("Failed to remove unavailable product %d from cart: %v", item.ProductID, err)
+			kept = append(kept, item)
+			continue
+		}
+		removed = append(removed, item)
+	}
+
+	return kept, removed
+}
@@ -0,0 +1,60 @@
+// internal/domain/cart/bulk.go
+package cart
+
+// BulkAddToCartRequest adds several items in one call, e.g. "add all" from a wishlist
+type BulkAddToCartRequest struct {
+	Items []AddToCartRequest `json:"items" binding:"required,min=1,max=50,dive"`
+}
+
+// BulkAddItemResult is the outcome of adding one item of a bulk request
+type BulkAddItemResult struct {
+	ProductID        uint   `json:"product_id"`
+	ProductVariantID *uint  `json:"product_variant_id,omitempty"`
+	Quantity         int    `json:"quantity"`
+	Success          bool   `json:"success"`
+	Error            string `json:"error,omitempty"`
+}
+
+// BulkAddToCartResponse reports each item's outcome along with the final cart
+type BulkAddToCartResponse struct {
+	Results []BulkAddItemResult `json:"results"`
+	Added   int                 `json:"added"`
+	Failed  int                 `json:"failed"`
+	Cart    *CartResponse       `json:"cart"`
+}
+
+// BulkAddToCart adds each item with the same checks as AddToCart. An item that
+// fails (out of stock, inactive, over the quantity limit) is reported and skipped
+// without affecting the others.
+func (s *Service) BulkAddToCart(userID *uint, sessionID string, req *BulkAddToCartRequest) (*BulkAddToCartResponse, error) {
+	response := &BulkAddToCartResponse{
+		Results: make([]BulkAddItemResult, len(req.Items)),
+	}
+
+	for i := range req.Items {
+		item := &req.Items[i]
+		result := BulkAddItemResult{
+			ProductID:        item.ProductID,
+			ProductVariantID: item.ProductVariantID,
+			Quantity:         item.Quantity,
+			Success:          true,
+		}
+
+		if err := s.addItem(userID, sessionID, item); err != nil {
+			result.Success = false
+			result.Error = err.Error()
+			response.Failed++
+		} else {
+			response.Added++
+		}
+		response.Results[i] = result
+	}
+
+	cartResponse, err := s.GetCart(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	response.Cart = cartResponse
+
+	return response, nil
+}
@@ -0,0 +1,64 @@
+// internal/domain/cart/bulk_test.go
+package cart
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"github.com/your-org/ecommerce-backend/internal/domain/user"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+func TestBulkAddToCartSkipsOutOfStockItem(t *testing.T) {
+	db := testutil.NewDB(t, &product.Category{}, &product.Brand{}, &product.Product{}, &product.ProductVariant{},
+		&user.Address{}, &CartItem{})
+	redisClient, _ := testutil.NewRedis(t)
+	s := NewService(db, redisClient, &config.Config{})
+
+	category := product.Category{Name: "Lighting", Slug: "lighting"}
+	db.Create(&category)
+	newProduct := func(sku string, price int64, quantity int) *product.Product {
+		p := &product.Product{SKU: sku, Name: sku, Slug: strings.ToLower(sku), Price: price, CategoryID: category.ID,
+			IsActive: true, TrackQuantity: true, Quantity: quantity}
+		if err := db.Create(p).Error; err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	lamp := newProduct("LAMP-1", 2500, 10)
+	soldOut := newProduct("SHADE-1", 900, 0)
+	bulb := newProduct("BULB-1", 300, 10)
+
+	userID := uint(7)
+	response, err := s.BulkAddToCart(&userID, "", &BulkAddToCartRequest{Items: []AddToCartRequest{
+		{ProductID: lamp.ID, Quantity: 1},
+		{ProductID: soldOut.ID, Quantity: 1},
+		{ProductID: bulb.ID, Quantity: 4},
+	}})
+	if err != nil {
+		t.Fatalf("BulkAddToCart() error = %v", err)
+	}
+
+	if response.Added != 2 || response.Failed != 1 {
+		t.Errorf("added %d, failed %d, want 2 and 1", response.Added, response.Failed)
+	}
+	for i, want := range []bool{true, false, true} {
+		if result := response.Results[i]; result.Success != want {
+			t.Errorf("result %d = %+v, want success %v", i, result, want)
+		}
+	}
+	if failed := response.Results[1]; failed.ProductID != soldOut.ID || !strings.Contains(failed.Error, "insufficient inventory") {
+		t.Errorf("failed result = %+v, want product %d out of stock", failed, soldOut.ID)
+	}
+
+	if len(response.Cart.Items) != 2 || response.Cart.Totals.SubTotal != 3700 {
+		t.Errorf("cart = %d items for %d, want 2 for 3700", len(response.Cart.Items), response.Cart.Totals.SubTotal)
+	}
+	var stored int64
+	db.Model(&CartItem{}).Where("user_id = ?", userID).Count(&stored)
+	if stored != 2 {
+		t.Errorf("stored cart items = %d, want 2", stored)
+	}
+}
@@ -25,6 +25,23 @@ func (CartItem) TableName() string {
 	return "cart_items"
 }
 
+// SavedItem represents a cart line set aside for later by an authenticated user
+type SavedItem struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"not null;index" json:"user_id"`
+	ProductID        uint      `gorm:"not null;index" json:"product_id"`
+	ProductVariantID *uint     `gorm:"index" json:"product_variant_id"`
+	Quantity         int       `gorm:"not null;default:1" json:"quantity"`
+	Price            int64     `gorm:"not null" json:"price"` // Price when it was in the cart
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (SavedItem) TableName() string {
+	return "saved_items"
+}
+
 // SessionCart represents a cart session for guest users (stored in Redis)
 type SessionCart struct {
 	SessionID string            `json:"session_id"`
@@ -52,4 +69,9 @@ type CartTotals struct {
 	ShippingCost   int64 `json:"shipping_cost"`
 	DiscountAmount int64 `json:"discount_amount"`
 	TotalAmount    int64 `json:"total_amount"` // Final total
+
+	// Tax, shipping and discount are estimates; checkout prices the final order
+	IsEstimate         bool   `json:"is_estimate"`
+	TaxAddressRequired bool   `json:"tax_address_required"` // No address is known, so tax is left out
+	CouponCode         string `json:"coupon_code,omitempty"`
 }
@@ -0,0 +1,42 @@
+// internal/domain/cart/quantity_limit.go
+package cart
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+)
+
+// ErrMaxQuantityExceeded is returned when a cart line would hold more units than allowed
+var ErrMaxQuantityExceeded = errors.New("maximum quantity per item exceeded")
+
+// maxItemQuantity returns how many units of a product one cart line may hold; the
+// product's own limit wins over the store-wide one. 0 means unlimited.
+func (s *Service) maxItemQuantity(prod *product.Product) int {
+	if prod.MaxCartQuantity > 0 {
+		return prod.MaxCartQuantity
+	}
+	return s.config.Cart.MaxItemQuantity
+}
+
+// checkMaxQuantity validates the resulting line quantity against the limit.
+// inCart is what the line already held, so the error can say how many more fit.
+func checkMaxQuantity(newQuantity, inCart, maxQuantity int) error {
+	if maxQuantity <= 0 || newQuantity <= maxQuantity {
+		return nil
+	}
+	if inCart > 0 {
+		return fmt.Errorf("%w: at most %d of this item allowed per order, you already have %d in your cart",
+			ErrMaxQuantityExceeded, maxQuantity, inCart)
+	}
+	return fmt.Errorf("%w: at most %d of this item allowed per order", ErrMaxQuantityExceeded, maxQuantity)
+}
+
+// clampToMaxQuantity caps a quantity at the limit, for merges that must not fail
+func clampToMaxQuantity(quantity, maxQuantity int) int {
+	if maxQuantity > 0 && quantity > maxQuantity {
+		return maxQuantity
+	}
+	return quantity
+}
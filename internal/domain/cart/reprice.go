@@ -0,0 +1,90 @@
+// internal/domain/cart/reprice.go
+package cart
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+)
+
+// PriceChange describes a cart item whose stored price no longer matches the catalog
+type PriceChange struct {
+	ProductID        uint   `json:"product_id"`
+	ProductVariantID *uint  `json:"product_variant_id,omitempty"`
+	ProductName      string `json:"product_name"`
+	OldPrice         int64  `json:"old_price"`
+	NewPrice         int64  `json:"new_price"`
+	Increased        bool   `json:"increased"`
+}
+
+// RepriceCart re-reads the current product and variant prices, updates the price
+// stored on each cart item and returns the items whose price changed. Unavailable
+// items are left alone; they cannot be checked out anyway.
+func (s *Service) RepriceCart(userID *uint, sessionID string) ([]PriceChange, error) {
+	cartResponse, err := s.GetCart(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []PriceChange
+	for _, item := range cartResponse.Items {
+		if item.Product == nil || !item.IsAvailable() {
+			continue
+		}
+
+		newPrice := currentPrice(item.Product, item.ProductVariant)
+		if newPrice == item.Price {
+			continue
+		}
+
+		changes = append(changes, PriceChange{
+			ProductID:        item.ProductID,
+			ProductVariantID: item.ProductVariantID,
+			ProductName:      item.Product.Name,
+			OldPrice:         item.Price,
+			NewPrice:         newPrice,
+			Increased:        newPrice > item.Price,
+		})
+	}
+
+	if len(changes) == 0 {
+		return changes, nil
+	}
+
+	if userID != nil {
+		for _, change := range changes {
+			err := whereVariant(s.db.Model(&CartItem{}).Where("user_id = ? AND product_id = ?", *userID, change.ProductID), change.ProductVariantID).
+				Update("price", change.NewPrice).Error
+			if err != nil {
+				return nil, fmt.Errorf("failed to update cart item price: %w", err)
+			}
+		}
+		return changes, nil
+	}
+
+	sessionCart, err := s.getGuestCart(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		if i := findSessionItem(sessionCart.Items, change.ProductID, change.ProductVariantID); i >= 0 {
+			sessionCart.Items[i].Price = change.NewPrice
+		}
+	}
+	sessionCart.UpdatedAt = time.Now().UTC()
+	if err := s.saveGuestCart(sessionID, sessionCart); err != nil {
+		return nil, fmt.Errorf("failed to update cart item price: %w", err)
+	}
+
+	return changes, nil
+}
+
+// currentPrice returns the catalog price of a product, or of its variant when the
+// variant has its own price
+func currentPrice(prod *product.Product, variant *product.ProductVariant) int64 {
+	if variant != nil && variant.Price > 0 {
+		return variant.Price
+	}
+	return prod.Price
+}
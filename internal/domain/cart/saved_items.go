@@ -0,0 +1,333 @@
+// internal/domain/cart/saved_items.go
+package cart
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// ErrItemNotInCart is returned when the line to save is not in the cart
+var ErrItemNotInCart = errors.New("item not found in cart")
+
+// ErrSavedItemNotFound is returned when the saved item does not exist
+var ErrSavedItemNotFound = errors.New("saved item not found")
+
+// SavedItemsResponse lists the items saved for later; they are not part of the cart totals
+type SavedItemsResponse struct {
+	Items []CartItemResponse `json:"items"`
+	Count int                `json:"count"`
+}
+
+// SaveForLater moves a cart line into the saved-for-later list. A line that is
+// already saved gets the cart quantity added to it.
+func (s *Service) SaveForLater(userID *uint, sessionID string, productID uint, variantID *uint) (*CartResponse, error) {
+	if userID != nil {
+		if err := s.saveUserItem(*userID, productID, variantID); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := s.saveGuestItem(sessionID, productID, variantID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetCart(userID, sessionID)
+}
+
+// GetSavedItems returns the items saved for later with their product details
+func (s *Service) GetSavedItems(userID *uint, sessionID string) (*SavedItemsResponse, error) {
+	var items []CartItemResponse
+
+	if userID != nil {
+		var saved []SavedItem
+		if err := s.db.Where("user_id = ?", *userID).Order("updated_at DESC").Find(&saved).Error; err != nil {
+			return nil, fmt.Errorf("failed to get saved items: %w", err)
+		}
+		items = make([]CartItemResponse, len(saved))
+		for i, item := range saved {
+			items[i] = CartItemResponse{
+				ProductID:        item.ProductID,
+				ProductVariantID: item.ProductVariantID,
+				Quantity:         item.Quantity,
+				Price:            item.Price,
+				AddedAt:          item.UpdatedAt,
+			}
+		}
+	} else {
+		saved, err := s.getGuestSavedItems(sessionID)
+		if err != nil {
+			return nil, err
+		}
+		items = make([]CartItemResponse, len(saved))
+		for i, item := range saved {
+			items[i] = CartItemResponse{
+				ProductID:        item.ProductID,
+				ProductVariantID: item.ProductVariantID,
+				Quantity:         item.Quantity,
+				Price:            item.Price,
+				AddedAt:          item.AddedAt,
+			}
+		}
+	}
+
+	if err := s.loadProductDetails(items); err != nil {
+		return nil, err
+	}
+
+	return &SavedItemsResponse{
+		Items: items,
+		Count: len(items),
+	}, nil
+}
+
+// MoveSavedToCart moves a saved item back into the cart. It goes through the same
+// checks as adding to the cart (product active, inventory, quantity limit), and the
+// item stays saved if any of them fail.
+func (s *Service) MoveSavedToCart(userID *uint, sessionID string, productID uint, variantID *uint) (*CartResponse, error) {
+	quantity, err := s.savedQuantity(userID, sessionID, productID, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	cartResponse, err := s.AddToCart(userID, sessionID, &AddToCartRequest{
+		ProductID:        productID,
+		ProductVariantID: variantID,
+		Quantity:         quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.removeSavedItem(userID, sessionID, productID, variantID); err != nil {
+		return nil, err
+	}
+
+	return cartResponse, nil
+}
+
+// RemoveSavedItem deletes an item from the saved-for-later list
+func (s *Service) RemoveSavedItem(userID *uint, sessionID string, productID uint, variantID *uint) error {
+	if _, err := s.savedQuantity(userID, sessionID, productID, variantID); err != nil {
+		return err
+	}
+	return s.removeSavedItem(userID, sessionID, productID, variantID)
+}
+
+// mergeGuestSavedItems moves a guest's saved items to the user's list on login
+func (s *Service) mergeGuestSavedItems(userID uint, sessionID string) error {
+	saved, err := s.getGuestSavedItems(sessionID)
+	if err != nil || len(saved) == 0 {
+		return nil // Nothing to merge
+	}
+
+	for _, item := range saved {
+		if err := s.addUserSavedItem(s.db, userID, item.ProductID, item.ProductVariantID, item.Quantity, item.Price); err != nil {
+			return err
+		}
+	}
+
+	return s.redisClient.Del(context.Background(), guestSavedKey(sessionID)).Err()
+}
+
+// saveUserItem moves a user's cart line into their saved list in one transaction
+func (s *Service) saveUserItem(userID, productID uint, variantID *uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var item CartItem
+		err := whereVariant(tx.Where("user_id = ? AND product_id = ?", userID, productID), variantID).
+			First(&item).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrItemNotInCart
+			}
+			return fmt.Errorf("failed to get cart item: %w", err)
+		}
+
+		if err := s.addUserSavedItem(tx, userID, productID, variantID, item.Quantity, item.Price); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&item).Error; err != nil {
+			return fmt.Errorf("failed to remove cart item: %w", err)
+		}
+		return nil
+	})
+}
+
+// addUserSavedItem adds a line to a user's saved list, merging with an existing one
+func (s *Service) addUserSavedItem(tx *gorm.DB, userID, productID uint, variantID *uint, quantity int, price int64) error {
+	var existing SavedItem
+	err := whereVariant(tx.Where("user_id = ? AND product_id = ?", userID, productID), variantID).
+		First(&existing).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get saved item: %w", err)
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		saved := SavedItem{
+			UserID:           userID,
+			ProductID:        productID,
+			ProductVariantID: variantID,
+			Quantity:         quantity,
+			Price:            price,
+		}
+		if err := tx.Create(&saved).Error; err != nil {
+			return fmt.Errorf("failed to save item: %w", err)
+		}
+		return nil
+	}
+
+	existing.Quantity += quantity
+	existing.Price = price
+	if err := tx.Save(&existing).Error; err != nil {
+		return fmt.Errorf("failed to save item: %w", err)
+	}
+	return nil
+}
+
+// saveGuestItem moves a guest cart line into the guest's saved list
+func (s *Service) saveGuestItem(sessionID string, productID uint, variantID *uint) error {
+	sessionCart, err := s.getGuestCart(sessionID)
+	if err != nil {
+		return err
+	}
+
+	index := findSessionItem(sessionCart.Items, productID, variantID)
+	if index < 0 {
+		return ErrItemNotInCart
+	}
+	item := sessionCart.Items[index]
+
+	saved, err := s.getGuestSavedItems(sessionID)
+	if err != nil {
+		return err
+	}
+	if i := findSessionItem(saved, productID, variantID); i >= 0 {
+		saved[i].Quantity += item.Quantity
+		saved[i].Price = item.Price
+		saved[i].AddedAt = time.Now().UTC()
+	} else {
+		item.AddedAt = time.Now().UTC()
+		saved = append(saved, item)
+	}
+
+	if err := s.saveGuestSavedItems(sessionID, saved); err != nil {
+		return err
+	}
+
+	sessionCart.Items = append(sessionCart.Items[:index], sessionCart.Items[index+1:]...)
+	sessionCart.UpdatedAt = time.Now().UTC()
+	return s.saveGuestCart(sessionID, sessionCart)
+}
+
+// savedQuantity returns the quantity of a saved item
+func (s *Service) savedQuantity(userID *uint, sessionID string, productID uint, variantID *uint) (int, error) {
+	if userID != nil {
+		var saved SavedItem
+		err := whereVariant(s.db.Where("user_id = ? AND product_id = ?", *userID, productID), variantID).
+			First(&saved).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, ErrSavedItemNotFound
+			}
+			return 0, fmt.Errorf("failed to get saved item: %w", err)
+		}
+		return saved.Quantity, nil
+	}
+
+	saved, err := s.getGuestSavedItems(sessionID)
+	if err != nil {
+		return 0, err
+	}
+	i := findSessionItem(saved, productID, variantID)
+	if i < 0 {
+		return 0, ErrSavedItemNotFound
+	}
+	return saved[i].Quantity, nil
+}
+
+// removeSavedItem deletes a saved item
+func (s *Service) removeSavedItem(userID *uint, sessionID string, productID uint, variantID *uint) error {
+	if userID != nil {
+		err := whereVariant(s.db.Where("user_id = ? AND product_id = ?", *userID, productID), variantID).
+			Delete(&SavedItem{}).Error
+		if err != nil {
+			return fmt.Errorf("failed to remove saved item: %w", err)
+		}
+		return nil
+	}
+
+	saved, err := s.getGuestSavedItems(sessionID)
+	if err != nil {
+		return err
+	}
+	if i := findSessionItem(saved, productID, variantID); i >= 0 {
+		saved = append(saved[:i], saved[i+1:]...)
+	}
+	return s.saveGuestSavedItems(sessionID, saved)
+}
+
+func (s *Service) getGuestSavedItems(sessionID string) ([]SessionCartItem, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("session ID required for guest cart")
+	}
+
+	data, err := s.redisClient.Get(context.Background(), guestSavedKey(sessionID)).Result()
+	if err == redis.Nil {
+		return []SessionCartItem{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var items []SessionCartItem
+	if err := json.Unmarshal([]byte(data), &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *Service) saveGuestSavedItems(sessionID string, items []SessionCartItem) error {
+	ctx := context.Background()
+	if len(items) == 0 {
+		return s.redisClient.Del(ctx, guestSavedKey(sessionID)).Err()
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	// Saved items live as long as the guest session, like the guest cart
+	return s.redisClient.Set(ctx, guestSavedKey(sessionID), data, 24*time.Hour).Err()
+}
+
+func guestSavedKey(sessionID string) string {
+	return fmt.Sprintf("cart:saved:%s", sessionID)
+}
+
+// whereVariant narrows a query to a variant, matching NULL when there is none
+func whereVariant(query *gorm.DB, variantID *uint) *gorm.DB {
+	if variantID == nil {
+		return query.Where("product_variant_id IS NULL")
+	}
+	return query.Where("product_variant_id = ?", *variantID)
+}
+
+// findSessionItem returns the index of a product/variant line, or -1
+func findSessionItem(items []SessionCartItem, productID uint, variantID *uint) int {
+	for i, item := range items {
+		if item.ProductID != productID {
+			continue
+		}
+		if (item.ProductVariantID == nil && variantID == nil) ||
+			(item.ProductVariantID != nil && variantID != nil && *item.ProductVariantID == *variantID) {
+			return i
+		}
+	}
+	return -1
+}
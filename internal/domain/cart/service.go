@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -29,6 +30,16 @@ func NewService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *Ser
 	}
 }
 
+// WithTx returns a cart service that reads and writes user carts within the given
+// transaction. Guest carts live in Redis and are not part of it.
+func (s *Service) WithTx(tx *gorm.DB) *Service {
+	return &Service{
+		db:          tx,
+		redisClient: s.redisClient,
+		config:      s.config,
+	}
+}
+
 // CartItemResponse represents a cart item with product details
 type CartItemResponse struct {
 	ProductID        uint                    `json:"product_id"`
@@ -38,6 +49,10 @@ type CartItemResponse struct {
 	Product          *product.Product        `json:"product,omitempty"`
 	ProductVariant   *product.ProductVariant `json:"product_variant,omitempty"`
 	AddedAt          time.Time               `json:"added_at"`
+
+	// Whether the product can still be bought; unavailable items are excluded from totals
+	Status            string `json:"status"`
+	UnavailableReason string `json:"unavailable_reason,omitempty"`
 }
 
 // CartResponse represents a shopping cart with items and summary
@@ -48,6 +63,9 @@ type CartResponse struct {
 	Totals    CartTotals         `json:"totals"`
 	CreatedAt time.Time          `json:"created_at"`
 	UpdatedAt time.Time          `json:"updated_at"`
+
+	HasUnavailableItems bool               `json:"has_unavailable_items"`
+	RemovedItems        []CartItemResponse `json:"removed_items,omitempty"` // Unavailable items removed automatically on this load
 }
 
 // AddToCartRequest represents add to cart request
@@ -122,26 +140,52 @@ func (s *Service) GetCart(userID *uint, sessionID string) (*CartResponse, error)
 		return nil, err
 	}
 
+	var removedItems []CartItemResponse
+	if s.config.Cart.AutoRemoveUnavailable {
+		cartItems, removedItems = s.removeUnavailableItems(userID, sessionID, cartItems)
+	}
+
+	hasUnavailable := false
+	for _, item := range cartItems {
+		if !item.IsAvailable() {
+			hasUnavailable = true
+			break
+		}
+	}
+
 	// Calculate totals
-	totals := s.calculateTotals(cartItems)
+	totals := s.calculateTotals(userID, cartItems)
 
 	return &CartResponse{
-		SessionID: sessionID,
-		UserID:    userID,
-		Items:     cartItems,
-		Totals:    totals,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+		SessionID:           sessionID,
+		UserID:              userID,
+		Items:               cartItems,
+		Totals:              totals,
+		CreatedAt:           createdAt,
+		UpdatedAt:           updatedAt,
+		HasUnavailableItems: hasUnavailable,
+		RemovedItems:        removedItems,
 	}, nil
 }
 
 // AddToCart adds an item to the cart
 func (s *Service) AddToCart(userID *uint, sessionID string, req *AddToCartRequest) (*CartResponse, error) {
+	if err := s.addItem(userID, sessionID, req); err != nil {
+		return nil, err
+	}
+
+	// Return updated cart
+	return s.GetCart(userID, sessionID)
+}
+
+// addItem validates an item (product, variant, inventory, quantity limit) and adds
+// it to the user or guest cart
+func (s *Service) addItem(userID *uint, sessionID string, req *AddToCartRequest) error {
 	// Validate product exists and is active
 	var prod product.Product
 	result := s.db.Where("id = ? AND is_active = ?", req.ProductID, true).First(&prod)
 	if result.Error != nil {
-		return nil, fmt.Errorf("product not found or inactive")
+		return fmt.Errorf("product not found or inactive")
 	}
 
 	// Validate variant if specified
@@ -151,43 +195,36 @@ func (s *Service) AddToCart(userID *uint, sessionID string, req *AddToCartReques
 		result := s.db.Where("id = ? AND product_id = ? AND is_active = ?",
 			*req.ProductVariantID, req.ProductID, true).First(&v)
 		if result.Error != nil {
-			return nil, fmt.Errorf("product variant not found or inactive")
+			return fmt.Errorf("product variant not found or inactive")
 		}
 		variant = &v
 	}
 
 	// Check inventory availability
-	availableQuantity := prod.Quantity
+	availableQuantity := prod.AvailableQuantity()
 	if variant != nil {
-		availableQuantity = variant.Quantity
+		availableQuantity = variant.AvailableQuantity()
 	}
 
 	if prod.TrackQuantity && availableQuantity < req.Quantity {
-		return nil, fmt.Errorf("insufficient inventory. Available: %d", availableQuantity)
+		return fmt.Errorf("insufficient inventory. Available: %d", availableQuantity)
 	}
 
-	// Determine price to use
-	itemPrice := prod.Price
-	if variant != nil && variant.Price > 0 {
-		itemPrice = variant.Price
+	maxQuantity := s.maxItemQuantity(&prod)
+	if err := checkMaxQuantity(req.Quantity, 0, maxQuantity); err != nil {
+		return err
 	}
 
+	// Determine price to use
+	itemPrice := currentPrice(&prod, variant)
+
 	if userID != nil {
 		// Handle user cart
-		err := s.addToUserCart(*userID, req.ProductID, req.ProductVariantID, req.Quantity, itemPrice, availableQuantity, prod.TrackQuantity)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		// Handle guest cart
-		err := s.addToGuestCart(sessionID, req.ProductID, req.ProductVariantID, req.Quantity, itemPrice, availableQuantity, prod.TrackQuantity)
-		if err != nil {
-			return nil, err
-		}
+		return s.addToUserCart(*userID, req.ProductID, req.ProductVariantID, req.Quantity, itemPrice, availableQuantity, prod.TrackQuantity, maxQuantity)
 	}
 
-	// Return updated cart
-	return s.GetCart(userID, sessionID)
+	// Handle guest cart
+	return s.addToGuestCart(sessionID, req.ProductID, req.ProductVariantID, req.Quantity, itemPrice, availableQuantity, prod.TrackQuantity, maxQuantity)
 }
 
 // UpdateCartItem updates quantity of a cart item
@@ -201,16 +238,20 @@ func (s *Service) UpdateCartItem(userID *uint, sessionID string, productID uint,
 		var prod product.Product
 		s.db.Where("id = ?", productID).First(&prod)
 
-		availableQuantity := prod.Quantity
+		availableQuantity := prod.AvailableQuantity()
 		if variantID != nil {
 			var variant product.ProductVariant
 			s.db.Where("id = ?", *variantID).First(&variant)
-			availableQuantity = variant.Quantity
+			availableQuantity = variant.AvailableQuantity()
 		}
 
 		if prod.TrackQuantity && availableQuantity < req.Quantity {
 			return nil, fmt.Errorf("insufficient inventory. Available: %d", availableQuantity)
 		}
+
+		if err := checkMaxQuantity(req.Quantity, 0, s.maxItemQuantity(&prod)); err != nil {
+			return nil, err
+		}
 	}
 
 	if userID != nil {
@@ -258,6 +299,9 @@ func (s *Service) GetCartItemCount(userID *uint, sessionID string) (int, error)
 
 	totalItems := 0
 	for _, item := range cartResponse.Items {
+		if !item.IsAvailable() {
+			continue
+		}
 		totalItems += item.Quantity
 	}
 
@@ -266,6 +310,11 @@ func (s *Service) GetCartItemCount(userID *uint, sessionID string) (int, error)
 
 // MergeGuestCartToUser merges guest cart to user cart when user logs in
 func (s *Service) MergeGuestCartToUser(userID uint, sessionID string) error {
+	// Saved-for-later items follow the guest to their account as well
+	if err := s.mergeGuestSavedItems(userID, sessionID); err != nil {
+		log.Printf("Failed to merge saved items for user %d: %v", userID, err)
+	}
+
 	// Get guest cart
 	guestCart, err := s.getGuestCart(sessionID)
 	if err != nil || len(guestCart.Items) == 0 {
@@ -286,19 +335,26 @@ func (s *Service) MergeGuestCartToUser(userID uint, sessionID string) error {
 
 		result := query.First(&existingItem)
 
+		// Merging must not fail the login, so quantities over the limit are capped
+		maxQuantity := s.config.Cart.MaxItemQuantity
+		var prod product.Product
+		if err := s.db.Unscoped().Select("id, max_cart_quantity").Where("id = ?", guestItem.ProductID).First(&prod).Error; err == nil {
+			maxQuantity = s.maxItemQuantity(&prod)
+		}
+
 		if result.Error == gorm.ErrRecordNotFound {
 			// Item doesn't exist, create new
 			newItem := CartItem{
 				UserID:           &userID,
 				ProductID:        guestItem.ProductID,
 				ProductVariantID: guestItem.ProductVariantID,
-				Quantity:         guestItem.Quantity,
+				Quantity:         clampToMaxQuantity(guestItem.Quantity, maxQuantity),
 				Price:            guestItem.Price,
 			}
 			s.db.Create(&newItem)
 		} else {
 			// Item exists, update quantity
-			existingItem.Quantity += guestItem.Quantity
+			existingItem.Quantity = clampToMaxQuantity(existingItem.Quantity+guestItem.Quantity, maxQuantity)
 			s.db.Save(&existingItem)
 		}
 	}
@@ -310,7 +366,7 @@ func (s *Service) MergeGuestCartToUser(userID uint, sessionID string) error {
 // Private helper methods
 
 // Fixed addToUserCart method with proper NULL handling
-func (s *Service) addToUserCart(userID, productID uint, variantID *uint, quantity int, price int64, availableQuantity int, trackQuantity bool) error {
+func (s *Service) addToUserCart(userID, productID uint, variantID *uint, quantity int, price int64, availableQuantity int, trackQuantity bool, maxQuantity int) error {
 	// Check if item already exists - handle NULL variant ID properly
 	var existingItem CartItem
 	query := s.db.Where("user_id = ? AND product_id = ?", userID, productID)
@@ -342,13 +398,17 @@ func (s *Service) addToUserCart(userID, productID uint, variantID *uint, quantit
 			return fmt.Errorf("insufficient inventory for total quantity. Available: %d", availableQuantity)
 		}
 
+		if err := checkMaxQuantity(newQuantity, existingItem.Quantity, maxQuantity); err != nil {
+			return err
+		}
+
 		existingItem.Quantity = newQuantity
 		existingItem.Price = price // Update price in case it changed
 		return s.db.Save(&existingItem).Error
 	}
 }
 
-func (s *Service) addToGuestCart(sessionID string, productID uint, variantID *uint, quantity int, price int64, availableQuantity int, trackQuantity bool) error {
+func (s *Service) addToGuestCart(sessionID string, productID uint, variantID *uint, quantity int, price int64, availableQuantity int, trackQuantity bool, maxQuantity int) error {
 	sessionCart, err := s.getGuestCart(sessionID)
 	if err != nil {
 		return err
@@ -370,6 +430,10 @@ func (s *Service) addToGuestCart(sessionID string, productID uint, variantID *ui
 				return fmt.Errorf("insufficient inventory for total quantity. Available: %d", availableQuantity)
 			}
 
+			if err := checkMaxQuantity(newQuantity, sessionCart.Items[i].Quantity, maxQuantity); err != nil {
+				return err
+			}
+
 			sessionCart.Items[i].Quantity = newQuantity
 			sessionCart.Items[i].Price = price // Update price in case it changed
 			itemExists = true
@@ -492,21 +556,39 @@ func (s *Service) saveGuestCart(sessionID string, cart *SessionCart) error {
 
 func (s *Service) loadProductDetails(cartItems []CartItemResponse) error {
 	for i := range cartItems {
-		// Load product details
+		cartItems[i].Status = ItemStatusAvailable
+
+		// Load product details, including deleted products so the item can still be shown
 		var prod product.Product
-		err := s.db.Preload("Category").Preload("Brand").
+		err := s.db.Unscoped().Preload("Category").Preload("Brand").
 			Where("id = ?", cartItems[i].ProductID).First(&prod).Error
 		if err != nil {
-			continue // Skip if product not found
+			if err != gorm.ErrRecordNotFound {
+				return fmt.Errorf("failed to load cart product: %w", err)
+			}
+			cartItems[i].markUnavailable(UnavailableProductRemoved)
+			continue
 		}
 		cartItems[i].Product = &prod
 
+		switch {
+		case prod.DeletedAt.Valid:
+			cartItems[i].markUnavailable(UnavailableProductRemoved)
+		case !prod.IsActive:
+			cartItems[i].markUnavailable(UnavailableProductInactive)
+		}
+
 		// Load variant details if applicable
 		if cartItems[i].ProductVariantID != nil {
 			var variant product.ProductVariant
 			err := s.db.Where("id = ?", *cartItems[i].ProductVariantID).First(&variant).Error
 			if err == nil {
 				cartItems[i].ProductVariant = &variant
+				if !variant.IsActive && cartItems[i].IsAvailable() {
+					cartItems[i].markUnavailable(UnavailableVariantInactive)
+				}
+			} else if cartItems[i].IsAvailable() {
+				cartItems[i].markUnavailable(UnavailableVariantRemoved)
 			}
 		}
 	}
@@ -514,20 +596,19 @@ func (s *Service) loadProductDetails(cartItems []CartItemResponse) error {
 	return nil
 }
 
-func (s *Service) calculateTotals(cartItems []CartItemResponse) CartTotals {
+func (s *Service) calculateTotals(userID *uint, cartItems []CartItemResponse) CartTotals {
 	var totals CartTotals
 
-	totals.ItemCount = len(cartItems)
-
 	for _, item := range cartItems {
+		if !item.IsAvailable() {
+			continue
+		}
+		totals.ItemCount++
 		totals.TotalQuantity += item.Quantity
 		totals.SubTotal += item.Price * int64(item.Quantity)
 	}
 
-	// TODO: Calculate tax and shipping based on business rules
-	totals.TaxAmount = 0
-	totals.ShippingCost = 0
-	totals.DiscountAmount = 0
+	s.estimateCharges(userID, cartItems, &totals)
 	totals.TotalAmount = totals.SubTotal + totals.TaxAmount + totals.ShippingCost - totals.DiscountAmount
 
 	return totals
@@ -0,0 +1,98 @@
+// internal/domain/cart/totals.go
+package cart
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/coupon"
+	"github.com/your-org/ecommerce-backend/internal/domain/setting"
+	"github.com/your-org/ecommerce-backend/internal/domain/tax"
+	"github.com/your-org/ecommerce-backend/internal/domain/user"
+)
+
+// estimateCharges fills in estimated tax, standard shipping and the stored coupon
+// discount. Tax needs the customer's default shipping address; without one it is
+// left at 0 and flagged.
+func (s *Service) estimateCharges(userID *uint, cartItems []CartItemResponse, totals *CartTotals) {
+	totals.IsEstimate = true
+	totals.TaxAddressRequired = true
+	if totals.SubTotal == 0 {
+		return
+	}
+
+	if userID != nil {
+		addressService := user.NewAddressService(s.db, s.config)
+		if address, err := addressService.GetDefaultAddress(*userID, "shipping"); err == nil {
+			totals.TaxAmount = tax.CalculateItemsGST(TaxLineItems(cartItems), address.Country, s.config.App.StoreState, address.State).TaxAmount
+			totals.TaxAddressRequired = false
+		}
+	}
+
+	totals.ShippingCost = s.estimateShipping(totals.SubTotal)
+
+	if userID != nil {
+		totals.CouponCode, totals.DiscountAmount = s.estimateDiscount(*userID, totals.SubTotal)
+	}
+}
+
+// TaxLineItems turns the available cart items into GST lines, each taxed at its
+// product's rate
+func TaxLineItems(cartItems []CartItemResponse) []tax.LineItem {
+	lines := make([]tax.LineItem, 0, len(cartItems))
+	for _, item := range cartItems {
+		if !item.IsAvailable() || item.Product == nil {
+			continue
+		}
+		hsnCode, rate := item.Product.GST()
+		lines = append(lines, tax.LineItem{
+			ProductID:        item.ProductID,
+			ProductVariantID: item.ProductVariantID,
+			Name:             item.Product.Name,
+			HSNCode:          hsnCode,
+			Rate:             rate,
+			Amount:           item.Price * int64(item.Quantity),
+		})
+	}
+	return lines
+}
+
+// estimateShipping prices standard shipping from the shipping settings, waiving it
+// once the subtotal reaches the free shipping threshold
+func (s *Service) estimateShipping(subtotal int64) int64 {
+	shipping, err := setting.NewService(s.db, s.redisClient, s.config).GetShippingSettings()
+	if err != nil {
+		log.Printf("Failed to load shipping settings, using defaults: %v", err)
+		defaults := setting.DefaultShippingSettings()
+		shipping = &defaults
+	}
+
+	if shipping.FreeShippingThreshold > 0 && subtotal >= shipping.FreeShippingThreshold {
+		return 0
+	}
+	return shipping.Rates.Standard
+}
+
+// estimateDiscount re-validates the coupon applied at checkout against the current
+// subtotal, returning its code and discount when it still applies
+func (s *Service) estimateDiscount(userID uint, subtotal int64) (string, int64) {
+	data, err := s.redisClient.Get(context.Background(), fmt.Sprintf("applied_coupon:%d", userID)).Result()
+	if err != nil {
+		return "", 0
+	}
+
+	var stored struct {
+		CouponCode string `json:"coupon_code"`
+	}
+	if err := json.Unmarshal([]byte(data), &stored); err != nil || stored.CouponCode == "" {
+		return "", 0
+	}
+
+	c, discount, err := coupon.NewService(s.db, s.config).Validate(stored.CouponCode, userID, subtotal)
+	if err != nil || c == nil {
+		return "", 0
+	}
+	return c.Code, coupon.CapDiscount(discount, subtotal, s.config.Order.MaxDiscountPercent)
+}
@@ -0,0 +1,98 @@
+// internal/domain/checkout/payment_methods.go
+package checkout
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/cart"
+)
+
+// GetPaymentMethods returns payment methods evaluated against the current cart
+func (s *Service) GetPaymentMethods(userID uint, sessionID string) ([]PaymentMethod, error) {
+	userIDPtr := &userID
+	cartResponse, err := s.cartService.GetCart(userIDPtr, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+
+	return s.getAvailablePaymentMethods(cartResponse, cartResponse.Totals.TotalAmount), nil
+}
+
+func (s *Service) getAvailablePaymentMethods(cartResponse *cart.CartResponse, orderTotal int64) []PaymentMethod {
+	codAvailable, codReason := s.isCODAvailable(cartResponse, orderTotal)
+
+	methods := []PaymentMethod{
+		{
+			ID:          "razorpay",
+			Name:        "Razorpay",
+			Description: "Pay using Credit Card, Debit Card, NetBanking, UPI, or Wallets",
+			Available:   s.config.External.Razorpay.KeyID != "",
+			Logo:        "/images/razorpay-logo.png",
+		},
+		{
+			ID:          "stripe",
+			Name:        "Stripe",
+			Description: "Pay using Credit Card, Debit Card, Apple Pay, or Google Pay",
+			Available:   s.config.External.Stripe.SecretKey != "",
+			Logo:        "/images/stripe-logo.png",
+		},
+		{
+			ID:          "cod",
+			Name:        "Cash on Delivery",
+			Description: "Pay cash when your order is delivered",
+			Available:   codAvailable,
+			Reason:      codReason,
+			Logo:        "/images/cod-logo.png",
+		},
+		{
+			ID:          "wallet",
+			Name:        "Digital Wallet",
+			Description: "Pay using Paytm, PhonePe, Google Pay",
+			Available:   true,
+			Logo:        "/images/wallet-logo.png",
+		},
+	}
+
+	// Only offer the methods enabled in payment settings
+	paymentSettings, err := s.settings.GetPaymentSettings()
+	if err != nil {
+		log.Printf("Failed to load payment settings, offering all methods: %v", err)
+		return methods
+	}
+
+	enabled := make([]PaymentMethod, 0, len(methods))
+	for _, method := range methods {
+		if paymentSettings.IsMethodEnabled(method.ID) {
+			enabled = append(enabled, method)
+		}
+	}
+	return enabled
+}
+
+// isCODAvailable applies the configured cash-on-delivery rules to the cart
+func (s *Service) isCODAvailable(cartResponse *cart.CartResponse, orderTotal int64) (bool, string) {
+	rules := s.config.Payment
+
+	if rules.CODMinOrderAmount > 0 && orderTotal < rules.CODMinOrderAmount {
+		return false, fmt.Sprintf("Cash on Delivery is available for orders of %.2f or more", float64(rules.CODMinOrderAmount)/100)
+	}
+	if rules.CODMaxOrderAmount > 0 && orderTotal > rules.CODMaxOrderAmount {
+		return false, fmt.Sprintf("Cash on Delivery is not available for orders above %.2f", float64(rules.CODMaxOrderAmount)/100)
+	}
+
+	if len(rules.PrepaidOnlyCategoryIDs) > 0 && cartResponse != nil {
+		prepaidOnly := make(map[uint]bool, len(rules.PrepaidOnlyCategoryIDs))
+		for _, id := range rules.PrepaidOnlyCategoryIDs {
+			prepaidOnly[id] = true
+		}
+
+		for _, item := range cartResponse.Items {
+			if item.Product != nil && prepaidOnly[item.Product.CategoryID] {
+				return false, fmt.Sprintf("%s must be paid for in advance", item.Product.Name)
+			}
+		}
+	}
+
+	return true, ""
+}
@@ -4,31 +4,44 @@ package checkout
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"github.com/your-org/ecommerce-backend/internal/domain/cart"
+	"github.com/your-org/ecommerce-backend/internal/domain/coupon"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/setting"
+	"github.com/your-org/ecommerce-backend/internal/domain/shipping"
+	"github.com/your-org/ecommerce-backend/internal/domain/tax"
 	"github.com/your-org/ecommerce-backend/internal/domain/user"
 	"gorm.io/gorm"
 )
 
 // Service handles checkout business logic
 type Service struct {
-	db          *gorm.DB
-	redisClient *redis.Client
-	config      *config.Config
-	cartService *cart.Service
+	db              *gorm.DB
+	redisClient     *redis.Client
+	config          *config.Config
+	cartService     *cart.Service
+	couponService   *coupon.Service
+	settings        *setting.Service
+	shippingService *shipping.Service
 }
 
 // NewService creates a new checkout service
 func NewService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *Service {
+	settingService := setting.NewService(db, redisClient, cfg)
 	return &Service{
-		db:          db,
-		redisClient: redisClient,
-		config:      cfg,
-		cartService: cart.NewService(db, redisClient, cfg),
+		db:              db,
+		redisClient:     redisClient,
+		config:          cfg,
+		cartService:     cart.NewService(db, redisClient, cfg),
+		couponService:   coupon.NewService(db, cfg),
+		settings:        settingService,
+		shippingService: shipping.NewService(db, settingService),
 	}
 }
 
@@ -58,6 +71,20 @@ type ShippingCalculation struct {
 	EstimatedDays  string         `json:"estimated_days"`
 }
 
+// AddOnCalculationRequest represents checkout add-on calculation request
+type AddOnCalculationRequest struct {
+	AddOns []string `json:"add_ons"`
+}
+
+// AddOnCalculation represents checkout add-on calculation result
+type AddOnCalculation struct {
+	AddOns          []order.AddOn `json:"add_ons"`
+	InsuranceAmount int64         `json:"insurance_amount"`
+	SignatureFee    int64         `json:"signature_fee"`
+	GiftWrapFee     int64         `json:"gift_wrap_fee"`
+	TotalCost       int64         `json:"total_cost"`
+}
+
 // TaxCalculationRequest represents tax calculation request
 type TaxCalculationRequest struct {
 	AddressID uint   `json:"address_id" binding:"required"`
@@ -70,7 +97,9 @@ type TaxCalculation struct {
 	TaxAmount     int64          `json:"tax_amount"`     // Tax amount in cents
 	TaxableAmount int64          `json:"taxable_amount"` // Amount subject to tax
 	TaxType       string         `json:"tax_type"`       // GST, VAT, Sales Tax, etc.
+	InterState    bool           `json:"inter_state"`    // IGST rather than CGST and SGST
 	Breakdown     []TaxBreakdown `json:"breakdown,omitempty"`
+	Items         []tax.LineTax  `json:"items,omitempty"` // Tax on each cart line
 }
 
 // TaxBreakdown represents detailed tax breakdown
@@ -102,6 +131,7 @@ type CheckoutSummary struct {
 	ShippingMethod  *ShippingMethod    `json:"shipping_method,omitempty"`
 	Pricing         CheckoutPricing    `json:"pricing"`
 	AppliedCoupon   *CouponApplication `json:"applied_coupon,omitempty"`
+	AddOns          []order.AddOn      `json:"add_ons"`
 	PaymentMethods  []PaymentMethod    `json:"payment_methods"`
 }
 
@@ -111,6 +141,8 @@ type CheckoutPricing struct {
 	ShippingCost   int64 `json:"shipping_cost"`
 	TaxAmount      int64 `json:"tax_amount"`
 	DiscountAmount int64 `json:"discount_amount"`
+	DiscountCapped bool  `json:"discount_capped,omitempty"` // Reduced to the store's maximum discount
+	AddOnsAmount   int64 `json:"add_ons_amount"`            // Selected checkout add-ons
 	TotalAmount    int64 `json:"total_amount"`
 }
 
@@ -120,16 +152,18 @@ type PaymentMethod struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Available   bool   `json:"available"`
+	Reason      string `json:"reason,omitempty"` // Why the method is unavailable for this cart
 	Logo        string `json:"logo,omitempty"`
 }
 
 // CheckoutValidationRequest represents checkout validation request
 type CheckoutValidationRequest struct {
-	ShippingAddressID uint   `json:"shipping_address_id" binding:"required"`
-	BillingAddressID  *uint  `json:"billing_address_id,omitempty"`
-	ShippingMethodID  string `json:"shipping_method_id" binding:"required"`
-	PaymentMethodID   string `json:"payment_method_id" binding:"required"`
-	CouponCode        string `json:"coupon_code,omitempty"`
+	ShippingAddressID uint     `json:"shipping_address_id" binding:"required"`
+	BillingAddressID  *uint    `json:"billing_address_id,omitempty"`
+	ShippingMethodID  string   `json:"shipping_method_id" binding:"required"`
+	PaymentMethodID   string   `json:"payment_method_id" binding:"required"`
+	CouponCode        string   `json:"coupon_code,omitempty"`
+	AddOns            []string `json:"add_ons,omitempty"`
 }
 
 // CheckoutValidation represents checkout validation result
@@ -181,7 +215,7 @@ func (s *Service) GetShippingMethods(userID uint, addressID *uint, country, stat
 	}
 
 	// Calculate shipping methods based on location and cart
-	return s.calculateShippingMethods(shippingAddress, cartResponse), nil
+	return s.calculateShippingMethods(shippingAddress, shipping.CartWeight(cartResponse.Items), cartResponse.Totals.SubTotal)
 }
 
 // CalculateShipping calculates shipping cost for specific method
@@ -193,8 +227,17 @@ func (s *Service) CalculateShipping(userID uint, req *ShippingCalculationRequest
 		return nil, fmt.Errorf("failed to get address: %w", err)
 	}
 
+	// Price against the cart so weight tiers and free shipping apply
+	cartResponse, err := s.cartService.GetCart(&userID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+
 	// Get shipping method
-	methods := s.calculateShippingMethods(address, nil)
+	methods, err := s.calculateShippingMethods(address, shipping.CartWeight(cartResponse.Items), cartResponse.Totals.SubTotal)
+	if err != nil {
+		return nil, err
+	}
 	var selectedMethod *ShippingMethod
 	for _, method := range methods {
 		if method.ID == req.ShippingMethodID {
@@ -219,6 +262,33 @@ func (s *Service) CalculateShipping(userID uint, req *ShippingCalculationRequest
 	}, nil
 }
 
+// CalculateAddOns prices the checkout add-ons for the user's cart
+func (s *Service) CalculateAddOns(userID uint, req *AddOnCalculationRequest) (*AddOnCalculation, error) {
+	userIDPtr := &userID
+	cartResponse, err := s.cartService.GetCart(userIDPtr, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+
+	if len(cartResponse.Items) == 0 {
+		return nil, fmt.Errorf("cart is empty")
+	}
+
+	subtotal := cartResponse.Totals.SubTotal
+	charges, err := order.PriceAddOns(s.config, subtotal, req.AddOns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddOnCalculation{
+		AddOns:          order.AvailableAddOns(s.config, subtotal, req.AddOns),
+		InsuranceAmount: charges.InsuranceAmount,
+		SignatureFee:    charges.SignatureFee,
+		GiftWrapFee:     charges.GiftWrapFee,
+		TotalCost:       charges.Total(),
+	}, nil
+}
+
 // ApplyCoupon applies a coupon code
 func (s *Service) ApplyCoupon(userID uint, couponCode string) (*CouponApplication, error) {
 	// Get user's cart
@@ -233,7 +303,7 @@ func (s *Service) ApplyCoupon(userID uint, couponCode string) (*CouponApplicatio
 	}
 
 	// Validate and apply coupon
-	coupon := s.validateCoupon(couponCode, cartResponse.Totals.SubTotal)
+	coupon := s.validateCoupon(userID, couponCode, cartResponse.Totals.SubTotal)
 	if !coupon.Applied {
 		return coupon, nil
 	}
@@ -263,24 +333,25 @@ func (s *Service) CalculateTax(userID uint, req *TaxCalculationRequest) (*TaxCal
 		return nil, fmt.Errorf("failed to get address: %w", err)
 	}
 
-	var subtotal int64
+	var lines []tax.LineItem
 	if req.Subtotal != nil {
-		subtotal = *req.Subtotal
+		// A bare subtotal has no products to take rates from
+		lines = []tax.LineItem{{Name: "Subtotal", Rate: tax.StandardGSTRate, Amount: *req.Subtotal}}
 	} else {
-		// Get cart subtotal
+		// Tax each cart line at its product's rate
 		userIDPtr := &userID
 		cartResponse, err := s.cartService.GetCart(userIDPtr, "")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get cart: %w", err)
 		}
-		subtotal = cartResponse.Totals.SubTotal
+		lines = cart.TaxLineItems(cartResponse.Items)
 	}
 
-	return s.calculateTaxForLocation(subtotal, address), nil
+	return s.calculateTaxForLocation(lines, address), nil
 }
 
 // GetCheckoutSummary gets complete checkout summary
-func (s *Service) GetCheckoutSummary(userID uint, addressID *uint, shippingMethodID, couponCode string) (*CheckoutSummary, error) {
+func (s *Service) GetCheckoutSummary(userID uint, addressID *uint, shippingMethodID, couponCode string, addOns []string) (*CheckoutSummary, error) {
 	// Get cart
 	userIDPtr := &userID
 	cartResponse, err := s.cartService.GetCart(userIDPtr, "")
@@ -297,7 +368,6 @@ func (s *Service) GetCheckoutSummary(userID uint, addressID *uint, shippingMetho
 		Pricing: CheckoutPricing{
 			Subtotal: cartResponse.Totals.SubTotal,
 		},
-		PaymentMethods: s.getAvailablePaymentMethods(),
 	}
 
 	// Get addresses
@@ -312,7 +382,10 @@ func (s *Service) GetCheckoutSummary(userID uint, addressID *uint, shippingMetho
 
 	// Calculate shipping
 	if shippingMethodID != "" && summary.ShippingAddress != nil {
-		methods := s.calculateShippingMethods(summary.ShippingAddress, cartResponse)
+		methods, err := s.calculateShippingMethods(summary.ShippingAddress, shipping.CartWeight(cartResponse.Items), cartResponse.Totals.SubTotal)
+		if err != nil {
+			return nil, err
+		}
 		for _, method := range methods {
 			if method.ID == shippingMethodID {
 				summary.ShippingMethod = &method
@@ -324,31 +397,52 @@ func (s *Service) GetCheckoutSummary(userID uint, addressID *uint, shippingMetho
 
 	// Calculate tax
 	if summary.ShippingAddress != nil {
-		taxCalc := s.calculateTaxForLocation(summary.Pricing.Subtotal, summary.ShippingAddress)
+		taxCalc := s.calculateTaxForLocation(cart.TaxLineItems(cartResponse.Items), summary.ShippingAddress)
 		summary.Pricing.TaxAmount = taxCalc.TaxAmount
 	}
 
 	// Apply coupon
 	if couponCode != "" {
-		coupon := s.validateCoupon(couponCode, summary.Pricing.Subtotal)
+		coupon := s.validateCoupon(userID, couponCode, summary.Pricing.Subtotal)
 		if coupon.Applied {
 			summary.AppliedCoupon = coupon
 			summary.Pricing.DiscountAmount = coupon.DiscountAmount
 		}
 	} else {
-		// Check for stored coupon
-		summary.AppliedCoupon = s.getStoredCoupon(userID)
-		if summary.AppliedCoupon != nil {
-			summary.Pricing.DiscountAmount = summary.AppliedCoupon.DiscountAmount
+		// Re-check the stored coupon; the cart or the coupon may have changed since it was applied
+		if stored := s.getStoredCoupon(userID); stored != nil {
+			coupon := s.validateCoupon(userID, stored.CouponCode, summary.Pricing.Subtotal)
+			if coupon.Applied {
+				summary.AppliedCoupon = coupon
+				summary.Pricing.DiscountAmount = coupon.DiscountAmount
+			}
 		}
 	}
 
+	// Apply the store-wide cap once all discounts are combined
+	if capped := coupon.CapDiscount(summary.Pricing.DiscountAmount, summary.Pricing.Subtotal, s.config.Order.MaxDiscountPercent); capped < summary.Pricing.DiscountAmount {
+		summary.Pricing.DiscountAmount = capped
+		summary.Pricing.DiscountCapped = true
+	}
+
+	// Price the selected add-ons
+	charges, err := order.PriceAddOns(s.config, summary.Pricing.Subtotal, addOns)
+	if err != nil {
+		return nil, err
+	}
+	summary.AddOns = order.AvailableAddOns(s.config, summary.Pricing.Subtotal, addOns)
+	summary.Pricing.AddOnsAmount = charges.Total()
+
 	// Calculate total
 	summary.Pricing.TotalAmount = summary.Pricing.Subtotal +
 		summary.Pricing.ShippingCost +
-		summary.Pricing.TaxAmount -
+		summary.Pricing.TaxAmount +
+		summary.Pricing.AddOnsAmount -
 		summary.Pricing.DiscountAmount
 
+	// Payment method rules depend on the final order value
+	summary.PaymentMethods = s.getAvailablePaymentMethods(cartResponse, summary.Pricing.TotalAmount)
+
 	return summary, nil
 }
 
@@ -360,8 +454,24 @@ func (s *Service) ValidateCheckout(userID uint, req *CheckoutValidationRequest)
 		Warnings: []string{},
 	}
 
+	// Bring cart prices up to date so the summary shows what the order will charge
+	userIDPtr := &userID
+	priceChanges, err := s.cartService.RepriceCart(userIDPtr, "")
+	if err != nil {
+		validation.IsValid = false
+		validation.Errors = append(validation.Errors, err.Error())
+		return validation, nil
+	}
+	for _, change := range priceChanges {
+		if change.Increased {
+			validation.Warnings = append(validation.Warnings,
+				fmt.Sprintf("price of %s has increased from %.2f to %.2f",
+					change.ProductName, float64(change.OldPrice)/100, float64(change.NewPrice)/100))
+		}
+	}
+
 	// Get checkout summary
-	summary, err := s.GetCheckoutSummary(userID, &req.ShippingAddressID, req.ShippingMethodID, req.CouponCode)
+	summary, err := s.GetCheckoutSummary(userID, &req.ShippingAddressID, req.ShippingMethodID, req.CouponCode, req.AddOns)
 	if err != nil {
 		validation.IsValid = false
 		validation.Errors = append(validation.Errors, err.Error())
@@ -407,10 +517,20 @@ func (s *Service) ValidateCheckout(userID uint, req *CheckoutValidationRequest)
 
 	// Validate inventory
 	for _, item := range summary.Cart.Items {
+		if !item.IsAvailable() {
+			name := fmt.Sprintf("product %d", item.ProductID)
+			if item.Product != nil {
+				name = item.Product.Name
+			}
+			validation.IsValid = false
+			validation.Errors = append(validation.Errors,
+				fmt.Sprintf("%s is no longer available (%s), please remove it from your cart", name, item.UnavailableReason))
+			continue
+		}
 		if item.Product != nil && item.Product.TrackQuantity {
-			availableQuantity := item.Product.Quantity
+			availableQuantity := item.Product.AvailableQuantity()
 			if item.ProductVariant != nil {
-				availableQuantity = item.ProductVariant.Quantity
+				availableQuantity = item.ProductVariant.AvailableQuantity()
 			}
 			if availableQuantity < item.Quantity {
 				validation.Warnings = append(validation.Warnings,
@@ -424,183 +544,126 @@ func (s *Service) ValidateCheckout(userID uint, req *CheckoutValidationRequest)
 
 // Private helper methods
 
-func (s *Service) calculateShippingMethods(address *user.Address, cartResponse *cart.CartResponse) []ShippingMethod {
-	methods := []ShippingMethod{
-		{
-			ID:            "standard",
-			Name:          "Standard Shipping",
-			Description:   "Regular delivery in 5-7 business days",
-			Price:         999, // ₹9.99
-			EstimatedDays: "5-7 business days",
-			Available:     true,
-			Carrier:       "India Post",
-		},
-		{
-			ID:            "express",
-			Name:          "Express Shipping",
-			Description:   "Fast delivery in 2-3 business days",
-			Price:         1999, // ₹19.99
-			EstimatedDays: "2-3 business days",
-			Available:     true,
-			Carrier:       "BlueDart",
-		},
+// calculateShippingMethods returns the methods that can deliver a parcel of the given
+// weight in grams to the address, priced for the subtotal
+func (s *Service) calculateShippingMethods(address *user.Address, weight float64, subtotal int64) ([]ShippingMethod, error) {
+	quotes, err := s.shippingService.Quote(shipping.Destination{Country: address.Country, State: address.State}, weight, subtotal)
+	if err != nil {
+		return nil, err
 	}
 
-	// Add premium options for certain locations
-	if address.Country == "IN" && (address.State == "Maharashtra" || address.State == "Delhi" || address.State == "Karnataka") {
-		methods = append(methods, ShippingMethod{
-			ID:            "same_day",
-			Name:          "Same Day Delivery",
-			Description:   "Delivery within 24 hours",
-			Price:         2999, // ₹29.99
-			EstimatedDays: "Same day",
+	methods := make([]ShippingMethod, len(quotes))
+	for i, quote := range quotes {
+		methods[i] = ShippingMethod{
+			ID:            quote.Code,
+			Name:          quote.Name,
+			Description:   quote.Description,
+			Price:         quote.Price,
+			EstimatedDays: quote.EstimatedDays,
 			Available:     true,
-			Carrier:       "Dunzo",
-		})
-	}
-
-	// Free shipping for orders above threshold
-	if cartResponse != nil && cartResponse.Totals.SubTotal >= 299900 { // ₹2999
-		for i := range methods {
-			if methods[i].ID == "standard" {
-				methods[i].Price = 0
-				methods[i].Description = "Free standard shipping on orders over ₹2999"
-			}
+			Carrier:       quote.Carrier,
 		}
 	}
-
-	return methods
+	return methods, nil
 }
 
 func (s *Service) calculateShippingTax(shippingCost int64, address *user.Address) int64 {
-	// Simple tax calculation - in India, shipping is generally taxable
-	if address.Country == "IN" {
-		return int64(float64(shippingCost) * 0.18) // 18% GST
-	}
-	return 0
+	// In India, shipping is generally taxable
+	_, taxAmount := tax.CalculateGST(shippingCost, address.Country)
+	return taxAmount
 }
 
-func (s *Service) validateCoupon(couponCode string, subtotal int64) *CouponApplication {
-	// Mock coupon validation - replace with actual coupon system
-	coupons := map[string]CouponApplication{
-		"SAVE10": {
-			CouponCode:        "SAVE10",
-			DiscountType:      "percentage",
-			DiscountValue:     10.0,
-			MinOrderAmount:    199900, // ₹1999
-			MaxDiscountAmount: 149900, // ₹1499
-			Applied:           false,
-		},
-		"FLAT500": {
-			CouponCode:     "FLAT500",
-			DiscountType:   "fixed_amount",
-			DiscountValue:  50000,  // ₹500
-			MinOrderAmount: 299900, // ₹2999
-			Applied:        false,
-		},
-		"WELCOME20": {
-			CouponCode:        "WELCOME20",
-			DiscountType:      "percentage",
-			DiscountValue:     20.0,
-			MinOrderAmount:    99900,  // ₹999
-			MaxDiscountAmount: 199900, // ₹1999
-			Applied:           false,
-		},
-	}
-
-	coupon, exists := coupons[couponCode]
-	if !exists {
+// validateCoupon checks a coupon code against the coupon table and the customer's
+// redemption history, returning a user-facing result either way
+func (s *Service) validateCoupon(userID uint, couponCode string, subtotal int64) *CouponApplication {
+	c, discount, err := s.couponService.Validate(couponCode, userID, subtotal)
+	if c == nil {
+		message := "Invalid coupon code"
+		if err != nil && !errors.Is(err, coupon.ErrCouponNotFound) {
+			message = "Unable to validate coupon, please try again"
+		}
 		return &CouponApplication{
-			CouponCode: couponCode,
+			CouponCode: coupon.NormalizeCode(couponCode),
 			Applied:    false,
-			Message:    "Invalid coupon code",
-		}
-	}
-
-	// Check minimum order amount
-	if subtotal < coupon.MinOrderAmount {
-		coupon.Message = fmt.Sprintf("Minimum order amount of ₹%.2f required", float64(coupon.MinOrderAmount)/100)
-		return &coupon
-	}
-
-	// Calculate discount
-	if coupon.DiscountType == "percentage" {
-		coupon.DiscountAmount = int64(float64(subtotal) * coupon.DiscountValue / 100)
-		if coupon.MaxDiscountAmount > 0 && coupon.DiscountAmount > coupon.MaxDiscountAmount {
-			coupon.DiscountAmount = coupon.MaxDiscountAmount
+			Message:    message,
 		}
-	} else {
-		coupon.DiscountAmount = int64(coupon.DiscountValue)
 	}
 
-	coupon.Applied = true
-	coupon.Message = fmt.Sprintf("Coupon applied! You saved ₹%.2f", float64(coupon.DiscountAmount)/100)
-	return &coupon
+	application := &CouponApplication{
+		CouponCode:        c.Code,
+		DiscountType:      string(c.DiscountType),
+		DiscountValue:     c.DiscountValue,
+		MinOrderAmount:    c.MinOrderAmount,
+		MaxDiscountAmount: c.MaxDiscountAmount,
+		ValidUntil:        c.ValidUntil,
+	}
+
+	switch {
+	case err == nil:
+		discount = coupon.CapDiscount(discount, subtotal, s.config.Order.MaxDiscountPercent)
+		application.DiscountAmount = discount
+		application.Applied = true
+		application.Message = fmt.Sprintf("Coupon applied! You saved ₹%.2f", float64(discount)/100)
+	case errors.Is(err, coupon.ErrCouponMinOrder):
+		application.Message = fmt.Sprintf("Minimum order amount of ₹%.2f required", float64(c.MinOrderAmount)/100)
+	case errors.Is(err, coupon.ErrCouponInactive):
+		application.Message = "Invalid coupon code"
+	case errors.Is(err, coupon.ErrCouponNotStarted):
+		application.Message = "This coupon is not active yet"
+	case errors.Is(err, coupon.ErrCouponExpired):
+		application.Message = "This coupon has expired"
+	case errors.Is(err, coupon.ErrCouponUsageExhausted):
+		application.Message = "This coupon has reached its usage limit"
+	case errors.Is(err, coupon.ErrCouponUserLimitReached):
+		application.Message = "You have already used this coupon the maximum number of times"
+	default:
+		application.Message = "Unable to validate coupon, please try again"
+	}
+
+	return application
 }
 
-func (s *Service) calculateTaxForLocation(subtotal int64, address *user.Address) *TaxCalculation {
+// calculateTaxForLocation taxes each line at its own GST rate, split into CGST and
+// SGST when the address is in the store's state and charged as IGST otherwise
+func (s *Service) calculateTaxForLocation(lines []tax.LineItem, address *user.Address) *TaxCalculation {
+	result := tax.CalculateItemsGST(lines, address.Country, s.config.App.StoreState, address.State)
+
 	// Tax calculation based on Indian GST system
 	if address.Country != "IN" {
 		return &TaxCalculation{
 			TaxRate:       0,
 			TaxAmount:     0,
-			TaxableAmount: subtotal,
+			TaxableAmount: result.TaxableAmount,
 			TaxType:       "No Tax",
 		}
 	}
 
-	// Standard GST rate for most products
-	taxRate := 18.0 // 18% GST
-	taxAmount := int64(float64(subtotal) * taxRate / 100)
-
-	breakdown := []TaxBreakdown{
-		{
-			Type:        "CGST",
-			Rate:        9.0,
-			Amount:      taxAmount / 2,
-			Description: "Central Goods and Services Tax",
-		},
-		{
-			Type:        "SGST",
-			Rate:        9.0,
-			Amount:      taxAmount / 2,
-			Description: "State Goods and Services Tax",
-		},
+	breakdown := make([]TaxBreakdown, len(result.Components))
+	for i, component := range result.Components {
+		breakdown[i] = TaxBreakdown{
+			Type:        component.Type,
+			Rate:        component.Rate,
+			Amount:      component.Amount,
+			Description: gstComponentDescriptions[component.Type],
+		}
 	}
 
 	return &TaxCalculation{
-		TaxRate:       taxRate,
-		TaxAmount:     taxAmount,
-		TaxableAmount: subtotal,
+		TaxRate:       result.EffectiveRate(),
+		TaxAmount:     result.TaxAmount,
+		TaxableAmount: result.TaxableAmount,
 		TaxType:       "GST",
+		InterState:    result.InterState,
 		Breakdown:     breakdown,
+		Items:         result.Items,
 	}
 }
 
-func (s *Service) getAvailablePaymentMethods() []PaymentMethod {
-	return []PaymentMethod{
-		{
-			ID:          "razorpay",
-			Name:        "Razorpay",
-			Description: "Pay using Credit Card, Debit Card, NetBanking, UPI, or Wallets",
-			Available:   s.config.External.Razorpay.KeyID != "",
-			Logo:        "/images/razorpay-logo.png",
-		},
-		{
-			ID:          "cod",
-			Name:        "Cash on Delivery",
-			Description: "Pay cash when your order is delivered",
-			Available:   true,
-			Logo:        "/images/cod-logo.png",
-		},
-		{
-			ID:          "wallet",
-			Name:        "Digital Wallet",
-			Description: "Pay using Paytm, PhonePe, Google Pay",
-			Available:   true,
-			Logo:        "/images/wallet-logo.png",
-		},
-	}
+// gstComponentDescriptions names each GST component on the tax breakdown
+var gstComponentDescriptions = map[string]string{
+	tax.ComponentCGST: "Central Goods and Services Tax",
+	tax.ComponentSGST: "State Goods and Services Tax",
+	tax.ComponentIGST: "Integrated Goods and Services Tax",
 }
 
 func (s *Service) getStoredCoupon(userID uint) *CouponApplication {
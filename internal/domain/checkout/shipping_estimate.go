@@ -0,0 +1,109 @@
+// internal/domain/checkout/shipping_estimate.go
+package checkout
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"github.com/your-org/ecommerce-backend/internal/domain/user"
+)
+
+// ShippingEstimateItem is a provisional line item for a shipping estimate
+type ShippingEstimateItem struct {
+	ProductID        uint  `json:"product_id" binding:"required"`
+	ProductVariantID *uint `json:"product_variant_id"`
+	Quantity         int   `json:"quantity" binding:"required,min=1,max=100"`
+}
+
+// ShippingEstimateRequest represents a shipping estimate for a destination that
+// need not be a saved address, for items that need not be in a cart
+type ShippingEstimateRequest struct {
+	Country    string                 `json:"country" binding:"required,len=2"`
+	State      string                 `json:"state"`
+	City       string                 `json:"city"`
+	PostalCode string                 `json:"postal_code"`
+	Items      []ShippingEstimateItem `json:"items" binding:"required,min=1,max=50,dive"`
+}
+
+// ShippingDestination is the location a shipping estimate was calculated for
+type ShippingDestination struct {
+	Country    string `json:"country"`
+	State      string `json:"state"`
+	City       string `json:"city"`
+	PostalCode string `json:"postal_code"`
+}
+
+// ShippingEstimate represents the estimated shipping options for provisional items
+type ShippingEstimate struct {
+	Destination      ShippingDestination `json:"destination"`
+	Subtotal         int64               `json:"subtotal"` // Items total in cents, used for free shipping
+	Weight           float64             `json:"weight"`   // Parcel weight in grams, used for weight tiers
+	ItemCount        int                 `json:"item_count"`
+	RequiresShipping bool                `json:"requires_shipping"`
+	Methods          []ShippingMethod    `json:"methods"`
+}
+
+// EstimateShipping returns the shipping methods and costs for a destination and
+// provisional item list, without a saved address or an existing cart
+func (s *Service) EstimateShipping(req *ShippingEstimateRequest) (*ShippingEstimate, error) {
+	destination := user.Address{
+		Country:    strings.ToUpper(strings.TrimSpace(req.Country)),
+		State:      strings.TrimSpace(req.State),
+		City:       strings.TrimSpace(req.City),
+		PostalCode: strings.TrimSpace(req.PostalCode),
+	}
+
+	estimate := &ShippingEstimate{
+		Destination: ShippingDestination{
+			Country:    destination.Country,
+			State:      destination.State,
+			City:       destination.City,
+			PostalCode: destination.PostalCode,
+		},
+		Methods: []ShippingMethod{},
+	}
+
+	for _, item := range req.Items {
+		var prod product.Product
+		if err := s.db.Where("id = ? AND is_active = ?", item.ProductID, true).First(&prod).Error; err != nil {
+			return nil, fmt.Errorf("product %d not found", item.ProductID)
+		}
+
+		price := prod.Price
+		weight := prod.Weight
+		if item.ProductVariantID != nil {
+			var variant product.ProductVariant
+			err := s.db.Where("id = ? AND product_id = ? AND is_active = ?", *item.ProductVariantID, prod.ID, true).
+				First(&variant).Error
+			if err != nil {
+				return nil, fmt.Errorf("variant %d not found for product %d", *item.ProductVariantID, prod.ID)
+			}
+			if variant.Price > 0 {
+				price = variant.Price
+			}
+			if variant.Weight > 0 {
+				weight = variant.Weight
+			}
+		}
+
+		estimate.Subtotal += price * int64(item.Quantity)
+		estimate.Weight += weight * float64(item.Quantity)
+		estimate.ItemCount += item.Quantity
+		if prod.RequiresShipping && !prod.IsDigital {
+			estimate.RequiresShipping = true
+		}
+	}
+
+	// Nothing to ship, e.g. only digital products
+	if !estimate.RequiresShipping {
+		return estimate, nil
+	}
+
+	methods, err := s.calculateShippingMethods(&destination, estimate.Weight, estimate.Subtotal)
+	if err != nil {
+		return nil, err
+	}
+	estimate.Methods = methods
+	return estimate, nil
+}
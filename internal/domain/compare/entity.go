@@ -0,0 +1,26 @@
+// internal/domain/compare/entity.go
+package compare
+
+import (
+	"time"
+)
+
+// CompareItem represents a product in a logged-in user's comparison list
+type CompareItem struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_compare_items_user_product" json:"user_id"`
+	ProductID uint      `gorm:"not null;uniqueIndex:idx_compare_items_user_product" json:"product_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName overrides the table name
+func (CompareItem) TableName() string {
+	return "compare_items"
+}
+
+// SessionCompareList represents a guest comparison list stored in Redis
+type SessionCompareList struct {
+	SessionID  string    `json:"session_id"`
+	ProductIDs []uint    `json:"product_ids"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,156 @@
+// internal/domain/compare/matrix.go
+package compare
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+)
+
+// Attribute groups in the comparison matrix
+const (
+	AttributeGroupPricing      = "pricing"
+	AttributeGroupReviews      = "reviews"
+	AttributeGroupSpecs        = "specs"
+	AttributeGroupAvailability = "availability"
+)
+
+// Stock statuses shown in the comparison matrix
+const (
+	StockStatusInStock    = "in_stock"
+	StockStatusLowStock   = "low_stock"
+	StockStatusOutOfStock = "out_of_stock"
+)
+
+// CompareAttribute is one row of the comparison matrix. Values holds one entry per
+// compared product, in the same order as CompareListResponse.Products; nil means
+// the attribute does not apply to that product.
+type CompareAttribute struct {
+	Key     string        `json:"key"`
+	Label   string        `json:"label"`
+	Group   string        `json:"group"`
+	Values  []interface{} `json:"values"`
+	Differs bool          `json:"differs"` // Values are not all equal
+}
+
+// productRating holds approved review statistics for a compared product
+type productRating struct {
+	ProductID     uint
+	AverageRating float64
+	ReviewCount   int
+}
+
+// buildAttributeMatrix normalizes the compared products into attribute rows
+func (s *Service) buildAttributeMatrix(products []product.Product) ([]CompareAttribute, error) {
+	ratings, err := s.getRatings(products)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []struct {
+		key, label, group string
+		value             func(p *product.Product) interface{}
+	}{
+		{"price", "Price", AttributeGroupPricing, func(p *product.Product) interface{} { return p.Price }},
+		{"compare_price", "Original Price", AttributeGroupPricing, func(p *product.Product) interface{} {
+			if p.ComparePrice <= p.Price {
+				return nil
+			}
+			return p.ComparePrice
+		}},
+		{"discount_percentage", "Discount", AttributeGroupPricing, func(p *product.Product) interface{} { return p.GetDiscountPercentage() }},
+		{"average_rating", "Rating", AttributeGroupReviews, func(p *product.Product) interface{} { return ratings[p.ID].AverageRating }},
+		{"review_count", "Reviews", AttributeGroupReviews, func(p *product.Product) interface{} { return ratings[p.ID].ReviewCount }},
+		{"brand", "Brand", AttributeGroupSpecs, func(p *product.Product) interface{} {
+			if p.Brand == nil {
+				return nil
+			}
+			return p.Brand.Name
+		}},
+		{"category", "Category", AttributeGroupSpecs, func(p *product.Product) interface{} { return p.Category.Name }},
+		{"weight", "Weight (g)", AttributeGroupSpecs, func(p *product.Product) interface{} {
+			if p.Weight <= 0 {
+				return nil
+			}
+			return p.Weight
+		}},
+		{"dimensions", "Dimensions", AttributeGroupSpecs, func(p *product.Product) interface{} {
+			if p.Dimensions == "" {
+				return nil
+			}
+			return p.Dimensions
+		}},
+		{"is_digital", "Digital Product", AttributeGroupSpecs, func(p *product.Product) interface{} { return p.IsDigital }},
+		{"stock_status", "Availability", AttributeGroupAvailability, func(p *product.Product) interface{} { return stockStatus(p) }},
+		{"requires_shipping", "Requires Shipping", AttributeGroupAvailability, func(p *product.Product) interface{} { return p.RequiresShipping }},
+	}
+
+	matrix := make([]CompareAttribute, 0, len(rows))
+	for _, row := range rows {
+		attr := CompareAttribute{
+			Key:    row.key,
+			Label:  row.label,
+			Group:  row.group,
+			Values: make([]interface{}, len(products)),
+		}
+		for i := range products {
+			attr.Values[i] = row.value(&products[i])
+		}
+		attr.Differs = valuesDiffer(attr.Values)
+		matrix = append(matrix, attr)
+	}
+
+	return matrix, nil
+}
+
+// getRatings returns approved review averages and counts keyed by product ID
+func (s *Service) getRatings(products []product.Product) (map[uint]productRating, error) {
+	ratings := make(map[uint]productRating, len(products))
+	if len(products) == 0 {
+		return ratings, nil
+	}
+
+	productIDs := make([]uint, len(products))
+	for i, p := range products {
+		productIDs[i] = p.ID
+	}
+
+	var rows []productRating
+	err := s.db.Model(&product.ProductReview{}).
+		Select("product_id, AVG(rating) AS average_rating, COUNT(*) AS review_count").
+		Where("product_id IN ? AND is_approved = ?", productIDs, true).
+		Group("product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve product ratings: %w", err)
+	}
+
+	for _, row := range rows {
+		row.AverageRating = math.Round(row.AverageRating*100) / 100
+		ratings[row.ProductID] = row
+	}
+	return ratings, nil
+}
+
+// stockStatus summarizes product availability
+func stockStatus(p *product.Product) string {
+	switch {
+	case !p.IsInStock():
+		return StockStatusOutOfStock
+	case p.IsLowStock():
+		return StockStatusLowStock
+	default:
+		return StockStatusInStock
+	}
+}
+
+// valuesDiffer reports whether a matrix row has more than one distinct value
+func valuesDiffer(values []interface{}) bool {
+	for i := 1; i < len(values); i++ {
+		if fmt.Sprint(values[i]) != fmt.Sprint(values[0]) {
+			return true
+		}
+	}
+	return false
+}
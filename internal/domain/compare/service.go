@@ -0,0 +1,318 @@
+// internal/domain/compare/service.go
+package compare
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"gorm.io/gorm"
+)
+
+// ErrCompareListFull is returned when adding beyond the configured comparison limit
+var ErrCompareListFull = errors.New("comparison list is full")
+
+// Service handles product comparison lists. Logged-in users' lists are stored in
+// the database and cached in Redis; guest lists live in Redis keyed by session.
+type Service struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+	config      *config.Config
+}
+
+// NewService creates a new compare service
+func NewService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *Service {
+	return &Service{
+		db:          db,
+		redisClient: redisClient,
+		config:      cfg,
+	}
+}
+
+// CompareListResponse represents a comparison list with product details
+type CompareListResponse struct {
+	ProductIDs []uint             `json:"product_ids"`
+	Products   []product.Product  `json:"products"`
+	Attributes []CompareAttribute `json:"attributes"` // Side-by-side matrix, one value per product
+	Count      int                `json:"count"`
+	MaxItems   int                `json:"max_items"`
+}
+
+// GetCompareList returns the comparison list with product details
+func (s *Service) GetCompareList(userID *uint, sessionID string) (*CompareListResponse, error) {
+	productIDs, err := s.GetProductIDs(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CompareListResponse{
+		ProductIDs: productIDs,
+		Products:   []product.Product{},
+		Attributes: []CompareAttribute{},
+		Count:      len(productIDs),
+		MaxItems:   s.maxItems(),
+	}
+
+	if len(productIDs) == 0 {
+		return response, nil
+	}
+
+	var products []product.Product
+	err = s.db.Preload("Category").Preload("Brand").Preload("Images").
+		Where("id IN ? AND is_active = ?", productIDs, true).
+		Find(&products).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve compare products: %w", err)
+	}
+
+	// Keep the order in which products were added
+	byID := make(map[uint]product.Product, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+	for _, id := range productIDs {
+		if p, ok := byID[id]; ok {
+			response.Products = append(response.Products, p)
+		}
+	}
+
+	response.Attributes, err = s.buildAttributeMatrix(response.Products)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetProductIDs returns the product IDs in the comparison list, oldest first
+func (s *Service) GetProductIDs(userID *uint, sessionID string) ([]uint, error) {
+	if userID != nil {
+		return s.getUserList(*userID)
+	}
+
+	list, err := s.getSessionList(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return list.ProductIDs, nil
+}
+
+// AddProduct adds a product to the comparison list
+func (s *Service) AddProduct(userID *uint, sessionID string, productID uint) ([]uint, error) {
+	var p product.Product
+	if err := s.db.Select("id").Where("id = ? AND is_active = ?", productID, true).First(&p).Error; err != nil {
+		return nil, fmt.Errorf("product not found")
+	}
+
+	productIDs, err := s.GetProductIDs(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range productIDs {
+		if id == productID {
+			return productIDs, nil
+		}
+	}
+
+	if len(productIDs) >= s.maxItems() {
+		return nil, fmt.Errorf("%w: you can compare up to %d products, remove one to add another", ErrCompareListFull, s.maxItems())
+	}
+
+	productIDs = append(productIDs, productID)
+
+	if userID != nil {
+		item := CompareItem{UserID: *userID, ProductID: productID}
+		if err := s.db.Where(CompareItem{UserID: *userID, ProductID: productID}).FirstOrCreate(&item).Error; err != nil {
+			return nil, fmt.Errorf("failed to add product to comparison: %w", err)
+		}
+		s.cacheUserList(*userID, productIDs)
+		return productIDs, nil
+	}
+
+	if err := s.saveSessionList(sessionID, productIDs); err != nil {
+		return nil, err
+	}
+	return productIDs, nil
+}
+
+// RemoveProduct removes a product from the comparison list
+func (s *Service) RemoveProduct(userID *uint, sessionID string, productID uint) ([]uint, error) {
+	productIDs, err := s.GetProductIDs(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := make([]uint, 0, len(productIDs))
+	for _, id := range productIDs {
+		if id != productID {
+			remaining = append(remaining, id)
+		}
+	}
+
+	if userID != nil {
+		if err := s.db.Where("user_id = ? AND product_id = ?", *userID, productID).Delete(&CompareItem{}).Error; err != nil {
+			return nil, fmt.Errorf("failed to remove product from comparison: %w", err)
+		}
+		s.cacheUserList(*userID, remaining)
+		return remaining, nil
+	}
+
+	if err := s.saveSessionList(sessionID, remaining); err != nil {
+		return nil, err
+	}
+	return remaining, nil
+}
+
+// ClearList removes all products from the comparison list
+func (s *Service) ClearList(userID *uint, sessionID string) error {
+	ctx := context.Background()
+
+	if userID != nil {
+		if err := s.db.Where("user_id = ?", *userID).Delete(&CompareItem{}).Error; err != nil {
+			return fmt.Errorf("failed to clear comparison list: %w", err)
+		}
+		s.redisClient.Del(ctx, s.userKey(*userID))
+		return nil
+	}
+
+	return s.redisClient.Del(ctx, s.sessionKey(sessionID)).Err()
+}
+
+// MergeSessionListToUser merges a guest comparison list into the user's persisted
+// list when they log in. Existing user items keep their place; session items fill
+// the remaining slots up to the configured maximum.
+func (s *Service) MergeSessionListToUser(userID uint, sessionID string) ([]uint, error) {
+	if sessionID == "" {
+		return s.getUserList(userID)
+	}
+
+	sessionList, err := s.getSessionList(sessionID)
+	if err != nil || len(sessionList.ProductIDs) == 0 {
+		return s.getUserList(userID)
+	}
+
+	productIDs, err := s.getUserList(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[uint]bool, len(productIDs))
+	for _, id := range productIDs {
+		existing[id] = true
+	}
+
+	for _, id := range sessionList.ProductIDs {
+		if existing[id] || len(productIDs) >= s.maxItems() {
+			continue
+		}
+		item := CompareItem{UserID: userID, ProductID: id}
+		if err := s.db.Where(CompareItem{UserID: userID, ProductID: id}).FirstOrCreate(&item).Error; err != nil {
+			return nil, fmt.Errorf("failed to merge comparison list: %w", err)
+		}
+		existing[id] = true
+		productIDs = append(productIDs, id)
+	}
+
+	s.cacheUserList(userID, productIDs)
+	s.redisClient.Del(context.Background(), s.sessionKey(sessionID))
+
+	return productIDs, nil
+}
+
+// Private helper methods
+
+func (s *Service) maxItems() int {
+	if s.config.Compare.MaxItems <= 0 {
+		return 4
+	}
+	return s.config.Compare.MaxItems
+}
+
+func (s *Service) userKey(userID uint) string {
+	return fmt.Sprintf("compare:user:%d", userID)
+}
+
+func (s *Service) sessionKey(sessionID string) string {
+	return fmt.Sprintf("compare:session:%s", sessionID)
+}
+
+// getUserList reads the user's list from the Redis cache, falling back to the database
+func (s *Service) getUserList(userID uint) ([]uint, error) {
+	ctx := context.Background()
+
+	if cached, err := s.redisClient.Get(ctx, s.userKey(userID)).Result(); err == nil {
+		var productIDs []uint
+		if err := json.Unmarshal([]byte(cached), &productIDs); err == nil {
+			return productIDs, nil
+		}
+	}
+
+	var items []CompareItem
+	if err := s.db.Where("user_id = ?", userID).Order("created_at ASC, id ASC").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve comparison list: %w", err)
+	}
+
+	productIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	s.cacheUserList(userID, productIDs)
+	return productIDs, nil
+}
+
+func (s *Service) cacheUserList(userID uint, productIDs []uint) {
+	data, err := json.Marshal(productIDs)
+	if err != nil {
+		return
+	}
+	s.redisClient.Set(context.Background(), s.userKey(userID), data, s.config.Compare.CacheTTL)
+}
+
+func (s *Service) getSessionList(sessionID string) (*SessionCompareList, error) {
+	list := &SessionCompareList{SessionID: sessionID, ProductIDs: []uint{}}
+	if sessionID == "" {
+		return list, nil
+	}
+
+	data, err := s.redisClient.Get(context.Background(), s.sessionKey(sessionID)).Result()
+	if err == redis.Nil {
+		return list, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve comparison list: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(data), list); err != nil {
+		return nil, fmt.Errorf("failed to parse comparison list: %w", err)
+	}
+	return list, nil
+}
+
+func (s *Service) saveSessionList(sessionID string, productIDs []uint) error {
+	if sessionID == "" {
+		return fmt.Errorf("session is required")
+	}
+
+	list := SessionCompareList{
+		SessionID:  sessionID,
+		ProductIDs: productIDs,
+		UpdatedAt:  time.Now(),
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("failed to serialize comparison list: %w", err)
+	}
+
+	if err := s.redisClient.Set(context.Background(), s.sessionKey(sessionID), data, s.config.Compare.SessionTTL).Err(); err != nil {
+		return fmt.Errorf("failed to save comparison list: %w", err)
+	}
+	return nil
+}
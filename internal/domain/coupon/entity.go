@@ -0,0 +1,95 @@
+// internal/domain/coupon/entity.go
+package coupon
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DiscountType represents how a coupon discount is calculated
+type DiscountType string
+
+const (
+	DiscountTypePercentage  DiscountType = "percentage"
+	DiscountTypeFixedAmount DiscountType = "fixed_amount"
+)
+
+// Coupon represents a discount code that customers can apply at checkout
+type Coupon struct {
+	ID                uint           `gorm:"primaryKey" json:"id"`
+	Code              string         `gorm:"uniqueIndex;not null;size:50" json:"code"` // Stored upper-case
+	Description       string         `gorm:"size:500" json:"description"`
+	DiscountType      DiscountType   `gorm:"not null;size:20" json:"discount_type"`
+	DiscountValue     float64        `gorm:"not null" json:"discount_value"`       // Percentage (10 = 10%) or fixed amount in cents
+	MinOrderAmount    int64          `gorm:"default:0" json:"min_order_amount"`    // In cents
+	MaxDiscountAmount int64          `gorm:"default:0" json:"max_discount_amount"` // In cents, 0 for no cap
+	UsageLimit        int            `gorm:"default:0" json:"usage_limit"`         // Total redemptions, 0 for unlimited
+	PerUserLimit      int            `gorm:"default:0" json:"per_user_limit"`      // Redemptions per customer, 0 for unlimited
+	ValidFrom         *time.Time     `json:"valid_from"`
+	ValidUntil        *time.Time     `json:"valid_until"`
+	IsActive          bool           `gorm:"default:true" json:"is_active"`
+	CreatedBy         uint           `json:"created_by"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Number of redemptions so far; populated by the service, not stored
+	UsedCount int64 `gorm:"-" json:"used_count"`
+}
+
+// CouponRedemption records a coupon being used on an order. Cancelling or refunding
+// the order releases the redemption so it no longer counts towards usage limits.
+type CouponRedemption struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	CouponID       uint       `gorm:"not null;index" json:"coupon_id"`
+	UserID         uint       `gorm:"not null;index" json:"user_id"`
+	OrderID        *uint      `gorm:"index" json:"order_id"`
+	DiscountAmount int64      `gorm:"not null" json:"discount_amount"` // In cents
+	ReleasedAt     *time.Time `gorm:"index" json:"released_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// TableName overrides
+func (Coupon) TableName() string           { return "coupons" }
+func (CouponRedemption) TableName() string { return "coupon_redemptions" }
+
+// IsValidDiscountType checks if the discount type is supported
+func IsValidDiscountType(discountType DiscountType) bool {
+	return discountType == DiscountTypePercentage || discountType == DiscountTypeFixedAmount
+}
+
+// DiscountFor returns the discount in cents this coupon gives on the subtotal,
+// honouring the maximum discount and never exceeding the subtotal itself
+func (c *Coupon) DiscountFor(subtotal int64) int64 {
+	var discount int64
+	switch c.DiscountType {
+	case DiscountTypePercentage:
+		discount = int64(float64(subtotal) * c.DiscountValue / 100)
+	case DiscountTypeFixedAmount:
+		discount = int64(c.DiscountValue)
+	}
+
+	if c.MaxDiscountAmount > 0 && discount > c.MaxDiscountAmount {
+		discount = c.MaxDiscountAmount
+	}
+	if discount > subtotal {
+		discount = subtotal
+	}
+	if discount < 0 {
+		discount = 0
+	}
+	return discount
+}
+
+// CapDiscount limits the combined discount on an order to maxPercent of its subtotal,
+// however coupons and other discounts stack. A maxPercent of 0 disables the cap.
+func CapDiscount(discount, subtotal int64, maxPercent int) int64 {
+	if maxPercent <= 0 {
+		return discount
+	}
+	if limit := subtotal * int64(maxPercent) / 100; discount > limit {
+		return limit
+	}
+	return discount
+}
@@ -0,0 +1,31 @@
+// internal/domain/coupon/entity_test.go
+package coupon
+
+import "testing"
+
+func TestCapDiscount(t *testing.T) {
+	tests := []struct {
+		name       string
+		discount   int64
+		subtotal   int64
+		maxPercent int
+		want       int64
+	}{
+		{"under the cap", 2000, 10000, 50, 2000},
+		{"at the cap", 5000, 10000, 50, 5000},
+		{"over the cap", 7500, 10000, 50, 5000},
+		{"cap rounds down to whole cents", 5000, 999, 50, 499},
+		{"zero disables the cap", 7500, 10000, 0, 7500},
+		{"negative disables the cap", 7500, 10000, -10, 7500},
+		{"full cap allows the whole subtotal", 10000, 10000, 100, 10000},
+		{"empty subtotal", 500, 0, 50, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CapDiscount(tt.discount, tt.subtotal, tt.maxPercent); got != tt.want {
+				t.Errorf("CapDiscount(%d, %d, %d) = %d, want %d", tt.discount, tt.subtotal, tt.maxPercent, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// internal/domain/coupon/repository.go
+package coupon
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository provides database access for coupons and their redemptions
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new coupon repository
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
+// FindByID loads a coupon by ID
+func (r *Repository) FindByID(id uint) (*Coupon, error) {
+	var c Coupon
+	if err := r.db.First(&c, id).Error; err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// FindByCode loads a coupon by its code, ignoring case
+func (r *Repository) FindByCode(code string) (*Coupon, error) {
+	var c Coupon
+	if err := r.db.Where("code = ?", NormalizeCode(code)).First(&c).Error; err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// FindByCodeForUpdate loads a coupon by code and locks its row until the transaction ends
+func (r *Repository) FindByCodeForUpdate(code string) (*Coupon, error) {
+	var c Coupon
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", NormalizeCode(code)).First(&c).Error
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// List returns a page of coupons matching the filters, newest first
+func (r *Repository) List(req *ListRequest) ([]Coupon, int64, error) {
+	query := r.db.Model(&Coupon{})
+
+	if req.Search != "" {
+		search := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where("LOWER(code) LIKE ? OR LOWER(description) LIKE ?", search, search)
+	}
+	if req.IsActive != nil {
+		query = query.Where("is_active = ?", *req.IsActive)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var coupons []Coupon
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(req.Limit).Find(&coupons).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return coupons, total, nil
+}
+
+// Create inserts a new coupon
+func (r *Repository) Create(c *Coupon) error {
+	return r.db.Create(c).Error
+}
+
+// Save updates all fields of an existing coupon
+func (r *Repository) Save(c *Coupon) error {
+	return r.db.Save(c).Error
+}
+
+// Delete soft-deletes a coupon; its redemption history is kept
+func (r *Repository) Delete(id uint) error {
+	return r.db.Delete(&Coupon{}, id).Error
+}
+
+// CountRedemptions returns how many times the coupon has been redeemed, not counting
+// released redemptions
+func (r *Repository) CountRedemptions(couponID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&CouponRedemption{}).Where("coupon_id = ? AND released_at IS NULL", couponID).Count(&count).Error
+	return count, err
+}
+
+// CountUserRedemptions returns how many times a customer has redeemed the coupon, not
+// counting released redemptions
+func (r *Repository) CountUserRedemptions(couponID, userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&CouponRedemption{}).
+		Where("coupon_id = ? AND user_id = ? AND released_at IS NULL", couponID, userID).
+		Count(&count).Error
+	return count, err
+}
+
+// RedemptionCounts returns unreleased redemption counts keyed by coupon ID
+func (r *Repository) RedemptionCounts(couponIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(couponIDs))
+	if len(couponIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		CouponID uint
+		Count    int64
+	}
+	err := r.db.Model(&CouponRedemption{}).
+		Select("coupon_id, COUNT(*) as count").
+		Where("coupon_id IN ? AND released_at IS NULL", couponIDs).
+		Group("coupon_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.CouponID] = row.Count
+	}
+	return counts, nil
+}
+
+// CreateRedemption records a coupon redemption
+func (r *Repository) CreateRedemption(redemption *CouponRedemption) error {
+	return r.db.Create(redemption).Error
+}
+
+// ReleaseOrderRedemptions marks the order's unreleased redemptions as released,
+// returning how many were released
+func (r *Repository) ReleaseOrderRedemptions(orderID uint, releasedAt time.Time) (int64, error) {
+	result := r.db.Model(&CouponRedemption{}).
+		Where("order_id = ? AND released_at IS NULL", orderID).
+		Update("released_at", releasedAt)
+	return result.RowsAffected, result.Error
+}
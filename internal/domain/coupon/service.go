@@ -0,0 +1,405 @@
+// internal/domain/coupon/service.go
+package coupon
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"gorm.io/gorm"
+)
+
+// Coupon validation errors
+var (
+	ErrCouponNotFound         = errors.New("coupon not found")
+	ErrCouponInactive         = errors.New("coupon is not active")
+	ErrCouponNotStarted       = errors.New("coupon is not yet valid")
+	ErrCouponExpired          = errors.New("coupon has expired")
+	ErrCouponUsageExhausted   = errors.New("coupon usage limit reached")
+	ErrCouponUserLimitReached = errors.New("coupon already used the maximum number of times")
+	ErrCouponMinOrder         = errors.New("order does not meet coupon minimum")
+	ErrCouponCodeExists       = errors.New("coupon code already exists")
+)
+
+var couponCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,50}$`)
+
+// Service handles coupon business logic
+type Service struct {
+	db     *gorm.DB
+	repo   *Repository
+	config *config.Config
+}
+
+// NewService creates a new coupon service
+func NewService(db *gorm.DB, cfg *config.Config) *Service {
+	return &Service{
+		db:     db,
+		repo:   NewRepository(db),
+		config: cfg,
+	}
+}
+
+// ListRequest represents coupon list filters
+type ListRequest struct {
+	Page     int    `form:"page,default=1"`
+	Limit    int    `form:"limit,default=20"`
+	Search   string `form:"search"`
+	IsActive *bool  `form:"is_active"`
+}
+
+// ListResponse represents a page of coupons
+type ListResponse struct {
+	Coupons    []Coupon `json:"coupons"`
+	Total      int64    `json:"total"`
+	Page       int      `json:"page"`
+	Limit      int      `json:"limit"`
+	TotalPages int      `json:"total_pages"`
+}
+
+// CreateCouponRequest represents coupon creation data
+type CreateCouponRequest struct {
+	Code              string       `json:"code" binding:"required"`
+	Description       string       `json:"description"`
+	DiscountType      DiscountType `json:"discount_type" binding:"required"`
+	DiscountValue     float64      `json:"discount_value" binding:"required"`
+	MinOrderAmount    int64        `json:"min_order_amount"`
+	MaxDiscountAmount int64        `json:"max_discount_amount"`
+	UsageLimit        int          `json:"usage_limit"`
+	PerUserLimit      int          `json:"per_user_limit"`
+	ValidFrom         *time.Time   `json:"valid_from"`
+	ValidUntil        *time.Time   `json:"valid_until"`
+	IsActive          *bool        `json:"is_active"`
+}
+
+// UpdateCouponRequest represents coupon update data; omitted fields are left unchanged
+type UpdateCouponRequest struct {
+	Code              *string       `json:"code"`
+	Description       *string       `json:"description"`
+	DiscountType      *DiscountType `json:"discount_type"`
+	DiscountValue     *float64      `json:"discount_value"`
+	MinOrderAmount    *int64        `json:"min_order_amount"`
+	MaxDiscountAmount *int64        `json:"max_discount_amount"`
+	UsageLimit        *int          `json:"usage_limit"`
+	PerUserLimit      *int          `json:"per_user_limit"`
+	ValidFrom         *time.Time    `json:"valid_from"`
+	ValidUntil        *time.Time    `json:"valid_until"`
+	ClearValidFrom    bool          `json:"clear_valid_from"`
+	ClearValidUntil   bool          `json:"clear_valid_until"`
+	IsActive          *bool         `json:"is_active"`
+}
+
+// NormalizeCode returns the canonical (trimmed, upper-case) form of a coupon code
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ListCoupons retrieves coupons with their redemption counts
+func (s *Service) ListCoupons(req *ListRequest) (*ListResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 20
+	}
+
+	coupons, total, err := s.repo.List(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coupons: %w", err)
+	}
+
+	ids := make([]uint, len(coupons))
+	for i := range coupons {
+		ids[i] = coupons[i].ID
+	}
+	counts, err := s.repo.RedemptionCounts(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count coupon redemptions: %w", err)
+	}
+	for i := range coupons {
+		coupons[i].UsedCount = counts[coupons[i].ID]
+	}
+
+	return &ListResponse{
+		Coupons:    coupons,
+		Total:      total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+	}, nil
+}
+
+// GetCoupon retrieves a coupon by ID
+func (s *Service) GetCoupon(id uint) (*Coupon, error) {
+	c, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCouponNotFound
+		}
+		return nil, fmt.Errorf("failed to get coupon: %w", err)
+	}
+
+	if c.UsedCount, err = s.repo.CountRedemptions(c.ID); err != nil {
+		return nil, fmt.Errorf("failed to count coupon redemptions: %w", err)
+	}
+
+	return c, nil
+}
+
+// CreateCoupon creates a new coupon
+func (s *Service) CreateCoupon(req *CreateCouponRequest, adminID uint) (*Coupon, error) {
+	c := &Coupon{
+		Code:              NormalizeCode(req.Code),
+		Description:       strings.TrimSpace(req.Description),
+		DiscountType:      req.DiscountType,
+		DiscountValue:     req.DiscountValue,
+		MinOrderAmount:    req.MinOrderAmount,
+		MaxDiscountAmount: req.MaxDiscountAmount,
+		UsageLimit:        req.UsageLimit,
+		PerUserLimit:      req.PerUserLimit,
+		ValidFrom:         req.ValidFrom,
+		ValidUntil:        req.ValidUntil,
+		IsActive:          true,
+		CreatedBy:         adminID,
+	}
+	if req.IsActive != nil {
+		c.IsActive = *req.IsActive
+	}
+
+	if err := validateCoupon(c); err != nil {
+		return nil, err
+	}
+	if err := s.ensureCodeAvailable(c.Code, 0); err != nil {
+		return nil, err
+	}
+
+	// GORM skips zero values on create and reads the column default back into the
+	// struct, so an inactive coupon needs an explicit update
+	isActive := c.IsActive
+	if err := s.repo.Create(c); err != nil {
+		return nil, fmt.Errorf("failed to create coupon: %w", err)
+	}
+	if !isActive {
+		if err := s.db.Model(c).Update("is_active", false).Error; err != nil {
+			return nil, fmt.Errorf("failed to create coupon: %w", err)
+		}
+	}
+
+	return c, nil
+}
+
+// UpdateCoupon updates an existing coupon
+func (s *Service) UpdateCoupon(id uint, req *UpdateCouponRequest) (*Coupon, error) {
+	c, err := s.GetCoupon(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Code != nil {
+		c.Code = NormalizeCode(*req.Code)
+	}
+	if req.Description != nil {
+		c.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.DiscountType != nil {
+		c.DiscountType = *req.DiscountType
+	}
+	if req.DiscountValue != nil {
+		c.DiscountValue = *req.DiscountValue
+	}
+	if req.MinOrderAmount != nil {
+		c.MinOrderAmount = *req.MinOrderAmount
+	}
+	if req.MaxDiscountAmount != nil {
+		c.MaxDiscountAmount = *req.MaxDiscountAmount
+	}
+	if req.UsageLimit != nil {
+		c.UsageLimit = *req.UsageLimit
+	}
+	if req.PerUserLimit != nil {
+		c.PerUserLimit = *req.PerUserLimit
+	}
+	if req.ClearValidFrom {
+		c.ValidFrom = nil
+	} else if req.ValidFrom != nil {
+		c.ValidFrom = req.ValidFrom
+	}
+	if req.ClearValidUntil {
+		c.ValidUntil = nil
+	} else if req.ValidUntil != nil {
+		c.ValidUntil = req.ValidUntil
+	}
+	if req.IsActive != nil {
+		c.IsActive = *req.IsActive
+	}
+
+	if err := validateCoupon(c); err != nil {
+		return nil, err
+	}
+	if err := s.ensureCodeAvailable(c.Code, c.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(c); err != nil {
+		return nil, fmt.Errorf("failed to update coupon: %w", err)
+	}
+
+	return c, nil
+}
+
+// DeleteCoupon soft-deletes a coupon
+func (s *Service) DeleteCoupon(id uint) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCouponNotFound
+		}
+		return fmt.Errorf("failed to get coupon: %w", err)
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete coupon: %w", err)
+	}
+	return nil
+}
+
+// Validate checks that a coupon code can be used by the customer on an order with the
+// given subtotal and returns the coupon together with the discount it gives
+func (s *Service) Validate(code string, userID uint, subtotal int64) (*Coupon, int64, error) {
+	c, err := s.repo.FindByCode(code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, ErrCouponNotFound
+		}
+		return nil, 0, fmt.Errorf("failed to get coupon: %w", err)
+	}
+
+	discount, err := checkUsable(s.repo, c, userID, subtotal)
+	return c, discount, err
+}
+
+// Redeem validates the coupon and records a redemption within the caller's transaction.
+// The coupon row is locked for the rest of the transaction so concurrent orders cannot
+// both take the last use; the caller must roll back if anything later fails.
+func (s *Service) Redeem(tx *gorm.DB, code string, userID uint, subtotal int64) (*CouponRedemption, error) {
+	repo := s.repo.WithTx(tx)
+
+	c, err := repo.FindByCodeForUpdate(code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCouponNotFound
+		}
+		return nil, fmt.Errorf("failed to lock coupon: %w", err)
+	}
+
+	discount, err := checkUsable(repo, c, userID, subtotal)
+	if err != nil {
+		return nil, err
+	}
+
+	redemption := &CouponRedemption{
+		CouponID:       c.ID,
+		UserID:         userID,
+		DiscountAmount: discount,
+	}
+	if err := repo.CreateRedemption(redemption); err != nil {
+		return nil, fmt.Errorf("failed to record coupon redemption: %w", err)
+	}
+
+	return redemption, nil
+}
+
+// ReleaseOrder releases the order's coupon redemption within the caller's transaction,
+// giving the use back to the coupon and the customer. It is a no-op for orders without
+// a coupon or whose redemption was already released.
+func (s *Service) ReleaseOrder(tx *gorm.DB, orderID uint) error {
+	if _, err := s.repo.WithTx(tx).ReleaseOrderRedemptions(orderID, time.Now()); err != nil {
+		return fmt.Errorf("failed to release coupon redemption: %w", err)
+	}
+	return nil
+}
+
+// checkUsable runs the coupon rules for a customer and subtotal, returning the discount
+func checkUsable(repo *Repository, c *Coupon, userID uint, subtotal int64) (int64, error) {
+	if !c.IsActive {
+		return 0, ErrCouponInactive
+	}
+
+	now := time.Now()
+	if c.ValidFrom != nil && now.Before(*c.ValidFrom) {
+		return 0, ErrCouponNotStarted
+	}
+	if c.ValidUntil != nil && now.After(*c.ValidUntil) {
+		return 0, ErrCouponExpired
+	}
+
+	if subtotal < c.MinOrderAmount {
+		return 0, fmt.Errorf("%w: minimum order amount is %d", ErrCouponMinOrder, c.MinOrderAmount)
+	}
+
+	var err error
+	if c.UsedCount, err = repo.CountRedemptions(c.ID); err != nil {
+		return 0, fmt.Errorf("failed to count coupon redemptions: %w", err)
+	}
+	if c.UsageLimit > 0 && c.UsedCount >= int64(c.UsageLimit) {
+		return 0, ErrCouponUsageExhausted
+	}
+
+	if c.PerUserLimit > 0 {
+		used, err := repo.CountUserRedemptions(c.ID, userID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count coupon redemptions: %w", err)
+		}
+		if used >= int64(c.PerUserLimit) {
+			return 0, ErrCouponUserLimitReached
+		}
+	}
+
+	return c.DiscountFor(subtotal), nil
+}
+
+// ensureCodeAvailable checks that no other coupon (including deleted ones) uses the code
+func (s *Service) ensureCodeAvailable(code string, excludeID uint) error {
+	var count int64
+	query := s.db.Unscoped().Model(&Coupon{}).Where("code = ?", code)
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check coupon code: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %s", ErrCouponCodeExists, code)
+	}
+	return nil
+}
+
+// validateCoupon checks coupon fields for consistency
+func validateCoupon(c *Coupon) error {
+	if !couponCodePattern.MatchString(c.Code) {
+		return fmt.Errorf("coupon code must be 3-50 characters of letters, digits, '-' or '_'")
+	}
+	if !IsValidDiscountType(c.DiscountType) {
+		return fmt.Errorf("invalid discount type: %s", c.DiscountType)
+	}
+	if c.DiscountValue <= 0 {
+		return fmt.Errorf("discount value must be greater than zero")
+	}
+	if c.DiscountType == DiscountTypePercentage && c.DiscountValue > 100 {
+		return fmt.Errorf("percentage discount cannot exceed 100")
+	}
+	if c.MinOrderAmount < 0 || c.MaxDiscountAmount < 0 {
+		return fmt.Errorf("amounts cannot be negative")
+	}
+	if c.UsageLimit < 0 || c.PerUserLimit < 0 {
+		return fmt.Errorf("usage limits cannot be negative")
+	}
+	if c.UsageLimit > 0 && c.PerUserLimit > c.UsageLimit {
+		return fmt.Errorf("per-user limit cannot exceed the total usage limit")
+	}
+	if c.ValidFrom != nil && c.ValidUntil != nil && !c.ValidUntil.After(*c.ValidFrom) {
+		return fmt.Errorf("valid_until must be after valid_from")
+	}
+	return nil
+}
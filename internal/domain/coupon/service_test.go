@@ -0,0 +1,128 @@
+// internal/domain/coupon/service_test.go
+package coupon
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+func TestCheckUsable(t *testing.T) {
+	const customer, otherCustomer = uint(1), uint(2)
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	released := time.Now()
+
+	tests := []struct {
+		name        string
+		coupon      Coupon
+		inactive    bool
+		redemptions []CouponRedemption
+		want        int64
+		wantErr     error
+	}{
+		{
+			name:   "usable",
+			coupon: Coupon{UsageLimit: 2, PerUserLimit: 1},
+			want:   1000,
+		},
+		{
+			name:     "inactive",
+			inactive: true,
+			wantErr:  ErrCouponInactive,
+		},
+		{
+			name:    "not started",
+			coupon:  Coupon{ValidFrom: &future},
+			wantErr: ErrCouponNotStarted,
+		},
+		{
+			name:    "expired",
+			coupon:  Coupon{ValidUntil: &past},
+			wantErr: ErrCouponExpired,
+		},
+		{
+			name:    "below the minimum order",
+			coupon:  Coupon{MinOrderAmount: 20000},
+			wantErr: ErrCouponMinOrder,
+		},
+		{
+			name:        "usage exhausted",
+			coupon:      Coupon{UsageLimit: 2},
+			redemptions: []CouponRedemption{{UserID: otherCustomer}, {UserID: otherCustomer}},
+			wantErr:     ErrCouponUsageExhausted,
+		},
+		{
+			name:        "released redemptions don't count towards usage",
+			coupon:      Coupon{UsageLimit: 1},
+			redemptions: []CouponRedemption{{UserID: otherCustomer, ReleasedAt: &released}},
+			want:        1000,
+		},
+		{
+			name:        "per-customer limit reached",
+			coupon:      Coupon{UsageLimit: 5, PerUserLimit: 1},
+			redemptions: []CouponRedemption{{UserID: customer}},
+			wantErr:     ErrCouponUserLimitReached,
+		},
+		{
+			name:        "other customers' uses don't count towards the per-customer limit",
+			coupon:      Coupon{PerUserLimit: 1},
+			redemptions: []CouponRedemption{{UserID: otherCustomer}},
+			want:        1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testutil.NewDB(t, &Coupon{}, &CouponRedemption{})
+			c := tt.coupon
+			c.Code = "SAVE10"
+			c.DiscountType = DiscountTypeFixedAmount
+			c.DiscountValue = 1000
+			if err := db.Create(&c).Error; err != nil {
+				t.Fatal(err)
+			}
+			if tt.inactive {
+				// The column defaults to true, so false has to be set explicitly
+				db.Model(&c).Update("is_active", false)
+			}
+			for _, r := range tt.redemptions {
+				r.CouponID = c.ID
+				r.DiscountAmount = 1000
+				db.Create(&r)
+			}
+
+			got, err := checkUsable(NewRepository(db), &c, customer, 10000)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkUsable() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("checkUsable() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateCouponInactive(t *testing.T) {
+	db := testutil.NewDB(t, &Coupon{}, &CouponRedemption{})
+	s := NewService(db, &config.Config{})
+	inactive := false
+
+	c, err := s.CreateCoupon(&CreateCouponRequest{Code: "later10", DiscountType: DiscountTypeFixedAmount,
+		DiscountValue: 1000, IsActive: &inactive}, 1)
+	if err != nil {
+		t.Fatalf("CreateCoupon() error = %v", err)
+	}
+
+	var stored Coupon
+	db.First(&stored, c.ID)
+	if c.IsActive || stored.IsActive {
+		t.Errorf("coupon active = %v, stored %v, want inactive", c.IsActive, stored.IsActive)
+	}
+	if _, err := checkUsable(NewRepository(db), &stored, 1, 10000); !errors.Is(err, ErrCouponInactive) {
+		t.Errorf("checkUsable() error = %v, want %v", err, ErrCouponInactive)
+	}
+}
@@ -0,0 +1,189 @@
+// internal/domain/customer/service.go
+package customer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/cart"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"github.com/your-org/ecommerce-backend/internal/domain/setting"
+	"github.com/your-org/ecommerce-backend/internal/domain/user"
+	"github.com/your-org/ecommerce-backend/internal/domain/wishlist"
+	"gorm.io/gorm"
+)
+
+// ErrCustomerNotFound is returned when the customer does not exist
+var ErrCustomerNotFound = errors.New("customer not found")
+
+const (
+	recentOrdersLimit  = 5
+	recentReviewsLimit = 5
+)
+
+// Service builds consolidated customer views for support agents
+type Service struct {
+	db              *gorm.DB
+	config          *config.Config
+	adminService    *user.AdminService
+	addressService  *user.AddressService
+	orderService    *order.Service
+	reviewService   *product.ReviewService
+	wishlistService *wishlist.Service
+}
+
+// NewService creates a new customer service
+func NewService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *Service {
+	cartService := cart.NewService(db, redisClient, cfg)
+
+	return &Service{
+		db:              db,
+		config:          cfg,
+		adminService:    user.NewAdminService(db, redisClient, cfg),
+		addressService:  user.NewAddressService(db, cfg),
+		orderService:    order.NewService(db, cfg, cartService, setting.NewService(db, redisClient, cfg)),
+		reviewService:   product.NewReviewService(db, redisClient, cfg),
+		wishlistService: wishlist.NewService(db, redisClient, cfg),
+	}
+}
+
+// Profile is everything support needs to know about a customer in one payload
+type Profile struct {
+	Customer  *user.UserWithStats `json:"customer"`
+	Addresses []user.Address      `json:"addresses"`
+	Orders    OrderSummary        `json:"orders"`
+	Reviews   ReviewSummary       `json:"reviews"`
+	Wishlist  WishlistSummary     `json:"wishlist"`
+}
+
+// OrderSummary summarizes a customer's order history
+type OrderSummary struct {
+	TotalOrders   int64                       `json:"total_orders"`
+	StatusCounts  map[order.OrderStatus]int64 `json:"status_counts"`
+	TotalSpent    int64                       `json:"total_spent"`    // Paid orders, in cents
+	TotalRefunded int64                       `json:"total_refunded"` // Refunded orders, in cents
+	Recent        []order.Order               `json:"recent"`
+}
+
+// ReviewSummary summarizes the reviews a customer has written
+type ReviewSummary struct {
+	Total   int64                    `json:"total"`
+	Pending int64                    `json:"pending"` // Awaiting moderation
+	Recent  []product.ReviewResponse `json:"recent"`
+}
+
+// WishlistSummary summarizes a customer's wishlist
+type WishlistSummary struct {
+	ItemCount int64 `json:"item_count"`
+}
+
+// GetProfile returns the consolidated profile of a customer. adminID is the agent
+// viewing it, so reviews still awaiting moderation are included.
+func (s *Service) GetProfile(customerID, adminID uint) (*Profile, error) {
+	customer, err := s.adminService.GetUser(customerID)
+	if err != nil {
+		return nil, ErrCustomerNotFound
+	}
+	customer.Addresses = nil // Returned in their own section
+
+	addresses, err := s.addressService.GetUserAddresses(customerID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.getOrderSummary(customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.getReviewSummary(customerID, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	wishlistCount, err := s.wishlistService.GetWishlistCount(customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count wishlist items: %w", err)
+	}
+
+	return &Profile{
+		Customer:  customer,
+		Addresses: addresses,
+		Orders:    *orders,
+		Reviews:   *reviews,
+		Wishlist:  WishlistSummary{ItemCount: wishlistCount},
+	}, nil
+}
+
+// getOrderSummary counts a customer's orders by status and totals their spend
+func (s *Service) getOrderSummary(customerID uint) (*OrderSummary, error) {
+	summary := &OrderSummary{
+		StatusCounts: make(map[order.OrderStatus]int64),
+	}
+
+	var rows []struct {
+		Status order.OrderStatus
+		Count  int64
+	}
+	if err := s.db.Model(&order.Order{}).
+		Select("status, COUNT(*) AS count").
+		Where("user_id = ?", customerID).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count orders: %w", err)
+	}
+	for _, row := range rows {
+		summary.StatusCounts[row.Status] = row.Count
+		summary.TotalOrders += row.Count
+	}
+
+	var totals struct {
+		Spent    int64
+		Refunded int64
+	}
+	if err := s.db.Model(&order.Order{}).
+		Select("COALESCE(SUM(CASE WHEN payment_status = ? THEN total_amount ELSE 0 END), 0) AS spent, "+
+			"COALESCE(SUM(CASE WHEN payment_status = ? THEN total_amount ELSE 0 END), 0) AS refunded",
+			order.PaymentStatusPaid, order.PaymentStatusRefunded).
+		Where("user_id = ?", customerID).
+		Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("failed to total order spend: %w", err)
+	}
+	summary.TotalSpent = totals.Spent
+	summary.TotalRefunded = totals.Refunded
+
+	recent, err := s.orderService.GetUserOrders(customerID, 1, recentOrdersLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent orders: %w", err)
+	}
+	summary.Recent = recent.Orders
+
+	return summary, nil
+}
+
+// getReviewSummary counts a customer's reviews and returns the most recent ones
+func (s *Service) getReviewSummary(customerID, adminID uint) (*ReviewSummary, error) {
+	reviews, err := s.reviewService.GetReviews(&product.ReviewListRequest{
+		UserID: &customerID,
+		Limit:  recentReviewsLimit,
+	}, &adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+
+	var pending int64
+	if err := s.db.Model(&product.ProductReview{}).
+		Where("user_id = ? AND is_approved = ?", customerID, false).
+		Count(&pending).Error; err != nil {
+		return nil, fmt.Errorf("failed to count pending reviews: %w", err)
+	}
+
+	return &ReviewSummary{
+		Total:   reviews.Pagination.Total,
+		Pending: pending,
+		Recent:  reviews.Reviews,
+	}, nil
+}
@@ -0,0 +1,38 @@
+// internal/domain/download/entity.go
+package download
+
+import (
+	"time"
+)
+
+// DigitalDownload is a tokenized, time-limited download link for a digital item
+// in a paid order
+type DigitalDownload struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	OrderID          uint       `gorm:"not null;index" json:"order_id"`
+	OrderItemID      uint       `gorm:"not null;uniqueIndex" json:"order_item_id"`
+	ProductID        uint       `gorm:"not null;index" json:"product_id"`
+	UserID           *uint      `gorm:"index" json:"user_id"`
+	Token            string     `gorm:"not null;uniqueIndex;size:64" json:"-"`
+	ExpiresAt        time.Time  `gorm:"not null" json:"expires_at"`
+	DownloadCount    int        `gorm:"default:0" json:"download_count"`
+	MaxDownloads     int        `gorm:"default:0" json:"max_downloads"` // 0 means unlimited
+	LastDownloadedAt *time.Time `json:"last_downloaded_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (DigitalDownload) TableName() string {
+	return "digital_downloads"
+}
+
+// IsExpired reports whether the link has passed its expiry time
+func (d *DigitalDownload) IsExpired() bool {
+	return time.Now().UTC().After(d.ExpiresAt)
+}
+
+// LimitReached reports whether the link has used all of its downloads
+func (d *DigitalDownload) LimitReached() bool {
+	return d.MaxDownloads > 0 && d.DownloadCount >= d.MaxDownloads
+}
@@ -0,0 +1,306 @@
+// internal/domain/download/service.go
+package download
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDownloadNotFound     = errors.New("download link not found")
+	ErrDownloadExpired      = errors.New("download link has expired")
+	ErrDownloadLimitReached = errors.New("download limit reached")
+	ErrOrderNotFound        = errors.New("order not found")
+	ErrOrderNotPaid         = errors.New("order has not been paid")
+)
+
+// Service issues and resolves download links for digital products
+type Service struct {
+	db     *gorm.DB
+	config *config.Config
+}
+
+// NewService creates a new download service
+func NewService(db *gorm.DB, cfg *config.Config) *Service {
+	return &Service{
+		db:     db,
+		config: cfg,
+	}
+}
+
+// DownloadLink represents a customer-facing download link for an order item
+type DownloadLink struct {
+	OrderItemID        uint      `json:"order_item_id"`
+	ProductID          uint      `json:"product_id"`
+	Name               string    `json:"name"`
+	URL                string    `json:"url"`
+	ExpiresAt          time.Time `json:"expires_at"`
+	DownloadCount      int       `json:"download_count"`
+	DownloadsRemaining *int      `json:"downloads_remaining"` // nil when unlimited
+	Expired            bool      `json:"expired"`
+}
+
+// DownloadFile is the file a download token resolves to
+type DownloadFile struct {
+	Source   string // Absolute URL or storage-relative path
+	FileName string
+}
+
+// digitalItem is an order item for a digital product with a downloadable file
+type digitalItem struct {
+	OrderItemID    uint
+	ProductID      uint
+	Name           string
+	DigitalFileURL string
+}
+
+// GenerateForOrder issues download links for the digital items of an order. It is
+// safe to call more than once; items that already have a link keep it.
+func (s *Service) GenerateForOrder(orderID uint) ([]DownloadLink, error) {
+	var orderRecord order.Order
+	if err := s.db.Select("id, user_id").Where("id = ?", orderID).First(&orderRecord).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	items, err := s.digitalItems(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return []DownloadLink{}, nil
+	}
+
+	existing, err := s.downloadsByItem(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if _, ok := existing[item.OrderItemID]; ok {
+			continue
+		}
+
+		token, err := generateToken()
+		if err != nil {
+			return nil, err
+		}
+
+		record := DigitalDownload{
+			OrderID:      orderID,
+			OrderItemID:  item.OrderItemID,
+			ProductID:    item.ProductID,
+			UserID:       orderRecord.UserID,
+			Token:        token,
+			ExpiresAt:    time.Now().UTC().Add(s.config.Download.LinkTTL),
+			MaxDownloads: s.config.Download.MaxDownloads,
+		}
+		if err := s.db.Create(&record).Error; err != nil {
+			return nil, fmt.Errorf("failed to create download link: %w", err)
+		}
+		existing[item.OrderItemID] = &record
+	}
+
+	return s.buildLinks(items, existing), nil
+}
+
+// GetOrderDownloads returns the download links for a customer's paid order, issuing
+// any that are missing and renewing links that have expired
+func (s *Service) GetOrderDownloads(userID, orderID uint) ([]DownloadLink, error) {
+	var orderRecord order.Order
+	if err := s.db.Select("id, user_id, payment_status").Where("id = ? AND user_id = ?", orderID, userID).First(&orderRecord).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if orderRecord.PaymentStatus != order.PaymentStatusPaid {
+		return nil, ErrOrderNotPaid
+	}
+
+	if _, err := s.GenerateForOrder(orderID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.digitalItems(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.downloadsByItem(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The owner can always get a fresh link; the download count carries over so
+	// renewing does not reset the limit
+	for _, record := range existing {
+		if !record.IsExpired() {
+			continue
+		}
+
+		token, err := generateToken()
+		if err != nil {
+			return nil, err
+		}
+
+		record.Token = token
+		record.ExpiresAt = time.Now().UTC().Add(s.config.Download.LinkTTL)
+		err = s.db.Model(record).Updates(map[string]interface{}{
+			"token":      record.Token,
+			"expires_at": record.ExpiresAt,
+		}).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to renew download link: %w", err)
+		}
+	}
+
+	return s.buildLinks(items, existing), nil
+}
+
+// ResolveDownload validates a download token, counts the download and returns the
+// file it points to
+func (s *Service) ResolveDownload(token string) (*DownloadFile, error) {
+	var record DigitalDownload
+	if err := s.db.Where("token = ?", token).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDownloadNotFound
+		}
+		return nil, fmt.Errorf("failed to get download link: %w", err)
+	}
+
+	if record.IsExpired() {
+		return nil, ErrDownloadExpired
+	}
+
+	var item digitalItem
+	err := s.db.Table("order_items").
+		Select("order_items.id AS order_item_id, order_items.product_id, order_items.name, products.digital_file_url").
+		Joins("JOIN products ON products.id = order_items.product_id").
+		Where("order_items.id = ?", record.OrderItemID).
+		Scan(&item).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get download file: %w", err)
+	}
+	if item.DigitalFileURL == "" {
+		return nil, ErrDownloadNotFound
+	}
+
+	// Count the download atomically so concurrent requests cannot exceed the limit
+	now := time.Now().UTC()
+	query := s.db.Model(&DigitalDownload{}).Where("id = ?", record.ID)
+	if record.MaxDownloads > 0 {
+		query = query.Where("download_count < max_downloads")
+	}
+	result := query.Updates(map[string]interface{}{
+		"download_count":     gorm.Expr("download_count + 1"),
+		"last_downloaded_at": now,
+	})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to record download: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrDownloadLimitReached
+	}
+
+	return &DownloadFile{
+		Source:   item.DigitalFileURL,
+		FileName: fileName(item.DigitalFileURL, item.Name),
+	}, nil
+}
+
+// digitalItems returns the order's items for digital products that have a file
+func (s *Service) digitalItems(orderID uint) ([]digitalItem, error) {
+	var items []digitalItem
+	err := s.db.Table("order_items").
+		Select("order_items.id AS order_item_id, order_items.product_id, order_items.name, products.digital_file_url").
+		Joins("JOIN products ON products.id = order_items.product_id").
+		Where("order_items.order_id = ? AND products.is_digital = ? AND products.digital_file_url <> ''", orderID, true).
+		Order("order_items.id ASC").
+		Scan(&items).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digital items: %w", err)
+	}
+	return items, nil
+}
+
+// downloadsByItem returns the order's download links keyed by order item
+func (s *Service) downloadsByItem(orderID uint) (map[uint]*DigitalDownload, error) {
+	var records []DigitalDownload
+	if err := s.db.Where("order_id = ?", orderID).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to get download links: %w", err)
+	}
+
+	byItem := make(map[uint]*DigitalDownload, len(records))
+	for i := range records {
+		byItem[records[i].OrderItemID] = &records[i]
+	}
+	return byItem, nil
+}
+
+// buildLinks formats download links in order item order
+func (s *Service) buildLinks(items []digitalItem, byItem map[uint]*DigitalDownload) []DownloadLink {
+	links := make([]DownloadLink, 0, len(items))
+	for _, item := range items {
+		record, ok := byItem[item.OrderItemID]
+		if !ok {
+			continue
+		}
+
+		link := DownloadLink{
+			OrderItemID:   item.OrderItemID,
+			ProductID:     item.ProductID,
+			Name:          item.Name,
+			URL:           s.downloadURL(record.Token),
+			ExpiresAt:     record.ExpiresAt,
+			DownloadCount: record.DownloadCount,
+			Expired:       record.IsExpired(),
+		}
+		if record.MaxDownloads > 0 {
+			remaining := record.MaxDownloads - record.DownloadCount
+			if remaining < 0 {
+				remaining = 0
+			}
+			link.DownloadsRemaining = &remaining
+		}
+		links = append(links, link)
+	}
+	return links
+}
+
+// downloadURL returns the public URL for a download token
+func (s *Service) downloadURL(token string) string {
+	return fmt.Sprintf("%s/downloads/%s", strings.TrimRight(s.config.Download.BaseURL, "/"), token)
+}
+
+// generateToken returns a random, URL-safe download token
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate download token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// fileName picks the attachment name for a download, falling back to the item name
+func fileName(source, itemName string) string {
+	source = strings.SplitN(source, "?", 2)[0]
+	if i := strings.LastIndex(source, "/"); i >= 0 {
+		source = source[i+1:]
+	}
+	if source != "" {
+		return source
+	}
+	return itemName
+}
@@ -0,0 +1,155 @@
+// internal/domain/download/service_test.go
+package download
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+	"gorm.io/gorm"
+)
+
+const testUserID = uint(7)
+
+func newTestService(t *testing.T) (*Service, *gorm.DB) {
+	t.Helper()
+	db := testutil.NewDB(t, &product.Category{}, &product.Brand{}, &product.Product{}, &product.ProductVariant{},
+		&order.Order{}, &order.OrderItem{}, &DigitalDownload{})
+	cfg := &config.Config{}
+	cfg.Download.BaseURL = "https://shop.example.com/api/v1/"
+	cfg.Download.LinkTTL = 72 * time.Hour
+	cfg.Download.MaxDownloads = 2
+	return NewService(db, cfg), db
+}
+
+// createPaidOrder creates a paid order for an e-book and a printed copy of it
+func createPaidOrder(t *testing.T, db *gorm.DB) *order.Order {
+	t.Helper()
+	category := product.Category{Name: "Books", Slug: "books"}
+	db.Create(&category)
+	ebook := product.Product{SKU: "BOOK-PDF", Name: "Field Guide (PDF)", Slug: "field-guide-pdf", Price: 900,
+		CategoryID: category.ID, IsActive: true, IsDigital: true, DigitalFileURL: "books/field-guide.pdf?v=2"}
+	printed := product.Product{SKU: "BOOK-1", Name: "Field Guide", Slug: "field-guide", Price: 2500,
+		CategoryID: category.ID, IsActive: true}
+	for _, p := range []*product.Product{&ebook, &printed} {
+		if err := db.Create(p).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	userID := testUserID
+	ord := &order.Order{OrderNumber: "ORD-1", UserID: &userID, Email: "reader@example.com",
+		Status: order.OrderStatusConfirmed, PaymentStatus: order.PaymentStatusPaid, TotalAmount: 3400,
+		Items: []order.OrderItem{
+			{ProductID: printed.ID, SKU: printed.SKU, Name: printed.Name, Price: 2500, Quantity: 1, TotalPrice: 2500},
+			{ProductID: ebook.ID, SKU: ebook.SKU, Name: ebook.Name, Price: 900, Quantity: 1, TotalPrice: 900},
+		}}
+	if err := db.Create(ord).Error; err != nil {
+		t.Fatal(err)
+	}
+	return ord
+}
+
+func tokenFromURL(url string) string {
+	return url[strings.LastIndex(url, "/")+1:]
+}
+
+func TestGenerateForOrder(t *testing.T) {
+	s, db := newTestService(t)
+	ord := createPaidOrder(t, db)
+
+	links, err := s.GenerateForOrder(ord.ID)
+	if err != nil {
+		t.Fatalf("GenerateForOrder() error = %v", err)
+	}
+	if len(links) != 1 {
+		t.Fatalf("links = %+v, want one for the e-book", links)
+	}
+
+	link := links[0]
+	token := tokenFromURL(link.URL)
+	if link.OrderItemID != ord.Items[1].ID || link.URL != "https://shop.example.com/api/v1/downloads/"+token || len(token) != 64 {
+		t.Errorf("link = %+v, want a tokenized link for item %d", link, ord.Items[1].ID)
+	}
+	if until := time.Until(link.ExpiresAt); until < 71*time.Hour || until > 72*time.Hour {
+		t.Errorf("link expires in %v, want 72h", until)
+	}
+	if link.Expired || link.DownloadsRemaining == nil || *link.DownloadsRemaining != 2 {
+		t.Errorf("link = %+v, want 2 downloads remaining", link)
+	}
+
+	// Payment notifications can arrive more than once
+	again, err := s.GenerateForOrder(ord.ID)
+	if err != nil {
+		t.Fatalf("second GenerateForOrder() error = %v", err)
+	}
+	if len(again) != 1 || again[0].URL != link.URL {
+		t.Errorf("second GenerateForOrder() = %+v, want the same link", again)
+	}
+}
+
+func TestResolveDownload(t *testing.T) {
+	s, db := newTestService(t)
+	ord := createPaidOrder(t, db)
+	links, _ := s.GenerateForOrder(ord.ID)
+	token := tokenFromURL(links[0].URL)
+
+	file, err := s.ResolveDownload(token)
+	if err != nil {
+		t.Fatalf("ResolveDownload() error = %v", err)
+	}
+	if file.Source != "books/field-guide.pdf?v=2" || file.FileName != "field-guide.pdf" {
+		t.Errorf("file = %+v, want the e-book's file", file)
+	}
+
+	if _, err := s.ResolveDownload(token); err != nil {
+		t.Fatalf("second ResolveDownload() error = %v", err)
+	}
+	if _, err := s.ResolveDownload(token); !errors.Is(err, ErrDownloadLimitReached) {
+		t.Errorf("third ResolveDownload() error = %v, want %v", err, ErrDownloadLimitReached)
+	}
+	if _, err := s.ResolveDownload(strings.Repeat("0", 64)); !errors.Is(err, ErrDownloadNotFound) {
+		t.Errorf("ResolveDownload(unknown) error = %v, want %v", err, ErrDownloadNotFound)
+	}
+}
+
+func TestExpiredDownloadLink(t *testing.T) {
+	s, db := newTestService(t)
+	ord := createPaidOrder(t, db)
+	links, _ := s.GenerateForOrder(ord.ID)
+	expiredToken := tokenFromURL(links[0].URL)
+	s.ResolveDownload(expiredToken)
+	db.Model(&DigitalDownload{}).Where("token = ?", expiredToken).Update("expires_at", time.Now().UTC().Add(-time.Minute))
+
+	if _, err := s.ResolveDownload(expiredToken); !errors.Is(err, ErrDownloadExpired) {
+		t.Fatalf("ResolveDownload(expired) error = %v, want %v", err, ErrDownloadExpired)
+	}
+
+	// The customer's order page renews the link without resetting the limit
+	renewed, err := s.GetOrderDownloads(testUserID, ord.ID)
+	if err != nil {
+		t.Fatalf("GetOrderDownloads() error = %v", err)
+	}
+	if len(renewed) != 1 || renewed[0].Expired || tokenFromURL(renewed[0].URL) == expiredToken {
+		t.Fatalf("renewed = %+v, want a new link", renewed)
+	}
+	if renewed[0].DownloadCount != 1 || *renewed[0].DownloadsRemaining != 1 {
+		t.Errorf("renewed link = %d downloads, %d remaining, want 1 and 1", renewed[0].DownloadCount, *renewed[0].DownloadsRemaining)
+	}
+	if _, err := s.ResolveDownload(expiredToken); !errors.Is(err, ErrDownloadNotFound) {
+		t.Errorf("ResolveDownload(old token) error = %v, want %v", err, ErrDownloadNotFound)
+	}
+	if _, err := s.ResolveDownload(tokenFromURL(renewed[0].URL)); err != nil {
+		t.Errorf("ResolveDownload(renewed) error = %v", err)
+	}
+
+	// Other customers can't renew it
+	if _, err := s.GetOrderDownloads(testUserID+1, ord.ID); !errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("GetOrderDownloads(other user) error = %v, want %v", err, ErrOrderNotFound)
+	}
+}
@@ -69,8 +69,10 @@ type InventoryItem struct {
 	SKU               string          `gorm:"not null;size:100;index" json:"sku"`
 	Quantity          int             `gorm:"default:0" json:"quantity"`
 	ReservedQuantity  int             `gorm:"default:0" json:"reserved_quantity"`
-	AvailableQuantity int             `gorm:"default:0;index" json:"available_quantity"`
+	SafetyStock       int             `gorm:"default:0" json:"safety_stock"`             // Buffer held back from sale
+	AvailableQuantity int             `gorm:"default:0;index" json:"available_quantity"` // Quantity - Reserved - SafetyStock, never below zero
 	ReorderLevel      int             `gorm:"default:10" json:"reorder_level"`
+	ReorderTarget     int             `gorm:"default:0" json:"reorder_target"` // Stock level to replenish up to, 0 if unset
 	MaxStockLevel     int             `gorm:"default:1000" json:"max_stock_level"`
 	CostPrice         int64           `gorm:"default:0" json:"cost_price"` // In cents like your order system
 	Status            InventoryStatus `gorm:"default:'active'" json:"status"`
@@ -127,7 +129,7 @@ type StockReservation struct {
 	OrderID         uint      `gorm:"not null;index" json:"order_id"`
 	OrderItemID     uint      `gorm:"not null;index" json:"order_item_id"`
 	Quantity        int       `gorm:"not null" json:"quantity"`
-	Status          string    `gorm:"default:'active'" json:"status"` // active, fulfilled, cancelled
+	Status          string    `gorm:"default:'active'" json:"status"` // active, fulfilled, cancelled, expired
 	ExpiresAt       time.Time `json:"expires_at"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
@@ -140,16 +142,26 @@ type StockReservation struct {
 
 // BeforeCreate hook to calculate available quantity
 func (ii *InventoryItem) BeforeCreate(tx *gorm.DB) error {
-	ii.AvailableQuantity = ii.Quantity - ii.ReservedQuantity
+	ii.AvailableQuantity = ii.calculateAvailable()
 	return nil
 }
 
 // BeforeUpdate hook to calculate available quantity
 func (ii *InventoryItem) BeforeUpdate(tx *gorm.DB) error {
-	ii.AvailableQuantity = ii.Quantity - ii.ReservedQuantity
+	ii.AvailableQuantity = ii.calculateAvailable()
 	return nil
 }
 
+// calculateAvailable returns on-hand stock less reservations and the safety stock
+// buffer, never below zero
+func (ii *InventoryItem) calculateAvailable() int {
+	available := ii.Quantity - ii.ReservedQuantity - ii.SafetyStock
+	if available < 0 {
+		return 0
+	}
+	return available
+}
+
 // IsLowStock checks if inventory is below reorder level
 func (ii *InventoryItem) IsLowStock() bool {
 	return ii.AvailableQuantity <= ii.ReorderLevel
@@ -0,0 +1,132 @@
+// internal/domain/inventory/movement_history.go
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInventoryItemNotFound is returned when the product has no stock record in the warehouse
+var ErrInventoryItemNotFound = errors.New("inventory item not found")
+
+// MovementHistoryRequest represents movement history query parameters
+type MovementHistoryRequest struct {
+	Page      int    `form:"page,default=1"`
+	Limit     int    `form:"limit,default=50"`
+	DateFrom  string `form:"date_from"`              // YYYY-MM-DD
+	DateTo    string `form:"date_to"`                // YYYY-MM-DD, inclusive
+	SortOrder string `form:"sort_order,default=asc"` // asc or desc by time
+}
+
+// MovementHistoryEntry is one movement with its effect on on-hand stock. Delta is
+// derived from the movement type and quantity, so a running balance that differs
+// from NewQuantity points at stock changed outside recorded movements.
+type MovementHistoryEntry struct {
+	ID               uint           `json:"id"`
+	MovementType     MovementType   `json:"movement_type"`
+	Reason           MovementReason `json:"reason"`
+	Quantity         int            `json:"quantity"`
+	QuantityDelta    int            `json:"quantity_delta"` // Change to on-hand stock; reservations are 0
+	PreviousQuantity int            `json:"previous_quantity"`
+	NewQuantity      int            `json:"new_quantity"`
+	RunningBalance   int            `json:"running_balance"` // Opening stock plus all deltas up to this movement
+	ReferenceType    string         `json:"reference_type"`
+	ReferenceID      uint           `json:"reference_id"`
+	Notes            string         `json:"notes"`
+	CreatedBy        uint           `json:"created_by"`
+	CreatedAt        time.Time      `json:"created_at"`
+}
+
+// MovementHistoryResponse represents a page of an inventory item's movement history
+type MovementHistoryResponse struct {
+	InventoryItemID uint                   `json:"inventory_item_id"`
+	CurrentQuantity int                    `json:"current_quantity"`
+	Movements       []MovementHistoryEntry `json:"movements"`
+	Pagination      Pagination             `json:"pagination"`
+}
+
+// Pagination represents pagination information
+type Pagination struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+	HasNext    bool  `json:"has_next"`
+	HasPrev    bool  `json:"has_prev"`
+}
+
+// GetMovementHistory returns the movements of a product in a warehouse with a
+// running balance. The balance is computed over the item's full history, opening
+// with the stock before its first movement, so filtered pages stay consistent.
+func (s *Service) GetMovementHistory(productID, warehouseID uint, req *MovementHistoryRequest) (*MovementHistoryResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 200 {
+		req.Limit = 50
+	}
+	direction := "ASC"
+	if req.SortOrder == "desc" {
+		direction = "DESC"
+	}
+
+	var item InventoryItem
+	if err := s.db.Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).First(&item).Error; err != nil {
+		return nil, ErrInventoryItemNotFound
+	}
+
+	history := s.db.Table("inventory_movements").
+		Select(`id, movement_type, reason, quantity, previous_quantity, new_quantity,
+			reference_type, reference_id, notes, created_by, created_at,
+			CASE movement_type WHEN ? THEN quantity WHEN ? THEN -quantity ELSE 0 END AS quantity_delta,
+			FIRST_VALUE(previous_quantity) OVER (ORDER BY created_at, id)
+				+ SUM(CASE movement_type WHEN ? THEN quantity WHEN ? THEN -quantity ELSE 0 END) OVER (ORDER BY created_at, id) AS running_balance`,
+			MovementTypeInbound, MovementTypeOutbound, MovementTypeInbound, MovementTypeOutbound).
+		Where("inventory_item_id = ?", item.ID)
+
+	query := s.db.Table("(?) AS history", history)
+
+	loc := s.config.GetLocation()
+	if req.DateFrom != "" {
+		from, err := time.ParseInLocation("2006-01-02", req.DateFrom, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date_from, expected YYYY-MM-DD")
+		}
+		query = query.Where("created_at >= ?", from)
+	}
+	if req.DateTo != "" {
+		to, err := time.ParseInLocation("2006-01-02", req.DateTo, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date_to, expected YYYY-MM-DD")
+		}
+		query = query.Where("created_at < ?", to.AddDate(0, 0, 1))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count movements: %w", err)
+	}
+
+	movements := []MovementHistoryEntry{}
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("created_at " + direction).Order("id " + direction).
+		Offset(offset).Limit(req.Limit).Scan(&movements).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve movements: %w", err)
+	}
+
+	totalPages := int((total + int64(req.Limit) - 1) / int64(req.Limit))
+	return &MovementHistoryResponse{
+		InventoryItemID: item.ID,
+		CurrentQuantity: item.Quantity,
+		Movements:       movements,
+		Pagination: Pagination{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    req.Page < totalPages,
+			HasPrev:    req.Page > 1,
+		},
+	}, nil
+}
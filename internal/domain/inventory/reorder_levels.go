@@ -0,0 +1,180 @@
+// internal/domain/inventory/reorder_levels.go
+package inventory
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxBulkReorderItems caps the number of items in one bulk reorder level update
+const MaxBulkReorderItems = 500
+
+// ErrReorderValidationFailed is returned when any item in a bulk reorder update is invalid;
+// no items are changed in that case
+var ErrReorderValidationFailed = errors.New("reorder level validation failed")
+
+// Bulk reorder item outcomes
+const (
+	ReorderItemUpdated   = "updated"
+	ReorderItemUnchanged = "unchanged"
+	ReorderItemInvalid   = "invalid"
+	ReorderItemNotFound  = "not_found"
+)
+
+// BulkReorderLevelRequest represents a bulk reorder level update
+type BulkReorderLevelRequest struct {
+	Items []ReorderLevelUpdate `json:"items" binding:"required,min=1,dive"`
+}
+
+// ReorderLevelUpdate sets the reorder level (and optionally the reorder target) of one inventory item
+type ReorderLevelUpdate struct {
+	ProductID     uint `json:"product_id" binding:"required"`
+	WarehouseID   uint `json:"warehouse_id" binding:"required"`
+	ReorderLevel  int  `json:"reorder_level"`
+	ReorderTarget *int `json:"reorder_target,omitempty"`
+}
+
+// ReorderLevelResult represents the outcome for one item of a bulk reorder update
+type ReorderLevelResult struct {
+	ProductID             uint   `json:"product_id"`
+	WarehouseID           uint   `json:"warehouse_id"`
+	InventoryItemID       uint   `json:"inventory_item_id,omitempty"`
+	Status                string `json:"status"`
+	PreviousReorderLevel  int    `json:"previous_reorder_level"`
+	ReorderLevel          int    `json:"reorder_level"`
+	PreviousReorderTarget int    `json:"previous_reorder_target"`
+	ReorderTarget         int    `json:"reorder_target"`
+	Error                 string `json:"error,omitempty"`
+}
+
+// BulkReorderLevelResult represents the outcome of a bulk reorder level update
+type BulkReorderLevelResult struct {
+	Updated   int                  `json:"updated"`
+	Unchanged int                  `json:"unchanged"`
+	Invalid   int                  `json:"invalid"`
+	NotFound  int                  `json:"not_found"`
+	Results   []ReorderLevelResult `json:"results"`
+}
+
+// BulkUpdateReorderLevels validates every item and, only if all are valid, applies
+// the new reorder levels in a single transaction. Per-item results are returned in
+// both cases so callers can see which items need fixing.
+func (s *Service) BulkUpdateReorderLevels(req *BulkReorderLevelRequest) (*BulkReorderLevelResult, error) {
+	if len(req.Items) > MaxBulkReorderItems {
+		return nil, fmt.Errorf("at most %d items can be updated at once", MaxBulkReorderItems)
+	}
+
+	result := &BulkReorderLevelResult{Results: make([]ReorderLevelResult, len(req.Items))}
+
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	type pendingUpdate struct {
+		index int
+		item  InventoryItem
+	}
+	var updates []pendingUpdate
+	seen := make(map[[2]uint]bool, len(req.Items))
+
+	for i, update := range req.Items {
+		res := &result.Results[i]
+		res.ProductID = update.ProductID
+		res.WarehouseID = update.WarehouseID
+
+		key := [2]uint{update.ProductID, update.WarehouseID}
+		if seen[key] {
+			res.Status = ReorderItemInvalid
+			res.Error = "duplicate product and warehouse in request"
+			result.Invalid++
+			continue
+		}
+		seen[key] = true
+
+		var item InventoryItem
+		err := tx.Where("product_id = ? AND warehouse_id = ?", update.ProductID, update.WarehouseID).First(&item).Error
+		if err != nil {
+			res.Status = ReorderItemNotFound
+			res.Error = "inventory item not found"
+			result.NotFound++
+			continue
+		}
+
+		res.InventoryItemID = item.ID
+		res.PreviousReorderLevel = item.ReorderLevel
+		res.PreviousReorderTarget = item.ReorderTarget
+
+		target := item.ReorderTarget
+		if update.ReorderTarget != nil {
+			target = *update.ReorderTarget
+		}
+		res.ReorderLevel = update.ReorderLevel
+		res.ReorderTarget = target
+
+		if msg := validateReorderLevels(update.ReorderLevel, target, item.MaxStockLevel); msg != "" {
+			res.Status = ReorderItemInvalid
+			res.Error = msg
+			result.Invalid++
+			continue
+		}
+
+		if item.ReorderLevel == update.ReorderLevel && item.ReorderTarget == target {
+			res.Status = ReorderItemUnchanged
+			result.Unchanged++
+			continue
+		}
+
+		item.ReorderLevel = update.ReorderLevel
+		item.ReorderTarget = target
+		updates = append(updates, pendingUpdate{index: i, item: item})
+	}
+
+	if result.Invalid > 0 || result.NotFound > 0 {
+		tx.Rollback()
+		return result, fmt.Errorf("%w: %d invalid, %d not found", ErrReorderValidationFailed, result.Invalid, result.NotFound)
+	}
+
+	for _, pending := range updates {
+		err := tx.Model(&InventoryItem{}).Where("id = ?", pending.item.ID).UpdateColumns(map[string]interface{}{
+			"reorder_level":  pending.item.ReorderLevel,
+			"reorder_target": pending.item.ReorderTarget,
+		}).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to update reorder level for product %d: %w", pending.item.ProductID, err)
+		}
+		result.Results[pending.index].Status = ReorderItemUpdated
+		result.Updated++
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit reorder level updates: %w", err)
+	}
+
+	// A raised reorder level may put items below threshold
+	for _, pending := range updates {
+		s.checkAndCreateAlerts(pending.item.ID)
+	}
+
+	return result, nil
+}
+
+// validateReorderLevels returns a validation message, or "" when the levels are acceptable
+func validateReorderLevels(level, target, maxStock int) string {
+	switch {
+	case level < 0:
+		return "reorder_level cannot be negative"
+	case target < 0:
+		return "reorder_target cannot be negative"
+	case target > 0 && target <= level:
+		return "reorder_target must be greater than reorder_level"
+	case maxStock > 0 && level >= maxStock:
+		return fmt.Sprintf("reorder_level must be below max stock level (%d)", maxStock)
+	case maxStock > 0 && target > maxStock:
+		return fmt.Sprintf("reorder_target cannot exceed max stock level (%d)", maxStock)
+	}
+	return ""
+}
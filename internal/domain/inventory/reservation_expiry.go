@@ -0,0 +1,247 @@
+// internal/domain/inventory/reservation_expiry.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Reservation statuses
+const (
+	ReservationStatusActive    = "active"
+	ReservationStatusFulfilled = "fulfilled"
+	ReservationStatusCancelled = "cancelled"
+	ReservationStatusExpired   = "expired"
+)
+
+// OrderReservationItem is one order item to reserve stock for
+type OrderReservationItem struct {
+	OrderItemID uint
+	ProductID   uint
+	Quantity    int
+}
+
+// reservationExpiry returns when a reservation made now stops holding stock
+func reservationExpiry(cfg *config.Config) time.Time {
+	ttl := cfg.Inventory.ReservationTTL
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	return time.Now().UTC().Add(ttl)
+}
+
+// ReserveForOrder reserves warehouse stock for a new order's items within the
+// order's transaction. Each item is reserved in the active warehouse with the most
+// available stock; products without inventory records, or without enough stock in
+// any single warehouse, are skipped since the order was already accepted against
+// the product's own quantity. It returns the inventory items it reserved from, for
+// CheckStockAlerts once the transaction commits.
+func ReserveForOrder(tx *gorm.DB, cfg *config.Config, orderID uint, items []OrderReservationItem) ([]uint, error) {
+	expiresAt := reservationExpiry(cfg)
+	var reserved []uint
+
+	for _, orderItem := range items {
+		var item InventoryItem
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id = ? AND status = ? AND available_quantity >= ?", orderItem.ProductID, InventoryStatusActive, orderItem.Quantity).
+			Order("available_quantity DESC").
+			First(&item).Error
+		if err == gorm.ErrRecordNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find inventory for product %d: %w", orderItem.ProductID, err)
+		}
+
+		item.ReservedQuantity += orderItem.Quantity
+		if err := tx.Save(&item).Error; err != nil {
+			return nil, fmt.Errorf("failed to update reserved quantity: %w", err)
+		}
+
+		reservation := &StockReservation{
+			InventoryItemID: item.ID,
+			OrderID:         orderID,
+			OrderItemID:     orderItem.OrderItemID,
+			Quantity:        orderItem.Quantity,
+			Status:          ReservationStatusActive,
+			ExpiresAt:       expiresAt,
+		}
+		if err := tx.Create(reservation).Error; err != nil {
+			return nil, fmt.Errorf("failed to create reservation: %w", err)
+		}
+		reserved = append(reserved, item.ID)
+	}
+
+	return reserved, nil
+}
+
+// FulfillOrderReservations converts a paid order's reservations into sales within
+// the caller's transaction. Reservations that expired before payment arrived no
+// longer hold stock, so only their on-hand quantity is taken. Orders without
+// reservations are left alone, so it is safe to call on every confirmation path.
+// It returns the inventory items it took stock from, for CheckStockAlerts once the
+// transaction commits.
+func FulfillOrderReservations(tx *gorm.DB, orderID uint) ([]uint, error) {
+	var reservations []StockReservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND status IN ?", orderID, []string{ReservationStatusActive, ReservationStatusExpired}).
+		Order("id ASC").
+		Find(&reservations).Error; err != nil {
+		return nil, fmt.Errorf("failed to load reservations: %w", err)
+	}
+
+	fulfilled := make([]uint, 0, len(reservations))
+	for i := range reservations {
+		reservation := &reservations[i]
+
+		var item InventoryItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, reservation.InventoryItemID).Error; err != nil {
+			return nil, fmt.Errorf("inventory item not found")
+		}
+
+		previousQuantity := item.Quantity
+		item.Quantity -= reservation.Quantity
+		if item.Quantity < 0 {
+			item.Quantity = 0
+		}
+		if reservation.Status == ReservationStatusActive {
+			item.ReservedQuantity -= reservation.Quantity
+			if item.ReservedQuantity < 0 {
+				item.ReservedQuantity = 0
+			}
+		}
+		if err := tx.Save(&item).Error; err != nil {
+			return nil, fmt.Errorf("failed to update inventory: %w", err)
+		}
+
+		movement := &InventoryMovement{
+			InventoryItemID:  item.ID,
+			MovementType:     MovementTypeOutbound,
+			Reason:           ReasonSale,
+			Quantity:         reservation.Quantity,
+			PreviousQuantity: previousQuantity,
+			NewQuantity:      item.Quantity,
+			ReferenceType:    "order",
+			ReferenceID:      orderID,
+			Notes:            fmt.Sprintf("Fulfilled reservation %d", reservation.ID),
+		}
+		if err := tx.Create(movement).Error; err != nil {
+			return nil, fmt.Errorf("failed to record movement: %w", err)
+		}
+
+		if err := tx.Model(reservation).Update("status", ReservationStatusFulfilled).Error; err != nil {
+			return nil, fmt.Errorf("failed to update reservation: %w", err)
+		}
+		fulfilled = append(fulfilled, item.ID)
+	}
+
+	return fulfilled, nil
+}
+
+// ReleaseOrderReservations returns a cancelled order's reserved stock within the
+// caller's transaction
+func ReleaseOrderReservations(tx *gorm.DB, orderID uint) error {
+	var reservations []StockReservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND status = ?", orderID, ReservationStatusActive).
+		Find(&reservations).Error; err != nil {
+		return fmt.Errorf("failed to load reservations: %w", err)
+	}
+
+	for i := range reservations {
+		if err := releaseReservation(tx, &reservations[i], ReservationStatusCancelled); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StartReservationExpiry releases expired reservations every ReservationSweepInterval
+// until ctx is cancelled
+func (s *Service) StartReservationExpiry(ctx context.Context) {
+	interval := s.config.Inventory.ReservationSweepInterval
+	if interval <= 0 {
+		log.Println("Stock reservation expiry disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if expired, err := s.ExpireReservations(time.Now()); err != nil {
+			log.Printf("Failed to expire stock reservations: %v", err)
+		} else if expired > 0 {
+			log.Printf("Released %d expired stock reservations", expired)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExpireReservations releases every active reservation past its expiry back to
+// available stock and marks it expired. It returns the number released.
+func (s *Service) ExpireReservations(now time.Time) (int, error) {
+	var ids []uint
+	if err := s.db.Model(&StockReservation{}).
+		Where("status = ? AND expires_at < ?", ReservationStatusActive, now.UTC()).
+		Order("expires_at ASC").
+		Pluck("id", &ids).Error; err != nil {
+		return 0, fmt.Errorf("failed to find expired reservations: %w", err)
+	}
+
+	expired := 0
+	for _, id := range ids {
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			// Re-check under lock: payment may have fulfilled it meanwhile
+			var reservation StockReservation
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ? AND status = ?", id, ReservationStatusActive).
+				First(&reservation).Error; err != nil {
+				return err
+			}
+			return releaseReservation(tx, &reservation, ReservationStatusExpired)
+		})
+		if err == gorm.ErrRecordNotFound {
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to expire stock reservation %d: %v", id, err)
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// releaseReservation returns a reservation's quantity to available stock and sets its final status
+func releaseReservation(tx *gorm.DB, reservation *StockReservation, status string) error {
+	var item InventoryItem
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, reservation.InventoryItemID).Error; err != nil {
+		return fmt.Errorf("inventory item not found")
+	}
+
+	item.ReservedQuantity -= reservation.Quantity
+	if item.ReservedQuantity < 0 {
+		item.ReservedQuantity = 0
+	}
+	if err := tx.Save(&item).Error; err != nil {
+		return fmt.Errorf("failed to update inventory: %w", err)
+	}
+
+	if err := tx.Model(reservation).Update("status", status).Error; err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+	return nil
+}
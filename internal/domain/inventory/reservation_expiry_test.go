@@ -0,0 +1,61 @@
+// internal/domain/inventory/reservation_expiry_test.go
+package inventory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+func TestExpireReservations(t *testing.T) {
+	db := testutil.NewDB(t, &InventoryItem{}, &InventoryMovement{}, &StockReservation{})
+	cfg := &config.Config{}
+	cfg.Inventory.ReservationTTL = time.Hour
+	s := NewService(db, cfg)
+
+	item := &InventoryItem{ProductID: 1, WarehouseID: 1, SKU: "LAMP-1", Quantity: 10, Status: InventoryStatusActive}
+	db.Create(item)
+
+	// Order 1 is never paid, order 2 is paid in time and order 3 was placed later
+	for orderID, quantity := range map[uint]int{1: 3, 2: 2, 3: 1} {
+		if _, err := ReserveForOrder(db, cfg, orderID, []OrderReservationItem{{OrderItemID: orderID, ProductID: 1, Quantity: quantity}}); err != nil {
+			t.Fatalf("ReserveForOrder(%d) error = %v", orderID, err)
+		}
+	}
+	if _, err := FulfillOrderReservations(db, 2); err != nil {
+		t.Fatalf("FulfillOrderReservations() error = %v", err)
+	}
+	db.Model(&StockReservation{}).Where("order_id = ?", 3).Update("expires_at", time.Now().Add(3*time.Hour))
+
+	expired, err := s.ExpireReservations(time.Now().Add(2 * time.Hour))
+	if err != nil {
+		t.Fatalf("ExpireReservations() error = %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("expired = %d, want 1", expired)
+	}
+
+	want := map[uint]string{1: ReservationStatusExpired, 2: ReservationStatusFulfilled, 3: ReservationStatusActive}
+	var reservations []StockReservation
+	db.Find(&reservations)
+	for _, reservation := range reservations {
+		if reservation.Status != want[reservation.OrderID] {
+			t.Errorf("order %d reservation = %s, want %s", reservation.OrderID, reservation.Status, want[reservation.OrderID])
+		}
+	}
+
+	// Order 1's three units are available again; order 2's two are sold
+	var stock InventoryItem
+	db.First(&stock, item.ID)
+	if stock.Quantity != 8 || stock.ReservedQuantity != 1 || stock.AvailableQuantity != 7 {
+		t.Errorf("stock = %d on hand, %d reserved, %d available; want 8, 1, 7",
+			stock.Quantity, stock.ReservedQuantity, stock.AvailableQuantity)
+	}
+
+	// Running again finds nothing more to release
+	if expired, _ := s.ExpireReservations(time.Now().Add(2 * time.Hour)); expired != 0 {
+		t.Errorf("second run expired = %d, want 0", expired)
+	}
+}
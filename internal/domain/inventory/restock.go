@@ -0,0 +1,64 @@
+// internal/domain/inventory/restock.go
+package inventory
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReturnedItem is a quantity of an order item coming back into stock
+type ReturnedItem struct {
+	OrderItemID uint
+	Quantity    int
+}
+
+// RestockReturn puts returned items back into the warehouse stock they were sold
+// from, within the caller's transaction, recording an inbound movement for each.
+// Items sold without a warehouse reservation have no stock to return to and are
+// skipped.
+func RestockReturn(tx *gorm.DB, returnID, orderID uint, items []ReturnedItem, restockedBy uint) error {
+	for _, returned := range items {
+		var reservation StockReservation
+		err := tx.Where("order_id = ? AND order_item_id = ? AND status = ?", orderID, returned.OrderItemID, ReservationStatusFulfilled).
+			Order("id ASC").
+			First(&reservation).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return fmt.Errorf("failed to load reservation: %w", err)
+		}
+
+		var item InventoryItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, reservation.InventoryItemID).Error; err != nil {
+			return fmt.Errorf("inventory item not found")
+		}
+
+		previousQuantity := item.Quantity
+		item.Quantity += returned.Quantity
+		if err := tx.Save(&item).Error; err != nil {
+			return fmt.Errorf("failed to update inventory: %w", err)
+		}
+
+		movement := &InventoryMovement{
+			InventoryItemID:  item.ID,
+			MovementType:     MovementTypeInbound,
+			Reason:           ReasonReturn,
+			Quantity:         returned.Quantity,
+			PreviousQuantity: previousQuantity,
+			NewQuantity:      item.Quantity,
+			ReferenceType:    "return",
+			ReferenceID:      returnID,
+			Notes:            fmt.Sprintf("Returned from order %d", orderID),
+			CreatedBy:        restockedBy,
+		}
+		if err := tx.Create(movement).Error; err != nil {
+			return fmt.Errorf("failed to record movement: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -6,20 +6,23 @@ import (
 	"time"
 
 	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/pkg/email"
 	"gorm.io/gorm"
 )
 
 // Service handles inventory business logic
 type Service struct {
-	db     *gorm.DB
-	config *config.Config
+	db           *gorm.DB
+	config       *config.Config
+	emailService *email.EmailService
 }
 
 // NewService creates a new inventory service
 func NewService(db *gorm.DB, cfg *config.Config) *Service {
 	return &Service{
-		db:     db,
-		config: cfg,
+		db:           db,
+		config:       cfg,
+		emailService: email.NewEmailService(db, cfg),
 	}
 }
 
@@ -151,6 +154,28 @@ func (s *Service) CreateOrUpdateInventoryItem(productID, warehouseID uint, sku s
 	return &item, nil
 }
 
+// SetSafetyStock sets the buffer held back from sale for an inventory item and
+// recalculates its available quantity; on-hand quantity is unchanged
+func (s *Service) SetSafetyStock(productID, warehouseID uint, safetyStock int) (*InventoryItem, error) {
+	if safetyStock < 0 {
+		return nil, fmt.Errorf("safety stock cannot be negative")
+	}
+
+	var item InventoryItem
+	if err := s.db.Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).First(&item).Error; err != nil {
+		return nil, fmt.Errorf("inventory item not found")
+	}
+
+	item.SafetyStock = safetyStock
+	if err := s.db.Save(&item).Error; err != nil {
+		return nil, fmt.Errorf("failed to update safety stock: %w", err)
+	}
+
+	go s.checkAndCreateAlerts(item.ID)
+
+	return &item, nil
+}
+
 // STOCK MOVEMENTS
 
 // RecordStockMovement records a stock movement and updates inventory
@@ -234,10 +259,11 @@ func (s *Service) RecordStockMovement(req *StockMovementRequest, userID uint) (*
 		return nil, fmt.Errorf("failed to record movement: %w", err)
 	}
 
-	// Check for alerts
+	tx.Commit()
+
+	// Check for alerts once the new stock level is visible
 	go s.checkAndCreateAlerts(item.ID)
 
-	tx.Commit()
 	return movement, nil
 }
 
@@ -278,8 +304,8 @@ func (s *Service) ReserveStock(req *ReservationRequest) (*StockReservation, erro
 		OrderID:         req.OrderID,
 		OrderItemID:     req.OrderItemID,
 		Quantity:        req.Quantity,
-		Status:          "active",
-		ExpiresAt:       time.Now().Add(24 * time.Hour), // 24 hour expiry
+		Status:          ReservationStatusActive,
+		ExpiresAt:       reservationExpiry(s.config),
 	}
 
 	if err := tx.Create(reservation).Error; err != nil {
@@ -288,6 +314,9 @@ func (s *Service) ReserveStock(req *ReservationRequest) (*StockReservation, erro
 	}
 
 	tx.Commit()
+
+	go s.checkAndCreateAlerts(item.ID)
+
 	return reservation, nil
 }
 
@@ -302,7 +331,7 @@ func (s *Service) ReleaseReservation(orderID, orderItemID uint) error {
 
 	// Find active reservation
 	var reservation StockReservation
-	if err := tx.Where("order_id = ? AND order_item_id = ? AND status = ?", orderID, orderItemID, "active").First(&reservation).Error; err != nil {
+	if err := tx.Where("order_id = ? AND order_item_id = ? AND status = ?", orderID, orderItemID, ReservationStatusActive).First(&reservation).Error; err != nil {
 		tx.Rollback()
 		return fmt.Errorf("reservation not found")
 	}
@@ -325,7 +354,7 @@ func (s *Service) ReleaseReservation(orderID, orderItemID uint) error {
 	}
 
 	// Update reservation status
-	reservation.Status = "cancelled"
+	reservation.Status = ReservationStatusCancelled
 	if err := tx.Save(&reservation).Error; err != nil {
 		tx.Rollback()
 		return fmt.Errorf("failed to update reservation: %w", err)
@@ -346,7 +375,7 @@ func (s *Service) FulfillReservation(orderID, orderItemID uint) error {
 
 	// Find active reservation
 	var reservation StockReservation
-	if err := tx.Where("order_id = ? AND order_item_id = ? AND status = ?", orderID, orderItemID, "active").First(&reservation).Error; err != nil {
+	if err := tx.Where("order_id = ? AND order_item_id = ? AND status = ?", orderID, orderItemID, ReservationStatusActive).First(&reservation).Error; err != nil {
 		tx.Rollback()
 		return fmt.Errorf("reservation not found")
 	}
@@ -368,13 +397,16 @@ func (s *Service) FulfillReservation(orderID, orderItemID uint) error {
 	}
 
 	// Update reservation status
-	reservation.Status = "fulfilled"
+	reservation.Status = ReservationStatusFulfilled
 	if err := tx.Save(&reservation).Error; err != nil {
 		tx.Rollback()
 		return fmt.Errorf("failed to update reservation: %w", err)
 	}
 
 	tx.Commit()
+
+	go s.checkAndCreateAlerts(item.ID)
+
 	return nil
 }
 
@@ -393,31 +425,3 @@ func (s *Service) GetStockLevel(productID uint, warehouseID *uint) (int, error)
 
 	return int(totalStock), nil
 }
-
-// checkAndCreateAlerts checks for low stock and creates alerts
-func (s *Service) checkAndCreateAlerts(inventoryItemID uint) {
-	var item InventoryItem
-	if err := s.db.Where("id = ?", inventoryItemID).First(&item).Error; err != nil {
-		return
-	}
-
-	// Check for existing unresolved alerts
-	var existingAlert StockAlert
-	hasExisting := s.db.Where("inventory_item_id = ? AND is_resolved = ?", inventoryItemID, false).First(&existingAlert).Error == nil
-
-	if item.IsOutOfStock() && !hasExisting {
-		alert := StockAlert{
-			InventoryItemID: inventoryItemID,
-			AlertType:       "out_of_stock",
-			Message:         fmt.Sprintf("Product %s is out of stock", item.SKU),
-		}
-		s.db.Create(&alert)
-	} else if item.IsLowStock() && !hasExisting {
-		alert := StockAlert{
-			InventoryItemID: inventoryItemID,
-			AlertType:       "low_stock",
-			Message:         fmt.Sprintf("Product %s is running low (Available: %d, Reorder Level: %d)", item.SKU, item.AvailableQuantity, item.ReorderLevel),
-		}
-		s.db.Create(&alert)
-	}
-}
@@ -0,0 +1,152 @@
+// internal/domain/inventory/stock_alerts.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/pkg/email"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Stock alert types
+const (
+	AlertTypeLowStock   = "low_stock"
+	AlertTypeOutOfStock = "out_of_stock"
+)
+
+// CheckStockAlerts runs the low stock check for inventory items whose stock was
+// changed outside this package's service, such as by ReserveForOrder. Call it after
+// the transaction that changed them commits; the checks run in the background.
+func CheckStockAlerts(db *gorm.DB, cfg *config.Config, inventoryItemIDs []uint) {
+	if len(inventoryItemIDs) == 0 {
+		return
+	}
+	s := NewService(db, cfg)
+	go func() {
+		for _, id := range inventoryItemIDs {
+			s.checkAndCreateAlerts(id)
+		}
+	}()
+}
+
+// checkAndCreateAlerts creates a stock alert when the item is at or below its
+// reorder level and emails the alert recipients. A product that still has an
+// unresolved alert, or was alerted within the debounce window, is skipped.
+func (s *Service) checkAndCreateAlerts(inventoryItemID uint) {
+	var item InventoryItem
+	if err := s.db.Where("id = ?", inventoryItemID).First(&item).Error; err != nil {
+		return
+	}
+	if !item.IsLowStock() {
+		return
+	}
+
+	var alert *StockAlert
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the product's stock rows so concurrent movements across warehouses
+		// cannot both decide to alert
+		var items []InventoryItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id = ?", item.ProductID).Find(&items).Error; err != nil {
+			return fmt.Errorf("failed to lock inventory items: %w", err)
+		}
+		for _, locked := range items {
+			if locked.ID == item.ID {
+				item = locked
+			}
+		}
+		if !item.IsLowStock() {
+			return nil
+		}
+
+		since := time.Now().Add(-s.config.Inventory.AlertDebounce)
+		var recent int64
+		if err := tx.Model(&StockAlert{}).
+			Joins("JOIN inventory_items ON inventory_items.id = stock_alerts.inventory_item_id").
+			Where("inventory_items.product_id = ?", item.ProductID).
+			Where("stock_alerts.is_resolved = ? OR stock_alerts.created_at > ?", false, since).
+			Count(&recent).Error; err != nil {
+			return fmt.Errorf("failed to check recent alerts: %w", err)
+		}
+		if recent > 0 {
+			return nil
+		}
+
+		alert = &StockAlert{
+			InventoryItemID: item.ID,
+			AlertType:       AlertTypeLowStock,
+			Message:         fmt.Sprintf("Product %s is running low (Available: %d, Reorder Level: %d)", item.SKU, item.AvailableQuantity, item.ReorderLevel),
+		}
+		if item.IsOutOfStock() {
+			alert.AlertType = AlertTypeOutOfStock
+			alert.Message = fmt.Sprintf("Product %s is out of stock", item.SKU)
+		}
+		if err := tx.Create(alert).Error; err != nil {
+			return fmt.Errorf("failed to create stock alert: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to check stock alerts for inventory item %d: %v", inventoryItemID, err)
+		return
+	}
+	if alert == nil {
+		return
+	}
+
+	go func() {
+		if err := s.sendStockAlertEmail(alert, &item); err != nil {
+			log.Printf("Failed to send stock alert %d: %v", alert.ID, err)
+		}
+	}()
+}
+
+// sendStockAlertEmail notifies the configured alert recipients, or every active
+// admin when none are configured
+func (s *Service) sendStockAlertEmail(alert *StockAlert, item *InventoryItem) error {
+	recipients := s.config.Inventory.AlertEmails
+	if len(recipients) == 0 {
+		if err := s.db.Table("users").
+			Where("is_admin = ? AND is_active = ? AND deleted_at IS NULL", true, true).
+			Pluck("email", &recipients).Error; err != nil {
+			return fmt.Errorf("failed to get admin emails: %w", err)
+		}
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	// The product package depends on this one, so only the name is read
+	var productName string
+	s.db.Table("products").Select("name").Where("id = ?", item.ProductID).Scan(&productName)
+	if productName == "" {
+		productName = item.SKU
+	}
+
+	subject := fmt.Sprintf("Low stock: %s", productName)
+	if alert.AlertType == AlertTypeOutOfStock {
+		subject = fmt.Sprintf("Out of stock: %s", productName)
+	}
+	link := fmt.Sprintf("%s/admin/inventory/%d/%d", strings.TrimRight(s.config.App.FrontendURL, "/"), item.ProductID, item.WarehouseID)
+
+	body := fmt.Sprintf(`<p><strong>%s</strong> (SKU %s) needs restocking.</p>
+<p>Available: %d<br>Reorder level: %d</p>
+<p><a href="%s">View inventory</a></p>`,
+		html.EscapeString(productName), html.EscapeString(item.SKU),
+		item.AvailableQuantity, item.ReorderLevel, html.EscapeString(link))
+
+	return s.emailService.SendEmail(context.Background(), &email.Email{
+		To:          recipients,
+		Subject:     subject,
+		HTMLContent: body,
+		TextContent: fmt.Sprintf("%s: %s: %s", subject, alert.Message, link),
+		Type:        email.EmailTypeStockAlert,
+	})
+}
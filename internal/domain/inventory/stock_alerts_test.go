@@ -0,0 +1,117 @@
+// internal/domain/inventory/stock_alerts_test.go
+package inventory
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+// logCapture collects log output written from background goroutines
+type logCapture struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (l *logCapture) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buf.Write(p)
+}
+
+func (l *logCapture) count(s string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Count(l.buf.String(), s)
+}
+
+// captureLog redirects the standard logger, which is where emails go without a
+// configured provider
+func captureLog(t *testing.T) *logCapture {
+	t.Helper()
+	capture := &logCapture{}
+	log.SetOutput(capture)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	return capture
+}
+
+// eventually waits for cond, failing the test if it doesn't hold within a few seconds
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStockMovementCrossingReorderLevelAlertsOnce(t *testing.T) {
+	db := testutil.NewDB(t, &InventoryItem{}, &InventoryMovement{}, &StockAlert{})
+	cfg := &config.Config{}
+	cfg.Inventory.AlertEmails = []string{"stock@example.com"}
+	cfg.Inventory.AlertDebounce = time.Hour
+	s := NewService(db, cfg)
+	emails := captureLog(t)
+
+	item := &InventoryItem{ProductID: 1, WarehouseID: 1, SKU: "LAMP-1", Quantity: 20, ReorderLevel: 10}
+	db.Create(item)
+	sell := func(quantity int) {
+		t.Helper()
+		_, err := s.RecordStockMovement(&StockMovementRequest{ProductID: 1, WarehouseID: 1,
+			MovementType: MovementTypeOutbound, Reason: ReasonSale, Quantity: quantity}, 1)
+		if err != nil {
+			t.Fatalf("RecordStockMovement() error = %v", err)
+		}
+	}
+	alerts := func() int64 {
+		var count int64
+		db.Model(&StockAlert{}).Count(&count)
+		return count
+	}
+
+	// Still above the reorder level
+	sell(5)
+	time.Sleep(100 * time.Millisecond)
+	if n := alerts(); n != 0 {
+		t.Fatalf("alerts above the reorder level = %d, want 0", n)
+	}
+
+	// Crossing it alerts
+	sell(7)
+	eventually(t, "the low stock alert", func() bool { return alerts() == 1 })
+	eventually(t, "the alert email", func() bool { return emails.count("Subject: Low stock: LAMP-1") == 1 })
+
+	// Further movements and concurrent checks while the alert is open don't
+	sell(3)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.checkAndCreateAlerts(item.ID)
+		}()
+	}
+	wg.Wait()
+	time.Sleep(200 * time.Millisecond)
+
+	if n := alerts(); n != 1 {
+		t.Errorf("alerts = %d, want 1", n)
+	}
+	if n := emails.count("Subject: Low stock"); n != 1 {
+		t.Errorf("alert emails = %d, want 1", n)
+	}
+	var alert StockAlert
+	db.First(&alert)
+	if alert.InventoryItemID != item.ID || alert.AlertType != AlertTypeLowStock {
+		t.Errorf("alert = %+v, want a low stock alert for item %d", alert, item.ID)
+	}
+}
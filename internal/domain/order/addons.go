@@ -0,0 +1,145 @@
+// internal/domain/order/addons.go
+package order
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+)
+
+// Checkout add-on identifiers
+const (
+	AddOnShippingInsurance   = "shipping_insurance"
+	AddOnSignatureOnDelivery = "signature_on_delivery"
+	AddOnGiftWrap            = "gift_wrap"
+)
+
+// ErrInvalidAddOn is returned when a selected add-on is unknown or disabled
+var ErrInvalidAddOn = errors.New("invalid checkout add-on")
+
+// ErrInvalidGiftMessage is returned when a gift message is too long
+var ErrInvalidGiftMessage = errors.New("invalid gift message")
+
+// AddOn is an optional checkout extra priced for a given order subtotal
+type AddOn struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Price       int64  `json:"price"` // In cents
+	Selected    bool   `json:"selected"`
+}
+
+// AddOnCharges holds the priced add-ons selected for an order
+type AddOnCharges struct {
+	InsuranceAmount int64 `json:"insurance_amount"`
+	SignatureFee    int64 `json:"signature_fee"`
+	GiftWrapFee     int64 `json:"gift_wrap_fee"`
+}
+
+// Total returns the combined add-on amount
+func (c AddOnCharges) Total() int64 {
+	return c.InsuranceAmount + c.SignatureFee + c.GiftWrapFee
+}
+
+// AvailableAddOns returns the enabled add-ons priced for the subtotal (in cents),
+// marking the ones in selected
+func AvailableAddOns(cfg *config.Config, subtotal int64, selected []string) []AddOn {
+	chosen := make(map[string]bool, len(selected))
+	for _, id := range selected {
+		chosen[normalizeAddOnID(id)] = true
+	}
+
+	addOns := []AddOn{}
+	if cfg.Checkout.InsuranceEnabled {
+		addOns = append(addOns, AddOn{
+			ID:          AddOnShippingInsurance,
+			Name:        "Shipping Insurance",
+			Description: fmt.Sprintf("Covers loss or damage in transit (%.2f%% of order value)", cfg.Checkout.InsurancePercent),
+			Price:       insuranceAmount(cfg, subtotal),
+			Selected:    chosen[AddOnShippingInsurance],
+		})
+	}
+	if cfg.Checkout.SignatureEnabled {
+		addOns = append(addOns, AddOn{
+			ID:          AddOnSignatureOnDelivery,
+			Name:        "Signature on Delivery",
+			Description: "The carrier collects a signature before leaving the package",
+			Price:       cfg.Checkout.SignatureFee,
+			Selected:    chosen[AddOnSignatureOnDelivery],
+		})
+	}
+	if cfg.Checkout.GiftWrapEnabled {
+		addOns = append(addOns, AddOn{
+			ID:          AddOnGiftWrap,
+			Name:        "Gift Wrapping",
+			Description: "Wrapped with a printed gift message; prices are left off the recipient's packing slip",
+			Price:       cfg.Checkout.GiftWrapFee,
+			Selected:    chosen[AddOnGiftWrap],
+		})
+	}
+	return addOns
+}
+
+// PriceAddOns validates the selected add-ons and prices them for the subtotal (in cents)
+func PriceAddOns(cfg *config.Config, subtotal int64, selected []string) (AddOnCharges, error) {
+	var charges AddOnCharges
+	for _, id := range selected {
+		switch normalizeAddOnID(id) {
+		case AddOnShippingInsurance:
+			if !cfg.Checkout.InsuranceEnabled {
+				return AddOnCharges{}, fmt.Errorf("%w: %s is not available", ErrInvalidAddOn, id)
+			}
+			charges.InsuranceAmount = insuranceAmount(cfg, subtotal)
+		case AddOnSignatureOnDelivery:
+			if !cfg.Checkout.SignatureEnabled {
+				return AddOnCharges{}, fmt.Errorf("%w: %s is not available", ErrInvalidAddOn, id)
+			}
+			charges.SignatureFee = cfg.Checkout.SignatureFee
+		case AddOnGiftWrap:
+			if !cfg.Checkout.GiftWrapEnabled {
+				return AddOnCharges{}, fmt.Errorf("%w: %s is not available", ErrInvalidAddOn, id)
+			}
+			charges.GiftWrapFee = cfg.Checkout.GiftWrapFee
+		default:
+			return AddOnCharges{}, fmt.Errorf("%w: %s", ErrInvalidAddOn, id)
+		}
+	}
+	return charges, nil
+}
+
+// insuranceAmount returns the insurance premium for the subtotal, rounded to the
+// nearest cent and never below the configured minimum fee
+func insuranceAmount(cfg *config.Config, subtotal int64) int64 {
+	amount := int64(math.Round(float64(subtotal) * cfg.Checkout.InsurancePercent / 100))
+	if amount < cfg.Checkout.InsuranceMinFee {
+		amount = cfg.Checkout.InsuranceMinFee
+	}
+	return amount
+}
+
+// HasAddOn reports whether id is among the selected add-ons
+func HasAddOn(selected []string, id string) bool {
+	for _, s := range selected {
+		if normalizeAddOnID(s) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateGiftMessage trims a gift message and checks it against the configured length
+func ValidateGiftMessage(cfg *config.Config, message string) (string, error) {
+	message = strings.TrimSpace(message)
+	if max := cfg.Checkout.GiftMessageMaxLength; max > 0 && utf8.RuneCountInString(message) > max {
+		return "", fmt.Errorf("%w: must be at most %d characters", ErrInvalidGiftMessage, max)
+	}
+	return message, nil
+}
+
+func normalizeAddOnID(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
@@ -0,0 +1,196 @@
+// internal/domain/order/address_verification.go
+package order
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/tax"
+	"gorm.io/gorm"
+)
+
+// ErrAddressVerificationRequired is returned when fulfillment is attempted on an order held for address verification
+var ErrAddressVerificationRequired = errors.New("order is held for address verification")
+
+// poBoxPattern matches common PO box spellings ("PO Box", "P.O. Box", "POB", "Post Office Box")
+var poBoxPattern = regexp.MustCompile(`(?i)\b(p\.?\s*o\.?\s*b(ox)?|post\s+office\s+box)\b`)
+
+// checkAddressQuality returns the address-quality rules the shipping address trips.
+// An empty result means the address can be fulfilled without manual verification.
+func (s *Service) checkAddressQuality(addr Address) []string {
+	rules := s.config.Order
+	if !rules.AddressVerificationEnabled {
+		return nil
+	}
+
+	var issues []string
+
+	if strings.TrimSpace(addr.AddressLine1) == "" {
+		issues = append(issues, "missing street address")
+	}
+	if strings.TrimSpace(addr.City) == "" {
+		issues = append(issues, "missing city")
+	}
+	if strings.TrimSpace(addr.Country) == "" {
+		issues = append(issues, "missing country")
+	}
+	if rules.RequirePostalCode && strings.TrimSpace(addr.PostalCode) == "" {
+		issues = append(issues, "missing postal code")
+	}
+	if rules.RequirePhone && strings.TrimSpace(addr.Phone) == "" {
+		issues = append(issues, "missing phone number")
+	}
+	if !rules.AllowPOBox && (poBoxPattern.MatchString(addr.AddressLine1) || poBoxPattern.MatchString(addr.AddressLine2)) {
+		issues = append(issues, "PO box addresses are not accepted")
+	}
+
+	return issues
+}
+
+// isFulfillmentStatus reports whether moving to status means the order is being fulfilled
+func isFulfillmentStatus(status OrderStatus) bool {
+	switch status {
+	case OrderStatusProcessing, OrderStatusShipped, OrderStatusOutForDelivery, OrderStatusDelivered:
+		return true
+	}
+	return false
+}
+
+// canChangeShippingAddress checks if the order has not yet left the warehouse
+func canChangeShippingAddress(status OrderStatus) bool {
+	switch status {
+	case OrderStatusPending, OrderStatusPaymentProcessing, OrderStatusConfirmed, OrderStatusProcessing:
+		return true
+	}
+	return false
+}
+
+// UpdateShippingAddress replaces the shipping address of an unshipped order and
+// re-evaluates the address-quality rules, placing or clearing the verification hold.
+func (s *Service) UpdateShippingAddress(orderID uint, addr Address, updatedBy uint) (*Order, error) {
+	var order Order
+	if err := s.db.First(&order, orderID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("order not found")
+		}
+		return nil, fmt.Errorf("failed to retrieve order: %w", err)
+	}
+
+	if !canChangeShippingAddress(order.Status) {
+		return nil, fmt.Errorf("shipping address cannot be changed for %s orders", order.Status)
+	}
+
+	issues := s.checkAddressQuality(addr)
+	wasHeld := order.AddressVerificationRequired
+
+	updates := map[string]interface{}{
+		"shipping_first_name":           addr.FirstName,
+		"shipping_last_name":            addr.LastName,
+		"shipping_company":              addr.Company,
+		"shipping_address_line1":        addr.AddressLine1,
+		"shipping_address_line2":        addr.AddressLine2,
+		"shipping_city":                 addr.City,
+		"shipping_state":                addr.State,
+		"shipping_postal_code":          addr.PostalCode,
+		"shipping_country":              addr.Country,
+		"shipping_phone":                addr.Phone,
+		"address_verification_required": len(issues) > 0,
+		"address_verification_reason":   strings.Join(issues, "; "),
+	}
+
+	// The tax charged stays the same, but moving across state lines changes its split
+	cgst, sgst, igst := tax.SplitAmount(order.TaxAmount, tax.IsInterState(s.config.App.StoreState, addr.State))
+	updates["cgst_amount"] = cgst
+	updates["sgst_amount"] = sgst
+	updates["igst_amount"] = igst
+
+	comment := "Shipping address updated"
+	switch {
+	case len(issues) > 0:
+		comment = "Held for address verification: " + strings.Join(issues, "; ")
+	case wasHeld:
+		comment = "Shipping address corrected, address verification hold cleared"
+	}
+
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Model(&order).Updates(updates).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update shipping address: %w", err)
+	}
+
+	history := OrderStatusHistory{
+		OrderID:   order.ID,
+		Status:    order.Status,
+		Comment:   comment,
+		CreatedBy: updatedBy,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := tx.Create(&history).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create status history: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit address update: %w", err)
+	}
+
+	return s.GetOrder(orderID)
+}
+
+// ClearAddressHold releases the verification hold after an admin has confirmed the
+// address is deliverable as entered
+func (s *Service) ClearAddressHold(orderID uint, comment string, adminID uint) error {
+	var order Order
+	if err := s.db.First(&order, orderID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("order not found")
+		}
+		return fmt.Errorf("failed to retrieve order: %w", err)
+	}
+
+	if !order.AddressVerificationRequired {
+		return fmt.Errorf("order is not held for address verification")
+	}
+
+	if comment == "" {
+		comment = "Address verified by admin"
+	}
+
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Model(&order).Updates(map[string]interface{}{
+		"address_verification_required": false,
+		"address_verification_reason":   "",
+	}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to clear address hold: %w", err)
+	}
+
+	history := OrderStatusHistory{
+		OrderID:   order.ID,
+		Status:    order.Status,
+		Comment:   comment,
+		CreatedBy: adminID,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := tx.Create(&history).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to create status history: %w", err)
+	}
+
+	return tx.Commit().Error
+}
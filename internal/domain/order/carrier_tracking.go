@@ -0,0 +1,265 @@
+// internal/domain/order/carrier_tracking.go
+package order
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+)
+
+// Carrier tracking providers
+const (
+	TrackingProviderAfterShip = "aftership"
+)
+
+// ErrTrackingNotFound is returned when the carrier has no record of a tracking number
+var ErrTrackingNotFound = errors.New("tracking number not found at carrier")
+
+// TrackingCheckpoint is one scan event reported by the carrier
+type TrackingCheckpoint struct {
+	Time        time.Time `json:"time"`
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	Location    string    `json:"location,omitempty"`
+}
+
+// CarrierTracking is the live state of a shipment as reported by the carrier
+type CarrierTracking struct {
+	Carrier           string               `json:"carrier"`
+	TrackingNumber    string               `json:"tracking_number"`
+	Status            string               `json:"status"`
+	EstimatedDelivery string               `json:"estimated_delivery,omitempty"` // YYYY-MM-DD
+	Checkpoints       []TrackingCheckpoint `json:"checkpoints"`                  // Newest first
+	CheckedAt         time.Time            `json:"checked_at"`
+}
+
+// CarrierTracker looks up a shipment with a carrier or tracking aggregator
+type CarrierTracker interface {
+	// Track returns the shipment's current status and checkpoints
+	Track(ctx context.Context, carrier, trackingNumber string) (*CarrierTracking, error)
+}
+
+// NewCarrierTracker returns the tracker for the configured provider, or nil when
+// live tracking is not configured
+func NewCarrierTracker(cfg *config.Config) CarrierTracker {
+	tracking := cfg.External.Tracking
+	if tracking.APIKey == "" {
+		return nil
+	}
+
+	switch strings.ToLower(tracking.Provider) {
+	case TrackingProviderAfterShip:
+		return NewAfterShipTracker(tracking)
+	default:
+		return nil
+	}
+}
+
+// TrackingService adds live carrier tracking to orders, caching lookups in Redis
+type TrackingService struct {
+	tracker     CarrierTracker // nil when live tracking is not configured
+	redisClient *redis.Client  // may be nil
+	config      *config.Config
+}
+
+// NewTrackingService creates a new tracking service
+func NewTrackingService(tracker CarrierTracker, redisClient *redis.Client, cfg *config.Config) *TrackingService {
+	return &TrackingService{
+		tracker:     tracker,
+		redisClient: redisClient,
+		config:      cfg,
+	}
+}
+
+// GetLiveTracking returns the carrier's view of a shipment. It returns nil without
+// an error when live tracking is not configured or the order has no tracking number.
+func (s *TrackingService) GetLiveTracking(ctx context.Context, carrier, trackingNumber string) (*CarrierTracking, error) {
+	trackingNumber = strings.TrimSpace(trackingNumber)
+	if s.tracker == nil || trackingNumber == "" {
+		return nil, nil
+	}
+
+	key := fmt.Sprintf("carrier_tracking:%s:%s", carrierSlug(carrier), trackingNumber)
+	if s.redisClient != nil {
+		if cached, err := s.redisClient.Get(ctx, key).Result(); err == nil {
+			var tracking CarrierTracking
+			if err := json.Unmarshal([]byte(cached), &tracking); err == nil {
+				return &tracking, nil
+			}
+		}
+	}
+
+	tracking, err := s.tracker.Track(ctx, carrier, trackingNumber)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(tracking.Checkpoints, func(i, j int) bool {
+		return tracking.Checkpoints[i].Time.After(tracking.Checkpoints[j].Time)
+	})
+
+	if s.redisClient != nil && s.config.External.Tracking.CacheTTL > 0 {
+		if data, err := json.Marshal(tracking); err == nil {
+			if err := s.redisClient.Set(ctx, key, data, s.config.External.Tracking.CacheTTL).Err(); err != nil {
+				log.Printf("Failed to cache tracking for %s: %v", trackingNumber, err)
+			}
+		}
+	}
+
+	return tracking, nil
+}
+
+// AfterShipTracker tracks shipments through the AfterShip API, which covers most carriers
+type AfterShipTracker struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewAfterShipTracker creates an AfterShip tracker
+func NewAfterShipTracker(cfg config.TrackingConfig) *AfterShipTracker {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &AfterShipTracker{
+		apiKey:     cfg.APIKey,
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// afterShipTracking is the part of an AfterShip tracking the store uses
+type afterShipTracking struct {
+	Slug             string `json:"slug"`
+	TrackingNumber   string `json:"tracking_number"`
+	Tag              string `json:"tag"`
+	ExpectedDelivery string `json:"expected_delivery"`
+	Checkpoints      []struct {
+		CheckpointTime string `json:"checkpoint_time"`
+		Tag            string `json:"tag"`
+		Message        string `json:"message"`
+		Location       string `json:"location"`
+	} `json:"checkpoints"`
+}
+
+// afterShipResponse is the AfterShip response envelope
+type afterShipResponse struct {
+	Meta struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"meta"`
+	Data struct {
+		Tracking afterShipTracking `json:"tracking"`
+	} `json:"data"`
+}
+
+// afterShipNotFound is the AfterShip meta code for a tracking it is not following yet
+const afterShipNotFound = 4004
+
+// Track fetches a tracking from AfterShip. Tracking numbers AfterShip is not yet
+// following are registered, so their checkpoints appear on later lookups.
+func (t *AfterShipTracker) Track(ctx context.Context, carrier, trackingNumber string) (*CarrierTracking, error) {
+	slug := carrierSlug(carrier)
+	endpoint := fmt.Sprintf("/trackings/%s/%s", url.PathEscape(slug), url.PathEscape(trackingNumber))
+
+	resp, err := t.doAPICall(ctx, http.MethodGet, endpoint, nil)
+	if err != nil && resp != nil && resp.Meta.Code == afterShipNotFound {
+		body, _ := json.Marshal(map[string]interface{}{
+			"tracking": map[string]string{"slug": slug, "tracking_number": trackingNumber},
+		})
+		resp, err = t.doAPICall(ctx, http.MethodPost, "/trackings", body)
+		if err != nil && resp != nil && resp.Meta.Code >= 4000 && resp.Meta.Code < 5000 {
+			return nil, fmt.Errorf("%w: %s", ErrTrackingNotFound, resp.Meta.Message)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tracking := &CarrierTracking{
+		Carrier:        carrier,
+		TrackingNumber: trackingNumber,
+		Status:         resp.Data.Tracking.Tag,
+		Checkpoints:    make([]TrackingCheckpoint, 0, len(resp.Data.Tracking.Checkpoints)),
+		CheckedAt:      time.Now().UTC(),
+	}
+	if len(resp.Data.Tracking.ExpectedDelivery) >= 10 {
+		tracking.EstimatedDelivery = resp.Data.Tracking.ExpectedDelivery[:10]
+	}
+	for _, cp := range resp.Data.Tracking.Checkpoints {
+		tracking.Checkpoints = append(tracking.Checkpoints, TrackingCheckpoint{
+			Time:        parseCarrierTime(cp.CheckpointTime),
+			Status:      cp.Tag,
+			Description: cp.Message,
+			Location:    cp.Location,
+		})
+	}
+
+	return tracking, nil
+}
+
+// doAPICall makes an AfterShip API call. On an API error the decoded response is
+// returned alongside the error so callers can inspect the meta code.
+func (t *AfterShipTracker) doAPICall(ctx context.Context, method, endpoint string, reqBody []byte) (*afterShipResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, method, t.baseURL+endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("aftership-api-key", t.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make API call: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	var body bytes.Buffer
+	if _, err := body.ReadFrom(httpResp.Body); err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var resp afterShipResponse
+	if err := json.Unmarshal(body.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response (status %d): %w", httpResp.StatusCode, err)
+	}
+	if httpResp.StatusCode >= 400 {
+		return &resp, fmt.Errorf("API call failed with status %d: %s", httpResp.StatusCode, resp.Meta.Message)
+	}
+	return &resp, nil
+}
+
+// carrierSlug turns a carrier name as entered by staff into a tracking provider
+// slug, e.g. "Blue Dart" becomes "bluedart"
+func carrierSlug(carrier string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(carrier) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// parseCarrierTime parses a checkpoint time, which carriers report with or without
+// a zone offset; unparseable times are left zero
+func parseCarrierTime(value string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
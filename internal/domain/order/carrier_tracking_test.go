@@ -0,0 +1,153 @@
+// internal/domain/order/carrier_tracking_test.go
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+// fakeTracker returns a fixed tracking and counts lookups
+type fakeTracker struct {
+	tracking *CarrierTracking
+	err      error
+	calls    int
+}
+
+func (f *fakeTracker) Track(ctx context.Context, carrier, trackingNumber string) (*CarrierTracking, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	tracking := *f.tracking
+	tracking.Checkpoints = append([]TrackingCheckpoint(nil), f.tracking.Checkpoints...)
+	return &tracking, nil
+}
+
+func TestGetLiveTracking(t *testing.T) {
+	redisClient, mr := testutil.NewRedis(t)
+	cfg := &config.Config{}
+	cfg.External.Tracking.CacheTTL = 15 * time.Minute
+	shipped := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	tracker := &fakeTracker{tracking: &CarrierTracking{Carrier: "Blue Dart", TrackingNumber: "BD123", Status: "InTransit",
+		EstimatedDelivery: "2026-03-05", Checkpoints: []TrackingCheckpoint{
+			{Time: shipped, Status: "InfoReceived", Description: "Shipment picked up", Location: "Mumbai"},
+			{Time: shipped.Add(20 * time.Hour), Status: "InTransit", Description: "Arrived at hub", Location: "Pune"},
+		}}}
+	s := NewTrackingService(tracker, redisClient, cfg)
+	ctx := context.Background()
+
+	live, err := s.GetLiveTracking(ctx, "Blue Dart", " BD123 ")
+	if err != nil {
+		t.Fatalf("GetLiveTracking() error = %v", err)
+	}
+	if live.Status != "InTransit" || live.EstimatedDelivery != "2026-03-05" || len(live.Checkpoints) != 2 {
+		t.Fatalf("live = %+v, want the carrier's tracking", live)
+	}
+	if live.Checkpoints[0].Location != "Pune" {
+		t.Errorf("first checkpoint = %+v, want the newest", live.Checkpoints[0])
+	}
+
+	// Repeat lookups are served from the cache until it expires
+	if cached, err := s.GetLiveTracking(ctx, "Blue Dart", "BD123"); err != nil || len(cached.Checkpoints) != 2 {
+		t.Fatalf("cached GetLiveTracking() = %+v, %v", cached, err)
+	}
+	if tracker.calls != 1 {
+		t.Errorf("carrier lookups = %d, want 1", tracker.calls)
+	}
+	mr.FastForward(16 * time.Minute)
+	s.GetLiveTracking(ctx, "Blue Dart", "BD123")
+	if tracker.calls != 2 {
+		t.Errorf("carrier lookups after the cache expired = %d, want 2", tracker.calls)
+	}
+
+	// Orders that haven't shipped aren't looked up
+	if live, err := s.GetLiveTracking(ctx, "Blue Dart", ""); live != nil || err != nil {
+		t.Errorf("GetLiveTracking(no tracking number) = %+v, %v, want nothing", live, err)
+	}
+	if tracker.calls != 2 {
+		t.Errorf("carrier lookups = %d, want 2", tracker.calls)
+	}
+
+	tracker.err = ErrTrackingNotFound
+	if _, err := s.GetLiveTracking(ctx, "Delhivery", "DL999"); !errors.Is(err, ErrTrackingNotFound) {
+		t.Errorf("GetLiveTracking(unknown) error = %v, want %v", err, ErrTrackingNotFound)
+	}
+}
+
+func TestGetLiveTrackingNotConfigured(t *testing.T) {
+	cfg := &config.Config{}
+	if tracker := NewCarrierTracker(cfg); tracker != nil {
+		t.Fatalf("NewCarrierTracker() without an API key = %T, want nil", tracker)
+	}
+
+	live, err := NewTrackingService(nil, nil, cfg).GetLiveTracking(context.Background(), "Blue Dart", "BD123")
+	if live != nil || err != nil {
+		t.Errorf("GetLiveTracking() = %+v, %v, want nothing", live, err)
+	}
+}
+
+func TestAfterShipTracker(t *testing.T) {
+	var registered bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("aftership-api-key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"meta":{"code":401,"message":"Invalid API key"}}`))
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/trackings/bluedart/BD123":
+			w.Write([]byte(`{"meta":{"code":200},"data":{"tracking":{"slug":"bluedart","tracking_number":"BD123",
+				"tag":"InTransit","expected_delivery":"2026-03-05T18:00:00+05:30","checkpoints":[
+				{"checkpoint_time":"2026-03-02T09:00:00+05:30","tag":"InfoReceived","message":"Shipment picked up","location":"Mumbai"},
+				{"checkpoint_time":"2026-03-03T05:00:00","tag":"InTransit","message":"Arrived at hub","location":"Pune"}]}}}`))
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"meta":{"code":4004,"message":"Tracking does not exist."}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/trackings":
+			var body struct {
+				Tracking map[string]string `json:"tracking"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			registered = body.Tracking["slug"] == "delhivery" && body.Tracking["tracking_number"] == "DL1"
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"meta":{"code":201},"data":{"tracking":{"slug":"delhivery","tracking_number":"DL1","tag":"Pending"}}}`))
+		}
+	}))
+	defer server.Close()
+
+	tracker := NewAfterShipTracker(config.TrackingConfig{APIKey: "test-key", BaseURL: server.URL + "/"})
+	ctx := context.Background()
+
+	tracking, err := tracker.Track(ctx, "Blue Dart", "BD123")
+	if err != nil {
+		t.Fatalf("Track() error = %v", err)
+	}
+	if tracking.Status != "InTransit" || tracking.EstimatedDelivery != "2026-03-05" || len(tracking.Checkpoints) != 2 {
+		t.Fatalf("tracking = %+v", tracking)
+	}
+	if cp := tracking.Checkpoints[1]; cp.Description != "Arrived at hub" || !cp.Time.Equal(time.Date(2026, 3, 3, 5, 0, 0, 0, time.UTC)) {
+		t.Errorf("checkpoint = %+v, want the hub scan", cp)
+	}
+
+	// Unknown trackings are registered so checkpoints show up on later lookups
+	tracking, err = tracker.Track(ctx, "Delhivery", "DL1")
+	if err != nil {
+		t.Fatalf("Track(unregistered) error = %v", err)
+	}
+	if !registered || tracking.Status != "Pending" || len(tracking.Checkpoints) != 0 {
+		t.Errorf("tracking = %+v, registered %v, want a pending registration", tracking, registered)
+	}
+
+	bad := NewAfterShipTracker(config.TrackingConfig{APIKey: "wrong", BaseURL: server.URL})
+	if _, err := bad.Track(ctx, "Blue Dart", "BD123"); err == nil {
+		t.Error("Track() with a bad API key succeeded")
+	}
+}
@@ -0,0 +1,27 @@
+// internal/domain/order/delivery_estimate.go
+package order
+
+import (
+	"log"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/shipping"
+)
+
+// EstimatedDelivery is when a shipped order should arrive: its ship date plus the
+// lead time of the shipping method it was placed with, in business or calendar days
+// as configured. It returns nil for orders that haven't shipped.
+func (s *Service) EstimatedDelivery(order *Order) *time.Time {
+	if order.ShippedAt == nil {
+		return nil
+	}
+
+	leadTime, err := s.shipping.LeadTime(order.ShippingMethod)
+	if err != nil {
+		log.Printf("Failed to get lead time for order %s, using the default: %v", order.OrderNumber, err)
+		leadTime = shipping.DefaultLeadTimeDays
+	}
+
+	estimate := shipping.AddDeliveryDays(*order.ShippedAt, leadTime, s.config.Order.DeliveryBusinessDays)
+	return &estimate
+}
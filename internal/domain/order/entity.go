@@ -28,12 +28,13 @@ const (
 type PaymentStatus string
 
 const (
-	PaymentStatusPending    PaymentStatus = "pending"
-	PaymentStatusProcessing PaymentStatus = "processing"
-	PaymentStatusPaid       PaymentStatus = "paid"
-	PaymentStatusFailed     PaymentStatus = "failed"
-	PaymentStatusCancelled  PaymentStatus = "cancelled"
-	PaymentStatusRefunded   PaymentStatus = "refunded"
+	PaymentStatusPending           PaymentStatus = "pending"
+	PaymentStatusProcessing        PaymentStatus = "processing"
+	PaymentStatusPaid              PaymentStatus = "paid"
+	PaymentStatusFailed            PaymentStatus = "failed"
+	PaymentStatusCancelled         PaymentStatus = "cancelled"
+	PaymentStatusRefunded          PaymentStatus = "refunded"
+	PaymentStatusPartiallyRefunded PaymentStatus = "partially_refunded"
 )
 
 // Order represents the order entity
@@ -52,10 +53,33 @@ type Order struct {
 	DiscountAmount int64 `gorm:"default:0" json:"discount_amount"`
 	TotalAmount    int64 `gorm:"not null" json:"total_amount"`
 
+	// GST split of TaxAmount: CGST and SGST within the store's state, IGST across states
+	CGSTAmount int64 `gorm:"default:0" json:"cgst_amount"`
+	SGSTAmount int64 `gorm:"default:0" json:"sgst_amount"`
+	IGSTAmount int64 `gorm:"default:0" json:"igst_amount"`
+
+	// Checkout add-ons, included in TotalAmount
+	InsuranceAmount int64 `gorm:"default:0" json:"insurance_amount"`
+	SignatureFee    int64 `gorm:"default:0" json:"signature_fee"`
+	GiftWrapFee     int64 `gorm:"default:0" json:"gift_wrap_fee"`
+
+	// Gift options; the message is printed on the packing slip
+	GiftWrap    bool   `gorm:"default:false" json:"gift_wrap"`
+	GiftMessage string `gorm:"type:text" json:"gift_message,omitempty"`
+
 	// Addresses
 	ShippingAddress Address `gorm:"embedded;embeddedPrefix:shipping_" json:"shipping_address"`
 	BillingAddress  Address `gorm:"embedded;embeddedPrefix:billing_" json:"billing_address"`
 
+	// Address verification hold - fulfillment is suppressed while set
+	AddressVerificationRequired bool   `gorm:"default:false;index" json:"address_verification_required"`
+	AddressVerificationReason   string `gorm:"type:text" json:"address_verification_reason,omitempty"`
+
+	// Payment attempt hold - set once the retry limit is reached; new payment
+	// attempts are refused until an admin clears it
+	PaymentAttemptsExceeded bool       `gorm:"default:false;index" json:"payment_attempts_exceeded"`
+	PaymentAttemptsResetAt  *time.Time `json:"payment_attempts_reset_at,omitempty"` // Attempts before this are not counted
+
 	// Additional Information
 	Currency      string `gorm:"size:3;default:'USD'" json:"currency"`
 	Notes         string `gorm:"type:text" json:"notes"`
@@ -64,6 +88,9 @@ type Order struct {
 	// Coupon/Discount
 	CouponCode string `gorm:"size:50" json:"coupon_code"`
 
+	// Payment method chosen at checkout (razorpay, stripe, cod, ...), selects the payment provider
+	PaymentMethod string `gorm:"size:50" json:"payment_method"`
+
 	// Shipping Information
 	ShippingMethod  string `gorm:"size:100" json:"shipping_method"`
 	TrackingNumber  string `gorm:"size:100" json:"tracking_number"`
@@ -78,25 +105,28 @@ type Order struct {
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relationships
-	Items         []OrderItem          `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"items"`
-	Payments      []Payment            `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"payments,omitempty"`
-	StatusHistory []OrderStatusHistory `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"status_history,omitempty"`
+	Items []OrderItem `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"items"`
+	// Separately fulfilled parts of the order; empty when the order ships as one
+	FulfillmentGroups []FulfillmentGroup   `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"fulfillment_groups,omitempty"`
+	Payments          []Payment            `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"payments,omitempty"`
+	StatusHistory     []OrderStatusHistory `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"status_history,omitempty"`
 }
 
 // OrderItem represents items in an order
 type OrderItem struct {
-	ID               uint      `gorm:"primaryKey" json:"id"`
-	OrderID          uint      `gorm:"not null;index" json:"order_id"`
-	ProductID        uint      `gorm:"not null;index" json:"product_id"`
-	ProductVariantID *uint     `gorm:"index" json:"product_variant_id"`
-	SKU              string    `gorm:"not null;size:100" json:"sku"`
-	Name             string    `gorm:"not null;size:255" json:"name"`
-	VariantTitle     string    `gorm:"size:255" json:"variant_title"`
-	Quantity         int       `gorm:"not null" json:"quantity"`
-	Price            int64     `gorm:"not null" json:"price"`       // Price per unit in cents
-	TotalPrice       int64     `gorm:"not null" json:"total_price"` // Quantity * Price
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID                 uint      `gorm:"primaryKey" json:"id"`
+	OrderID            uint      `gorm:"not null;index" json:"order_id"`
+	ProductID          uint      `gorm:"not null;index" json:"product_id"`
+	ProductVariantID   *uint     `gorm:"index" json:"product_variant_id"`
+	SKU                string    `gorm:"not null;size:100" json:"sku"`
+	Name               string    `gorm:"not null;size:255" json:"name"`
+	VariantTitle       string    `gorm:"size:255" json:"variant_title"`
+	Quantity           int       `gorm:"not null" json:"quantity"`
+	Price              int64     `gorm:"not null" json:"price"`       // Price per unit in cents
+	TotalPrice         int64     `gorm:"not null" json:"total_price"` // Quantity * Price
+	FulfillmentGroupID *uint     `gorm:"index" json:"fulfillment_group_id,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
 }
 
 // Payment represents payment transactions
@@ -110,6 +140,12 @@ type Payment struct {
 	Status            PaymentStatus  `gorm:"not null" json:"status"`
 	Gateway           string         `gorm:"size:50" json:"gateway"`
 	GatewayResponse   string         `gorm:"type:text" json:"gateway_response"` // JSON response from gateway
+	GatewayFee        int64          `gorm:"default:0" json:"gateway_fee"`      // Fee charged by the gateway in cents, including GatewayTax
+	GatewayTax        int64          `gorm:"default:0" json:"gateway_tax"`      // Tax portion of GatewayFee in cents
+	FailureReason     string         `gorm:"type:text" json:"failure_reason,omitempty"`
+	FailureCode       string         `gorm:"size:100" json:"failure_code,omitempty"`
+	RefundID          string         `gorm:"size:255" json:"refund_id,omitempty"` // Gateway refund ID once refunded
+	RefundedAmount    int64          `gorm:"default:0" json:"refunded_amount"`    // In cents, across all refunds
 	ProcessedAt       *time.Time     `json:"processed_at"`
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
@@ -124,8 +160,19 @@ type OrderStatusHistory struct {
 	Comment   string      `gorm:"type:text" json:"comment"`
 	CreatedBy uint        `gorm:"index" json:"created_by"` // User ID who made the change
 	CreatedAt time.Time   `json:"created_at"`
+
+	// Resolved from CreatedBy when the order is loaded
+	ActorName string `gorm:"-" json:"actor_name"`
+	ActorType string `gorm:"-" json:"actor_type"` // system, admin or customer
 }
 
+// Who made a status change
+const (
+	HistoryActorSystem   = "system"
+	HistoryActorAdmin    = "admin"
+	HistoryActorCustomer = "customer"
+)
+
 // Address represents shipping/billing address (embedded in Order)
 type Address struct {
 	FirstName    string `gorm:"size:100" json:"first_name"`
@@ -140,11 +187,93 @@ type Address struct {
 	Phone        string `gorm:"size:20" json:"phone"`
 }
 
+// ExportFrequency represents how often a scheduled order export runs
+type ExportFrequency string
+
+const (
+	ExportFrequencyDaily   ExportFrequency = "daily"
+	ExportFrequencyWeekly  ExportFrequency = "weekly"
+	ExportFrequencyMonthly ExportFrequency = "monthly"
+)
+
+// OrderExportSchedule is a recurring order/payment export delivered to a list of recipients
+type OrderExportSchedule struct {
+	ID         uint            `gorm:"primaryKey" json:"id"`
+	Name       string          `gorm:"not null;size:100" json:"name"`
+	Frequency  ExportFrequency `gorm:"not null;size:20" json:"frequency"`
+	RunHour    int             `gorm:"default:0" json:"run_hour"`   // Store-local hour the export is generated
+	Statuses   string          `gorm:"size:255" json:"statuses"`    // Comma-separated order statuses, empty for all
+	Recipients string          `gorm:"type:text" json:"recipients"` // Comma-separated email addresses
+	IsActive   bool            `gorm:"default:true;index" json:"is_active"`
+	LastRunAt  *time.Time      `json:"last_run_at"`
+	NextRunAt  time.Time       `gorm:"not null;index" json:"next_run_at"`
+	CreatedBy  uint            `json:"created_by"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt  `gorm:"index" json:"-"`
+}
+
+// OrderExportRun is a single generated export file
+type OrderExportRun struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	ScheduleID  *uint     `gorm:"index" json:"schedule_id"` // Nil for ad-hoc runs
+	PeriodStart time.Time `gorm:"not null" json:"period_start"`
+	PeriodEnd   time.Time `gorm:"not null" json:"period_end"`     // Exclusive
+	Status      string    `gorm:"not null;size:20" json:"status"` // completed, failed
+	FileName    string    `gorm:"size:255" json:"file_name"`
+	FilePath    string    `gorm:"size:500" json:"-"`
+	FileSize    int64     `json:"file_size"`
+	RowCount    int       `json:"row_count"`
+	Error       string    `gorm:"type:text" json:"error,omitempty"`
+	DeliveredTo string    `gorm:"type:text" json:"delivered_to,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// FulfillmentType separates items that ship from items delivered electronically
+type FulfillmentType string
+
+const (
+	FulfillmentTypePhysical FulfillmentType = "physical"
+	FulfillmentTypeDigital  FulfillmentType = "digital"
+)
+
+// FulfillmentStatus represents the progress of a fulfillment group
+type FulfillmentStatus string
+
+const (
+	FulfillmentStatusPending    FulfillmentStatus = "pending"
+	FulfillmentStatusProcessing FulfillmentStatus = "processing"
+	FulfillmentStatusShipped    FulfillmentStatus = "shipped"
+	FulfillmentStatusDelivered  FulfillmentStatus = "delivered"
+	FulfillmentStatusCancelled  FulfillmentStatus = "cancelled"
+)
+
+// FulfillmentGroup is a part of an order fulfilled on its own, with its own status
+// and tracking. The order keeps the payment and totals for all of its groups.
+type FulfillmentGroup struct {
+	ID              uint              `gorm:"primaryKey" json:"id"`
+	OrderID         uint              `gorm:"not null;index" json:"order_id"`
+	Type            FulfillmentType   `gorm:"not null;size:20" json:"type"`
+	Source          string            `gorm:"size:20" json:"source"` // warehouse or dropship; empty for digital groups
+	Status          FulfillmentStatus `gorm:"not null;size:20;default:'pending'" json:"status"`
+	TrackingNumber  string            `gorm:"size:100" json:"tracking_number"`
+	ShippingCarrier string            `gorm:"size:50" json:"shipping_carrier"`
+	ShippedAt       *time.Time        `json:"shipped_at"`
+	DeliveredAt     *time.Time        `json:"delivered_at"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+
+	Items []OrderItem `gorm:"foreignKey:FulfillmentGroupID" json:"items,omitempty"`
+}
+
 // TableName overrides
-func (Order) TableName() string              { return "orders" }
-func (OrderItem) TableName() string          { return "order_items" }
-func (Payment) TableName() string            { return "payments" }
-func (OrderStatusHistory) TableName() string { return "order_status_history" }
+func (Order) TableName() string               { return "orders" }
+func (OrderItem) TableName() string           { return "order_items" }
+func (Payment) TableName() string             { return "payments" }
+func (OrderStatusHistory) TableName() string  { return "order_status_history" }
+func (OrderExportSchedule) TableName() string { return "order_export_schedules" }
+func (OrderExportRun) TableName() string      { return "order_export_runs" }
+func (FulfillmentGroup) TableName() string    { return "order_fulfillment_groups" }
 
 // Business methods for Order
 
@@ -172,6 +301,16 @@ func (o *Order) CanBeRefunded() bool {
 		(o.Status == OrderStatusDelivered || o.Status == OrderStatusCompleted)
 }
 
+// IsOnAddressHold checks if fulfillment is suppressed pending address verification
+func (o *Order) IsOnAddressHold() bool {
+	return o.AddressVerificationRequired
+}
+
+// IsOnPaymentAttemptHold checks if new payment attempts are refused pending admin review
+func (o *Order) IsOnPaymentAttemptHold() bool {
+	return o.PaymentAttemptsExceeded
+}
+
 // IsCompleted checks if order is completed
 func (o *Order) IsCompleted() bool {
 	return o.Status == OrderStatusCompleted || o.Status == OrderStatusDelivered
@@ -0,0 +1,205 @@
+// internal/domain/order/export.go
+package order
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/pkg/email"
+	"gorm.io/gorm"
+)
+
+// ExportService generates order/payment exports and runs scheduled export jobs
+type ExportService struct {
+	db           *gorm.DB
+	config       *config.Config
+	emailService *email.EmailService
+}
+
+// NewExportService creates a new order export service
+func NewExportService(db *gorm.DB, cfg *config.Config) *ExportService {
+	return &ExportService{
+		db:           db,
+		config:       cfg,
+		emailService: email.NewEmailService(db, cfg),
+	}
+}
+
+// OrderExportRequest represents ad-hoc order export parameters
+type OrderExportRequest struct {
+	DateFrom string `form:"date_from" binding:"required"` // YYYY-MM-DD
+	DateTo   string `form:"date_to" binding:"required"`   // YYYY-MM-DD, inclusive
+	Statuses string `form:"status"`                       // Comma-separated, empty for all
+}
+
+// orderExportHeaders are the CSV columns of an order export
+var orderExportHeaders = []string{
+	"Order Number", "Order Date", "Status", "Payment Status", "Customer Email", "Customer Name",
+	"Items", "Subtotal", "Tax", "Shipping", "Discount", "Total", "Currency", "Coupon Code",
+	"Payment Method", "Payment Gateway", "Payment Reference", "Paid At", "Shipping City",
+	"Shipping State", "Shipping Country",
+}
+
+// ExportOrders exports orders placed within the requested store-local dates as CSV
+func (s *ExportService) ExportOrders(req *OrderExportRequest) ([]byte, string, error) {
+	loc := s.config.GetLocation()
+	from, err := time.ParseInLocation("2006-01-02", req.DateFrom, loc)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid date_from, expected YYYY-MM-DD")
+	}
+	to, err := time.ParseInLocation("2006-01-02", req.DateTo, loc)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid date_to, expected YYYY-MM-DD")
+	}
+	if to.Before(from) {
+		return nil, "", fmt.Errorf("date_to must not be before date_from")
+	}
+	if to.Sub(from) > 366*24*time.Hour {
+		return nil, "", fmt.Errorf("export range cannot exceed one year")
+	}
+
+	statuses, err := parseExportStatuses(req.Statuses)
+	if err != nil {
+		return nil, "", err
+	}
+
+	end := to.AddDate(0, 0, 1)
+	data, _, err := s.generateOrderCSV(from, end, statuses)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, exportFileName(from, end), nil
+}
+
+// generateOrderCSV builds the order/payment CSV for orders created in [start, end)
+func (s *ExportService) generateOrderCSV(start, end time.Time, statuses []OrderStatus) ([]byte, int, error) {
+	query := s.db.Model(&Order{}).
+		Preload("Items").
+		Preload("Payments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("created_at >= ? AND created_at < ?", start, end)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+
+	var orders []Order
+	if err := query.Order("created_at ASC, id ASC").Find(&orders).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to load orders for export: %w", err)
+	}
+
+	loc := s.config.GetLocation()
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(orderExportHeaders); err != nil {
+		return nil, 0, fmt.Errorf("failed to write export header: %w", err)
+	}
+
+	for i := range orders {
+		if err := writer.Write(orderExportRow(&orders[i], loc)); err != nil {
+			return nil, 0, fmt.Errorf("failed to write export row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, 0, fmt.Errorf("failed to write export: %w", err)
+	}
+
+	return buf.Bytes(), len(orders), nil
+}
+
+// orderExportRow flattens an order and its settling payment into a CSV row
+func orderExportRow(o *Order, loc *time.Location) []string {
+	itemCount := 0
+	for _, item := range o.Items {
+		itemCount += item.Quantity
+	}
+
+	// Prefer the successful payment; fall back to the latest attempt
+	var payment *Payment
+	for i := range o.Payments {
+		if o.Payments[i].Status == PaymentStatusPaid || payment == nil || payment.Status != PaymentStatusPaid {
+			payment = &o.Payments[i]
+		}
+	}
+
+	var method, gateway, reference, paidAt string
+	if payment != nil {
+		method = payment.PaymentMethod
+		gateway = payment.Gateway
+		reference = payment.PaymentProviderID
+		if payment.Status == PaymentStatusPaid && payment.ProcessedAt != nil {
+			paidAt = payment.ProcessedAt.In(loc).Format("2006-01-02 15:04:05")
+		}
+	}
+
+	return []string{
+		o.OrderNumber,
+		o.CreatedAt.In(loc).Format("2006-01-02 15:04:05"),
+		string(o.Status),
+		string(o.PaymentStatus),
+		o.Email,
+		strings.TrimSpace(o.ShippingAddress.FirstName + " " + o.ShippingAddress.LastName),
+		strconv.Itoa(itemCount),
+		exportAmount(o.SubtotalAmount),
+		exportAmount(o.TaxAmount),
+		exportAmount(o.ShippingAmount),
+		exportAmount(o.DiscountAmount),
+		exportAmount(o.TotalAmount),
+		o.Currency,
+		o.CouponCode,
+		method,
+		gateway,
+		reference,
+		paidAt,
+		o.ShippingAddress.City,
+		o.ShippingAddress.State,
+		o.ShippingAddress.Country,
+	}
+}
+
+// exportAmount formats cents as a plain decimal amount
+func exportAmount(cents int64) string {
+	return strconv.FormatFloat(float64(cents)/100, 'f', 2, 64)
+}
+
+// exportFileName names an export by its inclusive date range
+func exportFileName(start, end time.Time) string {
+	last := end.AddDate(0, 0, -1)
+	return fmt.Sprintf("orders_%s_to_%s.csv", start.Format("20060102"), last.Format("20060102"))
+}
+
+// parseExportStatuses parses a comma-separated status filter
+func parseExportStatuses(value string) ([]OrderStatus, error) {
+	var statuses []OrderStatus
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		status := OrderStatus(part)
+		if !isValidOrderStatus(status) {
+			return nil, fmt.Errorf("invalid order status: %s", part)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// isValidOrderStatus checks if the status is a known order status
+func isValidOrderStatus(status OrderStatus) bool {
+	switch status {
+	case OrderStatusPending, OrderStatusPaymentProcessing, OrderStatusConfirmed, OrderStatusProcessing,
+		OrderStatusShipped, OrderStatusOutForDelivery, OrderStatusDelivered, OrderStatusCompleted,
+		OrderStatusCancelled, OrderStatusRefunded:
+		return true
+	}
+	return false
+}
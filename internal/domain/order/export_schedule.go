@@ -0,0 +1,399 @@
+// internal/domain/order/export_schedule.go
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/pkg/email"
+	"gorm.io/gorm"
+)
+
+// ErrExportNotFound is returned when an export schedule or run does not exist
+var ErrExportNotFound = errors.New("export not found")
+
+// Export run outcomes
+const (
+	ExportRunCompleted = "completed"
+	ExportRunFailed    = "failed"
+)
+
+// ExportScheduleRequest represents export schedule create/update data
+type ExportScheduleRequest struct {
+	Name       string          `json:"name" binding:"required"`
+	Frequency  ExportFrequency `json:"frequency" binding:"required"`
+	RunHour    int             `json:"run_hour"`
+	Statuses   []OrderStatus   `json:"statuses"`
+	Recipients []string        `json:"recipients"`
+	IsActive   *bool           `json:"is_active"`
+}
+
+// ListExportSchedules retrieves all export schedules
+func (s *ExportService) ListExportSchedules() ([]OrderExportSchedule, error) {
+	var schedules []OrderExportSchedule
+	if err := s.db.Order("created_at DESC").Find(&schedules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get export schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// CreateExportSchedule creates a recurring export starting with the next period boundary
+func (s *ExportService) CreateExportSchedule(req *ExportScheduleRequest, adminID uint) (*OrderExportSchedule, error) {
+	schedule := &OrderExportSchedule{IsActive: true, CreatedBy: adminID}
+	if err := s.applyScheduleRequest(schedule, req); err != nil {
+		return nil, err
+	}
+
+	// GORM skips zero values on create and reads the column default back into the
+	// struct, so a paused schedule needs an explicit update
+	isActive := schedule.IsActive
+	if err := s.db.Create(schedule).Error; err != nil {
+		return nil, fmt.Errorf("failed to create export schedule: %w", err)
+	}
+	if !isActive {
+		if err := s.db.Model(schedule).Update("is_active", false).Error; err != nil {
+			return nil, fmt.Errorf("failed to create export schedule: %w", err)
+		}
+	}
+
+	return schedule, nil
+}
+
+// UpdateExportSchedule replaces a schedule's settings and recomputes its next run
+func (s *ExportService) UpdateExportSchedule(id uint, req *ExportScheduleRequest) (*OrderExportSchedule, error) {
+	schedule, err := s.getExportSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyScheduleRequest(schedule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(schedule).Error; err != nil {
+		return nil, fmt.Errorf("failed to update export schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// DeleteExportSchedule removes a schedule; files from past runs are kept
+func (s *ExportService) DeleteExportSchedule(id uint) error {
+	if _, err := s.getExportSchedule(id); err != nil {
+		return err
+	}
+	if err := s.db.Delete(&OrderExportSchedule{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete export schedule: %w", err)
+	}
+	return nil
+}
+
+// ListExportRuns retrieves the most recent runs of a schedule
+func (s *ExportService) ListExportRuns(scheduleID uint, limit int) ([]OrderExportRun, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	var runs []OrderExportRun
+	err := s.db.Where("schedule_id = ?", scheduleID).Order("created_at DESC").Limit(limit).Find(&runs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get export runs: %w", err)
+	}
+	return runs, nil
+}
+
+// GetExportRun retrieves a completed export run whose file is still on disk
+func (s *ExportService) GetExportRun(id uint) (*OrderExportRun, error) {
+	var run OrderExportRun
+	if err := s.db.First(&run, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExportNotFound
+		}
+		return nil, fmt.Errorf("failed to get export run: %w", err)
+	}
+
+	if run.Status != ExportRunCompleted || run.FilePath == "" {
+		return nil, fmt.Errorf("%w: run %d has no file", ErrExportNotFound, id)
+	}
+	if _, err := os.Stat(run.FilePath); err != nil {
+		return nil, fmt.Errorf("%w: file for run %d is no longer available", ErrExportNotFound, id)
+	}
+
+	return &run, nil
+}
+
+// RunExportScheduleNow generates the schedule's most recently completed period immediately,
+// without changing when it next runs
+func (s *ExportService) RunExportScheduleNow(id uint) (*OrderExportRun, error) {
+	schedule, err := s.getExportSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := exportPeriod(schedule.Frequency, time.Now().In(s.config.GetLocation()))
+	return s.runExport(schedule, start, end)
+}
+
+// RunDueExports generates every active schedule whose next run time has passed. Each schedule
+// is claimed by advancing next_run_at conditionally, so concurrent instances don't double-send.
+func (s *ExportService) RunDueExports(now time.Time) int {
+	var due []OrderExportSchedule
+	if err := s.db.Where("is_active = ? AND next_run_at <= ?", true, now).Find(&due).Error; err != nil {
+		log.Printf("Failed to load due export schedules: %v", err)
+		return 0
+	}
+
+	ran := 0
+	loc := s.config.GetLocation()
+	for i := range due {
+		schedule := &due[i]
+		scheduledAt := schedule.NextRunAt.In(loc)
+		next := nextExportRun(schedule.Frequency, schedule.RunHour, scheduledAt)
+
+		// Missed periods are caught up one per check since next stays in the past
+		result := s.db.Model(&OrderExportSchedule{}).
+			Where("id = ? AND next_run_at = ?", schedule.ID, schedule.NextRunAt).
+			Updates(map[string]interface{}{"next_run_at": next, "last_run_at": now})
+		if result.Error != nil {
+			log.Printf("Failed to claim export schedule %d: %v", schedule.ID, result.Error)
+			continue
+		}
+		if result.RowsAffected == 0 {
+			continue // Another instance took it
+		}
+
+		start, end := exportPeriod(schedule.Frequency, scheduledAt)
+		if _, err := s.runExport(schedule, start, end); err != nil {
+			log.Printf("Scheduled export %d failed: %v", schedule.ID, err)
+		}
+		ran++
+	}
+
+	return ran
+}
+
+// StartScheduler checks for due exports on the configured interval until ctx is cancelled
+func (s *ExportService) StartScheduler(ctx context.Context) {
+	interval := s.config.Order.ExportCheckInterval
+	if interval <= 0 {
+		log.Println("Order export scheduler disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.RunDueExports(time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runExport generates the file for [start, end), records the run and notifies recipients
+func (s *ExportService) runExport(schedule *OrderExportSchedule, start, end time.Time) (*OrderExportRun, error) {
+	run := &OrderExportRun{
+		ScheduleID:  &schedule.ID,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Status:      ExportRunFailed,
+	}
+
+	statuses, _ := parseExportStatuses(schedule.Statuses)
+	data, rows, err := s.generateOrderCSV(start, end, statuses)
+	if err == nil {
+		run.FileName = exportFileName(start, end)
+		run.RowCount = rows
+		run.FilePath, err = s.writeExportFile(schedule.ID, run.FileName, data)
+	}
+
+	if err != nil {
+		run.Error = err.Error()
+	} else {
+		run.Status = ExportRunCompleted
+		run.FileSize = int64(len(data))
+	}
+
+	if createErr := s.db.Create(run).Error; createErr != nil {
+		return nil, fmt.Errorf("failed to record export run: %w", createErr)
+	}
+	if err != nil {
+		return run, err
+	}
+
+	if recipients := splitRecipients(schedule.Recipients); len(recipients) > 0 {
+		if sendErr := s.sendExportEmail(schedule, run, recipients); sendErr != nil {
+			log.Printf("Failed to email export run %d: %v", run.ID, sendErr)
+		} else {
+			run.DeliveredTo = strings.Join(recipients, ",")
+			s.db.Model(run).Update("delivered_to", run.DeliveredTo)
+		}
+	}
+
+	return run, nil
+}
+
+// writeExportFile stores export data under the export directory, grouped by schedule
+func (s *ExportService) writeExportFile(scheduleID uint, fileName string, data []byte) (string, error) {
+	dir := filepath.Join(s.config.Order.ExportDir, fmt.Sprintf("schedule_%d", scheduleID))
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	// Re-runs of the same period get their own file rather than overwriting a delivered one
+	path := filepath.Join(dir, fmt.Sprintf("%d_%s", time.Now().Unix(), fileName))
+	if err := os.WriteFile(path, data, 0640); err != nil {
+		return "", fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	return path, nil
+}
+
+// sendExportEmail sends recipients a download link for a completed run
+func (s *ExportService) sendExportEmail(schedule *OrderExportSchedule, run *OrderExportRun, recipients []string) error {
+	loc := s.config.GetLocation()
+	period := fmt.Sprintf("%s to %s",
+		run.PeriodStart.In(loc).Format("2 Jan 2006"),
+		run.PeriodEnd.In(loc).AddDate(0, 0, -1).Format("2 Jan 2006"))
+	link := fmt.Sprintf("%s/admin/order-exports/runs/%d", strings.TrimRight(s.config.App.FrontendURL, "/"), run.ID)
+
+	subject := fmt.Sprintf("%s: orders %s", schedule.Name, period)
+	html := fmt.Sprintf(`<p>The scheduled order export <strong>%s</strong> for %s is ready.</p>
+<p>Orders: %d</p>
+<p><a href="%s">Download %s</a></p>
+<p>The link requires an admin login.</p>`, schedule.Name, period, run.RowCount, link, run.FileName)
+
+	return s.emailService.SendEmail(context.Background(), &email.Email{
+		To:          recipients,
+		Subject:     subject,
+		HTMLContent: html,
+		TextContent: fmt.Sprintf("The scheduled order export %s for %s is ready (%d orders): %s", schedule.Name, period, run.RowCount, link),
+		Type:        email.EmailType("order_export"),
+	})
+}
+
+// applyScheduleRequest validates a request and copies it onto the schedule
+func (s *ExportService) applyScheduleRequest(schedule *OrderExportSchedule, req *ExportScheduleRequest) error {
+	switch req.Frequency {
+	case ExportFrequencyDaily, ExportFrequencyWeekly, ExportFrequencyMonthly:
+	default:
+		return fmt.Errorf("invalid frequency: %s", req.Frequency)
+	}
+	if req.RunHour < 0 || req.RunHour > 23 {
+		return fmt.Errorf("run_hour must be between 0 and 23")
+	}
+
+	statuses := make([]string, 0, len(req.Statuses))
+	for _, status := range req.Statuses {
+		if !isValidOrderStatus(status) {
+			return fmt.Errorf("invalid order status: %s", status)
+		}
+		statuses = append(statuses, string(status))
+	}
+
+	recipients := make([]string, 0, len(req.Recipients))
+	for _, recipient := range req.Recipients {
+		addr, err := mail.ParseAddress(strings.TrimSpace(recipient))
+		if err != nil {
+			return fmt.Errorf("invalid recipient email: %s", recipient)
+		}
+		recipients = append(recipients, addr.Address)
+	}
+
+	schedule.Name = strings.TrimSpace(req.Name)
+	schedule.Frequency = req.Frequency
+	schedule.RunHour = req.RunHour
+	schedule.Statuses = strings.Join(statuses, ",")
+	schedule.Recipients = strings.Join(recipients, ",")
+	if req.IsActive != nil {
+		schedule.IsActive = *req.IsActive
+	}
+	schedule.NextRunAt = nextExportRun(schedule.Frequency, schedule.RunHour, time.Now().In(s.config.GetLocation()))
+
+	return nil
+}
+
+// getExportSchedule loads a schedule by ID
+func (s *ExportService) getExportSchedule(id uint) (*OrderExportSchedule, error) {
+	var schedule OrderExportSchedule
+	if err := s.db.First(&schedule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExportNotFound
+		}
+		return nil, fmt.Errorf("failed to get export schedule: %w", err)
+	}
+	return &schedule, nil
+}
+
+// periodBoundary returns the start of the day, ISO week or month containing t
+func periodBoundary(frequency ExportFrequency, t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch frequency {
+	case ExportFrequencyWeekly:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case ExportFrequencyMonthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+// advancePeriod moves a period boundary forward by one period
+func advancePeriod(frequency ExportFrequency, boundary time.Time) time.Time {
+	switch frequency {
+	case ExportFrequencyWeekly:
+		return boundary.AddDate(0, 0, 7)
+	case ExportFrequencyMonthly:
+		return boundary.AddDate(0, 1, 0)
+	default:
+		return boundary.AddDate(0, 0, 1)
+	}
+}
+
+// exportPeriod returns the last complete period before runAt, e.g. the previous calendar
+// month for a monthly export
+func exportPeriod(frequency ExportFrequency, runAt time.Time) (time.Time, time.Time) {
+	end := periodBoundary(frequency, runAt)
+	switch frequency {
+	case ExportFrequencyWeekly:
+		return end.AddDate(0, 0, -7), end
+	case ExportFrequencyMonthly:
+		return end.AddDate(0, -1, 0), end
+	default:
+		return end.AddDate(0, 0, -1), end
+	}
+}
+
+// nextExportRun returns the first period start (at runHour) strictly after the given time
+func nextExportRun(frequency ExportFrequency, runHour int, after time.Time) time.Time {
+	boundary := periodBoundary(frequency, after)
+	for {
+		run := time.Date(boundary.Year(), boundary.Month(), boundary.Day(), runHour, 0, 0, 0, boundary.Location())
+		if run.After(after) {
+			return run
+		}
+		boundary = advancePeriod(frequency, boundary)
+	}
+}
+
+// splitRecipients parses the stored comma-separated recipient list
+func splitRecipients(value string) []string {
+	var recipients []string
+	for _, recipient := range strings.Split(value, ",") {
+		if recipient = strings.TrimSpace(recipient); recipient != "" {
+			recipients = append(recipients, recipient)
+		}
+	}
+	return recipients
+}
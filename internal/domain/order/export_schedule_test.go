@@ -0,0 +1,31 @@
+// internal/domain/order/export_schedule_test.go
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+func TestCreatePausedExportSchedule(t *testing.T) {
+	db := testutil.NewDB(t, &Order{}, &OrderItem{}, &OrderExportSchedule{}, &OrderExportRun{})
+	s := NewExportService(db, &config.Config{})
+	paused := false
+
+	schedule, err := s.CreateExportSchedule(&ExportScheduleRequest{Name: "Daily orders", Frequency: ExportFrequencyDaily,
+		RunHour: 6, Recipients: []string{"ops@example.com"}, IsActive: &paused}, 1)
+	if err != nil {
+		t.Fatalf("CreateExportSchedule() error = %v", err)
+	}
+
+	var stored OrderExportSchedule
+	db.First(&stored, schedule.ID)
+	if schedule.IsActive || stored.IsActive {
+		t.Errorf("schedule active = %v, stored %v, want paused", schedule.IsActive, stored.IsActive)
+	}
+	if ran := s.RunDueExports(time.Now().AddDate(0, 0, 2)); ran != 0 {
+		t.Errorf("RunDueExports() = %d, want the paused schedule skipped", ran)
+	}
+}
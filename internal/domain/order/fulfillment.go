@@ -0,0 +1,259 @@
+// internal/domain/order/fulfillment.go
+package order
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"gorm.io/gorm"
+)
+
+// ErrFulfillmentGroupNotFound is returned when the fulfillment group does not belong to the order
+var ErrFulfillmentGroupNotFound = errors.New("fulfillment group not found")
+
+// ErrInvalidFulfillmentTransition is returned when a fulfillment group cannot move to the requested status
+var ErrInvalidFulfillmentTransition = errors.New("invalid fulfillment status transition")
+
+// FulfillmentGroupUpdateRequest updates the status and tracking of a fulfillment group
+type FulfillmentGroupUpdateRequest struct {
+	Status          FulfillmentStatus `json:"status" binding:"required,oneof=processing shipped delivered cancelled"`
+	TrackingNumber  string            `json:"tracking_number"`
+	ShippingCarrier string            `json:"shipping_carrier"`
+}
+
+// fulfillmentKey identifies the group an item is fulfilled in
+type fulfillmentKey struct {
+	Type   FulfillmentType
+	Source string
+}
+
+// SplitFulfillment splits a confirmed order into fulfillment groups by item type
+// (digital/physical) and source (warehouse/dropship). Orders whose items all share
+// one group are left whole. It is a no-op when splitting is disabled or the order
+// has already been split, so it is safe to call on every confirmation path.
+func SplitFulfillment(db *gorm.DB, cfg *config.Config, orderID uint) error {
+	if !cfg.Order.SplitFulfillment {
+		return nil
+	}
+
+	var existing int64
+	if err := db.Model(&FulfillmentGroup{}).Where("order_id = ?", orderID).Count(&existing).Error; err != nil {
+		return fmt.Errorf("failed to check fulfillment groups: %w", err)
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	var items []OrderItem
+	if err := db.Where("order_id = ?", orderID).Find(&items).Error; err != nil {
+		return fmt.Errorf("failed to load order items: %w", err)
+	}
+
+	productIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	var products []product.Product
+	if err := db.Unscoped().Select("id, is_digital, requires_shipping, fulfillment_source").
+		Where("id IN ?", productIDs).Find(&products).Error; err != nil {
+		return fmt.Errorf("failed to load order products: %w", err)
+	}
+	productsByID := make(map[uint]*product.Product, len(products))
+	for i := range products {
+		productsByID[products[i].ID] = &products[i]
+	}
+
+	itemsByKey := make(map[fulfillmentKey][]uint)
+	for _, item := range items {
+		key := itemFulfillmentKey(productsByID[item.ProductID])
+		itemsByKey[key] = append(itemsByKey[key], item.ID)
+	}
+	if len(itemsByKey) < 2 {
+		return nil
+	}
+
+	// Create groups in a stable order: physical before digital, warehouse before dropship
+	keys := make([]fulfillmentKey, 0, len(itemsByKey))
+	for key := range itemsByKey {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Type != keys[j].Type {
+			return keys[i].Type == FulfillmentTypePhysical
+		}
+		return keys[i].Source > keys[j].Source
+	})
+
+	for _, key := range keys {
+		group := FulfillmentGroup{
+			OrderID: orderID,
+			Type:    key.Type,
+			Source:  key.Source,
+			Status:  FulfillmentStatusPending,
+		}
+		if err := db.Create(&group).Error; err != nil {
+			return fmt.Errorf("failed to create fulfillment group: %w", err)
+		}
+		if err := db.Model(&OrderItem{}).Where("id IN ?", itemsByKey[key]).
+			Update("fulfillment_group_id", group.ID).Error; err != nil {
+			return fmt.Errorf("failed to assign items to fulfillment group: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// itemFulfillmentKey returns the fulfillment group of an item's product. Products
+// that no longer exist are treated as physical warehouse items.
+func itemFulfillmentKey(prod *product.Product) fulfillmentKey {
+	if prod == nil {
+		return fulfillmentKey{Type: FulfillmentTypePhysical, Source: product.FulfillmentSourceWarehouse}
+	}
+	if prod.IsDigital || !prod.RequiresShipping {
+		return fulfillmentKey{Type: FulfillmentTypeDigital}
+	}
+	source := prod.FulfillmentSource
+	if source == "" {
+		source = product.FulfillmentSourceWarehouse
+	}
+	return fulfillmentKey{Type: FulfillmentTypePhysical, Source: source}
+}
+
+// GetFulfillmentGroups returns the fulfillment groups of an order with their items
+func (s *Service) GetFulfillmentGroups(orderID uint) ([]FulfillmentGroup, error) {
+	var groups []FulfillmentGroup
+	if err := s.db.Preload("Items").Where("order_id = ?", orderID).Order("id ASC").Find(&groups).Error; err != nil {
+		return nil, fmt.Errorf("failed to get fulfillment groups: %w", err)
+	}
+	return groups, nil
+}
+
+// UpdateFulfillmentGroup moves a fulfillment group to a new status and records its
+// tracking. Once every group has shipped or been delivered the order follows.
+func (s *Service) UpdateFulfillmentGroup(orderID, groupID uint, req *FulfillmentGroupUpdateRequest, updatedBy uint) (*FulfillmentGroup, error) {
+	var group FulfillmentGroup
+	if err := s.db.Where("id = ? AND order_id = ?", groupID, orderID).First(&group).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFulfillmentGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to get fulfillment group: %w", err)
+	}
+
+	if !isValidFulfillmentTransition(group.Type, group.Status, req.Status) {
+		return nil, fmt.Errorf("%w: from %s to %s", ErrInvalidFulfillmentTransition, group.Status, req.Status)
+	}
+
+	var ord Order
+	if err := s.db.Select("id, status, address_verification_required, address_verification_reason").First(&ord, orderID).Error; err != nil {
+		return nil, fmt.Errorf("order not found: %w", err)
+	}
+	if ord.AddressVerificationRequired && group.Type == FulfillmentTypePhysical && req.Status != FulfillmentStatusCancelled {
+		return nil, fmt.Errorf("%w: %s", ErrAddressVerificationRequired, ord.AddressVerificationReason)
+	}
+
+	now := time.Now().UTC()
+	updates := map[string]interface{}{
+		"status": req.Status,
+	}
+	if req.TrackingNumber != "" {
+		updates["tracking_number"] = req.TrackingNumber
+	}
+	if req.ShippingCarrier != "" {
+		updates["shipping_carrier"] = req.ShippingCarrier
+	}
+	switch req.Status {
+	case FulfillmentStatusShipped:
+		updates["shipped_at"] = now
+	case FulfillmentStatusDelivered:
+		updates["delivered_at"] = now
+	}
+
+	if err := s.db.Model(&group).Updates(updates).Error; err != nil {
+		return nil, fmt.Errorf("failed to update fulfillment group: %w", err)
+	}
+
+	s.rollUpFulfillmentStatus(&ord, updatedBy)
+
+	if err := s.db.Preload("Items").First(&group, group.ID).Error; err != nil {
+		return nil, fmt.Errorf("failed to reload fulfillment group: %w", err)
+	}
+	return &group, nil
+}
+
+// rollUpFulfillmentStatus advances the order once all of its active groups have
+// shipped or been delivered. Digital groups count as shipped once delivered.
+func (s *Service) rollUpFulfillmentStatus(ord *Order, updatedBy uint) {
+	groups, err := s.GetFulfillmentGroups(ord.ID)
+	if err != nil {
+		log.Printf("Failed to roll up fulfillment status for order %d: %v", ord.ID, err)
+		return
+	}
+
+	active, shipped, delivered := 0, 0, 0
+	for _, group := range groups {
+		switch group.Status {
+		case FulfillmentStatusCancelled:
+			continue
+		case FulfillmentStatusShipped:
+			shipped++
+		case FulfillmentStatusDelivered:
+			shipped++
+			delivered++
+		}
+		active++
+	}
+	if active == 0 {
+		return
+	}
+
+	// Walk the order through the intermediate statuses it has to pass
+	var steps []OrderStatus
+	switch {
+	case delivered == active:
+		steps = []OrderStatus{OrderStatusProcessing, OrderStatusShipped, OrderStatusDelivered}
+	case shipped == active:
+		steps = []OrderStatus{OrderStatusProcessing, OrderStatusShipped}
+	default:
+		return
+	}
+
+	current := ord.Status
+	for _, step := range steps {
+		if !s.isValidStatusTransition(current, step) {
+			continue
+		}
+		if err := s.UpdateOrderStatus(ord.ID, step, "All fulfillment groups "+string(step), updatedBy); err != nil {
+			log.Printf("Failed to move order %d to %s: %v", ord.ID, step, err)
+			return
+		}
+		current = step
+	}
+}
+
+// isValidFulfillmentTransition checks if a group can move between statuses. Digital
+// groups are never shipped; they go straight to delivered.
+func isValidFulfillmentTransition(groupType FulfillmentType, from, to FulfillmentStatus) bool {
+	if to == FulfillmentStatusCancelled {
+		return from != FulfillmentStatusDelivered && from != FulfillmentStatusCancelled
+	}
+
+	if groupType == FulfillmentTypeDigital {
+		return (from == FulfillmentStatusPending || from == FulfillmentStatusProcessing) &&
+			(to == FulfillmentStatusProcessing || to == FulfillmentStatusDelivered) && from != to
+	}
+
+	switch from {
+	case FulfillmentStatusPending:
+		return to == FulfillmentStatusProcessing
+	case FulfillmentStatusProcessing:
+		return to == FulfillmentStatusShipped
+	case FulfillmentStatusShipped:
+		return to == FulfillmentStatusDelivered
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+// internal/domain/order/lookup.go
+package order
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrOrderLookupFailed is returned for any order number and email that don't match
+// an order together, so the lookup cannot be used to find out which orders exist
+var ErrOrderLookupFailed = errors.New("no order matches that order number and email")
+
+// LookupOrder finds an order for a customer who isn't signed in. Both the order number
+// and the email the order was placed with must match; the email is compared ignoring
+// case, as emails are stored lowercase.
+func (s *Service) LookupOrder(orderNumber, email string) (*Order, error) {
+	orderNumber = strings.TrimSpace(orderNumber)
+	email = strings.ToLower(strings.TrimSpace(email))
+	if orderNumber == "" || email == "" {
+		return nil, ErrOrderLookupFailed
+	}
+
+	var order Order
+	err := s.db.
+		Preload("StatusHistory", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at DESC")
+		}).
+		Where("order_number = ? AND LOWER(email) = ?", orderNumber, email).
+		First(&order).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderLookupFailed
+		}
+		return nil, fmt.Errorf("failed to look up order: %w", err)
+	}
+
+	return &order, nil
+}
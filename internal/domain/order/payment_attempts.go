@@ -0,0 +1,59 @@
+// internal/domain/order/payment_attempts.go
+package order
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ClearPaymentAttemptHold lifts the payment attempt hold on an order after admin
+// review, so the customer gets a fresh set of payment attempts
+func (s *Service) ClearPaymentAttemptHold(orderID uint, comment string, adminID uint) error {
+	var order Order
+	if err := s.db.First(&order, orderID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("order not found")
+		}
+		return fmt.Errorf("failed to retrieve order: %w", err)
+	}
+
+	if !order.PaymentAttemptsExceeded {
+		return fmt.Errorf("order is not held for payment attempts")
+	}
+
+	if comment == "" {
+		comment = "Payment attempts reset by admin"
+	}
+
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	now := time.Now().UTC()
+	if err := tx.Model(&order).Updates(map[string]interface{}{
+		"payment_attempts_exceeded": false,
+		"payment_attempts_reset_at": now,
+	}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to clear payment attempt hold: %w", err)
+	}
+
+	history := OrderStatusHistory{
+		OrderID:   order.ID,
+		Status:    order.Status,
+		Comment:   comment,
+		CreatedBy: adminID,
+		CreatedAt: now,
+	}
+	if err := tx.Create(&history).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to create status history: %w", err)
+	}
+
+	return tx.Commit().Error
+}
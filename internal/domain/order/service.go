@@ -3,34 +3,65 @@ package order
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"github.com/your-org/ecommerce-backend/internal/domain/cart"
+	"github.com/your-org/ecommerce-backend/internal/domain/coupon"
+	"github.com/your-org/ecommerce-backend/internal/domain/inventory"
 	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"github.com/your-org/ecommerce-backend/internal/domain/setting"
+	"github.com/your-org/ecommerce-backend/internal/domain/shipping"
+	"github.com/your-org/ecommerce-backend/internal/domain/tax"
 	"github.com/your-org/ecommerce-backend/internal/domain/user"
 	"github.com/your-org/ecommerce-backend/internal/pkg/email"
 
 	"gorm.io/gorm"
 )
 
+// ErrOrderLimitExceeded is returned when a customer places too many orders within the configured window
+var ErrOrderLimitExceeded = errors.New("order limit exceeded")
+
+// ErrCouponExhausted is returned when a coupon ran out of uses (overall or for this customer)
+// between checkout and order placement; the order is not created
+var ErrCouponExhausted = errors.New("coupon is no longer available")
+
+// ErrTrackingRequired is returned when an order is marked shipped without a tracking
+// number and carrier
+var ErrTrackingRequired = errors.New("tracking number and carrier are required to ship an order")
+
+// ShipmentTracking is the carrier and tracking number an order ships with
+type ShipmentTracking struct {
+	TrackingNumber  string
+	ShippingCarrier string
+}
+
 // Service handles order business logic
 type Service struct {
-	db           *gorm.DB
-	config       *config.Config
-	cartService  *cart.Service
-	emailService *email.EmailService
+	db            *gorm.DB
+	config        *config.Config
+	cartService   *cart.Service
+	couponService *coupon.Service
+	emailService  *email.EmailService
+	settings      *setting.Service
+	shipping      *shipping.Service
 }
 
-// NewService creates a new order service
-func NewService(db *gorm.DB, cfg *config.Config, cartService *cart.Service) *Service {
+// NewService creates a new order service; settingService may be nil when the
+// service is not used to place orders
+func NewService(db *gorm.DB, cfg *config.Config, cartService *cart.Service, settingService *setting.Service) *Service {
 	return &Service{
-		db:           db,
-		config:       cfg,
-		cartService:  cartService,
-		emailService: email.NewEmailService(cfg),
+		db:            db,
+		config:        cfg,
+		cartService:   cartService,
+		settings:      settingService,
+		shipping:      shipping.NewService(db, settingService),
+		couponService: coupon.NewService(db, cfg),
+		emailService:  email.NewEmailService(db, cfg),
 	}
 }
 
@@ -42,7 +73,10 @@ type CreateOrderRequest struct {
 	PaymentMethod        string   `json:"payment_method" binding:"required"`
 	Notes                string   `json:"notes,omitempty"`
 	CouponCode           string   `json:"coupon_code,omitempty"`
+	AddOns               []string `json:"add_ons,omitempty"` // Checkout add-on IDs, e.g. shipping_insurance
 	UseShippingAsBilling bool     `json:"use_shipping_as_billing"`
+	GiftWrap             bool     `json:"gift_wrap"` // Same as selecting the gift_wrap add-on
+	GiftMessage          string   `json:"gift_message,omitempty"`
 }
 
 // OrderListRequest represents order list query parameters
@@ -55,6 +89,8 @@ type OrderListRequest struct {
 	SortOrder string      `form:"sort_order,default=desc"`
 	DateFrom  string      `form:"date_from"`
 	DateTo    string      `form:"date_to"`
+
+	AddressHold *bool `form:"address_hold"` // Only orders held (or not held) for address verification
 }
 
 // OrderResponse represents order response with pagination
@@ -73,8 +109,44 @@ type Pagination struct {
 	HasPrev    bool  `json:"has_prev"`
 }
 
+// checkOrderLimit rejects the order when the customer has reached the configured
+// number of orders within the rolling window. Admins are exempt.
+func (s *Service) checkOrderLimit(userID uint) error {
+	limit := s.config.Order.MaxOrdersPerWindow
+	window := s.config.Order.LimitWindow
+	if limit <= 0 || window <= 0 {
+		return nil
+	}
+
+	var userRecord user.User
+	if err := s.db.Select("id, is_admin").Where("id = ?", userID).First(&userRecord).Error; err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if userRecord.IsAdmin {
+		return nil
+	}
+
+	var recentOrders int64
+	if err := s.db.Model(&Order{}).
+		Where("user_id = ? AND created_at >= ?", userID, time.Now().Add(-window)).
+		Count(&recentOrders).Error; err != nil {
+		return fmt.Errorf("failed to check recent orders: %w", err)
+	}
+
+	if recentOrders >= int64(limit) {
+		return fmt.Errorf("%w: at most %d orders can be placed every %s, please try again later", ErrOrderLimitExceeded, limit, window)
+	}
+
+	return nil
+}
+
 // CreateOrder creates a new order from user's cart
 func (s *Service) CreateOrder(userID uint, sessionID string, req *CreateOrderRequest) (*Order, error) {
+	// Enforce per-customer order rate limit before touching the cart
+	if err := s.checkOrderLimit(userID); err != nil {
+		return nil, err
+	}
+
 	// Start transaction
 	tx := s.db.Begin()
 	defer func() {
@@ -83,9 +155,12 @@ func (s *Service) CreateOrder(userID uint, sessionID string, req *CreateOrderReq
 		}
 	}()
 
+	// Read, reprice and clear the cart within the order transaction
+	cartService := s.cartService.WithTx(tx)
+
 	// Get user's cart
 	userIDPtr := &userID
-	cartResponse, err := s.cartService.GetCart(userIDPtr, sessionID)
+	cartResponse, err := cartService.GetCart(userIDPtr, sessionID)
 	if err != nil {
 		tx.Rollback()
 		return nil, fmt.Errorf("failed to retrieve cart: %w", err)
@@ -98,17 +173,63 @@ func (s *Service) CreateOrder(userID uint, sessionID string, req *CreateOrderReq
 	}
 
 	// Validate cart items (inventory, pricing, etc.)
-	if err := s.validateCartItems(cartResponse.Items); err != nil {
+	if err := s.validateCartItems(cartService, userIDPtr, sessionID, cartResponse.Items); err != nil {
 		tx.Rollback()
 		return nil, fmt.Errorf("cart validation failed: %w", err)
 	}
 
 	// Calculate totals
 	subtotal := s.calculateSubtotal(cartResponse.Items)
-	taxAmount := s.calculateTax(subtotal, req.ShippingAddress)
-	shippingCost := s.calculateShipping(req.ShippingMethod, cartResponse.Items)
-	discountAmount := s.calculateDiscount(req.CouponCode, subtotal)
-	totalAmount := subtotal + taxAmount + shippingCost - discountAmount
+	taxResult := s.calculateTax(cartResponse.Items, req.ShippingAddress)
+	taxAmount := taxResult.TaxAmount
+	cgstAmount, sgstAmount, igstAmount := taxResult.Split()
+	shippingQuote, err := s.shipping.QuoteMethod(req.ShippingMethod,
+		shipping.Destination{Country: req.ShippingAddress.Country, State: req.ShippingAddress.State},
+		shipping.CartWeight(cartResponse.Items), subtotal)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	shippingCost := shippingQuote.Price
+	selectedAddOns := req.AddOns
+	if req.GiftWrap && !HasAddOn(selectedAddOns, AddOnGiftWrap) {
+		selectedAddOns = append(selectedAddOns, AddOnGiftWrap)
+	}
+	addOns, err := PriceAddOns(s.config, subtotal, selectedAddOns)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	giftMessage, err := ValidateGiftMessage(s.config, req.GiftMessage)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Redeem the coupon under a row lock so concurrent orders can't overrun its usage limits
+	var discountAmount int64
+	var redemption *coupon.CouponRedemption
+	if req.CouponCode != "" {
+		redemption, err = s.couponService.Redeem(tx, req.CouponCode, userID, subtotal)
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, coupon.ErrCouponUsageExhausted) || errors.Is(err, coupon.ErrCouponUserLimitReached) {
+				return nil, fmt.Errorf("%w: %w", ErrCouponExhausted, err)
+			}
+			return nil, fmt.Errorf("failed to apply coupon: %w", err)
+		}
+		discountAmount = redemption.DiscountAmount
+	}
+
+	// Apply the store-wide cap once all discounts are combined
+	if capped := coupon.CapDiscount(discountAmount, subtotal, s.config.Order.MaxDiscountPercent); capped < discountAmount {
+		discountAmount = capped
+		if redemption != nil {
+			redemption.DiscountAmount = capped
+		}
+	}
+
+	totalAmount := subtotal + taxAmount + shippingCost + addOns.Total() - discountAmount
 
 	// Set billing address
 	billingAddress := req.ShippingAddress
@@ -124,17 +245,32 @@ func (s *Service) CreateOrder(userID uint, sessionID string, req *CreateOrderReq
 		PaymentStatus:   PaymentStatusPending,
 		SubtotalAmount:  subtotal,
 		TaxAmount:       taxAmount,
+		CGSTAmount:      cgstAmount,
+		SGSTAmount:      sgstAmount,
+		IGSTAmount:      igstAmount,
 		ShippingAmount:  shippingCost,
 		DiscountAmount:  discountAmount,
 		TotalAmount:     totalAmount,
+		InsuranceAmount: addOns.InsuranceAmount,
+		SignatureFee:    addOns.SignatureFee,
+		GiftWrapFee:     addOns.GiftWrapFee,
+		GiftWrap:        HasAddOn(selectedAddOns, AddOnGiftWrap),
+		GiftMessage:     giftMessage,
 		ShippingAddress: req.ShippingAddress,
 		BillingAddress:  billingAddress,
 		Currency:        "USD", // TODO: Make configurable
 		Notes:           req.Notes,
-		CouponCode:      req.CouponCode,
+		CouponCode:      coupon.NormalizeCode(req.CouponCode),
+		PaymentMethod:   strings.ToLower(strings.TrimSpace(req.PaymentMethod)),
 		ShippingMethod:  req.ShippingMethod,
 	}
 
+	// Hold orders whose shipping address trips the address-quality rules
+	if issues := s.checkAddressQuality(req.ShippingAddress); len(issues) > 0 {
+		order.AddressVerificationRequired = true
+		order.AddressVerificationReason = strings.Join(issues, "; ")
+	}
+
 	// Replace this section in CreateOrder method:
 	var userRecord user.User
 	if err := tx.Select("email").Where("id = ?", userID).First(&userRecord).Error; err != nil {
@@ -156,7 +292,19 @@ func (s *Service) CreateOrder(userID uint, sessionID string, req *CreateOrderReq
 		return nil, fmt.Errorf("failed to update order number: %w", err)
 	}
 
+	if redemption != nil {
+		err := tx.Model(redemption).Updates(map[string]interface{}{
+			"order_id":        order.ID,
+			"discount_amount": redemption.DiscountAmount,
+		}).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to link coupon redemption: %w", err)
+		}
+	}
+
 	// Create order items
+	reservations := make([]inventory.OrderReservationItem, 0, len(cartResponse.Items))
 	for _, cartItem := range cartResponse.Items {
 		orderItem := OrderItem{
 			OrderID:          order.ID,
@@ -178,6 +326,14 @@ func (s *Service) CreateOrder(userID uint, sessionID string, req *CreateOrderReq
 			tx.Rollback()
 			return nil, fmt.Errorf("failed to create order item: %w", err)
 		}
+
+		if cartItem.Product.TrackQuantity {
+			reservations = append(reservations, inventory.OrderReservationItem{
+				OrderItemID: orderItem.ID,
+				ProductID:   orderItem.ProductID,
+				Quantity:    orderItem.Quantity,
+			})
+		}
 	}
 
 	// Reserve inventory
@@ -186,8 +342,18 @@ func (s *Service) CreateOrder(userID uint, sessionID string, req *CreateOrderReq
 		return nil, fmt.Errorf("failed to reserve inventory: %w", err)
 	}
 
+	// Hold warehouse stock until the order is paid; unpaid holds expire
+	reservedItems, err := inventory.ReserveForOrder(tx, s.config, order.ID, reservations)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to reserve warehouse stock: %w", err)
+	}
+
 	// Add initial status history
 	order.AddStatusHistory(OrderStatusPending, "Order created", userID)
+	if order.AddressVerificationRequired {
+		order.AddStatusHistory(OrderStatusPending, "Held for address verification: "+order.AddressVerificationReason, userID)
+	}
 	for _, history := range order.StatusHistory {
 		if err := tx.Create(&history).Error; err != nil {
 			tx.Rollback()
@@ -195,22 +361,23 @@ func (s *Service) CreateOrder(userID uint, sessionID string, req *CreateOrderReq
 		}
 	}
 
-	// Clear user's cart
-	if err := s.cartService.ClearCart(userIDPtr, sessionID); err != nil {
-		// Log error but don't fail the order
-		// In production, you might want to handle this differently
-		fmt.Printf("Warning: failed to clear cart after order creation: %v\n", err)
+	// Clear user's cart; a failed statement aborts the transaction, so the order fails with it
+	if err := cartService.ClearCart(userIDPtr, sessionID); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to clear cart: %w", err)
 	}
 
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		return nil, fmt.Errorf("failed to commit order transaction: %w", err)
 	}
+	inventory.CheckStockAlerts(s.db, s.config, reservedItems)
 
 	// Load complete order with relationships
 	if err := s.db.Preload("Items").Preload("StatusHistory").First(&order, order.ID).Error; err != nil {
 		return nil, fmt.Errorf("failed to load complete order: %w", err)
 	}
+	s.resolveHistoryActors(&order)
 
 	go func() {
 		ctx := context.Background()
@@ -234,6 +401,11 @@ func (s *Service) CreateOrder(userID uint, sessionID string, req *CreateOrderReq
 			})
 		}
 
+		digitalCount, err := s.countDigitalItems(order.ID)
+		if err != nil {
+			log.Printf("Failed to check digital items for order %s: %v", order.OrderNumber, err)
+		}
+
 		// Prepare email data - FIX FIELD NAMES
 		emailData := email.OrderConfirmationData{
 			EmailTemplateData: email.GetBaseTemplateData(
@@ -274,6 +446,8 @@ func (s *Service) CreateOrder(userID uint, sessionID string, req *CreateOrderReq
 				Country:      order.ShippingAddress.Country,
 				Phone:        order.ShippingAddress.Phone,
 			},
+			DigitalOnly:     len(order.Items) > 0 && digitalCount == len(order.Items),
+			HasDigitalItems: digitalCount > 0,
 		}
 
 		// Send order confirmation email
@@ -285,6 +459,42 @@ func (s *Service) CreateOrder(userID uint, sessionID string, req *CreateOrderReq
 	return &order, nil
 }
 
+// requiresShipmentTracking reports whether the order ships as one parcel that needs
+// its own tracking. Orders split into fulfillment groups are tracked per group, and
+// orders with nothing to ship have no parcel.
+func (s *Service) requiresShipmentTracking(orderID uint) (bool, error) {
+	var groups int64
+	if err := s.db.Model(&FulfillmentGroup{}).Where("order_id = ?", orderID).Count(&groups).Error; err != nil {
+		return false, fmt.Errorf("failed to check fulfillment groups: %w", err)
+	}
+	if groups > 0 {
+		return false, nil
+	}
+
+	var shippable int64
+	err := s.db.Table("order_items").
+		Joins("JOIN products ON products.id = order_items.product_id").
+		Where("order_items.order_id = ? AND products.is_digital = ? AND products.requires_shipping = ?", orderID, false, true).
+		Count(&shippable).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to count shippable items: %w", err)
+	}
+	return shippable > 0, nil
+}
+
+// countDigitalItems returns how many of the order's items are digital products
+func (s *Service) countDigitalItems(orderID uint) (int, error) {
+	var count int64
+	err := s.db.Table("order_items").
+		Joins("JOIN products ON products.id = order_items.product_id").
+		Where("order_items.order_id = ? AND products.is_digital = ?", orderID, true).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count digital items: %w", err)
+	}
+	return int(count), nil
+}
+
 // GetOrders retrieves orders with filtering and pagination
 func (s *Service) GetOrders(req *OrderListRequest) (*OrderResponse, error) {
 	var orders []Order
@@ -306,6 +516,10 @@ func (s *Service) GetOrders(req *OrderListRequest) (*OrderResponse, error) {
 		query = query.Where("user_id = ?", req.UserID)
 	}
 
+	if req.AddressHold != nil {
+		query = query.Where("address_verification_required = ?", *req.AddressHold)
+	}
+
 	if req.DateFrom != "" {
 		query = query.Where("created_at >= ?", req.DateFrom)
 	}
@@ -329,6 +543,12 @@ func (s *Service) GetOrders(req *OrderListRequest) (*OrderResponse, error) {
 		return nil, fmt.Errorf("failed to retrieve orders: %w", err)
 	}
 
+	page := make([]*Order, len(orders))
+	for i := range orders {
+		page[i] = &orders[i]
+	}
+	s.resolveHistoryActors(page...)
+
 	// Calculate pagination info
 	totalPages := int((total + int64(req.Limit) - 1) / int64(req.Limit))
 	pagination := Pagination{
@@ -351,6 +571,7 @@ func (s *Service) GetOrder(id uint) (*Order, error) {
 	var order Order
 	result := s.db.
 		Preload("Items").
+		Preload("FulfillmentGroups.Items").
 		Preload("StatusHistory", func(db *gorm.DB) *gorm.DB {
 			return db.Order("created_at DESC")
 		}).
@@ -363,6 +584,7 @@ func (s *Service) GetOrder(id uint) (*Order, error) {
 		}
 		return nil, fmt.Errorf("failed to retrieve order: %w", result.Error)
 	}
+	s.resolveHistoryActors(&order)
 
 	return &order, nil
 }
@@ -372,6 +594,7 @@ func (s *Service) GetOrderByNumber(orderNumber string) (*Order, error) {
 	var order Order
 	result := s.db.
 		Preload("Items").
+		Preload("FulfillmentGroups.Items").
 		Preload("StatusHistory", func(db *gorm.DB) *gorm.DB {
 			return db.Order("created_at DESC")
 		}).
@@ -384,12 +607,72 @@ func (s *Service) GetOrderByNumber(orderNumber string) (*Order, error) {
 		}
 		return nil, fmt.Errorf("failed to retrieve order: %w", result.Error)
 	}
+	s.resolveHistoryActors(&order)
 
 	return &order, nil
 }
 
+// resolveHistoryActors names who made each status change: "System" for automatic
+// changes, otherwise the user's name. The users are loaded in one query across all
+// the orders given.
+func (s *Service) resolveHistoryActors(orders ...*Order) {
+	userIDs := []uint{}
+	seen := map[uint]bool{}
+	for _, ord := range orders {
+		for _, history := range ord.StatusHistory {
+			if history.CreatedBy != 0 && !seen[history.CreatedBy] {
+				seen[history.CreatedBy] = true
+				userIDs = append(userIDs, history.CreatedBy)
+			}
+		}
+	}
+
+	users := map[uint]user.User{}
+	if len(userIDs) > 0 {
+		var records []user.User
+		if err := s.db.Unscoped().Select("id, first_name, last_name, email, is_admin").
+			Where("id IN ?", userIDs).Find(&records).Error; err != nil {
+			log.Printf("Failed to load status history actors: %v", err)
+		}
+		for _, record := range records {
+			users[record.ID] = record
+		}
+	}
+
+	for _, ord := range orders {
+		for i := range ord.StatusHistory {
+			history := &ord.StatusHistory[i]
+			if history.CreatedBy == 0 {
+				history.ActorName = "System"
+				history.ActorType = HistoryActorSystem
+				continue
+			}
+
+			history.ActorType = HistoryActorCustomer
+			history.ActorName = fmt.Sprintf("User #%d", history.CreatedBy)
+			if record, ok := users[history.CreatedBy]; ok {
+				if record.IsAdmin {
+					history.ActorType = HistoryActorAdmin
+				}
+				if name := record.GetFullName(); name != "" {
+					history.ActorName = name
+				} else if record.Email != "" {
+					history.ActorName = record.Email
+				}
+			}
+		}
+	}
+}
+
 // UpdateOrderStatus updates order status
 func (s *Service) UpdateOrderStatus(orderID uint, status OrderStatus, comment string, updatedBy uint) error {
+	return s.UpdateOrderStatusWithTracking(orderID, status, comment, updatedBy, ShipmentTracking{})
+}
+
+// UpdateOrderStatusWithTracking updates order status, saving any tracking details in
+// the same update. Shipping an order that needs it requires a tracking number and
+// carrier, given here or already on the order.
+func (s *Service) UpdateOrderStatusWithTracking(orderID uint, status OrderStatus, comment string, updatedBy uint, tracking ShipmentTracking) error {
 	// Get current order
 	var order Order
 	if err := s.db.First(&order, orderID).Error; err != nil {
@@ -401,10 +684,41 @@ func (s *Service) UpdateOrderStatus(orderID uint, status OrderStatus, comment st
 		return fmt.Errorf("invalid status transition from %s to %s", order.Status, status)
 	}
 
+	// Suppress fulfillment until the shipping address has been verified
+	if order.AddressVerificationRequired && isFulfillmentStatus(status) {
+		return fmt.Errorf("%w: %s", ErrAddressVerificationRequired, order.AddressVerificationReason)
+	}
+
+	trackingNumber := strings.TrimSpace(tracking.TrackingNumber)
+	carrier := strings.TrimSpace(tracking.ShippingCarrier)
+
+	if status == OrderStatusShipped && s.config.Order.RequireTrackingToShip {
+		required, err := s.requiresShipmentTracking(orderID)
+		if err != nil {
+			return err
+		}
+		missing := []string{}
+		if trackingNumber == "" && order.TrackingNumber == "" {
+			missing = append(missing, "tracking_number")
+		}
+		if carrier == "" && order.ShippingCarrier == "" {
+			missing = append(missing, "shipping_carrier")
+		}
+		if required && len(missing) > 0 {
+			return fmt.Errorf("%w: missing %s", ErrTrackingRequired, strings.Join(missing, ", "))
+		}
+	}
+
 	// Update order status
 	updates := map[string]interface{}{
 		"status": status,
 	}
+	if trackingNumber != "" {
+		updates["tracking_number"] = trackingNumber
+	}
+	if carrier != "" {
+		updates["shipping_carrier"] = carrier
+	}
 
 	// Set timestamps based on status
 	now := time.Now().UTC()
@@ -417,80 +731,50 @@ func (s *Service) UpdateOrderStatus(orderID uint, status OrderStatus, comment st
 		updates["delivered_at"] = now
 	}
 
-	if err := s.db.Model(&order).Updates(updates).Error; err != nil {
-		return fmt.Errorf("failed to update order status: %w", err)
-	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&order).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update order status: %w", err)
+		}
 
-	// Add status history
-	statusHistory := OrderStatusHistory{
-		OrderID:   orderID,
-		Status:    status,
-		Comment:   comment,
-		CreatedBy: updatedBy,
-		CreatedAt: now,
-	}
+		// Add status history
+		statusHistory := OrderStatusHistory{
+			OrderID:   orderID,
+			Status:    status,
+			Comment:   comment,
+			CreatedBy: updatedBy,
+			CreatedAt: now,
+		}
+		if err := tx.Create(&statusHistory).Error; err != nil {
+			return fmt.Errorf("failed to create status history: %w", err)
+		}
 
-	if err := s.db.Create(&statusHistory).Error; err != nil {
-		return fmt.Errorf("failed to create status history: %w", err)
+		// Give the coupon use back so usage limits only count orders that went through
+		if status == OrderStatusCancelled || status == OrderStatusRefunded {
+			return s.couponService.ReleaseOrder(tx, orderID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	go func() {
-		if status == OrderStatusShipped || status == OrderStatusDelivered || status == OrderStatusCancelled {
-			ctx := context.Background()
-
-			// Get order with user details
-			var order Order
-			if err := s.db.Preload("Items").Where("id = ?", orderID).First(&order).Error; err != nil {
-				log.Printf("Failed to get order for status email: %v", err)
-				return
-			}
-
-			// Get user details
-			var userRecord user.User
-			if err := s.db.Select("email, first_name, last_name").Where("id = ?", *order.UserID).First(&userRecord).Error; err != nil {
-				log.Printf("Failed to get user for status email: %v", err)
-				return
-			}
-
-			// Prepare status message
-			var statusMessage string
-			var estimatedDelivery string
-
-			switch status {
-			case OrderStatusShipped:
-				statusMessage = "Your order has been shipped and is on its way!"
-				if order.ShippedAt != nil {
-					estimatedDelivery = order.ShippedAt.Add(5 * 24 * time.Hour).Format("January 2, 2006")
-				}
-			case OrderStatusDelivered:
-				statusMessage = "Your order has been delivered. Thank you for shopping with us!"
-			case OrderStatusCancelled:
-				statusMessage = "Your order has been cancelled."
-			}
-
-			// Prepare email data
-			emailData := email.OrderStatusUpdateData{
-				EmailTemplateData: email.GetBaseTemplateData(
-					s.config.External.Email.FromName,
-					s.config.External.Email.BaseURL,
-					userRecord.GetFullName(), // Use GetFullName() instead of GetDisplayName()
-					userRecord.Email,
-				),
-				OrderNumber:       order.OrderNumber,
-				Status:            string(status),
-				StatusMessage:     statusMessage,
-				TrackingNumber:    order.TrackingNumber,
-				TrackingURL:       fmt.Sprintf("%s/orders/%s/track", s.config.External.Email.BaseURL, order.OrderNumber),
-				OrderURL:          fmt.Sprintf("%s/orders/%s", s.config.External.Email.BaseURL, order.OrderNumber),
-				EstimatedDelivery: estimatedDelivery,
-			}
-
-			// Send status update email
-			if err := s.emailService.SendOrderStatusUpdateEmail(ctx, emailData); err != nil {
-				log.Printf("Failed to send order status update email for order %s: %v", order.OrderNumber, err)
-			}
+	if status == OrderStatusConfirmed {
+		if err := SplitFulfillment(s.db, s.config, orderID); err != nil {
+			log.Printf("Failed to split order %d into fulfillment groups: %v", orderID, err)
 		}
-	}()
+		var fulfilledItems []uint
+		if err := s.db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			fulfilledItems, err = inventory.FulfillOrderReservations(tx, orderID)
+			return err
+		}); err != nil {
+			log.Printf("Failed to fulfill stock reservations for order %d: %v", orderID, err)
+		} else {
+			inventory.CheckStockAlerts(s.db, s.config, fulfilledItems)
+		}
+	}
+
+	go s.sendStatusUpdateEmail(orderID, status)
 
 	return nil
 }
@@ -519,6 +803,14 @@ func (s *Service) CancelOrder(orderID uint, reason string, cancelledBy uint) err
 		tx.Rollback()
 		return fmt.Errorf("failed to restore inventory: %w", err)
 	}
+	if err := inventory.ReleaseOrderReservations(tx, orderID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to release stock reservations: %w", err)
+	}
+	if err := s.couponService.ReleaseOrder(tx, orderID); err != nil {
+		tx.Rollback()
+		return err
+	}
 
 	// Update order status
 	if err := tx.Model(&order).Updates(map[string]interface{}{
@@ -557,20 +849,37 @@ func (s *Service) GetUserOrders(userID uint, page, limit int) (*OrderResponse, e
 
 // Private helper methods
 
-func (s *Service) validateCartItems(items []cart.CartItemResponse) error {
+// validateCartItems reprices the cart so the order is charged current catalog
+// prices, then checks availability and inventory. Repriced items are updated in place.
+func (s *Service) validateCartItems(cartService *cart.Service, userID *uint, sessionID string, items []cart.CartItemResponse) error {
+	changes, err := cartService.RepriceCart(userID, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to reprice cart: %w", err)
+	}
+	for _, change := range changes {
+		for i := range items {
+			if items[i].ProductID == change.ProductID && sameVariant(items[i].ProductVariantID, change.ProductVariantID) {
+				items[i].Price = change.NewPrice
+			}
+		}
+		if change.Increased {
+			log.Printf("Cart price for product %d increased from %d to %d before checkout", change.ProductID, change.OldPrice, change.NewPrice)
+		}
+	}
+
 	for _, item := range items {
 		if item.Product == nil {
 			return fmt.Errorf("product %d not found", item.ProductID)
 		}
 
-		if !item.Product.IsActive {
-			return fmt.Errorf("product '%s' is no longer available", item.Product.Name)
+		if !item.Product.IsActive || !item.IsAvailable() {
+			return fmt.Errorf("product '%s' is no longer available, please remove it from your cart", item.Product.Name)
 		}
 
 		// Check inventory
-		availableQuantity := item.Product.Quantity
+		availableQuantity := item.Product.AvailableQuantity()
 		if item.ProductVariant != nil {
-			availableQuantity = item.ProductVariant.Quantity
+			availableQuantity = item.ProductVariant.AvailableQuantity()
 		}
 
 		if item.Product.TrackQuantity && availableQuantity < item.Quantity {
@@ -581,6 +890,14 @@ func (s *Service) validateCartItems(items []cart.CartItemResponse) error {
 	return nil
 }
 
+// sameVariant reports whether two optional variant IDs refer to the same variant
+func sameVariant(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
 func (s *Service) calculateSubtotal(items []cart.CartItemResponse) int64 {
 	var subtotal int64
 	for _, item := range items {
@@ -589,31 +906,10 @@ func (s *Service) calculateSubtotal(items []cart.CartItemResponse) int64 {
 	return subtotal
 }
 
-func (s *Service) calculateTax(subtotal int64, address Address) int64 {
-	// TODO: Implement tax calculation based on address
-	// For now, return 0
-	return 0
-}
-
-func (s *Service) calculateShipping(method string, items []cart.CartItemResponse) int64 {
-	// TODO: Implement shipping calculation based on method and items
-	// For now, return flat rate
-	switch method {
-	case "standard":
-		return 999 // $9.99
-	case "express":
-		return 1999 // $19.99
-	case "overnight":
-		return 2999 // $29.99
-	default:
-		return 999
-	}
-}
-
-func (s *Service) calculateDiscount(couponCode string, subtotal int64) int64 {
-	// TODO: Implement coupon/discount calculation
-	// For now, return 0
-	return 0
+// calculateTax charges GST on each item at its product's rate, split into CGST and
+// SGST when the address is in the store's state and charged as IGST otherwise
+func (s *Service) calculateTax(items []cart.CartItemResponse, address Address) *tax.Result {
+	return tax.CalculateItemsGST(cart.TaxLineItems(items), address.Country, s.config.App.StoreState, address.State)
 }
 
 func (s *Service) generateOrderNumber(orderID uint) string {
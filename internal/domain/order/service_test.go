@@ -0,0 +1,395 @@
+// internal/domain/order/service_test.go
+package order
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/cart"
+	"github.com/your-org/ecommerce-backend/internal/domain/coupon"
+	"github.com/your-org/ecommerce-backend/internal/domain/inventory"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"github.com/your-org/ecommerce-backend/internal/domain/setting"
+	"github.com/your-org/ecommerce-backend/internal/domain/shipping"
+	"github.com/your-org/ecommerce-backend/internal/domain/user"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+	"gorm.io/gorm"
+)
+
+// newTestService returns an order service over a fresh database with the tables
+// placing and updating orders touches
+func newTestService(t *testing.T) (*Service, *gorm.DB) {
+	t.Helper()
+	db := testutil.NewDB(t,
+		&user.User{}, &user.Address{},
+		&product.Category{}, &product.Brand{}, &product.Product{}, &product.ProductVariant{},
+		&cart.CartItem{}, &coupon.Coupon{}, &coupon.CouponRedemption{},
+		&Order{}, &OrderItem{}, &Payment{}, &OrderStatusHistory{}, &FulfillmentGroup{},
+		&inventory.InventoryItem{}, &inventory.StockReservation{},
+		&setting.Setting{}, &shipping.ShippingMethod{}, &shipping.ShippingRate{},
+		&shipping.ShippingZone{}, &shipping.ShippingZoneRegion{},
+	)
+	redisClient, _ := testutil.NewRedis(t)
+	cfg := &config.Config{}
+	return NewService(db, cfg, cart.NewService(db, redisClient, cfg), nil), db
+}
+
+func createTestCustomer(t *testing.T, db *gorm.DB, email string) *user.User {
+	t.Helper()
+	customer := &user.User{Email: email, Password: "hash", FirstName: "Test", LastName: "Customer", IsActive: true}
+	if err := db.Create(customer).Error; err != nil {
+		t.Fatalf("failed to create customer: %v", err)
+	}
+	return customer
+}
+
+func createTestProduct(t *testing.T, db *gorm.DB, sku string, price int64, quantity int) *product.Product {
+	t.Helper()
+	var category product.Category
+	if err := db.FirstOrCreate(&category, product.Category{Name: "General", Slug: "general"}).Error; err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+	prod := &product.Product{SKU: sku, Name: "Product " + sku, Slug: sku, Price: price, CategoryID: category.ID,
+		IsActive: true, TrackQuantity: true, Quantity: quantity}
+	if err := db.Create(prod).Error; err != nil {
+		t.Fatalf("failed to create product: %v", err)
+	}
+	return prod
+}
+
+func addTestCartItem(t *testing.T, db *gorm.DB, userID uint, prod *product.Product, quantity int) {
+	t.Helper()
+	item := cart.CartItem{UserID: &userID, ProductID: prod.ID, Quantity: quantity, Price: prod.Price}
+	if err := db.Create(&item).Error; err != nil {
+		t.Fatalf("failed to add cart item: %v", err)
+	}
+}
+
+func createTestCoupon(t *testing.T, db *gorm.DB, code string, usageLimit, perUserLimit int) *coupon.Coupon {
+	t.Helper()
+	c := &coupon.Coupon{Code: code, DiscountType: coupon.DiscountTypeFixedAmount, DiscountValue: 1000,
+		UsageLimit: usageLimit, PerUserLimit: perUserLimit, IsActive: true}
+	if err := db.Create(c).Error; err != nil {
+		t.Fatalf("failed to create coupon: %v", err)
+	}
+	return c
+}
+
+func testOrderRequest(couponCode string) *CreateOrderRequest {
+	address := Address{FirstName: "Test", LastName: "Customer", AddressLine1: "1 Main Street",
+		City: "Bengaluru", State: "Karnataka", PostalCode: "560001", Country: "IN", Phone: "9999999999"}
+	return &CreateOrderRequest{
+		ShippingAddress:      address,
+		ShippingMethod:       "standard",
+		PaymentMethod:        "razorpay",
+		CouponCode:           couponCode,
+		UseShippingAsBilling: true,
+	}
+}
+
+func TestCreateOrderChargesCurrentPrice(t *testing.T) {
+	s, db := newTestService(t)
+	customer := createTestCustomer(t, db, "buyer@example.com")
+	prod := createTestProduct(t, db, "SKU-1", 10000, 10)
+	addTestCartItem(t, db, customer.ID, prod, 2)
+
+	// The price rises after the product was added to the cart
+	if err := db.Model(prod).Update("price", 12000).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	placed, err := s.CreateOrder(customer.ID, "", testOrderRequest(""))
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	if placed.SubtotalAmount != 24000 {
+		t.Errorf("SubtotalAmount = %d, want 24000", placed.SubtotalAmount)
+	}
+	want := placed.SubtotalAmount + placed.TaxAmount + placed.ShippingAmount - placed.DiscountAmount
+	if placed.TotalAmount != want {
+		t.Errorf("TotalAmount = %d, want %d", placed.TotalAmount, want)
+	}
+	if len(placed.Items) != 1 || placed.Items[0].Price != 12000 || placed.Items[0].TotalPrice != 24000 {
+		t.Errorf("order items = %+v, want one item at 12000 x 2", placed.Items)
+	}
+
+	var remaining int64
+	db.Model(&cart.CartItem{}).Where("user_id = ?", customer.ID).Count(&remaining)
+	if remaining != 0 {
+		t.Errorf("cart items after order = %d, want 0", remaining)
+	}
+}
+
+func TestCreateOrderRollsBackFailedOrder(t *testing.T) {
+	s, db := newTestService(t)
+	customer := createTestCustomer(t, db, "buyer@example.com")
+	prod := createTestProduct(t, db, "SKU-1", 10000, 10)
+	addTestCartItem(t, db, customer.ID, prod, 1)
+	if err := db.Model(prod).Update("price", 12000).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.CreateOrder(customer.ID, "", testOrderRequest("MISSING")); err == nil {
+		t.Fatal("CreateOrder() with an unknown coupon succeeded")
+	}
+
+	var orders int64
+	db.Model(&Order{}).Count(&orders)
+	if orders != 0 {
+		t.Errorf("orders after a failed order = %d, want 0", orders)
+	}
+
+	// The cart is left as it was, repricing included
+	var items []cart.CartItem
+	db.Where("user_id = ?", customer.ID).Find(&items)
+	if len(items) != 1 || items[0].Price != 10000 {
+		t.Errorf("cart after a failed order = %+v, want the one item at 10000", items)
+	}
+}
+
+func TestCreateOrderCouponLastUse(t *testing.T) {
+	s, db := newTestService(t)
+	prod := createTestProduct(t, db, "SKU-1", 10000, 10)
+	createTestCoupon(t, db, "LASTONE", 1, 0)
+
+	customers := []*user.User{
+		createTestCustomer(t, db, "first@example.com"),
+		createTestCustomer(t, db, "second@example.com"),
+	}
+	for _, customer := range customers {
+		addTestCartItem(t, db, customer.ID, prod, 1)
+	}
+
+	// Both orders race for the coupon's last use
+	errs := make([]error, len(customers))
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, customer := range customers {
+		wg.Add(1)
+		go func(i int, userID uint) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = s.CreateOrder(userID, "", testOrderRequest("lastone"))
+		}(i, customer.ID)
+	}
+	close(start)
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, ErrCouponExhausted):
+			t.Errorf("losing order error = %v, want %v", err, ErrCouponExhausted)
+		}
+	}
+	if succeeded != 1 {
+		t.Fatalf("%d orders succeeded, want exactly 1 (errors: %v)", succeeded, errs)
+	}
+
+	var orders, redemptions int64
+	db.Model(&Order{}).Count(&orders)
+	db.Model(&coupon.CouponRedemption{}).Count(&redemptions)
+	if orders != 1 || redemptions != 1 {
+		t.Errorf("%d orders and %d redemptions, want 1 and 1", orders, redemptions)
+	}
+}
+
+func TestCreateOrderCouponExhaustedSinceSummary(t *testing.T) {
+	tests := []struct {
+		name         string
+		usageLimit   int
+		perUserLimit int
+		sameCustomer bool // Whether the use since checkout was by the same customer
+		wantCause    error
+	}{
+		{"usage limit", 1, 0, false, coupon.ErrCouponUsageExhausted},
+		{"per-customer limit", 5, 1, true, coupon.ErrCouponUserLimitReached},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, db := newTestService(t)
+			customer := createTestCustomer(t, db, "buyer@example.com")
+			other := createTestCustomer(t, db, "other@example.com")
+			prod := createTestProduct(t, db, "SKU-1", 10000, 10)
+			c := createTestCoupon(t, db, "SAVE10", tt.usageLimit, tt.perUserLimit)
+			addTestCartItem(t, db, customer.ID, prod, 1)
+
+			// The checkout summary accepts the coupon
+			if _, _, err := s.couponService.Validate("SAVE10", customer.ID, 10000); err != nil {
+				t.Fatalf("Validate() at checkout error = %v", err)
+			}
+
+			// Its last use goes to another order before this one is placed
+			usedBy := other.ID
+			if tt.sameCustomer {
+				usedBy = customer.ID
+			}
+			db.Create(&coupon.CouponRedemption{CouponID: c.ID, UserID: usedBy, DiscountAmount: 1000})
+
+			_, err := s.CreateOrder(customer.ID, "", testOrderRequest("SAVE10"))
+			if !errors.Is(err, ErrCouponExhausted) {
+				t.Fatalf("CreateOrder() error = %v, want %v", err, ErrCouponExhausted)
+			}
+			if !errors.Is(err, tt.wantCause) {
+				t.Errorf("CreateOrder() error = %v, want it to wrap %v", err, tt.wantCause)
+			}
+
+			var orders int64
+			db.Model(&Order{}).Count(&orders)
+			if orders != 0 {
+				t.Errorf("orders = %d, want 0", orders)
+			}
+		})
+	}
+}
+
+func TestCouponRedemptionFollowsOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		cancel func(s *Service, orderID uint) error
+	}{
+		{"status update", func(s *Service, orderID uint) error {
+			return s.UpdateOrderStatus(orderID, OrderStatusCancelled, "Out of stock", 1)
+		}},
+		{"customer cancellation", func(s *Service, orderID uint) error {
+			return s.CancelOrder(orderID, "Changed my mind", 1)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, db := newTestService(t)
+			customer := createTestCustomer(t, db, "buyer@example.com")
+			prod := createTestProduct(t, db, "SKU-1", 10000, 10)
+			c := createTestCoupon(t, db, "ONCE", 0, 1)
+			addTestCartItem(t, db, customer.ID, prod, 1)
+
+			placed, err := s.CreateOrder(customer.ID, "", testOrderRequest("ONCE"))
+			if err != nil {
+				t.Fatalf("CreateOrder() error = %v", err)
+			}
+			var redemption coupon.CouponRedemption
+			if err := db.Where("coupon_id = ?", c.ID).First(&redemption).Error; err != nil {
+				t.Fatalf("no redemption recorded: %v", err)
+			}
+			if redemption.OrderID == nil || *redemption.OrderID != placed.ID || redemption.UserID != customer.ID ||
+				redemption.DiscountAmount != placed.DiscountAmount || redemption.ReleasedAt != nil {
+				t.Fatalf("redemption = %+v, want an unreleased redemption for order %d", redemption, placed.ID)
+			}
+
+			if err := tt.cancel(s, placed.ID); err != nil {
+				t.Fatalf("cancelling error = %v", err)
+			}
+			db.First(&redemption, redemption.ID)
+			if redemption.ReleasedAt == nil {
+				t.Fatal("redemption was not released when the order was cancelled")
+			}
+
+			// The customer's one use is available again
+			addTestCartItem(t, db, customer.ID, prod, 1)
+			if _, err := s.CreateOrder(customer.ID, "", testOrderRequest("ONCE")); err != nil {
+				t.Errorf("CreateOrder() with the released coupon error = %v", err)
+			}
+		})
+	}
+}
+
+func TestShippingRequiresTracking(t *testing.T) {
+	tests := []struct {
+		name     string
+		digital  bool
+		tracking ShipmentTracking
+		wantErr  error
+	}{
+		{"without tracking", false, ShipmentTracking{}, ErrTrackingRequired},
+		{"without a carrier", false, ShipmentTracking{TrackingNumber: "1Z999"}, ErrTrackingRequired},
+		{"with tracking", false, ShipmentTracking{TrackingNumber: " 1Z999 ", ShippingCarrier: "UPS"}, nil},
+		{"digital order without tracking", true, ShipmentTracking{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, db := newTestService(t)
+			s.config.Order.RequireTrackingToShip = true
+			s.config.Order.StatusEmailsDisabled = []string{string(OrderStatusShipped)}
+			customer := createTestCustomer(t, db, "buyer@example.com")
+			prod := createTestProduct(t, db, "SKU-1", 10000, 10)
+			if tt.digital {
+				db.Model(prod).Updates(map[string]interface{}{"is_digital": true, "requires_shipping": false})
+			}
+			ord := &Order{OrderNumber: "ORD-1", UserID: &customer.ID, Email: customer.Email, Status: OrderStatusProcessing,
+				PaymentStatus: PaymentStatusPaid, SubtotalAmount: 10000, TotalAmount: 10000,
+				Items: []OrderItem{{ProductID: prod.ID, SKU: prod.SKU, Name: prod.Name, Price: 10000, Quantity: 1, TotalPrice: 10000}}}
+			if err := db.Create(ord).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			err := s.UpdateOrderStatusWithTracking(ord.ID, OrderStatusShipped, "", 1, tt.tracking)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateOrderStatusWithTracking() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var stored Order
+			db.First(&stored, ord.ID)
+			if tt.wantErr != nil {
+				if stored.Status != OrderStatusProcessing || stored.ShippedAt != nil {
+					t.Errorf("status = %s, shipped at %v, want it left at %s", stored.Status, stored.ShippedAt, OrderStatusProcessing)
+				}
+				return
+			}
+			if stored.Status != OrderStatusShipped || stored.ShippedAt == nil {
+				t.Errorf("status = %s, shipped at %v, want %s", stored.Status, stored.ShippedAt, OrderStatusShipped)
+			}
+			if stored.TrackingNumber != strings.TrimSpace(tt.tracking.TrackingNumber) || stored.ShippingCarrier != tt.tracking.ShippingCarrier {
+				t.Errorf("tracking = %q via %q, want %+v", stored.TrackingNumber, stored.ShippingCarrier, tt.tracking)
+			}
+		})
+	}
+}
+
+func TestStatusHistoryActors(t *testing.T) {
+	s, db := newTestService(t)
+	customer := createTestCustomer(t, db, "buyer@example.com")
+	admin := &user.User{Email: "ops@example.com", Password: "hash", FirstName: "Asha", LastName: "Rao", IsActive: true, IsAdmin: true}
+	if err := db.Create(admin).Error; err != nil {
+		t.Fatal(err)
+	}
+	ord := &Order{OrderNumber: "ORD-1", UserID: &customer.ID, Email: customer.Email, Status: OrderStatusCancelled,
+		SubtotalAmount: 10000, TotalAmount: 10000}
+	if err := db.Create(ord).Error; err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	db.Create(&[]OrderStatusHistory{
+		{OrderID: ord.ID, Status: OrderStatusPending, CreatedBy: 0, CreatedAt: now.Add(-3 * time.Minute)},
+		{OrderID: ord.ID, Status: OrderStatusConfirmed, CreatedBy: admin.ID, CreatedAt: now.Add(-2 * time.Minute)},
+		{OrderID: ord.ID, Status: OrderStatusCancelled, CreatedBy: customer.ID, CreatedAt: now.Add(-time.Minute)},
+	})
+
+	got, err := s.GetOrder(ord.ID)
+	if err != nil {
+		t.Fatalf("GetOrder() error = %v", err)
+	}
+
+	want := map[OrderStatus][2]string{
+		OrderStatusPending:   {"System", HistoryActorSystem},
+		OrderStatusConfirmed: {"Asha Rao", HistoryActorAdmin},
+		OrderStatusCancelled: {"Test Customer", HistoryActorCustomer},
+	}
+	if len(got.StatusHistory) != len(want) {
+		t.Fatalf("status history = %d entries, want %d", len(got.StatusHistory), len(want))
+	}
+	for _, history := range got.StatusHistory {
+		if actor := [2]string{history.ActorName, history.ActorType}; actor != want[history.Status] {
+			t.Errorf("%s change by %v, want %v", history.Status, actor, want[history.Status])
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// internal/domain/order/status_counts.go
+package order
+
+import "fmt"
+
+// OrderStatusCounts summarizes a customer's orders for the account dashboard
+type OrderStatusCounts struct {
+	Total      int64                 `json:"total"`
+	ByStatus   map[OrderStatus]int64 `json:"by_status"`   // Every status, including zero counts
+	InProgress int64                 `json:"in_progress"` // Confirmed or being processed
+	InTransit  int64                 `json:"in_transit"`  // Shipped or out for delivery
+	Delivered  int64                 `json:"delivered"`   // Delivered or completed
+	ToReview   int64                 `json:"to_review"`   // Delivered products the customer has not reviewed
+}
+
+// allOrderStatuses lists every order status, in lifecycle order
+var allOrderStatuses = []OrderStatus{
+	OrderStatusPending,
+	OrderStatusPaymentProcessing,
+	OrderStatusConfirmed,
+	OrderStatusProcessing,
+	OrderStatusShipped,
+	OrderStatusOutForDelivery,
+	OrderStatusDelivered,
+	OrderStatusCompleted,
+	OrderStatusCancelled,
+	OrderStatusRefunded,
+}
+
+// GetUserOrderStatusCounts returns the user's order counts grouped by status, plus
+// the number of distinct delivered products they have not reviewed yet
+func (s *Service) GetUserOrderStatusCounts(userID uint) (*OrderStatusCounts, error) {
+	var rows []struct {
+		Status OrderStatus
+		Count  int64
+	}
+	err := s.db.Model(&Order{}).
+		Select("status, COUNT(*) AS count").
+		Where("user_id = ?", userID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count orders: %w", err)
+	}
+
+	counts := &OrderStatusCounts{ByStatus: make(map[OrderStatus]int64, len(allOrderStatuses))}
+	for _, status := range allOrderStatuses {
+		counts.ByStatus[status] = 0
+	}
+
+	for _, row := range rows {
+		counts.ByStatus[row.Status] = row.Count
+		counts.Total += row.Count
+
+		switch row.Status {
+		case OrderStatusConfirmed, OrderStatusProcessing:
+			counts.InProgress += row.Count
+		case OrderStatusShipped, OrderStatusOutForDelivery:
+			counts.InTransit += row.Count
+		case OrderStatusDelivered, OrderStatusCompleted:
+			counts.Delivered += row.Count
+		}
+	}
+
+	err = s.db.Model(&OrderItem{}).
+		Joins("JOIN orders ON orders.id = order_items.order_id AND orders.deleted_at IS NULL").
+		Where("orders.user_id = ? AND orders.status IN ?", userID, []OrderStatus{OrderStatusDelivered, OrderStatusCompleted}).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM product_reviews
+			WHERE product_reviews.user_id = orders.user_id
+			AND product_reviews.product_id = order_items.product_id
+			AND product_reviews.deleted_at IS NULL)`).
+		Distinct("order_items.product_id").
+		Count(&counts.ToReview).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count products to review: %w", err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,120 @@
+// internal/domain/order/status_email.go
+package order
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/user"
+	"github.com/your-org/ecommerce-backend/internal/pkg/email"
+)
+
+// statusEmailCopy is what the status update email says for a status
+type statusEmailCopy struct {
+	Label   string // Shown in place of the raw status
+	Message string
+}
+
+// statusEmails are the statuses customers are emailed about. Pending, payment
+// processing and completed are internal and never emailed.
+var statusEmails = map[OrderStatus]statusEmailCopy{
+	OrderStatusConfirmed: {
+		Label:   "Confirmed",
+		Message: "Your order has been confirmed and will be prepared for shipping soon.",
+	},
+	OrderStatusProcessing: {
+		Label:   "Processing",
+		Message: "We're packing your order and will let you know when it ships.",
+	},
+	OrderStatusShipped: {
+		Label:   "Shipped",
+		Message: "Your order has been shipped and is on its way!",
+	},
+	OrderStatusOutForDelivery: {
+		Label:   "Out for delivery",
+		Message: "Your order is out for delivery and should arrive today.",
+	},
+	OrderStatusDelivered: {
+		Label:   "Delivered",
+		Message: "Your order has been delivered. Thank you for shopping with us!",
+	},
+	OrderStatusCancelled: {
+		Label:   "Cancelled",
+		Message: "Your order has been cancelled.",
+	},
+	OrderStatusRefunded: {
+		Label:   "Refunded",
+		Message: "Your order has been refunded. The amount will reach your original payment method in 5-7 business days.",
+	},
+}
+
+// statusEmailEnabled reports whether customers are emailed when an order moves to
+// status; stores can turn off individual statuses in config
+func (s *Service) statusEmailEnabled(status OrderStatus) bool {
+	if _, ok := statusEmails[status]; !ok {
+		return false
+	}
+	for _, disabled := range s.config.Order.StatusEmailsDisabled {
+		if strings.EqualFold(strings.TrimSpace(disabled), string(status)) {
+			return false
+		}
+	}
+	return true
+}
+
+// sendStatusUpdateEmail emails the customer that their order moved to status. Guest
+// orders are sent to the order's email.
+func (s *Service) sendStatusUpdateEmail(orderID uint, status OrderStatus) {
+	if !s.statusEmailEnabled(status) {
+		return
+	}
+	content := statusEmails[status]
+
+	var order Order
+	if err := s.db.Where("id = ?", orderID).First(&order).Error; err != nil {
+		log.Printf("Failed to get order for status email: %v", err)
+		return
+	}
+
+	recipientName := strings.TrimSpace(order.ShippingAddress.FirstName + " " + order.ShippingAddress.LastName)
+	recipientEmail := order.Email
+	if order.UserID != nil {
+		var userRecord user.User
+		if err := s.db.Select("email, first_name, last_name").Where("id = ?", *order.UserID).First(&userRecord).Error; err != nil {
+			log.Printf("Failed to get user for status email: %v", err)
+			return
+		}
+		recipientName = userRecord.GetFullName()
+		recipientEmail = userRecord.Email
+	}
+
+	var estimatedDelivery string
+	if status == OrderStatusShipped || status == OrderStatusOutForDelivery {
+		if estimate := s.EstimatedDelivery(&order); estimate != nil {
+			estimatedDelivery = estimate.Format("January 2, 2006")
+		}
+	}
+
+	emailData := email.OrderStatusUpdateData{
+		EmailTemplateData: email.GetBaseTemplateData(
+			s.config.External.Email.FromName,
+			s.config.External.Email.BaseURL,
+			recipientName,
+			recipientEmail,
+		),
+		OrderNumber:       order.OrderNumber,
+		Status:            string(status),
+		StatusLabel:       content.Label,
+		StatusMessage:     content.Message,
+		TrackingNumber:    order.TrackingNumber,
+		TrackingURL:       fmt.Sprintf("%s/orders/%s/track", s.config.External.Email.BaseURL, order.OrderNumber),
+		OrderURL:          fmt.Sprintf("%s/orders/%s", s.config.External.Email.BaseURL, order.OrderNumber),
+		EstimatedDelivery: estimatedDelivery,
+	}
+
+	if err := s.emailService.SendOrderStatusUpdateEmail(context.Background(), emailData); err != nil {
+		log.Printf("Failed to send order status update email for order %s: %v", order.OrderNumber, err)
+	}
+}
@@ -0,0 +1,33 @@
+// internal/domain/order/status_email_test.go
+package order
+
+import (
+	"testing"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+)
+
+func TestStatusEmailEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		disabled []string
+		status   OrderStatus
+		want     bool
+	}{
+		{"processing emailed by default", nil, OrderStatusProcessing, true},
+		{"processing opted out", []string{"processing"}, OrderStatusProcessing, false},
+		{"opt-out ignores case and spaces", []string{" Processing "}, OrderStatusProcessing, false},
+		{"opt-out only affects its status", []string{"processing"}, OrderStatusShipped, true},
+		{"internal statuses never emailed", nil, OrderStatusPending, false},
+		{"completed never emailed", nil, OrderStatusCompleted, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{config: &config.Config{Order: config.OrderConfig{StatusEmailsDisabled: tt.disabled}}}
+			if got := s.statusEmailEnabled(tt.status); got != tt.want {
+				t.Errorf("statusEmailEnabled(%s) = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
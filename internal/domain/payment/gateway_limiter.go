@@ -0,0 +1,66 @@
+// internal/domain/payment/gateway_limiter.go
+package payment
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxRetryDelay caps the backoff between retries of a rate-limited call
+const maxRetryDelay = 30 * time.Second
+
+// gatewayLimiter is a counting semaphore bounding concurrent gateway calls
+type gatewayLimiter struct {
+	slots chan struct{}
+}
+
+var (
+	sharedLimiter     *gatewayLimiter
+	sharedLimiterOnce sync.Once
+)
+
+// newGatewayLimiter creates a limiter allowing up to max concurrent calls; max < 1 means unlimited
+func newGatewayLimiter(max int) *gatewayLimiter {
+	if max < 1 {
+		return &gatewayLimiter{}
+	}
+	return &gatewayLimiter{slots: make(chan struct{}, max)}
+}
+
+// sharedGatewayLimiter returns the process-wide limiter. Handlers each construct their
+// own RazorpayService, so the cap only holds if they share one semaphore.
+func sharedGatewayLimiter(max int) *gatewayLimiter {
+	sharedLimiterOnce.Do(func() {
+		sharedLimiter = newGatewayLimiter(max)
+	})
+	return sharedLimiter
+}
+
+// acquire blocks until a call slot is free
+func (l *gatewayLimiter) acquire() {
+	if l.slots != nil {
+		l.slots <- struct{}{}
+	}
+}
+
+// release frees a call slot
+func (l *gatewayLimiter) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// retryDelay returns how long to wait before retrying after a 429. Razorpay's
+// Retry-After header wins when present; otherwise the base delay doubles per attempt.
+func retryDelay(attempt int, base time.Duration, header http.Header) time.Duration {
+	delay := base << attempt
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds > 0 {
+		delay = time.Duration(seconds) * time.Second
+	}
+	if delay < 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,125 @@
+// internal/domain/payment/notifications.go
+package payment
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/download"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/user"
+	"github.com/your-org/ecommerce-backend/internal/pkg/email"
+	"gorm.io/gorm"
+)
+
+// paymentNotifier sends payment outcome emails for every provider
+type paymentNotifier struct {
+	db              *gorm.DB
+	config          *config.Config
+	emailService    *email.EmailService
+	downloadService *download.Service
+}
+
+func newPaymentNotifier(db *gorm.DB, cfg *config.Config) *paymentNotifier {
+	return &paymentNotifier{
+		db:              db,
+		config:          cfg,
+		emailService:    email.NewEmailService(db, cfg),
+		downloadService: download.NewService(db, cfg),
+	}
+}
+
+// sendPaymentSuccessEmail emails the customer that their order has been paid, with
+// download links for any digital items
+func (n *paymentNotifier) sendPaymentSuccessEmail(orderID uint) {
+	var orderRecord order.Order
+	if err := n.db.Where("id = ?", orderID).First(&orderRecord).Error; err != nil {
+		log.Printf("Failed to get order for payment success email: %v", err)
+		return
+	}
+
+	var userRecord user.User
+	if err := n.db.Select("email, first_name, last_name").Where("id = ?", orderRecord.UserID).First(&userRecord).Error; err != nil {
+		log.Printf("Failed to get user for payment success email: %v", err)
+		return
+	}
+
+	links, err := n.downloadService.GenerateForOrder(orderID)
+	if err != nil {
+		log.Printf("Failed to generate download links for order %d: %v", orderID, err)
+	}
+
+	var itemCount int64
+	n.db.Model(&order.OrderItem{}).Where("order_id = ?", orderID).Count(&itemCount)
+
+	downloads := make([]email.DownloadLink, 0, len(links))
+	for _, link := range links {
+		downloads = append(downloads, email.DownloadLink{
+			Name:      link.Name,
+			URL:       link.URL,
+			ExpiresAt: link.ExpiresAt.In(n.config.GetLocation()).Format("January 2, 2006 3:04 PM MST"),
+		})
+	}
+
+	// Send email using email service
+	emailData := map[string]interface{}{
+		"UserName":    fmt.Sprintf("%s %s", userRecord.FirstName, userRecord.LastName),
+		"OrderNumber": orderRecord.OrderNumber,
+		"Amount":      float64(orderRecord.TotalAmount) / 100,
+		"OrderURL":    fmt.Sprintf("%s/orders/%d", n.config.App.FrontendURL, orderID),
+		"Downloads":   downloads,
+		"DigitalOnly": itemCount > 0 && int64(len(links)) == itemCount,
+	}
+
+	err = n.emailService.SendTemplateEmail(
+		userRecord.Email,
+		"Payment Successful - "+orderRecord.OrderNumber,
+		"payment_success",
+		emailData,
+	)
+
+	if err != nil {
+		log.Printf("Failed to send payment success email: %v", err)
+	}
+}
+
+// sendPaymentFailureEmail emails the customer that their payment failed and can be retried
+func (n *paymentNotifier) sendPaymentFailureEmail(orderID uint, paymentMethod, reason string) {
+	var orderRecord order.Order
+	if err := n.db.Where("id = ?", orderID).First(&orderRecord).Error; err != nil {
+		log.Printf("Failed to get order for payment failure email: %v", err)
+		return
+	}
+
+	var userRecord user.User
+	if err := n.db.Select("email, first_name, last_name").Where("id = ?", orderRecord.UserID).First(&userRecord).Error; err != nil {
+		log.Printf("Failed to get user for payment failure email: %v", err)
+		return
+	}
+
+	// Send email using email service
+	emailData := map[string]interface{}{
+		"UserName":      fmt.Sprintf("%s %s", userRecord.FirstName, userRecord.LastName),
+		"OrderNumber":   orderRecord.OrderNumber,
+		"Amount":        float64(orderRecord.TotalAmount) / 100,
+		"PaymentMethod": paymentMethod,
+		"Reason":        reason,
+		"OrderURL":      fmt.Sprintf("%s/orders/%d", n.config.App.FrontendURL, orderID),
+		"SupportURL":    fmt.Sprintf("%s/support", n.config.App.FrontendURL),
+		"Year":          time.Now().Year(),
+		"SiteName":      n.config.App.Name,
+	}
+
+	err := n.emailService.SendTemplateEmail(
+		userRecord.Email,
+		"Payment Failed - "+orderRecord.OrderNumber,
+		"payment_failed",
+		emailData,
+	)
+
+	if err != nil {
+		log.Printf("Failed to send payment failure email: %v", err)
+	}
+}
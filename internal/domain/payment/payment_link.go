@@ -0,0 +1,146 @@
+// internal/domain/payment/payment_link.go
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/user"
+	"github.com/your-org/ecommerce-backend/internal/pkg/email"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPaymentOrderNotFound   = errors.New("order not found")
+	ErrOrderNotPayable        = errors.New("order is not awaiting payment")
+	ErrPaymentLinkRateLimited = errors.New("payment link resend limit reached")
+)
+
+// PaymentLinkService lets customers resume payment for unpaid orders
+type PaymentLinkService struct {
+	db              *gorm.DB
+	redisClient     *redis.Client
+	config          *config.Config
+	razorpayService *RazorpayService
+	emailService    *email.EmailService
+}
+
+// NewPaymentLinkService creates a new payment link service
+func NewPaymentLinkService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *PaymentLinkService {
+	return &PaymentLinkService{
+		db:              db,
+		redisClient:     redisClient,
+		config:          cfg,
+		razorpayService: NewRazorpayService(db, cfg),
+		emailService:    email.NewEmailService(db, cfg),
+	}
+}
+
+// PaymentLinkResponse represents a re-initiated payment and how to complete it
+type PaymentLinkResponse struct {
+	Payment          *PaymentInitiationResponse `json:"payment"`     // Checkout data for the new gateway order
+	PaymentURL       string                     `json:"payment_url"` // Storefront page that resumes payment
+	EmailSent        bool                       `json:"email_sent"`
+	ResendsRemaining int                        `json:"resends_remaining"`
+}
+
+// ResendPaymentLink creates a fresh gateway order for the customer's unpaid order,
+// using the same retry-aware path as payment initiation, and emails the payment link
+func (s *PaymentLinkService) ResendPaymentLink(userID, orderID uint) (*PaymentLinkResponse, error) {
+	var orderRecord order.Order
+	if err := s.db.Where("id = ? AND user_id = ?", orderID, userID).First(&orderRecord).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPaymentOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if orderRecord.PaymentStatus == order.PaymentStatusPaid || orderRecord.TotalAmount <= 0 ||
+		!canAcceptPayment(orderRecord) {
+		return nil, fmt.Errorf("%w: status %s, payment status %s", ErrOrderNotPayable, orderRecord.Status, orderRecord.PaymentStatus)
+	}
+
+	remaining, err := s.consumeResend(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentResponse, err := s.razorpayService.CreatePaymentOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PaymentLinkResponse{
+		Payment:          paymentResponse,
+		PaymentURL:       fmt.Sprintf("%s/orders/%d/pay", strings.TrimRight(s.config.App.FrontendURL, "/"), orderID),
+		ResendsRemaining: remaining,
+	}
+
+	if err := s.sendPaymentLinkEmail(&orderRecord, response.PaymentURL); err != nil {
+		log.Printf("Failed to send payment link email for order %d: %v", orderID, err)
+	} else {
+		response.EmailSent = true
+	}
+
+	return response, nil
+}
+
+// consumeResend counts a resend against the order's rolling limit, returning how many remain
+func (s *PaymentLinkService) consumeResend(orderID uint) (int, error) {
+	limit := s.config.Payment.LinkResendLimit
+	window := s.config.Payment.LinkResendWindow
+	if limit <= 0 || window <= 0 {
+		return 0, nil
+	}
+
+	ctx := context.Background()
+	key := fmt.Sprintf("payment_link_resend:%d", orderID)
+
+	count, err := s.redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check resend limit: %w", err)
+	}
+	if count == 1 {
+		s.redisClient.Expire(ctx, key, window)
+	}
+
+	if count > int64(limit) {
+		retryIn := s.redisClient.TTL(ctx, key).Val()
+		if retryIn < 0 {
+			retryIn = window
+		}
+		return 0, fmt.Errorf("%w: try again in %s", ErrPaymentLinkRateLimited, retryIn.Round(time.Minute))
+	}
+	return limit - int(count), nil
+}
+
+// sendPaymentLinkEmail emails the customer a link to complete payment
+func (s *PaymentLinkService) sendPaymentLinkEmail(orderRecord *order.Order, paymentURL string) error {
+	var userRecord user.User
+	if err := s.db.Select("email, first_name").Where("id = ?", orderRecord.UserID).First(&userRecord).Error; err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	amount := fmt.Sprintf("%s %.2f", orderRecord.Currency, float64(orderRecord.TotalAmount)/100)
+	subject := "Complete your payment - " + orderRecord.OrderNumber
+	body := fmt.Sprintf(`<p>Hello %s,</p>
+<p>Your order <strong>%s</strong> for %s is waiting for payment.</p>
+<p><a href="%s">Complete payment</a></p>
+<p>If you have already paid, you can ignore this email.</p>`, html.EscapeString(userRecord.FirstName), orderRecord.OrderNumber, amount, paymentURL)
+
+	return s.emailService.SendEmail(context.Background(), &email.Email{
+		To:          []string{userRecord.Email},
+		Subject:     subject,
+		HTMLContent: body,
+		TextContent: fmt.Sprintf("Your order %s for %s is waiting for payment. Complete payment: %s", orderRecord.OrderNumber, amount, paymentURL),
+		Type:        email.EmailType("payment_link"),
+	})
+}
@@ -0,0 +1,388 @@
+// internal/domain/payment/provider.go
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/inventory"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"gorm.io/gorm"
+)
+
+// Payment provider names, stored as Payment.Gateway
+const (
+	ProviderRazorpay = "razorpay"
+	ProviderStripe   = "stripe"
+)
+
+var (
+	// ErrProviderNotConfigured is returned when the order's payment provider has no credentials
+	ErrProviderNotConfigured = errors.New("payment provider is not configured")
+
+	// ErrPaymentAttemptsExceeded is returned once an order has used all of its payment attempts
+	ErrPaymentAttemptsExceeded = errors.New("payment attempt limit reached for this order")
+)
+
+// Failure code and reason of payments expired while stuck in processing. The customer
+// never completed them, so they do not count as payment attempts.
+const (
+	paymentTimeoutCode   = "timeout"
+	paymentTimeoutReason = "Payment timeout - expired"
+)
+
+// PaymentProvider is a payment gateway that takes payment for orders
+type PaymentProvider interface {
+	// Name returns the provider name stored on payment records
+	Name() string
+
+	// CreateOrder starts a gateway payment for the order
+	CreateOrder(orderID uint) (*PaymentInitiationResponse, error)
+
+	// VerifyPayment confirms a payment the customer completed in the client
+	VerifyPayment(req *PaymentVerificationRequest) error
+
+	// Refund refunds amount (in cents, 0 for the full amount) of a gateway payment.
+	// reference identifies the refund, e.g. the return it settles, so a retried refund
+	// is sent to the gateway once while separate refunds of the same amount are not merged.
+	Refund(providerPaymentID string, amount int64, reference, reason string) error
+
+	// VerifyWebhook checks a webhook body against its signature header
+	VerifyWebhook(body []byte, signature string) bool
+}
+
+var (
+	_ PaymentProvider = (*RazorpayService)(nil)
+	_ PaymentProvider = (*StripeService)(nil)
+)
+
+// ProviderForMethod returns the provider that takes payment for an order payment method.
+// Methods without a dedicated provider (cards, UPI, wallets, older orders without a
+// method) go through Razorpay.
+func ProviderForMethod(method string) string {
+	switch strings.ToLower(strings.TrimSpace(method)) {
+	case ProviderStripe:
+		return ProviderStripe
+	default:
+		return ProviderRazorpay
+	}
+}
+
+// ProviderSelector picks the payment provider for an order
+type ProviderSelector struct {
+	razorpay *RazorpayService
+	stripe   *StripeService
+	config   *config.Config
+}
+
+// NewProviderSelector creates a provider selector over the given provider services
+func NewProviderSelector(razorpay *RazorpayService, stripe *StripeService, cfg *config.Config) *ProviderSelector {
+	return &ProviderSelector{
+		razorpay: razorpay,
+		stripe:   stripe,
+		config:   cfg,
+	}
+}
+
+// ForMethod returns the provider for an order payment method
+func (p *ProviderSelector) ForMethod(method string) (PaymentProvider, error) {
+	switch ProviderForMethod(method) {
+	case ProviderStripe:
+		if p.config.External.Stripe.SecretKey == "" {
+			return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, ProviderStripe)
+		}
+		return p.stripe, nil
+	default:
+		if !p.razorpay.IsConfigured() {
+			return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, ProviderRazorpay)
+		}
+		return p.razorpay, nil
+	}
+}
+
+// ForOrder returns the provider for the payment method chosen on the order
+func (p *ProviderSelector) ForOrder(o *order.Order) (PaymentProvider, error) {
+	return p.ForMethod(o.PaymentMethod)
+}
+
+// recordRefund adds a gateway refund of amount (0 for the full amount) to the payment
+func recordRefund(db *gorm.DB, payment *order.Payment, refundID string, amount int64) error {
+	status, refunded := refundState(payment, amount)
+	err := db.Model(payment).Updates(map[string]interface{}{
+		"status":          status,
+		"refund_id":       refundID,
+		"refunded_amount": refunded,
+		"updated_at":      time.Now().UTC(),
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update payment record: %w", err)
+	}
+	return nil
+}
+
+// refundState returns the payment's status and total refunded after a further refund
+// of amount. The payment is refunded once refunds cover its amount, and partially
+// refunded before that; amount 0 is a full refund.
+func refundState(payment *order.Payment, amount int64) (order.PaymentStatus, int64) {
+	refunded := payment.Amount
+	if amount > 0 {
+		refunded = min(payment.RefundedAmount+amount, payment.Amount)
+	}
+	if refunded >= payment.Amount {
+		return order.PaymentStatusRefunded, refunded
+	}
+	return order.PaymentStatusPartiallyRefunded, refunded
+}
+
+// canAcceptPayment reports whether an order can take a new payment attempt
+func canAcceptPayment(orderDetails order.Order) bool {
+	// Allow pending and payment processing orders
+	if orderDetails.Status == order.OrderStatusPending ||
+		orderDetails.Status == order.OrderStatusPaymentProcessing {
+		return true
+	}
+
+	// Allow confirmed orders ONLY if payment failed
+	if orderDetails.Status == order.OrderStatusConfirmed &&
+		orderDetails.PaymentStatus == order.PaymentStatusFailed {
+		return true
+	}
+
+	// Don't allow if order is cancelled, shipped, delivered, etc.
+	return false
+}
+
+// handleExistingPayments checks earlier payment attempts before a retry, expiring
+// attempts stuck in processing for longer than stuckTimeout
+func handleExistingPayments(db *gorm.DB, orderID uint, stuckTimeout time.Duration) error {
+	var existingPayments []order.Payment
+	err := db.Where("order_id = ?", orderID).Order("created_at DESC").Find(&existingPayments).Error
+	if err != nil {
+		return err
+	}
+
+	for _, payment := range existingPayments {
+		switch payment.Status {
+		case order.PaymentStatusPaid:
+			return fmt.Errorf("payment already completed for this order")
+		case order.PaymentStatusProcessing:
+			// Check if payment is stuck
+			if time.Since(payment.CreatedAt) > stuckTimeout {
+				// Mark as expired/failed
+				db.Model(&payment).Updates(map[string]interface{}{
+					"status":         order.PaymentStatusFailed,
+					"failure_reason": paymentTimeoutReason,
+					"failure_code":   paymentTimeoutCode,
+					"updated_at":     time.Now().UTC(),
+				})
+			} else {
+				return fmt.Errorf("payment is currently being processed")
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkPaymentAttempts enforces the per-order payment attempt limit before a new attempt
+// is made, returning how many attempts remain after it (nil when unlimited). Once the
+// limit is reached the order is put on hold until an admin clears it.
+func checkPaymentAttempts(db *gorm.DB, cfg *config.Config, orderRecord *order.Order) (*int, error) {
+	if orderRecord.IsOnPaymentAttemptHold() {
+		return nil, fmt.Errorf("%w: please contact support to complete your order", ErrPaymentAttemptsExceeded)
+	}
+
+	limit := cfg.Payment.MaxAttemptsPerOrder
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	query := db.Model(&order.Payment{}).
+		Where("order_id = ? AND COALESCE(failure_code, '') <> ?", orderRecord.ID, paymentTimeoutCode)
+	if orderRecord.PaymentAttemptsResetAt != nil {
+		query = query.Where("created_at > ?", *orderRecord.PaymentAttemptsResetAt)
+	}
+
+	var attempts int64
+	if err := query.Count(&attempts).Error; err != nil {
+		return nil, fmt.Errorf("failed to count payment attempts: %w", err)
+	}
+
+	if attempts >= int64(limit) {
+		if err := holdForPaymentAttempts(db, orderRecord, attempts); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: please contact support to complete your order", ErrPaymentAttemptsExceeded)
+	}
+
+	remaining := limit - int(attempts) - 1
+	return &remaining, nil
+}
+
+// holdForPaymentAttempts puts the order on payment attempt hold and records why
+func holdForPaymentAttempts(db *gorm.DB, orderRecord *order.Order, attempts int64) error {
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	err := tx.Model(&order.Order{}).
+		Where("id = ?", orderRecord.ID).
+		Update("payment_attempts_exceeded", true).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to hold order: %w", err)
+	}
+
+	statusHistory := order.OrderStatusHistory{
+		OrderID:   orderRecord.ID,
+		Status:    orderRecord.Status,
+		Comment:   fmt.Sprintf("Payment attempt limit reached after %d attempts - admin review required", attempts),
+		CreatedBy: 0, // System generated
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := tx.Create(&statusHistory).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to create status history: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	orderRecord.PaymentAttemptsExceeded = true
+	return nil
+}
+
+// confirmPayment marks the gateway payment paid with the given extra fields, confirms
+// the order and records the status change, in one transaction
+func confirmPayment(db *gorm.DB, cfg *config.Config, orderID uint, providerID string, paymentUpdates map[string]interface{}, comment string) error {
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	updates := map[string]interface{}{
+		"status":       order.PaymentStatusPaid,
+		"processed_at": time.Now().UTC(),
+	}
+	for k, v := range paymentUpdates {
+		updates[k] = v
+	}
+
+	// Update payment record
+	err := tx.Model(&order.Payment{}).
+		Where("order_id = ? AND payment_provider_id = ?", orderID, providerID).
+		Updates(updates).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update payment record: %w", err)
+	}
+
+	// Update order status
+	err = tx.Model(&order.Order{}).
+		Where("id = ?", orderID).
+		Updates(map[string]interface{}{
+			"status":         order.OrderStatusConfirmed,
+			"payment_status": order.PaymentStatusPaid,
+			"updated_at":     time.Now().UTC(),
+		}).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	// Add status history
+	statusHistory := order.OrderStatusHistory{
+		OrderID:   orderID,
+		Status:    order.OrderStatusConfirmed,
+		Comment:   comment,
+		CreatedBy: 0, // System generated
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := tx.Create(&statusHistory).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to create status history: %w", err)
+	}
+
+	if err := order.SplitFulfillment(tx, cfg, orderID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	fulfilledItems, err := inventory.FulfillOrderReservations(tx, orderID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	inventory.CheckStockAlerts(db, cfg, fulfilledItems)
+	return nil
+}
+
+// recordPaymentFailure marks the order's payments failed (only providerID's when set)
+// and keeps the order confirmed so the customer can retry, in one transaction
+func recordPaymentFailure(db *gorm.DB, orderID uint, providerID, reason, code string) error {
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Update payment status to failed
+	paymentQuery := tx.Model(&order.Payment{}).Where("order_id = ?", orderID)
+	if providerID != "" {
+		paymentQuery = paymentQuery.Where("payment_provider_id = ?", providerID)
+	}
+	err := paymentQuery.Updates(map[string]interface{}{
+		"status":         order.PaymentStatusFailed,
+		"failure_reason": reason,
+		"failure_code":   code,
+		"updated_at":     time.Now().UTC(),
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	// Reset order status to confirmed but mark payment as failed to allow retry
+	err = tx.Model(&order.Order{}).
+		Where("id = ?", orderID).
+		Updates(map[string]interface{}{
+			"status":         order.OrderStatusConfirmed, // Keep confirmed to allow retry
+			"payment_status": order.PaymentStatusFailed,
+			"updated_at":     time.Now().UTC(),
+		}).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+
+	// Add status history
+	statusHistory := order.OrderStatusHistory{
+		OrderID:   orderID,
+		Status:    order.OrderStatusConfirmed, // Keep as confirmed for retry
+		Comment:   fmt.Sprintf("Payment failed: %s (%s)", reason, code),
+		CreatedBy: 0,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := tx.Create(&statusHistory).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to create status history: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// internal/domain/payment/provider_test.go
+package payment
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+)
+
+func TestRefundState(t *testing.T) {
+	tests := []struct {
+		name         string
+		refunded     int64
+		amount       int64
+		wantStatus   order.PaymentStatus
+		wantRefunded int64
+	}{
+		{"full refund", 0, 0, order.PaymentStatusRefunded, 10000},
+		{"partial refund", 0, 2500, order.PaymentStatusPartiallyRefunded, 2500},
+		{"second partial refund", 2500, 2500, order.PaymentStatusPartiallyRefunded, 5000},
+		{"refunds reach the amount", 7500, 2500, order.PaymentStatusRefunded, 10000},
+		{"refunds capped at the amount", 7500, 5000, order.PaymentStatusRefunded, 10000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment := &order.Payment{Amount: 10000, RefundedAmount: tt.refunded}
+			status, refunded := refundState(payment, tt.amount)
+			if status != tt.wantStatus || refunded != tt.wantRefunded {
+				t.Errorf("refundState() = %s, %d; want %s, %d", status, refunded, tt.wantStatus, tt.wantRefunded)
+			}
+		})
+	}
+}
+
+func TestProviderSelectorForMethod(t *testing.T) {
+	configured := &config.Config{}
+	configured.External.Stripe.SecretKey = "sk_test"
+	configuredSelector := NewProviderSelector(&RazorpayService{keyID: "rzp_key", keySecret: "rzp_secret"},
+		&StripeService{}, configured)
+	unconfiguredSelector := NewProviderSelector(&RazorpayService{}, &StripeService{}, &config.Config{})
+
+	tests := []struct {
+		name     string
+		selector *ProviderSelector
+		method   string
+		want     string
+		wantErr  bool
+	}{
+		{"stripe", configuredSelector, "stripe", ProviderStripe, false},
+		{"stripe in any case", configuredSelector, " Stripe ", ProviderStripe, false},
+		{"razorpay", configuredSelector, "razorpay", ProviderRazorpay, false},
+		{"other methods use razorpay", configuredSelector, "upi", ProviderRazorpay, false},
+		{"no method uses razorpay", configuredSelector, "", ProviderRazorpay, false},
+		{"stripe not configured", unconfiguredSelector, "stripe", "", true},
+		{"razorpay not configured", unconfiguredSelector, "card", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := tt.selector.ForOrder(&order.Order{PaymentMethod: tt.method})
+			if tt.wantErr {
+				if !errors.Is(err, ErrProviderNotConfigured) {
+					t.Errorf("ForOrder(%q) error = %v, want %v", tt.method, err, ErrProviderNotConfigured)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ForOrder(%q) error = %v", tt.method, err)
+			}
+			if provider.Name() != tt.want {
+				t.Errorf("ForOrder(%q) = %s, want %s", tt.method, provider.Name(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRazorpayWithoutCredentials(t *testing.T) {
+	// Development allows starting without keys; Razorpay is then disabled, not broken
+	cfg := &config.Config{}
+	cfg.App.Environment = "development"
+	r := NewRazorpayService(nil, cfg)
+	if r.IsConfigured() {
+		t.Fatal("IsConfigured() = true without credentials")
+	}
+
+	if _, err := r.makeAPICall("GET", "/payments/pay_123", nil); !errors.Is(err, ErrProviderNotConfigured) {
+		t.Errorf("makeAPICall() error = %v, want %v", err, ErrProviderNotConfigured)
+	}
+	if err := r.Refund("pay_123", 1000, "return-1", "Return"); !errors.Is(err, ErrProviderNotConfigured) {
+		t.Errorf("Refund() error = %v, want %v", err, ErrProviderNotConfigured)
+	}
+}
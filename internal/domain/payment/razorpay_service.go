@@ -14,33 +14,30 @@ import (
 
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"github.com/your-org/ecommerce-backend/internal/domain/order"
-	"github.com/your-org/ecommerce-backend/internal/domain/user"
-	"github.com/your-org/ecommerce-backend/internal/pkg/email"
+	"github.com/your-org/ecommerce-backend/internal/pkg/metrics"
 	"gorm.io/gorm"
 )
 
 // RazorpayService handles Razorpay payment processing
 type RazorpayService struct {
-	db           *gorm.DB
-	config       *config.Config
-	keyID        string
-	keySecret    string
-	baseURL      string
-	httpClient   *http.Client
-	emailService *email.EmailService
+	db         *gorm.DB
+	config     *config.Config
+	keyID      string
+	keySecret  string
+	baseURL    string
+	httpClient *http.Client
+	limiter    *gatewayLimiter
+	notifier   *paymentNotifier
 }
 
 // NewRazorpayService creates a new Razorpay service
 func NewRazorpayService(db *gorm.DB, cfg *config.Config) *RazorpayService {
-	// Use hardcoded test credentials if config is empty
+	// Credentials come only from config; without them the provider stays disabled.
+	// Config validation refuses to start outside development when they are missing.
 	keyID := cfg.External.Razorpay.KeyID
 	keySecret := cfg.External.Razorpay.KeySecret
-
-	if keyID == "" {
-		keyID = "rzp_test_JKEqkGjDCkWMed"
-	}
-	if keySecret == "" {
-		keySecret = "lrcsYTuX1Y1iQmEzzfmYdxQZ"
+	if keyID == "" || keySecret == "" {
+		log.Printf("Warning: RAZORPAY_KEY_ID or RAZORPAY_KEY_SECRET is not set; Razorpay payments are disabled")
 	}
 
 	return &RazorpayService{
@@ -52,10 +49,16 @@ func NewRazorpayService(db *gorm.DB, cfg *config.Config) *RazorpayService {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		emailService: email.NewEmailService(cfg),
+		limiter:  sharedGatewayLimiter(cfg.External.Razorpay.MaxConcurrentCalls),
+		notifier: newPaymentNotifier(db, cfg),
 	}
 }
 
+// IsConfigured reports whether Razorpay API credentials are set
+func (r *RazorpayService) IsConfigured() bool {
+	return r.keyID != "" && r.keySecret != ""
+}
+
 // Required structs
 type RazorpayOrder struct {
 	ID        string                 `json:"id"`
@@ -75,10 +78,13 @@ type CreateOrderRequest struct {
 	Notes    map[string]interface{} `json:"notes,omitempty"`
 }
 
+// PaymentVerificationRequest carries the client-side result of a payment: the Razorpay
+// checkout fields, or the Stripe PaymentIntent ID
 type PaymentVerificationRequest struct {
-	RazorpayOrderID   string `json:"razorpay_order_id" binding:"required"`
-	RazorpayPaymentID string `json:"razorpay_payment_id" binding:"required"`
-	RazorpaySignature string `json:"razorpay_signature" binding:"required"`
+	RazorpayOrderID   string `json:"razorpay_order_id" binding:"required_without=PaymentIntentID"`
+	RazorpayPaymentID string `json:"razorpay_payment_id" binding:"required_without=PaymentIntentID"`
+	RazorpaySignature string `json:"razorpay_signature" binding:"required_without=PaymentIntentID"`
+	PaymentIntentID   string `json:"payment_intent_id"`
 	OrderID           uint   `json:"order_id" binding:"required"`
 }
 
@@ -99,14 +105,21 @@ type RazorpayPayment struct {
 	CreatedAt   int64                  `json:"created_at"`
 }
 
+// PaymentInitiationResponse holds what the client needs to complete payment with the provider
 type PaymentInitiationResponse struct {
-	RazorpayOrderID string                 `json:"razorpay_order_id"`
+	Provider        string                 `json:"provider"`
+	RazorpayOrderID string                 `json:"razorpay_order_id,omitempty"`
+	PaymentIntentID string                 `json:"payment_intent_id,omitempty"` // Stripe
+	ClientSecret    string                 `json:"client_secret,omitempty"`     // Stripe, confirms the PaymentIntent in Stripe.js
 	Amount          int64                  `json:"amount"`
 	Currency        string                 `json:"currency"`
 	Receipt         string                 `json:"receipt"`
-	KeyID           string                 `json:"key_id"`
+	KeyID           string                 `json:"key_id"` // Razorpay key ID or Stripe publishable key
 	Notes           map[string]interface{} `json:"notes"`
 	OrderDetails    *order.Order           `json:"order_details"`
+
+	// Payment attempts left for the order after this one; omitted when unlimited
+	AttemptsRemaining *int `json:"attempts_remaining,omitempty"`
 }
 
 type RefundRequest struct {
@@ -126,6 +139,35 @@ type RazorpayRefund struct {
 	CreatedAt int64  `json:"created_at"`
 }
 
+// Name returns the provider name stored on payment records
+func (r *RazorpayService) Name() string {
+	return ProviderRazorpay
+}
+
+// CreateOrder starts a Razorpay payment for the order
+func (r *RazorpayService) CreateOrder(orderID uint) (*PaymentInitiationResponse, error) {
+	return r.CreatePaymentOrder(orderID)
+}
+
+// Refund refunds a captured Razorpay payment
+func (r *RazorpayService) Refund(providerPaymentID string, amount int64, reference, reason string) error {
+	return r.CreateRefund(providerPaymentID, amount, reference, reason)
+}
+
+// VerifyWebhook checks a webhook body against the X-Razorpay-Signature header. Without a
+// webhook secret, verification is skipped in development only.
+func (r *RazorpayService) VerifyWebhook(body []byte, signature string) bool {
+	secret := r.config.External.Razorpay.WebhookSecret
+	if secret == "" {
+		return r.config.IsDevelopment()
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expectedSignature := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(signature), []byte(expectedSignature))
+}
+
 // CreatePaymentOrder creates a Razorpay order for payment - NOW WITH RETRY SUPPORT
 func (r *RazorpayService) CreatePaymentOrder(orderID uint) (*PaymentInitiationResponse, error) {
 	// Get order details
@@ -136,17 +178,22 @@ func (r *RazorpayService) CreatePaymentOrder(orderID uint) (*PaymentInitiationRe
 	}
 
 	// ENHANCED: Check if order can accept payment (supports retry)
-	if !r.canAcceptPayment(orderDetails) {
+	if !canAcceptPayment(orderDetails) {
 		return nil, fmt.Errorf("order cannot accept payment. Status: %s, Payment Status: %s",
 			orderDetails.Status, orderDetails.PaymentStatus)
 	}
 
 	// ENHANCED: Handle existing payments for retry scenarios
-	err = r.handleExistingPayments(orderID)
+	err = handleExistingPayments(r.db, orderID, r.config.Payment.StuckTimeout)
 	if err != nil {
 		return nil, fmt.Errorf("failed to handle existing payments: %w", err)
 	}
 
+	attemptsRemaining, err := checkPaymentAttempts(r.db, r.config, &orderDetails)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create Razorpay order
 	createReq := CreateOrderRequest{
 		Amount:   orderDetails.TotalAmount,
@@ -178,13 +225,13 @@ func (r *RazorpayService) CreatePaymentOrder(orderID uint) (*PaymentInitiationRe
 	// Create new payment record
 	payment := order.Payment{
 		OrderID:           orderID,
-		PaymentMethod:     "razorpay",
+		PaymentMethod:     ProviderRazorpay,
 		PaymentProviderID: razorpayOrder.ID,
 		Amount:            orderDetails.TotalAmount,
 		Currency:          orderDetails.Currency,
 		Status:            order.PaymentStatusProcessing,
-		Gateway:           "razorpay",
-		GatewayResponse:   r.structToJSON(razorpayOrder),
+		Gateway:           ProviderRazorpay,
+		GatewayResponse:   structToJSON(razorpayOrder),
 		CreatedAt:         time.Now().UTC(),
 	}
 
@@ -195,6 +242,7 @@ func (r *RazorpayService) CreatePaymentOrder(orderID uint) (*PaymentInitiationRe
 
 	// Prepare response for frontend
 	response := &PaymentInitiationResponse{
+		Provider:        ProviderRazorpay,
 		RazorpayOrderID: razorpayOrder.ID,
 		Amount:          orderDetails.TotalAmount,
 		Currency:        razorpayOrder.Currency,
@@ -202,57 +250,11 @@ func (r *RazorpayService) CreatePaymentOrder(orderID uint) (*PaymentInitiationRe
 		KeyID:           r.keyID,
 		Notes:           razorpayOrder.Notes,
 		OrderDetails:    &orderDetails,
-	}
 
-	return response, nil
-}
-
-// ENHANCED: Check if order can accept payment (supports confirmed orders with failed payments)
-func (r *RazorpayService) canAcceptPayment(orderDetails order.Order) bool {
-	// Allow pending and payment processing orders
-	if orderDetails.Status == order.OrderStatusPending ||
-		orderDetails.Status == order.OrderStatusPaymentProcessing {
-		return true
+		AttemptsRemaining: attemptsRemaining,
 	}
 
-	// Allow confirmed orders ONLY if payment failed
-	if orderDetails.Status == order.OrderStatusConfirmed &&
-		orderDetails.PaymentStatus == order.PaymentStatusFailed {
-		return true
-	}
-
-	// Don't allow if order is cancelled, shipped, delivered, etc.
-	return false
-}
-
-// ENHANCED: Handle existing payments for retry scenarios
-func (r *RazorpayService) handleExistingPayments(orderID uint) error {
-	var existingPayments []order.Payment
-	err := r.db.Where("order_id = ?", orderID).Order("created_at DESC").Find(&existingPayments).Error
-	if err != nil {
-		return err
-	}
-
-	for _, payment := range existingPayments {
-		switch payment.Status {
-		case order.PaymentStatusPaid:
-			return fmt.Errorf("payment already completed for this order")
-		case order.PaymentStatusProcessing:
-			// Check if payment is stuck (>15 minutes old)
-			if time.Since(payment.CreatedAt) > 15*time.Minute {
-				// Mark as expired/failed
-				r.db.Model(&payment).Updates(map[string]interface{}{
-					"status":         order.PaymentStatusFailed,
-					"failure_reason": "Payment timeout - expired",
-					"updated_at":     time.Now().UTC(),
-				})
-			} else {
-				return fmt.Errorf("payment is currently being processed")
-			}
-		}
-	}
-
-	return nil
+	return response, nil
 }
 
 // Check if this is a retry attempt
@@ -288,131 +290,31 @@ func (r *RazorpayService) VerifyPayment(req *PaymentVerificationRequest) error {
 			orderDetails.TotalAmount, payment.Amount)
 	}
 
-	// Start transaction
-	tx := r.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
-	// Update payment record
-	err = tx.Model(&order.Payment{}).
-		Where("order_id = ? AND payment_provider_id = ?", req.OrderID, req.RazorpayOrderID).
-		Updates(map[string]interface{}{
-			"status":           order.PaymentStatusPaid,
-			"gateway_response": r.structToJSON(payment),
-			"processed_at":     time.Now().UTC(),
-		}).Error
-
-	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to update payment record: %w", err)
-	}
-
-	// Update order status
-	err = tx.Model(&order.Order{}).
-		Where("id = ?", req.OrderID).
-		Updates(map[string]interface{}{
-			"status":         order.OrderStatusConfirmed,
-			"payment_status": order.PaymentStatusPaid,
-			"updated_at":     time.Now().UTC(),
-		}).Error
-
-	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to update order status: %w", err)
-	}
-
-	// Add status history
-	statusHistory := order.OrderStatusHistory{
-		OrderID:   req.OrderID,
-		Status:    order.OrderStatusConfirmed,
-		Comment:   fmt.Sprintf("Payment confirmed via Razorpay. Payment ID: %s", req.RazorpayPaymentID),
-		CreatedBy: 0, // System generated
-		CreatedAt: time.Now().UTC(),
-	}
-
-	err = tx.Create(&statusHistory).Error
+	err = confirmPayment(r.db, r.config, req.OrderID, req.RazorpayOrderID, map[string]interface{}{
+		"gateway_response": structToJSON(payment),
+		"gateway_fee":      payment.Fee,
+		"gateway_tax":      payment.Tax,
+	}, fmt.Sprintf("Payment confirmed via Razorpay. Payment ID: %s", req.RazorpayPaymentID))
 	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to create status history: %w", err)
-	}
-
-	// Commit transaction
-	err = tx.Commit().Error
-	if err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return err
 	}
+	metrics.RecordPayment(ProviderRazorpay, true)
 
 	// Send success email asynchronously
-	go r.sendPaymentSuccessEmail(req.OrderID)
+	go r.notifier.sendPaymentSuccessEmail(req.OrderID)
 
 	return nil
 }
 
 // HandlePaymentFailure handles payment failure scenarios
 func (r *RazorpayService) HandlePaymentFailure(orderID uint, reason, code string) error {
-	// Start transaction
-	tx := r.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
-	// Update payment status to failed
-	err := tx.Model(&order.Payment{}).
-		Where("order_id = ?", orderID).
-		Updates(map[string]interface{}{
-			"status":         order.PaymentStatusFailed,
-			"failure_reason": reason,
-			"failure_code":   code,
-			"updated_at":     time.Now().UTC(),
-		}).Error
-
-	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to update payment: %w", err)
-	}
-
-	// Reset order status to confirmed but mark payment as failed to allow retry
-	err = tx.Model(&order.Order{}).
-		Where("id = ?", orderID).
-		Updates(map[string]interface{}{
-			"status":         order.OrderStatusConfirmed, // Keep confirmed to allow retry
-			"payment_status": order.PaymentStatusFailed,
-			"updated_at":     time.Now().UTC(),
-		}).Error
-
-	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to update order: %w", err)
-	}
-
-	// Add status history
-	statusHistory := order.OrderStatusHistory{
-		OrderID:   orderID,
-		Status:    order.OrderStatusConfirmed, // Keep as confirmed for retry
-		Comment:   fmt.Sprintf("Payment failed: %s (%s)", reason, code),
-		CreatedBy: 0,
-		CreatedAt: time.Now().UTC(),
-	}
-
-	err = tx.Create(&statusHistory).Error
-	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to create status history: %w", err)
-	}
-
-	// Commit transaction
-	err = tx.Commit().Error
-	if err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	if err := recordPaymentFailure(r.db, orderID, "", reason, code); err != nil {
+		return err
 	}
+	metrics.RecordPayment(ProviderRazorpay, false)
 
 	// Send failure email asynchronously
-	go r.sendPaymentFailureEmail(orderID, reason)
+	go r.notifier.sendPaymentFailureEmail(orderID, "Razorpay", reason)
 
 	return nil
 }
@@ -431,15 +333,15 @@ func (r *RazorpayService) GetPaymentStatus(orderID uint) (*order.Payment, error)
 	return &payment, nil
 }
 
-// CreateRefund creates a refund for a payment
-func (r *RazorpayService) CreateRefund(paymentID string, amount int64, reason string) error {
+// CreateRefund creates a refund for a payment, with receipt as the refund's reference
+func (r *RazorpayService) CreateRefund(paymentID string, amount int64, receipt, reason string) error {
 	refundReq := RefundRequest{
 		Amount: amount,
 		Speed:  "normal",
 		Notes: map[string]interface{}{
 			"reason": reason,
 		},
-		Receipt: fmt.Sprintf("refund_%d", time.Now().Unix()),
+		Receipt: receipt,
 	}
 
 	endpoint := fmt.Sprintf("/payments/%s/refund", paymentID)
@@ -461,17 +363,7 @@ func (r *RazorpayService) CreateRefund(paymentID string, amount int64, reason st
 		return fmt.Errorf("payment not found: %w", err)
 	}
 
-	err = r.db.Model(&payment).Updates(map[string]interface{}{
-		"status":     order.PaymentStatusRefunded,
-		"refund_id":  refund.ID,
-		"updated_at": time.Now().UTC(),
-	}).Error
-
-	if err != nil {
-		return fmt.Errorf("failed to update payment record: %w", err)
-	}
-
-	return nil
+	return recordRefund(r.db, &payment, refund.ID, refund.Amount)
 }
 
 // createRazorpayOrder creates order in Razorpay
@@ -509,6 +401,11 @@ func (r *RazorpayService) getPaymentDetails(paymentID string) (*RazorpayPayment,
 
 // verifySignature verifies Razorpay webhook signature
 func (r *RazorpayService) verifySignature(orderID, paymentID, signature string) bool {
+	// An empty secret would make signatures trivially forgeable
+	if r.keySecret == "" {
+		return false
+	}
+
 	message := orderID + "|" + paymentID
 	mac := hmac.New(sha256.New, []byte(r.keySecret))
 	mac.Write([]byte(message))
@@ -516,10 +413,10 @@ func (r *RazorpayService) verifySignature(orderID, paymentID, signature string)
 	return hmac.Equal([]byte(signature), []byte(expectedSignature))
 }
 
-// makeAPICall makes HTTP calls to Razorpay API
+// makeAPICall makes HTTP calls to Razorpay API, retrying with backoff when rate limited
 func (r *RazorpayService) makeAPICall(method, endpoint string, data interface{}) ([]byte, error) {
-	if r.keyID == "" || r.keySecret == "" {
-		return nil, fmt.Errorf("Razorpay API credentials not configured")
+	if !r.IsConfigured() {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, ProviderRazorpay)
 	}
 
 	var reqBody []byte
@@ -532,9 +429,37 @@ func (r *RazorpayService) makeAPICall(method, endpoint string, data interface{})
 		}
 	}
 
-	req, err := http.NewRequest(method, r.baseURL+endpoint, bytes.NewBuffer(reqBody))
+	for attempt := 0; ; attempt++ {
+		statusCode, header, respBody, err := r.doAPICall(method, endpoint, reqBody)
+		if err != nil {
+			return nil, err
+		}
+
+		// Back off and retry when rate limited, without holding a call slot while waiting
+		if statusCode == http.StatusTooManyRequests && attempt < r.config.External.Razorpay.MaxRetries {
+			delay := retryDelay(attempt, r.config.External.Razorpay.RetryBaseDelay, header)
+			log.Printf("Razorpay rate limited %s %s, retrying in %s (attempt %d)", method, endpoint, delay, attempt+1)
+			time.Sleep(delay)
+			continue
+		}
+
+		// Check status code
+		if statusCode >= 400 {
+			return nil, fmt.Errorf("API call failed with status %d: %s", statusCode, respBody)
+		}
+
+		return respBody, nil
+	}
+}
+
+// doAPICall performs a single Razorpay request within the shared concurrency limit
+func (r *RazorpayService) doAPICall(method, endpoint string, reqBody []byte) (int, http.Header, []byte, error) {
+	r.limiter.acquire()
+	defer r.limiter.release()
+
+	req, err := http.NewRequest(method, r.baseURL+endpoint, bytes.NewReader(reqBody))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return 0, nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
@@ -544,7 +469,7 @@ func (r *RazorpayService) makeAPICall(method, endpoint string, data interface{})
 	// Make request
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make API call: %w", err)
+		return 0, nil, nil, fmt.Errorf("failed to make API call: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -552,91 +477,14 @@ func (r *RazorpayService) makeAPICall(method, endpoint string, data interface{})
 	var respBody bytes.Buffer
 	_, err = respBody.ReadFrom(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	// Check status code
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API call failed with status %d: %s", resp.StatusCode, respBody.String())
-	}
-
-	return respBody.Bytes(), nil
-}
-
-// Email notification helpers
-func (r *RazorpayService) sendPaymentSuccessEmail(orderID uint) {
-	var orderRecord order.Order
-	if err := r.db.Where("id = ?", orderID).First(&orderRecord).Error; err != nil {
-		log.Printf("Failed to get order for payment success email: %v", err)
-		return
-	}
-
-	var userRecord user.User
-	if err := r.db.Select("email, first_name, last_name").Where("id = ?", orderRecord.UserID).First(&userRecord).Error; err != nil {
-		log.Printf("Failed to get user for payment success email: %v", err)
-		return
-	}
-
-	// Send email using email service
-	emailData := map[string]interface{}{
-		"UserName":    fmt.Sprintf("%s %s", userRecord.FirstName, userRecord.LastName),
-		"OrderNumber": orderRecord.OrderNumber,
-		"Amount":      float64(orderRecord.TotalAmount) / 100,
-		"OrderURL":    fmt.Sprintf("%s/orders/%d", r.config.App.FrontendURL, orderID),
-	}
-
-	err := r.emailService.SendTemplateEmail(
-		userRecord.Email,
-		"Payment Successful - "+orderRecord.OrderNumber,
-		"payment_success",
-		emailData,
-	)
-
-	if err != nil {
-		log.Printf("Failed to send payment success email: %v", err)
-	}
-}
-
-func (r *RazorpayService) sendPaymentFailureEmail(orderID uint, reason string) {
-	var orderRecord order.Order
-	if err := r.db.Where("id = ?", orderID).First(&orderRecord).Error; err != nil {
-		log.Printf("Failed to get order for payment failure email: %v", err)
-		return
+		return 0, nil, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	var userRecord user.User
-	if err := r.db.Select("email, first_name, last_name").Where("id = ?", orderRecord.UserID).First(&userRecord).Error; err != nil {
-		log.Printf("Failed to get user for payment failure email: %v", err)
-		return
-	}
-
-	// Send email using email service
-	emailData := map[string]interface{}{
-		"UserName":      fmt.Sprintf("%s %s", userRecord.FirstName, userRecord.LastName),
-		"OrderNumber":   orderRecord.OrderNumber,
-		"Amount":        float64(orderRecord.TotalAmount) / 100,
-		"PaymentMethod": "Razorpay",
-		"Reason":        reason,
-		"OrderURL":      fmt.Sprintf("%s/orders/%d", r.config.App.FrontendURL, orderID),
-		"SupportURL":    fmt.Sprintf("%s/support", r.config.App.FrontendURL),
-		"Year":          time.Now().Year(),
-		"SiteName":      r.config.App.Name,
-	}
-
-	err := r.emailService.SendTemplateEmail(
-		userRecord.Email,
-		"Payment Failed - "+orderRecord.OrderNumber,
-		"payment_failed",
-		emailData,
-	)
-
-	if err != nil {
-		log.Printf("Failed to send payment failure email: %v", err)
-	}
+	return resp.StatusCode, resp.Header, respBody.Bytes(), nil
 }
 
 // structToJSON converts struct to JSON string
-func (r *RazorpayService) structToJSON(data interface{}) string {
+func structToJSON(data interface{}) string {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return ""
@@ -0,0 +1,200 @@
+// internal/domain/payment/settlement.go
+package payment
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+)
+
+// SettlementReportRequest represents settlement report filters
+type SettlementReportRequest struct {
+	DateFrom string `form:"date_from"` // YYYY-MM-DD, payments processed on or after
+	DateTo   string `form:"date_to"`   // YYYY-MM-DD, inclusive
+	Page     int    `form:"page,default=1"`
+	Limit    int    `form:"limit,default=50"`
+}
+
+// OrderSettlement represents gross, gateway fees and net settlement for one order
+type OrderSettlement struct {
+	OrderID     uint       `json:"order_id"`
+	OrderNumber string     `json:"order_number"`
+	Currency    string     `json:"currency"`
+	Gross       int64      `json:"gross"`       // Captured amount in cents
+	Fees        int64      `json:"fees"`        // Gateway fees in cents, including tax
+	Tax         int64      `json:"tax"`         // Tax portion of fees in cents
+	Net         int64      `json:"net"`         // Gross minus fees
+	PaymentIDs  []uint     `json:"payment_ids"` // Paid payment records included
+	PaidAt      *time.Time `json:"paid_at"`
+}
+
+// SettlementTotals sums a settlement report
+type SettlementTotals struct {
+	Orders int   `json:"orders"`
+	Gross  int64 `json:"gross"`
+	Fees   int64 `json:"fees"`
+	Tax    int64 `json:"tax"`
+	Net    int64 `json:"net"`
+}
+
+// SettlementReport represents a page of per-order settlements with totals for the whole range
+type SettlementReport struct {
+	Orders     []OrderSettlement `json:"orders"`
+	Totals     SettlementTotals  `json:"totals"`
+	Page       int               `json:"page"`
+	Limit      int               `json:"limit"`
+	TotalPages int               `json:"total_pages"`
+}
+
+// gatewayFees is the fee portion of a Razorpay payment entity
+type gatewayFees struct {
+	Fee *int64 `json:"fee"`
+	Tax *int64 `json:"tax"`
+}
+
+// ParseGatewayFees extracts the fee and tax from a stored Razorpay payment response.
+// Razorpay's fee already includes tax. ok is false when the response carries no fee,
+// e.g. an order entity stored before the payment was captured.
+func ParseGatewayFees(gatewayResponse string) (fee, tax int64, ok bool) {
+	if gatewayResponse == "" {
+		return 0, 0, false
+	}
+
+	var fees gatewayFees
+	if err := json.Unmarshal([]byte(gatewayResponse), &fees); err != nil || fees.Fee == nil {
+		return 0, 0, false
+	}
+
+	fee = *fees.Fee
+	if fees.Tax != nil {
+		tax = *fees.Tax
+	}
+	return fee, tax, true
+}
+
+// GetOrderSettlement returns the settlement of a single order's paid payments
+func (r *RazorpayService) GetOrderSettlement(orderID uint) (*OrderSettlement, error) {
+	var o order.Order
+	if err := r.db.Select("id, order_number, currency").First(&o, orderID).Error; err != nil {
+		return nil, fmt.Errorf("order not found: %w", err)
+	}
+
+	var payments []order.Payment
+	if err := r.db.Where("order_id = ? AND status = ?", orderID, order.PaymentStatusPaid).
+		Order("processed_at ASC").Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get payments: %w", err)
+	}
+
+	settlement := &OrderSettlement{OrderID: o.ID, OrderNumber: o.OrderNumber, Currency: o.Currency, PaymentIDs: []uint{}}
+	for i := range payments {
+		settlement.add(&payments[i])
+	}
+	return settlement, nil
+}
+
+// GetSettlementReport returns per-order gross, fees and net for payments processed in the date range
+func (r *RazorpayService) GetSettlementReport(req *SettlementReportRequest) (*SettlementReport, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 200 {
+		req.Limit = 50
+	}
+
+	query := r.db.Model(&order.Payment{}).Where("status = ?", order.PaymentStatusPaid)
+
+	loc := r.config.GetLocation()
+	if req.DateFrom != "" {
+		from, err := time.ParseInLocation("2006-01-02", req.DateFrom, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date_from, expected YYYY-MM-DD")
+		}
+		query = query.Where("processed_at >= ?", from)
+	}
+	if req.DateTo != "" {
+		to, err := time.ParseInLocation("2006-01-02", req.DateTo, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date_to, expected YYYY-MM-DD")
+		}
+		query = query.Where("processed_at < ?", to.AddDate(0, 0, 1))
+	}
+
+	var payments []order.Payment
+	if err := query.Order("processed_at DESC, id DESC").Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get payments: %w", err)
+	}
+
+	// Group payments by order, keeping the most recently paid orders first
+	var orderIDs []uint
+	byOrder := make(map[uint]*OrderSettlement)
+	for i := range payments {
+		p := &payments[i]
+		settlement, exists := byOrder[p.OrderID]
+		if !exists {
+			settlement = &OrderSettlement{OrderID: p.OrderID, Currency: p.Currency, PaymentIDs: []uint{}}
+			byOrder[p.OrderID] = settlement
+			orderIDs = append(orderIDs, p.OrderID)
+		}
+		settlement.add(p)
+	}
+
+	report := &SettlementReport{
+		Orders: []OrderSettlement{},
+		Page:   req.Page,
+		Limit:  req.Limit,
+	}
+	for _, id := range orderIDs {
+		s := byOrder[id]
+		report.Totals.Orders++
+		report.Totals.Gross += s.Gross
+		report.Totals.Fees += s.Fees
+		report.Totals.Tax += s.Tax
+		report.Totals.Net += s.Net
+	}
+	report.TotalPages = (len(orderIDs) + req.Limit - 1) / req.Limit
+
+	start := (req.Page - 1) * req.Limit
+	if start >= len(orderIDs) {
+		return report, nil
+	}
+	end := start + req.Limit
+	if end > len(orderIDs) {
+		end = len(orderIDs)
+	}
+	pageIDs := orderIDs[start:end]
+
+	var orders []order.Order
+	if err := r.db.Select("id, order_number, currency").Where("id IN ?", pageIDs).Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+	for _, o := range orders {
+		byOrder[o.ID].OrderNumber = o.OrderNumber
+		byOrder[o.ID].Currency = o.Currency
+	}
+
+	for _, id := range pageIDs {
+		report.Orders = append(report.Orders, *byOrder[id])
+	}
+
+	return report, nil
+}
+
+// add includes a paid payment in the settlement, parsing fees from the gateway
+// response for payments captured before fees were stored
+func (s *OrderSettlement) add(p *order.Payment) {
+	fee, tax := p.GatewayFee, p.GatewayTax
+	if fee == 0 {
+		fee, tax, _ = ParseGatewayFees(p.GatewayResponse)
+	}
+
+	s.Gross += p.Amount
+	s.Fees += fee
+	s.Tax += tax
+	s.Net = s.Gross - s.Fees
+	s.PaymentIDs = append(s.PaymentIDs, p.ID)
+	if p.ProcessedAt != nil && (s.PaidAt == nil || p.ProcessedAt.After(*s.PaidAt)) {
+		s.PaidAt = p.ProcessedAt
+	}
+}
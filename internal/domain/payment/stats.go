@@ -0,0 +1,174 @@
+// internal/domain/payment/stats.go
+package payment
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"gorm.io/gorm"
+)
+
+// PaymentStatsRequest represents payment statistics filters
+type PaymentStatsRequest struct {
+	DateFrom string `form:"date_from"` // YYYY-MM-DD, payments created on or after
+	DateTo   string `form:"date_to"`   // YYYY-MM-DD, inclusive
+}
+
+// PaymentGroupTotal represents the payment count and amount for one group
+type PaymentGroupTotal struct {
+	Key    string `json:"key"`
+	Count  int64  `json:"count"`
+	Amount int64  `json:"amount"` // In cents
+}
+
+// DailyPaymentVolume represents the payments captured on one store-local day
+type DailyPaymentVolume struct {
+	Date   string `json:"date"` // YYYY-MM-DD
+	Count  int64  `json:"count"`
+	Amount int64  `json:"amount"` // In cents
+}
+
+// PaymentStats represents payment totals for the admin dashboard
+type PaymentStats struct {
+	DateFrom       string               `json:"date_from,omitempty"`
+	DateTo         string               `json:"date_to,omitempty"`
+	TotalPayments  int64                `json:"total_payments"`
+	TotalCaptured  int64                `json:"total_captured"`  // Paid amount in cents
+	TotalRefunded  int64                `json:"total_refunded"`  // Refunded amount in cents
+	AveragePayment int64                `json:"average_payment"` // Average captured payment in cents
+	ByStatus       []PaymentGroupTotal  `json:"by_status"`
+	ByMethod       []PaymentGroupTotal  `json:"by_method"`
+	ByGateway      []PaymentGroupTotal  `json:"by_gateway"`
+	DailyCaptured  []DailyPaymentVolume `json:"daily_captured"` // Consecutive days, including days without payments
+}
+
+// GetPaymentStats returns payment totals grouped by status, method and gateway, with a
+// daily series of captured volume. Without a date range all payments are included.
+func (r *RazorpayService) GetPaymentStats(req *PaymentStatsRequest) (*PaymentStats, error) {
+	loc := r.config.GetLocation()
+
+	var from, to time.Time
+	var err error
+	if req.DateFrom != "" {
+		if from, err = time.ParseInLocation("2006-01-02", req.DateFrom, loc); err != nil {
+			return nil, fmt.Errorf("invalid date_from, expected YYYY-MM-DD")
+		}
+	}
+	if req.DateTo != "" {
+		if to, err = time.ParseInLocation("2006-01-02", req.DateTo, loc); err != nil {
+			return nil, fmt.Errorf("invalid date_to, expected YYYY-MM-DD")
+		}
+		if !from.IsZero() && to.Before(from) {
+			return nil, fmt.Errorf("date_to must not be before date_from")
+		}
+	}
+
+	// inRange scopes a payments query to the range on the given timestamp column
+	inRange := func(column string) *gorm.DB {
+		query := r.db.Model(&order.Payment{})
+		if !from.IsZero() {
+			query = query.Where(column+" >= ?", from)
+		}
+		if !to.IsZero() {
+			query = query.Where(column+" < ?", to.AddDate(0, 0, 1))
+		}
+		return query
+	}
+
+	stats := &PaymentStats{
+		DateFrom: req.DateFrom,
+		DateTo:   req.DateTo,
+	}
+
+	if stats.ByStatus, err = groupPaymentTotals(inRange("created_at"), "status"); err != nil {
+		return nil, err
+	}
+	if stats.ByMethod, err = groupPaymentTotals(inRange("created_at"), "payment_method"); err != nil {
+		return nil, err
+	}
+	if stats.ByGateway, err = groupPaymentTotals(inRange("created_at"), "gateway"); err != nil {
+		return nil, err
+	}
+
+	var capturedCount int64
+	for _, group := range stats.ByStatus {
+		stats.TotalPayments += group.Count
+		switch order.PaymentStatus(group.Key) {
+		case order.PaymentStatusPaid, order.PaymentStatusPartiallyRefunded:
+			stats.TotalCaptured += group.Amount
+			capturedCount += group.Count
+		case order.PaymentStatusRefunded:
+			stats.TotalRefunded = group.Amount
+		}
+	}
+	if capturedCount > 0 {
+		stats.AveragePayment = stats.TotalCaptured / capturedCount
+	}
+
+	// Captured volume is bucketed by the day the payment was processed
+	tz := strings.ReplaceAll(loc.String(), "'", "''")
+	day := fmt.Sprintf("DATE(COALESCE(processed_at, created_at) AT TIME ZONE '%s')", tz)
+
+	var rows []struct {
+		Day    time.Time
+		Count  int64
+		Amount int64
+	}
+	err = inRange("COALESCE(processed_at, created_at)").
+		Select(day+" AS day, COUNT(*) AS count, COALESCE(SUM(amount), 0) AS amount").
+		Where("status = ?", order.PaymentStatusPaid).
+		Group("day").
+		Order("day ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily payment volume: %w", err)
+	}
+
+	byDay := make(map[string]DailyPaymentVolume, len(rows))
+	for _, row := range rows {
+		date := row.Day.Format("2006-01-02")
+		byDay[date] = DailyPaymentVolume{Date: date, Count: row.Count, Amount: row.Amount}
+	}
+
+	// Fill the gaps so the series can be charted directly
+	start, end := from, to
+	if len(rows) > 0 {
+		if start.IsZero() {
+			start = time.Date(rows[0].Day.Year(), rows[0].Day.Month(), rows[0].Day.Day(), 0, 0, 0, 0, loc)
+		}
+		if end.IsZero() {
+			last := rows[len(rows)-1].Day
+			end = time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, loc)
+		}
+	}
+
+	stats.DailyCaptured = []DailyPaymentVolume{}
+	if !start.IsZero() && !end.IsZero() {
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			date := d.Format("2006-01-02")
+			volume, exists := byDay[date]
+			if !exists {
+				volume = DailyPaymentVolume{Date: date}
+			}
+			stats.DailyCaptured = append(stats.DailyCaptured, volume)
+		}
+	}
+
+	return stats, nil
+}
+
+// groupPaymentTotals counts and sums payments grouped by column, largest amount first
+func groupPaymentTotals(query *gorm.DB, column string) ([]PaymentGroupTotal, error) {
+	totals := []PaymentGroupTotal{}
+	err := query.
+		Select(fmt.Sprintf("COALESCE(%s, '') AS key, COUNT(*) AS count, COALESCE(SUM(amount), 0) AS amount", column)).
+		Group(column).
+		Order("amount DESC").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to group payments by %s: %w", column, err)
+	}
+	return totals, nil
+}
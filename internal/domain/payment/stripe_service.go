@@ -0,0 +1,465 @@
+// internal/domain/payment/stripe_service.go
+package payment
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/pkg/metrics"
+	"gorm.io/gorm"
+)
+
+// stripeWebhookTolerance is how far a webhook timestamp may be from now before the
+// event is rejected as a possible replay
+const stripeWebhookTolerance = 5 * time.Minute
+
+// StripeService handles Stripe payment processing using PaymentIntents
+type StripeService struct {
+	db             *gorm.DB
+	config         *config.Config
+	secretKey      string
+	publishableKey string
+	webhookSecret  string
+	baseURL        string
+	httpClient     *http.Client
+	notifier       *paymentNotifier
+}
+
+// NewStripeService creates a new Stripe service
+func NewStripeService(db *gorm.DB, cfg *config.Config) *StripeService {
+	return &StripeService{
+		db:             db,
+		config:         cfg,
+		secretKey:      cfg.External.Stripe.SecretKey,
+		publishableKey: cfg.External.Stripe.PublishableKey,
+		webhookSecret:  cfg.External.Stripe.WebhookSecret,
+		baseURL:        "https://api.stripe.com/v1",
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		notifier: newPaymentNotifier(db, cfg),
+	}
+}
+
+// StripePaymentIntent is the part of a Stripe PaymentIntent the store uses
+type StripePaymentIntent struct {
+	ID               string            `json:"id"`
+	Object           string            `json:"object"`
+	Amount           int64             `json:"amount"`
+	AmountReceived   int64             `json:"amount_received"`
+	Currency         string            `json:"currency"`
+	Status           string            `json:"status"`
+	ClientSecret     string            `json:"client_secret,omitempty"`
+	Metadata         map[string]string `json:"metadata"`
+	LatestCharge     string            `json:"latest_charge"`
+	LastPaymentError *StripeError      `json:"last_payment_error"`
+	Created          int64             `json:"created"`
+}
+
+// StripeError is a Stripe API or payment error
+type StripeError struct {
+	Type        string `json:"type"`
+	Code        string `json:"code"`
+	DeclineCode string `json:"decline_code"`
+	Message     string `json:"message"`
+}
+
+// StripeRefund is the part of a Stripe Refund the store uses
+type StripeRefund struct {
+	ID            string `json:"id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	PaymentIntent string `json:"payment_intent"`
+	Status        string `json:"status"`
+}
+
+// StripeEvent is a Stripe webhook event
+type StripeEvent struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// Stripe PaymentIntent statuses the store acts on
+const (
+	stripeIntentSucceeded  = "succeeded"
+	stripeIntentProcessing = "processing"
+)
+
+// Name returns the provider name stored on payment records
+func (s *StripeService) Name() string {
+	return ProviderStripe
+}
+
+// CreateOrder creates a Stripe PaymentIntent for the order; the client confirms it
+// with Stripe.js using the returned client secret
+func (s *StripeService) CreateOrder(orderID uint) (*PaymentInitiationResponse, error) {
+	var orderDetails order.Order
+	if err := s.db.Preload("Items").Where("id = ?", orderID).First(&orderDetails).Error; err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if !canAcceptPayment(orderDetails) {
+		return nil, fmt.Errorf("order cannot accept payment. Status: %s, Payment Status: %s",
+			orderDetails.Status, orderDetails.PaymentStatus)
+	}
+
+	if err := handleExistingPayments(s.db, orderID, s.config.Payment.StuckTimeout); err != nil {
+		return nil, fmt.Errorf("failed to handle existing payments: %w", err)
+	}
+
+	attemptsRemaining, err := checkPaymentAttempts(s.db, s.config, &orderDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	var attempts int64
+	s.db.Model(&order.Payment{}).Where("order_id = ?", orderID).Count(&attempts)
+
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(orderDetails.TotalAmount, 10))
+	form.Set("currency", strings.ToLower(orderDetails.Currency))
+	form.Set("description", "Order "+orderDetails.OrderNumber)
+	form.Set("automatic_payment_methods[enabled]", "true")
+	form.Set("metadata[order_id]", strconv.FormatUint(uint64(orderID), 10))
+	form.Set("metadata[order_number]", orderDetails.OrderNumber)
+	if orderDetails.UserID != nil {
+		form.Set("metadata[user_id]", strconv.FormatUint(uint64(*orderDetails.UserID), 10))
+	}
+	if orderDetails.Email != "" {
+		form.Set("receipt_email", orderDetails.Email)
+	}
+
+	// One key per attempt, so a retried request never creates a second intent
+	idempotencyKey := fmt.Sprintf("order-%d-attempt-%d", orderID, attempts+1)
+	response, err := s.makeAPICall("POST", "/payment_intents", form, idempotencyKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Stripe payment intent: %w", err)
+	}
+
+	var intent StripePaymentIntent
+	if err := json.Unmarshal(response, &intent); err != nil {
+		return nil, fmt.Errorf("failed to parse Stripe payment intent: %w", err)
+	}
+
+	err = s.db.Model(&orderDetails).Updates(map[string]interface{}{
+		"status":         order.OrderStatusPaymentProcessing,
+		"payment_status": order.PaymentStatusProcessing,
+		"updated_at":     time.Now().UTC(),
+	}).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	// The client secret is only returned to the customer, never stored
+	clientSecret := intent.ClientSecret
+	intent.ClientSecret = ""
+
+	payment := order.Payment{
+		OrderID:           orderID,
+		PaymentMethod:     ProviderStripe,
+		PaymentProviderID: intent.ID,
+		Amount:            orderDetails.TotalAmount,
+		Currency:          orderDetails.Currency,
+		Status:            order.PaymentStatusProcessing,
+		Gateway:           ProviderStripe,
+		GatewayResponse:   structToJSON(intent),
+		CreatedAt:         time.Now().UTC(),
+	}
+	if err := s.db.Create(&payment).Error; err != nil {
+		return nil, fmt.Errorf("failed to create payment record: %w", err)
+	}
+
+	return &PaymentInitiationResponse{
+		Provider:        ProviderStripe,
+		PaymentIntentID: intent.ID,
+		ClientSecret:    clientSecret,
+		Amount:          orderDetails.TotalAmount,
+		Currency:        orderDetails.Currency,
+		Receipt:         orderDetails.OrderNumber,
+		KeyID:           s.publishableKey,
+		Notes: map[string]interface{}{
+			"order_id":     orderID,
+			"order_number": orderDetails.OrderNumber,
+			"retry":        attempts > 0,
+		},
+		OrderDetails: &orderDetails,
+
+		AttemptsRemaining: attemptsRemaining,
+	}, nil
+}
+
+// VerifyPayment checks the PaymentIntent with Stripe after the client confirmed it,
+// confirming the order once the intent has succeeded
+func (s *StripeService) VerifyPayment(req *PaymentVerificationRequest) error {
+	if req.PaymentIntentID == "" {
+		return fmt.Errorf("payment_intent_id is required for Stripe payments")
+	}
+
+	var paymentRecord order.Payment
+	err := s.db.Where("order_id = ? AND payment_provider_id = ? AND gateway = ?", req.OrderID, req.PaymentIntentID, ProviderStripe).
+		First(&paymentRecord).Error
+	if err != nil {
+		return fmt.Errorf("payment not found for this order")
+	}
+
+	// The webhook may already have confirmed it
+	if paymentRecord.Status == order.PaymentStatusPaid {
+		return nil
+	}
+
+	intent, err := s.getPaymentIntent(req.PaymentIntentID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment details: %w", err)
+	}
+
+	switch intent.Status {
+	case stripeIntentSucceeded:
+		return s.confirmIntent(req.OrderID, intent)
+	case stripeIntentProcessing:
+		return fmt.Errorf("payment is still processing")
+	default:
+		return fmt.Errorf("payment not completed, status: %s", intent.Status)
+	}
+}
+
+// Refund refunds a Stripe PaymentIntent; amount 0 refunds it in full
+func (s *StripeService) Refund(providerPaymentID string, amount int64, reference, reason string) error {
+	var paymentRecord order.Payment
+	err := s.db.Where("payment_provider_id = ? AND gateway = ?", providerPaymentID, ProviderStripe).First(&paymentRecord).Error
+	if err != nil {
+		return fmt.Errorf("payment not found: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("payment_intent", providerPaymentID)
+	if amount > 0 {
+		form.Set("amount", strconv.FormatInt(amount, 10))
+	}
+	form.Set("metadata[reference]", reference)
+	if reason != "" {
+		form.Set("metadata[reason]", reason)
+	}
+
+	response, err := s.makeAPICall("POST", "/refunds", form, "refund-"+reference)
+	if err != nil {
+		return fmt.Errorf("failed to create refund: %w", err)
+	}
+
+	var refund StripeRefund
+	if err := json.Unmarshal(response, &refund); err != nil {
+		return fmt.Errorf("failed to parse refund response: %w", err)
+	}
+
+	return recordRefund(s.db, &paymentRecord, refund.ID, refund.Amount)
+}
+
+// VerifyWebhook checks a webhook body against the Stripe-Signature header
+// ("t=<timestamp>,v1=<signature>"). Without a webhook secret, verification is
+// skipped in development only.
+func (s *StripeService) VerifyWebhook(body []byte, signature string) bool {
+	if s.webhookSecret == "" {
+		return s.config.IsDevelopment()
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(signature, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return false
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > stripeWebhookTolerance || age < -stripeWebhookTolerance {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.webhookSecret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	expectedSignature := hex.EncodeToString(mac.Sum(nil))
+
+	for _, sig := range signatures {
+		if hmac.Equal([]byte(sig), []byte(expectedSignature)) {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleWebhookEvent applies a verified Stripe webhook event. Events for payments
+// this store did not create, and event types it does not use, are ignored.
+func (s *StripeService) HandleWebhookEvent(body []byte) error {
+	var event StripeEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	switch event.Type {
+	case "payment_intent.succeeded", "payment_intent.payment_failed":
+	default:
+		log.Printf("Ignoring Stripe webhook event %s (%s)", event.ID, event.Type)
+		return nil
+	}
+
+	var intent StripePaymentIntent
+	if err := json.Unmarshal(event.Data.Object, &intent); err != nil {
+		return fmt.Errorf("invalid payment intent in webhook: %w", err)
+	}
+
+	var paymentRecord order.Payment
+	err := s.db.Where("payment_provider_id = ? AND gateway = ?", intent.ID, ProviderStripe).First(&paymentRecord).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil // Not one of ours, e.g. created from the Stripe dashboard
+		}
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	// Already settled, e.g. verified by the client or a redelivered event
+	if paymentRecord.Status == order.PaymentStatusPaid ||
+		paymentRecord.Status == order.PaymentStatusPartiallyRefunded ||
+		paymentRecord.Status == order.PaymentStatusRefunded {
+		return nil
+	}
+
+	if event.Type == "payment_intent.succeeded" {
+		return s.confirmIntent(paymentRecord.OrderID, &intent)
+	}
+
+	reason, code := "Payment failed", ""
+	if intent.LastPaymentError != nil {
+		if intent.LastPaymentError.Message != "" {
+			reason = intent.LastPaymentError.Message
+		}
+		code = intent.LastPaymentError.Code
+		if intent.LastPaymentError.DeclineCode != "" {
+			code = intent.LastPaymentError.DeclineCode
+		}
+	}
+
+	if err := recordPaymentFailure(s.db, paymentRecord.OrderID, intent.ID, reason, code); err != nil {
+		return err
+	}
+	metrics.RecordPayment(ProviderStripe, false)
+
+	go s.notifier.sendPaymentFailureEmail(paymentRecord.OrderID, "Stripe", reason)
+	return nil
+}
+
+// confirmIntent marks the order paid for a succeeded PaymentIntent after checking the amount
+func (s *StripeService) confirmIntent(orderID uint, intent *StripePaymentIntent) error {
+	var orderDetails order.Order
+	if err := s.db.Select("id, total_amount").Where("id = ?", orderID).First(&orderDetails).Error; err != nil {
+		return fmt.Errorf("order not found: %w", err)
+	}
+
+	received := intent.AmountReceived
+	if received == 0 {
+		received = intent.Amount
+	}
+	if received != orderDetails.TotalAmount {
+		return fmt.Errorf("payment amount mismatch. Expected: %d, Got: %d", orderDetails.TotalAmount, received)
+	}
+
+	intent.ClientSecret = ""
+	err := confirmPayment(s.db, s.config, orderID, intent.ID, map[string]interface{}{
+		"gateway_response": structToJSON(intent),
+	}, fmt.Sprintf("Payment confirmed via Stripe. PaymentIntent: %s", intent.ID))
+	if err != nil {
+		return err
+	}
+	metrics.RecordPayment(ProviderStripe, true)
+
+	go s.notifier.sendPaymentSuccessEmail(orderID)
+	return nil
+}
+
+// getPaymentIntent retrieves a PaymentIntent from Stripe
+func (s *StripeService) getPaymentIntent(intentID string) (*StripePaymentIntent, error) {
+	response, err := s.makeAPICall("GET", "/payment_intents/"+url.PathEscape(intentID), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var intent StripePaymentIntent
+	if err := json.Unmarshal(response, &intent); err != nil {
+		return nil, fmt.Errorf("failed to parse payment intent: %w", err)
+	}
+	return &intent, nil
+}
+
+// makeAPICall makes a form-encoded call to the Stripe API
+func (s *StripeService) makeAPICall(method, endpoint string, form url.Values, idempotencyKey string) ([]byte, error) {
+	if s.secretKey == "" {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, ProviderStripe)
+	}
+
+	var reqBody []byte
+	if form != nil {
+		reqBody = []byte(form.Encode())
+	}
+
+	req, err := http.NewRequest(method, s.baseURL+endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+s.secretKey)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make API call: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var respBody bytes.Buffer
+	if _, err := respBody.ReadFrom(resp.Body); err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var apiError struct {
+			Error StripeError `json:"error"`
+		}
+		if json.Unmarshal(respBody.Bytes(), &apiError) == nil && apiError.Error.Message != "" {
+			return nil, fmt.Errorf("API call failed with status %d: %s", resp.StatusCode, apiError.Error.Message)
+		}
+		return nil, fmt.Errorf("API call failed with status %d: %s", resp.StatusCode, respBody.String())
+	}
+
+	return respBody.Bytes(), nil
+}
@@ -0,0 +1,103 @@
+// internal/domain/payment/stripe_service_test.go
+package payment
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/inventory"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func newStripeTestDB(t *testing.T) *gorm.DB {
+	return testutil.NewDB(t, &order.Order{}, &order.OrderItem{}, &order.Payment{}, &order.OrderStatusHistory{},
+		&inventory.InventoryItem{}, &inventory.StockReservation{})
+}
+
+func TestStripeWebhookPaymentSucceeded(t *testing.T) {
+	db := newStripeTestDB(t)
+	cfg := &config.Config{}
+	s := NewStripeService(db, cfg)
+
+	orderRecord := order.Order{OrderNumber: "ORD-1", Email: "buyer@example.com", Status: order.OrderStatusPaymentProcessing,
+		PaymentStatus: order.PaymentStatusProcessing, SubtotalAmount: 5000, TotalAmount: 5000, PaymentMethod: ProviderStripe}
+	if err := db.Create(&orderRecord).Error; err != nil {
+		t.Fatal(err)
+	}
+	paymentRecord := order.Payment{OrderID: orderRecord.ID, PaymentMethod: ProviderStripe, PaymentProviderID: "pi_123",
+		Amount: 5000, Status: order.PaymentStatusProcessing, Gateway: ProviderStripe}
+	if err := db.Create(&paymentRecord).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"id":   "evt_1",
+		"type": "payment_intent.succeeded",
+		"data": map[string]interface{}{
+			"object": map[string]interface{}{"id": "pi_123", "amount": 5000, "amount_received": 5000, "status": "succeeded"},
+		},
+	})
+	if err := s.HandleWebhookEvent(body); err != nil {
+		t.Fatalf("HandleWebhookEvent() error = %v", err)
+	}
+
+	var updated order.Order
+	db.First(&updated, orderRecord.ID)
+	if updated.Status != order.OrderStatusConfirmed || updated.PaymentStatus != order.PaymentStatusPaid {
+		t.Errorf("order status = %s/%s, want %s/%s", updated.Status, updated.PaymentStatus,
+			order.OrderStatusConfirmed, order.PaymentStatusPaid)
+	}
+	var updatedPayment order.Payment
+	db.First(&updatedPayment, paymentRecord.ID)
+	if updatedPayment.Status != order.PaymentStatusPaid {
+		t.Errorf("payment status = %s, want %s", updatedPayment.Status, order.PaymentStatusPaid)
+	}
+	var history int64
+	db.Model(&order.OrderStatusHistory{}).Where("order_id = ?", orderRecord.ID).Count(&history)
+	if history != 1 {
+		t.Errorf("status history entries = %d, want 1", history)
+	}
+}
+
+func TestStripeRefundIdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		w.Write([]byte(`{"id":"re_` + r.Header.Get("Idempotency-Key") + `","amount":1000,"status":"succeeded"}`))
+	}))
+	defer server.Close()
+
+	db := newStripeTestDB(t)
+	cfg := &config.Config{}
+	cfg.External.Stripe.SecretKey = "sk_test"
+	s := NewStripeService(db, cfg)
+	s.baseURL = server.URL
+
+	paymentRecord := order.Payment{OrderID: 1, PaymentMethod: ProviderStripe, PaymentProviderID: "pi_123",
+		Amount: 5000, Status: order.PaymentStatusPaid, Gateway: ProviderStripe}
+	if err := db.Create(&paymentRecord).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Two partial refunds of the same amount are separate refunds
+	for _, reference := range []string{"return-1", "return-2"} {
+		if err := s.Refund("pi_123", 1000, reference, "Return"); err != nil {
+			t.Fatalf("Refund(%s) error = %v", reference, err)
+		}
+	}
+	if len(keys) != 2 || keys[0] == keys[1] {
+		t.Errorf("idempotency keys = %v, want two distinct keys", keys)
+	}
+
+	var updated order.Payment
+	db.First(&updated, paymentRecord.ID)
+	if updated.RefundedAmount != 2000 || updated.Status != order.PaymentStatusPartiallyRefunded {
+		t.Errorf("payment = %s refunded %d, want %s refunded 2000", updated.Status, updated.RefundedAmount,
+			order.PaymentStatusPartiallyRefunded)
+	}
+}
@@ -0,0 +1,155 @@
+// internal/domain/payment/stuck_payments.go
+package payment
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"gorm.io/gorm"
+)
+
+// StuckPaymentSweeper fails payments left processing past the stuck timeout, for
+// gateways whose webhook never arrived, so customers can pay again
+type StuckPaymentSweeper struct {
+	db     *gorm.DB
+	config *config.Config
+}
+
+// NewStuckPaymentSweeper creates a new stuck payment sweeper
+func NewStuckPaymentSweeper(db *gorm.DB, cfg *config.Config) *StuckPaymentSweeper {
+	return &StuckPaymentSweeper{
+		db:     db,
+		config: cfg,
+	}
+}
+
+// StartSweeper sweeps stuck payments every StuckSweepInterval until ctx is cancelled
+func (s *StuckPaymentSweeper) StartSweeper(ctx context.Context) {
+	interval := s.config.Payment.StuckSweepInterval
+	if interval <= 0 || s.config.Payment.StuckTimeout <= 0 {
+		log.Println("Stuck payment sweeper disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if swept, err := s.SweepStuckPayments(time.Now()); err != nil {
+			log.Printf("Failed to sweep stuck payments: %v", err)
+		} else if swept > 0 {
+			log.Printf("Expired %d stuck payments", swept)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SweepStuckPayments fails every payment still processing after the stuck timeout and
+// returns its order to pending with a failed payment status, so the customer can
+// retry. It returns the number of payments expired.
+func (s *StuckPaymentSweeper) SweepStuckPayments(now time.Time) (int, error) {
+	cutoff := now.UTC().Add(-s.config.Payment.StuckTimeout)
+
+	var stuck []order.Payment
+	err := s.db.Where("status = ? AND created_at < ?", order.PaymentStatusProcessing, cutoff).
+		Order("created_at ASC").
+		Find(&stuck).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to find stuck payments: %w", err)
+	}
+
+	swept := 0
+	for i := range stuck {
+		expired, err := s.expirePayment(&stuck[i], now.UTC())
+		if err != nil {
+			log.Printf("Failed to expire stuck payment %d for order %d: %v", stuck[i].ID, stuck[i].OrderID, err)
+			continue
+		}
+		if expired {
+			swept++
+		}
+	}
+
+	return swept, nil
+}
+
+// expirePayment fails one stuck payment and, when it was the order's last payment in
+// flight, resets the order. It reports false if the payment settled meanwhile.
+func (s *StuckPaymentSweeper) expirePayment(payment *order.Payment, now time.Time) (bool, error) {
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Only a payment still processing is expired, in case a webhook settled it since
+	result := tx.Model(&order.Payment{}).
+		Where("id = ? AND status = ?", payment.ID, order.PaymentStatusProcessing).
+		Updates(map[string]interface{}{
+			"status":         order.PaymentStatusFailed,
+			"failure_reason": paymentTimeoutReason,
+			"failure_code":   paymentTimeoutCode,
+			"updated_at":     now,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to expire payment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	var inFlight int64
+	err := tx.Model(&order.Payment{}).
+		Where("order_id = ? AND status = ?", payment.OrderID, order.PaymentStatusProcessing).
+		Count(&inFlight).Error
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to check payments: %w", err)
+	}
+
+	if inFlight == 0 {
+		result = tx.Model(&order.Order{}).
+			Where("id = ? AND status = ? AND payment_status = ?", payment.OrderID,
+				order.OrderStatusPaymentProcessing, order.PaymentStatusProcessing).
+			Updates(map[string]interface{}{
+				"status":         order.OrderStatusPending,
+				"payment_status": order.PaymentStatusFailed,
+				"updated_at":     now,
+			})
+		if result.Error != nil {
+			tx.Rollback()
+			return false, fmt.Errorf("failed to reset order: %w", result.Error)
+		}
+
+		if result.RowsAffected > 0 {
+			statusHistory := order.OrderStatusHistory{
+				OrderID:   payment.OrderID,
+				Status:    order.OrderStatusPending,
+				Comment:   "Payment timed out - awaiting payment retry",
+				CreatedBy: 0, // System generated
+				CreatedAt: now,
+			}
+			if err := tx.Create(&statusHistory).Error; err != nil {
+				tx.Rollback()
+				return false, fmt.Errorf("failed to create status history: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
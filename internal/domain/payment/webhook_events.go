@@ -0,0 +1,69 @@
+// internal/domain/payment/webhook_events.go
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+)
+
+// ErrWebhookEventStoreUnavailable is returned when there is no Redis to deduplicate
+// webhook events with. Events are refused rather than risk applying one twice.
+var ErrWebhookEventStoreUnavailable = errors.New("webhook event store is not available")
+
+// WebhookEventStore remembers processed webhook event IDs so gateway retries of an
+// event already handled are acknowledged without being applied again
+type WebhookEventStore struct {
+	redisClient *redis.Client
+	config      *config.Config
+}
+
+// NewWebhookEventStore creates a new webhook event store
+func NewWebhookEventStore(redisClient *redis.Client, cfg *config.Config) *WebhookEventStore {
+	return &WebhookEventStore{
+		redisClient: redisClient,
+		config:      cfg,
+	}
+}
+
+// Claim records an event as processed, reporting false when it was already claimed.
+// The check and the insert are one SET NX, so concurrent deliveries of the same
+// event cannot both claim it.
+func (s *WebhookEventStore) Claim(provider, eventID string) (bool, error) {
+	if s.redisClient == nil {
+		return false, ErrWebhookEventStoreUnavailable
+	}
+	claimed, err := s.redisClient.SetNX(context.Background(), s.key(provider, eventID), time.Now().UTC().Unix(), s.ttl()).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to record webhook event: %w", err)
+	}
+	return claimed, nil
+}
+
+// Release forgets an event so its next delivery is processed again. Without Redis
+// nothing was claimed, so there is nothing to forget.
+func (s *WebhookEventStore) Release(provider, eventID string) error {
+	if s.redisClient == nil {
+		return nil
+	}
+	if err := s.redisClient.Del(context.Background(), s.key(provider, eventID)).Err(); err != nil {
+		return fmt.Errorf("failed to release webhook event: %w", err)
+	}
+	return nil
+}
+
+func (s *WebhookEventStore) key(provider, eventID string) string {
+	return fmt.Sprintf("processed_webhooks:%s:%s", provider, eventID)
+}
+
+// ttl returns how long event IDs are kept; gateways stop retrying well within it
+func (s *WebhookEventStore) ttl() time.Duration {
+	if s.config.Payment.WebhookEventTTL > 0 {
+		return s.config.Payment.WebhookEventTTL
+	}
+	return 72 * time.Hour
+}
@@ -0,0 +1,162 @@
+// internal/domain/payment/webhook_log.go
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Webhook delivery outcomes recorded in the webhook log
+const (
+	WebhookStatusReceived  = "received"  // Recorded before processing finished, e.g. it panicked
+	WebhookStatusProcessed = "processed" // Applied
+	WebhookStatusIgnored   = "ignored"   // Valid, but an event type the store does not act on
+	WebhookStatusDuplicate = "duplicate" // Redelivery of an event already applied
+	WebhookStatusRejected  = "rejected"  // Missing or invalid signature, or unreadable payload
+	WebhookStatusFailed    = "failed"    // Processing failed; the gateway will redeliver
+)
+
+// ErrWebhookEventNotFound is returned when a logged webhook delivery does not exist
+var ErrWebhookEventNotFound = errors.New("webhook event not found")
+
+// PaymentWebhookEvent is one webhook delivery from a payment gateway, kept with its
+// raw body so failed payments can be traced back to what the gateway sent
+type PaymentWebhookEvent struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	Provider       string    `gorm:"size:20;not null;index" json:"provider"`
+	EventID        string    `gorm:"size:100;index" json:"event_id,omitempty"`
+	EventType      string    `gorm:"size:100;index" json:"event_type,omitempty"`
+	Payload        string    `gorm:"type:text" json:"payload,omitempty"`
+	SignatureValid bool      `gorm:"default:false" json:"signature_valid"`
+	Status         string    `gorm:"size:20;not null;index" json:"status"`
+	Error          string    `gorm:"type:text" json:"error,omitempty"`
+	ReceivedAt     time.Time `gorm:"not null;index" json:"received_at"`
+}
+
+// TableName overrides the table name
+func (PaymentWebhookEvent) TableName() string { return "payment_webhook_events" }
+
+// NewWebhookEvent starts the log entry for a delivery; the handler fills in the
+// rest as it goes and records it when done
+func NewWebhookEvent(provider string, body []byte) *PaymentWebhookEvent {
+	return &PaymentWebhookEvent{
+		Provider:   provider,
+		Payload:    string(body),
+		Status:     WebhookStatusReceived,
+		ReceivedAt: time.Now().UTC(),
+	}
+}
+
+// WebhookEventListRequest represents webhook log filters
+type WebhookEventListRequest struct {
+	Provider       string `form:"provider"`
+	EventType      string `form:"event_type"`
+	Status         string `form:"status"`
+	SignatureValid *bool  `form:"signature_valid"`
+	DateFrom       string `form:"date_from"` // YYYY-MM-DD
+	DateTo         string `form:"date_to"`   // YYYY-MM-DD, inclusive
+	Page           int    `form:"page,default=1"`
+	Limit          int    `form:"limit,default=20"`
+}
+
+// WebhookEventListResponse represents a page of logged webhook deliveries
+type WebhookEventListResponse struct {
+	Events     []PaymentWebhookEvent `json:"events"`
+	Total      int64                 `json:"total"`
+	Page       int                   `json:"page"`
+	Limit      int                   `json:"limit"`
+	TotalPages int                   `json:"total_pages"`
+}
+
+// WebhookLog stores and lists webhook deliveries
+type WebhookLog struct {
+	db *gorm.DB
+}
+
+// NewWebhookLog creates a new webhook log
+func NewWebhookLog(db *gorm.DB) *WebhookLog {
+	return &WebhookLog{db: db}
+}
+
+// Record saves a delivery. Failing to log never fails the delivery itself.
+func (l *WebhookLog) Record(event *PaymentWebhookEvent) {
+	if err := l.db.Create(event).Error; err != nil {
+		log.Printf("Failed to log %s webhook %s: %v", event.Provider, event.EventID, err)
+	}
+}
+
+// List returns logged deliveries, newest first, without their payloads
+func (l *WebhookLog) List(req *WebhookEventListRequest) (*WebhookEventListResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+
+	query := l.db.Model(&PaymentWebhookEvent{})
+	if req.Provider != "" {
+		query = query.Where("provider = ?", req.Provider)
+	}
+	if req.EventType != "" {
+		query = query.Where("event_type = ?", req.EventType)
+	}
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+	if req.SignatureValid != nil {
+		query = query.Where("signature_valid = ?", *req.SignatureValid)
+	}
+	if req.DateFrom != "" {
+		from, err := time.Parse("2006-01-02", req.DateFrom)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date_from, expected YYYY-MM-DD")
+		}
+		query = query.Where("received_at >= ?", from)
+	}
+	if req.DateTo != "" {
+		to, err := time.Parse("2006-01-02", req.DateTo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date_to, expected YYYY-MM-DD")
+		}
+		query = query.Where("received_at < ?", to.AddDate(0, 0, 1))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count webhook events: %w", err)
+	}
+
+	events := []PaymentWebhookEvent{}
+	if err := query.Omit("payload").
+		Order("received_at DESC, id DESC").
+		Offset((req.Page - 1) * req.Limit).
+		Limit(req.Limit).
+		Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook events: %w", err)
+	}
+
+	return &WebhookEventListResponse{
+		Events:     events,
+		Total:      total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+	}, nil
+}
+
+// Get returns a logged delivery with its raw payload
+func (l *WebhookLog) Get(id uint) (*PaymentWebhookEvent, error) {
+	var event PaymentWebhookEvent
+	if err := l.db.First(&event, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookEventNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook event: %w", err)
+	}
+	return &event, nil
+}
@@ -0,0 +1,55 @@
+// internal/domain/policy/entity.go
+package policy
+
+import (
+	"time"
+)
+
+// PolicyType represents the kind of store policy
+type PolicyType string
+
+const (
+	PolicyTypeShipping PolicyType = "shipping"
+	PolicyTypeReturns  PolicyType = "returns"
+	PolicyTypePrivacy  PolicyType = "privacy"
+	PolicyTypeTerms    PolicyType = "terms"
+	PolicyTypeFAQ      PolicyType = "faq"
+)
+
+// ValidPolicyTypes lists the policy types that can be managed
+var ValidPolicyTypes = []PolicyType{
+	PolicyTypeShipping,
+	PolicyTypeReturns,
+	PolicyTypePrivacy,
+	PolicyTypeTerms,
+	PolicyTypeFAQ,
+}
+
+// StorePolicy represents editable store policy content shown on checkout and product pages
+type StorePolicy struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	Type      PolicyType `gorm:"not null;size:50;uniqueIndex" json:"type"`
+	Title     string     `gorm:"not null;size:255" json:"title"`
+	Summary   string     `gorm:"size:500" json:"summary"` // Short text for checkout/product pages
+	Content   string     `gorm:"type:text" json:"content"`
+	IsActive  bool       `gorm:"default:true" json:"is_active"`
+	Version   int        `gorm:"default:1" json:"version"`
+	UpdatedBy uint       `json:"updated_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name for StorePolicy
+func (StorePolicy) TableName() string {
+	return "store_policies"
+}
+
+// IsValidPolicyType checks if the policy type is supported
+func IsValidPolicyType(policyType PolicyType) bool {
+	for _, t := range ValidPolicyTypes {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+// internal/domain/policy/service.go
+package policy
+
+import (
+	"fmt"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"gorm.io/gorm"
+)
+
+// Service handles store policy business logic
+type Service struct {
+	db     *gorm.DB
+	config *config.Config
+}
+
+// NewService creates a new policy service
+func NewService(db *gorm.DB, cfg *config.Config) *Service {
+	return &Service{
+		db:     db,
+		config: cfg,
+	}
+}
+
+// UpdatePolicyRequest represents policy content update data
+type UpdatePolicyRequest struct {
+	Title    string `json:"title" binding:"required"`
+	Summary  string `json:"summary"`
+	Content  string `json:"content" binding:"required"`
+	IsActive *bool  `json:"is_active"`
+}
+
+// GetActivePolicies retrieves active policies, optionally filtered by type
+func (s *Service) GetActivePolicies(types []PolicyType) ([]StorePolicy, error) {
+	query := s.db.Where("is_active = ?", true)
+	if len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+
+	var policies []StorePolicy
+	if err := query.Order("type ASC").Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("failed to get policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// GetActivePolicy retrieves a single active policy by type
+func (s *Service) GetActivePolicy(policyType PolicyType) (*StorePolicy, error) {
+	if !IsValidPolicyType(policyType) {
+		return nil, fmt.Errorf("invalid policy type: %s", policyType)
+	}
+
+	var policy StorePolicy
+	if err := s.db.Where("type = ? AND is_active = ?", policyType, true).First(&policy).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("policy not found")
+		}
+		return nil, fmt.Errorf("failed to get policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// AdminGetPolicies retrieves all policies including inactive ones
+func (s *Service) AdminGetPolicies() ([]StorePolicy, error) {
+	var policies []StorePolicy
+	if err := s.db.Order("type ASC").Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("failed to get policies: %w", err)
+	}
+	return policies, nil
+}
+
+// UpsertPolicy creates or updates the policy content for a type, bumping its version
+func (s *Service) UpsertPolicy(policyType PolicyType, req *UpdatePolicyRequest, adminID uint) (*StorePolicy, error) {
+	if !IsValidPolicyType(policyType) {
+		return nil, fmt.Errorf("invalid policy type: %s", policyType)
+	}
+
+	var policy StorePolicy
+	err := s.db.Where("type = ?", policyType).First(&policy).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to get policy: %w", err)
+	}
+
+	if err == gorm.ErrRecordNotFound {
+		policy = StorePolicy{
+			Type:     policyType,
+			IsActive: true,
+			Version:  0,
+		}
+	}
+
+	policy.Title = req.Title
+	policy.Summary = req.Summary
+	policy.Content = req.Content
+	policy.Version++
+	policy.UpdatedBy = adminID
+	if req.IsActive != nil {
+		policy.IsActive = *req.IsActive
+	}
+
+	if policy.ID == 0 {
+		// GORM skips zero values on create and reads the column default back into the
+		// struct, so an inactive draft needs an explicit update
+		isActive := policy.IsActive
+		if err := s.db.Create(&policy).Error; err != nil {
+			return nil, fmt.Errorf("failed to create policy: %w", err)
+		}
+		if !isActive {
+			s.db.Model(&policy).Update("is_active", false)
+		}
+	} else if err := s.db.Save(&policy).Error; err != nil {
+		return nil, fmt.Errorf("failed to update policy: %w", err)
+	}
+
+	return &policy, nil
+}
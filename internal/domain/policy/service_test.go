@@ -0,0 +1,33 @@
+// internal/domain/policy/service_test.go
+package policy
+
+import (
+	"testing"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+func TestUpsertPolicyKeepsNewDraftInactive(t *testing.T) {
+	s := NewService(testutil.NewDB(t, &StorePolicy{}), &config.Config{})
+	inactive := false
+
+	draft, err := s.UpsertPolicy(PolicyTypeReturns, &UpdatePolicyRequest{Title: "Returns", Content: "30 days", IsActive: &inactive}, 1)
+	if err != nil {
+		t.Fatalf("UpsertPolicy() error = %v", err)
+	}
+	if draft.IsActive || draft.Version != 1 {
+		t.Errorf("draft = active %v, version %d, want inactive version 1", draft.IsActive, draft.Version)
+	}
+	if _, err := s.GetActivePolicy(PolicyTypeReturns); err == nil {
+		t.Fatal("new draft policy is published")
+	}
+
+	published, err := s.UpsertPolicy(PolicyTypeReturns, &UpdatePolicyRequest{Title: "Returns", Content: "30 days"}, 1)
+	if err != nil {
+		t.Fatalf("UpsertPolicy() error = %v", err)
+	}
+	if published.IsActive || published.Version != 2 {
+		t.Errorf("edited draft = active %v, version %d, want still inactive at version 2", published.IsActive, published.Version)
+	}
+}
@@ -0,0 +1,110 @@
+// internal/domain/product/badges.go
+package product
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+)
+
+// Product badges shown on storefront listings
+const (
+	BadgeNew        = "new"
+	BadgeSale       = "sale"
+	BadgeBestseller = "bestseller"
+)
+
+// bestsellerCache holds the most recent bestseller ranking shared across requests
+var bestsellerCache struct {
+	sync.Mutex
+	ids       map[uint]bool
+	expiresAt time.Time
+}
+
+// applyBadges computes badges for the given products using the configured rules
+func (s *Service) applyBadges(products []Product) {
+	if len(products) == 0 {
+		return
+	}
+
+	bestsellers := s.getBestsellerIDs()
+	now := time.Now()
+	for i := range products {
+		products[i].Badges = computeBadges(&products[i], s.config.Badge, now, bestsellers)
+	}
+}
+
+// applyBadge computes badges for a single product
+func (s *Service) applyBadge(p *Product) {
+	p.Badges = computeBadges(p, s.config.Badge, time.Now(), s.getBestsellerIDs())
+}
+
+// computeBadges evaluates the badge rules for a single product
+func computeBadges(p *Product, rules config.BadgeConfig, now time.Time, bestsellers map[uint]bool) []string {
+	badges := []string{}
+
+	if rules.NewDays > 0 && !p.CreatedAt.IsZero() && p.CreatedAt.After(now.AddDate(0, 0, -rules.NewDays)) {
+		badges = append(badges, BadgeNew)
+	}
+
+	if p.ComparePrice > p.Price && p.Price > 0 {
+		discountPercent := (p.ComparePrice - p.Price) * 100 / p.ComparePrice
+		if discountPercent >= int64(rules.SaleMinPercent) {
+			badges = append(badges, BadgeSale)
+		}
+	}
+
+	if bestsellers[p.ID] {
+		badges = append(badges, BadgeBestseller)
+	}
+
+	return badges
+}
+
+// getBestsellerIDs returns the top selling products within the configured window,
+// cached so listings don't re-rank sales on every request
+func (s *Service) getBestsellerIDs() map[uint]bool {
+	rules := s.config.Badge
+	if rules.BestsellerTopN <= 0 {
+		return nil
+	}
+
+	bestsellerCache.Lock()
+	defer bestsellerCache.Unlock()
+
+	if bestsellerCache.ids != nil && time.Now().Before(bestsellerCache.expiresAt) {
+		return bestsellerCache.ids
+	}
+
+	window := rules.BestsellerWindow
+	if window <= 0 {
+		window = 30 * 24 * time.Hour
+	}
+
+	var productIDs []uint
+	err := s.db.Raw(`
+		SELECT oi.product_id
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		WHERE o.created_at >= ? AND o.status NOT IN ('cancelled', 'refunded') AND o.deleted_at IS NULL
+		GROUP BY oi.product_id
+		HAVING SUM(oi.quantity) > 0
+		ORDER BY SUM(oi.quantity) DESC, oi.product_id ASC
+		LIMIT ?
+	`, time.Now().Add(-window), rules.BestsellerTopN).Scan(&productIDs).Error
+	if err != nil {
+		log.Printf("Failed to rank bestsellers: %v", err)
+		return bestsellerCache.ids
+	}
+
+	ids := make(map[uint]bool, len(productIDs))
+	for _, id := range productIDs {
+		ids[id] = true
+	}
+
+	bestsellerCache.ids = ids
+	bestsellerCache.expiresAt = time.Now().Add(rules.BestsellerTTL)
+	return ids
+}
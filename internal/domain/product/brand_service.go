@@ -0,0 +1,454 @@
+// internal/domain/product/brand_service.go
+package product
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"gorm.io/gorm"
+)
+
+// Brand errors
+var (
+	ErrBrandNotFound  = errors.New("brand not found")
+	ErrBrandInUse     = errors.New("brand has products assigned")
+	ErrBrandSlugTaken = errors.New("brand slug already exists")
+)
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// BrandService handles brand business logic
+type BrandService struct {
+	db     *gorm.DB
+	config *config.Config
+}
+
+// NewBrandService creates a new brand service
+func NewBrandService(db *gorm.DB, cfg *config.Config) *BrandService {
+	return &BrandService{
+		db:     db,
+		config: cfg,
+	}
+}
+
+// BrandListRequest represents brand list query parameters
+type BrandListRequest struct {
+	Page   int    `form:"page,default=1"`
+	Limit  int    `form:"limit,default=20"`
+	Search string `form:"search"`
+}
+
+// BrandListResponse represents a page of brands
+type BrandListResponse struct {
+	Brands     []BrandWithProductCount `json:"brands"`
+	Total      int64                   `json:"total"`
+	Page       int                     `json:"page"`
+	Limit      int                     `json:"limit"`
+	TotalPages int                     `json:"total_pages"`
+}
+
+// BrandWithProductCount represents a brand with the number of its products
+type BrandWithProductCount struct {
+	Brand
+	ProductCount int64 `json:"product_count"`
+}
+
+// BrandCreateRequest represents brand creation data
+type BrandCreateRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Slug        string `json:"slug"` // Generated from name when empty
+	Description string `json:"description"`
+	Logo        string `json:"logo"`
+	Website     string `json:"website"`
+	IsActive    *bool  `json:"is_active"`
+}
+
+// BrandUpdateRequest represents brand update data
+type BrandUpdateRequest struct {
+	Name        *string `json:"name"`
+	Slug        *string `json:"slug"`
+	Description *string `json:"description"`
+	Logo        *string `json:"logo"`
+	Website     *string `json:"website"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+// BrandProductsRequest represents products to associate with or remove from a brand
+type BrandProductsRequest struct {
+	ProductIDs []uint `json:"product_ids" binding:"required,min=1"`
+}
+
+// BrandProductsResult represents the outcome of a brand product association change
+type BrandProductsResult struct {
+	BrandID  uint   `json:"brand_id"`
+	Updated  int64  `json:"updated"`
+	NotFound []uint `json:"not_found,omitempty"`
+}
+
+// GetBrands retrieves a page of brands with product counts
+func (s *BrandService) GetBrands(req *BrandListRequest, includeInactive bool) (*BrandListResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 20
+	}
+
+	query := s.db.Model(&Brand{})
+	if !includeInactive {
+		query = query.Where("is_active = ?", true)
+	}
+	if req.Search != "" {
+		query = query.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(req.Search)+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count brands: %w", err)
+	}
+
+	var brands []Brand
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("name ASC").Offset(offset).Limit(req.Limit).Find(&brands).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve brands: %w", err)
+	}
+
+	counts, err := s.productCounts(brands, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]BrandWithProductCount, len(brands))
+	for i, brand := range brands {
+		result[i] = BrandWithProductCount{Brand: brand, ProductCount: counts[brand.ID]}
+	}
+
+	return &BrandListResponse{
+		Brands:     result,
+		Total:      total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+	}, nil
+}
+
+// GetBrand retrieves a single brand by ID
+func (s *BrandService) GetBrand(id uint, includeInactive bool) (*BrandWithProductCount, error) {
+	query := s.db.Where("id = ?", id)
+	if !includeInactive {
+		query = query.Where("is_active = ?", true)
+	}
+	return s.findBrand(query, includeInactive)
+}
+
+// GetBrandBySlug retrieves a single active brand by slug
+func (s *BrandService) GetBrandBySlug(slug string) (*BrandWithProductCount, error) {
+	return s.findBrand(s.db.Where("slug = ? AND is_active = ?", slug, true), false)
+}
+
+// CreateBrand creates a new brand
+func (s *BrandService) CreateBrand(req *BrandCreateRequest) (*Brand, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("brand name is required")
+	}
+
+	slug, err := s.resolveSlug(req.Slug, name, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	brand := Brand{
+		Name:        name,
+		Slug:        slug,
+		Description: req.Description,
+		Logo:        req.Logo,
+		Website:     req.Website,
+		IsActive:    true,
+	}
+	if req.IsActive != nil {
+		brand.IsActive = *req.IsActive
+	}
+
+	if err := s.db.Create(&brand).Error; err != nil {
+		return nil, fmt.Errorf("failed to create brand: %w", err)
+	}
+	// GORM skips zero values on create, so an inactive brand needs an explicit update
+	if !brand.IsActive {
+		s.db.Model(&brand).Update("is_active", false)
+	}
+
+	return &brand, nil
+}
+
+// UpdateBrand updates an existing brand. Renaming keeps the slug unless a new one is given,
+// so existing storefront links keep working.
+func (s *BrandService) UpdateBrand(id uint, req *BrandUpdateRequest) (*Brand, error) {
+	var brand Brand
+	if err := s.db.First(&brand, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBrandNotFound
+		}
+		return nil, fmt.Errorf("failed to find brand: %w", err)
+	}
+
+	updates := make(map[string]interface{})
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("brand name is required")
+		}
+		updates["name"] = name
+	}
+	if req.Slug != nil {
+		slug, err := s.resolveSlug(*req.Slug, brand.Name, brand.ID)
+		if err != nil {
+			return nil, err
+		}
+		updates["slug"] = slug
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Logo != nil {
+		updates["logo"] = *req.Logo
+	}
+	if req.Website != nil {
+		updates["website"] = *req.Website
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+
+	if len(updates) > 0 {
+		if err := s.db.Model(&brand).Updates(updates).Error; err != nil {
+			return nil, fmt.Errorf("failed to update brand: %w", err)
+		}
+	}
+
+	s.db.First(&brand, brand.ID)
+	return &brand, nil
+}
+
+// DeleteBrand soft deletes a brand. A brand with products is only deleted when
+// detachProducts is set, in which case those products are left without a brand.
+func (s *BrandService) DeleteBrand(id uint, detachProducts bool) error {
+	var brand Brand
+	if err := s.db.First(&brand, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrBrandNotFound
+		}
+		return fmt.Errorf("failed to find brand: %w", err)
+	}
+
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var productCount int64
+	if err := tx.Model(&Product{}).Where("brand_id = ?", id).Count(&productCount).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to count brand products: %w", err)
+	}
+
+	if productCount > 0 {
+		if !detachProducts {
+			tx.Rollback()
+			return fmt.Errorf("%w: %d products reference this brand", ErrBrandInUse, productCount)
+		}
+		if err := tx.Model(&Product{}).Where("brand_id = ?", id).Update("brand_id", nil).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to detach brand products: %w", err)
+		}
+	}
+
+	if err := tx.Delete(&brand).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete brand: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit brand deletion: %w", err)
+	}
+
+	return nil
+}
+
+// AssignProducts associates the given products with a brand
+func (s *BrandService) AssignProducts(brandID uint, req *BrandProductsRequest) (*BrandProductsResult, error) {
+	if err := checkBrandExists(s.db, brandID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.findProducts(brandID, req.ProductIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	update := s.db.Model(&Product{}).Where("id IN ?", req.ProductIDs).Update("brand_id", brandID)
+	if update.Error != nil {
+		return nil, fmt.Errorf("failed to assign products: %w", update.Error)
+	}
+	result.Updated = update.RowsAffected
+
+	return result, nil
+}
+
+// RemoveProducts clears the brand from the given products that currently belong to it
+func (s *BrandService) RemoveProducts(brandID uint, req *BrandProductsRequest) (*BrandProductsResult, error) {
+	result, err := s.findProducts(brandID, req.ProductIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	update := s.db.Model(&Product{}).Where("id IN ? AND brand_id = ?", req.ProductIDs, brandID).Update("brand_id", nil)
+	if update.Error != nil {
+		return nil, fmt.Errorf("failed to remove products from brand: %w", update.Error)
+	}
+	result.Updated = update.RowsAffected
+
+	return result, nil
+}
+
+// findBrand loads a brand matching the query together with its product count
+func (s *BrandService) findBrand(query *gorm.DB, includeInactive bool) (*BrandWithProductCount, error) {
+	var brand Brand
+	if err := query.First(&brand).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBrandNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve brand: %w", err)
+	}
+
+	counts, err := s.productCounts([]Brand{brand}, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BrandWithProductCount{Brand: brand, ProductCount: counts[brand.ID]}, nil
+}
+
+// productCounts counts products per brand; storefront counts only include active products
+func (s *BrandService) productCounts(brands []Brand, includeInactive bool) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(brands))
+	if len(brands) == 0 {
+		return counts, nil
+	}
+
+	ids := make([]uint, len(brands))
+	for i, brand := range brands {
+		ids[i] = brand.ID
+	}
+
+	query := s.db.Model(&Product{}).Select("brand_id, COUNT(*) as count").Where("brand_id IN ?", ids)
+	if !includeInactive {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var rows []struct {
+		BrandID uint
+		Count   int64
+	}
+	if err := query.Group("brand_id").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count brand products: %w", err)
+	}
+
+	for _, row := range rows {
+		counts[row.BrandID] = row.Count
+	}
+	return counts, nil
+}
+
+// findProducts reports which of the requested product IDs do not exist
+func (s *BrandService) findProducts(brandID uint, productIDs []uint) (*BrandProductsResult, error) {
+	var existing []uint
+	if err := s.db.Model(&Product{}).Where("id IN ?", productIDs).Pluck("id", &existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to find products: %w", err)
+	}
+
+	found := make(map[uint]bool, len(existing))
+	for _, id := range existing {
+		found[id] = true
+	}
+
+	result := &BrandProductsResult{BrandID: brandID}
+	for _, id := range productIDs {
+		if !found[id] {
+			result.NotFound = append(result.NotFound, id)
+		}
+	}
+	return result, nil
+}
+
+// resolveSlug validates a requested slug, or generates a unique one from the name when empty.
+// Soft-deleted brands still hold their slug because of the unique index.
+func (s *BrandService) resolveSlug(requested, name string, excludeID uint) (string, error) {
+	if requested = strings.TrimSpace(requested); requested != "" {
+		slug := slugify(requested)
+		if slug == "" {
+			return "", fmt.Errorf("invalid slug: %s", requested)
+		}
+		taken, err := s.slugTaken(slug, excludeID)
+		if err != nil {
+			return "", err
+		}
+		if taken {
+			return "", fmt.Errorf("%w: %s", ErrBrandSlugTaken, slug)
+		}
+		return slug, nil
+	}
+
+	base := slugify(name)
+	if base == "" {
+		base = "brand"
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		taken, err := s.slugTaken(slug, excludeID)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// slugTaken checks whether another brand, including deleted ones, uses the slug
+func (s *BrandService) slugTaken(slug string, excludeID uint) (bool, error) {
+	var count int64
+	query := s.db.Unscoped().Model(&Brand{}).Where("slug = ?", slug)
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check brand slug: %w", err)
+	}
+	return count > 0, nil
+}
+
+// checkBrandExists verifies a brand ID before products are associated with it
+func checkBrandExists(db *gorm.DB, brandID uint) error {
+	var count int64
+	if err := db.Model(&Brand{}).Where("id = ?", brandID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to find brand: %w", err)
+	}
+	if count == 0 {
+		return ErrBrandNotFound
+	}
+	return nil
+}
+
+// slugify lower-cases a value and collapses anything other than letters and digits into hyphens
+func slugify(value string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(value), "-"), "-")
+}
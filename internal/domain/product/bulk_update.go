@@ -0,0 +1,215 @@
+// internal/domain/product/bulk_update.go
+package product
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"gorm.io/gorm"
+)
+
+// Bulk product update outcomes
+const (
+	BulkProductUpdated  = "updated"
+	BulkProductNotFound = "not_found"
+	BulkProductFailed   = "failed"
+)
+
+// BulkProductUpdateRequest applies one partial update to many products
+type BulkProductUpdateRequest struct {
+	ProductIDs []uint            `json:"product_ids" binding:"required,min=1"`
+	Updates    BulkProductUpdate `json:"updates"`
+}
+
+// BulkProductUpdate is the partial update applied to every product in a bulk update
+type BulkProductUpdate struct {
+	IsActive     *bool    `json:"is_active"`
+	IsFeatured   *bool    `json:"is_featured"`
+	CategoryID   *uint    `json:"category_id"`
+	BrandID      *uint    `json:"brand_id"`      // 0 removes the brand
+	PricePercent *float64 `json:"price_percent"` // e.g. 10 raises prices by 10%, -15 lowers them by 15%
+}
+
+// BulkProductResult represents the outcome for one product of a bulk update
+type BulkProductResult struct {
+	ProductID     uint   `json:"product_id"`
+	Status        string `json:"status"`
+	PreviousPrice int64  `json:"previous_price,omitempty"`
+	Price         int64  `json:"price,omitempty"`
+	Warning       string `json:"warning,omitempty"` // Price below the floor, allowed because the floor only warns
+	Error         string `json:"error,omitempty"`
+}
+
+// BulkProductUpdateResult represents the outcome of a bulk product update
+type BulkProductUpdateResult struct {
+	Updated  int                 `json:"updated"`
+	NotFound int                 `json:"not_found"`
+	Failed   int                 `json:"failed"`
+	Results  []BulkProductResult `json:"results"`
+}
+
+// BulkUpdateProducts applies the update to each product in a single transaction.
+// A product that is missing or fails to update is reported in its result and rolled
+// back to a savepoint, without aborting the rest of the batch.
+func (s *Service) BulkUpdateProducts(req *BulkProductUpdateRequest) (*BulkProductUpdateResult, error) {
+	productIDs := uniqueIDs(req.ProductIDs)
+	if max := s.bulkUpdateMax(); len(productIDs) > max {
+		return nil, fmt.Errorf("at most %d products can be updated at once", max)
+	}
+
+	updates, err := s.bulkUpdateFields(&req.Updates)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkProductUpdateResult{Results: make([]BulkProductResult, len(productIDs))}
+
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for i, id := range productIDs {
+		res := &result.Results[i]
+		res.ProductID = id
+
+		if err := tx.SavePoint("bulk_product").Error; err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to update products: %w", err)
+		}
+
+		var product Product
+		if err := tx.Select("id, sku, price, cost_price").First(&product, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				res.Status = BulkProductNotFound
+				res.Error = "product not found"
+				result.NotFound++
+			} else {
+				tx.RollbackTo("bulk_product")
+				res.Status = BulkProductFailed
+				res.Error = "failed to load product"
+				result.Failed++
+			}
+			continue
+		}
+
+		if err := s.applyBulkUpdate(tx, &product, updates, req.Updates.PricePercent, res); err != nil {
+			tx.RollbackTo("bulk_product")
+			res.Status = BulkProductFailed
+			res.Error = err.Error()
+			result.Failed++
+			continue
+		}
+
+		res.Status = BulkProductUpdated
+		result.Updated++
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit product updates: %w", err)
+	}
+
+	return result, nil
+}
+
+// applyBulkUpdate updates one product, scaling its price and any variant price overrides
+func (s *Service) applyBulkUpdate(tx *gorm.DB, product *Product, updates map[string]interface{}, pricePercent *float64, res *BulkProductResult) error {
+	fields := make(map[string]interface{}, len(updates)+1)
+	for k, v := range updates {
+		fields[k] = v
+	}
+
+	if pricePercent != nil {
+		factor := 1 + *pricePercent/100
+		price := int64(math.Round(float64(product.Price) * factor))
+		if price < 1 {
+			return fmt.Errorf("adjusted price must be at least 1")
+		}
+		warning, err := s.checkPriceFloor(product.SKU, price, product.CostPrice)
+		if err != nil {
+			return err
+		}
+		res.Warning = warning
+		res.PreviousPrice = product.Price
+		res.Price = price
+		fields["price"] = price
+
+		err = tx.Model(&ProductVariant{}).
+			Where("product_id = ? AND price > 0", product.ID).
+			Update("price", gorm.Expr("GREATEST(ROUND(price * ?), 1)", factor)).Error
+		if err != nil {
+			return fmt.Errorf("failed to update variant prices")
+		}
+	}
+
+	if err := tx.Model(product).Updates(fields).Error; err != nil {
+		return fmt.Errorf("failed to update product")
+	}
+	return nil
+}
+
+// bulkUpdateFields validates a bulk update and returns the column updates it sets,
+// apart from the price which is computed per product
+func (s *Service) bulkUpdateFields(update *BulkProductUpdate) (map[string]interface{}, error) {
+	updates := make(map[string]interface{})
+
+	if update.IsActive != nil {
+		updates["is_active"] = *update.IsActive
+	}
+	if update.IsFeatured != nil {
+		updates["is_featured"] = *update.IsFeatured
+	}
+	if update.CategoryID != nil {
+		var count int64
+		if err := s.db.Model(&Category{}).Where("id = ?", *update.CategoryID).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to find category: %w", err)
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("category not found")
+		}
+		updates["category_id"] = *update.CategoryID
+	}
+	if update.BrandID != nil {
+		if *update.BrandID == 0 {
+			updates["brand_id"] = nil
+		} else {
+			if err := checkBrandExists(s.db, *update.BrandID); err != nil {
+				return nil, err
+			}
+			updates["brand_id"] = *update.BrandID
+		}
+	}
+	if update.PricePercent != nil {
+		if *update.PricePercent <= -100 || *update.PricePercent > 1000 {
+			return nil, fmt.Errorf("price_percent must be greater than -100 and at most 1000")
+		}
+	}
+
+	if len(updates) == 0 && update.PricePercent == nil {
+		return nil, fmt.Errorf("no updates specified")
+	}
+	return updates, nil
+}
+
+func (s *Service) bulkUpdateMax() int {
+	if s.config.Product.BulkUpdateMax <= 0 {
+		return 500
+	}
+	return s.config.Product.BulkUpdateMax
+}
+
+// uniqueIDs returns ids without duplicates, keeping the first occurrence
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
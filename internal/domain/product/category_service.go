@@ -8,6 +8,7 @@ import (
 
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // CategoryService handles category business logic
@@ -26,22 +27,27 @@ func NewCategoryService(db *gorm.DB, cfg *config.Config) *CategoryService {
 
 // CategoryCreateRequest represents category creation data
 type CategoryCreateRequest struct {
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description"`
-	Image       string `json:"image"`
-	ParentID    *uint  `json:"parent_id"`
-	SortOrder   int    `json:"sort_order"`
-	IsActive    bool   `json:"is_active"`
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Image       string   `json:"image"`
+	ParentID    *uint    `json:"parent_id"`
+	SortOrder   int      `json:"sort_order"`
+	IsActive    bool     `json:"is_active"`
+	HSNCode     string   `json:"hsn_code"`
+	GSTRate     *float64 `json:"gst_rate"` // Omit to use the standard rate
 }
 
 // CategoryUpdateRequest represents category update data
 type CategoryUpdateRequest struct {
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
-	Image       *string `json:"image"`
-	ParentID    *uint   `json:"parent_id"`
-	SortOrder   *int    `json:"sort_order"`
-	IsActive    *bool   `json:"is_active"`
+	Name         *string  `json:"name"`
+	Description  *string  `json:"description"`
+	Image        *string  `json:"image"`
+	ParentID     *uint    `json:"parent_id"`
+	SortOrder    *int     `json:"sort_order"`
+	IsActive     *bool    `json:"is_active"`
+	HSNCode      *string  `json:"hsn_code"`
+	GSTRate      *float64 `json:"gst_rate"`
+	ClearGSTRate bool     `json:"clear_gst_rate"` // Go back to the standard rate
 }
 
 // CategoryWithProductCount represents category with product count
@@ -197,6 +203,11 @@ func (s *CategoryService) CreateCategory(req *CategoryCreateRequest) (*Category,
 		}
 	}
 
+	req.HSNCode = strings.TrimSpace(req.HSNCode)
+	if err := validateGST(req.HSNCode, req.GSTRate); err != nil {
+		return nil, err
+	}
+
 	// Generate slug from name
 	slug := s.generateSlug(req.Name)
 
@@ -215,6 +226,8 @@ func (s *CategoryService) CreateCategory(req *CategoryCreateRequest) (*Category,
 		ParentID:    req.ParentID,
 		SortOrder:   req.SortOrder,
 		IsActive:    req.IsActive,
+		HSNCode:     req.HSNCode,
+		GSTRate:     req.GSTRate,
 	}
 
 	if err := s.db.Create(&category).Error; err != nil {
@@ -279,6 +292,21 @@ func (s *CategoryService) UpdateCategory(id uint, req *CategoryUpdateRequest) (*
 	if req.IsActive != nil {
 		updates["is_active"] = *req.IsActive
 	}
+	if req.HSNCode != nil {
+		hsnCode := strings.TrimSpace(*req.HSNCode)
+		if err := validateGST(hsnCode, nil); err != nil {
+			return nil, err
+		}
+		updates["hsn_code"] = hsnCode
+	}
+	if req.ClearGSTRate {
+		updates["gst_rate"] = nil
+	} else if req.GSTRate != nil {
+		if err := validateGST("", req.GSTRate); err != nil {
+			return nil, err
+		}
+		updates["gst_rate"] = *req.GSTRate
+	}
 
 	if err := s.db.Model(&category).Updates(updates).Error; err != nil {
 		return nil, fmt.Errorf("failed to update category: %w", err)
@@ -316,6 +344,142 @@ func (s *CategoryService) DeleteCategory(id uint) error {
 	return nil
 }
 
+// BulkCategoryAssignRequest represents a bulk product reassignment. Either
+// ProductIDs or Filter must be provided.
+type BulkCategoryAssignRequest struct {
+	ProductIDs []uint              `json:"product_ids"`
+	Filter     *BulkCategoryFilter `json:"filter"`
+}
+
+// BulkCategoryFilter selects products to reassign by their current attributes
+type BulkCategoryFilter struct {
+	CategoryID uint   `json:"category_id"`
+	BrandID    uint   `json:"brand_id"`
+	Search     string `json:"search"`
+	IsActive   *bool  `json:"is_active"`
+}
+
+// BulkCategoryAssignItem represents the outcome for a single product
+type BulkCategoryAssignItem struct {
+	ProductID          uint   `json:"product_id"`
+	PreviousCategoryID uint   `json:"previous_category_id,omitempty"`
+	Status             string `json:"status"` // moved, unchanged, not_found
+}
+
+// BulkCategoryAssignResult represents the outcome of a bulk reassignment
+type BulkCategoryAssignResult struct {
+	CategoryID     uint                     `json:"category_id"`
+	Moved          int                      `json:"moved"`
+	Unchanged      int                      `json:"unchanged"`
+	NotFound       int                      `json:"not_found"`
+	Results        []BulkCategoryAssignItem `json:"results"`
+	CategoryCounts map[uint]int64           `json:"category_counts"` // Product counts of affected categories after the move
+}
+
+// BulkAssignProducts moves the selected products into the target category in a single transaction
+func (s *CategoryService) BulkAssignProducts(categoryID uint, req *BulkCategoryAssignRequest) (*BulkCategoryAssignResult, error) {
+	if len(req.ProductIDs) == 0 && req.Filter == nil {
+		return nil, fmt.Errorf("either product_ids or filter is required")
+	}
+
+	var target Category
+	if err := s.db.Where("id = ?", categoryID).First(&target).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("category not found")
+		}
+		return nil, fmt.Errorf("failed to find category: %w", err)
+	}
+
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Lock the selected products so concurrent edits don't interleave with the move
+	query := tx.Model(&Product{}).Select("id, category_id").Clauses(clause.Locking{Strength: "UPDATE"})
+	if len(req.ProductIDs) > 0 {
+		query = query.Where("id IN ?", req.ProductIDs)
+	}
+	if req.Filter != nil {
+		if req.Filter.CategoryID > 0 {
+			query = query.Where("category_id = ?", req.Filter.CategoryID)
+		}
+		if req.Filter.BrandID > 0 {
+			query = query.Where("brand_id = ?", req.Filter.BrandID)
+		}
+		if req.Filter.Search != "" {
+			search := "%" + strings.ToLower(req.Filter.Search) + "%"
+			query = query.Where("LOWER(name) LIKE ? OR LOWER(sku) LIKE ?", search, search)
+		}
+		if req.Filter.IsActive != nil {
+			query = query.Where("is_active = ?", *req.Filter.IsActive)
+		}
+	}
+
+	var products []Product
+	if err := query.Order("id ASC").Find(&products).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to find products: %w", err)
+	}
+
+	result := &BulkCategoryAssignResult{
+		CategoryID:     categoryID,
+		Results:        []BulkCategoryAssignItem{},
+		CategoryCounts: map[uint]int64{categoryID: 0},
+	}
+
+	found := make(map[uint]bool, len(products))
+	var moveIDs []uint
+	for _, p := range products {
+		found[p.ID] = true
+		item := BulkCategoryAssignItem{ProductID: p.ID, PreviousCategoryID: p.CategoryID}
+		if p.CategoryID == categoryID {
+			item.Status = "unchanged"
+			result.Unchanged++
+		} else {
+			item.Status = "moved"
+			result.Moved++
+			moveIDs = append(moveIDs, p.ID)
+			result.CategoryCounts[p.CategoryID] = 0
+		}
+		result.Results = append(result.Results, item)
+	}
+
+	// Report explicitly requested IDs that do not exist or were excluded by the filter
+	for _, id := range req.ProductIDs {
+		if !found[id] {
+			found[id] = true
+			result.NotFound++
+			result.Results = append(result.Results, BulkCategoryAssignItem{ProductID: id, Status: "not_found"})
+		}
+	}
+
+	if len(moveIDs) > 0 {
+		if err := tx.Model(&Product{}).Where("id IN ?", moveIDs).Update("category_id", categoryID).Error; err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to reassign products: %w", err)
+		}
+	}
+
+	// Recalculate product counts for the target and every source category
+	for id := range result.CategoryCounts {
+		var count int64
+		if err := tx.Model(&Product{}).Where("category_id = ?", id).Count(&count).Error; err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to count category products: %w", err)
+		}
+		result.CategoryCounts[id] = count
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit category reassignment: %w", err)
+	}
+
+	return result, nil
+}
+
 // GetRootCategories retrieves only root categories (no parent)
 func (s *CategoryService) GetRootCategories(includeInactive bool) ([]Category, error) {
 	var categories []Category
@@ -7,6 +7,12 @@ import (
 	"gorm.io/gorm"
 )
 
+// Fulfillment sources of a product
+const (
+	FulfillmentSourceWarehouse = "warehouse" // Shipped from our own stock
+	FulfillmentSourceDropship  = "dropship"  // Shipped directly by the supplier
+)
+
 // Product represents the product entity
 type Product struct {
 	ID                uint           `gorm:"primaryKey" json:"id"`
@@ -22,13 +28,19 @@ type Product struct {
 	BrandID           *uint          `gorm:"index" json:"brand_id"`
 	Weight            float64        `json:"weight"`                     // Weight in grams
 	Dimensions        string         `gorm:"size:100" json:"dimensions"` // LxWxH format
+	HSNCode           string         `gorm:"size:8" json:"hsn_code"`     // Printed on GST invoices; empty uses the category's
+	GSTRate           *float64       `json:"gst_rate"`                   // Percent; nil uses the category's rate
 	IsActive          bool           `gorm:"default:true" json:"is_active"`
 	IsFeatured        bool           `gorm:"default:false" json:"is_featured"`
 	IsDigital         bool           `gorm:"default:false" json:"is_digital"`
+	DigitalFileURL    string         `gorm:"size:500" json:"-"` // Download source for digital products, never exposed directly
 	RequiresShipping  bool           `gorm:"default:true" json:"requires_shipping"`
 	TrackQuantity     bool           `gorm:"default:true" json:"track_quantity"`
-	Quantity          int            `gorm:"default:0" json:"quantity"`
+	Quantity          int            `gorm:"default:0" json:"quantity"`     // Physical on-hand stock
+	SafetyStock       int            `gorm:"default:0" json:"safety_stock"` // Buffer held back from sale
 	LowStockThreshold int            `gorm:"default:5" json:"low_stock_threshold"`
+	MaxCartQuantity   int            `gorm:"default:0" json:"max_cart_quantity"`                    // Per-line cart limit; 0 uses the store-wide limit
+	FulfillmentSource string         `gorm:"size:20;default:'warehouse'" json:"fulfillment_source"` // warehouse or dropship
 	SeoTitle          string         `gorm:"size:255" json:"seo_title"`
 	SeoDescription    string         `gorm:"size:500" json:"seo_description"`
 	Tags              string         `gorm:"size:500" json:"tags"` // Comma-separated tags
@@ -36,6 +48,12 @@ type Product struct {
 	UpdatedAt         time.Time      `json:"updated_at"`
 	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// Computed from the configured badge rules; not stored
+	Badges []string `gorm:"-" json:"badges,omitempty"`
+
+	// Set when the price is below the price floor and the floor only warns
+	PriceWarning string `gorm:"-" json:"price_warning,omitempty"`
+
 	// Relationships
 	Category Category         `gorm:"foreignKey:CategoryID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"category"`
 	Brand    *Brand           `gorm:"foreignKey:BrandID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"brand,omitempty"`
@@ -54,6 +72,8 @@ type Category struct {
 	ParentID    *uint          `gorm:"index" json:"parent_id"`
 	SortOrder   int            `gorm:"default:0" json:"sort_order"`
 	IsActive    bool           `gorm:"default:true" json:"is_active"`
+	HSNCode     string         `gorm:"size:8" json:"hsn_code"` // Default HSN code for the category's products
+	GSTRate     *float64       `json:"gst_rate"`               // Percent; nil uses the standard rate
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
@@ -102,7 +122,8 @@ type ProductVariant struct {
 	Price        int64          `json:"price"` // Override product price if set
 	ComparePrice int64          `json:"compare_price"`
 	CostPrice    int64          `json:"cost_price"`
-	Quantity     int            `gorm:"default:0" json:"quantity"`
+	Quantity     int            `gorm:"default:0" json:"quantity"`     // Physical on-hand stock
+	SafetyStock  int            `gorm:"default:0" json:"safety_stock"` // Buffer held back from sale
 	Weight       float64        `json:"weight"`
 	Options      string         `gorm:"type:text" json:"options"` // JSON string for variant options
 	IsActive     bool           `gorm:"default:true" json:"is_active"`
@@ -120,11 +141,34 @@ func (ProductVariant) TableName() string { return "product_variants" }
 
 // Business methods for Product
 func (p *Product) IsInStock() bool {
-	return p.Quantity > 0 || !p.TrackQuantity
+	return p.AvailableQuantity() > 0 || !p.TrackQuantity
+}
+
+// AvailableQuantity returns the stock that can be sold: on-hand quantity less the
+// safety stock buffer, never below zero
+func (p *Product) AvailableQuantity() int {
+	return sellableQuantity(p.Quantity, p.SafetyStock)
+}
+
+// AvailableQuantity returns the variant stock that can be sold: on-hand quantity
+// less the safety stock buffer, never below zero
+func (v *ProductVariant) AvailableQuantity() int {
+	return sellableQuantity(v.Quantity, v.SafetyStock)
+}
+
+// sellableQuantity subtracts a safety stock buffer from on-hand stock
+func sellableQuantity(onHand, safetyStock int) int {
+	if safetyStock < 0 {
+		safetyStock = 0
+	}
+	if onHand <= safetyStock {
+		return 0
+	}
+	return onHand - safetyStock
 }
 
 func (p *Product) IsLowStock() bool {
-	return p.TrackQuantity && p.Quantity <= p.LowStockThreshold
+	return p.TrackQuantity && p.AvailableQuantity() <= p.LowStockThreshold
 }
 
 func (p *Product) GetFormattedPrice() float64 {
@@ -189,7 +233,65 @@ type ProductReviewReport struct {
 	UserID    uint      `gorm:"not null;index" json:"user_id"`
 	Reason    string    `gorm:"not null;size:100" json:"reason"` // spam, inappropriate, fake, other
 	Comment   string    `gorm:"type:text" json:"comment,omitempty"`
-	Status    string    `gorm:"default:'pending'" json:"status"` // pending, reviewed, resolved
+	Status    string    `gorm:"default:'pending'" json:"status"` // pending, resolved, dismissed
+	CreatedAt time.Time `json:"created_at"`
+
+	// Set when an admin resolves the report
+	Resolution     string     `gorm:"size:20" json:"resolution,omitempty"` // approve, remove, dismiss
+	ResolutionNote string     `gorm:"type:text" json:"resolution_note,omitempty"`
+	ResolvedBy     *uint      `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+}
+
+// ProductReviewReply is a response to a review, shown under it. Official replies
+// are posted by the store.
+type ProductReviewReply struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	ReviewID     uint           `gorm:"not null;index" json:"review_id"`
+	AuthorUserID uint           `gorm:"not null;index" json:"author_user_id"`
+	Content      string         `gorm:"type:text;not null" json:"content"`
+	IsOfficial   bool           `gorm:"default:false" json:"is_official"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ProductQuestion is a customer question about a product, published once approved
+type ProductQuestion struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	ProductID  uint           `gorm:"not null;index" json:"product_id"`
+	UserID     uint           `gorm:"not null;index" json:"user_id"`
+	Question   string         `gorm:"type:text;not null" json:"question"`
+	IsApproved bool           `gorm:"default:false" json:"is_approved"` // Admin approved
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relationships
+	Answers []ProductAnswer `gorm:"foreignKey:QuestionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"answers,omitempty"`
+}
+
+// ProductAnswer is an answer to a product question, from the store or another
+// customer. Official answers are posted by admins and need no moderation.
+type ProductAnswer struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	QuestionID   uint           `gorm:"not null;index" json:"question_id"`
+	UserID       uint           `gorm:"not null;index" json:"user_id"`
+	Answer       string         `gorm:"type:text;not null" json:"answer"`
+	IsOfficial   bool           `gorm:"default:false" json:"is_official"`
+	IsApproved   bool           `gorm:"default:false" json:"is_approved"` // Admin approved
+	HelpfulCount int            `gorm:"default:0" json:"helpful_count"`   // Helpful votes
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ProductAnswerHelpful tracks helpful votes on answers
+type ProductAnswerHelpful struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	AnswerID  uint      `gorm:"not null;index" json:"answer_id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	IsHelpful bool      `gorm:"not null" json:"is_helpful"` // true for helpful, false for not helpful
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -197,6 +299,10 @@ type ProductReviewReport struct {
 func (ProductReviewImage) TableName() string   { return "product_review_images" }
 func (ProductReviewHelpful) TableName() string { return "product_review_helpful" }
 func (ProductReviewReport) TableName() string  { return "product_review_reports" }
+func (ProductReviewReply) TableName() string   { return "product_review_replies" }
+func (ProductQuestion) TableName() string      { return "product_questions" }
+func (ProductAnswer) TableName() string        { return "product_answers" }
+func (ProductAnswerHelpful) TableName() string { return "product_answer_helpful" }
 
 // Business methods for ProductReview
 func (r *ProductReview) CanBeEditedBy(userID uint) bool {
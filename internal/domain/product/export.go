@@ -0,0 +1,118 @@
+// internal/domain/product/export.go
+package product
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// productExportBatchSize is how many products are loaded per query while exporting
+const productExportBatchSize = 500
+
+// ProductCSVColumns is the product CSV layout shared by export and import, so an
+// exported file can be edited and imported back. Prices are in major units.
+var ProductCSVColumns = []string{
+	"sku",
+	"name",
+	"slug",
+	"description",
+	"short_description",
+	"price",
+	"compare_price",
+	"cost_price",
+	"category_slug",
+	"brand_slug",
+	"quantity",
+	"track_quantity",
+	"low_stock_threshold",
+	"weight",
+	"dimensions",
+	"is_active",
+	"is_featured",
+	"is_digital",
+	"requires_shipping",
+	"tags",
+}
+
+// ProductExportRequest represents product export filters
+type ProductExportRequest struct {
+	CategoryID uint  `form:"category_id"`
+	IsActive   *bool `form:"is_active"`
+}
+
+// ExportProductsCSV streams the matching products to w as CSV, loading and flushing
+// them in batches so the full catalog is never held in memory
+func (s *Service) ExportProductsCSV(w io.Writer, req *ProductExportRequest) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(ProductCSVColumns); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	query := s.db.Model(&Product{}).Preload("Category").Preload("Brand").Order("id ASC")
+	if req.CategoryID > 0 {
+		query = query.Where("category_id = ?", req.CategoryID)
+	}
+	if req.IsActive != nil {
+		query = query.Where("is_active = ?", *req.IsActive)
+	}
+
+	var batch []Product
+	result := query.FindInBatches(&batch, productExportBatchSize, func(tx *gorm.DB, _ int) error {
+		for i := range batch {
+			if err := writer.Write(productCSVRow(&batch[i])); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to export products: %w", result.Error)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// productCSVRow formats a product in ProductCSVColumns order
+func productCSVRow(p *Product) []string {
+	brandSlug := ""
+	if p.Brand != nil {
+		brandSlug = p.Brand.Slug
+	}
+
+	return []string{
+		p.SKU,
+		p.Name,
+		p.Slug,
+		p.Description,
+		p.ShortDesc,
+		csvAmount(p.Price),
+		csvAmount(p.ComparePrice),
+		csvAmount(p.CostPrice),
+		p.Category.Slug,
+		brandSlug,
+		strconv.Itoa(p.Quantity),
+		strconv.FormatBool(p.TrackQuantity),
+		strconv.Itoa(p.LowStockThreshold),
+		strconv.FormatFloat(p.Weight, 'f', -1, 64),
+		p.Dimensions,
+		strconv.FormatBool(p.IsActive),
+		strconv.FormatBool(p.IsFeatured),
+		strconv.FormatBool(p.IsDigital),
+		strconv.FormatBool(p.RequiresShipping),
+		p.Tags,
+	}
+}
+
+// csvAmount formats cents as major units with two decimals
+func csvAmount(cents int64) string {
+	return strconv.FormatFloat(float64(cents)/100, 'f', 2, 64)
+}
@@ -0,0 +1,188 @@
+// internal/domain/product/facets.go
+package product
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Facet names, used to leave a facet's own filter out of its counts
+const (
+	facetCategory = "category"
+	facetBrand    = "brand"
+	facetPrice    = "price"
+	facetRating   = "rating"
+	facetInStock  = "in_stock"
+)
+
+// inStockCondition matches products that can be sold now, as Product.IsInStock does
+const inStockCondition = "(track_quantity = false OR quantity > GREATEST(safety_stock, 0))"
+
+// priceBucketBounds are the lower bounds, in cents, of the price facet buckets;
+// the last bucket is open-ended
+var priceBucketBounds = []int64{0, 1000, 2500, 5000, 10000, 25000, 50000}
+
+// ratingFacetMinimums are the "N stars & up" options of the rating facet
+var ratingFacetMinimums = []int{4, 3, 2, 1}
+
+// ProductFacets holds the counts shoppers can refine a product list by. Each facet
+// counts matches for all active filters except its own, so picking a brand does
+// not hide the other brands.
+type ProductFacets struct {
+	Categories  []FacetCount  `json:"categories"`
+	Brands      []FacetCount  `json:"brands"`
+	PriceRanges []PriceFacet  `json:"price_ranges"`
+	Ratings     []RatingFacet `json:"ratings"`
+	InStock     int64         `json:"in_stock"`
+}
+
+// FacetCount is the number of matching products for one category or brand
+type FacetCount struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// PriceFacet is the number of matching products in a price range; Max is 0 for the top range
+type PriceFacet struct {
+	Min   int64 `json:"min"`
+	Max   int64 `json:"max,omitempty"` // Exclusive
+	Count int64 `json:"count"`
+}
+
+// RatingFacet is the number of matching products rated MinRating or higher
+type RatingFacet struct {
+	MinRating int   `json:"min_rating"`
+	Count     int64 `json:"count"`
+}
+
+// getProductFacets computes the facet counts for a product list request
+func (s *Service) getProductFacets(req *ProductListRequest) (*ProductFacets, error) {
+	facets := &ProductFacets{}
+
+	categories, err := s.countByColumn(req, facetCategory, "category_id", &Category{})
+	if err != nil {
+		return nil, err
+	}
+	facets.Categories = categories
+
+	brands, err := s.countByColumn(req, facetBrand, "brand_id", &Brand{})
+	if err != nil {
+		return nil, err
+	}
+	facets.Brands = brands
+
+	if facets.PriceRanges, err = s.countPriceRanges(req); err != nil {
+		return nil, err
+	}
+
+	if facets.Ratings, err = s.countRatings(req); err != nil {
+		return nil, err
+	}
+
+	query, _ := applyProductFilters(s.db.Model(&Product{}), req, facetInStock)
+	if err := query.Where(inStockCondition).Count(&facets.InStock).Error; err != nil {
+		return nil, fmt.Errorf("failed to count in-stock products: %w", err)
+	}
+
+	return facets, nil
+}
+
+// countByColumn counts matching products per value of column and names each value
+// from model's table. Products are counted on their own, without joining, so the
+// shared filters' unqualified columns stay unambiguous.
+func (s *Service) countByColumn(req *ProductListRequest, facet, column string, model interface{}) ([]FacetCount, error) {
+	counts := []FacetCount{}
+	query, _ := applyProductFilters(s.db.Model(&Product{}), req, facet)
+	if err := query.Select(column + " AS id, COUNT(*) AS count").
+		Where(column + " IS NOT NULL").
+		Group(column).
+		Order("count DESC").
+		Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("failed to count products by %s: %w", facet, err)
+	}
+	if len(counts) == 0 {
+		return counts, nil
+	}
+
+	ids := make([]uint, len(counts))
+	for i, count := range counts {
+		ids[i] = count.ID
+	}
+	var names []struct {
+		ID   uint
+		Name string
+	}
+	if err := s.db.Model(model).Select("id, name").Where("id IN ?", ids).Scan(&names).Error; err != nil {
+		return nil, fmt.Errorf("failed to load %s names: %w", facet, err)
+	}
+	nameByID := make(map[uint]string, len(names))
+	for _, n := range names {
+		nameByID[n.ID] = n.Name
+	}
+	for i := range counts {
+		counts[i].Name = nameByID[counts[i].ID]
+	}
+
+	return counts, nil
+}
+
+// countPriceRanges counts matching products in each price bucket, including empty ones
+func (s *Service) countPriceRanges(req *ProductListRequest) ([]PriceFacet, error) {
+	var caseSQL strings.Builder
+	caseSQL.WriteString("CASE")
+	for i := len(priceBucketBounds) - 1; i >= 0; i-- {
+		fmt.Fprintf(&caseSQL, " WHEN price >= %d THEN %d", priceBucketBounds[i], i)
+	}
+	caseSQL.WriteString(" ELSE 0 END")
+
+	var rows []struct {
+		Bucket int
+		Count  int64
+	}
+	query, _ := applyProductFilters(s.db.Model(&Product{}), req, facetPrice)
+	if err := query.Select(caseSQL.String() + " AS bucket, COUNT(*) AS count").
+		Group("bucket").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count products by price: %w", err)
+	}
+
+	ranges := make([]PriceFacet, len(priceBucketBounds))
+	for i, lower := range priceBucketBounds {
+		ranges[i].Min = lower
+		if i+1 < len(priceBucketBounds) {
+			ranges[i].Max = priceBucketBounds[i+1]
+		}
+	}
+	for _, row := range rows {
+		if row.Bucket >= 0 && row.Bucket < len(ranges) {
+			ranges[row.Bucket].Count = row.Count
+		}
+	}
+
+	return ranges, nil
+}
+
+// countRatings counts matching products at or above each rating threshold
+func (s *Service) countRatings(req *ProductListRequest) ([]RatingFacet, error) {
+	columns := make([]string, len(ratingFacetMinimums))
+	ratings := make([]RatingFacet, len(ratingFacetMinimums))
+	dest := make([]interface{}, len(ratingFacetMinimums))
+	for i, minimum := range ratingFacetMinimums {
+		columns[i] = fmt.Sprintf("COUNT(*) FILTER (WHERE ratings.average >= %d)", minimum)
+		ratings[i].MinRating = minimum
+		dest[i] = &ratings[i].Count
+	}
+
+	query, _ := applyProductFilters(s.db.Model(&Product{}), req, facetRating)
+	row := query.Select(strings.Join(columns, ", ")).
+		Joins(`JOIN (SELECT product_id, AVG(rating) AS average FROM product_reviews
+			WHERE is_approved = ? AND deleted_at IS NULL GROUP BY product_id) AS ratings
+			ON ratings.product_id = products.id`, true).
+		Row()
+	if err := row.Scan(dest...); err != nil {
+		return nil, fmt.Errorf("failed to count products by rating: %w", err)
+	}
+
+	return ratings, nil
+}
@@ -0,0 +1,32 @@
+// internal/domain/product/gst.go
+package product
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/tax"
+)
+
+var hsnCodePattern = regexp.MustCompile(`^[0-9]{4,8}$`)
+
+// GST returns the HSN code and GST rate the product is taxed at: its own, else its
+// category's, else the standard rate. The category must be loaded.
+func (p *Product) GST() (string, float64) {
+	hsnCode := p.HSNCode
+	if hsnCode == "" {
+		hsnCode = p.Category.HSNCode
+	}
+	return hsnCode, tax.ResolveRate(p.GSTRate, p.Category.GSTRate)
+}
+
+// validateGST checks the HSN code and GST rate set on a product or category
+func validateGST(hsnCode string, rate *float64) error {
+	if hsnCode != "" && !hsnCodePattern.MatchString(hsnCode) {
+		return fmt.Errorf("hsn_code must be 4 to 8 digits")
+	}
+	if rate != nil && !tax.IsValidGSTRate(*rate) {
+		return fmt.Errorf("gst_rate must be one of %v", tax.GSTRates)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+// internal/domain/product/price_floor.go
+package product
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+)
+
+// ErrPriceBelowFloor is returned when a price is below the product's cost plus the
+// required margin and the price floor is enforced
+var ErrPriceBelowFloor = errors.New("price is below the allowed minimum")
+
+// priceFloor returns the lowest price allowed for a cost (in cents); 0 when the
+// cost is not known
+func (s *Service) priceFloor(costPrice int64) int64 {
+	if costPrice <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(float64(costPrice) * (1 + s.config.Product.MinMarginPercent/100)))
+}
+
+// checkPriceFloor validates a price against the product's cost. In reject mode a
+// price below the floor is an error; in warn mode the warning is returned instead.
+func (s *Service) checkPriceFloor(sku string, price, costPrice int64) (string, error) {
+	mode := s.config.Product.PriceFloorMode
+	if mode == "off" {
+		return "", nil
+	}
+
+	floor := s.priceFloor(costPrice)
+	if floor == 0 || price >= floor {
+		return "", nil
+	}
+
+	message := fmt.Sprintf("price %d is below the minimum of %d for cost price %d", price, floor, costPrice)
+	if s.config.Product.MinMarginPercent > 0 {
+		message += fmt.Sprintf(" with a %.2f%% margin", s.config.Product.MinMarginPercent)
+	}
+
+	if mode == "warn" {
+		log.Printf("Price floor warning for product %s: %s", sku, message)
+		return message, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrPriceBelowFloor, message)
+}
@@ -0,0 +1,363 @@
+// internal/domain/product/questions.go
+package product
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrQuestionNotFound is returned when the question does not exist or is not published
+var ErrQuestionNotFound = errors.New("question not found")
+
+// ErrAnswerNotFound is returned when the answer does not exist or is not published
+var ErrAnswerNotFound = errors.New("answer not found")
+
+// AskQuestionRequest represents a question about a product
+type AskQuestionRequest struct {
+	Question string `json:"question" binding:"required,min=5,max=1000"`
+}
+
+// AnswerQuestionRequest represents an answer to a product question
+type AnswerQuestionRequest struct {
+	Answer string `json:"answer" binding:"required,min=2,max=2000"`
+}
+
+// QuestionListRequest represents query parameters for listing product questions
+type QuestionListRequest struct {
+	ProductID *uint  `form:"product_id"`
+	Pending   bool   `form:"pending"` // Admin only: questions or answers awaiting moderation
+	SortBy    string `form:"sort_by"` // created_at, answers
+	Page      int    `form:"page"`
+	Limit     int    `form:"limit"`
+}
+
+// QuestionResponse represents a question with its answers
+type QuestionResponse struct {
+	ID          uint             `json:"id"`
+	ProductID   uint             `json:"product_id"`
+	Question    string           `json:"question"`
+	AuthorName  string           `json:"author_name"`
+	IsApproved  bool             `json:"is_approved"`
+	AnswerCount int              `json:"answer_count"`
+	Answers     []AnswerResponse `json:"answers"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+// AnswerResponse represents an answer shown under a question
+type AnswerResponse struct {
+	ID           uint      `json:"id"`
+	QuestionID   uint      `json:"question_id"`
+	Answer       string    `json:"answer"`
+	AuthorName   string    `json:"author_name"`
+	IsOfficial   bool      `json:"is_official"`
+	IsApproved   bool      `json:"is_approved"`
+	HelpfulCount int       `json:"helpful_count"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	UserHelpful *bool `json:"user_helpful,omitempty"` // How the current user voted, if they did
+}
+
+// QuestionListResponse represents a paginated list of questions
+type QuestionListResponse struct {
+	Questions  []QuestionResponse `json:"questions"`
+	Pagination PaginationInfo     `json:"pagination"`
+}
+
+// AskQuestion posts a question about an active product. Questions from admins are
+// published straight away; others wait for moderation like reviews.
+func (s *ReviewService) AskQuestion(productID, userID uint, req *AskQuestionRequest) (*QuestionResponse, error) {
+	var count int64
+	if err := s.db.Model(&Product{}).Where("id = ? AND is_active = ?", productID, true).Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if count == 0 {
+		return nil, ErrProductNotFound
+	}
+
+	question := ProductQuestion{
+		ProductID:  productID,
+		UserID:     userID,
+		Question:   strings.TrimSpace(req.Question),
+		IsApproved: s.isUserAdmin(userID),
+	}
+	if err := s.db.Create(&question).Error; err != nil {
+		return nil, fmt.Errorf("failed to create question: %w", err)
+	}
+
+	responses, err := s.buildQuestionResponses([]ProductQuestion{question}, &userID, true)
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
+
+// AnswerQuestion posts an answer to a published question. Admin answers are
+// official and published straight away; customer answers wait for moderation.
+func (s *ReviewService) AnswerQuestion(questionID, userID uint, req *AnswerQuestionRequest) (*AnswerResponse, error) {
+	isAdmin := s.isUserAdmin(userID)
+
+	var question ProductQuestion
+	query := s.db.Select("id")
+	if !isAdmin {
+		query = query.Where("is_approved = ?", true)
+	}
+	if err := query.First(&question, questionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+
+	answer := ProductAnswer{
+		QuestionID: questionID,
+		UserID:     userID,
+		Answer:     strings.TrimSpace(req.Answer),
+		IsOfficial: isAdmin,
+		IsApproved: isAdmin,
+	}
+	if err := s.db.Create(&answer).Error; err != nil {
+		return nil, fmt.Errorf("failed to create answer: %w", err)
+	}
+
+	names := s.getAuthorNames([]uint{userID})
+	return s.buildAnswerResponse(&answer, names, nil), nil
+}
+
+// GetQuestions lists questions with their answers, most helpful answers first.
+// Shoppers see published questions and answers only; admins may list everything,
+// or with Pending just the questions that have something awaiting moderation.
+func (s *ReviewService) GetQuestions(req *QuestionListRequest, currentUserID *uint, isAdmin bool) (*QuestionListResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+
+	query := s.db.Model(&ProductQuestion{})
+	if req.ProductID != nil {
+		query = query.Where("product_id = ?", *req.ProductID)
+	}
+	if !isAdmin {
+		query = query.Where("is_approved = ?", true)
+	} else if req.Pending {
+		query = query.Where("is_approved = ? OR EXISTS (SELECT 1 FROM product_answers a WHERE a.question_id = product_questions.id AND a.is_approved = ? AND a.deleted_at IS NULL)", false, false)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count questions: %w", err)
+	}
+
+	if req.SortBy == "answers" {
+		query = query.Order("(SELECT COUNT(*) FROM product_answers a WHERE a.question_id = product_questions.id AND a.is_approved = true AND a.deleted_at IS NULL) DESC, created_at DESC")
+	} else {
+		query = query.Order("created_at DESC")
+	}
+
+	var questions []ProductQuestion
+	if err := query.Offset((req.Page - 1) * req.Limit).Limit(req.Limit).Find(&questions).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve questions: %w", err)
+	}
+
+	responses, err := s.buildQuestionResponses(questions, currentUserID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(req.Limit)))
+	return &QuestionListResponse{
+		Questions: responses,
+		Pagination: PaginationInfo{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    req.Page < totalPages,
+			HasPrev:    req.Page > 1,
+		},
+	}, nil
+}
+
+// VoteAnswerHelpful records whether a user found a published answer helpful,
+// replacing any earlier vote of theirs
+func (s *ReviewService) VoteAnswerHelpful(answerID, userID uint, req *ReviewHelpfulRequest) error {
+	var answer ProductAnswer
+	if err := s.db.Select("id").Where("is_approved = ?", true).First(&answer, answerID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAnswerNotFound
+		}
+		return fmt.Errorf("failed to get answer: %w", err)
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var existingVote ProductAnswerHelpful
+		err := tx.Where("answer_id = ? AND user_id = ?", answerID, userID).First(&existingVote).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			vote := ProductAnswerHelpful{
+				AnswerID:  answerID,
+				UserID:    userID,
+				IsHelpful: req.IsHelpful,
+			}
+			if err := tx.Create(&vote).Error; err != nil {
+				return fmt.Errorf("failed to create vote: %w", err)
+			}
+		case err != nil:
+			return fmt.Errorf("failed to get vote: %w", err)
+		default:
+			if err := tx.Model(&existingVote).Update("is_helpful", req.IsHelpful).Error; err != nil {
+				return fmt.Errorf("failed to update vote: %w", err)
+			}
+		}
+
+		var helpfulCount int64
+		if err := tx.Model(&ProductAnswerHelpful{}).Where("answer_id = ? AND is_helpful = ?", answerID, true).Count(&helpfulCount).Error; err != nil {
+			return fmt.Errorf("failed to count votes: %w", err)
+		}
+		if err := tx.Model(&ProductAnswer{}).Where("id = ?", answerID).Update("helpful_count", helpfulCount).Error; err != nil {
+			return fmt.Errorf("failed to update helpful count: %w", err)
+		}
+		return nil
+	})
+}
+
+// AdminModerateQuestion approves or rejects a question
+func (s *ReviewService) AdminModerateQuestion(questionID uint, action *AdminReviewActionRequest) error {
+	result := s.db.Model(&ProductQuestion{}).Where("id = ?", questionID).Update("is_approved", action.Action == "approve")
+	if result.Error != nil {
+		return fmt.Errorf("failed to update question status: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrQuestionNotFound
+	}
+	return nil
+}
+
+// AdminModerateAnswer approves or rejects an answer
+func (s *ReviewService) AdminModerateAnswer(answerID uint, action *AdminReviewActionRequest) error {
+	result := s.db.Model(&ProductAnswer{}).Where("id = ?", answerID).Update("is_approved", action.Action == "approve")
+	if result.Error != nil {
+		return fmt.Errorf("failed to update answer status: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAnswerNotFound
+	}
+	return nil
+}
+
+// buildQuestionResponses loads the answers, author names and the current user's
+// votes for a page of questions in a few queries. Unpublished answers are only
+// included when includePending is set.
+func (s *ReviewService) buildQuestionResponses(questions []ProductQuestion, currentUserID *uint, includePending bool) ([]QuestionResponse, error) {
+	responses := make([]QuestionResponse, len(questions))
+	if len(questions) == 0 {
+		return responses, nil
+	}
+
+	questionIDs := make([]uint, len(questions))
+	authorIDs := make([]uint, 0, len(questions))
+	for i, q := range questions {
+		questionIDs[i] = q.ID
+		authorIDs = append(authorIDs, q.UserID)
+	}
+
+	var answers []ProductAnswer
+	query := s.db.Where("question_id IN ?", questionIDs)
+	if !includePending {
+		query = query.Where("is_approved = ?", true)
+	}
+	if err := query.Order("is_official DESC, helpful_count DESC, created_at ASC").Find(&answers).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve answers: %w", err)
+	}
+
+	answerIDs := make([]uint, len(answers))
+	for i, a := range answers {
+		answerIDs[i] = a.ID
+		authorIDs = append(authorIDs, a.UserID)
+	}
+	names := s.getAuthorNames(authorIDs)
+
+	votes := map[uint]bool{}
+	if currentUserID != nil && len(answerIDs) > 0 {
+		var userVotes []ProductAnswerHelpful
+		if err := s.db.Where("answer_id IN ? AND user_id = ?", answerIDs, *currentUserID).Find(&userVotes).Error; err != nil {
+			return nil, fmt.Errorf("failed to retrieve votes: %w", err)
+		}
+		for _, v := range userVotes {
+			votes[v.AnswerID] = v.IsHelpful
+		}
+	}
+
+	answersByQuestion := make(map[uint][]AnswerResponse, len(questions))
+	for i := range answers {
+		answersByQuestion[answers[i].QuestionID] = append(answersByQuestion[answers[i].QuestionID],
+			*s.buildAnswerResponse(&answers[i], names, votes))
+	}
+
+	for i, q := range questions {
+		questionAnswers := answersByQuestion[q.ID]
+		if questionAnswers == nil {
+			questionAnswers = []AnswerResponse{}
+		}
+		responses[i] = QuestionResponse{
+			ID:          q.ID,
+			ProductID:   q.ProductID,
+			Question:    q.Question,
+			AuthorName:  names[q.UserID],
+			IsApproved:  q.IsApproved,
+			AnswerCount: len(questionAnswers),
+			Answers:     questionAnswers,
+			CreatedAt:   q.CreatedAt,
+		}
+	}
+
+	return responses, nil
+}
+
+// buildAnswerResponse names official answers after the store, as replies are,
+// and others after their author
+func (s *ReviewService) buildAnswerResponse(answer *ProductAnswer, names map[uint]string, votes map[uint]bool) *AnswerResponse {
+	authorName := names[answer.UserID]
+	if answer.IsOfficial && s.config.App.CompanyName != "" {
+		authorName = s.config.App.CompanyName
+	}
+
+	response := &AnswerResponse{
+		ID:           answer.ID,
+		QuestionID:   answer.QuestionID,
+		Answer:       answer.Answer,
+		AuthorName:   authorName,
+		IsOfficial:   answer.IsOfficial,
+		IsApproved:   answer.IsApproved,
+		HelpfulCount: answer.HelpfulCount,
+		CreatedAt:    answer.CreatedAt,
+	}
+	if helpful, ok := votes[answer.ID]; ok {
+		response.UserHelpful = &helpful
+	}
+	return response
+}
+
+// getAuthorNames returns display names for users, masked with the reviewer name format
+func (s *ReviewService) getAuthorNames(userIDs []uint) map[uint]string {
+	var users []struct {
+		ID        uint
+		FirstName string
+		LastName  string
+	}
+	s.db.Table("users").Select("id, first_name, last_name").Where("id IN ?", userIDs).Scan(&users)
+
+	names := make(map[uint]string, len(users))
+	for _, u := range users {
+		author := &ReviewUserResponse{ID: u.ID, FirstName: u.FirstName, LastName: u.LastName}
+		s.applyReviewerNameFormat(author)
+		names[u.ID] = author.DisplayName
+	}
+	return names
+}
@@ -0,0 +1,150 @@
+// internal/domain/product/related.go
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// ErrProductNotFound is returned when the product does not exist or is inactive
+var ErrProductNotFound = errors.New("product not found")
+
+// maxRelatedProducts is how many related products are computed and cached per product
+const maxRelatedProducts = 20
+
+// relatedProductsKey is the Redis key of a product's cached related product IDs
+func relatedProductsKey(productID uint) string {
+	return fmt.Sprintf("related_products:%d", productID)
+}
+
+// GetRelatedProducts returns up to limit active products frequently bought together
+// with the product, most co-purchased first, topped up with products from the same
+// category when there is not enough order history
+func (s *Service) GetRelatedProducts(productID uint, limit int) ([]Product, error) {
+	if limit <= 0 || limit > maxRelatedProducts {
+		limit = maxRelatedProducts
+	}
+
+	var base Product
+	if err := s.db.Select("id, category_id").Where("id = ? AND is_active = ?", productID, true).First(&base).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve product: %w", err)
+	}
+
+	ids, err := s.getRelatedProductIDs(&base)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	products := []Product{}
+	if len(ids) == 0 {
+		return products, nil
+	}
+
+	var found []Product
+	if err := s.db.
+		Preload("Category").
+		Preload("Brand").
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("is_primary DESC, sort_order ASC, id ASC")
+		}).
+		Where("id IN ? AND is_active = ?", ids, true).Find(&found).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve related products: %w", err)
+	}
+
+	// Keep the ranking; products deactivated since caching drop out
+	byID := make(map[uint]Product, len(found))
+	for _, p := range found {
+		byID[p.ID] = p
+	}
+	for _, id := range ids {
+		if p, ok := byID[id]; ok {
+			products = append(products, p)
+		}
+	}
+	s.applyBadges(products)
+
+	return products, nil
+}
+
+// getRelatedProductIDs reads the ranked related product IDs through the Redis
+// cache, computing them on a miss. Without Redis they are computed every time.
+func (s *Service) getRelatedProductIDs(base *Product) ([]uint, error) {
+	if s.redisClient == nil {
+		return s.computeRelatedProductIDs(base)
+	}
+
+	ctx := context.Background()
+	key := relatedProductsKey(base.ID)
+
+	if cached, err := s.redisClient.Get(ctx, key).Result(); err == nil {
+		var ids []uint
+		if err := json.Unmarshal([]byte(cached), &ids); err == nil {
+			return ids, nil
+		}
+	}
+
+	ids, err := s.computeRelatedProductIDs(base)
+	if err != nil {
+		return nil, err
+	}
+	if data, err := json.Marshal(ids); err == nil {
+		if err := s.redisClient.Set(ctx, key, data, s.config.Product.RelatedCacheTTL).Err(); err != nil {
+			log.Printf("Failed to cache related products for product %d: %v", base.ID, err)
+		}
+	}
+	return ids, nil
+}
+
+// computeRelatedProductIDs ranks active products by the number of orders they
+// share with the product, ignoring cancelled and refunded orders, then fills any
+// remaining places with the newest products in the same category
+func (s *Service) computeRelatedProductIDs(base *Product) ([]uint, error) {
+	minOrders := s.config.Product.RelatedMinOrders
+	if minOrders < 1 {
+		minOrders = 1
+	}
+
+	ids := []uint{}
+	err := s.db.Raw(`
+		SELECT other.product_id
+		FROM order_items base
+		JOIN order_items other ON other.order_id = base.order_id AND other.product_id <> base.product_id
+		JOIN orders o ON o.id = base.order_id
+		JOIN products p ON p.id = other.product_id
+		WHERE base.product_id = ? AND o.status NOT IN ('cancelled', 'refunded') AND o.deleted_at IS NULL
+			AND p.is_active = ? AND p.deleted_at IS NULL
+		GROUP BY other.product_id
+		HAVING COUNT(DISTINCT other.order_id) >= ?
+		ORDER BY COUNT(DISTINCT other.order_id) DESC, other.product_id ASC
+		LIMIT ?
+	`, base.ID, true, minOrders, maxRelatedProducts).Scan(&ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute related products: %w", err)
+	}
+
+	if len(ids) < maxRelatedProducts {
+		exclude := append([]uint{base.ID}, ids...)
+		var sameCategory []uint
+		if err := s.db.Model(&Product{}).
+			Where("category_id = ? AND is_active = ? AND id NOT IN ?", base.CategoryID, true, exclude).
+			Order("created_at DESC").
+			Limit(maxRelatedProducts-len(ids)).
+			Pluck("id", &sameCategory).Error; err != nil {
+			return nil, fmt.Errorf("failed to get same-category products: %w", err)
+		}
+		ids = append(ids, sameCategory...)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,143 @@
+// internal/domain/product/review_bulk.go
+package product
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrEmptyReviewFilter is returned when a bulk review action has no filter criteria
+var ErrEmptyReviewFilter = errors.New("at least one filter is required")
+
+// AdminBulkReviewActionRequest applies an action to every pending review matching a filter
+type AdminBulkReviewActionRequest struct {
+	Action string           `json:"action" binding:"required,oneof=approve reject"`
+	Filter BulkReviewFilter `json:"filter"`
+}
+
+// BulkReviewFilter selects pending reviews for a bulk action
+type BulkReviewFilter struct {
+	ProductID     *uint  `json:"product_id"`
+	IsVerified    *bool  `json:"is_verified"`
+	MinRating     int    `json:"min_rating" binding:"omitempty,min=1,max=5"`
+	MaxRating     int    `json:"max_rating" binding:"omitempty,min=1,max=5"`
+	IsReported    *bool  `json:"is_reported"`
+	CreatedBefore string `json:"created_before"` // YYYY-MM-DD, exclusive
+}
+
+// isEmpty reports whether the filter has no criteria
+func (f *BulkReviewFilter) isEmpty() bool {
+	return f.ProductID == nil && f.IsVerified == nil && f.MinRating == 0 && f.MaxRating == 0 &&
+		f.IsReported == nil && f.CreatedBefore == ""
+}
+
+// BulkReviewActionResult represents the outcome of a bulk review action
+type BulkReviewActionResult struct {
+	Action           string                 `json:"action"`
+	Count            int                    `json:"count"`
+	ReviewIDs        []uint                 `json:"review_ids"`
+	ProductSummaries map[uint]ReviewSummary `json:"product_summaries"` // Recomputed ratings of affected products
+}
+
+// AdminBulkReviewAction approves or rejects every pending review matching the filter
+// in one transaction. Approved reviews are published; rejected reviews are removed
+// from the moderation queue. The rating summaries of affected products are recomputed.
+func (s *ReviewService) AdminBulkReviewAction(req *AdminBulkReviewActionRequest) (*BulkReviewActionResult, error) {
+	filter := &req.Filter
+	if filter.isEmpty() {
+		return nil, ErrEmptyReviewFilter
+	}
+	if filter.MinRating > 0 && filter.MaxRating > 0 && filter.MinRating > filter.MaxRating {
+		return nil, fmt.Errorf("min_rating cannot be greater than max_rating")
+	}
+
+	var createdBefore time.Time
+	if filter.CreatedBefore != "" {
+		parsed, err := time.Parse("2006-01-02", filter.CreatedBefore)
+		if err != nil {
+			return nil, fmt.Errorf("invalid created_before date, expected YYYY-MM-DD")
+		}
+		createdBefore = parsed
+	}
+
+	tx := s.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := tx.Model(&ProductReview{}).Where("is_approved = ?", false)
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ?", *filter.ProductID)
+	}
+	if filter.IsVerified != nil {
+		query = query.Where("is_verified = ?", *filter.IsVerified)
+	}
+	if filter.MinRating > 0 {
+		query = query.Where("rating >= ?", filter.MinRating)
+	}
+	if filter.MaxRating > 0 {
+		query = query.Where("rating <= ?", filter.MaxRating)
+	}
+	if filter.IsReported != nil {
+		query = query.Where("is_reported = ?", *filter.IsReported)
+	}
+	if !createdBefore.IsZero() {
+		query = query.Where("created_at < ?", createdBefore)
+	}
+
+	// Lock the matching reviews so a concurrent moderation cannot act on them twice
+	var matched []ProductReview
+	if err := query.Select("id, product_id").Clauses(clause.Locking{Strength: "UPDATE"}).Find(&matched).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to find pending reviews: %w", err)
+	}
+
+	result := &BulkReviewActionResult{
+		Action:           req.Action,
+		ReviewIDs:        make([]uint, 0, len(matched)),
+		ProductSummaries: make(map[uint]ReviewSummary),
+	}
+	if len(matched) == 0 {
+		tx.Rollback()
+		return result, nil
+	}
+
+	productIDs := make([]uint, 0)
+	seen := make(map[uint]bool)
+	for _, review := range matched {
+		result.ReviewIDs = append(result.ReviewIDs, review.ID)
+		if !seen[review.ProductID] {
+			seen[review.ProductID] = true
+			productIDs = append(productIDs, review.ProductID)
+		}
+	}
+
+	var update *gorm.DB
+	if req.Action == "approve" {
+		update = tx.Model(&ProductReview{}).Where("id IN ?", result.ReviewIDs).Update("is_approved", true)
+	} else {
+		update = tx.Where("id IN ?", result.ReviewIDs).Delete(&ProductReview{})
+	}
+	if update.Error != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to %s reviews: %w", req.Action, update.Error)
+	}
+	result.Count = int(update.RowsAffected)
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for _, productID := range productIDs {
+		s.invalidateReviewSummary(productID)
+		result.ProductSummaries[productID] = s.getReviewSummary(productID)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,121 @@
+// internal/domain/product/review_cursor.go
+package product
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidCursor is returned when a review cursor cannot be decoded or is used
+// with a sort order it does not support
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// reviewCursor is the (created_at, id) position of the last review on a page
+type reviewCursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+// isCursorMode reports whether the request asks for keyset pagination
+func (r *ReviewListRequest) isCursorMode() bool {
+	return r.Cursor != "" || r.Pagination == "cursor"
+}
+
+// encodeReviewCursor encodes the position after review as an opaque cursor
+func encodeReviewCursor(review *ProductReview) string {
+	raw := review.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + strconv.FormatUint(uint64(review.ID), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeReviewCursor parses a cursor produced by encodeReviewCursor
+func decodeReviewCursor(cursor string) (*reviewCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	n, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &reviewCursor{CreatedAt: t, ID: uint(n)}, nil
+}
+
+// getReviewsByCursor pages through reviews by (created_at, id). Unlike offsets, a
+// keyset position is not shifted by reviews inserted while the client pages, so no
+// review is repeated or skipped. The total is not counted in this mode.
+func (s *ReviewService) getReviewsByCursor(query *gorm.DB, req *ReviewListRequest, currentUserID *uint) (*ReviewListResponse, error) {
+	if req.SortBy != "created_at" {
+		return nil, fmt.Errorf("%w: cursor pagination only supports sort_by=created_at", ErrInvalidCursor)
+	}
+	sortOrder := "desc"
+	if req.SortOrder == "asc" {
+		sortOrder = "asc"
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodeReviewCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		op := "<"
+		if sortOrder == "asc" {
+			op = ">"
+		}
+		query = query.Where(
+			fmt.Sprintf("created_at %s ? OR (created_at = ? AND id %s ?)", op, op),
+			cursor.CreatedAt, cursor.CreatedAt, cursor.ID,
+		)
+	}
+
+	// Fetch one extra row to learn whether another page exists
+	var reviews []ProductReview
+	err := query.Order(fmt.Sprintf("created_at %s, id %s", sortOrder, sortOrder)).
+		Limit(req.Limit + 1).
+		Find(&reviews).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve reviews: %w", err)
+	}
+
+	hasNext := len(reviews) > req.Limit
+	if hasNext {
+		reviews = reviews[:req.Limit]
+	}
+
+	reviewResponses := make([]ReviewResponse, len(reviews))
+	for i := range reviews {
+		reviewResponses[i] = *s.buildReviewResponse(&reviews[i], currentUserID)
+	}
+
+	response := &ReviewListResponse{
+		Reviews: reviewResponses,
+		Pagination: PaginationInfo{
+			Limit:   req.Limit,
+			HasNext: hasNext,
+			HasPrev: req.Cursor != "",
+		},
+	}
+	if hasNext {
+		response.NextCursor = encodeReviewCursor(&reviews[len(reviews)-1])
+	}
+	if req.ProductID != nil {
+		response.Summary = s.getReviewSummary(*req.ProductID)
+	}
+
+	return response, nil
+}
@@ -33,11 +33,16 @@ type ReviewListRequest struct {
 	Rating      *int   `form:"rating" binding:"omitempty,min=1,max=5"`
 	IsVerified  *bool  `form:"is_verified"`
 	IsApproved  *bool  `form:"is_approved"`
-	SortBy      string `form:"sort_by"`    // created_at, rating, helpful_count
+	SortBy      string `form:"sort_by"`    // created_at, rating, helpful_count (weighted), helpful_count_raw
 	SortOrder   string `form:"sort_order"` // asc, desc
 	Page        int    `form:"page"`
 	Limit       int    `form:"limit"`
 	SearchQuery string `form:"search"` // Search in title and content
+
+	// Keyset pagination, for products with many reviews: pass pagination=cursor for
+	// the first page, then the returned next_cursor. Only sort_by=created_at is supported.
+	Pagination string `form:"pagination" binding:"omitempty,oneof=offset cursor"`
+	Cursor     string `form:"cursor"`
 }
 
 // ReviewResponse represents a single review response
@@ -56,6 +61,7 @@ type ReviewResponse struct {
 	HelpfulCount int                    `json:"helpful_count"`
 	IsReported   bool                   `json:"is_reported"`
 	Images       []ProductReviewImage   `json:"images,omitempty"`
+	Replies      []ReviewReplyResponse  `json:"replies,omitempty"`
 	User         *ReviewUserResponse    `json:"user,omitempty"`
 	Product      *ReviewProductResponse `json:"product,omitempty"`
 	CreatedAt    time.Time              `json:"created_at"`
@@ -68,11 +74,27 @@ type ReviewResponse struct {
 	CanDelete   bool  `json:"can_delete"`             // Can current user delete this review
 }
 
+// AddReviewReplyRequest represents a reply to a review
+type AddReviewReplyRequest struct {
+	Content    string `json:"content" binding:"required,min=1,max=2000"`
+	IsOfficial *bool  `json:"is_official"` // Defaults to true
+}
+
+// ReviewReplyResponse represents a reply shown under a review
+type ReviewReplyResponse struct {
+	ID         uint      `json:"id"`
+	Content    string    `json:"content"`
+	IsOfficial bool      `json:"is_official"`
+	AuthorName string    `json:"author_name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 // ReviewListResponse represents paginated review list
 type ReviewListResponse struct {
 	Reviews    []ReviewResponse `json:"reviews"`
 	Pagination PaginationInfo   `json:"pagination"`
 	Summary    ReviewSummary    `json:"summary"`
+	NextCursor string           `json:"next_cursor,omitempty"` // Cursor mode only, set when more reviews exist
 }
 
 // ReviewSummary provides review statistics
@@ -99,6 +121,7 @@ type ReviewUserResponse struct {
 	ID          uint   `json:"id"`
 	FirstName   string `json:"first_name"`
 	LastName    string `json:"last_name"`
+	DisplayName string `json:"display_name"`
 	Avatar      string `json:"avatar,omitempty"`
 	IsVerified  bool   `json:"is_verified"`
 	ReviewCount int    `json:"review_count"`
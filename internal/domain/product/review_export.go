@@ -0,0 +1,286 @@
+// internal/domain/product/review_export.go
+package product
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// reviewExportBatchSize is how many reviews are loaded per query while exporting
+const reviewExportBatchSize = 500
+
+// ErrInvalidReviewExport is returned when the export filters are invalid
+var ErrInvalidReviewExport = errors.New("invalid review export filters")
+
+// ReviewExportColumns is the CSV layout of a review export; JSON rows use the same names
+var ReviewExportColumns = []string{
+	"id",
+	"product_id",
+	"product_sku",
+	"user_id",
+	"rating",
+	"title",
+	"content",
+	"pros",
+	"cons",
+	"is_verified",
+	"is_approved",
+	"is_reported",
+	"helpful_count",
+	"created_at",
+	"deleted_at",
+}
+
+// ReviewExportRequest represents review export filters
+type ReviewExportRequest struct {
+	Format         string `form:"format" binding:"omitempty,oneof=csv json"` // Defaults to csv
+	ProductID      uint   `form:"product_id"`
+	DateFrom       string `form:"date_from"` // YYYY-MM-DD, inclusive
+	DateTo         string `form:"date_to"`   // YYYY-MM-DD, inclusive
+	MinRating      int    `form:"min_rating" binding:"omitempty,min=1,max=5"`
+	MaxRating      int    `form:"max_rating" binding:"omitempty,min=1,max=5"`
+	IsApproved     *bool  `form:"is_approved"`
+	IncludeDeleted bool   `form:"include_deleted"`
+}
+
+// ReviewExportRow is one exported review
+type ReviewExportRow struct {
+	ID           uint       `json:"id"`
+	ProductID    uint       `json:"product_id"`
+	ProductSKU   string     `json:"product_sku"`
+	UserID       uint       `json:"user_id"`
+	Rating       int        `json:"rating"`
+	Title        string     `json:"title"`
+	Content      string     `json:"content"`
+	Pros         string     `json:"pros"`
+	Cons         string     `json:"cons"`
+	IsVerified   bool       `json:"is_verified"`
+	IsApproved   bool       `json:"is_approved"`
+	IsReported   bool       `json:"is_reported"`
+	HelpfulCount int        `json:"helpful_count"`
+	CreatedAt    time.Time  `json:"created_at"`
+	DeletedAt    *time.Time `json:"deleted_at"`
+}
+
+// ReviewExportFileName names a review export by the current store-local time
+func (s *ReviewService) ReviewExportFileName(format string) string {
+	if format == "" {
+		format = "csv"
+	}
+	return fmt.Sprintf("reviews_%s.%s", time.Now().In(s.config.GetLocation()).Format("20060102_150405"), format)
+}
+
+// ValidateReviewExport checks the export filters, so the caller can reject a request
+// before any of the response has been written
+func (s *ReviewService) ValidateReviewExport(req *ReviewExportRequest) error {
+	_, err := s.reviewExportQuery(req)
+	return err
+}
+
+// ExportReviews streams the matching reviews to w as CSV or JSON, loading and
+// flushing them in batches so the full review table is never held in memory.
+// Soft-deleted reviews are only included when requested.
+func (s *ReviewService) ExportReviews(w io.Writer, req *ReviewExportRequest) error {
+	query, err := s.reviewExportQuery(req)
+	if err != nil {
+		return err
+	}
+
+	var writeRow func(row *ReviewExportRow) error
+	var flush, finish func() error
+
+	if req.Format == "json" {
+		first := true
+		if _, err := io.WriteString(w, "["); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		writeRow = func(row *ReviewExportRow) error {
+			data, err := json.Marshal(row)
+			if err != nil {
+				return err
+			}
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			_, err = w.Write(data)
+			return err
+		}
+		flush = func() error { return nil }
+		finish = func() error {
+			_, err := io.WriteString(w, "]")
+			return err
+		}
+	} else {
+		writer := csv.NewWriter(w)
+		if err := writer.Write(ReviewExportColumns); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+		writeRow = func(row *ReviewExportRow) error {
+			return writer.Write(reviewCSVRow(row))
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+		finish = flush
+	}
+
+	var batch []ProductReview
+	result := query.FindInBatches(&batch, reviewExportBatchSize, func(tx *gorm.DB, _ int) error {
+		skus, err := s.reviewProductSKUs(batch)
+		if err != nil {
+			return err
+		}
+		for i := range batch {
+			row := reviewExportRow(&batch[i], skus[batch[i].ProductID])
+			if err := writeRow(&row); err != nil {
+				return err
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		// Push each batch to the client instead of buffering the whole export
+		if flusher, ok := w.(interface{ Flush() }); ok {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to export reviews: %w", result.Error)
+	}
+
+	if err := finish(); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+// reviewExportQuery builds the review query for the export filters
+func (s *ReviewService) reviewExportQuery(req *ReviewExportRequest) (*gorm.DB, error) {
+	query := s.db.Model(&ProductReview{})
+	if req.IncludeDeleted {
+		query = query.Unscoped()
+	}
+
+	if req.ProductID > 0 {
+		query = query.Where("product_id = ?", req.ProductID)
+	}
+	if req.MinRating > 0 && req.MaxRating > 0 && req.MinRating > req.MaxRating {
+		return nil, fmt.Errorf("%w: min_rating must not exceed max_rating", ErrInvalidReviewExport)
+	}
+	if req.MinRating > 0 {
+		query = query.Where("rating >= ?", req.MinRating)
+	}
+	if req.MaxRating > 0 {
+		query = query.Where("rating <= ?", req.MaxRating)
+	}
+	if req.IsApproved != nil {
+		query = query.Where("is_approved = ?", *req.IsApproved)
+	}
+
+	loc := s.config.GetLocation()
+	var from, to time.Time
+	if req.DateFrom != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", req.DateFrom, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid date_from, expected YYYY-MM-DD", ErrInvalidReviewExport)
+		}
+		from = parsed
+		query = query.Where("created_at >= ?", from)
+	}
+	if req.DateTo != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", req.DateTo, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid date_to, expected YYYY-MM-DD", ErrInvalidReviewExport)
+		}
+		to = parsed
+		query = query.Where("created_at < ?", to.AddDate(0, 0, 1))
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, fmt.Errorf("%w: date_to must not be before date_from", ErrInvalidReviewExport)
+	}
+
+	return query.Order("id ASC"), nil
+}
+
+// reviewProductSKUs loads the SKUs of the products reviewed in a batch, including
+// products that have since been deleted
+func (s *ReviewService) reviewProductSKUs(reviews []ProductReview) (map[uint]string, error) {
+	ids := make([]uint, 0, len(reviews))
+	for _, review := range reviews {
+		ids = append(ids, review.ProductID)
+	}
+
+	var products []Product
+	if err := s.db.Unscoped().Select("id, sku").Where("id IN ?", ids).Find(&products).Error; err != nil {
+		return nil, fmt.Errorf("failed to load review products: %w", err)
+	}
+
+	skus := make(map[uint]string, len(products))
+	for _, p := range products {
+		skus[p.ID] = p.SKU
+	}
+	return skus, nil
+}
+
+// reviewExportRow flattens a review into an export row
+func reviewExportRow(review *ProductReview, sku string) ReviewExportRow {
+	row := ReviewExportRow{
+		ID:           review.ID,
+		ProductID:    review.ProductID,
+		ProductSKU:   sku,
+		UserID:       review.UserID,
+		Rating:       review.Rating,
+		Title:        review.Title,
+		Content:      review.Content,
+		Pros:         review.Pros,
+		Cons:         review.Cons,
+		IsVerified:   review.IsVerified,
+		IsApproved:   review.IsApproved,
+		IsReported:   review.IsReported,
+		HelpfulCount: review.HelpfulCount,
+		CreatedAt:    review.CreatedAt.UTC(),
+	}
+	if review.DeletedAt.Valid {
+		deletedAt := review.DeletedAt.Time.UTC()
+		row.DeletedAt = &deletedAt
+	}
+	return row
+}
+
+// reviewCSVRow formats an export row in ReviewExportColumns order
+func reviewCSVRow(row *ReviewExportRow) []string {
+	deletedAt := ""
+	if row.DeletedAt != nil {
+		deletedAt = row.DeletedAt.Format(time.RFC3339)
+	}
+
+	return []string{
+		strconv.FormatUint(uint64(row.ID), 10),
+		strconv.FormatUint(uint64(row.ProductID), 10),
+		row.ProductSKU,
+		strconv.FormatUint(uint64(row.UserID), 10),
+		strconv.Itoa(row.Rating),
+		row.Title,
+		row.Content,
+		row.Pros,
+		row.Cons,
+		strconv.FormatBool(row.IsVerified),
+		strconv.FormatBool(row.IsApproved),
+		strconv.FormatBool(row.IsReported),
+		strconv.Itoa(row.HelpfulCount),
+		row.CreatedAt.Format(time.RFC3339),
+		deletedAt,
+	}
+}
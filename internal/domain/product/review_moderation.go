@@ -0,0 +1,239 @@
+// internal/domain/product/review_moderation.go
+package product
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Review report statuses
+const (
+	ReportStatusPending   = "pending"
+	ReportStatusResolved  = "resolved"  // The review was approved or removed
+	ReportStatusDismissed = "dismissed" // The report was unfounded
+)
+
+// Review report resolutions
+const (
+	ReportResolutionApprove = "approve" // Keep the review published
+	ReportResolutionRemove  = "remove"  // Unpublish the review
+	ReportResolutionDismiss = "dismiss" // Close the report without acting on the review
+)
+
+// ErrReportNotFound is returned when the review report does not exist
+var ErrReportNotFound = errors.New("review report not found")
+
+// ErrReportAlreadyResolved is returned when resolving a report that is no longer pending
+var ErrReportAlreadyResolved = errors.New("review report is already resolved")
+
+// ReviewReportListRequest represents the report moderation queue filters
+type ReviewReportListRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending resolved dismissed"` // Defaults to pending
+	Reason string `form:"reason" binding:"omitempty,oneof=spam inappropriate fake other"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+}
+
+// ResolveReportRequest represents an admin decision on a report
+type ResolveReportRequest struct {
+	Action string `json:"action" binding:"required,oneof=approve remove dismiss"`
+	Note   string `json:"note" binding:"max=500"`
+}
+
+// ReviewReportResponse is a report in the moderation queue with the review it concerns
+type ReviewReportResponse struct {
+	ProductReviewReport
+	Review *ReviewResponse `json:"review,omitempty"`
+}
+
+// ReviewReportListResponse represents a page of the moderation queue
+type ReviewReportListResponse struct {
+	Reports    []ReviewReportResponse `json:"reports"`
+	Pagination PaginationInfo         `json:"pagination"`
+}
+
+// ReportedReviewResponse is a review with every report filed against it
+type ReportedReviewResponse struct {
+	Review      *ReviewResponse       `json:"review"`
+	Reports     []ProductReviewReport `json:"reports"`
+	OpenReports int                   `json:"open_reports"`
+}
+
+// GetReviewReports lists review reports, oldest first so the queue is worked in order
+func (s *ReviewService) GetReviewReports(req *ReviewReportListRequest) (*ReviewReportListResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	if req.Status == "" {
+		req.Status = ReportStatusPending
+	}
+
+	query := s.db.Model(&ProductReviewReport{}).Where("status = ?", req.Status)
+	if req.Reason != "" {
+		query = query.Where("reason = ?", req.Reason)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count review reports: %w", err)
+	}
+
+	var reports []ProductReviewReport
+	offset := (req.Page - 1) * req.Limit
+	if err := query.Order("created_at ASC, id ASC").Offset(offset).Limit(req.Limit).Find(&reports).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve review reports: %w", err)
+	}
+
+	// Load each reported review once, even when it has several reports on the page
+	reviews := make(map[uint]*ReviewResponse)
+	responses := make([]ReviewReportResponse, len(reports))
+	for i, report := range reports {
+		review, ok := reviews[report.ReviewID]
+		if !ok {
+			review, _ = s.getModerationReview(report.ReviewID)
+			reviews[report.ReviewID] = review
+		}
+		responses[i] = ReviewReportResponse{
+			ProductReviewReport: report,
+			Review:              review,
+		}
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(req.Limit)))
+
+	return &ReviewReportListResponse{
+		Reports: responses,
+		Pagination: PaginationInfo{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    req.Page < totalPages,
+			HasPrev:    req.Page > 1,
+		},
+	}, nil
+}
+
+// GetReportedReview returns a review, published or not, with all of its reports
+func (s *ReviewService) GetReportedReview(reviewID uint) (*ReportedReviewResponse, error) {
+	review, err := s.getModerationReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []ProductReviewReport
+	if err := s.db.Where("review_id = ?", reviewID).Order("created_at ASC").Find(&reports).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve review reports: %w", err)
+	}
+
+	open := 0
+	for _, report := range reports {
+		if report.Status == ReportStatusPending {
+			open++
+		}
+	}
+
+	return &ReportedReviewResponse{
+		Review:      review,
+		Reports:     reports,
+		OpenReports: open,
+	}, nil
+}
+
+// ResolveReport records an admin decision on a report. Removing the review
+// unpublishes it and closes every open report against it; approving publishes it.
+// The review's reported flag is cleared once no open reports remain.
+func (s *ReviewService) ResolveReport(reportID, adminID uint, req *ResolveReportRequest) (*ReportedReviewResponse, error) {
+	var reviewID, productID uint
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var report ProductReviewReport
+		if err := tx.First(&report, reportID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrReportNotFound
+			}
+			return fmt.Errorf("failed to get review report: %w", err)
+		}
+		if report.Status != ReportStatusPending {
+			return fmt.Errorf("%w: status is %s", ErrReportAlreadyResolved, report.Status)
+		}
+		reviewID = report.ReviewID
+
+		status := ReportStatusResolved
+		if req.Action == ReportResolutionDismiss {
+			status = ReportStatusDismissed
+		}
+
+		// A removed review takes all of its open reports with it
+		reports := tx.Model(&ProductReviewReport{}).Where("id = ?", report.ID)
+		if req.Action == ReportResolutionRemove {
+			reports = tx.Model(&ProductReviewReport{}).
+				Where("review_id = ? AND status = ?", report.ReviewID, ReportStatusPending)
+		}
+		now := time.Now().UTC()
+		if err := reports.Updates(map[string]interface{}{
+			"status":          status,
+			"resolution":      req.Action,
+			"resolution_note": strings.TrimSpace(req.Note),
+			"resolved_by":     adminID,
+			"resolved_at":     now,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to resolve review report: %w", err)
+		}
+
+		var openReports int64
+		if err := tx.Model(&ProductReviewReport{}).
+			Where("review_id = ? AND status = ?", report.ReviewID, ReportStatusPending).
+			Count(&openReports).Error; err != nil {
+			return fmt.Errorf("failed to count open reports: %w", err)
+		}
+
+		var review ProductReview
+		if err := tx.Unscoped().Select("id, product_id").First(&review, report.ReviewID).Error; err != nil {
+			return fmt.Errorf("failed to get review: %w", err)
+		}
+		productID = review.ProductID
+
+		reviewUpdates := map[string]interface{}{
+			"is_reported": openReports > 0,
+		}
+		switch req.Action {
+		case ReportResolutionApprove:
+			reviewUpdates["is_approved"] = true
+		case ReportResolutionRemove:
+			reviewUpdates["is_approved"] = false
+		}
+		if err := tx.Model(&ProductReview{}).Where("id = ?", report.ReviewID).Updates(reviewUpdates).Error; err != nil {
+			return fmt.Errorf("failed to update review: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if req.Action != ReportResolutionDismiss {
+		s.invalidateReviewSummary(productID)
+	}
+
+	return s.GetReportedReview(reviewID)
+}
+
+// getModerationReview loads a review for moderators, including soft-deleted ones
+func (s *ReviewService) getModerationReview(reviewID uint) (*ReviewResponse, error) {
+	var review ProductReview
+	if err := s.db.Unscoped().Preload("Images").First(&review, reviewID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	return s.buildReviewResponse(&review, nil), nil
+}
@@ -0,0 +1,66 @@
+// internal/domain/product/review_prompts.go
+package product
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReviewPrompt represents a purchased item the user can still review
+type ReviewPrompt struct {
+	OrderID      uint       `json:"order_id"`
+	OrderNumber  string     `json:"order_number"`
+	DeliveredAt  *time.Time `json:"delivered_at,omitempty"`
+	ProductID    uint       `json:"product_id"`
+	ProductName  string     `json:"product_name"`
+	ProductSlug  string     `json:"product_slug"`
+	ProductImage string     `json:"product_image,omitempty"`
+	VariantTitle string     `json:"variant_title,omitempty"`
+}
+
+// GetReviewPrompts returns items from the user's delivered orders that they have
+// not reviewed yet. Each product appears once, linked to its most recent delivered order
+// so the resulting review is recorded as a verified purchase.
+func (s *ReviewService) GetReviewPrompts(userID uint, limit int) ([]ReviewPrompt, error) {
+	if limit <= 0 || limit > 50 {
+		limit = 20
+	}
+
+	var prompts []ReviewPrompt
+	err := s.db.Raw(`
+		SELECT * FROM (
+			SELECT DISTINCT ON (oi.product_id)
+				o.id as order_id,
+				o.order_number,
+				o.delivered_at,
+				p.id as product_id,
+				p.name as product_name,
+				p.slug as product_slug,
+				COALESCE((
+					SELECT pi.url FROM product_images pi
+					WHERE pi.product_id = p.id
+					ORDER BY pi.is_primary DESC, pi.sort_order ASC
+					LIMIT 1
+				), '') as product_image,
+				oi.variant_title
+			FROM orders o
+			JOIN order_items oi ON oi.order_id = o.id
+			JOIN products p ON p.id = oi.product_id AND p.is_active = true AND p.deleted_at IS NULL
+			WHERE o.user_id = ?
+				AND o.status IN ('delivered', 'completed')
+				AND o.deleted_at IS NULL
+				AND NOT EXISTS (
+					SELECT 1 FROM product_reviews r
+					WHERE r.user_id = o.user_id AND r.product_id = oi.product_id AND r.deleted_at IS NULL
+				)
+			ORDER BY oi.product_id, COALESCE(o.delivered_at, o.updated_at) DESC
+		) prompts
+		ORDER BY COALESCE(delivered_at, '-infinity'::timestamptz) DESC
+		LIMIT ?
+	`, userID, limit).Scan(&prompts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review prompts: %w", err)
+	}
+
+	return prompts, nil
+}
@@ -0,0 +1,81 @@
+// internal/domain/product/review_replies.go
+package product
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrOfficialReplyNotAllowed is returned when a non-admin tries to post an official reply
+var ErrOfficialReplyNotAllowed = errors.New("only admins can post official replies")
+
+// ErrReviewNotFound is returned when the review does not exist
+var ErrReviewNotFound = errors.New("review not found")
+
+// AddReply posts a reply under a review. Replies are official unless the request
+// says otherwise, and only admins may post official replies.
+func (s *ReviewService) AddReply(reviewID, authorID uint, req *AddReviewReplyRequest) (*ReviewReplyResponse, error) {
+	var review ProductReview
+	if err := s.db.Select("id").First(&review, reviewID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	isOfficial := true
+	if req.IsOfficial != nil {
+		isOfficial = *req.IsOfficial
+	}
+	if isOfficial && !s.isUserAdmin(authorID) {
+		return nil, ErrOfficialReplyNotAllowed
+	}
+
+	reply := ProductReviewReply{
+		ReviewID:     reviewID,
+		AuthorUserID: authorID,
+		Content:      strings.TrimSpace(req.Content),
+		IsOfficial:   isOfficial,
+	}
+	if err := s.db.Create(&reply).Error; err != nil {
+		return nil, fmt.Errorf("failed to create reply: %w", err)
+	}
+
+	return s.buildReplyResponse(&reply), nil
+}
+
+// GetReplies returns the replies to a review, oldest first
+func (s *ReviewService) GetReplies(reviewID uint) ([]ReviewReplyResponse, error) {
+	var replies []ProductReviewReply
+	if err := s.db.Where("review_id = ?", reviewID).Order("created_at ASC").Find(&replies).Error; err != nil {
+		return nil, fmt.Errorf("failed to get replies: %w", err)
+	}
+
+	responses := make([]ReviewReplyResponse, len(replies))
+	for i := range replies {
+		responses[i] = *s.buildReplyResponse(&replies[i])
+	}
+	return responses, nil
+}
+
+// buildReplyResponse names official replies after the store and other replies
+// after their author
+func (s *ReviewService) buildReplyResponse(reply *ProductReviewReply) *ReviewReplyResponse {
+	authorName := s.config.App.CompanyName
+	if !reply.IsOfficial || authorName == "" {
+		if author := s.getReviewUser(reply.AuthorUserID); author != nil {
+			authorName = author.DisplayName
+		}
+	}
+
+	return &ReviewReplyResponse{
+		ID:         reply.ID,
+		Content:    reply.Content,
+		IsOfficial: reply.IsOfficial,
+		AuthorName: authorName,
+		CreatedAt:  reply.CreatedAt,
+	}
+}
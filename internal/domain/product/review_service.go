@@ -2,28 +2,50 @@
 package product
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strings"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
 	"gorm.io/gorm"
 )
 
+// Reviewer name display formats
+const (
+	ReviewerNameFull         = "full"          // John Doe
+	ReviewerNameFirstOnly    = "first_only"    // John
+	ReviewerNameFirstInitial = "first_initial" // John D.
+	ReviewerNameAnonymous    = "anonymous"     // Anonymous
+)
+
+// ErrAccountTooNew is returned when an account is younger than the minimum age to post reviews
+var ErrAccountTooNew = errors.New("account is too new to post reviews")
+
 // ReviewService handles review business logic
 type ReviewService struct {
-	db *gorm.DB
+	db          *gorm.DB
+	redisClient *redis.Client // Caches rating summaries; may be nil
+	config      *config.Config
 }
 
 // NewReviewService creates a new review service
-func NewReviewService(db *gorm.DB) *ReviewService {
+func NewReviewService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *ReviewService {
 	return &ReviewService{
-		db: db,
+		db:          db,
+		redisClient: redisClient,
+		config:      cfg,
 	}
 }
 
 // CreateReview creates a new product review
 func (s *ReviewService) CreateReview(userID uint, req *CreateReviewRequest) (*ReviewResponse, error) {
+	if err := s.checkAccountAge(userID); err != nil {
+		return nil, err
+	}
+
 	// Check if user has already reviewed this product
 	var existingReview ProductReview
 	result := s.db.Where("user_id = ? AND product_id = ?", userID, req.ProductID).First(&existingReview)
@@ -67,7 +89,7 @@ func (s *ReviewService) CreateReview(userID uint, req *CreateReviewRequest) (*Re
 		Pros:         strings.TrimSpace(req.Pros),
 		Cons:         strings.TrimSpace(req.Cons),
 		IsVerified:   isVerified,
-		IsApproved:   false, // Requires admin approval by default
+		IsApproved:   s.autoApprove(isVerified), // Requires admin approval unless auto-approved
 		HelpfulCount: 0,
 		IsReported:   false,
 	}
@@ -103,6 +125,7 @@ func (s *ReviewService) CreateReview(userID uint, req *CreateReviewRequest) (*Re
 	if err := tx.Commit().Error; err != nil {
 		return nil, fmt.Errorf("failed to commit review creation: %w", err)
 	}
+	s.invalidateReviewSummary(review.ProductID)
 
 	// Return created review
 	return s.GetReview(review.ID, &userID)
@@ -166,6 +189,10 @@ func (s *ReviewService) GetReviews(req *ReviewListRequest, currentUserID *uint)
 		query = query.Where("LOWER(title) LIKE ? OR LOWER(content) LIKE ?", searchTerm, searchTerm)
 	}
 
+	if req.isCursorMode() {
+		return s.getReviewsByCursor(query, req, currentUserID)
+	}
+
 	// Count total
 	var total int64
 	query.Count(&total)
@@ -253,6 +280,7 @@ func (s *ReviewService) UpdateReview(reviewID, userID uint, req *UpdateReviewReq
 	if err := s.db.Model(&review).Updates(updates).Error; err != nil {
 		return nil, fmt.Errorf("failed to update review: %w", err)
 	}
+	s.invalidateReviewSummary(review.ProductID)
 
 	return s.GetReview(reviewID, &userID)
 }
@@ -274,6 +302,7 @@ func (s *ReviewService) DeleteReview(reviewID, userID uint) error {
 	if err := s.db.Delete(&review).Error; err != nil {
 		return fmt.Errorf("failed to delete review: %w", err)
 	}
+	s.invalidateReviewSummary(review.ProductID)
 
 	return nil
 }
@@ -325,7 +354,12 @@ func (s *ReviewService) VoteHelpful(reviewID, userID uint, req *ReviewHelpfulReq
 		return fmt.Errorf("failed to update helpful count: %w", err)
 	}
 
-	return tx.Commit().Error
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	s.invalidateReviewSummary(review.ProductID)
+
+	return nil
 }
 
 // ReportReview allows users to report inappropriate reviews
@@ -349,7 +383,7 @@ func (s *ReviewService) ReportReview(reviewID, userID uint, req *ReviewReportReq
 		UserID:   userID,
 		Reason:   req.Reason,
 		Comment:  req.Comment,
-		Status:   "pending",
+		Status:   ReportStatusPending,
 	}
 
 	if err := s.db.Create(&report).Error; err != nil {
@@ -362,7 +396,8 @@ func (s *ReviewService) ReportReview(reviewID, userID uint, req *ReviewReportReq
 	return nil
 }
 
-// GetProductReviewSummary gets review statistics for a product
+// GetProductReviewSummary gets review statistics for a product, served from the
+// Redis cache when available
 func (s *ReviewService) GetProductReviewSummary(productID uint) (*ReviewSummary, error) {
 	summary := s.getReviewSummary(productID)
 	return &summary, nil
@@ -384,6 +419,7 @@ func (s *ReviewService) AdminApproveReview(reviewID uint, action *AdminReviewAct
 	if err := s.db.Model(&review).Updates(updates).Error; err != nil {
 		return fmt.Errorf("failed to update review status: %w", err)
 	}
+	s.invalidateReviewSummary(review.ProductID)
 
 	return nil
 }
@@ -416,6 +452,11 @@ func (s *ReviewService) buildReviewResponse(review *ProductReview, currentUserID
 	// Load product info
 	response.Product = s.getReviewProduct(review.ProductID)
 
+	// Load store and seller replies
+	if replies, err := s.GetReplies(review.ID); err == nil {
+		response.Replies = replies
+	}
+
 	// Set permissions for current user
 	if currentUserID != nil {
 		response.CanEdit = review.CanBeEditedBy(*currentUserID)
@@ -447,7 +488,7 @@ func (s *ReviewService) getReviewUser(userID uint) *ReviewUserResponse {
 	var reviewCount int64
 	s.db.Model(&ProductReview{}).Where("user_id = ? AND is_approved = ?", userID, true).Count(&reviewCount)
 
-	return &ReviewUserResponse{
+	response := &ReviewUserResponse{
 		ID:          user.ID,
 		FirstName:   user.FirstName,
 		LastName:    user.LastName,
@@ -455,6 +496,36 @@ func (s *ReviewService) getReviewUser(userID uint) *ReviewUserResponse {
 		IsVerified:  true, // You can implement user verification logic
 		ReviewCount: int(reviewCount),
 	}
+
+	s.applyReviewerNameFormat(response)
+
+	return response
+}
+
+// applyReviewerNameFormat masks reviewer names according to the configured display format
+func (s *ReviewService) applyReviewerNameFormat(user *ReviewUserResponse) {
+	format := ReviewerNameFull
+	if s.config != nil && s.config.Review.ReviewerNameFormat != "" {
+		format = s.config.Review.ReviewerNameFormat
+	}
+
+	switch format {
+	case ReviewerNameFirstOnly:
+		user.LastName = ""
+	case ReviewerNameFirstInitial:
+		if lastName := strings.TrimSpace(user.LastName); lastName != "" {
+			user.LastName = strings.ToUpper(string([]rune(lastName)[0])) + "."
+		}
+	case ReviewerNameAnonymous:
+		user.FirstName = ""
+		user.LastName = ""
+		user.Avatar = ""
+	}
+
+	user.DisplayName = strings.TrimSpace(user.FirstName + " " + user.LastName)
+	if user.DisplayName == "" {
+		user.DisplayName = "Anonymous"
+	}
 }
 
 func (s *ReviewService) getReviewProduct(productID uint) *ReviewProductResponse {
@@ -478,7 +549,7 @@ func (s *ReviewService) getReviewProduct(productID uint) *ReviewProductResponse
 	}
 }
 
-func (s *ReviewService) getReviewSummary(productID uint) ReviewSummary {
+func (s *ReviewService) computeReviewSummary(productID uint) ReviewSummary {
 	var summary ReviewSummary
 
 	// Get basic stats
@@ -514,10 +585,11 @@ func (s *ReviewService) getReviewSummary(productID uint) ReviewSummary {
 
 func (s *ReviewService) buildOrderClause(sortBy, sortOrder string) string {
 	validSortFields := map[string]bool{
-		"created_at":    true,
-		"updated_at":    true,
-		"rating":        true,
-		"helpful_count": true,
+		"created_at":        true,
+		"updated_at":        true,
+		"rating":            true,
+		"helpful_count":     true,
+		"helpful_count_raw": true,
 	}
 
 	if !validSortFields[sortBy] {
@@ -528,9 +600,92 @@ func (s *ReviewService) buildOrderClause(sortBy, sortOrder string) string {
 		sortOrder = "desc"
 	}
 
+	switch sortBy {
+	case "helpful_count":
+		if s.config.Review.HelpfulRanking != "raw" {
+			return fmt.Sprintf("%s %s, helpful_count %s, created_at %s", s.helpfulScoreSQL(), sortOrder, sortOrder, sortOrder)
+		}
+	case "helpful_count_raw":
+		sortBy = "helpful_count"
+	}
+
 	return fmt.Sprintf("%s %s", sortBy, sortOrder)
 }
 
+// helpfulScoreSQL returns the weighted "most helpful" score: helpful votes plus one,
+// so unvoted reviews still rank by recency, boosted for verified purchases and
+// halved for every HelpfulHalfLife of age
+func (s *ReviewService) helpfulScoreSQL() string {
+	halfLifeDays := s.config.Review.HelpfulHalfLife.Hours() / 24
+	if halfLifeDays <= 0 {
+		halfLifeDays = 90
+	}
+	verifiedWeight := s.config.Review.VerifiedWeight
+	if verifiedWeight <= 0 {
+		verifiedWeight = 1
+	}
+
+	return fmt.Sprintf(
+		"((helpful_count + 1) * (CASE WHEN is_verified THEN %g ELSE 1 END) * "+
+			"POWER(0.5, EXTRACT(EPOCH FROM (NOW() - created_at)) / 86400.0 / %g))",
+		verifiedWeight, halfLifeDays,
+	)
+}
+
+// checkAccountAge rejects reviewers whose account is younger than the configured
+// minimum age; admins are exempt
+func (s *ReviewService) checkAccountAge(userID uint) error {
+	minAge := s.config.Review.MinAccountAge
+	if minAge <= 0 {
+		return nil
+	}
+
+	var account struct {
+		CreatedAt time.Time
+		IsAdmin   bool
+	}
+	err := s.db.Table("users").Select("created_at, is_admin").
+		Where("id = ? AND deleted_at IS NULL", userID).Take(&account).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("user not found")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if account.IsAdmin {
+		return nil
+	}
+
+	if age := time.Since(account.CreatedAt); age < minAge {
+		return fmt.Errorf("%w: accounts can post reviews %s after sign-up, try again in %s",
+			ErrAccountTooNew, formatAccountAge(minAge), formatAccountAge(minAge-age))
+	}
+	return nil
+}
+
+// formatAccountAge renders a duration as whole days, or hours when under a day
+func formatAccountAge(d time.Duration) string {
+	if d >= 24*time.Hour {
+		days := int(math.Ceil(d.Hours() / 24))
+		if days == 1 {
+			return "1 day"
+		}
+		return fmt.Sprintf("%d days", days)
+	}
+	hours := int(math.Ceil(d.Hours()))
+	if hours <= 1 {
+		return "1 hour"
+	}
+	return fmt.Sprintf("%d hours", hours)
+}
+
+// autoApprove reports whether a new review is published without moderation; only
+// verified purchase reviews are, and only when enabled
+func (s *ReviewService) autoApprove(isVerified bool) bool {
+	return isVerified && s.config != nil && s.config.Review.AutoApproveVerified
+}
+
 func (s *ReviewService) isUserAdmin(userID uint) bool {
 	// Check if user has admin role
 	var count int64
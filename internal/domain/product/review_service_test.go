@@ -0,0 +1,45 @@
+// internal/domain/product/review_service_test.go
+package product
+
+import (
+	"testing"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+)
+
+func TestApplyReviewerNameFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		first     string
+		last      string
+		wantFirst string
+		wantLast  string
+		wantName  string
+	}{
+		{"full", ReviewerNameFull, "John", "Doe", "John", "Doe", "John Doe"},
+		{"unset defaults to full", "", "John", "Doe", "John", "Doe", "John Doe"},
+		{"first only", ReviewerNameFirstOnly, "John", "Doe", "John", "", "John"},
+		{"first initial", ReviewerNameFirstInitial, "John", " doe", "John", "D.", "John D."},
+		{"first initial of a non-ASCII name", ReviewerNameFirstInitial, "Zoë", "Ólafsdóttir", "Zoë", "Ó.", "Zoë Ó."},
+		{"first initial without a last name", ReviewerNameFirstInitial, "John", "", "John", "", "John"},
+		{"anonymous", ReviewerNameAnonymous, "John", "Doe", "", "", "Anonymous"},
+		{"no name at all", ReviewerNameFull, "", "", "", "", "Anonymous"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &ReviewService{config: &config.Config{Review: config.ReviewConfig{ReviewerNameFormat: tt.format}}}
+			user := &ReviewUserResponse{FirstName: tt.first, LastName: tt.last, Avatar: "/avatar.png"}
+			s.applyReviewerNameFormat(user)
+
+			if user.FirstName != tt.wantFirst || user.LastName != tt.wantLast || user.DisplayName != tt.wantName {
+				t.Errorf("got %q %q %q, want %q %q %q", user.FirstName, user.LastName, user.DisplayName,
+					tt.wantFirst, tt.wantLast, tt.wantName)
+			}
+			if wantAvatar := tt.format != ReviewerNameAnonymous; (user.Avatar != "") != wantAvatar {
+				t.Errorf("avatar = %q, kept = %v", user.Avatar, wantAvatar)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// internal/domain/product/review_summary_cache.go
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// reviewSummaryKey is the Redis key of a product's cached rating summary
+func reviewSummaryKey(productID uint) string {
+	return fmt.Sprintf("review_summary:%d", productID)
+}
+
+// getReviewSummary reads a product's rating summary through the Redis cache,
+// computing and caching it on a miss. Without Redis it is computed every time.
+func (s *ReviewService) getReviewSummary(productID uint) ReviewSummary {
+	if s.redisClient == nil {
+		return s.computeReviewSummary(productID)
+	}
+
+	ctx := context.Background()
+	key := reviewSummaryKey(productID)
+
+	if cached, err := s.redisClient.Get(ctx, key).Result(); err == nil {
+		var summary ReviewSummary
+		if err := json.Unmarshal([]byte(cached), &summary); err == nil {
+			return summary
+		}
+	}
+
+	summary := s.computeReviewSummary(productID)
+	if data, err := json.Marshal(summary); err == nil {
+		s.redisClient.Set(ctx, key, data, s.config.Review.SummaryCacheTTL)
+	}
+	return summary
+}
+
+// invalidateReviewSummary drops a product's cached summary after its reviews change.
+// A failed delete is only logged; the entry still expires with its TTL.
+func (s *ReviewService) invalidateReviewSummary(productID uint) {
+	if s.redisClient == nil {
+		return
+	}
+	if err := s.redisClient.Del(context.Background(), reviewSummaryKey(productID)).Err(); err != nil {
+		log.Printf("Failed to invalidate review summary for product %d: %v", productID, err)
+	}
+}
@@ -0,0 +1,55 @@
+// internal/domain/product/search.go
+package product
+
+import (
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SortByRelevance orders search results by full-text rank
+const SortByRelevance = "relevance"
+
+// maxSearchTerms caps the words used from a search query
+const maxSearchTerms = 8
+
+// buildTSQuery turns free text into a to_tsquery expression that matches every
+// word, each as a prefix so partial words typed into a search box still match.
+// Punctuation and tsquery operators are dropped; "" means no usable terms.
+func buildTSQuery(search string) string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > maxSearchTerms {
+		words = words[:maxSearchTerms]
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = word + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// applySearch filters query by the search text using the products.search_vector
+// column, returning the tsquery used for ranking. Text without searchable words
+// falls back to substring matching and returns "".
+func applySearch(query *gorm.DB, search string) (*gorm.DB, string) {
+	tsQuery := buildTSQuery(search)
+	if tsQuery == "" {
+		like := "%" + strings.ToLower(search) + "%"
+		return query.Where("LOWER(name) LIKE ? OR LOWER(description) LIKE ? OR LOWER(tags) LIKE ?", like, like, like), ""
+	}
+	return query.Where("search_vector @@ to_tsquery('english', ?)", tsQuery), tsQuery
+}
+
+// orderByRank orders results by how well they match tsQuery, best first
+func orderByRank(query *gorm.DB, tsQuery string) *gorm.DB {
+	return query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                "ts_rank(search_vector, to_tsquery('english', ?)) DESC, id DESC",
+		Vars:               []interface{}{tsQuery},
+		WithoutParentheses: true,
+	}})
+}
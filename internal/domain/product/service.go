@@ -6,21 +6,25 @@ import (
 	"strings"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"gorm.io/gorm"
 )
 
 // Service handles product business logic
 type Service struct {
-	db     *gorm.DB
-	config *config.Config
+	db          *gorm.DB
+	redisClient *redis.Client
+	config      *config.Config
 }
 
-// NewService creates a new product service
-func NewService(db *gorm.DB, cfg *config.Config) *Service {
+// NewService creates a new product service; redisClient may be nil, which
+// disables caching
+func NewService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *Service {
 	return &Service{
-		db:     db,
-		config: cfg,
+		db:          db,
+		redisClient: redisClient,
+		config:      cfg,
 	}
 }
 
@@ -31,37 +35,47 @@ type ProductListRequest struct {
 	CategoryID uint   `form:"category_id"`
 	BrandID    uint   `form:"brand_id"`
 	Search     string `form:"search"`
-	SortBy     string `form:"sort_by,default=created_at"`
+	SortBy     string `form:"sort_by,default=created_at"` // "relevance" ranks search matches
 	SortOrder  string `form:"sort_order,default=desc"`
 	MinPrice   int64  `form:"min_price"`
 	MaxPrice   int64  `form:"max_price"`
 	IsActive   *bool  `form:"is_active"`
 	IsFeatured *bool  `form:"is_featured"`
+
+	MinRating  float64 `form:"min_rating" binding:"omitempty,min=0,max=5"` // Average approved review rating
+	InStock    *bool   `form:"in_stock"`
+	WithFacets bool    `form:"-"` // Return facet counts with the page of results
 }
 
 // ProductCreateRequest represents product creation data
 type ProductCreateRequest struct {
-	SKU               string  `json:"sku" binding:"required"`
-	Name              string  `json:"name" binding:"required"`
-	Description       string  `json:"description"`
-	ShortDesc         string  `json:"short_description"`
-	Price             int64   `json:"price" binding:"required"`
-	ComparePrice      int64   `json:"compare_price"`
-	CostPrice         int64   `json:"cost_price"`
-	CategoryID        uint    `json:"category_id" binding:"required"`
-	BrandID           *uint   `json:"brand_id"`
-	Weight            float64 `json:"weight"`
-	Dimensions        string  `json:"dimensions"`
-	IsActive          bool    `json:"is_active"`
-	IsFeatured        bool    `json:"is_featured"`
-	IsDigital         bool    `json:"is_digital"`
-	RequiresShipping  bool    `json:"requires_shipping"`
-	TrackQuantity     bool    `json:"track_quantity"`
-	Quantity          int     `json:"quantity"`
-	LowStockThreshold int     `json:"low_stock_threshold"`
-	SeoTitle          string  `json:"seo_title"`
-	SeoDescription    string  `json:"seo_description"`
-	Tags              string  `json:"tags"`
+	SKU               string   `json:"sku" binding:"required"`
+	Name              string   `json:"name" binding:"required"`
+	Description       string   `json:"description"`
+	ShortDesc         string   `json:"short_description"`
+	Price             int64    `json:"price" binding:"required"`
+	ComparePrice      int64    `json:"compare_price"`
+	CostPrice         int64    `json:"cost_price"`
+	CategoryID        uint     `json:"category_id" binding:"required"`
+	BrandID           *uint    `json:"brand_id"`
+	Weight            float64  `json:"weight"`
+	Dimensions        string   `json:"dimensions"`
+	HSNCode           string   `json:"hsn_code"`
+	GSTRate           *float64 `json:"gst_rate"` // Omit to use the category's rate
+	IsActive          bool     `json:"is_active"`
+	IsFeatured        bool     `json:"is_featured"`
+	IsDigital         bool     `json:"is_digital"`
+	DigitalFileURL    string   `json:"digital_file_url"` // URL or storage path of the file buyers download
+	RequiresShipping  bool     `json:"requires_shipping"`
+	TrackQuantity     bool     `json:"track_quantity"`
+	Quantity          int      `json:"quantity"`
+	LowStockThreshold int      `json:"low_stock_threshold"`
+	SafetyStock       int      `json:"safety_stock" binding:"min=0"`
+	MaxCartQuantity   int      `json:"max_cart_quantity" binding:"min=0"`
+	FulfillmentSource string   `json:"fulfillment_source" binding:"omitempty,oneof=warehouse dropship"`
+	SeoTitle          string   `json:"seo_title"`
+	SeoDescription    string   `json:"seo_description"`
+	Tags              string   `json:"tags"`
 }
 
 // ProductUpdateRequest represents product update data
@@ -76,13 +90,20 @@ type ProductUpdateRequest struct {
 	BrandID           *uint    `json:"brand_id"`
 	Weight            *float64 `json:"weight"`
 	Dimensions        *string  `json:"dimensions"`
+	HSNCode           *string  `json:"hsn_code"`
+	GSTRate           *float64 `json:"gst_rate"`
+	ClearGSTRate      bool     `json:"clear_gst_rate"` // Go back to the category's rate
 	IsActive          *bool    `json:"is_active"`
 	IsFeatured        *bool    `json:"is_featured"`
 	IsDigital         *bool    `json:"is_digital"`
+	DigitalFileURL    *string  `json:"digital_file_url"`
 	RequiresShipping  *bool    `json:"requires_shipping"`
 	TrackQuantity     *bool    `json:"track_quantity"`
 	Quantity          *int     `json:"quantity"`
 	LowStockThreshold *int     `json:"low_stock_threshold"`
+	SafetyStock       *int     `json:"safety_stock" binding:"omitempty,min=0"`
+	MaxCartQuantity   *int     `json:"max_cart_quantity" binding:"omitempty,min=0"`
+	FulfillmentSource *string  `json:"fulfillment_source" binding:"omitempty,oneof=warehouse dropship"`
 	SeoTitle          *string  `json:"seo_title"`
 	SeoDescription    *string  `json:"seo_description"`
 	Tags              *string  `json:"tags"`
@@ -90,8 +111,9 @@ type ProductUpdateRequest struct {
 
 // ProductResponse represents product response with pagination
 type ProductResponse struct {
-	Products   []Product  `json:"products"`
-	Pagination Pagination `json:"pagination"`
+	Products   []Product      `json:"products"`
+	Pagination Pagination     `json:"pagination"`
+	Facets     *ProductFacets `json:"facets,omitempty"`
 }
 
 // Pagination represents pagination information
@@ -118,34 +140,7 @@ func (s *Service) GetProducts(req *ProductListRequest) (*ProductResponse, error)
 		})
 
 	// Apply filters
-	if req.CategoryID > 0 {
-		query = query.Where("category_id = ?", req.CategoryID)
-	}
-
-	if req.BrandID > 0 {
-		query = query.Where("brand_id = ?", req.BrandID)
-	}
-
-	if req.Search != "" {
-		search := "%" + strings.ToLower(req.Search) + "%"
-		query = query.Where("LOWER(name) LIKE ? OR LOWER(description) LIKE ? OR LOWER(tags) LIKE ?", search, search, search)
-	}
-
-	if req.MinPrice > 0 {
-		query = query.Where("price >= ?", req.MinPrice)
-	}
-
-	if req.MaxPrice > 0 {
-		query = query.Where("price <= ?", req.MaxPrice)
-	}
-
-	if req.IsActive != nil {
-		query = query.Where("is_active = ?", *req.IsActive)
-	}
-
-	if req.IsFeatured != nil {
-		query = query.Where("is_featured = ?", *req.IsFeatured)
-	}
+	query, tsQuery := applyProductFilters(query, req, "")
 
 	// Count total records
 	if err := query.Count(&total).Error; err != nil {
@@ -153,14 +148,19 @@ func (s *Service) GetProducts(req *ProductListRequest) (*ProductResponse, error)
 	}
 
 	// Apply sorting
-	orderClause := s.buildOrderClause(req.SortBy, req.SortOrder)
-	query = query.Order(orderClause)
+	if req.SortBy == SortByRelevance && tsQuery != "" {
+		query = orderByRank(query, tsQuery)
+	} else {
+		orderClause := s.buildOrderClause(req.SortBy, req.SortOrder)
+		query = query.Order(orderClause)
+	}
 
 	// Apply pagination
 	offset := (req.Page - 1) * req.Limit
 	if err := query.Offset(offset).Limit(req.Limit).Find(&products).Error; err != nil {
 		return nil, fmt.Errorf("failed to retrieve products: %w", err)
 	}
+	s.applyBadges(products)
 
 	// Calculate pagination info
 	totalPages := int((total + int64(req.Limit) - 1) / int64(req.Limit))
@@ -173,10 +173,72 @@ func (s *Service) GetProducts(req *ProductListRequest) (*ProductResponse, error)
 		HasPrev:    req.Page > 1,
 	}
 
-	return &ProductResponse{
+	response := &ProductResponse{
 		Products:   products,
 		Pagination: pagination,
-	}, nil
+	}
+
+	if req.WithFacets {
+		facets, err := s.getProductFacets(req)
+		if err != nil {
+			return nil, err
+		}
+		response.Facets = facets
+	}
+
+	return response, nil
+}
+
+// applyProductFilters applies the list filters to query, skipping the facet named
+// by exclude so that facet's counts reflect every other active filter. It returns
+// the tsquery used for search, if any.
+func applyProductFilters(query *gorm.DB, req *ProductListRequest, exclude string) (*gorm.DB, string) {
+	if req.CategoryID > 0 && exclude != facetCategory {
+		query = query.Where("category_id = ?", req.CategoryID)
+	}
+
+	if req.BrandID > 0 && exclude != facetBrand {
+		query = query.Where("brand_id = ?", req.BrandID)
+	}
+
+	var tsQuery string
+	if strings.TrimSpace(req.Search) != "" {
+		query, tsQuery = applySearch(query, req.Search)
+	}
+
+	if exclude != facetPrice {
+		if req.MinPrice > 0 {
+			query = query.Where("price >= ?", req.MinPrice)
+		}
+
+		if req.MaxPrice > 0 {
+			query = query.Where("price <= ?", req.MaxPrice)
+		}
+	}
+
+	if req.IsActive != nil {
+		query = query.Where("is_active = ?", *req.IsActive)
+	}
+
+	if req.IsFeatured != nil {
+		query = query.Where("is_featured = ?", *req.IsFeatured)
+	}
+
+	if req.MinRating > 0 && exclude != facetRating {
+		query = query.Where(`id IN (SELECT product_id FROM product_reviews
+			WHERE is_approved = ? AND deleted_at IS NULL
+			GROUP BY product_id HAVING AVG(rating) >= ?)`, true, req.MinRating)
+	}
+
+	if req.InStock != nil && exclude != facetInStock {
+		if *req.InStock {
+			query = query.Where(inStockCondition)
+		} else {
+			query = query.Where("NOT (" + inStockCondition + ")")
+		}
+	}
+
+	return query, tsQuery
 }
 
 // GetProduct retrieves a single product by ID
@@ -198,6 +260,7 @@ func (s *Service) GetProduct(id uint) (*Product, error) {
 		}
 		return nil, fmt.Errorf("failed to retrieve product: %w", result.Error)
 	}
+	s.applyBadge(&product)
 
 	return &product, nil
 }
@@ -221,6 +284,7 @@ func (s *Service) GetProductBySlug(slug string) (*Product, error) {
 		}
 		return nil, fmt.Errorf("failed to retrieve product: %w", result.Error)
 	}
+	s.applyBadge(&product)
 
 	return &product, nil
 }
@@ -233,6 +297,22 @@ func (s *Service) CreateProduct(req *ProductCreateRequest) (*Product, error) {
 		return nil, fmt.Errorf("product with SKU %s already exists", req.SKU)
 	}
 
+	if req.BrandID != nil {
+		if err := checkBrandExists(s.db, *req.BrandID); err != nil {
+			return nil, err
+		}
+	}
+
+	req.HSNCode = strings.TrimSpace(req.HSNCode)
+	if err := validateGST(req.HSNCode, req.GSTRate); err != nil {
+		return nil, err
+	}
+
+	priceWarning, err := s.checkPriceFloor(req.SKU, req.Price, req.CostPrice)
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate slug from name
 	slug := s.generateSlug(req.Name)
 
@@ -250,13 +330,19 @@ func (s *Service) CreateProduct(req *ProductCreateRequest) (*Product, error) {
 		BrandID:           req.BrandID,
 		Weight:            req.Weight,
 		Dimensions:        req.Dimensions,
+		HSNCode:           req.HSNCode,
+		GSTRate:           req.GSTRate,
 		IsActive:          req.IsActive,
 		IsFeatured:        req.IsFeatured,
 		IsDigital:         req.IsDigital,
+		DigitalFileURL:    strings.TrimSpace(req.DigitalFileURL),
 		RequiresShipping:  req.RequiresShipping,
 		TrackQuantity:     req.TrackQuantity,
 		Quantity:          req.Quantity,
 		LowStockThreshold: req.LowStockThreshold,
+		SafetyStock:       req.SafetyStock,
+		MaxCartQuantity:   req.MaxCartQuantity,
+		FulfillmentSource: req.FulfillmentSource,
 		SeoTitle:          req.SeoTitle,
 		SeoDescription:    req.SeoDescription,
 		Tags:              req.Tags,
@@ -268,6 +354,7 @@ func (s *Service) CreateProduct(req *ProductCreateRequest) (*Product, error) {
 
 	// Load relationships
 	s.db.Preload("Category").Preload("Brand").First(&product, product.ID)
+	product.PriceWarning = priceWarning
 
 	return &product, nil
 }
@@ -283,6 +370,23 @@ func (s *Service) UpdateProduct(id uint, req *ProductUpdateRequest) (*Product, e
 		return nil, fmt.Errorf("failed to find product: %w", result.Error)
 	}
 
+	// Validate the resulting price against the resulting cost
+	var priceWarning string
+	if req.Price != nil || req.CostPrice != nil {
+		price, costPrice := product.Price, product.CostPrice
+		if req.Price != nil {
+			price = *req.Price
+		}
+		if req.CostPrice != nil {
+			costPrice = *req.CostPrice
+		}
+		warning, err := s.checkPriceFloor(product.SKU, price, costPrice)
+		if err != nil {
+			return nil, err
+		}
+		priceWarning = warning
+	}
+
 	// Update fields
 	updates := make(map[string]interface{})
 
@@ -309,7 +413,15 @@ func (s *Service) UpdateProduct(id uint, req *ProductUpdateRequest) (*Product, e
 		updates["category_id"] = *req.CategoryID
 	}
 	if req.BrandID != nil {
-		updates["brand_id"] = *req.BrandID
+		// A brand_id of 0 removes the product from its brand
+		if *req.BrandID == 0 {
+			updates["brand_id"] = nil
+		} else {
+			if err := checkBrandExists(s.db, *req.BrandID); err != nil {
+				return nil, err
+			}
+			updates["brand_id"] = *req.BrandID
+		}
 	}
 	if req.Weight != nil {
 		updates["weight"] = *req.Weight
@@ -317,6 +429,21 @@ func (s *Service) UpdateProduct(id uint, req *ProductUpdateRequest) (*Product, e
 	if req.Dimensions != nil {
 		updates["dimensions"] = *req.Dimensions
 	}
+	if req.HSNCode != nil {
+		hsnCode := strings.TrimSpace(*req.HSNCode)
+		if err := validateGST(hsnCode, nil); err != nil {
+			return nil, err
+		}
+		updates["hsn_code"] = hsnCode
+	}
+	if req.ClearGSTRate {
+		updates["gst_rate"] = nil
+	} else if req.GSTRate != nil {
+		if err := validateGST("", req.GSTRate); err != nil {
+			return nil, err
+		}
+		updates["gst_rate"] = *req.GSTRate
+	}
 	if req.IsActive != nil {
 		updates["is_active"] = *req.IsActive
 	}
@@ -326,6 +453,9 @@ func (s *Service) UpdateProduct(id uint, req *ProductUpdateRequest) (*Product, e
 	if req.IsDigital != nil {
 		updates["is_digital"] = *req.IsDigital
 	}
+	if req.DigitalFileURL != nil {
+		updates["digital_file_url"] = strings.TrimSpace(*req.DigitalFileURL)
+	}
 	if req.RequiresShipping != nil {
 		updates["requires_shipping"] = *req.RequiresShipping
 	}
@@ -338,6 +468,15 @@ func (s *Service) UpdateProduct(id uint, req *ProductUpdateRequest) (*Product, e
 	if req.LowStockThreshold != nil {
 		updates["low_stock_threshold"] = *req.LowStockThreshold
 	}
+	if req.SafetyStock != nil {
+		updates["safety_stock"] = *req.SafetyStock
+	}
+	if req.MaxCartQuantity != nil {
+		updates["max_cart_quantity"] = *req.MaxCartQuantity
+	}
+	if req.FulfillmentSource != nil {
+		updates["fulfillment_source"] = *req.FulfillmentSource
+	}
 	if req.SeoTitle != nil {
 		updates["seo_title"] = *req.SeoTitle
 	}
@@ -354,6 +493,7 @@ func (s *Service) UpdateProduct(id uint, req *ProductUpdateRequest) (*Product, e
 
 	// Load updated product with relationships
 	s.db.Preload("Category").Preload("Brand").First(&product, product.ID)
+	product.PriceWarning = priceWarning
 
 	return &product, nil
 }
@@ -370,11 +510,16 @@ func (s *Service) DeleteProduct(id uint) error {
 	return nil
 }
 
-// UpdateInventory updates product inventory
-func (s *Service) UpdateInventory(productID uint, quantity int) error {
+// UpdateInventory updates product on-hand stock and, when given, its safety stock
+func (s *Service) UpdateInventory(productID uint, quantity int, safetyStock *int) error {
+	updates := map[string]interface{}{"quantity": quantity}
+	if safetyStock != nil {
+		updates["safety_stock"] = *safetyStock
+	}
+
 	result := s.db.Model(&Product{}).
 		Where("id = ? AND track_quantity = ?", productID, true).
-		Update("quantity", quantity)
+		Updates(updates)
 
 	if result.Error != nil {
 		return fmt.Errorf("failed to update inventory: %w", result.Error)
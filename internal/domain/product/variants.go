@@ -0,0 +1,249 @@
+// internal/domain/product/variants.go
+package product
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// maxGeneratedVariants caps the combinations one generate request may produce
+const maxGeneratedVariants = 200
+
+// ErrTooManyVariants is returned when the option values multiply out to more
+// combinations than can be generated at once
+var ErrTooManyVariants = errors.New("options produce too many variants")
+
+// GenerateVariantsRequest represents the request to generate a product's variant matrix
+type GenerateVariantsRequest struct {
+	Options        map[string][]string    `json:"options" binding:"required,min=1"` // e.g. {"size": ["S", "M"], "color": ["Red"]}
+	PriceOverrides []VariantPriceOverride `json:"price_overrides,omitempty"`
+}
+
+// VariantPriceOverride sets the price of one option combination instead of the product price
+type VariantPriceOverride struct {
+	Options map[string]string `json:"options" binding:"required"`
+	Price   int64             `json:"price" binding:"required,min=1"` // Price in cents
+}
+
+// GenerateVariantsResult represents the outcome of generating a variant matrix
+type GenerateVariantsResult struct {
+	Created  []ProductVariant `json:"created"`
+	Skipped  int              `json:"skipped"` // Combinations that already had a variant
+	Warnings []string         `json:"warnings,omitempty"`
+}
+
+// variantOption is one option name with its values, in the order given
+type variantOption struct {
+	Name   string
+	Values []string
+}
+
+// GenerateVariants creates a variant for every combination of the option values
+// that the product does not have yet. Each variant is named after its values and
+// given the product's SKU with the values appended; it takes the product's prices
+// unless a price override names that combination. Running it again with the same
+// options creates nothing.
+func (s *Service) GenerateVariants(productID uint, options map[string][]string, priceOverrides []VariantPriceOverride) (*GenerateVariantsResult, error) {
+	normalized, err := normalizeVariantOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]int64, len(priceOverrides))
+	for _, override := range priceOverrides {
+		overrides[variantKey(override.Options)] = override.Price
+	}
+
+	result := &GenerateVariantsResult{Created: []ProductVariant{}}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var product Product
+		if err := tx.First(&product, productID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrProductNotFound
+			}
+			return fmt.Errorf("failed to get product: %w", err)
+		}
+
+		var existing []ProductVariant
+		if err := tx.Where("product_id = ?", productID).Find(&existing).Error; err != nil {
+			return fmt.Errorf("failed to get variants: %w", err)
+		}
+		existingKeys := make(map[string]bool, len(existing))
+		for _, variant := range existing {
+			var values map[string]string
+			if err := json.Unmarshal([]byte(variant.Options), &values); err == nil {
+				existingKeys[variantKey(values)] = true
+			}
+		}
+
+		for _, combination := range variantCombinations(normalized) {
+			key := variantKey(combination)
+			if existingKeys[key] {
+				result.Skipped++
+				continue
+			}
+
+			price := product.Price
+			if override, ok := overrides[key]; ok {
+				price = override
+			}
+
+			sku, err := s.uniqueVariantSKU(tx, product.SKU, normalized, combination)
+			if err != nil {
+				return err
+			}
+
+			warning, err := s.checkPriceFloor(sku, price, product.CostPrice)
+			if err != nil {
+				return err
+			}
+			if warning != "" {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %s", sku, warning))
+			}
+
+			optionsJSON, err := json.Marshal(combination)
+			if err != nil {
+				return fmt.Errorf("failed to encode variant options: %w", err)
+			}
+
+			names := make([]string, len(normalized))
+			for i, option := range normalized {
+				names[i] = combination[option.Name]
+			}
+
+			variant := ProductVariant{
+				ProductID:    productID,
+				SKU:          sku,
+				Name:         strings.Join(names, " / "),
+				Price:        price,
+				ComparePrice: product.ComparePrice,
+				CostPrice:    product.CostPrice,
+				Weight:       product.Weight,
+				Options:      string(optionsJSON),
+				IsActive:     true,
+			}
+			if err := tx.Create(&variant).Error; err != nil {
+				return fmt.Errorf("failed to create variant %s: %w", sku, err)
+			}
+
+			existingKeys[key] = true
+			result.Created = append(result.Created, variant)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// normalizeVariantOptions trims option names and values, drops blanks and
+// duplicate values, and orders the options by name so SKUs are stable
+func normalizeVariantOptions(options map[string][]string) ([]variantOption, error) {
+	normalized := make([]variantOption, 0, len(options))
+	combinations := 1
+	for name, values := range options {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("option names cannot be empty")
+		}
+
+		seen := make(map[string]bool, len(values))
+		option := variantOption{Name: name}
+		for _, value := range values {
+			value = strings.TrimSpace(value)
+			if value == "" || seen[strings.ToLower(value)] {
+				continue
+			}
+			seen[strings.ToLower(value)] = true
+			option.Values = append(option.Values, value)
+		}
+		if len(option.Values) == 0 {
+			return nil, fmt.Errorf("option %q has no values", name)
+		}
+
+		combinations *= len(option.Values)
+		if combinations > maxGeneratedVariants {
+			return nil, fmt.Errorf("%w: at most %d can be generated at once", ErrTooManyVariants, maxGeneratedVariants)
+		}
+		normalized = append(normalized, option)
+	}
+
+	sort.Slice(normalized, func(i, j int) bool { return normalized[i].Name < normalized[j].Name })
+	return normalized, nil
+}
+
+// variantCombinations returns the cartesian product of the option values, varying
+// the last option fastest
+func variantCombinations(options []variantOption) []map[string]string {
+	combinations := []map[string]string{{}}
+	for _, option := range options {
+		next := make([]map[string]string, 0, len(combinations)*len(option.Values))
+		for _, combination := range combinations {
+			for _, value := range option.Values {
+				extended := make(map[string]string, len(combination)+1)
+				for k, v := range combination {
+					extended[k] = v
+				}
+				extended[option.Name] = value
+				next = append(next, extended)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// variantKey identifies an option combination regardless of key order or case
+func variantKey(values map[string]string) string {
+	parts := make([]string, 0, len(values))
+	for name, value := range values {
+		parts = append(parts, strings.ToLower(strings.TrimSpace(name))+"="+strings.ToLower(strings.TrimSpace(value)))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "|")
+}
+
+// uniqueVariantSKU builds a variant SKU from the product SKU and the option values,
+// e.g. TSHIRT-RED-M, adding a numeric suffix if another variant already uses it
+func (s *Service) uniqueVariantSKU(tx *gorm.DB, baseSKU string, options []variantOption, combination map[string]string) (string, error) {
+	sku := baseSKU
+	for _, option := range options {
+		sku += "-" + skuSegment(combination[option.Name])
+	}
+
+	candidate := sku
+	for i := 2; ; i++ {
+		var count int64
+		if err := tx.Unscoped().Model(&ProductVariant{}).Where("sku = ?", candidate).Count(&count).Error; err != nil {
+			return "", fmt.Errorf("failed to check variant SKU: %w", err)
+		}
+		if count == 0 {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", sku, i)
+	}
+}
+
+// skuSegment turns an option value into an uppercase SKU segment of letters and digits
+func skuSegment(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "X"
+	}
+	return b.String()
+}
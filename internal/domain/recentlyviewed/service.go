@@ -0,0 +1,164 @@
+// internal/domain/recentlyviewed/service.go
+package recentlyviewed
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"gorm.io/gorm"
+)
+
+// Service tracks recently viewed products in a capped Redis list per user or
+// guest session, most recent first
+type Service struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+	config      *config.Config
+}
+
+// NewService creates a new recently viewed service
+func NewService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *Service {
+	return &Service{
+		db:          db,
+		redisClient: redisClient,
+		config:      cfg,
+	}
+}
+
+// RecentlyViewedResponse represents recently viewed products, most recent first
+type RecentlyViewedResponse struct {
+	Products []product.Product `json:"products"`
+	Count    int               `json:"count"`
+	MaxItems int               `json:"max_items"`
+}
+
+// AddProduct records a product view, moving it to the front if already present
+// and trimming the oldest entries beyond the configured limit
+func (s *Service) AddProduct(userID *uint, sessionID string, productID uint) error {
+	var p product.Product
+	if err := s.db.Select("id").Where("id = ? AND is_active = ?", productID, true).First(&p).Error; err != nil {
+		return fmt.Errorf("product not found")
+	}
+
+	key, err := s.key(userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	member := strconv.FormatUint(uint64(productID), 10)
+	ctx := context.Background()
+	_, err = s.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LRem(ctx, key, 0, member)
+		pipe.LPush(ctx, key, member)
+		pipe.LTrim(ctx, key, 0, int64(s.maxItems()-1))
+		if s.config.Recent.TTL > 0 {
+			pipe.Expire(ctx, key, s.config.Recent.TTL)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record recently viewed product: %w", err)
+	}
+	return nil
+}
+
+// GetProductIDs returns the recently viewed product IDs, most recent first
+func (s *Service) GetProductIDs(userID *uint, sessionID string) ([]uint, error) {
+	productIDs := []uint{}
+
+	key, err := s.key(userID, sessionID)
+	if err != nil {
+		return productIDs, nil
+	}
+
+	members, err := s.redisClient.LRange(context.Background(), key, 0, int64(s.maxItems()-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve recently viewed products: %w", err)
+	}
+
+	seen := make(map[uint]bool, len(members))
+	for _, member := range members {
+		id, err := strconv.ParseUint(member, 10, 32)
+		if err != nil || seen[uint(id)] {
+			continue
+		}
+		seen[uint(id)] = true
+		productIDs = append(productIDs, uint(id))
+	}
+	return productIDs, nil
+}
+
+// GetRecentlyViewed returns the recently viewed products, most recent first.
+// Products that have since been deactivated or deleted are skipped.
+func (s *Service) GetRecentlyViewed(userID *uint, sessionID string) (*RecentlyViewedResponse, error) {
+	productIDs, err := s.GetProductIDs(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RecentlyViewedResponse{
+		Products: []product.Product{},
+		MaxItems: s.maxItems(),
+	}
+
+	if len(productIDs) == 0 {
+		return response, nil
+	}
+
+	var products []product.Product
+	err = s.db.Preload("Category").Preload("Brand").Preload("Images").
+		Where("id IN ? AND is_active = ?", productIDs, true).
+		Find(&products).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve recently viewed products: %w", err)
+	}
+
+	byID := make(map[uint]product.Product, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+	for _, id := range productIDs {
+		if p, ok := byID[id]; ok {
+			response.Products = append(response.Products, p)
+		}
+	}
+	response.Count = len(response.Products)
+
+	return response, nil
+}
+
+// Clear removes all recently viewed products
+func (s *Service) Clear(userID *uint, sessionID string) error {
+	key, err := s.key(userID, sessionID)
+	if err != nil {
+		return nil
+	}
+
+	if err := s.redisClient.Del(context.Background(), key).Err(); err != nil {
+		return fmt.Errorf("failed to clear recently viewed products: %w", err)
+	}
+	return nil
+}
+
+// Private helper methods
+
+func (s *Service) maxItems() int {
+	if s.config.Recent.MaxItems <= 0 {
+		return 10
+	}
+	return s.config.Recent.MaxItems
+}
+
+func (s *Service) key(userID *uint, sessionID string) (string, error) {
+	if userID != nil {
+		return fmt.Sprintf("recently_viewed:user:%d", *userID), nil
+	}
+	if sessionID == "" {
+		return "", fmt.Errorf("session is required")
+	}
+	return fmt.Sprintf("recently_viewed:session:%s", sessionID), nil
+}
@@ -0,0 +1,62 @@
+// internal/domain/returns/entity.go
+package returns
+
+import "time"
+
+// ReturnStatus is where a return request is in the return workflow
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested" // Awaiting admin review
+	ReturnStatusApproved  ReturnStatus = "approved"  // Accepted and restocked, awaiting refund
+	ReturnStatusRejected  ReturnStatus = "rejected"
+	ReturnStatusRefunding ReturnStatus = "refunding" // Refund sent to the payment provider
+	ReturnStatusRefunded  ReturnStatus = "refunded"
+)
+
+// Return reasons a customer can give
+const (
+	ReasonDamaged        = "damaged"
+	ReasonWrongItem      = "wrong_item"
+	ReasonNotAsDescribed = "not_as_described"
+	ReasonNoLongerNeeded = "no_longer_needed"
+	ReasonOther          = "other"
+)
+
+// Reasons are the accepted return reasons
+var Reasons = []string{ReasonDamaged, ReasonWrongItem, ReasonNotAsDescribed, ReasonNoLongerNeeded, ReasonOther}
+
+// ReturnRequest is a customer's request to send back items from a delivered order
+type ReturnRequest struct {
+	ID           uint         `gorm:"primaryKey" json:"id"`
+	OrderID      uint         `gorm:"not null;index" json:"order_id"`
+	UserID       uint         `gorm:"not null;index" json:"user_id"`
+	Status       ReturnStatus `gorm:"not null;default:'requested';index" json:"status"`
+	Reason       string       `gorm:"not null;size:50" json:"reason"`
+	Comments     string       `gorm:"type:text" json:"comments"`
+	AdminNotes   string       `gorm:"type:text" json:"admin_notes,omitempty"`
+	RefundAmount int64        `gorm:"not null" json:"refund_amount"` // Value of the returned items in cents
+	ProcessedBy  *uint        `gorm:"index" json:"processed_by,omitempty"`
+	ApprovedAt   *time.Time   `json:"approved_at,omitempty"`
+	RejectedAt   *time.Time   `json:"rejected_at,omitempty"`
+	RefundedAt   *time.Time   `json:"refunded_at,omitempty"`
+	Items        []ReturnItem `gorm:"foreignKey:ReturnRequestID;constraint:OnDelete:CASCADE" json:"items"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// ReturnItem is a quantity of one order item being returned
+type ReturnItem struct {
+	ID               uint   `gorm:"primaryKey" json:"id"`
+	ReturnRequestID  uint   `gorm:"not null;index" json:"return_request_id"`
+	OrderItemID      uint   `gorm:"not null;index" json:"order_item_id"`
+	ProductID        uint   `gorm:"not null" json:"product_id"`
+	ProductVariantID *uint  `json:"product_variant_id,omitempty"`
+	Name             string `gorm:"not null;size:255" json:"name"`
+	Quantity         int    `gorm:"not null" json:"quantity"`
+	Amount           int64  `gorm:"not null" json:"amount"` // Unit price times quantity, in cents
+}
+
+// TableName overrides
+func (ReturnRequest) TableName() string { return "return_requests" }
+func (ReturnItem) TableName() string    { return "return_items" }
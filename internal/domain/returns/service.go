@@ -0,0 +1,467 @@
+// internal/domain/returns/service.go
+package returns
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/inventory"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/payment"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Return errors
+var (
+	ErrReturnNotFound         = errors.New("return request not found")
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrOrderNotReturnable     = errors.New("only delivered orders can be returned")
+	ErrReturnWindowClosed     = errors.New("the return window for this order has closed")
+	ErrInvalidReturnReason    = errors.New("invalid return reason")
+	ErrInvalidReturnItems     = errors.New("invalid return items")
+	ErrInvalidReturnStatus    = errors.New("return request cannot be changed in its current status")
+	ErrNoRefundablePayment    = errors.New("order has no captured payment to refund")
+	ErrRefundExceedsPayment   = errors.New("refund would exceed the amount paid")
+	ErrReturnsDisabled        = errors.New("returns are not accepted")
+	ErrReturnQuantityExceeded = errors.New("return quantity exceeds the quantity left to return")
+)
+
+// Service handles return requests, from the customer's request through approval,
+// restocking and refunding the original payment
+type Service struct {
+	db        *gorm.DB
+	config    *config.Config
+	providers *payment.ProviderSelector
+}
+
+// NewService creates a new returns service
+func NewService(db *gorm.DB, cfg *config.Config) *Service {
+	return &Service{
+		db:        db,
+		config:    cfg,
+		providers: payment.NewProviderSelector(payment.NewRazorpayService(db, cfg), payment.NewStripeService(db, cfg), cfg),
+	}
+}
+
+// ReturnItemRequest is a quantity of one order item to return
+type ReturnItemRequest struct {
+	OrderItemID uint `json:"order_item_id" binding:"required"`
+	Quantity    int  `json:"quantity" binding:"required,min=1"`
+}
+
+// CreateReturnRequest represents a customer's return request
+type CreateReturnRequest struct {
+	Reason   string              `json:"reason" binding:"required"`
+	Comments string              `json:"comments" binding:"max=2000"`
+	Items    []ReturnItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// ReviewReturnRequest represents an admin approving or rejecting a return
+type ReviewReturnRequest struct {
+	Notes string `json:"notes" binding:"max=2000"`
+}
+
+// ListRequest represents return list filters
+type ListRequest struct {
+	Page    int          `form:"page,default=1"`
+	Limit   int          `form:"limit,default=20"`
+	Status  ReturnStatus `form:"status"`
+	OrderID uint         `form:"order_id"`
+}
+
+// ListResponse represents a page of return requests
+type ListResponse struct {
+	Returns    []ReturnRequest `json:"returns"`
+	Total      int64           `json:"total"`
+	Page       int             `json:"page"`
+	Limit      int             `json:"limit"`
+	TotalPages int             `json:"total_pages"`
+}
+
+// CreateReturn records a customer's request to return items from one of their
+// delivered orders. Requests must arrive within the return window, counted from
+// delivery, and can't return more of an item than is left after earlier returns.
+func (s *Service) CreateReturn(userID, orderID uint, req *CreateReturnRequest) (*ReturnRequest, error) {
+	if s.config.Order.ReturnWindowDays <= 0 {
+		return nil, ErrReturnsDisabled
+	}
+	if !isValidReason(req.Reason) {
+		return nil, fmt.Errorf("%w: must be one of %s", ErrInvalidReturnReason, strings.Join(Reasons, ", "))
+	}
+
+	var ord order.Order
+	err := s.db.Preload("Items").Where("id = ? AND user_id = ?", orderID, userID).First(&ord).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if (ord.Status != order.OrderStatusDelivered && ord.Status != order.OrderStatusCompleted) || ord.DeliveredAt == nil {
+		return nil, ErrOrderNotReturnable
+	}
+	windowEnds := ord.DeliveredAt.AddDate(0, 0, s.config.Order.ReturnWindowDays)
+	if time.Now().After(windowEnds) {
+		return nil, fmt.Errorf("%w on %s", ErrReturnWindowClosed, windowEnds.Format("January 2, 2006"))
+	}
+
+	returnRequest := &ReturnRequest{
+		OrderID:  ord.ID,
+		UserID:   userID,
+		Status:   ReturnStatusRequested,
+		Reason:   req.Reason,
+		Comments: strings.TrimSpace(req.Comments),
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the order so concurrent requests can't both return the same items
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&order.Order{}, ord.ID).Error; err != nil {
+			return fmt.Errorf("failed to lock order: %w", err)
+		}
+
+		returned, err := returnedQuantities(tx, ord.ID)
+		if err != nil {
+			return err
+		}
+
+		orderItems := make(map[uint]*order.OrderItem, len(ord.Items))
+		for i := range ord.Items {
+			orderItems[ord.Items[i].ID] = &ord.Items[i]
+		}
+
+		requested := map[uint]int{}
+		for _, itemReq := range req.Items {
+			item, ok := orderItems[itemReq.OrderItemID]
+			if !ok {
+				return fmt.Errorf("%w: item %d is not part of this order", ErrInvalidReturnItems, itemReq.OrderItemID)
+			}
+			requested[item.ID] += itemReq.Quantity
+			if requested[item.ID]+returned[item.ID] > item.Quantity {
+				return fmt.Errorf("%w: %s", ErrReturnQuantityExceeded, item.Name)
+			}
+
+			amount := item.Price * int64(itemReq.Quantity)
+			returnRequest.Items = append(returnRequest.Items, ReturnItem{
+				OrderItemID:      item.ID,
+				ProductID:        item.ProductID,
+				ProductVariantID: item.ProductVariantID,
+				Name:             item.Name,
+				Quantity:         itemReq.Quantity,
+				Amount:           amount,
+			})
+			returnRequest.RefundAmount += amount
+		}
+
+		if err := tx.Create(returnRequest).Error; err != nil {
+			return fmt.Errorf("failed to create return request: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return returnRequest, nil
+}
+
+// GetOrderReturns retrieves the return requests a customer has made for an order
+func (s *Service) GetOrderReturns(userID, orderID uint) ([]ReturnRequest, error) {
+	returnRequests := []ReturnRequest{}
+	err := s.db.Preload("Items").
+		Where("order_id = ? AND user_id = ?", orderID, userID).
+		Order("created_at DESC").
+		Find(&returnRequests).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get return requests: %w", err)
+	}
+	return returnRequests, nil
+}
+
+// ListReturns retrieves return requests for admins, newest first
+func (s *Service) ListReturns(req *ListRequest) (*ListResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 20
+	}
+
+	query := s.db.Model(&ReturnRequest{})
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+	if req.OrderID != 0 {
+		query = query.Where("order_id = ?", req.OrderID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count return requests: %w", err)
+	}
+
+	returnRequests := []ReturnRequest{}
+	offset := (req.Page - 1) * req.Limit
+	err := query.Preload("Items").
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(req.Limit).
+		Find(&returnRequests).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get return requests: %w", err)
+	}
+
+	return &ListResponse{
+		Returns:    returnRequests,
+		Total:      total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+	}, nil
+}
+
+// GetReturn retrieves a return request by ID
+func (s *Service) GetReturn(id uint) (*ReturnRequest, error) {
+	var returnRequest ReturnRequest
+	if err := s.db.Preload("Items").First(&returnRequest, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReturnNotFound
+		}
+		return nil, fmt.Errorf("failed to get return request: %w", err)
+	}
+	return &returnRequest, nil
+}
+
+// ApproveReturn accepts a requested return and puts its items back into stock, both
+// the catalog quantity and the warehouse stock they were sold from
+func (s *Service) ApproveReturn(id, adminID uint, req *ReviewReturnRequest) (*ReturnRequest, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		returnRequest, err := lockReturn(tx, id)
+		if err != nil {
+			return err
+		}
+		if returnRequest.Status != ReturnStatusRequested {
+			return fmt.Errorf("%w: %s", ErrInvalidReturnStatus, returnRequest.Status)
+		}
+
+		restocked := make([]inventory.ReturnedItem, 0, len(returnRequest.Items))
+		for _, item := range returnRequest.Items {
+			if item.ProductVariantID != nil {
+				err = tx.Model(&product.ProductVariant{}).
+					Where("id = ?", *item.ProductVariantID).
+					UpdateColumn("quantity", gorm.Expr("quantity + ?", item.Quantity)).Error
+			} else {
+				err = tx.Model(&product.Product{}).
+					Where("id = ?", item.ProductID).
+					UpdateColumn("quantity", gorm.Expr("quantity + ?", item.Quantity)).Error
+			}
+			if err != nil {
+				return fmt.Errorf("failed to restock %s: %w", item.Name, err)
+			}
+			restocked = append(restocked, inventory.ReturnedItem{OrderItemID: item.OrderItemID, Quantity: item.Quantity})
+		}
+		if err := inventory.RestockReturn(tx, returnRequest.ID, returnRequest.OrderID, restocked, adminID); err != nil {
+			return fmt.Errorf("failed to restock returned items: %w", err)
+		}
+
+		now := time.Now().UTC()
+		return tx.Model(returnRequest).Updates(map[string]interface{}{
+			"status":       ReturnStatusApproved,
+			"admin_notes":  strings.TrimSpace(req.Notes),
+			"processed_by": adminID,
+			"approved_at":  now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetReturn(id)
+}
+
+// RejectReturn declines a requested return; its items can be requested again
+func (s *Service) RejectReturn(id, adminID uint, req *ReviewReturnRequest) (*ReturnRequest, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		returnRequest, err := lockReturn(tx, id)
+		if err != nil {
+			return err
+		}
+		if returnRequest.Status != ReturnStatusRequested {
+			return fmt.Errorf("%w: %s", ErrInvalidReturnStatus, returnRequest.Status)
+		}
+
+		now := time.Now().UTC()
+		return tx.Model(returnRequest).Updates(map[string]interface{}{
+			"status":       ReturnStatusRejected,
+			"admin_notes":  strings.TrimSpace(req.Notes),
+			"processed_by": adminID,
+			"rejected_at":  now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetReturn(id)
+}
+
+// RefundReturn refunds an approved return's value to the order's captured payment
+// through its payment provider, Razorpay unless the order was paid with Stripe.
+// Refunds can't exceed what was paid. The return is claimed by moving it to
+// refunding first, so concurrent requests can't both refund it.
+func (s *Service) RefundReturn(id, adminID uint) (*ReturnRequest, error) {
+	var returnRequest *ReturnRequest
+	var ord order.Order
+	var captured order.Payment
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		returnRequest, err = lockReturn(tx, id)
+		if err != nil {
+			return err
+		}
+		if returnRequest.Status != ReturnStatusApproved {
+			return fmt.Errorf("%w: %s", ErrInvalidReturnStatus, returnRequest.Status)
+		}
+
+		if err := tx.First(&ord, returnRequest.OrderID).Error; err != nil {
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+		if err := lockRefundablePayment(tx, returnRequest, &captured); err != nil {
+			return err
+		}
+
+		return tx.Model(returnRequest).Update("status", ReturnStatusRefunding).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.refund(&ord, &captured, returnRequest); err != nil {
+		// Nothing was refunded, so the return can be retried
+		release := s.db.Model(&ReturnRequest{}).
+			Where("id = ? AND status = ?", id, ReturnStatusRefunding).
+			Update("status", ReturnStatusApproved)
+		if release.Error != nil {
+			log.Printf("Failed to release return %d after a failed refund: %v", id, release.Error)
+		}
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	err = s.db.Model(returnRequest).Updates(map[string]interface{}{
+		"status":       ReturnStatusRefunded,
+		"processed_by": adminID,
+		"refunded_at":  now,
+	}).Error
+	if err != nil {
+		// The money has already gone back, so this needs fixing by hand; the return
+		// stays in refunding so it can't be refunded again
+		log.Printf("Refunded return %d but failed to mark it refunded: %v", returnRequest.ID, err)
+		return nil, fmt.Errorf("refund issued but failed to update return request: %w", err)
+	}
+
+	return s.GetReturn(id)
+}
+
+// lockRefundablePayment loads the order's captured payment into captured, locking it
+// until the transaction ends, and checks the return's refund fits in what is left of
+// it. Refunds of other returns still in flight count as spent, since their payment
+// record is only updated once the provider accepts them.
+func lockRefundablePayment(tx *gorm.DB, returnRequest *ReturnRequest, captured *order.Payment) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND status IN ? AND payment_provider_id <> ''",
+			returnRequest.OrderID, []order.PaymentStatus{order.PaymentStatusPaid, order.PaymentStatusPartiallyRefunded}).
+		Order("created_at DESC").
+		First(captured).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNoRefundablePayment
+		}
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	var inFlight int64
+	err = tx.Model(&ReturnRequest{}).
+		Where("order_id = ? AND status = ? AND id <> ?", returnRequest.OrderID, ReturnStatusRefunding, returnRequest.ID).
+		Select("COALESCE(SUM(refund_amount), 0)").
+		Scan(&inFlight).Error
+	if err != nil {
+		return fmt.Errorf("failed to get refunds in progress: %w", err)
+	}
+
+	if captured.RefundedAmount+inFlight+returnRequest.RefundAmount > captured.Amount {
+		return ErrRefundExceedsPayment
+	}
+	return nil
+}
+
+// refund sends a claimed return's value back to the order's captured payment
+func (s *Service) refund(ord *order.Order, captured *order.Payment, returnRequest *ReturnRequest) error {
+	provider, err := s.providers.ForOrder(ord)
+	if err != nil {
+		return err
+	}
+	reason := fmt.Sprintf("Return %d for order %s", returnRequest.ID, ord.OrderNumber)
+	reference := fmt.Sprintf("return-%d", returnRequest.ID)
+	if err := provider.Refund(captured.PaymentProviderID, returnRequest.RefundAmount, reference, reason); err != nil {
+		return fmt.Errorf("failed to refund return: %w", err)
+	}
+	return nil
+}
+
+// lockReturn loads a return request with its items, locking it until the
+// transaction ends
+func lockReturn(tx *gorm.DB, id uint) (*ReturnRequest, error) {
+	var returnRequest ReturnRequest
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&returnRequest, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReturnNotFound
+		}
+		return nil, fmt.Errorf("failed to get return request: %w", err)
+	}
+	if err := tx.Where("return_request_id = ?", id).Find(&returnRequest.Items).Error; err != nil {
+		return nil, fmt.Errorf("failed to get return items: %w", err)
+	}
+	return &returnRequest, nil
+}
+
+// returnedQuantities sums, per order item, the quantity already in returns that
+// weren't rejected
+func returnedQuantities(tx *gorm.DB, orderID uint) (map[uint]int, error) {
+	var rows []struct {
+		OrderItemID uint
+		Quantity    int
+	}
+	err := tx.Model(&ReturnItem{}).
+		Select("return_items.order_item_id, SUM(return_items.quantity) as quantity").
+		Joins("JOIN return_requests ON return_requests.id = return_items.return_request_id").
+		Where("return_requests.order_id = ? AND return_requests.status <> ?", orderID, ReturnStatusRejected).
+		Group("return_items.order_item_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count returned items: %w", err)
+	}
+
+	returned := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		returned[row.OrderItemID] = row.Quantity
+	}
+	return returned, nil
+}
+
+// isValidReason reports whether reason is one of the accepted return reasons
+func isValidReason(reason string) bool {
+	for _, r := range Reasons {
+		if reason == r {
+			return true
+		}
+	}
+	return false
+}
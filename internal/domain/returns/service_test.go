@@ -0,0 +1,149 @@
+// internal/domain/returns/service_test.go
+package returns
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/inventory"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+	"gorm.io/gorm"
+)
+
+const testUserID = uint(7)
+
+func newTestService(t *testing.T) (*Service, *gorm.DB) {
+	t.Helper()
+	db := testutil.NewDB(t, &product.Category{}, &product.Brand{}, &product.Product{}, &product.ProductVariant{},
+		&order.Order{}, &order.OrderItem{}, &order.Payment{}, &inventory.InventoryItem{},
+		&inventory.StockReservation{}, &inventory.InventoryMovement{}, &ReturnRequest{}, &ReturnItem{})
+	cfg := &config.Config{}
+	cfg.Order.ReturnWindowDays = 30
+	return NewService(db, cfg), db
+}
+
+// createDeliveredOrder creates a paid order for two lamps at 2500 each, delivered at
+// deliveredAt, with the stock it took from a warehouse
+func createDeliveredOrder(t *testing.T, db *gorm.DB, deliveredAt time.Time) (*order.Order, *product.Product, *inventory.InventoryItem) {
+	t.Helper()
+	category := product.Category{Name: "General", Slug: "general"}
+	db.Create(&category)
+	prod := &product.Product{SKU: "LAMP-1", Name: "Lamp", Slug: "lamp", Price: 2500, CategoryID: category.ID,
+		IsActive: true, TrackQuantity: true, Quantity: 8}
+	if err := db.Create(prod).Error; err != nil {
+		t.Fatal(err)
+	}
+	item := &inventory.InventoryItem{ProductID: prod.ID, WarehouseID: 1, SKU: prod.SKU, Quantity: 8}
+	db.Create(item)
+
+	userID := testUserID
+	ord := &order.Order{OrderNumber: "ORD-1", UserID: &userID, Email: "buyer@example.com",
+		Status: order.OrderStatusDelivered, PaymentStatus: order.PaymentStatusPaid,
+		SubtotalAmount: 5000, TotalAmount: 5000, DeliveredAt: &deliveredAt,
+		Items: []order.OrderItem{{ProductID: prod.ID, SKU: prod.SKU, Name: prod.Name, Price: 2500, Quantity: 2, TotalPrice: 5000}}}
+	if err := db.Create(ord).Error; err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&inventory.StockReservation{InventoryItemID: item.ID, OrderID: ord.ID, OrderItemID: ord.Items[0].ID,
+		Quantity: 2, Status: inventory.ReservationStatusFulfilled})
+	db.Create(&order.Payment{OrderID: ord.ID, PaymentMethod: "razorpay", PaymentProviderID: "pay_123",
+		Amount: 5000, Status: order.PaymentStatusPaid})
+	return ord, prod, item
+}
+
+func returnOneLamp(ord *order.Order) *CreateReturnRequest {
+	return &CreateReturnRequest{Reason: ReasonDamaged, Items: []ReturnItemRequest{{OrderItemID: ord.Items[0].ID, Quantity: 1}}}
+}
+
+func TestCreateReturnWindow(t *testing.T) {
+	tests := []struct {
+		name        string
+		deliveredAt time.Time
+		wantErr     error
+	}{
+		{"inside the window", time.Now().AddDate(0, 0, -29), nil},
+		{"outside the window", time.Now().AddDate(0, 0, -31), ErrReturnWindowClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, db := newTestService(t)
+			ord, _, _ := createDeliveredOrder(t, db, tt.deliveredAt)
+
+			returnRequest, err := s.CreateReturn(testUserID, ord.ID, returnOneLamp(ord))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateReturn() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if returnRequest.Status != ReturnStatusRequested || returnRequest.RefundAmount != 2500 {
+				t.Errorf("return = %s for %d, want %s for 2500", returnRequest.Status, returnRequest.RefundAmount,
+					ReturnStatusRequested)
+			}
+		})
+	}
+}
+
+func TestApproveReturnRestocks(t *testing.T) {
+	s, db := newTestService(t)
+	ord, prod, item := createDeliveredOrder(t, db, time.Now().AddDate(0, 0, -1))
+
+	returnRequest, err := s.CreateReturn(testUserID, ord.ID, returnOneLamp(ord))
+	if err != nil {
+		t.Fatalf("CreateReturn() error = %v", err)
+	}
+	approved, err := s.ApproveReturn(returnRequest.ID, 1, &ReviewReturnRequest{})
+	if err != nil {
+		t.Fatalf("ApproveReturn() error = %v", err)
+	}
+	if approved.Status != ReturnStatusApproved {
+		t.Errorf("status = %s, want %s", approved.Status, ReturnStatusApproved)
+	}
+
+	var restockedProduct product.Product
+	db.First(&restockedProduct, prod.ID)
+	if restockedProduct.Quantity != 9 {
+		t.Errorf("product quantity = %d, want 9", restockedProduct.Quantity)
+	}
+	var restockedItem inventory.InventoryItem
+	db.First(&restockedItem, item.ID)
+	if restockedItem.Quantity != 9 {
+		t.Errorf("inventory quantity = %d, want 9", restockedItem.Quantity)
+	}
+	var movements int64
+	db.Model(&inventory.InventoryMovement{}).Where("reference_type = ? AND reference_id = ?", "return", returnRequest.ID).Count(&movements)
+	if movements != 1 {
+		t.Errorf("return movements = %d, want 1", movements)
+	}
+
+	// Approving again would restock twice
+	if _, err := s.ApproveReturn(returnRequest.ID, 1, &ReviewReturnRequest{}); !errors.Is(err, ErrInvalidReturnStatus) {
+		t.Errorf("second ApproveReturn() error = %v, want %v", err, ErrInvalidReturnStatus)
+	}
+}
+
+func TestRefundReturnCountsRefundsInProgress(t *testing.T) {
+	s, db := newTestService(t)
+	ord, _, _ := createDeliveredOrder(t, db, time.Now().AddDate(0, 0, -1))
+
+	// Another refund for the whole payment is already with the provider
+	db.Create(&ReturnRequest{OrderID: ord.ID, UserID: testUserID, Status: ReturnStatusRefunding,
+		Reason: ReasonOther, RefundAmount: 5000})
+	returnRequest := &ReturnRequest{OrderID: ord.ID, UserID: testUserID, Status: ReturnStatusApproved,
+		Reason: ReasonDamaged, RefundAmount: 2500}
+	db.Create(returnRequest)
+
+	if _, err := s.RefundReturn(returnRequest.ID, 1); !errors.Is(err, ErrRefundExceedsPayment) {
+		t.Fatalf("RefundReturn() error = %v, want %v", err, ErrRefundExceedsPayment)
+	}
+	var unchanged ReturnRequest
+	db.First(&unchanged, returnRequest.ID)
+	if unchanged.Status != ReturnStatusApproved {
+		t.Errorf("status = %s, want it left at %s", unchanged.Status, ReturnStatusApproved)
+	}
+}
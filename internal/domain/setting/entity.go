@@ -0,0 +1,77 @@
+// internal/domain/setting/entity.go
+package setting
+
+import (
+	"time"
+)
+
+// Setting keys
+const (
+	KeyGeneral  = "general"
+	KeyShipping = "shipping"
+	KeyPayment  = "payment"
+)
+
+// Payment method IDs that can be enabled in payment settings
+const (
+	PaymentMethodRazorpay = "razorpay"
+	PaymentMethodStripe   = "stripe"
+	PaymentMethodCOD      = "cod"
+	PaymentMethodWallet   = "wallet"
+)
+
+// Setting represents a store setting stored as a JSON value under a unique key
+type Setting struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Key       string    `gorm:"not null;size:100;uniqueIndex" json:"key"`
+	Value     string    `gorm:"type:jsonb;not null" json:"value"`
+	UpdatedBy uint      `json:"updated_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name for Setting
+func (Setting) TableName() string {
+	return "settings"
+}
+
+// GeneralSettings represents store-wide details shown to customers
+type GeneralSettings struct {
+	StoreName    string `json:"store_name" binding:"required,max=255"`
+	SupportEmail string `json:"support_email" binding:"required,email"`
+}
+
+// ShippingRates represents the price in cents of each shipping method
+type ShippingRates struct {
+	Standard int64 `json:"standard" binding:"min=0"`
+	Express  int64 `json:"express" binding:"min=0"`
+	SameDay  int64 `json:"same_day" binding:"min=0"`
+}
+
+// ShippingSettings represents shipping rates and the free shipping rule
+type ShippingSettings struct {
+	Rates                 ShippingRates `json:"rates"`
+	FreeShippingThreshold int64         `json:"free_shipping_threshold" binding:"min=0"` // Subtotal in cents for free standard shipping, 0 disables
+}
+
+// PaymentSettings represents the payment methods offered at checkout
+type PaymentSettings struct {
+	EnabledMethods []string `json:"enabled_methods" binding:"required,min=1"`
+}
+
+// AllSettings represents every store setting group
+type AllSettings struct {
+	General  GeneralSettings  `json:"general"`
+	Shipping ShippingSettings `json:"shipping"`
+	Payment  PaymentSettings  `json:"payment"`
+}
+
+// IsMethodEnabled reports whether a payment method is enabled
+func (p *PaymentSettings) IsMethodEnabled(method string) bool {
+	for _, m := range p.EnabledMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
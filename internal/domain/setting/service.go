@@ -0,0 +1,216 @@
+// internal/domain/setting/service.go
+package setting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// settingsCacheTTL bounds how long a cached setting is served if an update's
+// invalidation is ever missed
+const settingsCacheTTL = time.Hour
+
+// Service handles store settings, read through a Redis cache
+type Service struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+	config      *config.Config
+}
+
+// NewService creates a new setting service
+func NewService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *Service {
+	return &Service{
+		db:          db,
+		redisClient: redisClient,
+		config:      cfg,
+	}
+}
+
+// DefaultShippingSettings returns the shipping settings used until an admin saves their own
+func DefaultShippingSettings() ShippingSettings {
+	return ShippingSettings{
+		Rates: ShippingRates{
+			Standard: 999,  // ₹9.99
+			Express:  1999, // ₹19.99
+			SameDay:  2999, // ₹29.99
+		},
+		FreeShippingThreshold: 299900, // ₹2999
+	}
+}
+
+// DefaultPaymentSettings returns the payment settings used until an admin saves their own
+func DefaultPaymentSettings() PaymentSettings {
+	return PaymentSettings{
+		EnabledMethods: []string{PaymentMethodRazorpay, PaymentMethodStripe, PaymentMethodCOD, PaymentMethodWallet},
+	}
+}
+
+// GetAllSettings retrieves every setting group
+func (s *Service) GetAllSettings() (*AllSettings, error) {
+	general, err := s.GetGeneralSettings()
+	if err != nil {
+		return nil, err
+	}
+	shipping, err := s.GetShippingSettings()
+	if err != nil {
+		return nil, err
+	}
+	payment, err := s.GetPaymentSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AllSettings{
+		General:  *general,
+		Shipping: *shipping,
+		Payment:  *payment,
+	}, nil
+}
+
+// GetGeneralSettings retrieves the general store settings, defaulting to the company config
+func (s *Service) GetGeneralSettings() (*GeneralSettings, error) {
+	settings := GeneralSettings{
+		StoreName:    s.config.App.CompanyName,
+		SupportEmail: s.config.App.CompanyEmail,
+	}
+	if err := s.get(KeyGeneral, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// GetShippingSettings retrieves the shipping rates and free shipping threshold
+func (s *Service) GetShippingSettings() (*ShippingSettings, error) {
+	settings := DefaultShippingSettings()
+	if err := s.get(KeyShipping, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// GetPaymentSettings retrieves the payment methods enabled at checkout
+func (s *Service) GetPaymentSettings() (*PaymentSettings, error) {
+	settings := DefaultPaymentSettings()
+	if err := s.get(KeyPayment, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateGeneralSettings saves the general store settings
+func (s *Service) UpdateGeneralSettings(req *GeneralSettings, adminID uint) (*GeneralSettings, error) {
+	req.StoreName = strings.TrimSpace(req.StoreName)
+	req.SupportEmail = strings.ToLower(strings.TrimSpace(req.SupportEmail))
+	if req.StoreName == "" {
+		return nil, fmt.Errorf("store name is required")
+	}
+
+	if err := s.set(KeyGeneral, req, adminID); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// UpdateShippingSettings saves the shipping rates and free shipping threshold
+func (s *Service) UpdateShippingSettings(req *ShippingSettings, adminID uint) (*ShippingSettings, error) {
+	if err := s.set(KeyShipping, req, adminID); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// UpdatePaymentSettings saves the payment methods enabled at checkout
+func (s *Service) UpdatePaymentSettings(req *PaymentSettings, adminID uint) (*PaymentSettings, error) {
+	seen := make(map[string]bool, len(req.EnabledMethods))
+	methods := make([]string, 0, len(req.EnabledMethods))
+	for _, method := range req.EnabledMethods {
+		switch method {
+		case PaymentMethodRazorpay, PaymentMethodStripe, PaymentMethodCOD, PaymentMethodWallet:
+		default:
+			return nil, fmt.Errorf("unsupported payment method: %s", method)
+		}
+		if !seen[method] {
+			seen[method] = true
+			methods = append(methods, method)
+		}
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("at least one payment method must be enabled")
+	}
+	req.EnabledMethods = methods
+
+	if err := s.set(KeyPayment, req, adminID); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// get decodes the stored value for key over dest, which holds the defaults. Missing
+// settings leave the defaults in place, and fields absent from an older stored value
+// keep their default too.
+func (s *Service) get(key string, dest interface{}) error {
+	ctx := context.Background()
+
+	value, err := s.redisClient.Get(ctx, cacheKey(key)).Result()
+	if err != nil {
+		if err != redis.Nil {
+			log.Printf("Failed to read setting %s from cache: %v", key, err)
+		}
+
+		var record Setting
+		if err := s.db.Where("key = ?", key).First(&record).Error; err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("failed to get %s settings: %w", key, err)
+			}
+			record.Value = "{}"
+		}
+		value = record.Value
+
+		s.redisClient.Set(ctx, cacheKey(key), value, settingsCacheTTL)
+	}
+
+	if err := json.Unmarshal([]byte(value), dest); err != nil {
+		return fmt.Errorf("failed to decode %s settings: %w", key, err)
+	}
+	return nil
+}
+
+// set stores value under key and invalidates its cached copy
+func (s *Service) set(key string, value interface{}, adminID uint) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s settings: %w", key, err)
+	}
+
+	record := Setting{
+		Key:       key,
+		Value:     string(data),
+		UpdatedBy: adminID,
+	}
+	err = s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_by", "updated_at"}),
+	}).Create(&record).Error
+	if err != nil {
+		return fmt.Errorf("failed to save %s settings: %w", key, err)
+	}
+
+	if err := s.redisClient.Del(context.Background(), cacheKey(key)).Err(); err != nil {
+		log.Printf("Failed to invalidate cached setting %s: %v", key, err)
+	}
+	return nil
+}
+
+func cacheKey(key string) string {
+	return "settings:" + key
+}
@@ -0,0 +1,34 @@
+// internal/domain/shipping/delivery.go
+package shipping
+
+import "time"
+
+// DefaultLeadTimeDays is the lead time of new methods and of orders whose method no
+// longer exists
+const DefaultLeadTimeDays = 5
+
+// AddDeliveryDays returns the date a parcel shipped at from arrives after days. With
+// businessDays, Saturdays and Sundays are skipped, and a parcel shipped at the weekend
+// starts counting from Monday.
+func AddDeliveryDays(from time.Time, days int, businessDays bool) time.Time {
+	if !businessDays {
+		return from.AddDate(0, 0, days)
+	}
+
+	date := from
+	for isWeekend(date) {
+		date = date.AddDate(0, 0, 1)
+	}
+	for added := 0; added < days; {
+		date = date.AddDate(0, 0, 1)
+		if !isWeekend(date) {
+			added++
+		}
+	}
+	return date
+}
+
+// isWeekend reports whether t falls on a Saturday or Sunday
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
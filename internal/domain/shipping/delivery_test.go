@@ -0,0 +1,38 @@
+// internal/domain/shipping/delivery_test.go
+package shipping
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddDeliveryDays(t *testing.T) {
+	date := func(day int) time.Time { return time.Date(2026, time.October, day, 15, 4, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name         string
+		from         time.Time
+		days         int
+		businessDays bool
+		want         time.Time
+	}{
+		{"within the week", date(12), 3, true, date(15)},   // Monday to Thursday
+		{"over one weekend", date(16), 1, true, date(19)},  // Friday to Monday
+		{"over two weekends", date(16), 7, true, date(27)}, // Friday to Tuesday week
+		{"shipped on Saturday counts from Monday", date(17), 1, true, date(20)},
+		{"shipped on Sunday counts from Monday", date(18), 5, true, date(26)},
+		{"zero days at the weekend moves to Monday", date(17), 0, true, date(19)},
+		{"calendar days include weekends", date(16), 7, false, date(23)},
+		{"zero calendar days", date(17), 0, false, date(17)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AddDeliveryDays(tt.from, tt.days, tt.businessDays)
+			if !got.Equal(tt.want) {
+				t.Errorf("AddDeliveryDays(%s, %d) = %s, want %s",
+					tt.from.Format("Mon Jan 2"), tt.days, got.Format("Mon Jan 2"), tt.want.Format("Mon Jan 2"))
+			}
+		})
+	}
+}
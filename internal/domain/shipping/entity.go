@@ -0,0 +1,132 @@
+// internal/domain/shipping/entity.go
+package shipping
+
+import (
+	"strings"
+	"time"
+)
+
+// ShippingMethod is a delivery option offered at checkout, e.g. standard or express.
+// What it costs comes from its rates, so a method with no rate matching the
+// destination and parcel weight is not offered.
+type ShippingMethod struct {
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	Code          string         `gorm:"uniqueIndex;not null;size:50" json:"code"` // Sent as shipping_method when ordering
+	Name          string         `gorm:"not null;size:100" json:"name"`
+	Description   string         `gorm:"size:255" json:"description"`
+	Carrier       string         `gorm:"size:100" json:"carrier"`
+	EstimatedDays string         `gorm:"size:50" json:"estimated_days"`
+	LeadTimeDays  int            `gorm:"not null;default:5" json:"lead_time_days"` // Days from shipping to delivery, for estimated delivery dates
+	IsActive      bool           `gorm:"default:true;index" json:"is_active"`
+	SortOrder     int            `gorm:"default:0" json:"sort_order"`
+	Rates         []ShippingRate `gorm:"foreignKey:MethodID;constraint:OnDelete:CASCADE" json:"rates"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// ShippingZone is a named set of countries and states that rates can be limited to
+type ShippingZone struct {
+	ID        uint                 `gorm:"primaryKey" json:"id"`
+	Name      string               `gorm:"uniqueIndex;not null;size:100" json:"name"`
+	Regions   []ShippingZoneRegion `gorm:"foreignKey:ZoneID;constraint:OnDelete:CASCADE" json:"regions"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// ShippingZoneRegion is a country, or a single state within it, that belongs to a zone
+type ShippingZoneRegion struct {
+	ID      uint   `gorm:"primaryKey" json:"id"`
+	ZoneID  uint   `gorm:"not null;index" json:"zone_id"`
+	Country string `gorm:"not null;size:2" json:"country"` // ISO 3166-1 alpha-2
+	State   string `gorm:"size:100" json:"state"`          // Empty for the whole country
+}
+
+// ShippingRate prices a method for parcels in a weight tier, either within a zone or,
+// without one, anywhere no zone rate covers
+type ShippingRate struct {
+	ID                    uint          `gorm:"primaryKey" json:"id"`
+	MethodID              uint          `gorm:"not null;index" json:"method_id"`
+	ZoneID                *uint         `gorm:"index" json:"zone_id"`
+	Zone                  *ShippingZone `gorm:"foreignKey:ZoneID;constraint:OnDelete:RESTRICT" json:"zone,omitempty"`
+	MinWeight             float64       `gorm:"default:0" json:"min_weight"`              // Grams, inclusive
+	MaxWeight             float64       `gorm:"default:0" json:"max_weight"`              // Grams, exclusive; 0 for no upper limit
+	Price                 int64         `gorm:"not null" json:"price"`                    // In cents
+	FreeShippingThreshold int64         `gorm:"default:0" json:"free_shipping_threshold"` // Subtotal in cents from which the rate is free, 0 to never waive
+}
+
+// TableName overrides
+func (ShippingMethod) TableName() string     { return "shipping_methods" }
+func (ShippingZone) TableName() string       { return "shipping_zones" }
+func (ShippingZoneRegion) TableName() string { return "shipping_zone_regions" }
+func (ShippingRate) TableName() string       { return "shipping_rates" }
+
+// Destination is where a parcel is going
+type Destination struct {
+	Country string
+	State   string
+}
+
+// Zone match strengths; a rate for the destination's state beats one for its
+// country, which beats a rate without a zone
+const (
+	noMatch      = -1
+	matchDefault = 0
+	matchCountry = 1
+	matchState   = 2
+)
+
+// match returns how specifically the zone covers the destination
+func (z *ShippingZone) match(dest Destination) int {
+	best := noMatch
+	for _, region := range z.Regions {
+		if !strings.EqualFold(region.Country, dest.Country) {
+			continue
+		}
+		if region.State == "" {
+			best = max(best, matchCountry)
+		} else if strings.EqualFold(strings.TrimSpace(region.State), strings.TrimSpace(dest.State)) {
+			return matchState
+		}
+	}
+	return best
+}
+
+// coversWeight reports whether a parcel of the given weight falls in the rate's tier
+func (r *ShippingRate) coversWeight(weight float64) bool {
+	return weight >= r.MinWeight && (r.MaxWeight <= 0 || weight < r.MaxWeight)
+}
+
+// overlaps reports whether two rates for the same zone have overlapping weight tiers
+func (r *ShippingRate) overlaps(other *ShippingRate) bool {
+	if (r.ZoneID == nil) != (other.ZoneID == nil) || (r.ZoneID != nil && *r.ZoneID != *other.ZoneID) {
+		return false
+	}
+	startsBelow := func(weight float64, rate *ShippingRate) bool { return rate.MaxWeight <= 0 || weight < rate.MaxWeight }
+	return startsBelow(r.MinWeight, other) && startsBelow(other.MinWeight, r)
+}
+
+// MatchRate picks the rate for a destination and parcel weight: the most specific
+// zone wins, then the earliest rate. It returns nil when no rate applies. Rates must
+// have their zones and regions loaded.
+func MatchRate(rates []ShippingRate, dest Destination, weight float64) *ShippingRate {
+	var best *ShippingRate
+	bestScore := noMatch
+	for i := range rates {
+		rate := &rates[i]
+		if !rate.coversWeight(weight) {
+			continue
+		}
+
+		score := matchDefault
+		if rate.ZoneID != nil {
+			if rate.Zone == nil {
+				continue
+			}
+			score = rate.Zone.match(dest)
+		}
+		if score > bestScore {
+			best, bestScore = rate, score
+		}
+	}
+	return best
+}
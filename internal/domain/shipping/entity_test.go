@@ -0,0 +1,45 @@
+// internal/domain/shipping/entity_test.go
+package shipping
+
+import "testing"
+
+func TestMatchRate(t *testing.T) {
+	zoneID := func(id uint) *uint { return &id }
+	india := &ShippingZone{ID: 1, Regions: []ShippingZoneRegion{{Country: "IN"}}}
+	karnataka := &ShippingZone{ID: 2, Regions: []ShippingZoneRegion{{Country: "IN", State: "Karnataka"}}}
+
+	rates := []ShippingRate{
+		{ID: 1, Price: 900}, // Anywhere, any weight
+		{ID: 2, ZoneID: zoneID(1), Zone: india, Price: 500, MaxWeight: 5000},
+		{ID: 3, ZoneID: zoneID(1), Zone: india, Price: 800, MinWeight: 5000},
+		{ID: 4, ZoneID: zoneID(2), Zone: karnataka, Price: 300, MaxWeight: 2000},
+		{ID: 5, ZoneID: zoneID(2), Zone: karnataka, Price: 350, MaxWeight: 2000}, // Later duplicate
+		{ID: 6, ZoneID: zoneID(3), Price: 100},                                   // Zone not loaded
+	}
+
+	tests := []struct {
+		name   string
+		dest   Destination
+		weight float64
+		want   uint // 0 when no rate applies
+	}{
+		{"state zone beats country zone", Destination{"IN", " karnataka "}, 1000, 4},
+		{"state zone max weight is exclusive", Destination{"IN", "Karnataka"}, 2000, 2},
+		{"country zone for another state", Destination{"in", "Kerala"}, 1000, 2},
+		{"country zone min weight is inclusive", Destination{"IN", "Kerala"}, 5000, 3},
+		{"default rate outside every zone", Destination{"US", "California"}, 1000, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate := MatchRate(rates, tt.dest, tt.weight)
+			if rate == nil || rate.ID != tt.want {
+				t.Errorf("MatchRate() = %+v, want rate %d", rate, tt.want)
+			}
+		})
+	}
+
+	if rate := MatchRate(rates[1:5], Destination{"US", ""}, 1000); rate != nil {
+		t.Errorf("MatchRate() without a default rate = %+v, want nil", rate)
+	}
+}
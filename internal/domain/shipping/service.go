@@ -0,0 +1,532 @@
+// internal/domain/shipping/service.go
+package shipping
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/cart"
+	"github.com/your-org/ecommerce-backend/internal/domain/setting"
+	"gorm.io/gorm"
+)
+
+// Shipping errors
+var (
+	ErrMethodNotFound     = errors.New("shipping method not found")
+	ErrMethodCodeExists   = errors.New("shipping method code already exists")
+	ErrMethodUnavailable  = errors.New("shipping method not available for this address")
+	ErrZoneNotFound       = errors.New("shipping zone not found")
+	ErrZoneNameExists     = errors.New("shipping zone name already exists")
+	ErrZoneInUse          = errors.New("shipping zone is used by shipping rates")
+	ErrInvalidWeightRange = errors.New("max weight must be greater than min weight")
+	ErrOverlappingRates   = errors.New("rates for the same zone have overlapping weight tiers")
+)
+
+var methodCodePattern = regexp.MustCompile(`^[a-z0-9_-]{2,50}$`)
+
+// Service prices shipping from the configured methods, zones and rates
+type Service struct {
+	db       *gorm.DB
+	settings *setting.Service
+}
+
+// NewService creates a new shipping service; settingService may be nil, in which
+// case the fallback methods use the default shipping settings
+func NewService(db *gorm.DB, settingService *setting.Service) *Service {
+	return &Service{
+		db:       db,
+		settings: settingService,
+	}
+}
+
+// Quote is the price of a shipping method for a particular parcel and destination
+type Quote struct {
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Carrier       string `json:"carrier"`
+	EstimatedDays string `json:"estimated_days"`
+	Price         int64  `json:"price"` // In cents
+	FreeShipping  bool   `json:"free_shipping"`
+}
+
+// ShippingRateRequest is one rate of a shipping method
+type ShippingRateRequest struct {
+	ZoneID                *uint   `json:"zone_id"` // Omit for a rate that applies anywhere
+	MinWeight             float64 `json:"min_weight" binding:"min=0"`
+	MaxWeight             float64 `json:"max_weight" binding:"min=0"`
+	Price                 int64   `json:"price" binding:"min=0"`
+	FreeShippingThreshold int64   `json:"free_shipping_threshold" binding:"min=0"`
+}
+
+// ShippingMethodRequest represents shipping method data; rates replace the
+// method's existing rates
+type ShippingMethodRequest struct {
+	Code          string                `json:"code" binding:"required"`
+	Name          string                `json:"name" binding:"required,max=100"`
+	Description   string                `json:"description" binding:"max=255"`
+	Carrier       string                `json:"carrier" binding:"max=100"`
+	EstimatedDays string                `json:"estimated_days" binding:"max=50"`
+	LeadTimeDays  *int                  `json:"lead_time_days" binding:"omitempty,min=0,max=90"` // Defaults to DefaultLeadTimeDays
+	IsActive      *bool                 `json:"is_active"`
+	SortOrder     int                   `json:"sort_order"`
+	Rates         []ShippingRateRequest `json:"rates" binding:"required,min=1,dive"`
+}
+
+// ShippingZoneRegionRequest is one region of a shipping zone
+type ShippingZoneRegionRequest struct {
+	Country string `json:"country" binding:"required,len=2"`
+	State   string `json:"state" binding:"max=100"`
+}
+
+// ShippingZoneRequest represents shipping zone data; regions replace the zone's
+// existing regions
+type ShippingZoneRequest struct {
+	Name    string                      `json:"name" binding:"required,max=100"`
+	Regions []ShippingZoneRegionRequest `json:"regions" binding:"required,min=1,dive"`
+}
+
+// CartWeight is the total weight of the cart's items in grams, using the variant's
+// weight where it has one
+func CartWeight(items []cart.CartItemResponse) float64 {
+	var weight float64
+	for _, item := range items {
+		itemWeight := 0.0
+		if item.ProductVariant != nil && item.ProductVariant.Weight > 0 {
+			itemWeight = item.ProductVariant.Weight
+		} else if item.Product != nil {
+			itemWeight = item.Product.Weight
+		}
+		weight += itemWeight * float64(item.Quantity)
+	}
+	return weight
+}
+
+// Quote returns every method that can deliver a parcel of the given weight in grams
+// to the destination, priced for the order subtotal
+func (s *Service) Quote(dest Destination, weight float64, subtotal int64) ([]Quote, error) {
+	methods, err := s.activeMethods()
+	if err != nil {
+		return nil, err
+	}
+
+	quotes := []Quote{}
+	for i := range methods {
+		if quote := quoteMethod(&methods[i], dest, weight, subtotal); quote != nil {
+			quotes = append(quotes, *quote)
+		}
+	}
+	return quotes, nil
+}
+
+// QuoteMethod prices a single method by code, returning ErrMethodUnavailable when it
+// does not exist or has no rate for the destination and weight
+func (s *Service) QuoteMethod(code string, dest Destination, weight float64, subtotal int64) (*Quote, error) {
+	methods, err := s.activeMethods()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range methods {
+		if methods[i].Code != code {
+			continue
+		}
+		if quote := quoteMethod(&methods[i], dest, weight, subtotal); quote != nil {
+			return quote, nil
+		}
+		break
+	}
+	return nil, fmt.Errorf("%w: %s", ErrMethodUnavailable, code)
+}
+
+// quoteMethod prices a method for the parcel, or returns nil when no rate applies
+func quoteMethod(method *ShippingMethod, dest Destination, weight float64, subtotal int64) *Quote {
+	rate := MatchRate(method.Rates, dest, weight)
+	if rate == nil {
+		return nil
+	}
+
+	quote := &Quote{
+		Code:          method.Code,
+		Name:          method.Name,
+		Description:   method.Description,
+		Carrier:       method.Carrier,
+		EstimatedDays: method.EstimatedDays,
+		Price:         rate.Price,
+	}
+	if rate.FreeShippingThreshold > 0 && subtotal >= rate.FreeShippingThreshold {
+		quote.Price = 0
+		quote.FreeShipping = true
+		quote.Description = fmt.Sprintf("Free %s on orders over ₹%d", strings.ToLower(method.Name), rate.FreeShippingThreshold/100)
+	}
+	return quote
+}
+
+// activeMethods loads the active methods with their rates and zones. Until an admin
+// adds a method, standard and express shipping are priced from the shipping settings.
+func (s *Service) activeMethods() ([]ShippingMethod, error) {
+	var methods []ShippingMethod
+	err := s.db.Preload("Rates", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Rates.Zone.Regions").
+		Where("is_active = ?", true).
+		Order("sort_order ASC, id ASC").
+		Find(&methods).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load shipping methods: %w", err)
+	}
+
+	if len(methods) == 0 {
+		var count int64
+		if err := s.db.Model(&ShippingMethod{}).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to count shipping methods: %w", err)
+		}
+		if count == 0 {
+			return s.fallbackMethods(), nil
+		}
+	}
+	return methods, nil
+}
+
+// fallbackMethods are the methods offered before any are configured
+func (s *Service) fallbackMethods() []ShippingMethod {
+	shipping := setting.DefaultShippingSettings()
+	if s.settings != nil {
+		if current, err := s.settings.GetShippingSettings(); err != nil {
+			log.Printf("Failed to load shipping settings, using defaults: %v", err)
+		} else {
+			shipping = *current
+		}
+	}
+	return DefaultMethods(shipping)
+}
+
+// DefaultMethods builds the standard and express methods from the shipping settings.
+// They apply everywhere; location-specific methods such as same-day delivery need a zone.
+func DefaultMethods(settings setting.ShippingSettings) []ShippingMethod {
+	return []ShippingMethod{
+		{
+			Code:          "standard",
+			Name:          "Standard Shipping",
+			Description:   "Regular delivery in 5-7 business days",
+			Carrier:       "India Post",
+			EstimatedDays: "5-7 business days",
+			LeadTimeDays:  7,
+			IsActive:      true,
+			SortOrder:     1,
+			Rates: []ShippingRate{
+				{Price: settings.Rates.Standard, FreeShippingThreshold: settings.FreeShippingThreshold},
+			},
+		},
+		{
+			Code:          "express",
+			Name:          "Express Shipping",
+			Description:   "Fast delivery in 2-3 business days",
+			Carrier:       "BlueDart",
+			EstimatedDays: "2-3 business days",
+			LeadTimeDays:  3,
+			IsActive:      true,
+			SortOrder:     2,
+			Rates: []ShippingRate{
+				{Price: settings.Rates.Express},
+			},
+		},
+	}
+}
+
+// LeadTime returns how many days the method with the code takes to deliver once
+// shipped. Inactive methods count, since orders keep the method they were placed with;
+// codes that no longer exist get DefaultLeadTimeDays.
+func (s *Service) LeadTime(code string) (int, error) {
+	var method ShippingMethod
+	err := s.db.Select("lead_time_days").Where("code = ?", code).First(&method).Error
+	if err == nil {
+		return method.LeadTimeDays, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, fmt.Errorf("failed to get shipping method lead time: %w", err)
+	}
+
+	for _, fallback := range DefaultMethods(setting.DefaultShippingSettings()) {
+		if fallback.Code == code {
+			return fallback.LeadTimeDays, nil
+		}
+	}
+	return DefaultLeadTimeDays, nil
+}
+
+// ListMethods retrieves every shipping method, including inactive ones
+func (s *Service) ListMethods() ([]ShippingMethod, error) {
+	methods := []ShippingMethod{}
+	err := s.db.Preload("Rates", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Rates.Zone").
+		Order("sort_order ASC, id ASC").
+		Find(&methods).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipping methods: %w", err)
+	}
+	return methods, nil
+}
+
+// GetMethod retrieves a shipping method by ID
+func (s *Service) GetMethod(id uint) (*ShippingMethod, error) {
+	var method ShippingMethod
+	err := s.db.Preload("Rates", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Rates.Zone").
+		First(&method, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMethodNotFound
+		}
+		return nil, fmt.Errorf("failed to get shipping method: %w", err)
+	}
+	return &method, nil
+}
+
+// CreateMethod creates a shipping method with its rates
+func (s *Service) CreateMethod(req *ShippingMethodRequest) (*ShippingMethod, error) {
+	method := &ShippingMethod{IsActive: true, LeadTimeDays: DefaultLeadTimeDays}
+	if err := s.applyMethodRequest(method, req); err != nil {
+		return nil, err
+	}
+
+	// GORM skips zero values on create and reads the column defaults back into the
+	// struct, so an inactive or same-day method needs an explicit update
+	isActive, leadTimeDays := method.IsActive, method.LeadTimeDays
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(method).Error; err != nil {
+			return fmt.Errorf("failed to create shipping method: %w", err)
+		}
+		if !isActive {
+			if err := tx.Model(method).Update("is_active", false).Error; err != nil {
+				return fmt.Errorf("failed to create shipping method: %w", err)
+			}
+		}
+		if leadTimeDays == 0 {
+			if err := tx.Model(method).Update("lead_time_days", 0).Error; err != nil {
+				return fmt.Errorf("failed to create shipping method: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetMethod(method.ID)
+}
+
+// UpdateMethod replaces a shipping method's details and rates
+func (s *Service) UpdateMethod(id uint, req *ShippingMethodRequest) (*ShippingMethod, error) {
+	method, err := s.GetMethod(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyMethodRequest(method, req); err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("method_id = ?", method.ID).Delete(&ShippingRate{}).Error; err != nil {
+			return fmt.Errorf("failed to replace shipping rates: %w", err)
+		}
+		for i := range method.Rates {
+			method.Rates[i].MethodID = method.ID
+		}
+		if err := tx.Create(&method.Rates).Error; err != nil {
+			return fmt.Errorf("failed to replace shipping rates: %w", err)
+		}
+		if err := tx.Omit("Rates").Save(method).Error; err != nil {
+			return fmt.Errorf("failed to update shipping method: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetMethod(method.ID)
+}
+
+// DeleteMethod deletes a shipping method and its rates. Orders keep the method's code.
+func (s *Service) DeleteMethod(id uint) error {
+	result := s.db.Delete(&ShippingMethod{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete shipping method: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrMethodNotFound
+	}
+	return nil
+}
+
+// applyMethodRequest validates the request and copies it onto the method
+func (s *Service) applyMethodRequest(method *ShippingMethod, req *ShippingMethodRequest) error {
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	if !methodCodePattern.MatchString(code) {
+		return fmt.Errorf("code must be 2-50 lowercase letters, digits, dashes or underscores")
+	}
+
+	var existing int64
+	if err := s.db.Model(&ShippingMethod{}).Where("code = ? AND id <> ?", code, method.ID).Count(&existing).Error; err != nil {
+		return fmt.Errorf("failed to check shipping method code: %w", err)
+	}
+	if existing > 0 {
+		return ErrMethodCodeExists
+	}
+
+	rates := make([]ShippingRate, len(req.Rates))
+	for i, r := range req.Rates {
+		if r.MaxWeight > 0 && r.MaxWeight <= r.MinWeight {
+			return ErrInvalidWeightRange
+		}
+		if r.ZoneID != nil {
+			if err := s.db.First(&ShippingZone{}, *r.ZoneID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return fmt.Errorf("rate references unknown shipping zone %d", *r.ZoneID)
+				}
+				return fmt.Errorf("failed to get shipping zone: %w", err)
+			}
+		}
+		rates[i] = ShippingRate{
+			MethodID:              method.ID,
+			ZoneID:                r.ZoneID,
+			MinWeight:             r.MinWeight,
+			MaxWeight:             r.MaxWeight,
+			Price:                 r.Price,
+			FreeShippingThreshold: r.FreeShippingThreshold,
+		}
+	}
+	for i := range rates {
+		for j := i + 1; j < len(rates); j++ {
+			if rates[i].overlaps(&rates[j]) {
+				return ErrOverlappingRates
+			}
+		}
+	}
+
+	method.Code = code
+	method.Name = strings.TrimSpace(req.Name)
+	method.Description = strings.TrimSpace(req.Description)
+	method.Carrier = strings.TrimSpace(req.Carrier)
+	method.EstimatedDays = strings.TrimSpace(req.EstimatedDays)
+	method.SortOrder = req.SortOrder
+	if req.IsActive != nil {
+		method.IsActive = *req.IsActive
+	}
+	if req.LeadTimeDays != nil {
+		method.LeadTimeDays = *req.LeadTimeDays
+	}
+	method.Rates = rates
+	return nil
+}
+
+// ListZones retrieves every shipping zone with its regions
+func (s *Service) ListZones() ([]ShippingZone, error) {
+	zones := []ShippingZone{}
+	if err := s.db.Preload("Regions").Order("name ASC").Find(&zones).Error; err != nil {
+		return nil, fmt.Errorf("failed to get shipping zones: %w", err)
+	}
+	return zones, nil
+}
+
+// GetZone retrieves a shipping zone by ID
+func (s *Service) GetZone(id uint) (*ShippingZone, error) {
+	var zone ShippingZone
+	if err := s.db.Preload("Regions").First(&zone, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrZoneNotFound
+		}
+		return nil, fmt.Errorf("failed to get shipping zone: %w", err)
+	}
+	return &zone, nil
+}
+
+// CreateZone creates a shipping zone with its regions
+func (s *Service) CreateZone(req *ShippingZoneRequest) (*ShippingZone, error) {
+	zone := &ShippingZone{}
+	if err := s.applyZoneRequest(zone, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(zone).Error; err != nil {
+		return nil, fmt.Errorf("failed to create shipping zone: %w", err)
+	}
+	return zone, nil
+}
+
+// UpdateZone replaces a shipping zone's name and regions
+func (s *Service) UpdateZone(id uint, req *ShippingZoneRequest) (*ShippingZone, error) {
+	zone, err := s.GetZone(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyZoneRequest(zone, req); err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("zone_id = ?", zone.ID).Delete(&ShippingZoneRegion{}).Error; err != nil {
+			return fmt.Errorf("failed to replace shipping zone regions: %w", err)
+		}
+		if err := tx.Create(&zone.Regions).Error; err != nil {
+			return fmt.Errorf("failed to replace shipping zone regions: %w", err)
+		}
+		if err := tx.Omit("Regions").Save(zone).Error; err != nil {
+			return fmt.Errorf("failed to update shipping zone: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetZone(zone.ID)
+}
+
+// DeleteZone deletes a shipping zone that no rate uses
+func (s *Service) DeleteZone(id uint) error {
+	if _, err := s.GetZone(id); err != nil {
+		return err
+	}
+
+	var rateCount int64
+	if err := s.db.Model(&ShippingRate{}).Where("zone_id = ?", id).Count(&rateCount).Error; err != nil {
+		return fmt.Errorf("failed to check shipping zone usage: %w", err)
+	}
+	if rateCount > 0 {
+		return ErrZoneInUse
+	}
+
+	if err := s.db.Delete(&ShippingZone{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete shipping zone: %w", err)
+	}
+	return nil
+}
+
+// applyZoneRequest validates the request and copies it onto the zone
+func (s *Service) applyZoneRequest(zone *ShippingZone, req *ShippingZoneRequest) error {
+	name := strings.TrimSpace(req.Name)
+	var existing int64
+	if err := s.db.Model(&ShippingZone{}).Where("LOWER(name) = LOWER(?) AND id <> ?", name, zone.ID).Count(&existing).Error; err != nil {
+		return fmt.Errorf("failed to check shipping zone name: %w", err)
+	}
+	if existing > 0 {
+		return ErrZoneNameExists
+	}
+
+	regions := make([]ShippingZoneRegion, len(req.Regions))
+	for i, r := range req.Regions {
+		regions[i] = ShippingZoneRegion{
+			ZoneID:  zone.ID,
+			Country: strings.ToUpper(strings.TrimSpace(r.Country)),
+			State:   strings.TrimSpace(r.State),
+		}
+	}
+
+	zone.Name = name
+	zone.Regions = regions
+	return nil
+}
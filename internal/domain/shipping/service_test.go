@@ -0,0 +1,32 @@
+// internal/domain/shipping/service_test.go
+package shipping
+
+import (
+	"testing"
+
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+func TestCreateMethodKeepsZeroValues(t *testing.T) {
+	db := testutil.NewDB(t, &ShippingMethod{}, &ShippingRate{}, &ShippingZone{}, &ShippingZoneRegion{})
+	s := NewService(db, nil)
+	inactive, sameDay := false, 0
+
+	method, err := s.CreateMethod(&ShippingMethodRequest{Code: "courier", Name: "Same-day courier",
+		LeadTimeDays: &sameDay, IsActive: &inactive, Rates: []ShippingRateRequest{{Price: 900}}})
+	if err != nil {
+		t.Fatalf("CreateMethod() error = %v", err)
+	}
+	if method.IsActive || method.LeadTimeDays != 0 {
+		t.Errorf("method = active %v, lead time %d, want inactive same-day", method.IsActive, method.LeadTimeDays)
+	}
+
+	defaults, err := s.CreateMethod(&ShippingMethodRequest{Code: "standard", Name: "Standard",
+		Rates: []ShippingRateRequest{{Price: 500}}})
+	if err != nil {
+		t.Fatalf("CreateMethod() error = %v", err)
+	}
+	if !defaults.IsActive || defaults.LeadTimeDays != DefaultLeadTimeDays {
+		t.Errorf("method = active %v, lead time %d, want active with %d days", defaults.IsActive, defaults.LeadTimeDays, DefaultLeadTimeDays)
+	}
+}
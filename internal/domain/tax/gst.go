@@ -0,0 +1,187 @@
+// internal/domain/tax/gst.go
+package tax
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// StandardGSTRate is the GST rate, in percent, applied to most products
+const StandardGSTRate = 18.0
+
+// GSTRates are the GST slabs, in percent, a product or category can be taxed at
+var GSTRates = []float64{0, 0.25, 3, 5, 12, 18, 28}
+
+// GST components shown on a tax breakdown
+const (
+	ComponentCGST = "CGST" // Central GST, half the rate on same-state deliveries
+	ComponentSGST = "SGST" // State GST, the other half
+	ComponentIGST = "IGST" // Integrated GST, the full rate on inter-state deliveries
+)
+
+// CalculateGST returns the GST rate and amount (in cents) for an amount delivered
+// to country. Only deliveries within India are taxed.
+func CalculateGST(amount int64, country string) (float64, int64) {
+	if country != "IN" {
+		return 0, 0
+	}
+	return StandardGSTRate, int64(float64(amount) * StandardGSTRate / 100)
+}
+
+// IsValidGSTRate checks that rate is one of the GST slabs
+func IsValidGSTRate(rate float64) bool {
+	for _, slab := range GSTRates {
+		if rate == slab {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveRate returns the first rate that is set, e.g. a product's own rate and then
+// its category's, falling back to the standard rate
+func ResolveRate(rates ...*float64) float64 {
+	for _, rate := range rates {
+		if rate != nil {
+			return *rate
+		}
+	}
+	return StandardGSTRate
+}
+
+// IsInterState reports whether a delivery crosses state lines. Either state being
+// unknown counts as same-state.
+func IsInterState(storeState, buyerState string) bool {
+	storeState, buyerState = strings.TrimSpace(storeState), strings.TrimSpace(buyerState)
+	if storeState == "" || buyerState == "" {
+		return false
+	}
+	return !strings.EqualFold(storeState, buyerState)
+}
+
+// LineItem is one taxable line of an order
+type LineItem struct {
+	ProductID        uint
+	ProductVariantID *uint
+	Name             string
+	HSNCode          string
+	Rate             float64 // GST rate in percent
+	Amount           int64   // Taxable value in cents
+}
+
+// LineTax is the GST charged on one line
+type LineTax struct {
+	ProductID        uint    `json:"product_id"`
+	ProductVariantID *uint   `json:"product_variant_id,omitempty"`
+	Name             string  `json:"name"`
+	HSNCode          string  `json:"hsn_code,omitempty"`
+	TaxableAmount    int64   `json:"taxable_amount"`
+	Rate             float64 `json:"rate"`
+	CGST             int64   `json:"cgst"`
+	SGST             int64   `json:"sgst"`
+	IGST             int64   `json:"igst"`
+	TaxAmount        int64   `json:"tax_amount"`
+}
+
+// Component is the total of one GST component at one rate, e.g. CGST at 9%
+type Component struct {
+	Type   string  `json:"type"`
+	Rate   float64 `json:"rate"`
+	Amount int64   `json:"amount"`
+}
+
+// Result is the GST on a set of lines
+type Result struct {
+	TaxableAmount int64       `json:"taxable_amount"`
+	TaxAmount     int64       `json:"tax_amount"`
+	InterState    bool        `json:"inter_state"`
+	Items         []LineTax   `json:"items"`
+	Components    []Component `json:"components"`
+}
+
+// EffectiveRate is the overall rate, in percent, across lines taxed at different rates
+func (r *Result) EffectiveRate() float64 {
+	if r.TaxableAmount == 0 {
+		return 0
+	}
+	return math.Round(float64(r.TaxAmount)/float64(r.TaxableAmount)*10000) / 100
+}
+
+// Split returns the total CGST, SGST and IGST across all lines
+func (r *Result) Split() (cgst, sgst, igst int64) {
+	for _, item := range r.Items {
+		cgst += item.CGST
+		sgst += item.SGST
+		igst += item.IGST
+	}
+	return cgst, sgst, igst
+}
+
+// SplitAmount divides an amount of GST already charged into CGST and SGST, or
+// returns it all as IGST for an inter-state delivery
+func SplitAmount(taxAmount int64, interState bool) (cgst, sgst, igst int64) {
+	if interState {
+		return 0, 0, taxAmount
+	}
+	cgst = taxAmount / 2
+	return cgst, taxAmount - cgst, 0
+}
+
+// CalculateItemsGST taxes each line at its own rate and sums them. Same-state
+// deliveries split each line's tax evenly into CGST and SGST; inter-state deliveries
+// charge IGST. Only deliveries within India are taxed.
+func CalculateItemsGST(items []LineItem, country, storeState, buyerState string) *Result {
+	taxed := country == "IN"
+	result := &Result{
+		InterState: taxed && IsInterState(storeState, buyerState),
+		Items:      make([]LineTax, 0, len(items)),
+		Components: []Component{},
+	}
+
+	type componentKey struct {
+		kind string
+		rate float64
+	}
+	totals := map[componentKey]int64{}
+
+	for _, item := range items {
+		line := LineTax{
+			ProductID:        item.ProductID,
+			ProductVariantID: item.ProductVariantID,
+			Name:             item.Name,
+			HSNCode:          item.HSNCode,
+			TaxableAmount:    item.Amount,
+		}
+		if taxed {
+			line.Rate = item.Rate
+			line.TaxAmount = int64(math.Round(float64(item.Amount) * item.Rate / 100))
+			if result.InterState {
+				line.IGST = line.TaxAmount
+				totals[componentKey{ComponentIGST, item.Rate}] += line.IGST
+			} else {
+				line.CGST = line.TaxAmount / 2
+				line.SGST = line.TaxAmount - line.CGST
+				totals[componentKey{ComponentCGST, item.Rate / 2}] += line.CGST
+				totals[componentKey{ComponentSGST, item.Rate / 2}] += line.SGST
+			}
+		}
+
+		result.TaxableAmount += line.TaxableAmount
+		result.TaxAmount += line.TaxAmount
+		result.Items = append(result.Items, line)
+	}
+
+	for key, amount := range totals {
+		result.Components = append(result.Components, Component{Type: key.kind, Rate: key.rate, Amount: amount})
+	}
+	sort.Slice(result.Components, func(i, j int) bool {
+		a, b := result.Components[i], result.Components[j]
+		if a.Rate != b.Rate {
+			return a.Rate < b.Rate
+		}
+		return a.Type < b.Type
+	})
+
+	return result
+}
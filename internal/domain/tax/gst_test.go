@@ -0,0 +1,96 @@
+// internal/domain/tax/gst_test.go
+package tax
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testLines = []LineItem{
+	{ProductID: 1, Rate: 18, Amount: 10050},  // 1809.00 tax
+	{ProductID: 2, Rate: 5, Amount: 999},     // 49.95 rounds up to 50
+	{ProductID: 3, Rate: 0.25, Amount: 1000}, // 2.50 rounds half away from zero to 3
+}
+
+func TestCalculateItemsGSTIntraState(t *testing.T) {
+	result := CalculateItemsGST(testLines, "IN", "Karnataka", " karnataka ")
+
+	if result.InterState {
+		t.Fatal("same state in different case reported as inter-state")
+	}
+	if result.TaxableAmount != 12049 || result.TaxAmount != 1862 {
+		t.Errorf("totals = %d taxable, %d tax; want 12049, 1862", result.TaxableAmount, result.TaxAmount)
+	}
+
+	// Odd amounts put the extra cent on SGST
+	wantLines := [][3]int64{{904, 905, 0}, {25, 25, 0}, {1, 2, 0}}
+	for i, line := range result.Items {
+		got := [3]int64{line.CGST, line.SGST, line.IGST}
+		if got != wantLines[i] {
+			t.Errorf("line %d CGST/SGST/IGST = %v, want %v", i, got, wantLines[i])
+		}
+		if line.CGST+line.SGST != line.TaxAmount {
+			t.Errorf("line %d split %d+%d doesn't add up to %d", i, line.CGST, line.SGST, line.TaxAmount)
+		}
+	}
+
+	wantComponents := []Component{
+		{Type: ComponentCGST, Rate: 0.125, Amount: 1},
+		{Type: ComponentSGST, Rate: 0.125, Amount: 2},
+		{Type: ComponentCGST, Rate: 2.5, Amount: 25},
+		{Type: ComponentSGST, Rate: 2.5, Amount: 25},
+		{Type: ComponentCGST, Rate: 9, Amount: 904},
+		{Type: ComponentSGST, Rate: 9, Amount: 905},
+	}
+	if !reflect.DeepEqual(result.Components, wantComponents) {
+		t.Errorf("components = %+v, want %+v", result.Components, wantComponents)
+	}
+}
+
+func TestCalculateItemsGSTInterState(t *testing.T) {
+	result := CalculateItemsGST(testLines, "IN", "Karnataka", "Maharashtra")
+
+	if !result.InterState {
+		t.Fatal("different states not reported as inter-state")
+	}
+	cgst, sgst, igst := result.Split()
+	if cgst != 0 || sgst != 0 || igst != 1862 {
+		t.Errorf("Split() = %d, %d, %d; want 0, 0, 1862", cgst, sgst, igst)
+	}
+
+	wantComponents := []Component{
+		{Type: ComponentIGST, Rate: 0.25, Amount: 3},
+		{Type: ComponentIGST, Rate: 5, Amount: 50},
+		{Type: ComponentIGST, Rate: 18, Amount: 1809},
+	}
+	if !reflect.DeepEqual(result.Components, wantComponents) {
+		t.Errorf("components = %+v, want %+v", result.Components, wantComponents)
+	}
+}
+
+func TestCalculateItemsGSTUnknownStateIsIntraState(t *testing.T) {
+	result := CalculateItemsGST(testLines, "IN", "Karnataka", "")
+	if result.InterState {
+		t.Error("unknown buyer state reported as inter-state")
+	}
+}
+
+func TestCalculateItemsGSTOutsideIndia(t *testing.T) {
+	result := CalculateItemsGST(testLines, "US", "Karnataka", "California")
+
+	if result.InterState || result.TaxAmount != 0 || len(result.Components) != 0 {
+		t.Errorf("foreign delivery was taxed: %+v", result)
+	}
+	if result.TaxableAmount != 12049 || len(result.Items) != len(testLines) {
+		t.Errorf("foreign delivery lost its lines: %+v", result)
+	}
+}
+
+func TestSplitAmount(t *testing.T) {
+	if cgst, sgst, igst := SplitAmount(101, false); cgst != 50 || sgst != 51 || igst != 0 {
+		t.Errorf("SplitAmount(101, false) = %d, %d, %d; want 50, 51, 0", cgst, sgst, igst)
+	}
+	if cgst, sgst, igst := SplitAmount(101, true); cgst != 0 || sgst != 0 || igst != 101 {
+		t.Errorf("SplitAmount(101, true) = %d, %d, %d; want 0, 0, 101", cgst, sgst, igst)
+	}
+}
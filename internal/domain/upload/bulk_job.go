@@ -0,0 +1,330 @@
+// internal/domain/upload/bulk_job.go
+package upload
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrBulkJobNotFound is returned when a bulk upload job is unknown or has expired
+var ErrBulkJobNotFound = errors.New("bulk upload job not found")
+
+// BulkJobStatus represents the state of a bulk upload job
+type BulkJobStatus string
+
+const (
+	BulkJobStatusPending    BulkJobStatus = "pending"
+	BulkJobStatusProcessing BulkJobStatus = "processing"
+	BulkJobStatusCompleted  BulkJobStatus = "completed"
+)
+
+// BulkFileStatus represents the state of a single file within a bulk upload job
+type BulkFileStatus string
+
+const (
+	BulkFileStatusPending   BulkFileStatus = "pending"
+	BulkFileStatusUploaded  BulkFileStatus = "uploaded"
+	BulkFileStatusDuplicate BulkFileStatus = "duplicate"
+	BulkFileStatusFailed    BulkFileStatus = "failed"
+)
+
+// BulkUploadJob represents the progress of an asynchronous bulk upload
+type BulkUploadJob struct {
+	ID          string           `json:"id"`
+	Status      BulkJobStatus    `json:"status"`
+	Category    string           `json:"category"`
+	Total       int              `json:"total"`
+	Processed   int              `json:"processed"`
+	Success     int              `json:"success"`
+	Failed      int              `json:"failed"`
+	Deduped     int              `json:"deduped"`
+	TotalSize   int64            `json:"total_size"`
+	Files       []BulkUploadFile `json:"files"`
+	UploadedBy  uint             `json:"uploaded_by"`
+	CreatedAt   time.Time        `json:"created_at"`
+	StartedAt   *time.Time       `json:"started_at,omitempty"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+}
+
+// BulkUploadFile represents the outcome of one file in a bulk upload job
+type BulkUploadFile struct {
+	Filename string         `json:"filename"`
+	Status   BulkFileStatus `json:"status"`
+	FileID   uint           `json:"file_id,omitempty"`
+	URL      string         `json:"url,omitempty"`
+	Error    string         `json:"error,omitempty"`
+}
+
+// stagedFile is a bulk upload file copied out of the request so it outlives it
+type stagedFile struct {
+	index    int
+	filename string
+	path     string
+	size     int64
+}
+
+// bulkJobRun tracks an in-flight job; updates are serialized through mu
+type bulkJobRun struct {
+	mu        sync.Mutex
+	job       *BulkUploadJob
+	stageDir  string
+	remaining int
+	req       *BulkUploadRequest
+}
+
+// bulkFileTask is a single file queued on the bulk upload worker pool
+type bulkFileTask struct {
+	run  *bulkJobRun
+	file stagedFile
+}
+
+var (
+	bulkPoolOnce sync.Once
+	bulkTasks    chan bulkFileTask
+)
+
+// StartBulkUpload stages the request's files on disk, records a pending job in
+// Redis and hands the files to the bulk upload worker pool. The multipart files
+// are removed when the request ends, so they must be copied before returning.
+func (s *Service) StartBulkUpload(req *BulkUploadRequest) (*BulkUploadJob, error) {
+	jobID := uuid.New().String()
+
+	stageDir := filepath.Join(os.TempDir(), "bulk-uploads", jobID)
+	if err := os.MkdirAll(stageDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	job := &BulkUploadJob{
+		ID:         jobID,
+		Status:     BulkJobStatusPending,
+		Category:   req.Category,
+		Total:      len(req.Files),
+		Files:      make([]BulkUploadFile, len(req.Files)),
+		UploadedBy: req.UploadedBy,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	run := &bulkJobRun{job: job, stageDir: stageDir, req: req}
+
+	var staged []stagedFile
+	for i, header := range req.Files {
+		job.Files[i] = BulkUploadFile{Filename: header.Filename, Status: BulkFileStatusPending}
+
+		path, err := stageUpload(stageDir, i, header)
+		if err != nil {
+			job.Files[i].Status = BulkFileStatusFailed
+			job.Files[i].Error = err.Error()
+			job.Failed++
+			job.Processed++
+			continue
+		}
+		staged = append(staged, stagedFile{index: i, filename: header.Filename, path: path, size: header.Size})
+	}
+	run.remaining = len(staged)
+
+	if len(staged) == 0 {
+		now := time.Now().UTC()
+		job.Status = BulkJobStatusCompleted
+		job.CompletedAt = &now
+		os.RemoveAll(stageDir)
+	}
+
+	if err := s.saveBulkJob(job); err != nil {
+		os.RemoveAll(stageDir)
+		return nil, err
+	}
+
+	if len(staged) > 0 {
+		pool := s.getBulkPool()
+		go func() {
+			for _, file := range staged {
+				pool <- bulkFileTask{run: run, file: file}
+			}
+		}()
+	}
+
+	return job, nil
+}
+
+// GetBulkUploadJob returns the current state of a bulk upload job
+func (s *Service) GetBulkUploadJob(jobID string) (*BulkUploadJob, error) {
+	data, err := s.redisClient.Get(context.Background(), bulkJobKey(jobID)).Result()
+	if err == redis.Nil {
+		return nil, ErrBulkJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve bulk upload job: %w", err)
+	}
+
+	var job BulkUploadJob
+	if err := json.Unmarshal([]byte(data), &job); err != nil {
+		return nil, fmt.Errorf("failed to parse bulk upload job: %w", err)
+	}
+	return &job, nil
+}
+
+// Private helper methods
+
+func bulkJobKey(jobID string) string {
+	return fmt.Sprintf("upload:bulk_job:%s", jobID)
+}
+
+// getBulkPool lazily starts the shared bulk upload workers; the worker count
+// bounds how many files are stored concurrently across all jobs
+func (s *Service) getBulkPool() chan bulkFileTask {
+	bulkPoolOnce.Do(func() {
+		workers := s.config.Upload.BulkWorkers
+		if workers <= 0 {
+			workers = 1
+		}
+
+		bulkTasks = make(chan bulkFileTask)
+		for i := 0; i < workers; i++ {
+			go s.runBulkWorker()
+		}
+
+		log.Printf("📦 Bulk upload workers started with %d workers", workers)
+	})
+
+	return bulkTasks
+}
+
+func (s *Service) runBulkWorker() {
+	for task := range bulkTasks {
+		s.markBulkJobStarted(task.run)
+		uploaded, err := s.uploadStagedFile(task.run.req, task.file)
+		s.recordBulkResult(task.run, task.file.index, uploaded, err)
+	}
+}
+
+func (s *Service) uploadStagedFile(req *BulkUploadRequest, staged stagedFile) (uploaded *UploadedFile, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("upload panicked: %v", r)
+		}
+	}()
+
+	file, err := os.Open(staged.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open staged file: %w", err)
+	}
+	defer file.Close()
+
+	return s.UploadImage(&ImageUploadRequest{
+		File:        file,
+		Header:      &multipart.FileHeader{Filename: staged.filename, Size: staged.size},
+		Category:    req.Category,
+		Description: req.Description,
+		UploadedBy:  req.UploadedBy,
+	})
+}
+
+func (s *Service) markBulkJobStarted(run *bulkJobRun) {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	if run.job.Status != BulkJobStatusPending {
+		return
+	}
+
+	now := time.Now().UTC()
+	run.job.Status = BulkJobStatusProcessing
+	run.job.StartedAt = &now
+	if err := s.saveBulkJob(run.job); err != nil {
+		log.Printf("Failed to update bulk upload job %s: %v", run.job.ID, err)
+	}
+}
+
+func (s *Service) recordBulkResult(run *bulkJobRun, index int, uploaded *UploadedFile, err error) {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	job := run.job
+	result := &job.Files[index]
+
+	switch {
+	case err != nil:
+		result.Status = BulkFileStatusFailed
+		result.Error = err.Error()
+		job.Failed++
+	case uploaded.Deduplicated:
+		result.Status = BulkFileStatusDuplicate
+		result.FileID = uploaded.ID
+		result.URL = uploaded.URL
+		job.Success++
+		job.Deduped++
+	default:
+		result.Status = BulkFileStatusUploaded
+		result.FileID = uploaded.ID
+		result.URL = uploaded.URL
+		job.Success++
+		job.TotalSize += uploaded.Size
+	}
+	job.Processed++
+
+	run.remaining--
+	if run.remaining == 0 {
+		now := time.Now().UTC()
+		job.Status = BulkJobStatusCompleted
+		job.CompletedAt = &now
+		os.RemoveAll(run.stageDir)
+	}
+
+	if err := s.saveBulkJob(job); err != nil {
+		log.Printf("Failed to update bulk upload job %s: %v", job.ID, err)
+	}
+}
+
+func (s *Service) saveBulkJob(job *BulkUploadJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to serialize bulk upload job: %w", err)
+	}
+
+	ttl := s.config.Upload.BulkJobTTL
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+
+	if err := s.redisClient.Set(context.Background(), bulkJobKey(job.ID), data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save bulk upload job: %w", err)
+	}
+	return nil
+}
+
+// stageUpload copies a multipart file into the job's staging directory
+func stageUpload(stageDir string, index int, header *multipart.FileHeader) (string, error) {
+	src, err := header.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer src.Close()
+
+	path := filepath.Join(stageDir, fmt.Sprintf("%04d%s", index, filepath.Ext(header.Filename)))
+	dst, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stage file: %w", err)
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return "", fmt.Errorf("failed to stage file: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		return "", fmt.Errorf("failed to stage file: %w", err)
+	}
+
+	return path, nil
+}
@@ -8,6 +8,16 @@ import (
 	"gorm.io/gorm"
 )
 
+// ProcessingStatus represents the state of derived image generation
+type ProcessingStatus string
+
+const (
+	ProcessingStatusPending    ProcessingStatus = "pending"
+	ProcessingStatusProcessing ProcessingStatus = "processing"
+	ProcessingStatusCompleted  ProcessingStatus = "completed"
+	ProcessingStatusFailed     ProcessingStatus = "failed"
+)
+
 // UploadedFile represents an uploaded file in the database
 type UploadedFile struct {
 	ID           uint   `gorm:"primaryKey" json:"id"`
@@ -21,12 +31,19 @@ type UploadedFile struct {
 	Description  string `gorm:"size:500" json:"description"`
 	AltText      string `gorm:"size:255" json:"alt_text"`
 	Tags         string `gorm:"size:500" json:"tags"`
+	ContentHash  string `gorm:"size:64;index" json:"content_hash,omitempty"` // SHA-256 of the file bytes
 
 	// Image specific fields
-	Width        int    `json:"width,omitempty"`
-	Height       int    `json:"height,omitempty"`
-	ThumbnailURL string `gorm:"size:500" json:"thumbnail_url,omitempty"`
-	OptimizedURL string `gorm:"size:500" json:"optimized_url,omitempty"`
+	Width           int    `json:"width,omitempty"`
+	Height          int    `json:"height,omitempty"`
+	ThumbnailURL    string `gorm:"size:500" json:"thumbnail_url,omitempty"`
+	ThumbnailWidth  int    `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight int    `json:"thumbnail_height,omitempty"`
+	OptimizedURL    string `gorm:"size:500" json:"optimized_url,omitempty"`
+
+	// Derived image processing
+	ProcessingStatus ProcessingStatus `gorm:"size:20;default:'completed';index" json:"processing_status"`
+	ProcessingError  string           `gorm:"size:500" json:"processing_error,omitempty"`
 
 	// Metadata
 	UploadedBy uint       `gorm:"not null;index" json:"uploaded_by"`
@@ -38,6 +55,9 @@ type UploadedFile struct {
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Deduplicated is set when an upload matched an existing file instead of creating a new one
+	Deduplicated bool `gorm:"-" json:"deduplicated,omitempty"`
 }
 
 // FileUsage represents where a file is being used
@@ -0,0 +1,217 @@
+// internal/domain/upload/exif.go
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+)
+
+const (
+	jpegMarkerSOI   = 0xD8
+	jpegMarkerEOI   = 0xD9
+	jpegMarkerSOS   = 0xDA
+	jpegMarkerAPP1  = 0xE1 // Exif and XMP
+	jpegMarkerAPP13 = 0xED // Photoshop IRB / IPTC
+	jpegMarkerCOM   = 0xFE
+
+	exifTagOrientation = 0x0112
+)
+
+// stripJPEGMetadata removes Exif, XMP, IPTC and comment segments from a JPEG.
+// When the Exif orientation is not the default, the image is rotated into its
+// upright position and re-encoded so viewers that relied on the tag still show
+// it correctly; otherwise the segments are dropped without re-encoding.
+func stripJPEGMetadata(data []byte) ([]byte, error) {
+	segments, err := splitJPEGSegments(data)
+	if err != nil {
+		return nil, err
+	}
+
+	orientation := 1
+	for _, segment := range segments {
+		if segment.marker == jpegMarkerAPP1 {
+			if o := exifOrientation(segment.payload()); o != 0 {
+				orientation = o
+				break
+			}
+		}
+	}
+
+	if orientation > 1 && orientation <= 8 {
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode image: %w", err)
+		}
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, applyOrientation(img, orientation), &jpeg.Options{Quality: 92}); err != nil {
+			return nil, fmt.Errorf("failed to encode image: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(data))
+	for _, segment := range segments {
+		switch segment.marker {
+		case jpegMarkerAPP1, jpegMarkerAPP13, jpegMarkerCOM:
+			continue
+		}
+		buf.Write(segment.raw)
+	}
+	return buf.Bytes(), nil
+}
+
+// jpegSegment is a marker segment; raw includes the marker bytes and, for the
+// start-of-scan segment, all entropy-coded data through the end of the file
+type jpegSegment struct {
+	marker byte
+	raw    []byte
+}
+
+// payload returns the segment data after the marker and length fields
+func (s jpegSegment) payload() []byte {
+	if len(s.raw) < 4 {
+		return nil
+	}
+	return s.raw[4:]
+}
+
+// splitJPEGSegments walks the JPEG marker structure up to the start of scan
+func splitJPEGSegments(data []byte) ([]jpegSegment, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != jpegMarkerSOI {
+		return nil, fmt.Errorf("invalid JPEG: missing start of image marker")
+	}
+
+	segments := []jpegSegment{{marker: jpegMarkerSOI, raw: data[:2]}}
+	pos := 2
+
+	for pos < len(data) {
+		if data[pos] != 0xFF {
+			return nil, fmt.Errorf("invalid JPEG: expected marker at offset %d", pos)
+		}
+
+		// Markers may be preceded by any number of 0xFF fill bytes
+		start := pos
+		for pos < len(data) && data[pos] == 0xFF {
+			pos++
+		}
+		if pos >= len(data) {
+			return nil, fmt.Errorf("invalid JPEG: truncated marker")
+		}
+		marker := data[pos]
+		pos++
+
+		switch {
+		case marker == jpegMarkerSOS || marker == jpegMarkerEOI:
+			// Everything from here on is image data; keep it verbatim
+			segments = append(segments, jpegSegment{marker: marker, raw: data[start:]})
+			return segments, nil
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			// Standalone markers carry no length
+			segments = append(segments, jpegSegment{marker: marker, raw: data[start:pos]})
+			continue
+		}
+
+		if pos+2 > len(data) {
+			return nil, fmt.Errorf("invalid JPEG: truncated segment")
+		}
+		length := int(binary.BigEndian.Uint16(data[pos : pos+2]))
+		if length < 2 || pos+length > len(data) {
+			return nil, fmt.Errorf("invalid JPEG: bad segment length")
+		}
+		pos += length
+
+		segments = append(segments, jpegSegment{marker: marker, raw: data[start:pos]})
+	}
+
+	return nil, fmt.Errorf("invalid JPEG: missing image data")
+}
+
+// exifOrientation reads the orientation tag from an APP1 payload, returning 0
+// when the payload is not Exif or carries no orientation
+func exifOrientation(payload []byte) int {
+	if len(payload) < 14 || !bytes.Equal(payload[:6], []byte("Exif\x00\x00")) {
+		return 0
+	}
+	tiff := payload[6:]
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	if order.Uint16(tiff[2:4]) != 42 {
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+
+	entries := int(order.Uint16(tiff[ifd : ifd+2]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:entry+2]) == exifTagOrientation {
+			return int(order.Uint16(tiff[entry+8 : entry+10]))
+		}
+	}
+
+	return 0
+}
+
+// applyOrientation transforms img so it displays upright for the given Exif orientation (2-8)
+func applyOrientation(img image.Image, orientation int) image.Image {
+	bounds := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // Mirrored horizontally
+				dx, dy = w-1-x, y
+			case 3: // Rotated 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // Mirrored vertically
+				dx, dy = x, h-1-y
+			case 5: // Transposed
+				dx, dy = y, x
+			case 6: // Rotated 90 clockwise
+				dx, dy = h-1-y, x
+			case 7: // Transversed
+				dx, dy = h-1-y, w-1-x
+			case 8: // Rotated 90 counter-clockwise
+				dx, dy = y, w-1-x
+			default:
+				dx, dy = x, y
+			}
+
+			si := src.PixOffset(x, y)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+
+	return dst
+}
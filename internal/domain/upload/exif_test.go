@@ -0,0 +1,126 @@
+// internal/domain/upload/exif_test.go
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// exifSegment builds an APP1 Exif segment with a GPS IFD and, when orientation is
+// set, an orientation tag
+func exifSegment(orientation uint16) []byte {
+	var tiff bytes.Buffer
+	tiff.WriteString("MM\x00\x2a")
+	binary.Write(&tiff, binary.BigEndian, uint32(8)) // IFD0 offset
+
+	entries := [][]uint16{{0x8825, 4, 0, 1, 0, 38}} // GPS IFD pointer, LONG 38
+	if orientation != 0 {
+		entries = append([][]uint16{{exifTagOrientation, 3, 0, 1, orientation, 0}}, entries...)
+	}
+	binary.Write(&tiff, binary.BigEndian, uint16(len(entries)))
+	for _, entry := range entries {
+		binary.Write(&tiff, binary.BigEndian, entry)
+	}
+	binary.Write(&tiff, binary.BigEndian, uint32(0)) // No next IFD
+
+	// GPS IFD: GPSLatitudeRef "N", followed by a recognisable location string
+	for tiff.Len() < 38 {
+		tiff.WriteByte(0)
+	}
+	binary.Write(&tiff, binary.BigEndian, []uint16{1, 0x0001, 2, 0, 2})
+	tiff.WriteString("N\x00\x00\x00")
+	binary.Write(&tiff, binary.BigEndian, uint32(0))
+	tiff.WriteString("GPS 12.9716N 77.5946E")
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, jpegMarkerAPP1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// jpegWithExif encodes a width x height JPEG and inserts the Exif segment and a
+// comment right after the start of image marker
+func jpegWithExif(t *testing.T, width, height int, orientation uint16) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 4), G: uint8(y * 4), B: 90, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buf.Bytes()
+
+	comment := []byte{0xFF, jpegMarkerCOM, 0, 9, 'c', 'a', 'm', 'e', 'r', 'a', '!'}
+	out := append([]byte{}, encoded[:2]...)
+	out = append(out, exifSegment(orientation)...)
+	out = append(out, comment...)
+	return append(out, encoded[2:]...)
+}
+
+func TestStripJPEGMetadata(t *testing.T) {
+	tests := []struct {
+		name        string
+		orientation uint16
+		wantWidth   int
+		wantHeight  int
+	}{
+		{"without orientation", 0, 40, 24},
+		{"upright orientation", 1, 40, 24},
+		{"rotated orientation is applied", 6, 24, 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := jpegWithExif(t, 40, 24, tt.orientation)
+			if !bytes.Contains(data, []byte("GPS 12.9716N")) {
+				t.Fatal("test image is missing its GPS data")
+			}
+
+			stripped, err := stripJPEGMetadata(data)
+			if err != nil {
+				t.Fatalf("stripJPEGMetadata() error = %v", err)
+			}
+			for _, leaked := range []string{"Exif", "GPS 12.9716N", "camera!"} {
+				if bytes.Contains(stripped, []byte(leaked)) {
+					t.Errorf("output still contains %q", leaked)
+				}
+			}
+
+			segments, err := splitJPEGSegments(stripped)
+			if err != nil {
+				t.Fatalf("output is not a valid JPEG: %v", err)
+			}
+			for _, segment := range segments {
+				if segment.marker == jpegMarkerAPP1 || segment.marker == jpegMarkerCOM {
+					t.Errorf("output still has a %#x segment", segment.marker)
+				}
+			}
+
+			img, err := jpeg.Decode(bytes.NewReader(stripped))
+			if err != nil {
+				t.Fatalf("output does not decode: %v", err)
+			}
+			if b := img.Bounds(); b.Dx() != tt.wantWidth || b.Dy() != tt.wantHeight {
+				t.Errorf("decoded size = %dx%d, want %dx%d", b.Dx(), b.Dy(), tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestExifOrientation(t *testing.T) {
+	segment := exifSegment(6)
+	if got := exifOrientation(segment[4:]); got != 6 {
+		t.Errorf("exifOrientation() = %d, want 6", got)
+	}
+	if got := exifOrientation([]byte("http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta/>")); got != 0 {
+		t.Errorf("exifOrientation() of XMP = %d, want 0", got)
+	}
+}
@@ -0,0 +1,152 @@
+// internal/domain/upload/processor.go
+package upload
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+// ImageProcessingJob represents a queued derived-image generation task
+type ImageProcessingJob struct {
+	FileID   uint
+	Optimize *ImageOptimizeRequest // nil generates the thumbnail
+}
+
+// imageProcessor runs derived-image generation on background workers
+type imageProcessor struct {
+	service *Service
+	jobs    chan ImageProcessingJob
+}
+
+// getProcessor lazily starts the service's worker pool
+func (s *Service) getProcessor() *imageProcessor {
+	s.processorOnce.Do(func() {
+		workers := s.config.Upload.ProcessingWorkers
+		if workers <= 0 {
+			workers = 1
+		}
+		queueSize := s.config.Upload.ProcessingQueueSize
+		if queueSize <= 0 {
+			queueSize = 100
+		}
+
+		s.processor = &imageProcessor{
+			service: s,
+			jobs:    make(chan ImageProcessingJob, queueSize),
+		}
+
+		for i := 0; i < workers; i++ {
+			go s.processor.run()
+		}
+
+		log.Printf("🖼️ Image processing queue started with %d workers", workers)
+	})
+
+	return s.processor
+}
+
+func (p *imageProcessor) run() {
+	for job := range p.jobs {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Image processing panic for file %d: %v", job.FileID, r)
+					p.service.setProcessingStatus(job.FileID, ProcessingStatusFailed, fmt.Sprintf("%v", r))
+				}
+			}()
+
+			if err := p.service.processImage(job); err != nil {
+				log.Printf("Image processing failed for file %d: %v", job.FileID, err)
+			}
+		}()
+	}
+}
+
+// enqueueProcessing queues a job, processing inline when async processing is
+// disabled or the queue is full
+func (s *Service) enqueueProcessing(job ImageProcessingJob) {
+	if !s.config.Upload.AsyncProcessing {
+		s.processImage(job)
+		return
+	}
+
+	select {
+	case s.getProcessor().jobs <- job:
+	default:
+		log.Printf("Image processing queue full, processing file %d inline", job.FileID)
+		s.processImage(job)
+	}
+}
+
+// processImage generates the derived image for a job and records the outcome
+func (s *Service) processImage(job ImageProcessingJob) error {
+	var image UploadedFile
+	if err := s.db.First(&image, job.FileID).Error; err != nil {
+		return fmt.Errorf("image not found: %w", err)
+	}
+
+	s.setProcessingStatus(image.ID, ProcessingStatusProcessing, "")
+
+	originalPath := filepath.Join(s.config.External.Storage.LocalPath, image.Path)
+	updates := map[string]interface{}{}
+
+	if job.Optimize == nil {
+		thumbnailPath, width, height, err := s.generateThumbnail(originalPath, image.Category, image.Filename)
+		switch {
+		case errors.Is(err, ErrThumbnailUnsupported):
+			// Formats such as WebP cannot be decoded; serve the original instead
+			log.Printf("Skipping thumbnail for file %d: %v", image.ID, err)
+		case err != nil:
+			s.setProcessingStatus(image.ID, ProcessingStatusFailed, err.Error())
+			return fmt.Errorf("failed to generate thumbnail: %w", err)
+		default:
+			updates["thumbnail_url"] = s.getFileURL(thumbnailPath)
+			updates["thumbnail_width"] = width
+			updates["thumbnail_height"] = height
+		}
+	} else {
+		req := job.Optimize
+		ext := filepath.Ext(image.Filename)
+		nameWithoutExt := strings.TrimSuffix(image.Filename, ext)
+		if req.Format != "" {
+			ext = formatExtensions[req.Format]
+		}
+		optimizedFilename := fmt.Sprintf("%s_optimized_%dx%d_q%d%s", nameWithoutExt, req.Width, req.Height, req.Quality, ext)
+		optimizedPath := filepath.Join(s.config.External.Storage.LocalPath, image.Category, optimizedFilename)
+
+		err := s.optimizeImageFile(originalPath, optimizedPath, req)
+		switch {
+		case errors.Is(err, ErrOptimizedNotSmaller):
+			// Keep serving the original rather than a bigger conversion
+			log.Printf("Keeping original for file %d: %v", image.ID, err)
+			updates["optimized_url"] = s.getFileURL(image.Path)
+		case err != nil:
+			s.setProcessingStatus(image.ID, ProcessingStatusFailed, err.Error())
+			return fmt.Errorf("failed to optimize image: %w", err)
+		default:
+			updates["optimized_url"] = s.getFileURL(filepath.Join(image.Category, optimizedFilename))
+		}
+	}
+
+	updates["processing_status"] = ProcessingStatusCompleted
+	updates["processing_error"] = ""
+
+	if err := s.db.Model(&UploadedFile{}).Where("id = ?", image.ID).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to save derived image URLs: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) setProcessingStatus(fileID uint, status ProcessingStatus, errMsg string) {
+	if len(errMsg) > 500 {
+		errMsg = errMsg[:500]
+	}
+	s.db.Model(&UploadedFile{}).Where("id = ?", fileID).Updates(map[string]interface{}{
+		"processing_status": status,
+		"processing_error":  errMsg,
+	})
+}
@@ -0,0 +1,56 @@
+// internal/domain/upload/processor_test.go
+package upload
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUploadImageGeneratesThumbnailInBackground(t *testing.T) {
+	s, db := newTestService(t, true)
+
+	uploaded, err := s.UploadImage(uploadRequest(t, "lamp.png", pngBytes(t, 120, 60)))
+	if err != nil {
+		t.Fatalf("UploadImage() error = %v", err)
+	}
+	// The upload returns before the thumbnail exists
+	if uploaded.ProcessingStatus != ProcessingStatusPending || uploaded.ThumbnailURL != "" {
+		t.Fatalf("uploaded = %s with thumbnail %q, want pending without one", uploaded.ProcessingStatus, uploaded.ThumbnailURL)
+	}
+
+	var processed UploadedFile
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		db.First(&processed, uploaded.ID)
+		if processed.ProcessingStatus == ProcessingStatusCompleted || processed.ProcessingStatus == ProcessingStatusFailed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for processing, status = %s", processed.ProcessingStatus)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if processed.ProcessingStatus != ProcessingStatusCompleted || processed.ThumbnailWidth != 30 || processed.ThumbnailHeight != 15 {
+		t.Fatalf("processed = %s (%q) with a %dx%d thumbnail, want completed with 30x15", processed.ProcessingStatus,
+			processed.ProcessingError, processed.ThumbnailWidth, processed.ThumbnailHeight)
+	}
+	thumbnailPath := filepath.Join(s.config.External.Storage.LocalPath, s.urlToPath(processed.ThumbnailURL))
+	if _, err := os.Stat(thumbnailPath); err != nil {
+		t.Errorf("thumbnail %s was not written: %v", processed.ThumbnailURL, err)
+	}
+}
+
+func TestUploadImageWithoutAsyncProcessing(t *testing.T) {
+	s, _ := newTestService(t, false)
+
+	uploaded, err := s.UploadImage(uploadRequest(t, "lamp.png", pngBytes(t, 120, 60)))
+	if err != nil {
+		t.Fatalf("UploadImage() error = %v", err)
+	}
+	if uploaded.ProcessingStatus != ProcessingStatusCompleted || uploaded.ThumbnailURL == "" {
+		t.Errorf("uploaded = %s with thumbnail %q, want it completed inline", uploaded.ProcessingStatus, uploaded.ThumbnailURL)
+	}
+}
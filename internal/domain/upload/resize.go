@@ -0,0 +1,55 @@
+// internal/domain/upload/resize.go
+package upload
+
+import (
+	"errors"
+	"image"
+
+	"golang.org/x/image/draw"
+)
+
+// ErrThumbnailUnsupported is returned for image formats that cannot be decoded for resizing
+var ErrThumbnailUnsupported = errors.New("thumbnail generation not supported for this image format")
+
+// fitWithin returns dimensions that fit inside maxWidth x maxHeight while
+// preserving the aspect ratio. Images already inside the box are not upscaled.
+func fitWithin(width, height, maxWidth, maxHeight int) (int, int) {
+	if width <= 0 || height <= 0 {
+		return 0, 0
+	}
+	if maxWidth <= 0 || maxHeight <= 0 || (width <= maxWidth && height <= maxHeight) {
+		return width, height
+	}
+
+	scaleX := float64(maxWidth) / float64(width)
+	scaleY := float64(maxHeight) / float64(height)
+	scale := scaleX
+	if scaleY < scaleX {
+		scale = scaleY
+	}
+
+	newWidth := int(float64(width)*scale + 0.5)
+	newHeight := int(float64(height)*scale + 0.5)
+	if newWidth < 1 {
+		newWidth = 1
+	}
+	if newHeight < 1 {
+		newHeight = 1
+	}
+	if newWidth > maxWidth {
+		newWidth = maxWidth
+	}
+	if newHeight > maxHeight {
+		newHeight = maxHeight
+	}
+
+	return newWidth, newHeight
+}
+
+// resizeImage scales src to width x height with Catmull-Rom resampling, which stays
+// sharp when downscaling to thumbnails
+func resizeImage(src image.Image, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Src, nil)
+	return dst
+}
@@ -0,0 +1,70 @@
+// internal/domain/upload/resize_test.go
+package upload
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestFitWithin(t *testing.T) {
+	tests := []struct {
+		name                string
+		width, height       int
+		maxWidth, maxHeight int
+		wantWidth           int
+		wantHeight          int
+	}{
+		{"landscape", 4000, 3000, 300, 300, 300, 225},
+		{"portrait", 1080, 1920, 300, 300, 169, 300},
+		{"square into a wide box", 1000, 1000, 400, 200, 200, 200},
+		{"already small is not upscaled", 120, 80, 300, 300, 120, 80},
+		{"extreme panorama keeps a pixel", 10000, 10, 300, 300, 300, 1},
+		{"no box leaves the size alone", 640, 480, 0, 300, 640, 480},
+		{"empty image", 0, 480, 300, 300, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, h := fitWithin(tt.width, tt.height, tt.maxWidth, tt.maxHeight)
+			if w != tt.wantWidth || h != tt.wantHeight {
+				t.Errorf("fitWithin() = %dx%d, want %dx%d", w, h, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestThumbnailBoundsAndAspectRatio(t *testing.T) {
+	sizes := [][2]int{{4000, 3000}, {1080, 1920}, {333, 777}, {301, 299}}
+	for _, size := range sizes {
+		src := image.NewNRGBA(image.Rect(10, 20, 10+size[0], 20+size[1])) // Non-zero origin
+		for y := src.Rect.Min.Y; y < src.Rect.Max.Y; y++ {
+			for x := src.Rect.Min.X; x < src.Rect.Max.X; x++ {
+				src.SetNRGBA(x, y, color.NRGBA{R: 200, G: 100, B: 50, A: 0xff})
+			}
+		}
+
+		w, h := fitWithin(size[0], size[1], 300, 300)
+		thumb := resizeImage(src, w, h)
+
+		bounds := thumb.Bounds()
+		if bounds.Dx() > 300 || bounds.Dy() > 300 || bounds.Dx() != w || bounds.Dy() != h {
+			t.Errorf("%dx%d: thumbnail is %v, want %dx%d within 300x300", size[0], size[1], bounds, w, h)
+		}
+
+		// Rounding to whole pixels can move the ratio by at most a pixel's worth
+		srcRatio := float64(size[0]) / float64(size[1])
+		thumbRatio := float64(w) / float64(h)
+		if tolerance := srcRatio / float64(min(w, h)); math.Abs(srcRatio-thumbRatio) > tolerance {
+			t.Errorf("%dx%d: thumbnail ratio %.3f, want %.3f", size[0], size[1], thumbRatio, srcRatio)
+		}
+
+		// A flat colour stays flat all the way to the edges
+		for _, p := range []image.Point{{0, 0}, {w - 1, h - 1}, {w / 2, h / 2}} {
+			if got := thumb.RGBAAt(p.X, p.Y); got != (color.RGBA{R: 200, G: 100, B: 50, A: 0xff}) {
+				t.Errorf("%dx%d: pixel %v = %v", size[0], size[1], p, got)
+			}
+		}
+	}
+}
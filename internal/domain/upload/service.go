@@ -2,32 +2,45 @@
 package upload
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"image"
+	_ "image/gif"
 	"image/jpeg"
 	"image/png"
 	"io"
 	"mime/multipart"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"gorm.io/gorm"
 )
 
 // Service handles file upload business logic
 type Service struct {
-	db     *gorm.DB
-	config *config.Config
+	db          *gorm.DB
+	redisClient *redis.Client
+	config      *config.Config
+
+	processorOnce sync.Once
+	processor     *imageProcessor
 }
 
 // NewService creates a new upload service
-func NewService(db *gorm.DB, cfg *config.Config) *Service {
+func NewService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *Service {
 	return &Service{
-		db:     db,
-		config: cfg,
+		db:          db,
+		redisClient: redisClient,
+		config:      cfg,
 	}
 }
 
@@ -53,9 +66,10 @@ type ImageUpdateRequest struct {
 
 // ImageOptimizeRequest represents image optimization request
 type ImageOptimizeRequest struct {
-	Quality int `json:"quality"`
-	Width   int `json:"width"`
-	Height  int `json:"height"`
+	Quality int    `json:"quality"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Format  string `json:"format"` // Output format; empty keeps the source format
 }
 
 // BulkUploadRequest represents bulk upload request
@@ -66,27 +80,6 @@ type BulkUploadRequest struct {
 	UploadedBy  uint                    `json:"uploaded_by"`
 }
 
-// BulkUploadResult represents bulk upload result
-type BulkUploadResult struct {
-	Uploaded []UploadedFile `json:"uploaded"`
-	Failed   []FailedUpload `json:"failed"`
-	Summary  UploadSummary  `json:"summary"`
-}
-
-// FailedUpload represents a failed upload
-type FailedUpload struct {
-	Filename string `json:"filename"`
-	Error    string `json:"error"`
-}
-
-// UploadSummary represents upload summary
-type UploadSummary struct {
-	TotalFiles   int   `json:"total_files"`
-	SuccessCount int   `json:"success_count"`
-	FailureCount int   `json:"failure_count"`
-	TotalSize    int64 `json:"total_size"`
-}
-
 // ImageListRequest represents image list request
 type ImageListRequest struct {
 	Page      int    `json:"page"`
@@ -134,10 +127,28 @@ type MonthlyUpload struct {
 // UploadImage uploads a single image
 func (s *Service) UploadImage(req *ImageUploadRequest) (*UploadedFile, error) {
 	// Validate file
-	if err := s.validateImageFile(req.Header); err != nil {
+	mimeType, err := s.validateImageFile(req.Header, req.File)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reuse an identical file already uploaded by the same user
+	contentHash, err := s.hashFile(req.File)
+	if err != nil {
 		return nil, err
 	}
 
+	var existing UploadedFile
+	err = s.db.Where("content_hash = ? AND uploaded_by = ?", contentHash, req.UploadedBy).
+		Order("id ASC").First(&existing).Error
+	if err == nil {
+		existing.Deduplicated = true
+		return &existing, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to check for duplicate upload: %w", err)
+	}
+
 	// Generate unique filename
 	filename := s.generateUniqueFilename(req.Header.Filename)
 
@@ -162,39 +173,59 @@ func (s *Service) UploadImage(req *ImageUploadRequest) (*UploadedFile, error) {
 	}
 	defer dst.Close()
 
-	if _, err := io.Copy(dst, req.File); err != nil {
+	size := req.Header.Size
+	if s.config.Upload.StripEXIF && mimeType == "image/jpeg" {
+		// Drop Exif (GPS, camera details) and other metadata before storing
+		data, err := io.ReadAll(req.File)
+		if err != nil {
+			os.Remove(fullPath)
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		cleaned, err := stripJPEGMetadata(data)
+		if err != nil {
+			os.Remove(fullPath)
+			return nil, fmt.Errorf("failed to strip image metadata: %w", err)
+		}
+		if _, err := dst.Write(cleaned); err != nil {
+			os.Remove(fullPath)
+			return nil, fmt.Errorf("failed to save file: %w", err)
+		}
+		size = int64(len(cleaned))
+	} else if _, err := io.Copy(dst, req.File); err != nil {
+		return nil, fmt.Errorf("failed to save file: %w", err)
+	}
+	if err := dst.Close(); err != nil {
 		return nil, fmt.Errorf("failed to save file: %w", err)
 	}
 
 	// Get image dimensions
 	width, height := s.getImageDimensions(fullPath)
 
-	// Generate thumbnail if it's an image
-	thumbnailURL := ""
-	if s.isImageFile(req.Header.Filename) {
-		thumbnailPath, err := s.generateThumbnail(fullPath, category, filename)
-		if err == nil {
-			thumbnailURL = s.getFileURL(thumbnailPath)
-		}
+	// Derived images (thumbnail) are generated after the original is stored
+	isImage := strings.HasPrefix(mimeType, "image/")
+	processingStatus := ProcessingStatusCompleted
+	if isImage {
+		processingStatus = ProcessingStatusPending
 	}
 
 	// Create database record
 	uploadedFile := UploadedFile{
-		OriginalName: req.Header.Filename,
-		Filename:     filename,
-		Path:         relativePath,
-		URL:          s.getFileURL(relativePath),
-		MimeType:     s.getMimeType(req.Header.Filename),
-		Size:         req.Header.Size,
-		Category:     category,
-		Description:  req.Description,
-		AltText:      req.AltText,
-		Tags:         req.Tags,
-		Width:        width,
-		Height:       height,
-		ThumbnailURL: thumbnailURL,
-		UploadedBy:   req.UploadedBy,
-		IsPublic:     true,
+		OriginalName:     req.Header.Filename,
+		Filename:         filename,
+		Path:             relativePath,
+		URL:              s.getFileURL(relativePath),
+		MimeType:         mimeType,
+		Size:             size,
+		Category:         category,
+		Description:      req.Description,
+		AltText:          req.AltText,
+		Tags:             req.Tags,
+		ContentHash:      contentHash,
+		Width:            width,
+		Height:           height,
+		ProcessingStatus: processingStatus,
+		UploadedBy:       req.UploadedBy,
+		IsPublic:         true,
 	}
 
 	if err := s.db.Create(&uploadedFile).Error; err != nil {
@@ -203,55 +234,16 @@ func (s *Service) UploadImage(req *ImageUploadRequest) (*UploadedFile, error) {
 		return nil, fmt.Errorf("failed to save file info: %w", err)
 	}
 
-	return &uploadedFile, nil
-}
-
-// BulkUploadImages uploads multiple images
-func (s *Service) BulkUploadImages(req *BulkUploadRequest) (*BulkUploadResult, error) {
-	result := &BulkUploadResult{
-		Uploaded: []UploadedFile{},
-		Failed:   []FailedUpload{},
-		Summary: UploadSummary{
-			TotalFiles: len(req.Files),
-		},
-	}
-
-	for _, fileHeader := range req.Files {
-		file, err := fileHeader.Open()
-		if err != nil {
-			result.Failed = append(result.Failed, FailedUpload{
-				Filename: fileHeader.Filename,
-				Error:    fmt.Sprintf("Failed to open file: %v", err),
-			})
-			result.Summary.FailureCount++
-			continue
-		}
-
-		uploadReq := &ImageUploadRequest{
-			File:        file,
-			Header:      fileHeader,
-			Category:    req.Category,
-			Description: req.Description,
-			UploadedBy:  req.UploadedBy,
-		}
-
-		uploadedFile, err := s.UploadImage(uploadReq)
-		file.Close()
-
-		if err != nil {
-			result.Failed = append(result.Failed, FailedUpload{
-				Filename: fileHeader.Filename,
-				Error:    err.Error(),
-			})
-			result.Summary.FailureCount++
-		} else {
-			result.Uploaded = append(result.Uploaded, *uploadedFile)
-			result.Summary.SuccessCount++
-			result.Summary.TotalSize += uploadedFile.Size
+	if isImage {
+		s.enqueueProcessing(ImageProcessingJob{FileID: uploadedFile.ID})
+		if !s.config.Upload.AsyncProcessing {
+			if processed, err := s.GetImage(uploadedFile.ID); err == nil {
+				return processed, nil
+			}
 		}
 	}
 
-	return result, nil
+	return &uploadedFile, nil
 }
 
 // DeleteImage deletes an uploaded image
@@ -423,27 +415,29 @@ func (s *Service) OptimizeImage(imageID, userID uint, req *ImageOptimizeRequest)
 		return nil, fmt.Errorf("file is not an image")
 	}
 
-	// Original file path
-	originalPath := filepath.Join(s.config.External.Storage.LocalPath, image.Path)
+	req.Format = strings.ToLower(strings.TrimSpace(req.Format))
+	if req.Format == "jpg" {
+		req.Format = "jpeg"
+	}
+	if _, ok := formatExtensions[req.Format]; req.Format != "" && !ok {
+		return nil, fmt.Errorf("unsupported output format '%s'. Supported formats: %v", req.Format, SupportedOutputFormats)
+	}
 
-	// Generate optimized filename
-	ext := filepath.Ext(image.Filename)
-	nameWithoutExt := strings.TrimSuffix(image.Filename, ext)
-	optimizedFilename := fmt.Sprintf("%s_optimized_%dx%d_q%d%s", nameWithoutExt, req.Width, req.Height, req.Quality, ext)
-	optimizedPath := filepath.Join(s.config.External.Storage.LocalPath, image.Category, optimizedFilename)
+	job := ImageProcessingJob{FileID: image.ID, Optimize: req}
 
-	// Optimize image
-	if err := s.optimizeImageFile(originalPath, optimizedPath, req); err != nil {
-		return nil, fmt.Errorf("failed to optimize image: %w", err)
+	if !s.config.Upload.AsyncProcessing {
+		if err := s.processImage(job); err != nil {
+			return nil, err
+		}
+		return s.GetImage(image.ID)
 	}
 
-	// Update database record
-	optimizedURL := s.getFileURL(filepath.Join(image.Category, optimizedFilename))
-	if err := s.db.Model(&image).Update("optimized_url", optimizedURL).Error; err != nil {
-		return nil, fmt.Errorf("failed to update optimized URL: %w", err)
-	}
+	// Queue optimization; optimized_url is populated when the worker finishes
+	s.setProcessingStatus(image.ID, ProcessingStatusPending, "")
+	s.enqueueProcessing(job)
 
-	image.OptimizedURL = optimizedURL
+	image.ProcessingStatus = ProcessingStatusPending
+	image.ProcessingError = ""
 	return &image, nil
 }
 
@@ -510,10 +504,13 @@ func (s *Service) GetUploadStats() (*UploadStats, error) {
 
 // Private helper methods
 
-func (s *Service) validateImageFile(header *multipart.FileHeader) error {
+// validateImageFile checks the upload against size and extension limits, then
+// sniffs its content so a renamed file cannot pass as an allowed type. It returns
+// the detected MIME type.
+func (s *Service) validateImageFile(header *multipart.FileHeader, file multipart.File) (string, error) {
 	// Check file size
 	if header.Size > s.config.Upload.MaxSize {
-		return fmt.Errorf("file size exceeds maximum allowed size of %s", s.formatFileSize(s.config.Upload.MaxSize))
+		return "", fmt.Errorf("file size exceeds maximum allowed size of %s", s.formatFileSize(s.config.Upload.MaxSize))
 	}
 
 	// Check file extension
@@ -531,10 +528,53 @@ func (s *Service) validateImageFile(header *multipart.FileHeader) error {
 	}
 
 	if !allowed {
-		return fmt.Errorf("file type '%s' is not allowed. Allowed types: %v", ext, s.config.Upload.AllowedExtensions)
+		return "", fmt.Errorf("file type '%s' is not allowed. Allowed types: %v", ext, s.config.Upload.AllowedExtensions)
+	}
+
+	// Check actual content, independent of the filename
+	mimeType, err := s.detectContentType(file)
+	if err != nil {
+		return "", err
+	}
+
+	for _, allowedExt := range s.config.Upload.AllowedExtensions {
+		if s.getMimeType("."+allowedExt) == mimeType {
+			return mimeType, nil
+		}
+	}
+
+	return "", fmt.Errorf("file content (%s) does not match an allowed file type", mimeType)
+}
+
+// hashFile returns the hex SHA-256 of the file contents and rewinds it
+func (s *Service) hashFile(file multipart.File) (string, error) {
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// detectContentType sniffs the first 512 bytes of the file and rewinds it
+func (s *Service) detectContentType(file multipart.File) (string, error) {
+	buffer := make([]byte, 512)
+	n, err := file.Read(buffer)
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	if n == 0 {
+		return "", fmt.Errorf("file is empty")
 	}
 
-	return nil
+	// Strip parameters such as "; charset=utf-8"
+	mimeType, _, _ := strings.Cut(http.DetectContentType(buffer[:n]), ";")
+	return strings.TrimSpace(mimeType), nil
 }
 
 func (s *Service) generateUniqueFilename(originalFilename string) string {
@@ -550,18 +590,6 @@ func (s *Service) generateUniqueFilename(originalFilename string) string {
 	return fmt.Sprintf("%s_%s%s", name, uuid.New().String()[:8], ext)
 }
 
-func (s *Service) isImageFile(filename string) bool {
-	ext := strings.ToLower(filepath.Ext(filename))
-	imageExts := []string{".jpg", ".jpeg", ".png", ".gif", ".webp", ".bmp"}
-
-	for _, imageExt := range imageExts {
-		if ext == imageExt {
-			return true
-		}
-	}
-	return false
-}
-
 func (s *Service) getMimeType(filename string) string {
 	ext := strings.ToLower(filepath.Ext(filename))
 
@@ -599,97 +627,121 @@ func (s *Service) getImageDimensions(filePath string) (int, int) {
 	return config.Width, config.Height
 }
 
-func (s *Service) generateThumbnail(originalPath, category, filename string) (string, error) {
+// generateThumbnail writes a resized copy of the original that fits within the
+// configured thumbnail box and returns its relative path and dimensions.
+// JPEG and PNG keep their format; GIFs are converted to PNG from their first frame.
+func (s *Service) generateThumbnail(originalPath, category, filename string) (string, int, int, error) {
 	// Open original image
 	file, err := os.Open(originalPath)
 	if err != nil {
-		return "", err
+		return "", 0, 0, err
 	}
 	defer file.Close()
 
 	// Decode image
 	img, format, err := image.Decode(file)
 	if err != nil {
-		return "", err
+		if errors.Is(err, image.ErrFormat) {
+			return "", 0, 0, ErrThumbnailUnsupported
+		}
+		return "", 0, 0, err
 	}
 
 	// Calculate thumbnail dimensions while maintaining aspect ratio
-	origWidth := img.Bounds().Dx()
-	origHeight := img.Bounds().Dy()
-
-	thumbWidth := s.config.Upload.ThumbnailWidth
-	thumbHeight := s.config.Upload.ThumbnailHeight
-
-	// Calculate scaling factor
-	scaleX := float64(thumbWidth) / float64(origWidth)
-	scaleY := float64(thumbHeight) / float64(origHeight)
-	scale := scaleX
-	if scaleY < scaleX {
-		scale = scaleY
+	newWidth, newHeight := fitWithin(
+		img.Bounds().Dx(), img.Bounds().Dy(),
+		s.config.Upload.ThumbnailWidth, s.config.Upload.ThumbnailHeight,
+	)
+	if newWidth == 0 || newHeight == 0 {
+		return "", 0, 0, fmt.Errorf("image has no pixels")
 	}
 
-	newWidth := int(float64(origWidth) * scale)
-	newHeight := int(float64(origHeight) * scale)
-
-	fmt.Println(newWidth, newHeight, format)
+	thumbnail := resizeImage(img, newWidth, newHeight)
 
-	// Create thumbnail (simplified - in production, use image processing library)
-	// For now, we'll just copy the original with a different name
 	ext := filepath.Ext(filename)
 	nameWithoutExt := strings.TrimSuffix(filename, ext)
+	if format == "gif" {
+		ext = ".png"
+	}
 	thumbnailFilename := fmt.Sprintf("%s_thumb%s", nameWithoutExt, ext)
 	thumbnailPath := filepath.Join(s.config.External.Storage.LocalPath, category, thumbnailFilename)
 
-	// In a real implementation, you'd resize the image here
-	// For simplicity, we're just copying the file
-	srcFile, _ := os.Open(originalPath)
-	defer srcFile.Close()
-
 	dstFile, err := os.Create(thumbnailPath)
 	if err != nil {
-		return "", err
+		return "", 0, 0, err
 	}
 	defer dstFile.Close()
 
-	_, err = io.Copy(dstFile, srcFile)
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(dstFile, thumbnail, &jpeg.Options{Quality: 85})
+	case "png", "gif":
+		err = png.Encode(dstFile, thumbnail)
+	default:
+		err = ErrThumbnailUnsupported
+	}
 	if err != nil {
-		return "", err
+		dstFile.Close()
+		os.Remove(thumbnailPath)
+		return "", 0, 0, err
 	}
 
 	// Return relative path for URL generation
-	return filepath.Join(category, thumbnailFilename), nil
+	return filepath.Join(category, thumbnailFilename), newWidth, newHeight, nil
 }
 
 func (s *Service) optimizeImageFile(originalPath, optimizedPath string, req *ImageOptimizeRequest) error {
-	// Open original image
-	file, err := os.Open(originalPath)
+	// Read original image
+	original, err := os.ReadFile(originalPath)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
 	// Decode image
-	img, format, err := image.Decode(file)
+	img, format, err := image.Decode(bytes.NewReader(original))
 	if err != nil {
 		return err
 	}
 
-	// Create optimized file
-	optimizedFile, err := os.Create(optimizedPath)
-	if err != nil {
-		return err
+	if req.Format != "" {
+		format = req.Format
 	}
-	defer optimizedFile.Close()
 
 	// Encode with specified quality (simplified implementation)
+	var optimized bytes.Buffer
 	switch format {
 	case "jpeg":
-		return jpeg.Encode(optimizedFile, img, &jpeg.Options{Quality: req.Quality})
+		err = jpeg.Encode(&optimized, img, &jpeg.Options{Quality: req.Quality})
 	case "png":
-		return png.Encode(optimizedFile, img)
+		err = png.Encode(&optimized, img)
+	case "webp":
+		err = encodeWebP(&optimized, img, req.Quality)
+		// The encoder is lossless, so photos can come out larger than their JPEG
+		if err == nil && optimized.Len() >= len(original) {
+			return ErrOptimizedNotSmaller
+		}
 	default:
 		return fmt.Errorf("unsupported image format: %s", format)
 	}
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(optimizedPath, optimized.Bytes(), 0644)
+}
+
+// ErrOptimizedNotSmaller is returned when a WebP conversion is no smaller than the
+// original image, which is then kept as the optimized version
+var ErrOptimizedNotSmaller = errors.New("optimized image is not smaller than the original")
+
+// SupportedOutputFormats lists the formats images can be converted to during optimization
+var SupportedOutputFormats = []string{"jpeg", "png", "webp"}
+
+// formatExtensions maps output formats to file extensions
+var formatExtensions = map[string]string{
+	"jpeg": ".jpg",
+	"png":  ".png",
+	"webp": ".webp",
 }
 
 func (s *Service) getFileURL(relativePath string) string {
@@ -0,0 +1,146 @@
+// internal/domain/upload/service_test.go
+package upload
+
+import (
+	"bytes"
+	"image/png"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func newTestService(t *testing.T, async bool) (*Service, *gorm.DB) {
+	t.Helper()
+	db := testutil.NewDB(t, &UploadedFile{})
+	cfg := &config.Config{}
+	cfg.External.Storage.LocalPath = t.TempDir()
+	cfg.Upload.MaxSize = 1 << 20
+	cfg.Upload.AllowedExtensions = []string{"jpg", "png"}
+	cfg.Upload.ThumbnailWidth = 30
+	cfg.Upload.ThumbnailHeight = 30
+	cfg.Upload.AsyncProcessing = async
+	return NewService(db, nil, cfg), db
+}
+
+// uploadRequest wraps data in a multipart file the way the upload handler receives it
+func uploadRequest(t *testing.T, filename string, data []byte) *ImageUploadRequest {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", filename)
+	part.Write(data)
+	writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+	header := form.File["file"][0]
+	file, err := header.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { file.Close() })
+	return &ImageUploadRequest{File: file, Header: header, UploadedBy: 1}
+}
+
+func pngBytes(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(width, height, false)); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUploadImageChecksContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+		wantErr  string
+	}{
+		{"png", "lamp.png", pngBytes(t, 4, 4), ""},
+		{"text renamed to png", "notes.png", []byte("just some notes, not an image\n"), "does not match an allowed file type"},
+		{"disallowed extension", "lamp.gif", pngBytes(t, 4, 4), "is not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, db := newTestService(t, false)
+
+			uploaded, err := s.UploadImage(uploadRequest(t, tt.filename, tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("UploadImage() error = %v, want %q", err, tt.wantErr)
+				}
+				var count int64
+				db.Model(&UploadedFile{}).Count(&count)
+				if count != 0 {
+					t.Errorf("rejected upload was recorded")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UploadImage() error = %v", err)
+			}
+			if uploaded.MimeType != "image/png" {
+				t.Errorf("MimeType = %s, want image/png", uploaded.MimeType)
+			}
+		})
+	}
+}
+
+func TestUploadImageDeduplicatesByContent(t *testing.T) {
+	s, db := newTestService(t, false)
+	data := pngBytes(t, 8, 8)
+
+	first, err := s.UploadImage(uploadRequest(t, "lamp.png", data))
+	if err != nil {
+		t.Fatalf("first UploadImage() error = %v", err)
+	}
+	second, err := s.UploadImage(uploadRequest(t, "lamp-copy.png", data))
+	if err != nil {
+		t.Fatalf("second UploadImage() error = %v", err)
+	}
+
+	if first.Deduplicated || !second.Deduplicated {
+		t.Errorf("Deduplicated = %v then %v, want false then true", first.Deduplicated, second.Deduplicated)
+	}
+	if second.ID != first.ID || second.URL != first.URL {
+		t.Errorf("second upload = %d at %s, want a reference to %d at %s", second.ID, second.URL, first.ID, first.URL)
+	}
+
+	originals, _ := filepath.Glob(filepath.Join(s.config.External.Storage.LocalPath, "general", "lamp*.png"))
+	var stored []string
+	for _, path := range originals {
+		if !strings.HasSuffix(path, "_thumb.png") {
+			stored = append(stored, path)
+		}
+	}
+	if len(stored) != 1 {
+		t.Errorf("stored files = %v, want one", stored)
+	}
+	var records int64
+	db.Model(&UploadedFile{}).Count(&records)
+	if records != 1 {
+		t.Errorf("records = %d, want 1", records)
+	}
+
+	// Another uploader gets their own copy
+	req := uploadRequest(t, "lamp.png", data)
+	req.UploadedBy = 2
+	other, err := s.UploadImage(req)
+	if err != nil {
+		t.Fatalf("other uploader's UploadImage() error = %v", err)
+	}
+	if other.Deduplicated || other.ID == first.ID {
+		t.Errorf("other uploader's upload = %d (deduplicated %v), want a new file", other.ID, other.Deduplicated)
+	}
+}
@@ -0,0 +1,672 @@
+// internal/domain/upload/webp.go
+package upload
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"io"
+	"math/bits"
+	"sort"
+)
+
+// encodeWebP writes img as a lossless (VP8L) WebP image. Quality below 90 enables
+// near-lossless quantization of the colour channels, trading fidelity for size.
+//
+// The encoder uses the subtract-green and predictor transforms with LZ77 backward
+// references and no colour cache, which keeps it small while giving reasonable
+// compression for product imagery.
+func encodeWebP(w io.Writer, img image.Image, quality int) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width < 1 || height < 1 || width > 1<<14 || height > 1<<14 {
+		return fmt.Errorf("image dimensions %dx%d are not supported by WebP", width, height)
+	}
+
+	nrgba := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(nrgba, nrgba.Bounds(), img, bounds.Min, draw.Src)
+
+	argb := make([]uint32, width*height)
+	hasAlpha := false
+	for i := range argb {
+		p := nrgba.Pix[i*4 : i*4+4]
+		if p[3] != 0xff {
+			hasAlpha = true
+		}
+		argb[i] = uint32(p[3])<<24 | uint32(p[0])<<16 | uint32(p[1])<<8 | uint32(p[2])
+	}
+
+	if shift := nearLosslessBits(quality); shift > 0 {
+		quantizeColors(argb, shift)
+	}
+
+	bw := &bitWriter{}
+	bw.writeBits(0x2f, 8) // VP8L signature
+	bw.writeBits(uint32(width-1), 14)
+	bw.writeBits(uint32(height-1), 14)
+	if hasAlpha {
+		bw.writeBits(1, 1)
+	} else {
+		bw.writeBits(0, 1)
+	}
+	bw.writeBits(0, 3) // version
+
+	// Subtract green transform
+	bw.writeBits(1, 1)
+	bw.writeBits(vp8lSubtractGreen, 2)
+	subtractGreen(argb)
+
+	// Predictor transform
+	bw.writeBits(1, 1)
+	bw.writeBits(vp8lPredictorTransform, 2)
+	bw.writeBits(vp8lPredictorBits-2, 3)
+	modes, tilesWide := choosePredictors(argb, width, height)
+	writeImageData(bw, modes, tilesWide, false)
+	residuals := applyPredictors(argb, width, height, modes, tilesWide)
+
+	bw.writeBits(0, 1) // no more transforms
+
+	writeImageData(bw, residuals, width, true)
+
+	data := bw.bytes()
+	padded := len(data) + len(data)&1
+
+	header := make([]byte, 20)
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(4+8+padded))
+	copy(header[8:12], "WEBP")
+	copy(header[12:16], "VP8L")
+	binary.LittleEndian.PutUint32(header[16:20], uint32(len(data)))
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if len(data)&1 == 1 {
+		if _, err := w.Write([]byte{0}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const (
+	vp8lPredictorTransform = 0
+	vp8lSubtractGreen      = 2
+	vp8lPredictorBits      = 4 // 16x16 predictor tiles
+
+	vp8lNumLiterals      = 256
+	vp8lNumLengthCodes   = 24
+	vp8lNumDistanceCodes = 40
+	vp8lMaxLength        = 4096
+	vp8lMinMatch         = 3
+	vp8lMaxDistance      = 1<<20 - 120
+	vp8lHashBits         = 16
+	vp8lMaxChainLength   = 32
+)
+
+// kCodeLengthCodeOrder is the order in which code length code lengths are stored
+var vp8lCodeLengthOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// nearLosslessBits maps a quality setting to the number of low bits dropped per colour channel
+func nearLosslessBits(quality int) uint {
+	switch {
+	case quality <= 0 || quality >= 90:
+		return 0
+	case quality >= 75:
+		return 1
+	case quality >= 50:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func quantizeColors(argb []uint32, shift uint) {
+	half := uint32(1) << (shift - 1)
+	maxValue := uint32(0xff) &^ (1<<shift - 1)
+	quantize := func(v uint32) uint32 {
+		v = (v + half) >> shift << shift
+		if v > maxValue {
+			v = maxValue
+		}
+		return v
+	}
+
+	for i, p := range argb {
+		r := quantize(p >> 16 & 0xff)
+		g := quantize(p >> 8 & 0xff)
+		b := quantize(p & 0xff)
+		argb[i] = p&0xff000000 | r<<16 | g<<8 | b
+	}
+}
+
+func subtractGreen(argb []uint32) {
+	for i, p := range argb {
+		g := p >> 8 & 0xff
+		r := (p>>16 - g) & 0xff
+		b := (p - g) & 0xff
+		argb[i] = p&0xff00ff00 | r<<16 | b
+	}
+}
+
+// choosePredictors picks the predictor mode per tile that minimises residual magnitude
+func choosePredictors(argb []uint32, width, height int) ([]uint32, int) {
+	tileSize := 1 << vp8lPredictorBits
+	tilesWide := (width + tileSize - 1) / tileSize
+	tilesHigh := (height + tileSize - 1) / tileSize
+	modes := make([]uint32, tilesWide*tilesHigh)
+
+	for ty := 0; ty < tilesHigh; ty++ {
+		for tx := 0; tx < tilesWide; tx++ {
+			bestMode, bestCost := 0, -1
+			for mode := 0; mode < 14; mode++ {
+				cost := 0
+				for y := ty * tileSize; y < height && y < (ty+1)*tileSize; y++ {
+					for x := tx * tileSize; x < width && x < (tx+1)*tileSize; x++ {
+						i := y*width + x
+						cost += residualCost(subPixels(argb[i], predict(argb, width, x, y, mode)))
+					}
+				}
+				if bestCost < 0 || cost < bestCost {
+					bestMode, bestCost = mode, cost
+				}
+			}
+			modes[ty*tilesWide+tx] = 0xff000000 | uint32(bestMode)<<8
+		}
+	}
+
+	return modes, tilesWide
+}
+
+func applyPredictors(argb []uint32, width, height int, modes []uint32, tilesWide int) []uint32 {
+	residuals := make([]uint32, len(argb))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			mode := int(modes[(y>>vp8lPredictorBits)*tilesWide+x>>vp8lPredictorBits] >> 8 & 0xf)
+			i := y*width + x
+			residuals[i] = subPixels(argb[i], predict(argb, width, x, y, mode))
+		}
+	}
+	return residuals
+}
+
+// predict returns the predicted pixel at (x, y) as defined by the VP8L predictor transform
+func predict(argb []uint32, width, x, y, mode int) uint32 {
+	i := y*width + x
+	switch {
+	case x == 0 && y == 0:
+		return 0xff000000
+	case y == 0:
+		return argb[i-1]
+	case x == 0:
+		return argb[i-width]
+	}
+
+	left := argb[i-1]
+	top := argb[i-width]
+	topLeft := argb[i-width-1]
+	topRight := argb[i-width+1] // For the last column this is the first pixel of the current row
+
+	switch mode {
+	case 0:
+		return 0xff000000
+	case 1:
+		return left
+	case 2:
+		return top
+	case 3:
+		return topRight
+	case 4:
+		return topLeft
+	case 5:
+		return average2(average2(left, topRight), top)
+	case 6:
+		return average2(left, topLeft)
+	case 7:
+		return average2(left, top)
+	case 8:
+		return average2(topLeft, top)
+	case 9:
+		return average2(top, topRight)
+	case 10:
+		return average2(average2(left, topLeft), average2(top, topRight))
+	case 11:
+		return selectPredictor(left, top, topLeft)
+	case 12:
+		return clampAddSubtractFull(left, top, topLeft)
+	default:
+		return clampAddSubtractHalf(average2(left, top), topLeft)
+	}
+}
+
+func channel(p uint32, shift uint) int {
+	return int(p >> shift & 0xff)
+}
+
+func average2(a, b uint32) uint32 {
+	var out uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		out |= uint32((channel(a, shift)+channel(b, shift))/2) << shift
+	}
+	return out
+}
+
+func selectPredictor(left, top, topLeft uint32) uint32 {
+	predLeft, predTop := 0, 0
+	for shift := uint(0); shift < 32; shift += 8 {
+		estimate := channel(left, shift) + channel(top, shift) - channel(topLeft, shift)
+		predLeft += absInt(estimate - channel(left, shift))
+		predTop += absInt(estimate - channel(top, shift))
+	}
+	if predLeft < predTop {
+		return left
+	}
+	return top
+}
+
+func clampAddSubtractFull(a, b, c uint32) uint32 {
+	var out uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		out |= uint32(clampByte(channel(a, shift)+channel(b, shift)-channel(c, shift))) << shift
+	}
+	return out
+}
+
+func clampAddSubtractHalf(a, b uint32) uint32 {
+	var out uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		ca := channel(a, shift)
+		out |= uint32(clampByte(ca+(ca-channel(b, shift))/2)) << shift
+	}
+	return out
+}
+
+func subPixels(a, b uint32) uint32 {
+	var out uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		out |= uint32((channel(a, shift)-channel(b, shift))&0xff) << shift
+	}
+	return out
+}
+
+func residualCost(p uint32) int {
+	cost := 0
+	for shift := uint(0); shift < 32; shift += 8 {
+		cost += absInt(int(int8(p >> shift & 0xff)))
+	}
+	return cost
+}
+
+func clampByte(v int) int {
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return v
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// vp8lToken is either a literal pixel or a backward reference
+type vp8lToken struct {
+	pixel    uint32
+	length   int // 0 for literals
+	distance int
+}
+
+// findBackwardRefs performs greedy LZ77 matching over the pixel stream using hash chains
+func findBackwardRefs(argb []uint32) []vp8lToken {
+	n := len(argb)
+	tokens := make([]vp8lToken, 0, n)
+	head := make([]int32, 1<<vp8lHashBits)
+	for i := range head {
+		head[i] = -1
+	}
+	prev := make([]int32, n)
+
+	hash := func(i int) uint32 {
+		return (argb[i]*0x9e3779b1 ^ argb[i+1]*0x85ebca6b) >> (32 - vp8lHashBits)
+	}
+	insert := func(i int) {
+		if i+1 < n {
+			h := hash(i)
+			prev[i] = head[h]
+			head[h] = int32(i)
+		}
+	}
+
+	for i := 0; i < n; {
+		bestLength, bestDistance := 0, 0
+		if i+1 < n {
+			maxLength := n - i
+			if maxLength > vp8lMaxLength {
+				maxLength = vp8lMaxLength
+			}
+			candidate := head[hash(i)]
+			for tries := 0; candidate >= 0 && tries < vp8lMaxChainLength; tries++ {
+				distance := i - int(candidate)
+				if distance > vp8lMaxDistance {
+					break
+				}
+				length := 0
+				for length < maxLength && argb[int(candidate)+length] == argb[i+length] {
+					length++
+				}
+				if length > bestLength {
+					bestLength, bestDistance = length, distance
+					if length == maxLength {
+						break
+					}
+				}
+				candidate = prev[candidate]
+			}
+		}
+
+		if bestLength >= vp8lMinMatch {
+			tokens = append(tokens, vp8lToken{length: bestLength, distance: bestDistance})
+			for k := 0; k < bestLength; k++ {
+				insert(i + k)
+			}
+			i += bestLength
+			continue
+		}
+
+		tokens = append(tokens, vp8lToken{pixel: argb[i]})
+		insert(i)
+		i++
+	}
+
+	return tokens
+}
+
+// prefixEncode splits a length or distance value into a prefix symbol and extra bits
+func prefixEncode(value int) (int, uint, uint32) {
+	d := value - 1
+	if d < 4 {
+		return d, 0, 0
+	}
+	highBit := bits.Len(uint(d)) - 1
+	secondBit := (d >> (highBit - 1)) & 1
+	extraBits := highBit - 1
+	return 2*highBit + secondBit, uint(extraBits), uint32(d & (1<<extraBits - 1))
+}
+
+// writeImageData writes an entropy-coded image: prefix codes followed by the pixel tokens
+func writeImageData(bw *bitWriter, argb []uint32, width int, isMain bool) {
+	bw.writeBits(0, 1) // no colour cache
+	if isMain {
+		bw.writeBits(0, 1) // single prefix code group
+	}
+
+	tokens := findBackwardRefs(argb)
+
+	green := make([]uint32, vp8lNumLiterals+vp8lNumLengthCodes)
+	red := make([]uint32, vp8lNumLiterals)
+	blue := make([]uint32, vp8lNumLiterals)
+	alpha := make([]uint32, vp8lNumLiterals)
+	dist := make([]uint32, vp8lNumDistanceCodes)
+
+	for _, t := range tokens {
+		if t.length == 0 {
+			green[t.pixel>>8&0xff]++
+			red[t.pixel>>16&0xff]++
+			blue[t.pixel&0xff]++
+			alpha[t.pixel>>24]++
+			continue
+		}
+		lengthCode, _, _ := prefixEncode(t.length)
+		distCode, _, _ := prefixEncode(t.distance + 120)
+		green[vp8lNumLiterals+lengthCode]++
+		dist[distCode]++
+	}
+
+	codes := make([]huffmanCode, 5)
+	for i, counts := range [][]uint32{green, red, blue, alpha, dist} {
+		codes[i] = buildHuffmanCode(counts, 15)
+		writeHuffmanCode(bw, codes[i].lengths)
+	}
+
+	for _, t := range tokens {
+		if t.length == 0 {
+			codes[0].write(bw, int(t.pixel>>8&0xff))
+			codes[1].write(bw, int(t.pixel>>16&0xff))
+			codes[2].write(bw, int(t.pixel&0xff))
+			codes[3].write(bw, int(t.pixel>>24))
+			continue
+		}
+		lengthCode, lengthBits, lengthExtra := prefixEncode(t.length)
+		codes[0].write(bw, vp8lNumLiterals+lengthCode)
+		bw.writeBits(lengthExtra, lengthBits)
+
+		distCode, distBits, distExtra := prefixEncode(t.distance + 120)
+		codes[4].write(bw, distCode)
+		bw.writeBits(distExtra, distBits)
+	}
+}
+
+// writeHuffmanCode stores code lengths using the normal (code length code) encoding
+func writeHuffmanCode(bw *bitWriter, lengths []uint8) {
+	type clToken struct {
+		code      int
+		extra     uint32
+		extraBits uint
+	}
+
+	var tokens []clToken
+	for i := 0; i < len(lengths); {
+		if lengths[i] != 0 {
+			tokens = append(tokens, clToken{code: int(lengths[i])})
+			i++
+			continue
+		}
+
+		run := 0
+		for i+run < len(lengths) && lengths[i+run] == 0 {
+			run++
+		}
+		i += run
+
+		for run >= 11 {
+			n := run
+			if n > 138 {
+				n = 138
+			}
+			tokens = append(tokens, clToken{code: 18, extra: uint32(n - 11), extraBits: 7})
+			run -= n
+		}
+		if run >= 3 {
+			tokens = append(tokens, clToken{code: 17, extra: uint32(run - 3), extraBits: 3})
+			run = 0
+		}
+		for ; run > 0; run-- {
+			tokens = append(tokens, clToken{code: 0})
+		}
+	}
+
+	counts := make([]uint32, len(vp8lCodeLengthOrder))
+	for _, t := range tokens {
+		counts[t.code]++
+	}
+	clCode := buildHuffmanCode(counts, 7)
+
+	numCodes := len(vp8lCodeLengthOrder)
+	for numCodes > 4 && clCode.lengths[vp8lCodeLengthOrder[numCodes-1]] == 0 {
+		numCodes--
+	}
+
+	bw.writeBits(0, 1) // normal code
+	bw.writeBits(uint32(numCodes-4), 4)
+	for i := 0; i < numCodes; i++ {
+		bw.writeBits(uint32(clCode.lengths[vp8lCodeLengthOrder[i]]), 3)
+	}
+	bw.writeBits(0, 1) // code lengths cover the whole alphabet
+
+	for _, t := range tokens {
+		clCode.write(bw, t.code)
+		if t.extraBits > 0 {
+			bw.writeBits(t.extra, t.extraBits)
+		}
+	}
+}
+
+// huffmanCode holds canonical prefix code lengths and bit-reversed codes
+type huffmanCode struct {
+	lengths []uint8
+	codes   []uint16
+}
+
+func (h huffmanCode) write(bw *bitWriter, symbol int) {
+	bw.writeBits(uint32(h.codes[symbol]), uint(h.lengths[symbol]))
+}
+
+// buildHuffmanCode builds a complete, length-limited canonical prefix code. At least
+// two symbols always receive a code so decoders never see a degenerate tree.
+func buildHuffmanCode(counts []uint32, maxLength int) huffmanCode {
+	weights := make([]uint32, len(counts))
+	copy(weights, counts)
+
+	used := 0
+	for _, c := range weights {
+		if c > 0 {
+			used++
+		}
+	}
+	for i := 0; used < 2 && i < len(weights); i++ {
+		if weights[i] == 0 {
+			weights[i] = 1
+			used++
+		}
+	}
+
+	var lengths []uint8
+	for {
+		lengths = huffmanLengths(weights)
+		longest := 0
+		for _, l := range lengths {
+			if int(l) > longest {
+				longest = int(l)
+			}
+		}
+		if longest <= maxLength {
+			break
+		}
+		// Flatten the distribution until the tree fits within maxLength
+		for i, c := range weights {
+			if c > 0 {
+				weights[i] = c/2 + 1
+			}
+		}
+	}
+
+	return huffmanCode{lengths: lengths, codes: canonicalCodes(lengths)}
+}
+
+func huffmanLengths(weights []uint32) []uint8 {
+	type node struct {
+		weight      uint64
+		left, right int
+		symbol      int
+	}
+
+	var nodes []node
+	for symbol, w := range weights {
+		if w > 0 {
+			nodes = append(nodes, node{weight: uint64(w), left: -1, right: -1, symbol: symbol})
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].weight < nodes[j].weight })
+
+	// Two-queue construction: leaves are sorted and merged nodes are produced in order
+	leaves := len(nodes)
+	nextLeaf, nextMerged := 0, leaves
+	pick := func() int {
+		if nextLeaf < leaves && (nextMerged >= len(nodes) || nodes[nextLeaf].weight <= nodes[nextMerged].weight) {
+			nextLeaf++
+			return nextLeaf - 1
+		}
+		nextMerged++
+		return nextMerged - 1
+	}
+	for i := 0; i < leaves-1; i++ {
+		a := pick()
+		b := pick()
+		nodes = append(nodes, node{weight: nodes[a].weight + nodes[b].weight, left: a, right: b, symbol: -1})
+	}
+
+	lengths := make([]uint8, len(weights))
+	var walk func(index, depth int)
+	walk = func(index, depth int) {
+		if nodes[index].left < 0 {
+			lengths[nodes[index].symbol] = uint8(depth)
+			return
+		}
+		walk(nodes[index].left, depth+1)
+		walk(nodes[index].right, depth+1)
+	}
+	walk(len(nodes)-1, 0)
+
+	return lengths
+}
+
+// canonicalCodes assigns canonical codes, bit-reversed for the LSB-first bit writer
+func canonicalCodes(lengths []uint8) []uint16 {
+	var lengthCount [16]uint16
+	for _, l := range lengths {
+		if l > 0 {
+			lengthCount[l]++
+		}
+	}
+
+	var nextCode [16]uint16
+	code := uint16(0)
+	for l := 1; l < 16; l++ {
+		code = (code + lengthCount[l-1]) << 1
+		nextCode[l] = code
+	}
+
+	codes := make([]uint16, len(lengths))
+	for symbol, l := range lengths {
+		if l > 0 {
+			codes[symbol] = bits.Reverse16(nextCode[l]) >> (16 - l)
+			nextCode[l]++
+		}
+	}
+	return codes
+}
+
+// bitWriter packs bits least-significant first, as required by VP8L
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+func (w *bitWriter) writeBits(value uint32, n uint) {
+	w.acc |= uint64(value) << w.nbits
+	w.nbits += n
+	for w.nbits >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.nbits -= 8
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	if w.nbits > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc, w.nbits = 0, 0
+	}
+	return w.buf
+}
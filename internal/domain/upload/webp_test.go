@@ -0,0 +1,121 @@
+// internal/domain/upload/webp_test.go
+package upload
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+// testImage returns a gradient with a few hard edges, similar to a product shot
+func testImage(width, height int, alpha bool) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBA{R: uint8(x * 255 / width), G: uint8(y * 255 / height), B: 128, A: 0xff}
+			if x > width/3 && x < width/2 && y > height/3 {
+				c = color.NRGBA{R: 200, G: 30, B: 30, A: 0xff}
+			}
+			if alpha && x < width/4 {
+				c.A = uint8(y * 255 / height)
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestEncodeWebPRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		alpha     bool
+		quality   int
+		tolerance int
+	}{
+		{"lossless", false, 100, 0},
+		{"lossless with alpha", true, 95, 0},
+		{"near lossless", false, 60, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := testImage(67, 45, tt.alpha)
+
+			var buf bytes.Buffer
+			if err := encodeWebP(&buf, src, tt.quality); err != nil {
+				t.Fatalf("encodeWebP() error = %v", err)
+			}
+			if raw := len(src.Pix); buf.Len() >= raw/2 {
+				t.Errorf("encoded size = %d bytes, want under half the raw %d bytes", buf.Len(), raw)
+			}
+
+			decoded, err := webp.Decode(&buf)
+			if err != nil {
+				t.Fatalf("webp.Decode() error = %v", err)
+			}
+			if decoded.Bounds() != src.Bounds() {
+				t.Fatalf("decoded bounds = %v, want %v", decoded.Bounds(), src.Bounds())
+			}
+
+			for y := 0; y < 45; y++ {
+				for x := 0; x < 67; x++ {
+					want := src.NRGBAAt(x, y)
+					got := color.NRGBAModel.Convert(decoded.At(x, y)).(color.NRGBA)
+					if !withinTolerance(got, want, tt.tolerance) {
+						t.Fatalf("pixel (%d, %d) = %v, want %v", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func withinTolerance(got, want color.NRGBA, tolerance int) bool {
+	if want.A == 0 {
+		return got.A == 0
+	}
+	for _, d := range []int{
+		int(got.R) - int(want.R), int(got.G) - int(want.G),
+		int(got.B) - int(want.B), int(got.A) - int(want.A),
+	} {
+		if d < -tolerance || d > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func TestOptimizeImageFileKeepsSmallerOriginal(t *testing.T) {
+	// Noise compresses far better as a lossy JPEG than as a lossless WebP
+	src := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	for i := range src.Pix {
+		src.Pix[i] = uint8(i * 7919 % 251)
+	}
+	var original bytes.Buffer
+	if err := jpeg.Encode(&original, src, &jpeg.Options{Quality: 50}); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	originalPath := filepath.Join(dir, "noise.jpg")
+	optimizedPath := filepath.Join(dir, "noise.webp")
+	if err := os.WriteFile(originalPath, original.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Service{}
+	err := s.optimizeImageFile(originalPath, optimizedPath, &ImageOptimizeRequest{Format: "webp", Quality: 100})
+	if !errors.Is(err, ErrOptimizedNotSmaller) {
+		t.Fatalf("optimizeImageFile() error = %v, want ErrOptimizedNotSmaller", err)
+	}
+	if _, err := os.Stat(optimizedPath); !os.IsNotExist(err) {
+		t.Errorf("optimized file was written, stat error = %v", err)
+	}
+}
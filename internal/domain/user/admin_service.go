@@ -9,21 +9,27 @@ import (
 	"strings"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/pkg/auth"
 	"gorm.io/gorm"
 )
 
 // AdminService handles admin user management operations
 type AdminService struct {
-	db     *gorm.DB
-	config *config.Config
+	db          *gorm.DB
+	config      *config.Config
+	jwtManager  *auth.JWTManager
+	redisClient *redis.Client
 }
 
 // NewAdminService creates a new admin user service
-func NewAdminService(db *gorm.DB, cfg *config.Config) *AdminService {
+func NewAdminService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *AdminService {
 	return &AdminService{
-		db:     db,
-		config: cfg,
+		db:          db,
+		config:      cfg,
+		jwtManager:  auth.NewJWTManager(cfg).WithTokenStore(auth.NewTokenStore(redisClient)),
+		redisClient: redisClient,
 	}
 }
 
@@ -73,7 +79,7 @@ type UserAdminToggleRequest struct {
 
 // UserExportRequest represents user export parameters
 type UserExportRequest struct {
-	Format        string `form:"format,default=csv"` // csv, json
+	Format        string `form:"format,default=csv" binding:"omitempty,oneof=csv json"`
 	Status        string `form:"status"`
 	Role          string `form:"role"`
 	DateFrom      string `form:"date_from"`
@@ -424,9 +430,10 @@ func (s *AdminService) generateCSVExport(users []User, includeStats bool) ([]byt
 	return []byte(csvData.String()), filename, nil
 }
 
-// generateJSONExport generates JSON export
+// generateJSONExport generates JSON export: an array with one object per user,
+// holding the same columns and stats as the CSV export
 func (s *AdminService) generateJSONExport(users []User, includeStats bool) ([]byte, string, error) {
-	var exportData []interface{}
+	exportData := make([]map[string]interface{}, 0, len(users)) // Encodes as [] rather than null when empty
 
 	for _, user := range users {
 		// Clear password
@@ -456,12 +463,7 @@ func (s *AdminService) generateJSONExport(users []User, includeStats bool) ([]by
 		exportData = append(exportData, userData)
 	}
 
-	jsonData, err := json.MarshalIndent(map[string]interface{}{
-		"exported_at": time.Now(),
-		"total_users": len(users),
-		"users":       exportData,
-	}, "", "  ")
-
+	jsonData, err := json.MarshalIndent(exportData, "", "  ")
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to generate JSON: %w", err)
 	}
@@ -0,0 +1,48 @@
+// internal/domain/user/admin_service_test.go
+package user
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestGenerateJSONExportEmpty(t *testing.T) {
+	s := &AdminService{}
+	data, filename, err := s.generateJSONExport(nil, false)
+	if err != nil {
+		t.Fatalf("generateJSONExport() error = %v", err)
+	}
+	if string(data) != "[]" {
+		t.Errorf("empty export = %s, want []", data)
+	}
+	if !strings.HasSuffix(filename, ".json") {
+		t.Errorf("filename = %q, want a .json file", filename)
+	}
+}
+
+func TestGenerateJSONExportUsers(t *testing.T) {
+	s := &AdminService{}
+	users := []User{
+		{ID: 1, Email: "a@example.com", FirstName: "Ann", Password: "hash"},
+		{ID: 2, Email: "b@example.com", FirstName: "Ben", IsAdmin: true},
+	}
+	data, _, err := s.generateJSONExport(users, false)
+	if err != nil {
+		t.Fatalf("generateJSONExport() error = %v", err)
+	}
+	if strings.Contains(string(data), "hash") || strings.Contains(string(data), "password") {
+		t.Errorf("export leaks the password: %s", data)
+	}
+
+	var exported []map[string]interface{}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("export is not a JSON array: %v\n%s", err, data)
+	}
+	if len(exported) != 2 || exported[0]["email"] != "a@example.com" || exported[1]["is_admin"] != true {
+		t.Errorf("export = %v", exported)
+	}
+	if _, ok := exported[0]["order_count"]; ok {
+		t.Error("stats exported without include_stats")
+	}
+}
@@ -0,0 +1,81 @@
+// internal/domain/user/email_change.go
+package user
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrEmailInUse is returned when the requested email already belongs to an account
+var ErrEmailInUse = errors.New("email is already in use")
+
+// ChangeEmailRequest asks to move the account to a new email address
+type ChangeEmailRequest struct {
+	NewEmail string `json:"new_email" binding:"required,email"`
+	Password string `json:"password" binding:"required"` // Current password, to confirm the request
+}
+
+// RequestEmailChange checks that the user may move to newEmail and returns the user
+// and the normalized address. Nothing changes until ConfirmEmailChange is called
+// from the link sent to the new address.
+func (s *Service) RequestEmailChange(userID uint, req *ChangeEmailRequest) (*User, string, error) {
+	var user User
+	if err := s.db.Where("id = ? AND is_active = ?", userID, true).First(&user).Error; err != nil {
+		return nil, "", fmt.Errorf("user not found")
+	}
+
+	if err := s.passwordManager.VerifyPassword(req.Password, user.Password); err != nil {
+		return nil, "", fmt.Errorf("password is incorrect")
+	}
+
+	newEmail := strings.ToLower(strings.TrimSpace(req.NewEmail))
+	if newEmail == strings.ToLower(user.Email) {
+		return nil, "", fmt.Errorf("new email is the same as the current email")
+	}
+	if err := s.checkEmailAvailable(newEmail, userID); err != nil {
+		return nil, "", err
+	}
+
+	user.Password = ""
+	return &user, newEmail, nil
+}
+
+// ConfirmEmailChange moves the account to newEmail once the user has followed the
+// link sent there. Following the link proves the address, so it is marked verified.
+func (s *Service) ConfirmEmailChange(userID uint, newEmail string) error {
+	// The address may have been taken since the link was sent
+	if err := s.checkEmailAvailable(newEmail, userID); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	result := s.db.Model(&User{}).Where("id = ? AND is_active = ?", userID, true).Updates(map[string]interface{}{
+		"email":             newEmail,
+		"email_verified":    true,
+		"email_verified_at": now,
+		"updated_at":        now,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to change email: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+	return nil
+}
+
+// checkEmailAvailable fails when another account, including a deleted one, uses the email
+func (s *Service) checkEmailAvailable(email string, userID uint) error {
+	var count int64
+	if err := s.db.Unscoped().Model(&User{}).
+		Where("LOWER(email) = ? AND id != ?", strings.ToLower(email), userID).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check email: %w", err)
+	}
+	if count > 0 {
+		return ErrEmailInUse
+	}
+	return nil
+}
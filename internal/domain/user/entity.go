@@ -27,6 +27,11 @@ type User struct {
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// Two-factor authentication; the TOTP secret is stored encrypted
+	TwoFactorEnabled  bool   `gorm:"default:false" json:"two_factor_enabled"`
+	TwoFactorSecret   string `gorm:"size:255" json:"-"`
+	TwoFactorLastStep int64  `gorm:"default:0" json:"-"` // TOTP time step of the last accepted code
+
 	// Relationships
 	Addresses []Address `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"addresses,omitempty"`
 }
@@ -51,6 +56,16 @@ type Address struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// RecoveryCode is a single-use code that stands in for a TOTP code when the
+// user has lost their authenticator
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"size:64;not null" json:"-"` // SHA-256 of the code
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
 // TableName overrides the table name for User
 func (User) TableName() string {
 	return "users"
@@ -61,6 +76,11 @@ func (Address) TableName() string {
 	return "addresses"
 }
 
+// TableName overrides the table name for RecoveryCode
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
+
 // BeforeCreate hook to handle business logic before user creation
 func (u *User) BeforeCreate(tx *gorm.DB) error {
 	// Email should be lowercase
@@ -0,0 +1,174 @@
+// internal/domain/user/gdpr.go
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"github.com/your-org/ecommerce-backend/internal/domain/upload"
+	"gorm.io/gorm"
+)
+
+// ErrUserNotFound is returned when the user does not exist
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrCannotAnonymize is returned when anonymizing the account would lock out administration
+var ErrCannotAnonymize = errors.New("user cannot be anonymized")
+
+// anonymizedEmailDomain keeps anonymized emails unique and undeliverable
+const anonymizedEmailDomain = "anonymized.invalid"
+
+// UserDataExport bundles everything stored about a user for a data-subject access request
+type UserDataExport struct {
+	ExportedAt time.Time                `json:"exported_at"`
+	Profile    User                     `json:"profile"`
+	Addresses  []Address                `json:"addresses"`
+	Orders     []map[string]interface{} `json:"orders"` // Each with its "items"
+	Reviews    []product.ProductReview  `json:"reviews"`
+	Files      []upload.UploadedFile    `json:"files"`
+}
+
+// ExportUserData returns a user's profile, addresses, orders, reviews and uploaded
+// files as a JSON document along with its download filename
+func (s *AdminService) ExportUserData(userID uint) ([]byte, string, error) {
+	var user User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", ErrUserNotFound
+		}
+		return nil, "", fmt.Errorf("failed to get user: %w", err)
+	}
+	user.Password = ""
+
+	export := UserDataExport{
+		ExportedAt: time.Now().UTC(),
+		Profile:    user,
+		Addresses:  []Address{},
+		Orders:     []map[string]interface{}{},
+		Reviews:    []product.ProductReview{},
+		Files:      []upload.UploadedFile{},
+	}
+
+	if err := s.db.Where("user_id = ?", userID).Order("id ASC").Find(&export.Addresses).Error; err != nil {
+		return nil, "", fmt.Errorf("failed to get addresses: %w", err)
+	}
+
+	orders, err := s.exportUserOrders(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	export.Orders = orders
+
+	if err := s.db.Preload("Images").Where("user_id = ?", userID).Order("created_at ASC").Find(&export.Reviews).Error; err != nil {
+		return nil, "", fmt.Errorf("failed to get reviews: %w", err)
+	}
+
+	if err := s.db.Where("uploaded_by = ?", userID).Order("created_at ASC").Find(&export.Files).Error; err != nil {
+		return nil, "", fmt.Errorf("failed to get uploaded files: %w", err)
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate JSON: %w", err)
+	}
+
+	filename := fmt.Sprintf("user_%d_data_%s.json", userID, time.Now().Format("2006-01-02_15-04-05"))
+	return data, filename, nil
+}
+
+// exportUserOrders reads the user's orders and their items as plain rows; the order
+// package depends on this one, so its models cannot be used here
+func (s *AdminService) exportUserOrders(userID uint) ([]map[string]interface{}, error) {
+	orders := []map[string]interface{}{}
+	if err := s.db.Table("orders").Where("user_id = ? AND deleted_at IS NULL", userID).Order("id ASC").Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+	if len(orders) == 0 {
+		return orders, nil
+	}
+
+	orderIDs := make([]interface{}, len(orders))
+	byID := make(map[string]map[string]interface{}, len(orders))
+	for i, order := range orders {
+		orderIDs[i] = order["id"]
+		order["items"] = []map[string]interface{}{}
+		byID[fmt.Sprint(order["id"])] = order
+	}
+
+	var items []map[string]interface{}
+	if err := s.db.Table("order_items").Where("order_id IN ?", orderIDs).Order("id ASC").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to get order items: %w", err)
+	}
+	for _, item := range items {
+		if order, ok := byID[fmt.Sprint(item["order_id"])]; ok {
+			order["items"] = append(order["items"].([]map[string]interface{}), item)
+		}
+	}
+
+	return orders, nil
+}
+
+// AnonymizeUser erases a user's personal data for a right-to-erasure request: the
+// profile is scrubbed and deactivated and saved addresses are deleted. Orders are
+// kept for accounting with the contact details removed; amounts, items and the
+// city/state/postal code/country used for tax are left intact.
+func (s *AdminService) AnonymizeUser(userID, adminID uint) error {
+	if userID == adminID {
+		return fmt.Errorf("%w: cannot anonymize your own account", ErrCannotAnonymize)
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var user User
+		if err := tx.First(&user, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		if user.IsAdmin {
+			var adminCount int64
+			tx.Model(&User{}).Where("is_admin = ? AND id != ?", true, userID).Count(&adminCount)
+			if adminCount == 0 {
+				return fmt.Errorf("%w: at least one admin must remain", ErrCannotAnonymize)
+			}
+		}
+
+		email := fmt.Sprintf("deleted-user-%d@%s", userID, anonymizedEmailDomain)
+		if err := tx.Model(&user).Updates(map[string]interface{}{
+			"email":             email,
+			"password":          "", // No password hash matches, so the account cannot sign in
+			"first_name":        "",
+			"last_name":         "",
+			"phone":             "",
+			"avatar":            "",
+			"date_of_birth":     nil,
+			"is_active":         false,
+			"is_admin":          false,
+			"email_verified":    false,
+			"email_verified_at": nil,
+			"updated_at":        time.Now(),
+		}).Error; err != nil {
+			return fmt.Errorf("failed to anonymize user: %w", err)
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&Address{}).Error; err != nil {
+			return fmt.Errorf("failed to delete addresses: %w", err)
+		}
+
+		orderUpdates := map[string]interface{}{"email": email}
+		for _, prefix := range []string{"shipping_", "billing_"} {
+			for _, column := range []string{"first_name", "last_name", "company", "address_line1", "address_line2", "phone"} {
+				orderUpdates[prefix+column] = ""
+			}
+		}
+		if err := tx.Table("orders").Where("user_id = ?", userID).Updates(orderUpdates).Error; err != nil {
+			return fmt.Errorf("failed to anonymize orders: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,203 @@
+// internal/domain/user/gdpr_test.go
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"github.com/your-org/ecommerce-backend/internal/domain/upload"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+	"gorm.io/gorm"
+)
+
+// gdprOrder and gdprOrderItem stand in for the order package's models, which
+// can't be imported here, with the columns anonymization touches
+type gdprOrder struct {
+	ID                   uint
+	UserID               *uint
+	OrderNumber          string
+	Email                string
+	TotalAmount          int64
+	ShippingFirstName    string
+	ShippingLastName     string
+	ShippingCompany      string
+	ShippingAddressLine1 string
+	ShippingAddressLine2 string
+	ShippingCity         string
+	ShippingPostalCode   string
+	ShippingPhone        string
+	BillingFirstName     string
+	BillingLastName      string
+	BillingCompany       string
+	BillingAddressLine1  string
+	BillingAddressLine2  string
+	BillingPhone         string
+	DeletedAt            gorm.DeletedAt
+}
+
+func (gdprOrder) TableName() string { return "orders" }
+
+type gdprOrderItem struct {
+	ID         uint
+	OrderID    uint
+	Name       string
+	Quantity   int
+	TotalPrice int64
+}
+
+func (gdprOrderItem) TableName() string { return "order_items" }
+
+func newTestAdminService(t *testing.T) (*AdminService, *gorm.DB) {
+	t.Helper()
+	db := testutil.NewDB(t, &User{}, &Address{}, &gdprOrder{}, &gdprOrderItem{}, &product.ProductReview{},
+		&product.ProductReviewImage{}, &upload.UploadedFile{})
+	redisClient, _ := testutil.NewRedis(t)
+	cfg := &config.Config{}
+	cfg.JWT.Secret = "test-secret"
+	return NewAdminService(db, redisClient, cfg), db
+}
+
+// createCustomerData gives u an address, an order with an item, a review and an upload
+func createCustomerData(t *testing.T, db *gorm.DB, u *User) *gdprOrder {
+	t.Helper()
+	u.Phone = "+91 98765 43210"
+	db.Save(u)
+	db.Create(&Address{UserID: u.ID, Type: "shipping", FirstName: u.FirstName, LastName: u.LastName,
+		AddressLine1: "12 MG Road", City: "Bengaluru", State: "KA", PostalCode: "560001", Country: "IN"})
+	ord := &gdprOrder{UserID: &u.ID, OrderNumber: "ORD-1", Email: u.Email, TotalAmount: 5000,
+		ShippingFirstName: u.FirstName, ShippingLastName: u.LastName, ShippingAddressLine1: "12 MG Road",
+		ShippingCity: "Bengaluru", ShippingPostalCode: "560001", ShippingPhone: u.Phone,
+		BillingFirstName: u.FirstName, BillingLastName: u.LastName, BillingAddressLine1: "12 MG Road", BillingPhone: u.Phone}
+	if err := db.Create(ord).Error; err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&gdprOrderItem{OrderID: ord.ID, Name: "Lamp", Quantity: 2, TotalPrice: 5000})
+	db.Create(&product.ProductReview{ProductID: 1, UserID: u.ID, Rating: 5, Title: "Bright"})
+	db.Create(&upload.UploadedFile{OriginalName: "lamp.jpg", Filename: "lamp_1.jpg", Path: "reviews/lamp_1.jpg",
+		URL: "/uploads/reviews/lamp_1.jpg", MimeType: "image/jpeg", Size: 10, UploadedBy: u.ID})
+	return ord
+}
+
+func TestExportUserData(t *testing.T) {
+	s, db := newTestAdminService(t)
+	u := createTestUser(t, db, "shopper@example.com")
+	ord := createCustomerData(t, db, u)
+	// Another customer's data stays out of the export
+	other := createTestUser(t, db, "other@example.com")
+	createCustomerData(t, db, other)
+
+	data, filename, err := s.ExportUserData(u.ID)
+	if err != nil {
+		t.Fatalf("ExportUserData() error = %v", err)
+	}
+	if filename == "" {
+		t.Error("ExportUserData() returned no filename")
+	}
+
+	var export struct {
+		Profile   map[string]interface{}   `json:"profile"`
+		Addresses []Address                `json:"addresses"`
+		Orders    []map[string]interface{} `json:"orders"`
+		Reviews   []product.ProductReview  `json:"reviews"`
+		Files     []upload.UploadedFile    `json:"files"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("export is not valid JSON: %v", err)
+	}
+
+	if export.Profile["email"] != u.Email || export.Profile["phone"] != u.Phone {
+		t.Errorf("profile = %v, want %s's", export.Profile, u.Email)
+	}
+	if _, ok := export.Profile["password"]; ok {
+		t.Error("profile includes the password hash")
+	}
+	if len(export.Addresses) != 1 || export.Addresses[0].UserID != u.ID {
+		t.Errorf("addresses = %+v, want the user's one", export.Addresses)
+	}
+	if len(export.Orders) != 1 || export.Orders[0]["order_number"] != "ORD-1" || export.Orders[0]["id"] != float64(ord.ID) {
+		t.Fatalf("orders = %v, want ORD-1", export.Orders)
+	}
+	if items, _ := export.Orders[0]["items"].([]interface{}); len(items) != 1 {
+		t.Errorf("order items = %v, want one", export.Orders[0]["items"])
+	}
+	if len(export.Reviews) != 1 || export.Reviews[0].UserID != u.ID {
+		t.Errorf("reviews = %+v, want the user's one", export.Reviews)
+	}
+	if len(export.Files) != 1 || export.Files[0].UploadedBy != u.ID {
+		t.Errorf("files = %+v, want the user's one", export.Files)
+	}
+
+	if _, _, err := s.ExportUserData(999); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("ExportUserData(missing) error = %v, want %v", err, ErrUserNotFound)
+	}
+}
+
+func TestAnonymizeUserKeepsOrders(t *testing.T) {
+	s, db := newTestAdminService(t)
+	admin := createTestUser(t, db, "admin@example.com")
+	u := createTestUser(t, db, "shopper@example.com")
+	ord := createCustomerData(t, db, u)
+
+	if err := s.AnonymizeUser(u.ID, admin.ID); err != nil {
+		t.Fatalf("AnonymizeUser() error = %v", err)
+	}
+
+	var scrubbed User
+	db.First(&scrubbed, u.ID)
+	if scrubbed.Email != fmt.Sprintf("deleted-user-%d@anonymized.invalid", u.ID) || scrubbed.FirstName != "" ||
+		scrubbed.LastName != "" || scrubbed.Phone != "" || scrubbed.Password != "" || scrubbed.IsActive {
+		t.Errorf("user = %+v, want PII cleared and deactivated", scrubbed)
+	}
+	var addresses int64
+	db.Model(&Address{}).Where("user_id = ?", u.ID).Count(&addresses)
+	if addresses != 0 {
+		t.Errorf("addresses = %d, want 0", addresses)
+	}
+
+	var kept gdprOrder
+	if err := db.First(&kept, ord.ID).Error; err != nil {
+		t.Fatalf("order was removed: %v", err)
+	}
+	if kept.TotalAmount != 5000 || kept.ShippingCity != "Bengaluru" || kept.ShippingPostalCode != "560001" {
+		t.Errorf("order = %+v, want amounts and tax location kept", kept)
+	}
+	if kept.Email != scrubbed.Email || kept.ShippingFirstName != "" || kept.ShippingAddressLine1 != "" ||
+		kept.ShippingPhone != "" || kept.BillingLastName != "" || kept.BillingPhone != "" {
+		t.Errorf("order = %+v, want contact details cleared", kept)
+	}
+	var items int64
+	db.Model(&gdprOrderItem{}).Where("order_id = ?", ord.ID).Count(&items)
+	if items != 1 {
+		t.Errorf("order items = %d, want 1", items)
+	}
+}
+
+func TestAnonymizeUserKeepsAnAdmin(t *testing.T) {
+	s, db := newTestAdminService(t)
+	admin := createTestUser(t, db, "admin@example.com")
+	db.Model(admin).Update("is_admin", true)
+
+	if err := s.AnonymizeUser(admin.ID, admin.ID); !errors.Is(err, ErrCannotAnonymize) {
+		t.Errorf("anonymizing yourself = %v, want %v", err, ErrCannotAnonymize)
+	}
+	// A caller whose admin rights have since been removed
+	if err := s.AnonymizeUser(admin.ID, 999); !errors.Is(err, ErrCannotAnonymize) {
+		t.Errorf("anonymizing the last admin = %v, want %v", err, ErrCannotAnonymize)
+	}
+	var unchanged User
+	db.First(&unchanged, admin.ID)
+	if unchanged.Email != admin.Email || !unchanged.IsAdmin {
+		t.Errorf("last admin = %+v, want it left alone", unchanged)
+	}
+
+	// With another admin left it goes ahead
+	other := createTestUser(t, db, "other-admin@example.com")
+	db.Model(other).Update("is_admin", true)
+	if err := s.AnonymizeUser(admin.ID, other.ID); err != nil {
+		t.Errorf("AnonymizeUser() with another admin = %v, want success", err)
+	}
+}
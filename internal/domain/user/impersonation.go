@@ -0,0 +1,58 @@
+// internal/domain/user/impersonation.go
+package user
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// ErrCannotImpersonate is returned when the target account may not be impersonated
+var ErrCannotImpersonate = errors.New("user cannot be impersonated")
+
+// ImpersonationResponse carries a token that lets a support agent act as a customer
+type ImpersonationResponse struct {
+	User           *User  `json:"user"`
+	AccessToken    string `json:"access_token"`
+	ExpiresIn      int64  `json:"expires_in"`
+	ImpersonatedBy uint   `json:"impersonated_by"`
+}
+
+// ImpersonateUser issues a short-lived access token for userID on behalf of adminID.
+// Admin accounts and inactive accounts cannot be impersonated.
+func (s *AdminService) ImpersonateUser(userID, adminID uint) (*ImpersonationResponse, error) {
+	if userID == adminID {
+		return nil, fmt.Errorf("%w: cannot impersonate yourself", ErrCannotImpersonate)
+	}
+
+	var user User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsAdmin {
+		return nil, fmt.Errorf("%w: admin accounts cannot be impersonated", ErrCannotImpersonate)
+	}
+	if !user.IsActive {
+		return nil, fmt.Errorf("%w: account is inactive", ErrCannotImpersonate)
+	}
+
+	token, err := s.jwtManager.GenerateImpersonationToken(user.ID, user.Email, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+
+	log.Printf("Impersonation started: admin %d as user %d", adminID, user.ID)
+
+	user.Password = ""
+	return &ImpersonationResponse{
+		User:           &user,
+		AccessToken:    token,
+		ExpiresIn:      int64(s.config.JWT.ImpersonationTokenExpiry.Seconds()),
+		ImpersonatedBy: adminID,
+	}, nil
+}
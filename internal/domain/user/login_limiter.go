@@ -0,0 +1,240 @@
+// internal/domain/user/login_limiter.go
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// lockoutMemory is how long past lockouts count towards lengthening the next one
+const lockoutMemory = 24 * time.Hour
+
+// ErrAccountLocked is the sentinel behind AccountLockedError
+var ErrAccountLocked = errors.New("account temporarily locked due to too many failed login attempts")
+
+// AccountLockedError is returned by Login while the account is locked
+type AccountLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *AccountLockedError) Error() string {
+	return fmt.Sprintf("%s, try again in %s", ErrAccountLocked.Error(), e.RetryAfter.Round(time.Second))
+}
+
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+func loginFailuresKey(email, clientIP string) string {
+	return fmt.Sprintf("auth:login_failures:%s:%s", normalizeLoginEmail(email), clientIP)
+}
+
+// loginLockKey is per client IP as well as email, so failures from one client can't
+// lock the account owner out everywhere else
+func loginLockKey(email, clientIP string) string {
+	return fmt.Sprintf("auth:login_lock:%s:%s", normalizeLoginEmail(email), clientIP)
+}
+
+func loginLockoutsKey(email, clientIP string) string {
+	return fmt.Sprintf("auth:login_lockouts:%s:%s", normalizeLoginEmail(email), clientIP)
+}
+
+// Account-wide counterparts of the keys above, counting failures from every client
+func accountLoginFailuresKey(email string) string {
+	return fmt.Sprintf("auth:account_login_failures:%s", normalizeLoginEmail(email))
+}
+
+func accountLoginLockKey(email string) string {
+	return fmt.Sprintf("auth:account_login_lock:%s", normalizeLoginEmail(email))
+}
+
+func accountLoginLockoutsKey(email string) string {
+	return fmt.Sprintf("auth:account_login_lockouts:%s", normalizeLoginEmail(email))
+}
+
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// escapeGlob escapes the characters SCAN MATCH treats as wildcards
+var escapeGlob = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`).Replace
+
+// loginLimit is one failure counter and the lock it leads to
+type loginLimit struct {
+	failuresKey string
+	lockKey     string
+	lockoutsKey string
+	maxAttempts int
+	scope       string // Who the lock applies to, for the log
+}
+
+// loginLimits returns the enabled limits for the email and client: per client IP,
+// and across all clients
+func (s *Service) loginLimits(email, clientIP string) []loginLimit {
+	var limits []loginLimit
+	if attempts := s.config.Security.LoginMaxAttempts; attempts > 0 {
+		limits = append(limits, loginLimit{
+			failuresKey: loginFailuresKey(email, clientIP),
+			lockKey:     loginLockKey(email, clientIP),
+			lockoutsKey: loginLockoutsKey(email, clientIP),
+			maxAttempts: attempts,
+			scope:       "from " + clientIP,
+		})
+	}
+	if attempts := s.config.Security.LoginAccountMaxAttempts; attempts > 0 {
+		limits = append(limits, loginLimit{
+			failuresKey: accountLoginFailuresKey(email),
+			lockKey:     accountLoginLockKey(email),
+			lockoutsKey: accountLoginLockoutsKey(email),
+			maxAttempts: attempts,
+			scope:       "for all clients",
+		})
+	}
+	return limits
+}
+
+func (s *Service) loginLimitEnabled() bool {
+	return s.redisClient != nil &&
+		(s.config.Security.LoginMaxAttempts > 0 || s.config.Security.LoginAccountMaxAttempts > 0)
+}
+
+// checkLoginLock returns an AccountLockedError while the account is locked for the
+// client or for everyone, retrying after the longer of the two
+func (s *Service) checkLoginLock(email, clientIP string) error {
+	if !s.loginLimitEnabled() {
+		return nil
+	}
+
+	var retryAfter time.Duration
+	for _, limit := range s.loginLimits(email, clientIP) {
+		ttl, err := s.redisClient.TTL(context.Background(), limit.lockKey).Result()
+		if err != nil {
+			log.Printf("Failed to check login lock: %v", err)
+			continue
+		}
+		retryAfter = max(retryAfter, ttl)
+	}
+	if retryAfter > 0 {
+		return &AccountLockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed attempt against each limit and locks the account
+// for the client, or for everyone, once a limit is reached. It returns the error to
+// report: cause, or the lockout that the attempt triggered. Each lockout within
+// lockoutMemory doubles the next one.
+func (s *Service) recordLoginFailure(email, clientIP string, cause error) error {
+	if !s.loginLimitEnabled() {
+		return cause
+	}
+
+	var locked *AccountLockedError
+	for _, limit := range s.loginLimits(email, clientIP) {
+		duration, ok := s.countLoginFailure(limit)
+		if !ok {
+			continue
+		}
+		log.Printf("Account %s locked %s for %s", normalizeLoginEmail(email), limit.scope, duration)
+		if locked == nil || duration > locked.RetryAfter {
+			locked = &AccountLockedError{RetryAfter: duration}
+		}
+	}
+	if locked != nil {
+		return locked
+	}
+	return cause
+}
+
+// countLoginFailure adds a failure to the limit's counter, locking once it reaches
+// the maximum. It returns the lock duration and whether the failure locked the account.
+func (s *Service) countLoginFailure(limit loginLimit) (time.Duration, bool) {
+	ctx := context.Background()
+
+	failures, err := s.redisClient.Incr(ctx, limit.failuresKey).Result()
+	if err != nil {
+		log.Printf("Failed to record login failure: %v", err)
+		return 0, false
+	}
+	if failures == 1 {
+		s.redisClient.Expire(ctx, limit.failuresKey, s.config.Security.LoginAttemptWindow)
+	}
+	if failures < int64(limit.maxAttempts) {
+		return 0, false
+	}
+
+	lockouts, err := s.redisClient.Incr(ctx, limit.lockoutsKey).Result()
+	if err != nil {
+		lockouts = 1
+	}
+	s.redisClient.Expire(ctx, limit.lockoutsKey, lockoutMemory)
+
+	duration := s.config.Security.LoginLockoutDuration
+	for i := int64(1); i < lockouts && duration < s.config.Security.LoginMaxLockout; i++ {
+		duration *= 2
+	}
+	if maxLockout := s.config.Security.LoginMaxLockout; maxLockout > 0 && duration > maxLockout {
+		duration = maxLockout
+	}
+
+	pipe := s.redisClient.TxPipeline()
+	pipe.Set(ctx, limit.lockKey, failures, duration)
+	pipe.Del(ctx, limit.failuresKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to lock account: %v", err)
+		return 0, false
+	}
+	return duration, true
+}
+
+// clearLoginFailures resets the failure and lockout counters after a successful login
+func (s *Service) clearLoginFailures(email, clientIP string) {
+	if !s.loginLimitEnabled() {
+		return
+	}
+	s.redisClient.Del(context.Background(),
+		loginFailuresKey(email, clientIP), loginLockoutsKey(email, clientIP),
+		accountLoginFailuresKey(email), accountLoginLockoutsKey(email))
+}
+
+// UnlockUser lifts the user's login lockouts and clears their failed attempt counters,
+// account-wide and for every client
+func (s *AdminService) UnlockUser(userID uint) error {
+	var user User
+	if err := s.db.Select("id, email").First(&user, userID).Error; err != nil {
+		return ErrUserNotFound
+	}
+	if s.redisClient == nil {
+		return nil
+	}
+
+	ctx := context.Background()
+	keys := []string{
+		accountLoginLockKey(user.Email),
+		accountLoginLockoutsKey(user.Email),
+		accountLoginFailuresKey(user.Email),
+	}
+
+	// Per-client keys end in the client IP; the email is escaped so it only matches itself
+	email := escapeGlob(normalizeLoginEmail(user.Email))
+	for _, prefix := range []string{"auth:login_lock:", "auth:login_lockouts:", "auth:login_failures:"} {
+		iter := s.redisClient.Scan(ctx, 0, prefix+email+":*", 100).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		if err := iter.Err(); err != nil && err != redis.Nil {
+			return fmt.Errorf("failed to find login lockouts: %w", err)
+		}
+	}
+
+	if err := s.redisClient.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to unlock user: %w", err)
+	}
+	return nil
+}
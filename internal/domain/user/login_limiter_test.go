@@ -0,0 +1,51 @@
+// internal/domain/user/login_limiter_test.go
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+func TestUnlockUserOnlyUnlocksThatUser(t *testing.T) {
+	db := testutil.NewDB(t, &User{})
+	redisClient, _ := testutil.NewRedis(t)
+	cfg := &config.Config{}
+	cfg.Security.LoginMaxAttempts = 1
+	cfg.Security.LoginAccountMaxAttempts = 1
+	cfg.Security.LoginAttemptWindow = time.Minute
+	cfg.Security.LoginLockoutDuration = time.Minute
+	s := &Service{db: db, config: cfg, redisClient: redisClient}
+	admin := &AdminService{db: db, config: cfg, redisClient: redisClient}
+
+	// "[x]@example.com" is a SCAN pattern that matches "x@example.com"
+	target := User{Email: "[x]@example.com", Password: "hash"}
+	other := User{Email: "x@example.com", Password: "hash"}
+	db.Create(&target)
+	db.Create(&other)
+
+	cause := errors.New("invalid email or password")
+	for _, email := range []string{target.Email, other.Email} {
+		if err := s.recordLoginFailure(email, "10.0.0.1", cause); !errors.Is(err, ErrAccountLocked) {
+			t.Fatalf("recordLoginFailure(%s) = %v, want %v", email, err, ErrAccountLocked)
+		}
+	}
+
+	if err := admin.UnlockUser(target.ID); err != nil {
+		t.Fatalf("UnlockUser() error = %v", err)
+	}
+
+	if err := s.checkLoginLock(target.Email, "10.0.0.1"); err != nil {
+		t.Errorf("unlocked user is still locked: %v", err)
+	}
+	if err := s.checkLoginLock(other.Email, "10.0.0.1"); !errors.Is(err, ErrAccountLocked) {
+		t.Errorf("other user lock = %v, want %v", err, ErrAccountLocked)
+	}
+	if n, _ := redisClient.Exists(context.Background(), accountLoginLockKey(other.Email)).Result(); n != 1 {
+		t.Errorf("other user's account-wide lock was removed")
+	}
+}
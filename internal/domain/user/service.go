@@ -2,10 +2,13 @@
 package user
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"github.com/your-org/ecommerce-backend/internal/pkg/auth"
 	"github.com/your-org/ecommerce-backend/internal/pkg/email"
@@ -19,16 +22,18 @@ type Service struct {
 	passwordManager *auth.PasswordManager
 	jwtManager      *auth.JWTManager
 	emailService    *email.EmailService
+	redisClient     *redis.Client // Login attempt tracking; may be nil
 }
 
 // NewService creates a new user service
-func NewService(db *gorm.DB, cfg *config.Config) *Service {
+func NewService(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *Service {
 	return &Service{
 		db:              db,
 		config:          cfg,
 		passwordManager: auth.NewPasswordManager(cfg),
-		jwtManager:      auth.NewJWTManager(cfg),
-		emailService:    email.NewEmailService(cfg),
+		jwtManager:      auth.NewJWTManager(cfg).WithTokenStore(auth.NewTokenStore(redisClient)),
+		emailService:    email.NewEmailService(db, cfg),
+		redisClient:     redisClient,
 	}
 }
 
@@ -46,6 +51,7 @@ type RegisterRequest struct {
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
+	TOTPCode string `json:"totp_code"` // Authenticator or recovery code, when 2FA is enabled
 }
 
 // AuthResponse represents authentication response
@@ -56,13 +62,17 @@ type AuthResponse struct {
 	ExpiresIn    int64  `json:"expires_in"`
 }
 
-// Register creates a new user account
-func (s *Service) Register(req *RegisterRequest) (*AuthResponse, error) {
+// Register creates a new user account and starts a session for it
+func (s *Service) Register(req *RegisterRequest, client ClientInfo) (*AuthResponse, error) {
 	// Validate password confirmation
 	if req.Password != req.ConfirmPassword {
 		return nil, fmt.Errorf("passwords do not match")
 	}
 
+	if err := s.passwordManager.ValidatePassword(req.Password); err != nil {
+		return nil, err
+	}
+
 	// Check if user already exists
 	var existingUser User
 	result := s.db.Where("email = ?", req.Email).First(&existingUser)
@@ -92,14 +102,9 @@ func (s *Service) Register(req *RegisterRequest) (*AuthResponse, error) {
 	}
 
 	// Generate tokens
-	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.IsAdmin)
+	response, err := s.issueTokens(&user, "", client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
-	}
-
-	refreshToken, err := s.jwtManager.GenerateRefreshToken(user.ID, user.Email)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		return nil, err
 	}
 
 	// Update last login
@@ -110,37 +115,45 @@ func (s *Service) Register(req *RegisterRequest) (*AuthResponse, error) {
 	// Clear password from response
 	user.Password = ""
 
-	return &AuthResponse{
-		User:         &user,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresIn:    int64(s.config.JWT.AccessTokenExpiry.Seconds()),
-	}, nil
+	return response, nil
 }
 
-// Login authenticates a user
-func (s *Service) Login(req *LoginRequest) (*AuthResponse, error) {
+// Login authenticates a user and starts a new session. Repeated failures from the
+// client's IP lock the account for that IP for a while, and more failures from any
+// IPs lock it everywhere; see checkLoginLock.
+func (s *Service) Login(req *LoginRequest, client ClientInfo) (*AuthResponse, error) {
+	clientIP := client.IP
+
+	if err := s.checkLoginLock(req.Email, clientIP); err != nil {
+		return nil, err
+	}
+
 	// Find user by email
 	var user User
 	result := s.db.Where("email = ? AND is_active = ?", req.Email, true).First(&user)
 	if result.Error != nil {
-		return nil, fmt.Errorf("invalid email or password")
+		return nil, s.recordLoginFailure(req.Email, clientIP, fmt.Errorf("invalid email or password"))
 	}
 
 	// Verify password
 	if err := s.passwordManager.VerifyPassword(req.Password, user.Password); err != nil {
-		return nil, fmt.Errorf("invalid email or password")
+		return nil, s.recordLoginFailure(req.Email, clientIP, fmt.Errorf("invalid email or password"))
 	}
 
-	// Generate tokens
-	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.IsAdmin)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	if user.TwoFactorEnabled {
+		if err := s.checkTwoFactor(&user, req.TOTPCode); err != nil {
+			if errors.Is(err, ErrInvalidTwoFactorCode) {
+				return nil, s.recordLoginFailure(req.Email, clientIP, err)
+			}
+			return nil, err
+		}
 	}
+	s.clearLoginFailures(req.Email, clientIP)
 
-	refreshToken, err := s.jwtManager.GenerateRefreshToken(user.ID, user.Email)
+	// Generate tokens
+	response, err := s.issueTokens(&user, "", client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		return nil, err
 	}
 
 	// Update last login
@@ -151,21 +164,21 @@ func (s *Service) Login(req *LoginRequest) (*AuthResponse, error) {
 	// Clear password from response
 	user.Password = ""
 
-	return &AuthResponse{
-		User:         &user,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresIn:    int64(s.config.JWT.AccessTokenExpiry.Seconds()),
-	}, nil
+	return response, nil
 }
 
-// RefreshToken generates new tokens using refresh token
-func (s *Service) RefreshToken(refreshToken string) (*AuthResponse, error) {
+// RefreshToken generates new tokens using refresh token. The token must belong to
+// a session that has not been revoked; with rotation the session moves to the
+// new refresh token.
+func (s *Service) RefreshToken(refreshToken string, client ClientInfo) (*AuthResponse, error) {
 	// Validate refresh token
 	claims, err := s.jwtManager.ValidateRefreshToken(refreshToken)
 	if err != nil {
 		return nil, fmt.Errorf("invalid refresh token: %w", err)
 	}
+	if err := s.checkSession(claims); err != nil {
+		return nil, err
+	}
 
 	// Find user
 	var user User
@@ -174,35 +187,99 @@ func (s *Service) RefreshToken(refreshToken string) (*AuthResponse, error) {
 		return nil, fmt.Errorf("user not found or inactive")
 	}
 
-	// Generate new tokens
-	newAccessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.IsAdmin)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
-	}
-
-	var newRefreshToken string
+	var response *AuthResponse
 	if s.config.JWT.RefreshTokenRotation {
-		// Generate new refresh token (rotation)
-		newRefreshToken, err = s.jwtManager.GenerateRefreshToken(user.ID, user.Email)
+		// Generate new tokens (rotation)
+		response, err = s.issueTokens(&user, claims.SessionID, client)
 		if err != nil {
-			return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+			return nil, err
+		}
+		// A rotated-out refresh token must not be usable again
+		if err := s.jwtManager.TokenStore().Revoke(claims); err != nil {
+			log.Printf("Failed to revoke rotated refresh token for user %d: %v", user.ID, err)
 		}
 	} else {
 		// Reuse existing refresh token
-		newRefreshToken = refreshToken
+		accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.IsAdmin, claims.SessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate access token: %w", err)
+		}
+		s.touchSession(user.ID, claims.SessionID, client)
+
+		response = &AuthResponse{
+			User:         &user,
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+			ExpiresIn:    int64(s.config.JWT.AccessTokenExpiry.Seconds()),
+		}
 	}
 
 	// Clear password from response
 	user.Password = ""
 
+	return response, nil
+}
+
+// issueTokens generates an access and refresh token pair for the session,
+// starting a new session when sessionID is empty
+func (s *Service) issueTokens(user *User, sessionID string, client ClientInfo) (*AuthResponse, error) {
+	if sessionID == "" {
+		sessionID = uuid.New().String()
+	}
+
+	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.IsAdmin, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := s.jwtManager.GenerateRefreshToken(user.ID, user.Email, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	s.saveSession(user.ID, sessionID, refreshToken, client)
+
 	return &AuthResponse{
-		User:         &user,
-		AccessToken:  newAccessToken,
-		RefreshToken: newRefreshToken,
+		User:         user,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
 		ExpiresIn:    int64(s.config.JWT.AccessTokenExpiry.Seconds()),
 	}, nil
 }
 
+// Logout revokes the access token the request was made with and ends its session,
+// which also invalidates the session's refresh token
+func (s *Service) Logout(accessClaims *auth.Claims, refreshToken string) error {
+	store := s.jwtManager.TokenStore()
+	if err := store.Revoke(accessClaims); err != nil {
+		return err
+	}
+	if accessClaims.SessionID != "" {
+		if err := s.RevokeSession(accessClaims.UserID, accessClaims.SessionID); err != nil && !errors.Is(err, ErrSessionNotFound) {
+			return err
+		}
+	}
+
+	if refreshToken != "" {
+		claims, err := s.jwtManager.ValidateRefreshToken(refreshToken)
+		if err == nil && claims.UserID == accessClaims.UserID {
+			if err := store.Revoke(claims); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// LogoutAll revokes every access and refresh token issued to the user so far,
+// signing them out on all devices
+func (s *Service) LogoutAll(userID uint) error {
+	if err := s.jwtManager.TokenStore().RevokeAllForUser(userID); err != nil {
+		return err
+	}
+	return s.clearSessions(userID)
+}
+
 // GetProfile gets user profile by ID
 func (s *Service) GetProfile(userID uint) (*User, error) {
 	var user User
@@ -230,6 +307,10 @@ func (s *Service) UpdateProfile(userID uint, updates map[string]interface{}) (*U
 	delete(updates, "is_admin")
 	delete(updates, "is_active")
 	delete(updates, "email_verified")
+	delete(updates, "email_verified_at")
+	delete(updates, "email") // Changed through RequestEmailChange, which verifies the new address
+	delete(updates, "two_factor_enabled")
+	delete(updates, "two_factor_secret")
 
 	if err := s.db.Model(&user).Updates(updates).Error; err != nil {
 		return nil, fmt.Errorf("failed to update profile: %w", err)
@@ -255,6 +336,10 @@ func (s *Service) ChangePassword(userID uint, currentPassword, newPassword strin
 		return fmt.Errorf("current password is incorrect")
 	}
 
+	if err := s.passwordManager.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
 	// Hash new password
 	hashedPassword, err := s.passwordManager.HashPassword(newPassword)
 	if err != nil {
@@ -278,6 +363,10 @@ func (s *Service) ResetPassword(userID uint, newPassword string) error {
 		return fmt.Errorf("user not found")
 	}
 
+	if err := s.passwordManager.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
 	// Hash new password
 	hashedPassword, err := s.passwordManager.HashPassword(newPassword)
 	if err != nil {
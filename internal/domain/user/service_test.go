@@ -0,0 +1,84 @@
+// internal/domain/user/service_test.go
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const testPassword = "Correct-Horse-42"
+
+// newTestService returns a user service over a fresh database and Redis
+func newTestService(t *testing.T) (*Service, *gorm.DB, *redis.Client) {
+	t.Helper()
+	db := testutil.NewDB(t, &User{}, &Address{}, &RecoveryCode{})
+	redisClient, _ := testutil.NewRedis(t)
+	cfg := &config.Config{}
+	cfg.App.Name = "Shop"
+	cfg.JWT.Secret = "test-secret"
+	cfg.JWT.AccessTokenExpiry = 15 * time.Minute
+	cfg.JWT.RefreshTokenExpiry = 24 * time.Hour
+	cfg.Security.BcryptCost = bcrypt.MinCost
+	cfg.Security.LoginMaxAttempts = 10
+	cfg.Security.LoginAccountMaxAttempts = 10
+	cfg.Security.LoginAttemptWindow = 15 * time.Minute
+	cfg.Security.LoginLockoutDuration = 15 * time.Minute
+	return NewService(db, redisClient, cfg), db, redisClient
+}
+
+func createTestUser(t *testing.T, db *gorm.DB, email string) *User {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &User{Email: email, Password: string(hash), FirstName: "Test", LastName: "User", IsActive: true}
+	if err := db.Create(u).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	return u
+}
+
+func loginTestUser(t *testing.T, s *Service, email string) *AuthResponse {
+	t.Helper()
+	response, err := s.Login(&LoginRequest{Email: email, Password: testPassword}, ClientInfo{IP: "10.0.0.1", UserAgent: "test"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	return response
+}
+
+func TestLogoutRevokesTokens(t *testing.T) {
+	s, db, _ := newTestService(t)
+	u := createTestUser(t, db, "shopper@example.com")
+
+	old := loginTestUser(t, s, u.Email)
+	claims, err := s.jwtManager.ValidateAccessToken(old.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() error = %v", err)
+	}
+	if err := s.Logout(claims, old.RefreshToken); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+
+	if _, err := s.jwtManager.ValidateAccessToken(old.AccessToken); err == nil {
+		t.Error("logged-out access token was accepted")
+	}
+	if _, err := s.RefreshToken(old.RefreshToken, ClientInfo{}); err == nil {
+		t.Error("logged-out refresh token was accepted")
+	}
+
+	fresh := loginTestUser(t, s, u.Email)
+	if _, err := s.jwtManager.ValidateAccessToken(fresh.AccessToken); err != nil {
+		t.Errorf("fresh access token error = %v, want it accepted", err)
+	}
+	if _, err := s.RefreshToken(fresh.RefreshToken, ClientInfo{}); err != nil {
+		t.Errorf("fresh refresh token error = %v, want it accepted", err)
+	}
+}
@@ -0,0 +1,248 @@
+// internal/domain/user/sessions.go
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/your-org/ecommerce-backend/internal/pkg/auth"
+)
+
+// ErrSessionNotFound is returned when the session does not exist or has expired
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrSessionRevoked is returned when refreshing with a token whose session has ended
+var ErrSessionRevoked = errors.New("session has been revoked")
+
+// ClientInfo describes the client a login or refresh request came from
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// Session is a signed-in device, identified by the session ID carried in its tokens
+type Session struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	IssuedAt   time.Time `json:"issued_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"` // The session the request was made from
+}
+
+// sessionRecord is a session as stored in Redis, with the ID of its current refresh token
+type sessionRecord struct {
+	Session
+	TokenID string `json:"token_id"`
+}
+
+// Sessions are kept in one Redis hash per user, keyed by session ID
+func sessionsKey(userID uint) string {
+	return fmt.Sprintf("auth:sessions:%d", userID)
+}
+
+// saveSession records the refresh token now held by the session, creating the
+// session on first use
+func (s *Service) saveSession(userID uint, sessionID, refreshToken string, client ClientInfo) {
+	if s.redisClient == nil {
+		return
+	}
+
+	var claims auth.Claims
+	if _, _, err := jwt.NewParser().ParseUnverified(refreshToken, &claims); err != nil {
+		log.Printf("Failed to read refresh token for session %s: %v", sessionID, err)
+		return
+	}
+
+	now := time.Now().UTC()
+	record, err := s.getSession(userID, sessionID)
+	if err != nil {
+		record = &sessionRecord{Session: Session{ID: sessionID, IssuedAt: now}}
+	}
+	record.TokenID = claims.ID
+	record.ExpiresAt = claims.ExpiresAt.Time
+	record.setClient(client, now)
+
+	s.putSession(userID, record)
+}
+
+// touchSession updates when and from where the session was last used
+func (s *Service) touchSession(userID uint, sessionID string, client ClientInfo) {
+	if s.redisClient == nil || sessionID == "" {
+		return
+	}
+	record, err := s.getSession(userID, sessionID)
+	if err != nil {
+		return
+	}
+	record.setClient(client, time.Now().UTC())
+	s.putSession(userID, record)
+}
+
+// checkSession rejects refresh tokens whose session was revoked, and tokens that
+// were already rotated out, which can only be replayed by someone else
+func (s *Service) checkSession(claims *auth.Claims) error {
+	if s.redisClient == nil || claims.SessionID == "" {
+		return nil // Tokens issued before sessions were tracked
+	}
+
+	record, err := s.getSession(claims.UserID, claims.SessionID)
+	if err != nil {
+		return ErrSessionRevoked
+	}
+	if record.TokenID != claims.ID {
+		log.Printf("Rotated refresh token reused for session %s of user %d, revoking session", claims.SessionID, claims.UserID)
+		s.RevokeSession(claims.UserID, claims.SessionID)
+		return ErrSessionRevoked
+	}
+	return nil
+}
+
+// ListSessions returns the user's active sessions, most recently used first.
+// currentSessionID marks the session the request was made from.
+func (s *Service) ListSessions(userID uint, currentSessionID string) ([]Session, error) {
+	sessions := []Session{}
+	if s.redisClient == nil {
+		return sessions, nil
+	}
+
+	ctx := context.Background()
+	entries, err := s.redisClient.HGetAll(ctx, sessionsKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	now := time.Now()
+	var expired []string
+	for id, data := range entries {
+		var record sessionRecord
+		if err := json.Unmarshal([]byte(data), &record); err != nil || now.After(record.ExpiresAt) {
+			expired = append(expired, id)
+			continue
+		}
+		record.Current = record.ID == currentSessionID
+		sessions = append(sessions, record.Session)
+	}
+	if len(expired) > 0 {
+		s.redisClient.HDel(ctx, sessionsKey(userID), expired...)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+	return sessions, nil
+}
+
+// RevokeSession ends a session: its refresh token can no longer be used
+func (s *Service) RevokeSession(userID uint, sessionID string) error {
+	if s.redisClient == nil {
+		return ErrSessionNotFound
+	}
+
+	record, err := s.getSession(userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.redisClient.HDel(context.Background(), sessionsKey(userID), sessionID).Err(); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	// Blacklist the refresh token as well, in case the session is looked up stale
+	refreshClaims := &auth.Claims{UserID: userID}
+	refreshClaims.ID = record.TokenID
+	refreshClaims.ExpiresAt = jwt.NewNumericDate(record.ExpiresAt)
+	if err := s.jwtManager.TokenStore().Revoke(refreshClaims); err != nil {
+		log.Printf("Failed to blacklist refresh token of session %s: %v", sessionID, err)
+	}
+	return nil
+}
+
+// clearSessions ends all of the user's sessions
+func (s *Service) clearSessions(userID uint) error {
+	if s.redisClient == nil {
+		return nil
+	}
+	if err := s.redisClient.Del(context.Background(), sessionsKey(userID)).Err(); err != nil {
+		return fmt.Errorf("failed to clear sessions: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) getSession(userID uint, sessionID string) (*sessionRecord, error) {
+	data, err := s.redisClient.HGet(context.Background(), sessionsKey(userID), sessionID).Result()
+	if err != nil {
+		return nil, ErrSessionNotFound
+	}
+
+	var record sessionRecord
+	if err := json.Unmarshal([]byte(data), &record); err != nil || time.Now().After(record.ExpiresAt) {
+		return nil, ErrSessionNotFound
+	}
+	return &record, nil
+}
+
+func (s *Service) putSession(userID uint, record *sessionRecord) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	// The hash lives as long as the newest refresh token; stale entries are pruned on listing
+	ctx := context.Background()
+	pipe := s.redisClient.Pipeline()
+	pipe.HSet(ctx, sessionsKey(userID), record.ID, data)
+	pipe.Expire(ctx, sessionsKey(userID), s.config.JWT.RefreshTokenExpiry)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to save session %s for user %d: %v", record.ID, userID, err)
+	}
+}
+
+func (r *sessionRecord) setClient(client ClientInfo, now time.Time) {
+	r.IP = client.IP
+	r.UserAgent = client.UserAgent
+	r.Device = deviceFromUserAgent(client.UserAgent)
+	r.LastUsedAt = now
+}
+
+// deviceFromUserAgent gives a short "Browser on OS" description of a user agent
+func deviceFromUserAgent(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	if ua == "" {
+		return "Unknown device"
+	}
+
+	os := "Unknown OS"
+	for _, candidate := range []struct{ token, name string }{
+		{"iphone", "iPhone"}, {"ipad", "iPad"}, {"android", "Android"},
+		{"windows", "Windows"}, {"mac os", "macOS"}, {"cros", "ChromeOS"}, {"linux", "Linux"},
+	} {
+		if strings.Contains(ua, candidate.token) {
+			os = candidate.name
+			break
+		}
+	}
+
+	// Order matters: Edge and Opera user agents also mention Chrome, and Chrome mentions Safari
+	browser := "Unknown browser"
+	for _, candidate := range []struct{ token, name string }{
+		{"edg/", "Edge"}, {"opr/", "Opera"}, {"firefox", "Firefox"},
+		{"chrome", "Chrome"}, {"safari", "Safari"}, {"okhttp", "Android app"}, {"cfnetwork", "iOS app"},
+	} {
+		if strings.Contains(ua, candidate.token) {
+			browser = candidate.name
+			break
+		}
+	}
+
+	return browser + " on " + os
+}
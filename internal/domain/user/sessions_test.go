@@ -0,0 +1,90 @@
+// internal/domain/user/sessions_test.go
+package user
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRevokedSessionRefreshRejected(t *testing.T) {
+	s, db, _ := newTestService(t)
+	u := createTestUser(t, db, "shopper@example.com")
+
+	laptop := loginTestUser(t, s, u.Email)
+	phone := loginTestUser(t, s, u.Email)
+	laptopClaims, _ := s.jwtManager.ValidateRefreshToken(laptop.RefreshToken)
+
+	sessions, err := s.ListSessions(u.ID, "")
+	if err != nil || len(sessions) != 2 {
+		t.Fatalf("ListSessions() = %d sessions, %v, want 2", len(sessions), err)
+	}
+
+	if err := s.RevokeSession(u.ID, laptopClaims.SessionID); err != nil {
+		t.Fatalf("RevokeSession() error = %v", err)
+	}
+	if _, err := s.RefreshToken(laptop.RefreshToken, ClientInfo{}); err == nil {
+		t.Error("refresh token of a revoked session was accepted")
+	}
+	if _, err := s.RefreshToken(phone.RefreshToken, ClientInfo{}); err != nil {
+		t.Errorf("refresh token of another session error = %v, want it accepted", err)
+	}
+
+	// A session removed without its token being blacklisted is still refused
+	phoneClaims, _ := s.jwtManager.ValidateRefreshToken(phone.RefreshToken)
+	if err := s.clearSessions(u.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.checkSession(phoneClaims); !errors.Is(err, ErrSessionRevoked) {
+		t.Errorf("checkSession() after the session ended = %v, want %v", err, ErrSessionRevoked)
+	}
+}
+
+func TestRefreshRotationInvalidatesPreviousToken(t *testing.T) {
+	s, db, _ := newTestService(t)
+	s.config.JWT.RefreshTokenRotation = true
+	u := createTestUser(t, db, "shopper@example.com")
+
+	first := loginTestUser(t, s, u.Email)
+	second, err := s.RefreshToken(first.RefreshToken, ClientInfo{})
+	if err != nil {
+		t.Fatalf("RefreshToken() error = %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Fatal("rotation returned the same refresh token")
+	}
+
+	if _, err := s.RefreshToken(first.RefreshToken, ClientInfo{}); err == nil {
+		t.Error("rotated-out refresh token was accepted")
+	}
+
+	// The rotated token stays in the same session
+	firstClaims, _ := s.jwtManager.ValidateAccessToken(first.AccessToken)
+	secondClaims, _ := s.jwtManager.ValidateRefreshToken(second.RefreshToken)
+	if secondClaims == nil || secondClaims.SessionID != firstClaims.SessionID {
+		t.Fatalf("rotated token session = %+v, want %s", secondClaims, firstClaims.SessionID)
+	}
+	if _, err := s.RefreshToken(second.RefreshToken, ClientInfo{}); err != nil {
+		t.Errorf("current refresh token error = %v, want it accepted", err)
+	}
+}
+
+func TestReusedRotatedTokenRevokesSession(t *testing.T) {
+	s, db, _ := newTestService(t)
+	s.config.JWT.RefreshTokenRotation = true
+	u := createTestUser(t, db, "shopper@example.com")
+
+	first := loginTestUser(t, s, u.Email)
+	firstClaims, _ := s.jwtManager.ValidateRefreshToken(first.RefreshToken)
+	second, err := s.RefreshToken(first.RefreshToken, ClientInfo{})
+	if err != nil {
+		t.Fatalf("RefreshToken() error = %v", err)
+	}
+
+	// Replaying the old token, had its blacklisting failed, ends the whole session
+	if err := s.checkSession(firstClaims); !errors.Is(err, ErrSessionRevoked) {
+		t.Fatalf("checkSession(old token) = %v, want %v", err, ErrSessionRevoked)
+	}
+	if _, err := s.RefreshToken(second.RefreshToken, ClientInfo{}); err == nil {
+		t.Error("refresh token of a session ended by token reuse was accepted")
+	}
+}
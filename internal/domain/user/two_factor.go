@@ -0,0 +1,190 @@
+// internal/domain/user/two_factor.go
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/pkg/auth"
+	"gorm.io/gorm"
+)
+
+// recoveryCodeCount is how many recovery codes are issued when 2FA is enabled
+const recoveryCodeCount = 10
+
+// ErrTwoFactorRequired is returned by Login when the account has 2FA enabled and no code was given
+var ErrTwoFactorRequired = errors.New("two-factor authentication code required")
+
+// ErrInvalidTwoFactorCode is returned when a TOTP or recovery code does not match
+var ErrInvalidTwoFactorCode = errors.New("invalid two-factor authentication code")
+
+// ErrTwoFactorAlreadyEnabled is returned when enabling 2FA on an account that already has it
+var ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+// ErrTwoFactorNotPending is returned when verifying before 2FA setup has been started
+var ErrTwoFactorNotPending = errors.New("two-factor authentication setup has not been started")
+
+// TwoFactorSetupResponse carries what the user needs to add the account to an authenticator app
+type TwoFactorSetupResponse struct {
+	Secret        string   `json:"secret"`
+	OTPAuthURL    string   `json:"otpauth_url"`
+	RecoveryCodes []string `json:"recovery_codes"` // Shown once; only hashes are stored
+}
+
+// TwoFactorVerifyRequest confirms 2FA setup with a code from the authenticator app
+type TwoFactorVerifyRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// EnableTwoFactor starts 2FA setup: it stores a new encrypted TOTP secret and fresh
+// recovery codes. 2FA is not enforced until VerifyTwoFactor confirms a code, so
+// calling this again before verifying replaces the pending secret.
+func (s *Service) EnableTwoFactor(userID uint) (*TwoFactorSetupResponse, error) {
+	var user User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	if user.TwoFactorEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := auth.EncryptSecret(s.twoFactorKey(), secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt two-factor secret: %w", err)
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&user).Update("two_factor_secret", encrypted).Error; err != nil {
+			return fmt.Errorf("failed to save two-factor secret: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&RecoveryCode{}).Error; err != nil {
+			return fmt.Errorf("failed to replace recovery codes: %w", err)
+		}
+
+		for i := range codes {
+			code, err := generateRecoveryCode()
+			if err != nil {
+				return err
+			}
+			codes[i] = code
+			if err := tx.Create(&RecoveryCode{UserID: userID, CodeHash: hashRecoveryCode(code)}).Error; err != nil {
+				return fmt.Errorf("failed to save recovery code: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorSetupResponse{
+		Secret:        secret,
+		OTPAuthURL:    auth.TOTPURL(s.config.App.Name, user.Email, secret),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// VerifyTwoFactor activates 2FA once the user proves their authenticator produces valid codes
+func (s *Service) VerifyTwoFactor(userID uint, code string) error {
+	var user User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return fmt.Errorf("user not found")
+	}
+	if user.TwoFactorEnabled {
+		return ErrTwoFactorAlreadyEnabled
+	}
+	if user.TwoFactorSecret == "" {
+		return ErrTwoFactorNotPending
+	}
+
+	secret, err := auth.DecryptSecret(s.twoFactorKey(), user.TwoFactorSecret)
+	if err != nil {
+		return err
+	}
+	step, ok := auth.ValidateTOTPAfter(secret, code, time.Now(), user.TwoFactorLastStep)
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	err = s.db.Model(&user).Updates(map[string]interface{}{
+		"two_factor_enabled":   true,
+		"two_factor_last_step": step,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+	return nil
+}
+
+// checkTwoFactor verifies the code given at login, accepting either a current TOTP
+// code or an unused recovery code, which is then spent. A TOTP code is spent too:
+// its time step is recorded and codes from that step or earlier are refused.
+func (s *Service) checkTwoFactor(user *User, code string) error {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return ErrTwoFactorRequired
+	}
+
+	secret, err := auth.DecryptSecret(s.twoFactorKey(), user.TwoFactorSecret)
+	if err != nil {
+		return err
+	}
+	if step, ok := auth.ValidateTOTPAfter(secret, code, time.Now(), user.TwoFactorLastStep); ok {
+		// Conditional so two logins racing with the same code can't both spend it
+		result := s.db.Model(&User{}).
+			Where("id = ? AND two_factor_last_step < ?", user.ID, step).
+			Update("two_factor_last_step", step)
+		if result.Error != nil {
+			return fmt.Errorf("failed to record two-factor code: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvalidTwoFactorCode
+		}
+		user.TwoFactorLastStep = step
+		return nil
+	}
+
+	result := s.db.Model(&RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", user.ID, hashRecoveryCode(code)).
+		Update("used_at", time.Now().UTC())
+	if result.Error != nil {
+		return fmt.Errorf("failed to check recovery code: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// twoFactorKey is the passphrase two-factor secrets are encrypted under
+func (s *Service) twoFactorKey() string {
+	if s.config.Security.TwoFactorEncryptionKey != "" {
+		return s.config.Security.TwoFactorEncryptionKey
+	}
+	return s.config.JWT.Secret
+}
+
+// generateRecoveryCode returns a random code formatted as xxxxx-xxxxx
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+	code := hex.EncodeToString(buf)
+	return code[:5] + "-" + code[5:], nil
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,139 @@
+// internal/domain/user/two_factor_test.go
+package user
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// totpAt computes the authenticator app's code for secret at time t
+func totpAt(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(at.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	return fmt.Sprintf("%06d", (binary.BigEndian.Uint32(sum[offset:offset+4])&0x7fffffff)%1000000)
+}
+
+// enableTwoFactor sets up and verifies 2FA for u with the code for the previous
+// period, leaving the current period's code unused for the test to log in with
+func enableTwoFactor(t *testing.T, s *Service, u *User) *TwoFactorSetupResponse {
+	t.Helper()
+	setup, err := s.EnableTwoFactor(u.ID)
+	if err != nil {
+		t.Fatalf("EnableTwoFactor() error = %v", err)
+	}
+	if err := s.VerifyTwoFactor(u.ID, totpAt(t, setup.Secret, time.Now().Add(-30*time.Second))); err != nil {
+		t.Fatalf("VerifyTwoFactor() error = %v", err)
+	}
+	return setup
+}
+
+func twoFactorLogin(s *Service, email, code string) error {
+	_, err := s.Login(&LoginRequest{Email: email, Password: testPassword, TOTPCode: code}, ClientInfo{IP: "10.0.0.1"})
+	return err
+}
+
+func TestEnableAndVerifyTwoFactor(t *testing.T) {
+	s, db, _ := newTestService(t)
+	u := createTestUser(t, db, "shopper@example.com")
+
+	setup, err := s.EnableTwoFactor(u.ID)
+	if err != nil {
+		t.Fatalf("EnableTwoFactor() error = %v", err)
+	}
+	if len(setup.RecoveryCodes) != recoveryCodeCount || setup.OTPAuthURL == "" {
+		t.Fatalf("setup = %d recovery codes, URL %q", len(setup.RecoveryCodes), setup.OTPAuthURL)
+	}
+
+	// Setup alone doesn't enforce 2FA
+	if err := twoFactorLogin(s, u.Email, ""); err != nil {
+		t.Fatalf("login before verifying = %v, want success", err)
+	}
+
+	if err := s.VerifyTwoFactor(u.ID, "000000"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("VerifyTwoFactor(wrong code) = %v, want %v", err, ErrInvalidTwoFactorCode)
+	}
+	if err := s.VerifyTwoFactor(u.ID, totpAt(t, setup.Secret, time.Now())); err != nil {
+		t.Fatalf("VerifyTwoFactor() error = %v", err)
+	}
+
+	if err := twoFactorLogin(s, u.Email, ""); !errors.Is(err, ErrTwoFactorRequired) {
+		t.Errorf("login without a code = %v, want %v", err, ErrTwoFactorRequired)
+	}
+	if _, err := s.EnableTwoFactor(u.ID); !errors.Is(err, ErrTwoFactorAlreadyEnabled) {
+		t.Errorf("EnableTwoFactor() again = %v, want %v", err, ErrTwoFactorAlreadyEnabled)
+	}
+}
+
+func TestTwoFactorLoginRejectsBadCode(t *testing.T) {
+	s, db, _ := newTestService(t)
+	u := createTestUser(t, db, "shopper@example.com")
+	setup := enableTwoFactor(t, s, u)
+
+	if err := twoFactorLogin(s, u.Email, "123456"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Errorf("login with a wrong code = %v, want %v", err, ErrInvalidTwoFactorCode)
+	}
+	// A code from well outside the allowed drift
+	if err := twoFactorLogin(s, u.Email, totpAt(t, setup.Secret, time.Now().Add(-5*time.Minute))); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Errorf("login with an old code = %v, want %v", err, ErrInvalidTwoFactorCode)
+	}
+	if err := twoFactorLogin(s, u.Email, totpAt(t, setup.Secret, time.Now())); err != nil {
+		t.Errorf("login with the current code = %v, want success", err)
+	}
+}
+
+func TestTwoFactorCodeCannotBeReplayed(t *testing.T) {
+	s, db, _ := newTestService(t)
+	u := createTestUser(t, db, "shopper@example.com")
+	setup := enableTwoFactor(t, s, u)
+
+	code := totpAt(t, setup.Secret, time.Now())
+	if err := twoFactorLogin(s, u.Email, code); err != nil {
+		t.Fatalf("first login = %v, want success", err)
+	}
+	if err := twoFactorLogin(s, u.Email, code); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Errorf("replayed code = %v, want %v", err, ErrInvalidTwoFactorCode)
+	}
+
+	// The code used to verify setup is older than the one just used
+	if err := twoFactorLogin(s, u.Email, totpAt(t, setup.Secret, time.Now().Add(-30*time.Second))); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Errorf("earlier code = %v, want %v", err, ErrInvalidTwoFactorCode)
+	}
+}
+
+func TestRecoveryCodeUsedOnce(t *testing.T) {
+	s, db, _ := newTestService(t)
+	u := createTestUser(t, db, "shopper@example.com")
+	setup := enableTwoFactor(t, s, u)
+	code := setup.RecoveryCodes[0]
+
+	if err := twoFactorLogin(s, u.Email, code); err != nil {
+		t.Fatalf("login with a recovery code = %v, want success", err)
+	}
+	if err := twoFactorLogin(s, u.Email, code); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Errorf("login with a spent recovery code = %v, want %v", err, ErrInvalidTwoFactorCode)
+	}
+	if err := twoFactorLogin(s, u.Email, setup.RecoveryCodes[1]); err != nil {
+		t.Errorf("login with another recovery code = %v, want success", err)
+	}
+
+	var unused int64
+	db.Model(&RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", u.ID).Count(&unused)
+	if unused != recoveryCodeCount-2 {
+		t.Errorf("unused recovery codes = %d, want %d", unused, recoveryCodeCount-2)
+	}
+}
@@ -22,3 +22,16 @@ type WishlistItem struct {
 func (WishlistItem) TableName() string {
 	return "wishlist_items"
 }
+
+// WishlistShare is a public link to a user's wishlist; revoking deletes it
+type WishlistShare struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	UserID    uint      `gorm:"not null;uniqueIndex" json:"-"`
+	Token     string    `gorm:"not null;size:64;uniqueIndex" json:"token"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName overrides the table name
+func (WishlistShare) TableName() string {
+	return "wishlist_shares"
+}
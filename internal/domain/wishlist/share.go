@@ -0,0 +1,124 @@
+package wishlist
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrShareNotFound is returned when a share link does not exist or was revoked
+var ErrShareNotFound = errors.New("shared wishlist not found")
+
+// WishlistShareResponse represents a wishlist share link
+type WishlistShareResponse struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SharedWishlistResponse is the read-only public view of a shared wishlist. It
+// carries products and prices only, nothing about the owner.
+type SharedWishlistResponse struct {
+	Items    []WishlistItemResponse `json:"items"`
+	Count    int                    `json:"count"`
+	SharedAt time.Time              `json:"shared_at"`
+}
+
+// maxSharedItems caps the items returned in a shared wishlist view
+const maxSharedItems = 200
+
+// ShareWishlist returns the user's share link, creating one if none is active
+func (s *Service) ShareWishlist(userID uint) (*WishlistShareResponse, error) {
+	var share WishlistShare
+	err := s.db.Where("user_id = ?", userID).First(&share).Error
+	if err == gorm.ErrRecordNotFound {
+		token, err := generateShareToken()
+		if err != nil {
+			return nil, err
+		}
+		share = WishlistShare{UserID: userID, Token: token}
+		if err := s.db.Create(&share).Error; err != nil {
+			return nil, fmt.Errorf("failed to create share link: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	return &WishlistShareResponse{
+		Token:     share.Token,
+		URL:       fmt.Sprintf("%s/wishlist/shared/%s", strings.TrimRight(s.config.App.FrontendURL, "/"), share.Token),
+		CreatedAt: share.CreatedAt,
+	}, nil
+}
+
+// RevokeWishlistShare disables the user's share link; sharing again issues a new one
+func (s *Service) RevokeWishlistShare(userID uint) error {
+	result := s.db.Where("user_id = ?", userID).Delete(&WishlistShare{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke share link: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrShareNotFound
+	}
+	return nil
+}
+
+// GetSharedWishlist returns the wishlist behind a share token
+func (s *Service) GetSharedWishlist(token string) (*SharedWishlistResponse, error) {
+	var share WishlistShare
+	if err := s.db.Where("token = ?", token).First(&share).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrShareNotFound
+		}
+		return nil, fmt.Errorf("failed to get shared wishlist: %w", err)
+	}
+
+	var items []WishlistItem
+	if err := s.db.Where("user_id = ?", share.UserID).Order("added_at DESC").
+		Limit(maxSharedItems).Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve wishlist items: %w", err)
+	}
+
+	sharedItems := make([]WishlistItemResponse, len(items))
+	for i, item := range items {
+		sharedItems[i] = WishlistItemResponse{
+			ID:               item.ID,
+			ProductID:        item.ProductID,
+			ProductVariantID: item.ProductVariantID,
+			AddedAt:          item.AddedAt,
+		}
+	}
+	if err := s.loadProductDetails(sharedItems); err != nil {
+		return nil, err
+	}
+
+	// Internal pricing is not for the public
+	for i := range sharedItems {
+		if sharedItems[i].Product != nil {
+			sharedItems[i].Product.CostPrice = 0
+		}
+		if sharedItems[i].ProductVariant != nil {
+			sharedItems[i].ProductVariant.CostPrice = 0
+		}
+	}
+
+	return &SharedWishlistResponse{
+		Items:    sharedItems,
+		Count:    len(sharedItems),
+		SharedAt: share.CreatedAt,
+	}, nil
+}
+
+// generateShareToken returns a random, URL-safe share token
+func generateShareToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -5,13 +5,23 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/your-org/ecommerce-backend/internal/domain/analytics"
 	"github.com/your-org/ecommerce-backend/internal/domain/cart"
+	"github.com/your-org/ecommerce-backend/internal/domain/compare"
+	"github.com/your-org/ecommerce-backend/internal/domain/coupon"
+	"github.com/your-org/ecommerce-backend/internal/domain/download"
 	"github.com/your-org/ecommerce-backend/internal/domain/inventory"
 	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/payment"
+	"github.com/your-org/ecommerce-backend/internal/domain/policy"
 	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"github.com/your-org/ecommerce-backend/internal/domain/returns"
+	"github.com/your-org/ecommerce-backend/internal/domain/setting"
+	"github.com/your-org/ecommerce-backend/internal/domain/shipping"
 	"github.com/your-org/ecommerce-backend/internal/domain/upload"
 	"github.com/your-org/ecommerce-backend/internal/domain/user"
 	"github.com/your-org/ecommerce-backend/internal/domain/wishlist"
+	"github.com/your-org/ecommerce-backend/internal/pkg/email"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
@@ -37,6 +47,7 @@ func (m *Migration) RunAutoMigrations() error {
 		// User domain - Base tables
 		&user.User{},
 		&user.Address{},
+		&user.RecoveryCode{},
 
 		// Product domain - Base tables
 		&product.Category{},
@@ -55,9 +66,11 @@ func (m *Migration) RunAutoMigrations() error {
 
 		// Cart domain
 		&cart.CartItem{},
+		&cart.SavedItem{},
 
 		// Order domain - Dependent tables
 		&order.Order{},
+		&order.FulfillmentGroup{}, // Before OrderItem, which references it
 		&order.OrderItem{},
 		&order.Payment{},
 		&order.OrderStatusHistory{},
@@ -68,13 +81,62 @@ func (m *Migration) RunAutoMigrations() error {
 
 		// Wishlist domain
 		&wishlist.WishlistItem{},
+		&wishlist.WishlistShare{},
+
+		// Compare domain
+		&compare.CompareItem{},
 
 		&product.ProductReview{},
 		&product.ProductReviewImage{},
 		&product.ProductReviewHelpful{},
 		&product.ProductReviewReport{},
+		&product.ProductReviewReply{},
+		&product.ProductQuestion{},
+		&product.ProductAnswer{},
+		&product.ProductAnswerHelpful{},
+
+		// Analytics domain
+		&analytics.RevenueTarget{},
+		&analytics.SearchQuery{},
+
+		// Policy domain
+		&policy.StorePolicy{},
+
+		// Order export domain
+		&order.OrderExportSchedule{},
+		&order.OrderExportRun{},
+
+		// Coupon domain
+		&coupon.Coupon{},
+		&coupon.CouponRedemption{},
+
+		// Setting domain
+		&setting.Setting{},
+
+		// Shipping domain
+		&shipping.ShippingZone{},
+		&shipping.ShippingZoneRegion{},
+		&shipping.ShippingMethod{},
+		&shipping.ShippingRate{},
+
+		// Returns domain
+		&returns.ReturnRequest{},
+		&returns.ReturnItem{},
+
+		// Payment webhook delivery log
+		&payment.PaymentWebhookEvent{},
+
+		// Operator-edited email templates
+		&email.EmailTemplate{},
+
+		// Download domain
+		&download.DigitalDownload{},
 	}
 
+	// Shipping methods created before lead times existed would all get the column default
+	backfillLeadTimes := m.db.Migrator().HasTable(&shipping.ShippingMethod{}) &&
+		!m.db.Migrator().HasColumn(&shipping.ShippingMethod{}, "LeadTimeDays")
+
 	// Run auto-migration for each model
 	for _, model := range models {
 		log.Printf("Migrating model: %T", model)
@@ -83,10 +145,41 @@ func (m *Migration) RunAutoMigrations() error {
 		}
 	}
 
+	if backfillLeadTimes {
+		if err := m.backfillShippingLeadTimes(); err != nil {
+			return err
+		}
+	}
+
 	log.Println("✅ Database auto-migrations completed successfully")
 	return nil
 }
 
+// backfillShippingLeadTimes gives the seeded shipping methods their own lead times,
+// and same-day methods a single day, instead of DefaultLeadTimeDays
+func (m *Migration) backfillShippingLeadTimes() error {
+	leadTimes := map[string]int{"same_day": 1}
+	for _, method := range shipping.DefaultMethods(setting.DefaultShippingSettings()) {
+		leadTimes[method.Code] = method.LeadTimeDays
+	}
+
+	for code, days := range leadTimes {
+		if err := m.db.Model(&shipping.ShippingMethod{}).
+			Where("code = ?", code).
+			Update("lead_time_days", days).Error; err != nil {
+			return fmt.Errorf("failed to backfill lead time for shipping method %s: %w", code, err)
+		}
+	}
+	if err := m.db.Model(&shipping.ShippingMethod{}).
+		Where("LOWER(estimated_days) LIKE ?", "%same day%").
+		Update("lead_time_days", 1).Error; err != nil {
+		return fmt.Errorf("failed to backfill same-day lead times: %w", err)
+	}
+
+	log.Println("✅ Backfilled shipping method lead times")
+	return nil
+}
+
 // CreateIndexes creates additional indexes for better performance
 func (m *Migration) CreateIndexes() error {
 	log.Println("🔄 Creating additional database indexes...")
@@ -181,6 +274,17 @@ func (m *Migration) CreateIndexes() error {
 		"CREATE INDEX IF NOT EXISTS idx_product_review_reports_user ON product_review_reports(user_id)",
 		"CREATE INDEX IF NOT EXISTS idx_product_review_reports_status ON product_review_reports(status)",
 		"CREATE UNIQUE INDEX IF NOT EXISTS idx_product_review_reports_unique ON product_review_reports(review_id, user_id)",
+
+		// Product Q&A indexes
+		"CREATE INDEX IF NOT EXISTS idx_product_questions_product_approved ON product_questions(product_id, is_approved)",
+		"CREATE INDEX IF NOT EXISTS idx_product_answers_question_approved ON product_answers(question_id, is_approved)",
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_product_answer_helpful_unique ON product_answer_helpful(answer_id, user_id)",
+
+		// Revenue target indexes
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_revenue_targets_effective_from ON revenue_targets(effective_from)",
+
+		// Coupon redemption indexes
+		"CREATE INDEX IF NOT EXISTS idx_coupon_redemptions_coupon_user ON coupon_redemptions(coupon_id, user_id)",
 	}
 
 	successCount := 0
@@ -199,6 +303,45 @@ func (m *Migration) CreateIndexes() error {
 	return nil
 }
 
+// CreateSearchTriggers adds the products.search_vector full-text column and the
+// trigger that keeps it in sync with name, tags and description, weighted in
+// that order, then fills it for existing rows
+func (m *Migration) CreateSearchTriggers() error {
+	log.Println("🔄 Creating product search triggers...")
+
+	statements := []string{
+		"ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector",
+		`CREATE OR REPLACE FUNCTION products_search_vector_update() RETURNS trigger AS $$
+BEGIN
+	NEW.search_vector :=
+		setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(NEW.tags, '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(NEW.description, '')), 'C');
+	RETURN NEW;
+END
+$$ LANGUAGE plpgsql`,
+		"DROP TRIGGER IF EXISTS products_search_vector_trigger ON products",
+		`CREATE TRIGGER products_search_vector_trigger
+	BEFORE INSERT OR UPDATE OF name, tags, description ON products
+	FOR EACH ROW EXECUTE FUNCTION products_search_vector_update()`,
+		`UPDATE products SET search_vector =
+	setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+	setweight(to_tsvector('english', coalesce(tags, '')), 'B') ||
+	setweight(to_tsvector('english', coalesce(description, '')), 'C')
+WHERE search_vector IS NULL`,
+		"CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN(search_vector)",
+	}
+
+	for _, statement := range statements {
+		if err := m.db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to create product search trigger: %w", err)
+		}
+	}
+
+	log.Println("✅ Product search triggers created successfully")
+	return nil
+}
+
 // SeedInitialData inserts initial data into the database
 func (m *Migration) SeedInitialData() error {
 	log.Println("🌱 Seeding initial data...")
@@ -227,6 +370,14 @@ func (m *Migration) SeedInitialData() error {
 		return fmt.Errorf("failed to seed test reviews: %w", err)
 	}
 
+	if err := m.seedCoupons(); err != nil {
+		return fmt.Errorf("failed to seed coupons: %w", err)
+	}
+
+	if err := m.seedShippingMethods(); err != nil {
+		return fmt.Errorf("failed to seed shipping methods: %w", err)
+	}
+
 	log.Println("✅ Initial data seeded successfully")
 	return nil
 }
@@ -482,17 +633,43 @@ func (m *Migration) DropAllTables() error {
 
 	// Define tables in reverse dependency order
 	tables := []string{
+		"return_items",
+		"return_requests",
+		"digital_downloads",
+		"email_templates",
+		"settings",
+		"shipping_rates",
+		"shipping_methods",
+		"shipping_zone_regions",
+		"shipping_zones",
+		"order_export_runs",
+		"order_export_schedules",
+		"coupon_redemptions",
+		"coupons",
+		"compare_items",
+		"store_policies",
+		"search_queries",
+		"revenue_targets",
 		"order_status_history",
+		"payment_webhook_events",
 		"payments", // Payment table for Razorpay integration
 		"order_items",
+		"order_fulfillment_groups",
 		"orders",
+		"saved_items",
+		"wishlist_shares",
 		"cart_items",
+		"product_answer_helpful",
+		"product_answers",
+		"product_questions",
+		"product_review_replies",
 		"product_reviews",
 		"product_variants",
 		"product_images",
 		"products",
 		"brands",
 		"categories",
+		"user_recovery_codes",
 		"addresses",
 		"users",
 	}
@@ -809,3 +986,96 @@ func (m *Migration) seedReviewHelpfulVotes() error {
 	log.Printf("✅ Created %d helpful votes", createdVotes)
 	return nil
 }
+
+// seedCoupons creates the starter coupons that used to be hardcoded in checkout
+func (m *Migration) seedCoupons() error {
+	var couponCount int64
+	m.db.Model(&coupon.Coupon{}).Count(&couponCount)
+	if couponCount > 0 {
+		log.Println("⏭️ Coupons already exist")
+		return nil
+	}
+
+	coupons := []coupon.Coupon{
+		{
+			Code:              "SAVE10",
+			Description:       "10% off orders over ₹1999",
+			DiscountType:      coupon.DiscountTypePercentage,
+			DiscountValue:     10,
+			MinOrderAmount:    199900, // ₹1999
+			MaxDiscountAmount: 149900, // ₹1499
+			IsActive:          true,
+		},
+		{
+			Code:           "FLAT500",
+			Description:    "₹500 off orders over ₹2999",
+			DiscountType:   coupon.DiscountTypeFixedAmount,
+			DiscountValue:  50000,  // ₹500
+			MinOrderAmount: 299900, // ₹2999
+			IsActive:       true,
+		},
+		{
+			Code:              "WELCOME20",
+			Description:       "20% off your first order",
+			DiscountType:      coupon.DiscountTypePercentage,
+			DiscountValue:     20,
+			MinOrderAmount:    99900,  // ₹999
+			MaxDiscountAmount: 199900, // ₹1999
+			PerUserLimit:      1,
+			IsActive:          true,
+		},
+	}
+
+	if err := m.db.Create(&coupons).Error; err != nil {
+		return err
+	}
+
+	log.Printf("✅ Created %d coupons", len(coupons))
+	return nil
+}
+
+// seedShippingMethods creates standard and express shipping everywhere, priced from
+// the default shipping settings, and same-day delivery for the metro zone
+func (m *Migration) seedShippingMethods() error {
+	var methodCount int64
+	m.db.Model(&shipping.ShippingMethod{}).Count(&methodCount)
+	if methodCount > 0 {
+		log.Println("⏭️ Shipping methods already exist")
+		return nil
+	}
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		metro := shipping.ShippingZone{
+			Name: "Metro India",
+			Regions: []shipping.ShippingZoneRegion{
+				{Country: "IN", State: "Maharashtra"},
+				{Country: "IN", State: "Delhi"},
+				{Country: "IN", State: "Karnataka"},
+			},
+		}
+		if err := tx.Create(&metro).Error; err != nil {
+			return err
+		}
+
+		defaults := setting.DefaultShippingSettings()
+		methods := append(shipping.DefaultMethods(defaults), shipping.ShippingMethod{
+			Code:          "same_day",
+			Name:          "Same Day Delivery",
+			Description:   "Delivery within 24 hours",
+			Carrier:       "Dunzo",
+			EstimatedDays: "Same day",
+			LeadTimeDays:  1,
+			IsActive:      true,
+			SortOrder:     3,
+			Rates: []shipping.ShippingRate{
+				{ZoneID: &metro.ID, Price: defaults.Rates.SameDay},
+			},
+		})
+		if err := tx.Create(&methods).Error; err != nil {
+			return err
+		}
+
+		log.Printf("✅ Created %d shipping methods", len(methods))
+		return nil
+	})
+}
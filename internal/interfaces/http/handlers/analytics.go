@@ -4,10 +4,13 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"github.com/your-org/ecommerce-backend/internal/domain/analytics"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
 	"gorm.io/gorm"
 )
 
@@ -18,9 +21,9 @@ type AnalyticsHandler struct {
 }
 
 // NewAnalyticsHandler creates a new analytics handler
-func NewAnalyticsHandler(db *gorm.DB, cfg *config.Config) *AnalyticsHandler {
+func NewAnalyticsHandler(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *AnalyticsHandler {
 	return &AnalyticsHandler{
-		analyticsService: analytics.NewService(db, cfg),
+		analyticsService: analytics.NewService(db, redisClient, cfg),
 		config:           cfg,
 	}
 }
@@ -124,6 +127,56 @@ func (h *AnalyticsHandler) GetSales(c *gin.Context) {
 	})
 }
 
+// GetPeakTimes handles GET /admin/analytics/sales/peak-times
+func (h *AnalyticsHandler) GetPeakTimes(c *gin.Context) {
+	var req analytics.PeakTimesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	peakTimes, err := h.analyticsService.GetPeakTimes(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to retrieve peak shopping times: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Peak shopping times retrieved successfully",
+		"data":    peakTimes,
+	})
+}
+
+// GetSearchTerms handles GET /admin/analytics/search-terms
+func (h *AnalyticsHandler) GetSearchTerms(c *gin.Context) {
+	var req analytics.SearchTermsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	report, err := h.analyticsService.GetTopSearchTerms(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to retrieve search terms: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Search terms retrieved successfully",
+		"data":    report,
+	})
+}
+
 // GetProducts handles GET /admin/analytics/products
 func (h *AnalyticsHandler) GetProducts(c *gin.Context) {
 	productData, err := h.analyticsService.GetProductAnalytics()
@@ -228,6 +281,148 @@ func (h *AnalyticsHandler) GetRevenue(c *gin.Context) {
 	})
 }
 
+// GetAbandonedCarts handles GET /admin/analytics/abandoned-carts
+func (h *AnalyticsHandler) GetAbandonedCarts(c *gin.Context) {
+	// Carts idle for longer than this are considered abandoned (default 24 hours)
+	olderThan, err := time.ParseDuration(c.DefaultQuery("older_than", "24h"))
+	if err != nil || olderThan <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid older_than duration (e.g. 24h, 90m)",
+		})
+		return
+	}
+
+	summary, err := h.analyticsService.GetAbandonedCarts(olderThan)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve abandoned carts",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Abandoned carts retrieved successfully",
+		"data":    summary,
+	})
+}
+
+// GetRevenueTargets handles GET /admin/analytics/revenue/targets
+func (h *AnalyticsHandler) GetRevenueTargets(c *gin.Context) {
+	current, err := h.analyticsService.GetRevenueTargetAt(time.Now().In(h.config.GetLocation()))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve revenue targets",
+		})
+		return
+	}
+
+	history, err := h.analyticsService.GetRevenueTargetHistory()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve revenue targets",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Revenue targets retrieved successfully",
+		"data": gin.H{
+			"current": current,
+			"history": history,
+		},
+	})
+}
+
+// UpdateRevenueTargets handles PUT /admin/analytics/revenue/targets
+func (h *AnalyticsHandler) UpdateRevenueTargets(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req analytics.RevenueTargetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	target, err := h.analyticsService.SetRevenueTargets(&req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Revenue targets updated successfully",
+		"data":    target,
+	})
+}
+
+// ExportSales handles GET /admin/analytics/sales/export
+func (h *AnalyticsHandler) ExportSales(c *gin.Context) {
+	var req analytics.ReportExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	data, filename, err := h.analyticsService.ExportSalesReport(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to export sales report: " + err.Error(),
+		})
+		return
+	}
+
+	sendReportFile(c, req.Format, filename, data)
+}
+
+// ExportRevenue handles GET /admin/analytics/revenue/export
+func (h *AnalyticsHandler) ExportRevenue(c *gin.Context) {
+	var req analytics.ReportExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	data, filename, err := h.analyticsService.ExportRevenueReport(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to export revenue report: " + err.Error(),
+		})
+		return
+	}
+
+	sendReportFile(c, req.Format, filename, data)
+}
+
+// sendReportFile writes an exported report as a file download
+func sendReportFile(c *gin.Context, format, filename string, data []byte) {
+	contentType := "text/csv"
+	if format == "xlsx" {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Content-Length", strconv.Itoa(len(data)))
+
+	c.Data(http.StatusOK, contentType, data)
+}
+
 // Helper functions for formatting data
 
 // formatCurrency formats cents to currency string
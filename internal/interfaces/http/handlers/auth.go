@@ -4,9 +4,12 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,6 +18,7 @@ import (
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"github.com/your-org/ecommerce-backend/internal/domain/user"
 	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"github.com/your-org/ecommerce-backend/internal/pkg/auth"
 	"github.com/your-org/ecommerce-backend/internal/pkg/email"
 	"gorm.io/gorm"
 )
@@ -31,11 +35,11 @@ type AuthHandler struct {
 // NewAuthHandler creates a new auth handler
 func NewAuthHandler(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *AuthHandler {
 	return &AuthHandler{
-		userService:  user.NewService(db, cfg),
+		userService:  user.NewService(db, redisClient, cfg),
 		config:       cfg,
 		db:           db,
 		redisClient:  redisClient,
-		emailService: email.NewEmailService(cfg),
+		emailService: email.NewEmailService(db, cfg),
 	}
 }
 
@@ -47,7 +51,8 @@ type TokenService struct {
 type TokenData struct {
 	UserID    uint      `json:"user_id"`
 	Email     string    `json:"email"`
-	TokenType string    `json:"token_type"` // "email_verification", "password_reset"
+	TokenType string    `json:"token_type"` // "email_verification", "password_reset", "email_change"
+	NewEmail  string    `json:"new_email,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
@@ -63,8 +68,14 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	response, err := h.userService.Register(&req)
+	response, err := h.userService.Register(&req, clientInfo(c))
 	if err != nil {
+		if errors.Is(err, auth.ErrWeakPassword) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusConflict, gin.H{
 			"error": err.Error(),
 		})
@@ -105,8 +116,25 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	response, err := h.userService.Login(&req)
+	response, err := h.userService.Login(&req, clientInfo(c))
 	if err != nil {
+		var locked *user.AccountLockedError
+		if errors.As(err, &locked) {
+			retryAfter := int(math.Ceil(locked.RetryAfter.Seconds()))
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":       err.Error(),
+				"retry_after": retryAfter,
+			})
+			return
+		}
+		if errors.Is(err, user.ErrTwoFactorRequired) || errors.Is(err, user.ErrInvalidTwoFactorCode) {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":               err.Error(),
+				"two_factor_required": true,
+			})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -133,7 +161,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	response, err := h.userService.RefreshToken(req.RefreshToken)
+	response, err := h.userService.RefreshToken(req.RefreshToken, clientInfo(c))
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": err.Error(),
@@ -149,19 +177,58 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 // Logout handles user logout
 func (h *AuthHandler) Logout(c *gin.Context) {
-	// In a stateless JWT system, logout is handled client-side
-	// Here you could add token blacklisting logic if needed
+	claims, exists := middleware.GetTokenClaimsFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
 
-	userID, exists := middleware.GetUserIDFromContext(c)
-	if exists {
-		log.Printf("User %d logged out", userID)
+	// The refresh token is optional; without it only the access token is revoked
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
 	}
+	_ = c.ShouldBindJSON(&req)
+
+	if err := h.userService.Logout(claims, req.RefreshToken); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to log out",
+		})
+		return
+	}
+
+	log.Printf("User %d logged out", claims.UserID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Logged out successfully",
 	})
 }
 
+// LogoutAll handles POST /auth/logout-all
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.userService.LogoutAll(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to log out of all sessions",
+		})
+		return
+	}
+
+	log.Printf("User %d logged out of all sessions", userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged out of all sessions successfully",
+	})
+}
+
 // GetProfile gets current user profile
 func (h *AuthHandler) GetProfile(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
@@ -522,6 +589,234 @@ func (h *AuthHandler) ValidateToken(c *gin.Context) {
 	})
 }
 
+// EnableTwoFactor handles POST /auth/2fa/enable
+func (h *AuthHandler) EnableTwoFactor(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	setup, err := h.userService.EnableTwoFactor(userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, user.ErrTwoFactorAlreadyEnabled) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Scan the code with your authenticator app, then verify it to finish enabling two-factor authentication",
+		"data":    setup,
+	})
+}
+
+// VerifyTwoFactor handles POST /auth/2fa/verify
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req user.TwoFactorVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.userService.VerifyTwoFactor(userID, req.Code); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, user.ErrInvalidTwoFactorCode), errors.Is(err, user.ErrTwoFactorNotPending):
+			status = http.StatusBadRequest
+		case errors.Is(err, user.ErrTwoFactorAlreadyEnabled):
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Two-factor authentication enabled successfully",
+	})
+}
+
+// ChangeEmail handles POST /auth/change-email
+func (h *AuthHandler) ChangeEmail(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req user.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userRecord, newEmail, err := h.userService.RequestEmailChange(userID, &req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, user.ErrEmailInUse) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	token, err := h.generateEmailChangeToken(userID, newEmail)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to generate confirmation token",
+		})
+		return
+	}
+
+	// Send the confirmation link to the new address asynchronously
+	go func() {
+		ctx := context.Background()
+		userName := userRecord.GetDisplayName()
+
+		emailData := email.EmailVerificationData{
+			EmailTemplateData: email.GetBaseTemplateData(
+				h.config.External.Email.FromName,
+				h.config.External.Email.BaseURL,
+				userName,
+				newEmail,
+			),
+			VerificationURL: fmt.Sprintf("%s/confirm-email-change?token=%s", h.config.External.Email.BaseURL, token),
+			ExpiryTime:      "24 hours",
+		}
+
+		if err := h.emailService.SendEmailVerificationEmail(ctx, emailData); err != nil {
+			log.Printf("Failed to send email change confirmation to %s: %v", newEmail, err)
+		}
+	}()
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "A confirmation link has been sent to the new email address",
+	})
+}
+
+// ConfirmEmailChange handles GET /auth/confirm-email-change
+func (h *AuthHandler) ConfirmEmailChange(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Confirmation token is required",
+		})
+		return
+	}
+
+	tokenData, err := h.validateToken(token, "email_change")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid or expired confirmation token",
+		})
+		return
+	}
+
+	if err := h.userService.ConfirmEmailChange(tokenData.UserID, tokenData.NewEmail); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, user.ErrEmailInUse) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Invalidate the confirmation token
+	h.invalidateToken(token)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email changed successfully",
+	})
+}
+
+// GetSessions handles GET /auth/sessions
+func (h *AuthHandler) GetSessions(c *gin.Context) {
+	claims, exists := middleware.GetTokenClaimsFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	sessions, err := h.userService.ListSessions(claims.UserID, claims.SessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve sessions",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sessions retrieved successfully",
+		"data":    sessions,
+	})
+}
+
+// RevokeSession handles DELETE /auth/sessions/:id
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.userService.RevokeSession(userID, c.Param("id")); err != nil {
+		if errors.Is(err, user.ErrSessionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Session not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to revoke session",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Session revoked successfully",
+	})
+}
+
+// clientInfo describes the client making the request, for session tracking
+func clientInfo(c *gin.Context) user.ClientInfo {
+	return user.ClientInfo{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
 // Token management helper methods
 
 func (h *AuthHandler) generateVerificationToken(userID uint, email string) (string, error) {
@@ -552,6 +847,21 @@ func (h *AuthHandler) generatePasswordResetToken(userID uint, email string) (str
 	return h.storeToken(token, tokenData)
 }
 
+func (h *AuthHandler) generateEmailChangeToken(userID uint, newEmail string) (string, error) {
+	token := uuid.New().String()
+
+	tokenData := TokenData{
+		UserID:    userID,
+		Email:     newEmail,
+		NewEmail:  newEmail,
+		TokenType: "email_change",
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: time.Now().UTC().Add(24 * time.Hour), // 24 hours expiry
+	}
+
+	return h.storeToken(token, tokenData)
+}
+
 // Better storage format (JSON)
 func (h *AuthHandler) storeToken(token string, data TokenData) (string, error) {
 	ctx := context.Background()
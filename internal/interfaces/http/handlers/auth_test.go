@@ -0,0 +1,248 @@
+// internal/interfaces/http/handlers/auth_test.go
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/user"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const testPassword = "Correct-Horse-42"
+
+func newAuthTestConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.JWT.Secret = "test-secret"
+	cfg.JWT.AccessTokenExpiry = 15 * time.Minute
+	cfg.JWT.RefreshTokenExpiry = 24 * time.Hour
+	cfg.Security.BcryptCost = bcrypt.MinCost
+	cfg.Security.LoginMaxAttempts = 3
+	cfg.Security.LoginAccountMaxAttempts = 5
+	cfg.Security.LoginAttemptWindow = 15 * time.Minute
+	cfg.Security.LoginLockoutDuration = 15 * time.Minute
+	cfg.Security.LoginMaxLockout = time.Hour
+	return cfg
+}
+
+// newAuthTestHandler returns an auth handler over a fresh database and Redis, routed
+// the way the API routes it
+func newAuthTestHandler(t *testing.T, cfg *config.Config) (*gin.Engine, *gorm.DB, *redis.Client) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	db := testutil.NewDB(t, &user.User{}, &user.Address{})
+	redisClient, _ := testutil.NewRedis(t)
+	h := NewAuthHandler(db, redisClient, cfg)
+
+	router := gin.New()
+	router.POST("/auth/login", h.Login)
+	router.POST("/auth/refresh", h.RefreshToken)
+	router.GET("/auth/confirm-email-change", h.ConfirmEmailChange)
+	router.POST("/auth/change-email", middleware.AuthMiddleware(cfg, redisClient), h.ChangeEmail)
+	return router, db, redisClient
+}
+
+func createTestUser(t *testing.T, db *gorm.DB, email string) *user.User {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &user.User{Email: email, Password: string(hash), FirstName: "Test", LastName: "User", IsActive: true}
+	if err := db.Create(u).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	return u
+}
+
+// postJSON sends a JSON request from the given client IP
+func postJSON(router *gin.Engine, path, clientIP string, body interface{}, headers ...string) *httptest.ResponseRecorder {
+	data, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = clientIP + ":40000"
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func login(router *gin.Engine, clientIP, email, password string) *httptest.ResponseRecorder {
+	return postJSON(router, "/auth/login", clientIP, map[string]string{"email": email, "password": password})
+}
+
+func TestLoginLockoutPerClient(t *testing.T) {
+	router, db, _ := newAuthTestHandler(t, newAuthTestConfig())
+	createTestUser(t, db, "shopper@example.com")
+
+	for i := 1; i < 3; i++ {
+		if w := login(router, "10.0.0.1", "shopper@example.com", "wrong"); w.Code != http.StatusBadRequest {
+			t.Fatalf("failure %d status = %d, want %d", i, w.Code, http.StatusBadRequest)
+		}
+	}
+
+	w := login(router, "10.0.0.1", "shopper@example.com", "wrong")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("third failure status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	if got := w.Header().Get("Retry-After"); got != "900" {
+		t.Errorf("Retry-After = %q, want 900", got)
+	}
+
+	// Locked for this client even with the right password, but not for others
+	if w := login(router, "10.0.0.1", "shopper@example.com", testPassword); w.Code != http.StatusTooManyRequests {
+		t.Errorf("locked client login status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	if w := login(router, "10.0.0.2", "shopper@example.com", testPassword); w.Code != http.StatusOK {
+		t.Errorf("other client login status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestLoginLockoutAcrossClients(t *testing.T) {
+	router, db, _ := newAuthTestHandler(t, newAuthTestConfig())
+	createTestUser(t, db, "shopper@example.com")
+
+	// One failure from each of five addresses stays under the per-client limit
+	var w *httptest.ResponseRecorder
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5"} {
+		w = login(router, ip, "shopper@example.com", "wrong")
+	}
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Fatalf("fifth failure = %d with Retry-After %q, want %d with Retry-After", w.Code,
+			w.Header().Get("Retry-After"), http.StatusTooManyRequests)
+	}
+
+	if w := login(router, "10.0.0.9", "SHOPPER@example.com", testPassword); w.Code != http.StatusTooManyRequests {
+		t.Errorf("login from a new client status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+}
+
+func TestLoginSuccessResetsFailures(t *testing.T) {
+	router, db, _ := newAuthTestHandler(t, newAuthTestConfig())
+	createTestUser(t, db, "shopper@example.com")
+
+	login(router, "10.0.0.1", "shopper@example.com", "wrong")
+	login(router, "10.0.0.1", "shopper@example.com", "wrong")
+	if w := login(router, "10.0.0.1", "shopper@example.com", testPassword); w.Code != http.StatusOK {
+		t.Fatalf("login status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	// Counting starts again, so two more failures don't reach the limit of three
+	for i := 1; i <= 2; i++ {
+		if w := login(router, "10.0.0.1", "shopper@example.com", "wrong"); w.Code != http.StatusBadRequest {
+			t.Fatalf("failure %d after a successful login status = %d, want %d", i, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+// requestEmailChange logs in and asks to move the account to newEmail, returning the
+// confirmation token that was emailed to it
+func requestEmailChange(t *testing.T, router *gin.Engine, redisClient *redis.Client, email, newEmail string) string {
+	t.Helper()
+	var loggedIn struct {
+		Data user.AuthResponse `json:"data"`
+	}
+	json.Unmarshal(login(router, "10.0.0.1", email, testPassword).Body.Bytes(), &loggedIn)
+
+	w := postJSON(router, "/auth/change-email", "10.0.0.1",
+		map[string]string{"new_email": newEmail, "password": testPassword},
+		"Authorization", "Bearer "+loggedIn.Data.AccessToken)
+	if w.Code != http.StatusOK {
+		t.Fatalf("change-email status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	keys, _ := redisClient.Keys(context.Background(), "auth_token:*").Result()
+	if len(keys) != 1 {
+		t.Fatalf("confirmation tokens = %v, want one", keys)
+	}
+	return strings.TrimPrefix(keys[0], "auth_token:")
+}
+
+func confirmEmailChange(router *gin.Engine, token string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/confirm-email-change?token="+token, nil))
+	return w
+}
+
+func TestConfirmEmailChange(t *testing.T) {
+	router, db, redisClient := newAuthTestHandler(t, newAuthTestConfig())
+	u := createTestUser(t, db, "old@example.com")
+	token := requestEmailChange(t, router, redisClient, u.Email, "New@Example.com")
+
+	// Nothing changes until the new address is confirmed
+	var pending user.User
+	db.First(&pending, u.ID)
+	if pending.Email != "old@example.com" {
+		t.Fatalf("email before confirming = %s, want it unchanged", pending.Email)
+	}
+
+	if w := confirmEmailChange(router, token); w.Code != http.StatusOK {
+		t.Fatalf("confirm status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var changed user.User
+	db.First(&changed, u.ID)
+	if changed.Email != "new@example.com" || !changed.EmailVerified || changed.EmailVerifiedAt == nil {
+		t.Errorf("user = %s verified %v, want new@example.com verified", changed.Email, changed.EmailVerified)
+	}
+	if w := login(router, "10.0.0.1", "new@example.com", testPassword); w.Code != http.StatusOK {
+		t.Errorf("login with the new email status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	// The link works once
+	if w := confirmEmailChange(router, token); w.Code != http.StatusBadRequest {
+		t.Errorf("reused token status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestConfirmEmailChangeExpiredToken(t *testing.T) {
+	router, db, redisClient := newAuthTestHandler(t, newAuthTestConfig())
+	u := createTestUser(t, db, "old@example.com")
+	token := requestEmailChange(t, router, redisClient, u.Email, "new@example.com")
+
+	// Age the token past its expiry
+	ctx := context.Background()
+	key := "auth_token:" + token
+	var data TokenData
+	raw, _ := redisClient.Get(ctx, key).Bytes()
+	json.Unmarshal(raw, &data)
+	data.ExpiresAt = time.Now().Add(-time.Minute)
+	raw, _ = json.Marshal(data)
+	redisClient.Set(ctx, key, raw, time.Hour)
+
+	if w := confirmEmailChange(router, token); w.Code != http.StatusBadRequest {
+		t.Errorf("expired token status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	var unchanged user.User
+	db.First(&unchanged, u.ID)
+	if unchanged.Email != "old@example.com" {
+		t.Errorf("email = %s, want it unchanged", unchanged.Email)
+	}
+}
+
+func TestConfirmEmailChangeRejectsOtherTokenTypes(t *testing.T) {
+	router, db, redisClient := newAuthTestHandler(t, newAuthTestConfig())
+	u := createTestUser(t, db, "old@example.com")
+	h := NewAuthHandler(db, redisClient, newAuthTestConfig())
+	token, err := h.generatePasswordResetToken(u.ID, u.Email)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w := confirmEmailChange(router, token); w.Code != http.StatusBadRequest {
+		t.Errorf("password reset token status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,239 @@
+// internal/interfaces/http/handlers/brand.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"gorm.io/gorm"
+)
+
+// BrandHandler handles brand endpoints
+type BrandHandler struct {
+	brandService *product.BrandService
+	config       *config.Config
+}
+
+// NewBrandHandler creates a new brand handler
+func NewBrandHandler(db *gorm.DB, cfg *config.Config) *BrandHandler {
+	return &BrandHandler{
+		brandService: product.NewBrandService(db, cfg),
+		config:       cfg,
+	}
+}
+
+// GetBrands handles GET /products/brands
+func (h *BrandHandler) GetBrands(c *gin.Context) {
+	h.listBrands(c, false)
+}
+
+// GetBrand handles GET /products/brands/:id
+func (h *BrandHandler) GetBrand(c *gin.Context) {
+	h.getBrand(c, false)
+}
+
+// GetBrandBySlug handles GET /products/brands/slug/:slug
+func (h *BrandHandler) GetBrandBySlug(c *gin.Context) {
+	brand, err := h.brandService.GetBrandBySlug(c.Param("slug"))
+	if err != nil {
+		respondBrandError(c, err, "Failed to retrieve brand")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Brand retrieved successfully",
+		"data":    brand,
+	})
+}
+
+// AdminGetBrands handles GET /admin/brands
+func (h *BrandHandler) AdminGetBrands(c *gin.Context) {
+	h.listBrands(c, true)
+}
+
+// AdminGetBrand handles GET /admin/brands/:id
+func (h *BrandHandler) AdminGetBrand(c *gin.Context) {
+	h.getBrand(c, true)
+}
+
+// AdminCreateBrand handles POST /admin/brands
+func (h *BrandHandler) AdminCreateBrand(c *gin.Context) {
+	var req product.BrandCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	brand, err := h.brandService.CreateBrand(&req)
+	if err != nil {
+		respondBrandError(c, err, "")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Brand created successfully",
+		"data":    brand,
+	})
+}
+
+// AdminUpdateBrand handles PUT /admin/brands/:id
+func (h *BrandHandler) AdminUpdateBrand(c *gin.Context) {
+	brandID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid brand ID",
+		})
+		return
+	}
+
+	var req product.BrandUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	brand, err := h.brandService.UpdateBrand(uint(brandID), &req)
+	if err != nil {
+		respondBrandError(c, err, "")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Brand updated successfully",
+		"data":    brand,
+	})
+}
+
+// AdminDeleteBrand handles DELETE /admin/brands/:id?detach_products=true
+func (h *BrandHandler) AdminDeleteBrand(c *gin.Context) {
+	brandID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid brand ID",
+		})
+		return
+	}
+
+	detachProducts := c.Query("detach_products") == "true"
+	if err := h.brandService.DeleteBrand(uint(brandID), detachProducts); err != nil {
+		respondBrandError(c, err, "Failed to delete brand")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Brand deleted successfully",
+	})
+}
+
+// AdminAssignBrandProducts handles POST /admin/brands/:id/products
+func (h *BrandHandler) AdminAssignBrandProducts(c *gin.Context) {
+	h.changeBrandProducts(c, h.brandService.AssignProducts, "Products assigned to brand successfully")
+}
+
+// AdminRemoveBrandProducts handles DELETE /admin/brands/:id/products
+func (h *BrandHandler) AdminRemoveBrandProducts(c *gin.Context) {
+	h.changeBrandProducts(c, h.brandService.RemoveProducts, "Products removed from brand successfully")
+}
+
+// listBrands serves a paginated brand list, including inactive brands for admins
+func (h *BrandHandler) listBrands(c *gin.Context, includeInactive bool) {
+	var req product.BrandListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	brands, err := h.brandService.GetBrands(&req, includeInactive)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve brands",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Brands retrieved successfully",
+		"data":    brands,
+	})
+}
+
+// getBrand serves a single brand, including inactive brands for admins
+func (h *BrandHandler) getBrand(c *gin.Context, includeInactive bool) {
+	brandID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid brand ID",
+		})
+		return
+	}
+
+	brand, err := h.brandService.GetBrand(uint(brandID), includeInactive)
+	if err != nil {
+		respondBrandError(c, err, "Failed to retrieve brand")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Brand retrieved successfully",
+		"data":    brand,
+	})
+}
+
+// changeBrandProducts binds a product ID list and applies the given association change
+func (h *BrandHandler) changeBrandProducts(c *gin.Context, change func(uint, *product.BrandProductsRequest) (*product.BrandProductsResult, error), message string) {
+	brandID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid brand ID",
+		})
+		return
+	}
+
+	var req product.BrandProductsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := change(uint(brandID), &req)
+	if err != nil {
+		respondBrandError(c, err, "Failed to update brand products")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    result,
+	})
+}
+
+// respondBrandError maps brand service errors to HTTP responses. When fallback is empty,
+// unrecognised errors are treated as validation failures and returned as-is.
+func respondBrandError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, product.ErrBrandNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, product.ErrBrandSlugTaken), errors.Is(err, product.ErrBrandInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case fallback != "":
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
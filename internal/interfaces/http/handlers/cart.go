@@ -2,6 +2,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -12,6 +13,7 @@ import (
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"github.com/your-org/ecommerce-backend/internal/domain/cart"
 	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"github.com/your-org/ecommerce-backend/internal/pkg/i18n"
 	"gorm.io/gorm"
 )
 
@@ -45,13 +47,13 @@ func (h *CartHandler) GetCart(c *gin.Context) {
 	cartResponse, err := h.cartService.GetCart(userID, sessionID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve cart",
+			"error": localize(c, i18n.MsgFailedToRetrieveCart),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Cart retrieved successfully",
+		"message": localize(c, i18n.MsgCartRetrieved),
 		"data":    cartResponse,
 	})
 }
@@ -64,7 +66,7 @@ func (h *CartHandler) AddToCart(c *gin.Context) {
 	var req cart.AddToCartRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
+			"error":   localize(c, i18n.MsgInvalidRequestData),
 			"details": err.Error(),
 		})
 		return
@@ -79,11 +81,39 @@ func (h *CartHandler) AddToCart(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Item added to cart successfully",
+		"message": localize(c, i18n.MsgCartItemAdded),
 		"data":    cartResponse,
 	})
 }
 
+// BulkAddToCart handles POST /cart/items/bulk
+func (h *CartHandler) BulkAddToCart(c *gin.Context) {
+	userID := h.getUserIDAsPointer(c)
+	sessionID := h.getOrCreateSessionID(c)
+
+	var req cart.BulkAddToCartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   localize(c, i18n.MsgInvalidRequestData),
+			"details": err.Error(),
+		})
+		return
+	}
+
+	response, err := h.cartService.BulkAddToCart(userID, sessionID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": localize(c, i18n.MsgCartItemsBulkAdded, response.Added, len(req.Items)),
+		"data":    response,
+	})
+}
+
 // UpdateCartItem handles PUT /cart/items/:id
 func (h *CartHandler) UpdateCartItem(c *gin.Context) {
 	userID := h.getUserIDAsPointer(c)
@@ -94,7 +124,7 @@ func (h *CartHandler) UpdateCartItem(c *gin.Context) {
 	productID, err := strconv.ParseUint(productIDParam, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid product ID",
+			"error": localize(c, i18n.MsgInvalidProductID),
 		})
 		return
 	}
@@ -111,7 +141,7 @@ func (h *CartHandler) UpdateCartItem(c *gin.Context) {
 	var req cart.UpdateCartItemRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
+			"error":   localize(c, i18n.MsgInvalidRequestData),
 			"details": err.Error(),
 		})
 		return
@@ -126,7 +156,7 @@ func (h *CartHandler) UpdateCartItem(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Cart item updated successfully",
+		"message": localize(c, i18n.MsgCartItemUpdated),
 		"data":    cartResponse,
 	})
 }
@@ -141,7 +171,7 @@ func (h *CartHandler) RemoveFromCart(c *gin.Context) {
 	productID, err := strconv.ParseUint(productIDParam, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid product ID",
+			"error": localize(c, i18n.MsgInvalidProductID),
 		})
 		return
 	}
@@ -164,7 +194,7 @@ func (h *CartHandler) RemoveFromCart(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Item removed from cart successfully",
+		"message": localize(c, i18n.MsgCartItemRemoved),
 		"data":    cartResponse,
 	})
 }
@@ -177,13 +207,13 @@ func (h *CartHandler) ClearCart(c *gin.Context) {
 	err := h.cartService.ClearCart(userID, sessionID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to clear cart",
+			"error": localize(c, i18n.MsgFailedToClearCart),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Cart cleared successfully",
+		"message": localize(c, i18n.MsgCartCleared),
 	})
 }
 
@@ -195,13 +225,13 @@ func (h *CartHandler) GetCartCount(c *gin.Context) {
 	count, err := h.cartService.GetCartItemCount(userID, sessionID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to get cart count",
+			"error": localize(c, i18n.MsgFailedToGetCartCount),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Cart count retrieved successfully",
+		"message": localize(c, i18n.MsgCartCountRetrieved),
 		"data": gin.H{
 			"count": count,
 		},
@@ -213,7 +243,7 @@ func (h *CartHandler) MergeGuestCart(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
+			"error": localize(c, i18n.MsgUserNotAuthenticated),
 		})
 		return
 	}
@@ -223,7 +253,7 @@ func (h *CartHandler) MergeGuestCart(c *gin.Context) {
 	err := h.cartService.MergeGuestCartToUser(userID, sessionID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to merge cart",
+			"error": localize(c, i18n.MsgFailedToMergeCart),
 		})
 		return
 	}
@@ -233,13 +263,13 @@ func (h *CartHandler) MergeGuestCart(c *gin.Context) {
 	cartResponse, err := h.cartService.GetCart(userIDPtr, sessionID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve merged cart",
+			"error": localize(c, i18n.MsgFailedToRetrieveMergedCart),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Guest cart merged successfully",
+		"message": localize(c, i18n.MsgGuestCartMerged),
 		"data":    cartResponse,
 	})
 }
@@ -249,10 +279,19 @@ func (h *CartHandler) ValidateCart(c *gin.Context) {
 	userID := h.getUserIDAsPointer(c)
 	sessionID := h.getOrCreateSessionID(c)
 
+	// Bring stored prices up to date; increases are reported so the customer can review them
+	priceChanges, err := h.cartService.RepriceCart(userID, sessionID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": localize(c, i18n.MsgCartNotFound),
+		})
+		return
+	}
+
 	cartResponse, err := h.cartService.GetCart(userID, sessionID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Cart not found",
+			"error": localize(c, i18n.MsgCartNotFound),
 		})
 		return
 	}
@@ -260,21 +299,29 @@ func (h *CartHandler) ValidateCart(c *gin.Context) {
 	// Validate each item in cart
 	validationErrors := []string{}
 
+	for _, change := range priceChanges {
+		if change.Increased {
+			validationErrors = append(validationErrors,
+				fmt.Sprintf("Price for product '%s' has increased. Current: $%.2f, Previously: $%.2f",
+					change.ProductName, float64(change.NewPrice)/100, float64(change.OldPrice)/100))
+		}
+	}
+
 	for _, item := range cartResponse.Items {
 		if item.Product == nil {
 			validationErrors = append(validationErrors, fmt.Sprintf("Product %d not found", item.ProductID))
 			continue
 		}
 
-		if !item.Product.IsActive {
+		if !item.Product.IsActive || !item.IsAvailable() {
 			validationErrors = append(validationErrors, fmt.Sprintf("Product '%s' is no longer available", item.Product.Name))
 			continue
 		}
 
 		// Check inventory
-		availableQuantity := item.Product.Quantity
+		availableQuantity := item.Product.AvailableQuantity()
 		if item.ProductVariant != nil {
-			availableQuantity = item.ProductVariant.Quantity
+			availableQuantity = item.ProductVariant.AvailableQuantity()
 		}
 
 		if item.Product.TrackQuantity && availableQuantity < item.Quantity {
@@ -282,36 +329,142 @@ func (h *CartHandler) ValidateCart(c *gin.Context) {
 				fmt.Sprintf("Product '%s' has insufficient stock. Available: %d, Requested: %d",
 					item.Product.Name, availableQuantity, item.Quantity))
 		}
-
-		// Check if price has changed
-		currentPrice := item.Product.Price
-		if item.ProductVariant != nil && item.ProductVariant.Price > 0 {
-			currentPrice = item.ProductVariant.Price
-		}
-
-		if item.Price != currentPrice {
-			validationErrors = append(validationErrors,
-				fmt.Sprintf("Price for product '%s' has changed. Current: $%.2f, Cart: $%.2f",
-					item.Product.Name, float64(currentPrice)/100, float64(item.Price)/100))
-		}
 	}
 
 	// Return validation results
 	if len(validationErrors) > 0 {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":             "Cart validation failed",
+			"error":             localize(c, i18n.MsgCartValidationFailed),
 			"validation_errors": validationErrors,
+			"price_changes":     priceChanges,
 			"data":              cartResponse,
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Cart validation successful",
+		"message":       localize(c, i18n.MsgCartValid),
+		"price_changes": priceChanges,
+		"data":          cartResponse,
+	})
+}
+
+// SaveForLater handles POST /cart/items/:id/save-for-later
+func (h *CartHandler) SaveForLater(c *gin.Context) {
+	userID := h.getUserIDAsPointer(c)
+	sessionID := h.getOrCreateSessionID(c)
+
+	productID, variantID, ok := h.parseItemParams(c)
+	if !ok {
+		return
+	}
+
+	cartResponse, err := h.cartService.SaveForLater(userID, sessionID, productID, variantID)
+	if err != nil {
+		h.respondSavedItemError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": localize(c, i18n.MsgCartItemSaved),
+		"data":    cartResponse,
+	})
+}
+
+// GetSavedItems handles GET /cart/saved
+func (h *CartHandler) GetSavedItems(c *gin.Context) {
+	userID := h.getUserIDAsPointer(c)
+	sessionID := h.getOrCreateSessionID(c)
+
+	saved, err := h.cartService.GetSavedItems(userID, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": localize(c, i18n.MsgFailedToGetSavedItems),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": localize(c, i18n.MsgSavedItemsRetrieved),
+		"data":    saved,
+	})
+}
+
+// MoveSavedToCart handles POST /cart/saved/:id/move-to-cart
+func (h *CartHandler) MoveSavedToCart(c *gin.Context) {
+	userID := h.getUserIDAsPointer(c)
+	sessionID := h.getOrCreateSessionID(c)
+
+	productID, variantID, ok := h.parseItemParams(c)
+	if !ok {
+		return
+	}
+
+	cartResponse, err := h.cartService.MoveSavedToCart(userID, sessionID, productID, variantID)
+	if err != nil {
+		h.respondSavedItemError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": localize(c, i18n.MsgSavedItemMovedToCart),
 		"data":    cartResponse,
 	})
 }
 
+// RemoveSavedItem handles DELETE /cart/saved/:id
+func (h *CartHandler) RemoveSavedItem(c *gin.Context) {
+	userID := h.getUserIDAsPointer(c)
+	sessionID := h.getOrCreateSessionID(c)
+
+	productID, variantID, ok := h.parseItemParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.cartService.RemoveSavedItem(userID, sessionID, productID, variantID); err != nil {
+		h.respondSavedItemError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": localize(c, i18n.MsgSavedItemRemoved),
+	})
+}
+
+// parseItemParams reads the product ID from the path and the optional variant_id
+// query parameter, writing a 400 response when the product ID is invalid
+func (h *CartHandler) parseItemParams(c *gin.Context) (uint, *uint, bool) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": localize(c, i18n.MsgInvalidProductID),
+		})
+		return 0, nil, false
+	}
+
+	var variantID *uint
+	if variantIDParam := c.Query("variant_id"); variantIDParam != "" {
+		if vID, err := strconv.ParseUint(variantIDParam, 10, 32); err == nil {
+			variantIDUint := uint(vID)
+			variantID = &variantIDUint
+		}
+	}
+
+	return uint(productID), variantID, true
+}
+
+// respondSavedItemError maps save-for-later errors to status codes
+func (h *CartHandler) respondSavedItemError(c *gin.Context, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, cart.ErrItemNotInCart) || errors.Is(err, cart.ErrSavedItemNotFound) {
+		status = http.StatusNotFound
+	}
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+	})
+}
+
 // getOrCreateSessionID gets session ID from cookie or creates a new one
 // BUT only for guest users (when no authentication)
 func (h *CartHandler) getOrCreateSessionID(c *gin.Context) string {
@@ -0,0 +1,71 @@
+// internal/interfaces/http/handlers/cart_test.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/cart"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"github.com/your-org/ecommerce-backend/internal/domain/setting"
+	"github.com/your-org/ecommerce-backend/internal/domain/user"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+func TestValidateCartReportsPriceChanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := testutil.NewDB(t, &user.User{}, &user.Address{}, &product.Category{}, &product.Brand{},
+		&product.Product{}, &product.ProductVariant{}, &cart.CartItem{}, &setting.Setting{})
+	redisClient, _ := testutil.NewRedis(t)
+	h := NewCartHandler(db, redisClient, &config.Config{})
+
+	category := product.Category{Name: "General", Slug: "general"}
+	db.Create(&category)
+	prod := product.Product{SKU: "SKU-1", Name: "Lamp", Slug: "lamp", Price: 10000, CategoryID: category.ID,
+		IsActive: true, TrackQuantity: true, Quantity: 10}
+	db.Create(&prod)
+	userID := uint(7)
+	db.Create(&cart.CartItem{UserID: &userID, ProductID: prod.ID, Quantity: 1, Price: 10000})
+
+	// The price rises after the product was added to the cart
+	db.Model(&prod).Update("price", 12500)
+
+	router := gin.New()
+	router.POST("/cart/validate", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		h.ValidateCart(c)
+	})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/cart/validate", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d for a price increase", w.Code, http.StatusBadRequest)
+	}
+	var body struct {
+		PriceChanges []cart.PriceChange `json:"price_changes"`
+		Data         cart.CartResponse  `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if len(body.PriceChanges) != 1 {
+		t.Fatalf("price_changes = %+v, want one change", body.PriceChanges)
+	}
+	change := body.PriceChanges[0]
+	if change.ProductID != prod.ID || change.OldPrice != 10000 || change.NewPrice != 12500 || !change.Increased {
+		t.Errorf("price change = %+v, want product %d from 10000 to 12500", change, prod.ID)
+	}
+	if body.Data.Totals.SubTotal != 12500 {
+		t.Errorf("cart subtotal = %d, want 12500", body.Data.Totals.SubTotal)
+	}
+
+	var stored cart.CartItem
+	db.Where("user_id = ?", userID).First(&stored)
+	if stored.Price != 12500 {
+		t.Errorf("stored cart price = %d, want 12500", stored.Price)
+	}
+}
@@ -312,6 +312,40 @@ func (h *CategoryHandler) AdminUpdateCategory(c *gin.Context) {
 	})
 }
 
+// AdminBulkAssignProducts handles POST /admin/categories/:id/products
+func (h *CategoryHandler) AdminBulkAssignProducts(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid category ID",
+		})
+		return
+	}
+
+	var req product.BulkCategoryAssignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.categoryService.BulkAssignProducts(uint(id), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Products assigned to category successfully",
+		"data":    result,
+	})
+}
+
 // AdminDeleteCategory handles DELETE /admin/categories/:id
 func (h *CategoryHandler) AdminDeleteCategory(c *gin.Context) {
 	idParam := c.Param("id")
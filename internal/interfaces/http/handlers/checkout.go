@@ -2,8 +2,11 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
@@ -67,6 +70,31 @@ func (h *CheckoutHandler) GetShippingMethods(c *gin.Context) {
 	})
 }
 
+// EstimateShipping handles POST /shipping/estimate
+func (h *CheckoutHandler) EstimateShipping(c *gin.Context) {
+	var req checkout.ShippingEstimateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	estimate, err := h.checkoutService.EstimateShipping(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shipping estimate retrieved successfully",
+		"data":    estimate,
+	})
+}
+
 // CalculateShipping handles POST /checkout/calculate-shipping
 func (h *CheckoutHandler) CalculateShipping(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
@@ -159,6 +187,40 @@ func (h *CheckoutHandler) RemoveCoupon(c *gin.Context) {
 	})
 }
 
+// CalculateAddOns handles POST /checkout/calculate-add-ons
+func (h *CheckoutHandler) CalculateAddOns(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	// The body is optional; without it the available add-ons are listed unselected
+	var req checkout.AddOnCalculationRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.checkoutService.CalculateAddOns(userID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Add-ons calculated successfully",
+		"data":    result,
+	})
+}
+
 // GetTaxCalculation handles POST /checkout/calculate-tax
 func (h *CheckoutHandler) GetTaxCalculation(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
@@ -206,6 +268,10 @@ func (h *CheckoutHandler) GetCheckoutSummary(c *gin.Context) {
 	shippingMethodParam := c.Query("shipping_method")
 	addressIDParam := c.Query("address_id")
 	couponCode := c.Query("coupon_code")
+	var addOns []string
+	if addOnsParam := c.Query("add_ons"); addOnsParam != "" {
+		addOns = strings.Split(addOnsParam, ",")
+	}
 
 	var addressID *uint
 	if addressIDParam != "" {
@@ -215,7 +281,7 @@ func (h *CheckoutHandler) GetCheckoutSummary(c *gin.Context) {
 		}
 	}
 
-	summary, err := h.checkoutService.GetCheckoutSummary(userID, addressID, shippingMethodParam, couponCode)
+	summary, err := h.checkoutService.GetCheckoutSummary(userID, addressID, shippingMethodParam, couponCode, addOns)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -0,0 +1,174 @@
+// internal/interfaces/http/handlers/compare.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/compare"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"gorm.io/gorm"
+)
+
+// CompareHandler handles product comparison endpoints
+type CompareHandler struct {
+	compareService *compare.Service
+	config         *config.Config
+}
+
+// NewCompareHandler creates a new compare handler
+func NewCompareHandler(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *CompareHandler {
+	return &CompareHandler{
+		compareService: compare.NewService(db, redisClient, cfg),
+		config:         cfg,
+	}
+}
+
+// GetCompareList handles GET /compare
+func (h *CompareHandler) GetCompareList(c *gin.Context) {
+	userID, sessionID := h.getOwner(c)
+
+	list, err := h.compareService.GetCompareList(userID, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve comparison list",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comparison list retrieved successfully",
+		"data":    list,
+	})
+}
+
+// AddToCompare handles POST /compare/add/:id
+func (h *CompareHandler) AddToCompare(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	userID, sessionID := h.getOwner(c)
+
+	productIDs, err := h.compareService.AddProduct(userID, sessionID, uint(productID))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, compare.ErrCompareListFull) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Product added to comparison successfully",
+		"data": gin.H{
+			"product_ids": productIDs,
+			"count":       len(productIDs),
+		},
+	})
+}
+
+// RemoveFromCompare handles DELETE /compare/remove/:id
+func (h *CompareHandler) RemoveFromCompare(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	userID, sessionID := h.getOwner(c)
+
+	productIDs, err := h.compareService.RemoveProduct(userID, sessionID, uint(productID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to remove product from comparison",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Product removed from comparison successfully",
+		"data": gin.H{
+			"product_ids": productIDs,
+			"count":       len(productIDs),
+		},
+	})
+}
+
+// ClearCompare handles DELETE /compare
+func (h *CompareHandler) ClearCompare(c *gin.Context) {
+	userID, sessionID := h.getOwner(c)
+
+	if err := h.compareService.ClearList(userID, sessionID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to clear comparison list",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comparison list cleared successfully",
+	})
+}
+
+// MergeGuestCompare handles POST /compare/merge - called when user logs in
+func (h *CompareHandler) MergeGuestCompare(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	sessionID, _ := c.Cookie("session_id")
+
+	if _, err := h.compareService.MergeSessionListToUser(userID, sessionID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to merge comparison list",
+		})
+		return
+	}
+
+	list, err := h.compareService.GetCompareList(&userID, "")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve merged comparison list",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comparison list merged successfully",
+		"data":    list,
+	})
+}
+
+// getOwner returns the authenticated user, or the guest session ID (creating one if needed)
+func (h *CompareHandler) getOwner(c *gin.Context) (*uint, string) {
+	if userID, exists := middleware.GetUserIDFromContext(c); exists {
+		return &userID, ""
+	}
+
+	sessionID, err := c.Cookie("session_id")
+	if err != nil || sessionID == "" {
+		sessionID = uuid.New().String()
+		c.SetCookie("session_id", sessionID, 86400, "/", "", false, true)
+	}
+
+	return nil, sessionID
+}
@@ -0,0 +1,172 @@
+// internal/interfaces/http/handlers/coupon.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/coupon"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"gorm.io/gorm"
+)
+
+// CouponHandler handles coupon management endpoints
+type CouponHandler struct {
+	couponService *coupon.Service
+	config        *config.Config
+}
+
+// NewCouponHandler creates a new coupon handler
+func NewCouponHandler(db *gorm.DB, cfg *config.Config) *CouponHandler {
+	return &CouponHandler{
+		couponService: coupon.NewService(db, cfg),
+		config:        cfg,
+	}
+}
+
+// AdminGetCoupons handles GET /admin/coupons
+func (h *CouponHandler) AdminGetCoupons(c *gin.Context) {
+	var req coupon.ListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.couponService.ListCoupons(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve coupons",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Coupons retrieved successfully",
+		"data":    result,
+	})
+}
+
+// AdminGetCoupon handles GET /admin/coupons/:id
+func (h *CouponHandler) AdminGetCoupon(c *gin.Context) {
+	couponID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid coupon ID",
+		})
+		return
+	}
+
+	result, err := h.couponService.GetCoupon(uint(couponID))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve coupon")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Coupon retrieved successfully",
+		"data":    result,
+	})
+}
+
+// AdminCreateCoupon handles POST /admin/coupons
+func (h *CouponHandler) AdminCreateCoupon(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req coupon.CreateCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.couponService.CreateCoupon(&req, userID)
+	if err != nil {
+		h.respondError(c, err, "")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Coupon created successfully",
+		"data":    result,
+	})
+}
+
+// AdminUpdateCoupon handles PUT /admin/coupons/:id
+func (h *CouponHandler) AdminUpdateCoupon(c *gin.Context) {
+	couponID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid coupon ID",
+		})
+		return
+	}
+
+	var req coupon.UpdateCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.couponService.UpdateCoupon(uint(couponID), &req)
+	if err != nil {
+		h.respondError(c, err, "")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Coupon updated successfully",
+		"data":    result,
+	})
+}
+
+// AdminDeleteCoupon handles DELETE /admin/coupons/:id
+func (h *CouponHandler) AdminDeleteCoupon(c *gin.Context) {
+	couponID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid coupon ID",
+		})
+		return
+	}
+
+	if err := h.couponService.DeleteCoupon(uint(couponID)); err != nil {
+		h.respondError(c, err, "Failed to delete coupon")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Coupon deleted successfully",
+	})
+}
+
+// respondError maps coupon service errors to HTTP responses. When fallback is empty,
+// unrecognised errors are treated as validation failures and returned as-is.
+func (h *CouponHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, coupon.ErrCouponNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, coupon.ErrCouponCodeExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case fallback != "":
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
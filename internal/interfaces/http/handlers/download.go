@@ -0,0 +1,113 @@
+// internal/interfaces/http/handlers/download.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/download"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"gorm.io/gorm"
+)
+
+// DownloadHandler handles digital product download endpoints
+type DownloadHandler struct {
+	downloadService *download.Service
+	config          *config.Config
+}
+
+// NewDownloadHandler creates a new download handler
+func NewDownloadHandler(db *gorm.DB, cfg *config.Config) *DownloadHandler {
+	return &DownloadHandler{
+		downloadService: download.NewService(db, cfg),
+		config:          cfg,
+	}
+}
+
+// GetOrderDownloads handles GET /orders/:id/downloads
+func (h *DownloadHandler) GetOrderDownloads(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid order ID",
+		})
+		return
+	}
+
+	links, err := h.downloadService.GetOrderDownloads(userID, uint(orderID))
+	if err != nil {
+		switch {
+		case errors.Is(err, download.ErrOrderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, download.ErrOrderNotPaid):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to get downloads",
+				"details": err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Downloads retrieved successfully",
+		"data":    links,
+	})
+}
+
+// Download handles GET /downloads/:token
+func (h *DownloadHandler) Download(c *gin.Context) {
+	file, err := h.downloadService.ResolveDownload(c.Param("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, download.ErrDownloadNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, download.ErrDownloadExpired), errors.Is(err, download.ErrDownloadLimitReached):
+			c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to get download",
+				"details": err.Error(),
+			})
+		}
+		return
+	}
+
+	// Remote files (CDN, signed storage URLs) are served by redirect
+	if strings.HasPrefix(file.Source, "http://") || strings.HasPrefix(file.Source, "https://") {
+		c.Redirect(http.StatusFound, file.Source)
+		return
+	}
+
+	// Local files must stay inside the storage directory
+	root, err := filepath.Abs(h.config.External.Storage.LocalPath)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get download",
+		})
+		return
+	}
+	path := filepath.Join(root, filepath.Clean("/"+file.Source))
+	if !strings.HasPrefix(path, root+string(filepath.Separator)) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": download.ErrDownloadNotFound.Error(),
+		})
+		return
+	}
+
+	c.FileAttachment(path, file.FileName)
+}
@@ -0,0 +1,176 @@
+// internal/interfaces/http/handlers/email_template.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/pkg/email"
+	"gorm.io/gorm"
+)
+
+// EmailTemplateHandler handles admin endpoints for stored email templates
+type EmailTemplateHandler struct {
+	emailService *email.EmailService
+}
+
+// NewEmailTemplateHandler creates a new email template handler
+func NewEmailTemplateHandler(db *gorm.DB, cfg *config.Config) *EmailTemplateHandler {
+	return &EmailTemplateHandler{
+		emailService: email.NewEmailService(db, cfg),
+	}
+}
+
+// AdminGetTemplates handles GET /admin/email-templates
+func (h *EmailTemplateHandler) AdminGetTemplates(c *gin.Context) {
+	templates, err := h.emailService.ListTemplates()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve email templates",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email templates retrieved successfully",
+		"data":    templates,
+	})
+}
+
+// AdminGetTemplate handles GET /admin/email-templates/:id
+func (h *EmailTemplateHandler) AdminGetTemplate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid template ID",
+		})
+		return
+	}
+
+	tmpl, err := h.emailService.GetTemplate(uint(id))
+	if err != nil {
+		h.respondTemplateError(c, err, "Failed to retrieve email template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email template retrieved successfully",
+		"data":    tmpl,
+	})
+}
+
+// AdminCreateTemplate handles POST /admin/email-templates
+func (h *EmailTemplateHandler) AdminCreateTemplate(c *gin.Context) {
+	var req email.EmailTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tmpl, err := h.emailService.CreateTemplate(&req)
+	if err != nil {
+		h.respondTemplateError(c, err, "Failed to create email template")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Email template created successfully",
+		"data":    tmpl,
+	})
+}
+
+// AdminUpdateTemplate handles PUT /admin/email-templates/:id
+func (h *EmailTemplateHandler) AdminUpdateTemplate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid template ID",
+		})
+		return
+	}
+
+	var req email.EmailTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tmpl, err := h.emailService.UpdateTemplate(uint(id), &req)
+	if err != nil {
+		h.respondTemplateError(c, err, "Failed to update email template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email template updated successfully",
+		"data":    tmpl,
+	})
+}
+
+// AdminDeleteTemplate handles DELETE /admin/email-templates/:id
+func (h *EmailTemplateHandler) AdminDeleteTemplate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid template ID",
+		})
+		return
+	}
+
+	if err := h.emailService.DeleteTemplate(uint(id)); err != nil {
+		h.respondTemplateError(c, err, "Failed to delete email template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email template deleted successfully",
+	})
+}
+
+// AdminPreviewTemplate handles POST /admin/email-templates/preview
+func (h *EmailTemplateHandler) AdminPreviewTemplate(c *gin.Context) {
+	var req email.EmailTemplatePreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	preview, err := h.emailService.PreviewTemplate(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email template rendered successfully",
+		"data":    preview,
+	})
+}
+
+// respondTemplateError maps email template errors to HTTP responses
+func (h *EmailTemplateHandler) respondTemplateError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, email.ErrTemplateNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, email.ErrTemplateExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, email.ErrInvalidTemplate):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,13 @@
+// internal/interfaces/http/handlers/i18n.go
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"github.com/your-org/ecommerce-backend/internal/pkg/i18n"
+)
+
+// localize returns the message for id in the request's locale
+func localize(c *gin.Context, id string, args ...interface{}) string {
+	return i18n.Translate(middleware.GetLocaleFromContext(c), id, args...)
+}
@@ -2,6 +2,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -122,6 +123,50 @@ func (h *InventoryHandler) GetInventoryItem(c *gin.Context) {
 	})
 }
 
+// SetSafetyStock handles PUT /admin/inventory/:productId/:warehouseId/safety-stock
+func (h *InventoryHandler) SetSafetyStock(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("productId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	warehouseID, err := strconv.ParseUint(c.Param("warehouseId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid warehouse ID",
+		})
+		return
+	}
+
+	var req struct {
+		SafetyStock *int `json:"safety_stock" binding:"required,min=0"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	item, err := h.inventoryService.SetSafetyStock(uint(productID), uint(warehouseID), *req.SafetyStock)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Safety stock updated successfully",
+		"data":    item,
+	})
+}
+
 // CreateOrUpdateInventoryItem handles POST /admin/inventory
 func (h *InventoryHandler) CreateOrUpdateInventoryItem(c *gin.Context) {
 	var req struct {
@@ -153,8 +198,85 @@ func (h *InventoryHandler) CreateOrUpdateInventoryItem(c *gin.Context) {
 	})
 }
 
+// BulkUpdateReorderLevels handles PUT /admin/inventory/reorder-levels
+func (h *InventoryHandler) BulkUpdateReorderLevels(c *gin.Context) {
+	var req inventory.BulkReorderLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.inventoryService.BulkUpdateReorderLevels(&req)
+	if err != nil {
+		if errors.Is(err, inventory.ErrReorderValidationFailed) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error": err.Error(),
+				"data":  result,
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Reorder levels updated successfully",
+		"data":    result,
+	})
+}
+
 // STOCK MOVEMENT ENDPOINTS
 
+// GetMovementHistory handles GET /admin/inventory/:productId/:warehouseId/movements
+func (h *InventoryHandler) GetMovementHistory(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("productId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	warehouseID, err := strconv.ParseUint(c.Param("warehouseId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid warehouse ID",
+		})
+		return
+	}
+
+	var req inventory.MovementHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	history, err := h.inventoryService.GetMovementHistory(uint(productID), uint(warehouseID), &req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, inventory.ErrInventoryItemNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Movement history retrieved successfully",
+		"data":    history,
+	})
+}
+
 // RecordStockMovement handles POST /admin/inventory/movements
 func (h *InventoryHandler) RecordStockMovement(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
@@ -3,8 +3,10 @@ package handlers
 
 import (
 	"fmt"
+	"html"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -24,7 +26,7 @@ type InvoiceHandler struct {
 // NewInvoiceHandler creates a new invoice handler
 func NewInvoiceHandler(db *gorm.DB, cfg *config.Config) *InvoiceHandler {
 	return &InvoiceHandler{
-		orderService: order.NewService(db, cfg, nil),
+		orderService: order.NewService(db, cfg, nil, nil),
 		config:       cfg,
 		db:           db,
 	}
@@ -181,6 +183,13 @@ func (h *InvoiceHandler) generateSimpleInvoice(orderRecord *order.Order) string
             border-top: 1px solid #eee; 
             color: #666; 
         }
+        .gift-message { 
+            clear: both; 
+            border: 2px dashed #007bff; 
+            padding: 15px; 
+            margin-top: 30px; 
+            font-style: italic; 
+        }
         .print-btn { 
             background: #007bff; 
             color: white; 
@@ -310,18 +319,25 @@ func (h *InvoiceHandler) generateSimpleInvoice(orderRecord *order.Order) string
             <tr>
                 <td>Shipping:</td>
                 <td class="text-right">$` + fmt.Sprintf("%.2f", shipping) + `</td>
-            </tr>
+            </tr>`
+
+	// Add gift wrapping if chosen
+	if orderRecord.GiftWrapFee > 0 {
+		html += `
             <tr>
-                <td>Tax:</td>
-                <td class="text-right">$` + fmt.Sprintf("%.2f", tax) + `</td>
-            </tr>
+                <td>Gift Wrapping:</td>
+                <td class="text-right">$` + fmt.Sprintf("%.2f", float64(orderRecord.GiftWrapFee)/100) + `</td>
+            </tr>`
+	}
+
+	html += taxRowsHTML(orderRecord, tax) + `
             <tr class="total-row">
                 <td><strong>Total:</strong></td>
                 <td class="text-right"><strong>$` + fmt.Sprintf("%.2f", total) + `</strong></td>
             </tr>
         </table>
     </div>
-
+` + giftMessageHTML(orderRecord) + `
     <div class="footer">
         <p>Thank you for your business!</p>
         <p>Questions? Contact us at info@yourcompany.com</p>
@@ -333,6 +349,211 @@ func (h *InvoiceHandler) generateSimpleInvoice(orderRecord *order.Order) string
 	return html
 }
 
+// GeneratePackingSlip handles GET /orders/:id/packing-slip. With ?copy=gift the
+// prices are left off so the slip can travel with a gift.
+func (h *InvoiceHandler) GeneratePackingSlip(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	orderRecord, ok := h.loadOrder(c)
+	if !ok {
+		return
+	}
+
+	// Ensure user can only access their own orders
+	if orderRecord.UserID == nil || *orderRecord.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Access denied",
+		})
+		return
+	}
+
+	h.sendPackingSlip(c, orderRecord)
+}
+
+// AdminGeneratePackingSlip handles GET /admin/orders/:id/packing-slip
+func (h *InvoiceHandler) AdminGeneratePackingSlip(c *gin.Context) {
+	orderRecord, ok := h.loadOrder(c)
+	if !ok {
+		return
+	}
+
+	h.sendPackingSlip(c, orderRecord)
+}
+
+// loadOrder loads the order named by the :id parameter with its items, writing
+// the error response when it cannot
+func (h *InvoiceHandler) loadOrder(c *gin.Context) (*order.Order, bool) {
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid order ID",
+		})
+		return nil, false
+	}
+
+	var orderRecord order.Order
+	if err := h.db.Preload("Items").Where("id = ?", orderID).First(&orderRecord).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Order not found",
+		})
+		return nil, false
+	}
+	return &orderRecord, true
+}
+
+func (h *InvoiceHandler) sendPackingSlip(c *gin.Context, orderRecord *order.Order) {
+	giftCopy := c.Query("copy") == "gift"
+
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Header("Cache-Control", "no-cache")
+	c.String(http.StatusOK, h.generatePackingSlip(orderRecord, giftCopy))
+}
+
+// generatePackingSlip lists what is in the parcel and where it goes. The gift copy
+// drops every price.
+func (h *InvoiceHandler) generatePackingSlip(orderRecord *order.Order, giftCopy bool) string {
+	addr := orderRecord.ShippingAddress
+	title := "PACKING SLIP"
+	if giftCopy {
+		title = "GIFT RECEIPT"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>%s - %s</title>
+    <style>
+        body { font-family: Arial, sans-serif; color: #333; max-width: 800px; margin: 0 auto; padding: 20px; }
+        .header { text-align: center; border-bottom: 3px solid #007bff; padding-bottom: 20px; margin-bottom: 30px; }
+        .company-name { font-size: 28px; font-weight: bold; color: #007bff; }
+        .section-title { font-weight: bold; font-size: 18px; margin-bottom: 10px; color: #007bff; }
+        .items-table { width: 100%%; border-collapse: collapse; margin: 30px 0; }
+        .items-table th, .items-table td { border: 1px solid #ddd; padding: 10px; text-align: left; }
+        .items-table th { background-color: #f8f9fa; }
+        .text-right { text-align: right; }
+        .gift-message { border: 2px dashed #007bff; padding: 15px; margin: 20px 0; font-style: italic; }
+    </style>
+</head>
+<body>
+    <div class="header">
+        <div class="company-name">%s</div>
+        <div>%s</div>
+    </div>
+
+    <p><strong>Order #:</strong> %s</p>
+
+    <div class="section-title">Ship To</div>
+    <p><strong>%s %s</strong></p>
+    <p>%s</p>`,
+		title,
+		html.EscapeString(orderRecord.OrderNumber),
+		html.EscapeString(h.getCompanyName()),
+		title,
+		html.EscapeString(orderRecord.OrderNumber),
+		html.EscapeString(addr.FirstName),
+		html.EscapeString(addr.LastName),
+		html.EscapeString(addr.AddressLine1),
+	)
+	if addr.AddressLine2 != "" {
+		fmt.Fprintf(&b, "\n    <p>%s</p>", html.EscapeString(addr.AddressLine2))
+	}
+	fmt.Fprintf(&b, `
+    <p>%s, %s %s</p>
+    <p>%s</p>
+%s
+    <table class="items-table">
+        <thead>
+            <tr>
+                <th>Item</th>
+                <th>SKU</th>
+                <th class="text-right">Qty</th>`,
+		html.EscapeString(addr.City),
+		html.EscapeString(addr.State),
+		html.EscapeString(addr.PostalCode),
+		html.EscapeString(addr.Country),
+		giftMessageHTML(orderRecord),
+	)
+	if !giftCopy {
+		b.WriteString(`
+                <th class="text-right">Price</th>
+                <th class="text-right">Total</th>`)
+	}
+	b.WriteString(`
+            </tr>
+        </thead>
+        <tbody>`)
+
+	for _, item := range orderRecord.Items {
+		name := "<strong>" + html.EscapeString(item.Name) + "</strong>"
+		if item.VariantTitle != "" {
+			name += "<br><small>" + html.EscapeString(item.VariantTitle) + "</small>"
+		}
+		fmt.Fprintf(&b, `
+            <tr>
+                <td>%s</td>
+                <td>%s</td>
+                <td class="text-right">%d</td>`, name, html.EscapeString(item.SKU), item.Quantity)
+		if !giftCopy {
+			fmt.Fprintf(&b, `
+                <td class="text-right">$%.2f</td>
+                <td class="text-right">$%.2f</td>`, float64(item.Price)/100, float64(item.TotalPrice)/100)
+		}
+		b.WriteString(`
+            </tr>`)
+	}
+
+	b.WriteString(`
+        </tbody>
+    </table>
+</body>
+</html>`)
+
+	return b.String()
+}
+
+// taxRowsHTML renders the tax as its GST components when the order recorded them,
+// or as a single line otherwise
+func taxRowsHTML(orderRecord *order.Order, tax float64) string {
+	row := func(label string, amount float64) string {
+		return `
+            <tr>
+                <td>` + label + `:</td>
+                <td class="text-right">$` + fmt.Sprintf("%.2f", amount) + `</td>
+            </tr>`
+	}
+
+	switch {
+	case orderRecord.IGSTAmount > 0:
+		return row("IGST", float64(orderRecord.IGSTAmount)/100)
+	case orderRecord.CGSTAmount > 0 || orderRecord.SGSTAmount > 0:
+		return row("CGST", float64(orderRecord.CGSTAmount)/100) + row("SGST", float64(orderRecord.SGSTAmount)/100)
+	default:
+		return row("Tax", tax)
+	}
+}
+
+// giftMessageHTML renders the order's gift message, or nothing when there is none
+func giftMessageHTML(orderRecord *order.Order) string {
+	if orderRecord.GiftMessage == "" {
+		return ""
+	}
+	return `
+    <div class="gift-message">
+        <div class="section-title">Gift Message</div>
+        <p>` + html.EscapeString(orderRecord.GiftMessage) + `</p>
+    </div>
+`
+}
+
 func (h *InvoiceHandler) getCompanyName() string {
 	if h.config != nil && h.config.App.Name != "" {
 		return h.config.App.Name
@@ -2,33 +2,41 @@
 package handlers
 
 import (
+	"errors"
+	"io"
+	"log"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"github.com/your-org/ecommerce-backend/internal/domain/cart"
 	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/setting"
 	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"github.com/your-org/ecommerce-backend/internal/pkg/i18n"
 	"gorm.io/gorm"
 )
 
 // OrderHandler handles order endpoints
 type OrderHandler struct {
-	orderService *order.Service
-	config       *config.Config
+	orderService    *order.Service
+	exportService   *order.ExportService
+	trackingService *order.TrackingService
+	config          *config.Config
 }
 
 // NewOrderHandler creates a new order handler
 func NewOrderHandler(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *OrderHandler {
 	cartService := cart.NewService(db, redisClient, cfg)
-	orderService := order.NewService(db, cfg, cartService)
+	orderService := order.NewService(db, cfg, cartService, setting.NewService(db, redisClient, cfg))
 
 	return &OrderHandler{
-		orderService: orderService,
-		config:       cfg,
+		orderService:    orderService,
+		exportService:   order.NewExportService(db, cfg),
+		trackingService: order.NewTrackingService(order.NewCarrierTracker(cfg), redisClient, cfg),
+		config:          cfg,
 	}
 }
 
@@ -37,7 +45,7 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
+			"error": localize(c, i18n.MsgUserNotAuthenticated),
 		})
 		return
 	}
@@ -45,7 +53,7 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 	var req order.CreateOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
+			"error":   localize(c, i18n.MsgInvalidRequestData),
 			"details": err.Error(),
 		})
 		return
@@ -56,6 +64,18 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 
 	createdOrder, err := h.orderService.CreateOrder(userID, sessionID, &req)
 	if err != nil {
+		if errors.Is(err, order.ErrOrderLimitExceeded) {
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, order.ErrCouponExhausted) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -63,7 +83,7 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "Order created successfully",
+		"message": localize(c, i18n.MsgOrderCreated),
 		"data":    createdOrder,
 	})
 }
@@ -73,7 +93,7 @@ func (h *OrderHandler) GetOrders(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
+			"error": localize(c, i18n.MsgUserNotAuthenticated),
 		})
 		return
 	}
@@ -96,23 +116,47 @@ func (h *OrderHandler) GetOrders(c *gin.Context) {
 	response, err := h.orderService.GetUserOrders(userID, page, limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve orders",
+			"error": localize(c, i18n.MsgFailedToRetrieveOrders),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Orders retrieved successfully",
+		"message": localize(c, i18n.MsgOrdersRetrieved),
 		"data":    response,
 	})
 }
 
+// GetOrderStatusCounts handles GET /orders/status-counts
+func (h *OrderHandler) GetOrderStatusCounts(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": localize(c, i18n.MsgUserNotAuthenticated),
+		})
+		return
+	}
+
+	counts, err := h.orderService.GetUserOrderStatusCounts(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": localize(c, i18n.MsgFailedToRetrieveOrderCounts),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": localize(c, i18n.MsgOrderCountsRetrieved),
+		"data":    counts,
+	})
+}
+
 // GetOrder handles GET /orders/:id
 func (h *OrderHandler) GetOrder(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
+			"error": localize(c, i18n.MsgUserNotAuthenticated),
 		})
 		return
 	}
@@ -121,7 +165,7 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 	orderID, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid order ID",
+			"error": localize(c, i18n.MsgInvalidOrderID),
 		})
 		return
 	}
@@ -137,13 +181,13 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 	// Ensure user can only access their own orders
 	if order.UserID == nil || *order.UserID != userID {
 		c.JSON(http.StatusForbidden, gin.H{
-			"error": "Access denied",
+			"error": localize(c, i18n.MsgAccessDenied),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Order retrieved successfully",
+		"message": localize(c, i18n.MsgOrderRetrieved),
 		"data":    order,
 	})
 }
@@ -153,7 +197,7 @@ func (h *OrderHandler) GetOrderByNumber(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
+			"error": localize(c, i18n.MsgUserNotAuthenticated),
 		})
 		return
 	}
@@ -161,7 +205,7 @@ func (h *OrderHandler) GetOrderByNumber(c *gin.Context) {
 	orderNumber := c.Param("orderNumber")
 	if orderNumber == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Order number is required",
+			"error": localize(c, i18n.MsgOrderNumberRequired),
 		})
 		return
 	}
@@ -177,13 +221,13 @@ func (h *OrderHandler) GetOrderByNumber(c *gin.Context) {
 	// Ensure user can only access their own orders
 	if order.UserID == nil || *order.UserID != userID {
 		c.JSON(http.StatusForbidden, gin.H{
-			"error": "Access denied",
+			"error": localize(c, i18n.MsgAccessDenied),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Order retrieved successfully",
+		"message": localize(c, i18n.MsgOrderRetrieved),
 		"data":    order,
 	})
 }
@@ -193,7 +237,7 @@ func (h *OrderHandler) CancelOrder(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
+			"error": localize(c, i18n.MsgUserNotAuthenticated),
 		})
 		return
 	}
@@ -202,7 +246,7 @@ func (h *OrderHandler) CancelOrder(c *gin.Context) {
 	orderID, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid order ID",
+			"error": localize(c, i18n.MsgInvalidOrderID),
 		})
 		return
 	}
@@ -212,7 +256,7 @@ func (h *OrderHandler) CancelOrder(c *gin.Context) {
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
+			"error":   localize(c, i18n.MsgInvalidRequestData),
 			"details": err.Error(),
 		})
 		return
@@ -222,14 +266,14 @@ func (h *OrderHandler) CancelOrder(c *gin.Context) {
 	order, err := h.orderService.GetOrder(uint(orderID))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Order not found",
+			"error": localize(c, i18n.MsgOrderNotFound),
 		})
 		return
 	}
 
 	if order.UserID == nil || *order.UserID != userID {
 		c.JSON(http.StatusForbidden, gin.H{
-			"error": "Access denied",
+			"error": localize(c, i18n.MsgAccessDenied),
 		})
 		return
 	}
@@ -243,7 +287,65 @@ func (h *OrderHandler) CancelOrder(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Order cancelled successfully",
+		"message": localize(c, i18n.MsgOrderCancelled),
+	})
+}
+
+// UpdateShippingAddress handles PUT /orders/:id/shipping-address
+func (h *OrderHandler) UpdateShippingAddress(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": localize(c, i18n.MsgUserNotAuthenticated),
+		})
+		return
+	}
+
+	idParam := c.Param("id")
+	orderID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": localize(c, i18n.MsgInvalidOrderID),
+		})
+		return
+	}
+
+	var req order.Address
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   localize(c, i18n.MsgInvalidRequestData),
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Verify order belongs to user
+	existing, err := h.orderService.GetOrder(uint(orderID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": localize(c, i18n.MsgOrderNotFound),
+		})
+		return
+	}
+
+	if existing.UserID == nil || *existing.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": localize(c, i18n.MsgAccessDenied),
+		})
+		return
+	}
+
+	updated, err := h.orderService.UpdateShippingAddress(uint(orderID), req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": localize(c, i18n.MsgOrderShippingAddressUpdated),
+		"data":    updated,
 	})
 }
 
@@ -252,7 +354,7 @@ func (h *OrderHandler) TrackOrder(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
+			"error": localize(c, i18n.MsgUserNotAuthenticated),
 		})
 		return
 	}
@@ -261,7 +363,7 @@ func (h *OrderHandler) TrackOrder(c *gin.Context) {
 	orderID, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid order ID",
+			"error": localize(c, i18n.MsgInvalidOrderID),
 		})
 		return
 	}
@@ -277,7 +379,7 @@ func (h *OrderHandler) TrackOrder(c *gin.Context) {
 	// Ensure user can only track their own orders
 	if order.UserID == nil || *order.UserID != userID {
 		c.JSON(http.StatusForbidden, gin.H{
-			"error": "Access denied",
+			"error": localize(c, i18n.MsgAccessDenied),
 		})
 		return
 	}
@@ -295,8 +397,93 @@ func (h *OrderHandler) TrackOrder(c *gin.Context) {
 		"estimated_delivery": h.calculateEstimatedDelivery(order),
 	}
 
+	// Merge live carrier checkpoints when a tracking provider is configured
+	live, err := h.trackingService.GetLiveTracking(c.Request.Context(), order.ShippingCarrier, order.TrackingNumber)
+	if err != nil {
+		log.Printf("Failed to get carrier tracking for order %s: %v", order.OrderNumber, err)
+	} else if live != nil {
+		trackingInfo["carrier_status"] = live.Status
+		trackingInfo["checkpoints"] = live.Checkpoints
+		trackingInfo["tracking_checked_at"] = live.CheckedAt
+		if live.EstimatedDelivery != "" {
+			trackingInfo["estimated_delivery"] = live.EstimatedDelivery
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": localize(c, i18n.MsgOrderTrackingRetrieved),
+		"data":    trackingInfo,
+	})
+}
+
+// LookupOrderRequest identifies an order for a guest lookup
+type LookupOrderRequest struct {
+	OrderNumber string `json:"order_number" binding:"required"`
+	Email       string `json:"email" binding:"required,email"`
+}
+
+// LookupOrder handles POST /orders/track. Anyone with the order number and email can
+// track the order, so the response leaves out addresses, items and payment details.
+func (h *OrderHandler) LookupOrder(c *gin.Context) {
+	var req LookupOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   localize(c, i18n.MsgInvalidRequestData),
+			"details": err.Error(),
+		})
+		return
+	}
+
+	orderRecord, err := h.orderService.LookupOrder(req.OrderNumber, req.Email)
+	if err != nil {
+		if errors.Is(err, order.ErrOrderLookupFailed) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": localize(c, i18n.MsgOrderNotFound),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to look up order",
+		})
+		return
+	}
+
+	// Only the status and when it changed; comments and who made the change stay internal
+	history := make([]gin.H, 0, len(orderRecord.StatusHistory))
+	for _, entry := range orderRecord.StatusHistory {
+		history = append(history, gin.H{
+			"status":     entry.Status,
+			"created_at": entry.CreatedAt,
+		})
+	}
+
+	trackingInfo := gin.H{
+		"order_number":       orderRecord.OrderNumber,
+		"status":             orderRecord.Status,
+		"ordered_at":         orderRecord.CreatedAt,
+		"shipping_method":    orderRecord.ShippingMethod,
+		"tracking_number":    orderRecord.TrackingNumber,
+		"shipping_carrier":   orderRecord.ShippingCarrier,
+		"shipped_at":         orderRecord.ShippedAt,
+		"delivered_at":       orderRecord.DeliveredAt,
+		"status_history":     history,
+		"estimated_delivery": h.calculateEstimatedDelivery(orderRecord),
+	}
+
+	live, err := h.trackingService.GetLiveTracking(c.Request.Context(), orderRecord.ShippingCarrier, orderRecord.TrackingNumber)
+	if err != nil {
+		log.Printf("Failed to get carrier tracking for order %s: %v", orderRecord.OrderNumber, err)
+	} else if live != nil {
+		trackingInfo["carrier_status"] = live.Status
+		trackingInfo["checkpoints"] = live.Checkpoints
+		trackingInfo["tracking_checked_at"] = live.CheckedAt
+		if live.EstimatedDelivery != "" {
+			trackingInfo["estimated_delivery"] = live.EstimatedDelivery
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Order tracking information retrieved successfully",
+		"message": localize(c, i18n.MsgOrderTrackingRetrieved),
 		"data":    trackingInfo,
 	})
 }
@@ -309,7 +496,7 @@ func (h *OrderHandler) AdminGetOrders(c *gin.Context) {
 
 	if err := c.ShouldBindQuery(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid query parameters",
+			"error":   localize(c, i18n.MsgInvalidQueryParams),
 			"details": err.Error(),
 		})
 		return
@@ -326,24 +513,167 @@ func (h *OrderHandler) AdminGetOrders(c *gin.Context) {
 	response, err := h.orderService.GetOrders(&req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve orders",
+			"error": localize(c, i18n.MsgFailedToRetrieveOrders),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": localize(c, i18n.MsgOrdersRetrieved),
+		"data":    response,
+	})
+}
+
+// AdminGetAddressHolds handles GET /admin/orders/address-holds
+func (h *OrderHandler) AdminGetAddressHolds(c *gin.Context) {
+	var req order.OrderListRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   localize(c, i18n.MsgInvalidQueryParams),
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	held := true
+	req.AddressHold = &held
+
+	response, err := h.orderService.GetOrders(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": localize(c, i18n.MsgFailedToRetrieveOrders),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Orders retrieved successfully",
+		"message": localize(c, i18n.MsgOrdersAwaitingAddressVerification),
 		"data":    response,
 	})
 }
 
+// AdminUpdateShippingAddress handles PUT /admin/orders/:id/shipping-address
+func (h *OrderHandler) AdminUpdateShippingAddress(c *gin.Context) {
+	userID, _ := middleware.GetUserIDFromContext(c) // Admin user ID
+
+	idParam := c.Param("id")
+	orderID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": localize(c, i18n.MsgInvalidOrderID),
+		})
+		return
+	}
+
+	var req order.Address
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   localize(c, i18n.MsgInvalidRequestData),
+			"details": err.Error(),
+		})
+		return
+	}
+
+	updated, err := h.orderService.UpdateShippingAddress(uint(orderID), req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": localize(c, i18n.MsgOrderShippingAddressUpdated),
+		"data":    updated,
+	})
+}
+
+// AdminVerifyAddress handles POST /admin/orders/:id/verify-address
+func (h *OrderHandler) AdminVerifyAddress(c *gin.Context) {
+	userID, _ := middleware.GetUserIDFromContext(c) // Admin user ID
+
+	idParam := c.Param("id")
+	orderID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": localize(c, i18n.MsgInvalidOrderID),
+		})
+		return
+	}
+
+	var req struct {
+		Comment string `json:"comment"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   localize(c, i18n.MsgInvalidRequestData),
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.orderService.ClearAddressHold(uint(orderID), req.Comment, userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": localize(c, i18n.MsgOrderAddressVerified),
+	})
+}
+
+// AdminResetPaymentAttempts handles POST /admin/orders/:id/reset-payment-attempts
+func (h *OrderHandler) AdminResetPaymentAttempts(c *gin.Context) {
+	userID, _ := middleware.GetUserIDFromContext(c) // Admin user ID
+
+	idParam := c.Param("id")
+	orderID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": localize(c, i18n.MsgInvalidOrderID),
+		})
+		return
+	}
+
+	var req struct {
+		Comment string `json:"comment"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   localize(c, i18n.MsgInvalidRequestData),
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.orderService.ClearPaymentAttemptHold(uint(orderID), req.Comment, userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": localize(c, i18n.MsgOrderPaymentAttemptsReset),
+	})
+}
+
 // AdminGetOrder handles GET /admin/orders/:id
 func (h *OrderHandler) AdminGetOrder(c *gin.Context) {
 	idParam := c.Param("id")
 	orderID, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid order ID",
+			"error": localize(c, i18n.MsgInvalidOrderID),
 		})
 		return
 	}
@@ -357,7 +687,7 @@ func (h *OrderHandler) AdminGetOrder(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Order retrieved successfully",
+		"message": localize(c, i18n.MsgOrderRetrieved),
 		"data":    order,
 	})
 }
@@ -370,7 +700,7 @@ func (h *OrderHandler) AdminUpdateOrderStatus(c *gin.Context) {
 	orderID, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid order ID",
+			"error": localize(c, i18n.MsgInvalidOrderID),
 		})
 		return
 	}
@@ -384,36 +714,33 @@ func (h *OrderHandler) AdminUpdateOrderStatus(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
+			"error":   localize(c, i18n.MsgInvalidRequestData),
 			"details": err.Error(),
 		})
 		return
 	}
 
-	// Update order status
-	err = h.orderService.UpdateOrderStatus(uint(orderID), req.Status, req.Comment, userID)
+	// Update order status and tracking together, so shipped orders are never left untracked
+	tracking := order.ShipmentTracking{
+		TrackingNumber:  req.TrackingNumber,
+		ShippingCarrier: req.ShippingCarrier,
+	}
+	err = h.orderService.UpdateOrderStatusWithTracking(uint(orderID), req.Status, req.Comment, userID, tracking)
 	if err != nil {
+		if errors.Is(err, order.ErrAddressVerificationRequired) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
 		return
 	}
 
-	// Update tracking information if provided
-	if req.TrackingNumber != "" || req.ShippingCarrier != "" {
-		err = h.updateTrackingInfo(uint(orderID), req.TrackingNumber, req.ShippingCarrier)
-		if err != nil {
-			// Log error but don't fail the status update
-			c.JSON(http.StatusPartialContent, gin.H{
-				"message": "Order status updated, but failed to update tracking info",
-				"warning": err.Error(),
-			})
-			return
-		}
-	}
-
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Order status updated successfully",
+		"message": localize(c, i18n.MsgOrderStatusUpdated),
 	})
 }
 
@@ -425,7 +752,7 @@ func (h *OrderHandler) AdminCancelOrder(c *gin.Context) {
 	orderID, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid order ID",
+			"error": localize(c, i18n.MsgInvalidOrderID),
 		})
 		return
 	}
@@ -436,7 +763,7 @@ func (h *OrderHandler) AdminCancelOrder(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
+			"error":   localize(c, i18n.MsgInvalidRequestData),
 			"details": err.Error(),
 		})
 		return
@@ -451,7 +778,7 @@ func (h *OrderHandler) AdminCancelOrder(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Order cancelled successfully",
+		"message": localize(c, i18n.MsgOrderCancelled),
 	})
 }
 
@@ -461,7 +788,7 @@ func (h *OrderHandler) AdminRefundOrder(c *gin.Context) {
 	orderID, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid order ID",
+			"error": localize(c, i18n.MsgInvalidOrderID),
 		})
 		return
 	}
@@ -473,7 +800,7 @@ func (h *OrderHandler) AdminRefundOrder(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
+			"error":   localize(c, i18n.MsgInvalidRequestData),
 			"details": err.Error(),
 		})
 		return
@@ -491,14 +818,6 @@ func (h *OrderHandler) AdminRefundOrder(c *gin.Context) {
 	})
 }
 
-// AdminExportOrders handles GET /admin/orders/export
-func (h *OrderHandler) AdminExportOrders(c *gin.Context) {
-	// TODO: Implement order export functionality
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Order export - Coming soon",
-	})
-}
-
 // AdminGetOrderStats handles GET /admin/orders/stats
 func (h *OrderHandler) AdminGetOrderStats(c *gin.Context) {
 	// TODO: Implement order statistics
@@ -516,47 +835,14 @@ func (h *OrderHandler) AdminGetOrderStats(c *gin.Context) {
 
 // Helper methods
 
-// calculateEstimatedDelivery calculates estimated delivery date
+// calculateEstimatedDelivery formats the order's estimated delivery date, or returns
+// nil when it hasn't shipped
 func (h *OrderHandler) calculateEstimatedDelivery(order *order.Order) *string {
-	if order.ShippedAt == nil {
+	estimate := h.orderService.EstimatedDelivery(order)
+	if estimate == nil {
 		return nil
 	}
 
-	// Simple calculation based on shipping method
-	var daysToAdd int
-	switch order.ShippingMethod {
-	case "standard":
-		daysToAdd = 5
-	case "express":
-		daysToAdd = 2
-	case "overnight":
-		daysToAdd = 1
-	default:
-		daysToAdd = 5
-	}
-
-	estimatedDate := order.ShippedAt.Add(time.Duration(daysToAdd) * 24 * time.Hour)
-	dateStr := estimatedDate.Format("2006-01-02")
+	dateStr := estimate.Format("2006-01-02")
 	return &dateStr
 }
-
-// updateTrackingInfo updates order tracking information
-func (h *OrderHandler) updateTrackingInfo(orderID uint, trackingNumber, carrier string) error {
-	updates := make(map[string]interface{})
-
-	if trackingNumber != "" {
-		updates["tracking_number"] = trackingNumber
-	}
-
-	if carrier != "" {
-		updates["shipping_carrier"] = carrier
-	}
-
-	if len(updates) == 0 {
-		return nil
-	}
-
-	// Use the order service's database connection
-	db := h.orderService.GetDB() // We need to add this method to the service
-	return db.Model(&order.Order{}).Where("id = ?", orderID).Updates(updates).Error
-}
@@ -0,0 +1,220 @@
+// internal/interfaces/http/handlers/order_export.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+)
+
+// AdminExportOrders handles GET /admin/orders/export
+func (h *OrderHandler) AdminExportOrders(c *gin.Context) {
+	var req order.OrderExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	data, filename, err := h.exportService.ExportOrders(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to export orders: " + err.Error(),
+		})
+		return
+	}
+
+	sendReportFile(c, "csv", filename, data)
+}
+
+// AdminGetExportSchedules handles GET /admin/orders/export-schedules
+func (h *OrderHandler) AdminGetExportSchedules(c *gin.Context) {
+	schedules, err := h.exportService.ListExportSchedules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve export schedules",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Export schedules retrieved successfully",
+		"data":    schedules,
+	})
+}
+
+// AdminCreateExportSchedule handles POST /admin/orders/export-schedules
+func (h *OrderHandler) AdminCreateExportSchedule(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req order.ExportScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	schedule, err := h.exportService.CreateExportSchedule(&req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Export schedule created successfully",
+		"data":    schedule,
+	})
+}
+
+// AdminUpdateExportSchedule handles PUT /admin/orders/export-schedules/:id
+func (h *OrderHandler) AdminUpdateExportSchedule(c *gin.Context) {
+	scheduleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid schedule ID",
+		})
+		return
+	}
+
+	var req order.ExportScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	schedule, err := h.exportService.UpdateExportSchedule(uint(scheduleID), &req)
+	if err != nil {
+		respondExportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Export schedule updated successfully",
+		"data":    schedule,
+	})
+}
+
+// AdminDeleteExportSchedule handles DELETE /admin/orders/export-schedules/:id
+func (h *OrderHandler) AdminDeleteExportSchedule(c *gin.Context) {
+	scheduleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid schedule ID",
+		})
+		return
+	}
+
+	if err := h.exportService.DeleteExportSchedule(uint(scheduleID)); err != nil {
+		respondExportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Export schedule deleted successfully",
+	})
+}
+
+// AdminRunExportSchedule handles POST /admin/orders/export-schedules/:id/run
+func (h *OrderHandler) AdminRunExportSchedule(c *gin.Context) {
+	scheduleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid schedule ID",
+		})
+		return
+	}
+
+	run, err := h.exportService.RunExportScheduleNow(uint(scheduleID))
+	if err != nil {
+		if run != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Export failed: " + err.Error(),
+				"data":  run,
+			})
+			return
+		}
+		respondExportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Export generated successfully",
+		"data":    run,
+	})
+}
+
+// AdminGetExportRuns handles GET /admin/orders/export-schedules/:id/runs
+func (h *OrderHandler) AdminGetExportRuns(c *gin.Context) {
+	scheduleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid schedule ID",
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	runs, err := h.exportService.ListExportRuns(uint(scheduleID), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve export runs",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Export runs retrieved successfully",
+		"data":    runs,
+	})
+}
+
+// AdminDownloadExportRun handles GET /admin/orders/export-runs/:id/download
+func (h *OrderHandler) AdminDownloadExportRun(c *gin.Context) {
+	runID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid run ID",
+		})
+		return
+	}
+
+	run, err := h.exportService.GetExportRun(uint(runID))
+	if err != nil {
+		respondExportError(c, err)
+		return
+	}
+
+	c.FileAttachment(run.FilePath, run.FileName)
+}
+
+// respondExportError maps export service errors to HTTP responses
+func respondExportError(c *gin.Context, err error) {
+	if errors.Is(err, order.ErrExportNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": err.Error(),
+	})
+}
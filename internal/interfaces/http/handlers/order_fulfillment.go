@@ -0,0 +1,86 @@
+// internal/interfaces/http/handlers/order_fulfillment.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+)
+
+// AdminGetFulfillmentGroups handles GET /admin/orders/:id/fulfillment-groups
+func (h *OrderHandler) AdminGetFulfillmentGroups(c *gin.Context) {
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid order ID",
+		})
+		return
+	}
+
+	groups, err := h.orderService.GetFulfillmentGroups(uint(orderID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve fulfillment groups",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Fulfillment groups retrieved successfully",
+		"data":    groups,
+	})
+}
+
+// AdminUpdateFulfillmentGroup handles PUT /admin/orders/:id/fulfillment-groups/:groupId
+func (h *OrderHandler) AdminUpdateFulfillmentGroup(c *gin.Context) {
+	userID, _ := middleware.GetUserIDFromContext(c) // Admin user ID
+
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid order ID",
+		})
+		return
+	}
+
+	groupID, err := strconv.ParseUint(c.Param("groupId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid fulfillment group ID",
+		})
+		return
+	}
+
+	var req order.FulfillmentGroupUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	group, err := h.orderService.UpdateFulfillmentGroup(uint(orderID), uint(groupID), &req, userID)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, order.ErrFulfillmentGroupNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, order.ErrAddressVerificationRequired):
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Fulfillment group updated successfully",
+		"data":    group,
+	})
+}
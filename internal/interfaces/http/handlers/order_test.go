@@ -0,0 +1,121 @@
+// internal/interfaces/http/handlers/order_test.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/setting"
+	"github.com/your-org/ecommerce-backend/internal/domain/shipping"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+// orderLookupRouter serves the guest order lookup behind its rate limit, allowing a
+// burst of burst lookups per client
+func orderLookupRouter(t *testing.T, burst int) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	db := testutil.NewDB(t, &order.Order{}, &order.OrderItem{}, &order.OrderStatusHistory{}, &setting.Setting{},
+		&shipping.ShippingMethod{}, &shipping.ShippingRate{}, &shipping.ShippingZone{}, &shipping.ShippingZoneRegion{})
+	redisClient, _ := testutil.NewRedis(t)
+	cfg := &config.Config{}
+	cfg.Security.RateLimitAuthPerMinute = 1
+	cfg.Security.RateLimitAuthBurst = burst
+	h := NewOrderHandler(db, redisClient, cfg)
+
+	shippedAt := time.Now().Add(-24 * time.Hour)
+	ord := &order.Order{OrderNumber: "ORD-1001", Email: "guest@example.com", Status: order.OrderStatusShipped,
+		PaymentStatus: order.PaymentStatusPaid, SubtotalAmount: 5000, TotalAmount: 5000, ShippingMethod: "standard",
+		TrackingNumber: "1Z999", ShippingCarrier: "UPS", ShippedAt: &shippedAt,
+		ShippingAddress: order.Address{FirstName: "Guest", AddressLine1: "1 Main Street", City: "Bengaluru"},
+		Items:           []order.OrderItem{{ProductID: 1, SKU: "LAMP-1", Name: "Lamp", Price: 5000, Quantity: 1, TotalPrice: 5000}}}
+	if err := db.Create(ord).Error; err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&order.OrderStatusHistory{OrderID: ord.ID, Status: order.OrderStatusShipped, Comment: "Left with the courier",
+		CreatedBy: 1, CreatedAt: shippedAt})
+
+	router := gin.New()
+	router.POST("/orders/track", middleware.RateLimitWithRule(cfg, redisClient, middleware.OrderLookupRateLimitRule(cfg)), h.LookupOrder)
+	return router
+}
+
+func lookupOrder(router *gin.Engine, orderNumber, email string) *httptest.ResponseRecorder {
+	body := `{"order_number":"` + orderNumber + `","email":"` + email + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/orders/track", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "192.0.2.1:1234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestLookupOrder(t *testing.T) {
+	tests := []struct {
+		name        string
+		orderNumber string
+		email       string
+		wantStatus  int
+	}{
+		{"correct match", "ORD-1001", "guest@example.com", http.StatusOK},
+		{"email in another case", "ORD-1001", "Guest@Example.com", http.StatusOK},
+		{"wrong email", "ORD-1001", "someone@example.com", http.StatusNotFound},
+		{"unknown order number", "ORD-1002", "guest@example.com", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := lookupOrder(orderLookupRouter(t, 5), tt.orderNumber, tt.email)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if strings.Contains(w.Body.String(), "ORD-1001") {
+					t.Errorf("response to a failed lookup mentions the order: %s", w.Body.String())
+				}
+				return
+			}
+
+			var body struct {
+				Data map[string]interface{} `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if body.Data["status"] != string(order.OrderStatusShipped) || body.Data["tracking_number"] != "1Z999" ||
+				body.Data["estimated_delivery"] == nil {
+				t.Errorf("tracking info = %+v, want the shipment and an estimated delivery", body.Data)
+			}
+			if history, _ := body.Data["status_history"].([]interface{}); len(history) != 1 {
+				t.Errorf("status_history = %v, want one entry", body.Data["status_history"])
+			}
+			for _, private := range []string{"guest@example.com", "Main Street", "Lamp", "Left with the courier"} {
+				if strings.Contains(w.Body.String(), private) {
+					t.Errorf("response exposes %q: %s", private, w.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestLookupOrderRateLimited(t *testing.T) {
+	router := orderLookupRouter(t, 2)
+
+	// Failed guesses use up the client's lookups just as successful ones do
+	for i := 0; i < 2; i++ {
+		if w := lookupOrder(router, "ORD-1001", "someone@example.com"); w.Code != http.StatusNotFound {
+			t.Fatalf("lookup %d status = %d, want %d", i+1, w.Code, http.StatusNotFound)
+		}
+	}
+	if w := lookupOrder(router, "ORD-1001", "guest@example.com"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("lookup over the limit status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+}
@@ -2,12 +2,11 @@
 package handlers
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"strconv"
 	"time"
@@ -15,25 +14,43 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/checkout"
+	"github.com/your-org/ecommerce-backend/internal/domain/inventory"
 	"github.com/your-org/ecommerce-backend/internal/domain/order"
 	"github.com/your-org/ecommerce-backend/internal/domain/payment"
 	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"github.com/your-org/ecommerce-backend/internal/pkg/metrics"
 	"gorm.io/gorm"
 )
 
 // PaymentHandler handles payment endpoints
 type PaymentHandler struct {
-	razorpayService *payment.RazorpayService
-	config          *config.Config
-	db              *gorm.DB
+	razorpayService    *payment.RazorpayService
+	stripeService      *payment.StripeService
+	providers          *payment.ProviderSelector
+	paymentLinkService *payment.PaymentLinkService
+	webhookEvents      *payment.WebhookEventStore
+	webhookLog         *payment.WebhookLog
+	checkoutService    *checkout.Service
+	config             *config.Config
+	db                 *gorm.DB
 }
 
 // NewPaymentHandler creates a new payment handler
 func NewPaymentHandler(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *PaymentHandler {
+	razorpayService := payment.NewRazorpayService(db, cfg)
+	stripeService := payment.NewStripeService(db, cfg)
+
 	return &PaymentHandler{
-		razorpayService: payment.NewRazorpayService(db, cfg),
-		config:          cfg,
-		db:              db,
+		razorpayService:    razorpayService,
+		stripeService:      stripeService,
+		providers:          payment.NewProviderSelector(razorpayService, stripeService, cfg),
+		paymentLinkService: payment.NewPaymentLinkService(db, redisClient, cfg),
+		webhookEvents:      payment.NewWebhookEventStore(redisClient, cfg),
+		webhookLog:         payment.NewWebhookLog(db),
+		checkoutService:    checkout.NewService(db, redisClient, cfg),
+		config:             cfg,
+		db:                 db,
 	}
 }
 
@@ -119,12 +136,27 @@ func (h *PaymentHandler) InitiatePayment(c *gin.Context) {
 		return
 	}
 
-	// Create payment order through Razorpay service
-	paymentResponse, err := h.razorpayService.CreatePaymentOrder(req.OrderID)
+	// Take payment through the provider for the order's payment method
+	provider, err := h.providers.ForOrder(&orderRecord)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	paymentResponse, err := provider.CreateOrder(req.OrderID)
 	if err != nil {
 		// Log the error for debugging
 		fmt.Printf("Payment initiation error for order %d: %v\n", req.OrderID, err)
 
+		if errors.Is(err, payment.ErrPaymentAttemptsExceeded) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -138,6 +170,47 @@ func (h *PaymentHandler) InitiatePayment(c *gin.Context) {
 	})
 }
 
+// ResendPaymentLink handles POST /payment/resend-link/:orderId
+func (h *PaymentHandler) ResendPaymentLink(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	orderID, err := strconv.ParseUint(c.Param("orderId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid order ID",
+		})
+		return
+	}
+
+	link, err := h.paymentLinkService.ResendPaymentLink(userID, uint(orderID))
+	if err != nil {
+		switch {
+		case errors.Is(err, payment.ErrPaymentOrderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found or access denied"})
+		case errors.Is(err, payment.ErrOrderNotPayable):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.Is(err, payment.ErrPaymentLinkRateLimited):
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		case errors.Is(err, payment.ErrPaymentAttemptsExceeded):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Payment link sent successfully",
+		"data":    link,
+	})
+}
+
 // VerifyPayment handles POST /payment/verify
 func (h *PaymentHandler) VerifyPayment(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
@@ -167,23 +240,37 @@ func (h *PaymentHandler) VerifyPayment(c *gin.Context) {
 		return
 	}
 
-	// Verify payment through Razorpay service
-	err := h.razorpayService.VerifyPayment(&req)
+	provider, err := h.providers.ForOrder(&orderRecord)
 	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Verify payment through the order's provider
+	if err := provider.VerifyPayment(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
 		return
 	}
 
+	data := gin.H{
+		"order_id": req.OrderID,
+		"provider": provider.Name(),
+		"status":   "verified",
+	}
+	if provider.Name() == payment.ProviderStripe {
+		data["payment_intent_id"] = req.PaymentIntentID
+	} else {
+		data["razorpay_order_id"] = req.RazorpayOrderID
+		data["razorpay_payment_id"] = req.RazorpayPaymentID
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Payment verified successfully",
-		"data": gin.H{
-			"order_id":            req.OrderID,
-			"razorpay_order_id":   req.RazorpayOrderID,
-			"razorpay_payment_id": req.RazorpayPaymentID,
-			"status":              "verified",
-		},
+		"data":    data,
 	})
 }
 
@@ -286,7 +373,33 @@ func (h *PaymentHandler) GetPaymentStatus(c *gin.Context) {
 
 // GetPaymentMethods handles GET /payment/methods
 func (h *PaymentHandler) GetPaymentMethods(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
 	razorpayEnabled := h.config.External.Razorpay.KeyID != "" && h.config.External.Razorpay.KeySecret != ""
+	stripeEnabled := h.config.External.Stripe.SecretKey != "" && h.config.External.Stripe.PublishableKey != ""
+
+	// Evaluate availability rules (e.g. COD order value limits) against the cart
+	sessionID, _ := c.Cookie("session_id")
+	available, err := h.checkoutService.GetPaymentMethods(userID, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve payment methods",
+		})
+		return
+	}
+
+	codEnabled, codReason := true, ""
+	for _, method := range available {
+		if method.ID == "cod" {
+			codEnabled, codReason = method.Available, method.Reason
+		}
+	}
 
 	methods := []gin.H{
 		{
@@ -305,12 +418,29 @@ func (h *PaymentHandler) GetPaymentMethods(c *gin.Context) {
 				"card", "netbanking", "upi", "wallet", "emi",
 			},
 		},
+		{
+			"id":          "stripe",
+			"name":        "Stripe",
+			"description": "Pay using Credit Card, Debit Card, Apple Pay, or Google Pay",
+			"logo":        "/images/stripe-logo.png",
+			"enabled":     stripeEnabled,
+			"key_id": func() string {
+				if stripeEnabled {
+					return h.config.External.Stripe.PublishableKey
+				}
+				return ""
+			}(),
+			"types": []string{
+				"card", "apple_pay", "google_pay",
+			},
+		},
 		{
 			"id":          "cod",
 			"name":        "Cash on Delivery",
 			"description": "Pay cash when your order is delivered",
 			"logo":        "/images/cod-logo.png",
-			"enabled":     true,
+			"enabled":     codEnabled,
+			"reason":      codReason,
 			"types": []string{
 				"cash",
 			},
@@ -327,16 +457,29 @@ func (h *PaymentHandler) GetPaymentMethods(c *gin.Context) {
 func (h *PaymentHandler) WebhookHandler(c *gin.Context) {
 	// Read the request body
 	body, err := io.ReadAll(c.Request.Body)
+
+	// Every delivery is logged, valid or not
+	event := payment.NewWebhookEvent(payment.ProviderRazorpay, body)
+	defer h.webhookLog.Record(event)
+
 	if err != nil {
+		rejectWebhook(event, "failed to read request body")
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Failed to read request body",
 		})
 		return
 	}
 
+	// Parse webhook data; unsigned payloads are parsed only to label the log entry
+	var webhookData map[string]interface{}
+	parseErr := json.Unmarshal(body, &webhookData)
+	event.EventType, _ = webhookData["event"].(string)
+	event.EventID = razorpayEventID(webhookData, c.GetHeader("X-Razorpay-Event-Id"))
+
 	// Get signature from header
 	signature := c.GetHeader("X-Razorpay-Signature")
 	if signature == "" {
+		rejectWebhook(event, "missing signature header")
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Missing signature header",
 		})
@@ -344,16 +487,17 @@ func (h *PaymentHandler) WebhookHandler(c *gin.Context) {
 	}
 
 	// Verify webhook signature
-	if !h.verifyWebhookSignature(string(body), signature) {
+	if !h.razorpayService.VerifyWebhook(body, signature) {
+		rejectWebhook(event, "invalid signature")
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "Invalid signature",
 		})
 		return
 	}
+	event.SignatureValid = true
 
-	// Parse webhook data
-	var webhookData map[string]interface{}
-	if err := json.Unmarshal(body, &webhookData); err != nil {
+	if parseErr != nil {
+		rejectWebhook(event, "invalid JSON payload")
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Invalid JSON payload",
 		})
@@ -361,25 +505,78 @@ func (h *PaymentHandler) WebhookHandler(c *gin.Context) {
 	}
 
 	// Get event type
-	eventType, ok := webhookData["event"].(string)
-	if !ok {
+	eventType := event.EventType
+	if eventType == "" {
+		rejectWebhook(event, "missing event type")
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Missing event type",
 		})
 		return
 	}
 
+	// Skip redeliveries of events already applied, before touching any state
+	eventID := event.EventID
+	if eventID != "" {
+		claimed, err := h.webhookEvents.Claim(payment.ProviderRazorpay, eventID)
+		if err != nil {
+			// Razorpay retries on a non-2xx response, so fail rather than risk applying twice
+			log.Printf("Failed to deduplicate Razorpay webhook %s: %v", eventID, err)
+			event.Status = payment.WebhookStatusFailed
+			event.Error = err.Error()
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to process webhook",
+			})
+			return
+		}
+		if !claimed {
+			event.Status = payment.WebhookStatusDuplicate
+			c.JSON(http.StatusOK, gin.H{
+				"status": "duplicate",
+			})
+			return
+		}
+
+		// An event that fails mid-way must stay retryable
+		defer func() {
+			if r := recover(); r != nil {
+				if err := h.webhookEvents.Release(payment.ProviderRazorpay, eventID); err != nil {
+					log.Printf("Failed to release Razorpay webhook %s: %v", eventID, err)
+				}
+				panic(r)
+			}
+		}()
+	}
+
 	// Handle different webhook events
+	var handleErr error
+	event.Status = payment.WebhookStatusProcessed
 	switch eventType {
 	case "payment.captured":
-		h.handlePaymentCaptured(webhookData)
+		handleErr = h.handlePaymentCaptured(webhookData)
 	case "payment.failed":
-		h.handlePaymentFailed(webhookData)
+		handleErr = h.handlePaymentFailed(webhookData)
 	case "order.paid":
-		h.handleOrderPaid(webhookData)
+		handleErr = h.handleOrderPaid(webhookData)
 	default:
 		// Log unknown event type but don't fail
-		fmt.Printf("Unknown webhook event: %s\n", eventType)
+		log.Printf("Unknown webhook event: %s", eventType)
+		event.Status = payment.WebhookStatusIgnored
+	}
+
+	// Razorpay retries on a non-2xx response, so let the redelivery through
+	if handleErr != nil {
+		log.Printf("Failed to handle Razorpay webhook %s: %v", eventType, handleErr)
+		event.Status = payment.WebhookStatusFailed
+		event.Error = handleErr.Error()
+		if eventID != "" {
+			if err := h.webhookEvents.Release(payment.ProviderRazorpay, eventID); err != nil {
+				log.Printf("Failed to release Razorpay webhook %s: %v", eventID, err)
+			}
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to process webhook",
+		})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -387,6 +584,21 @@ func (h *PaymentHandler) WebhookHandler(c *gin.Context) {
 	})
 }
 
+// rejectWebhook marks a logged delivery as rejected before it was processed
+func rejectWebhook(event *payment.PaymentWebhookEvent, reason string) {
+	event.Status = payment.WebhookStatusRejected
+	event.Error = reason
+}
+
+// razorpayEventID returns the webhook event ID from the payload, falling back to
+// the X-Razorpay-Event-Id header
+func razorpayEventID(webhookData map[string]interface{}, header string) string {
+	if id, ok := webhookData["id"].(string); ok && id != "" {
+		return id
+	}
+	return header
+}
+
 // --- ADMIN ENDPOINTS ---
 
 // AdminGetPayments handles GET /admin/payments
@@ -479,6 +691,61 @@ func (h *PaymentHandler) AdminGetPaymentDetails(c *gin.Context) {
 	})
 }
 
+// AdminGetWebhookEvents handles GET /admin/payments/webhooks
+func (h *PaymentHandler) AdminGetWebhookEvents(c *gin.Context) {
+	var req payment.WebhookEventListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	events, err := h.webhookLog.List(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook events retrieved successfully",
+		"data":    events,
+	})
+}
+
+// AdminGetWebhookEvent handles GET /admin/payments/webhooks/:eventId
+func (h *PaymentHandler) AdminGetWebhookEvent(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("eventId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid webhook event ID",
+		})
+		return
+	}
+
+	event, err := h.webhookLog.Get(uint(id))
+	if err != nil {
+		if errors.Is(err, payment.ErrWebhookEventNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Webhook event not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve webhook event",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook event retrieved successfully",
+		"data":    event,
+	})
+}
+
 // AdminRefundPayment handles POST /admin/payments/:paymentId/refund
 func (h *PaymentHandler) AdminRefundPayment(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
@@ -488,8 +755,75 @@ func (h *PaymentHandler) AdminRefundPayment(c *gin.Context) {
 
 // AdminGetPaymentStats handles GET /admin/payments/stats
 func (h *PaymentHandler) AdminGetPaymentStats(c *gin.Context) {
+	var req payment.PaymentStatsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	stats, err := h.razorpayService.GetPaymentStats(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to get payment stats: " + err.Error(),
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Payment stats endpoint - Coming soon",
+		"message": "Payment stats retrieved successfully",
+		"data":    stats,
+	})
+}
+
+// AdminGetSettlementReport handles GET /admin/payments/settlements
+func (h *PaymentHandler) AdminGetSettlementReport(c *gin.Context) {
+	var req payment.SettlementReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	report, err := h.razorpayService.GetSettlementReport(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to generate settlement report: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Settlement report retrieved successfully",
+		"data":    report,
+	})
+}
+
+// AdminGetOrderSettlement handles GET /admin/payments/settlements/:orderId
+func (h *PaymentHandler) AdminGetOrderSettlement(c *gin.Context) {
+	orderID, err := strconv.ParseUint(c.Param("orderId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid order ID",
+		})
+		return
+	}
+
+	settlement, err := h.razorpayService.GetOrderSettlement(uint(orderID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Order settlement retrieved successfully",
+		"data":    settlement,
 	})
 }
 
@@ -498,75 +832,188 @@ func (h *PaymentHandler) RazorpayWebhook(c *gin.Context) {
 	h.WebhookHandler(c)
 }
 
+// StripeWebhook handles POST /webhooks/stripe
+func (h *PaymentHandler) StripeWebhook(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+
+	// Every delivery is logged, valid or not
+	event := payment.NewWebhookEvent(payment.ProviderStripe, body)
+	defer h.webhookLog.Record(event)
+
+	if err != nil {
+		rejectWebhook(event, "failed to read request body")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to read request body",
+		})
+		return
+	}
+
+	var envelope payment.StripeEvent
+	if json.Unmarshal(body, &envelope) == nil {
+		event.EventID = envelope.ID
+		event.EventType = envelope.Type
+	}
+
+	signature := c.GetHeader("Stripe-Signature")
+	if signature == "" {
+		rejectWebhook(event, "missing signature header")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Missing signature header",
+		})
+		return
+	}
+
+	if !h.stripeService.VerifyWebhook(body, signature) {
+		rejectWebhook(event, "invalid signature")
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid signature",
+		})
+		return
+	}
+	event.SignatureValid = true
+
+	// A non-2xx response makes Stripe redeliver the event later
+	if err := h.stripeService.HandleWebhookEvent(body); err != nil {
+		log.Printf("Failed to handle Stripe webhook: %v", err)
+		event.Status = payment.WebhookStatusFailed
+		event.Error = err.Error()
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to process webhook",
+		})
+		return
+	}
+	event.Status = payment.WebhookStatusProcessed
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "received",
+	})
+}
+
 // --- WEBHOOK EVENT HANDLERS ---
 
-func (h *PaymentHandler) handlePaymentCaptured(data map[string]interface{}) {
-	payload := data["payload"].(map[string]interface{})
-	paymentEntity := payload["payment"].(map[string]interface{})["entity"].(map[string]interface{})
+// webhookPaymentEntity returns the payment entity of a Razorpay payment event and
+// the gateway order ID it belongs to
+func webhookPaymentEntity(data map[string]interface{}) (map[string]interface{}, string, error) {
+	payload, _ := data["payload"].(map[string]interface{})
+	paymentData, _ := payload["payment"].(map[string]interface{})
+	paymentEntity, _ := paymentData["entity"].(map[string]interface{})
+	orderID, _ := paymentEntity["order_id"].(string)
+	if orderID == "" {
+		return nil, "", fmt.Errorf("webhook payload has no payment order ID")
+	}
+	return paymentEntity, orderID, nil
+}
 
-	// Extract payment ID and order ID
-	_ = paymentEntity["id"].(string) // paymentID (not used currently)
-	orderID := paymentEntity["order_id"].(string)
+func (h *PaymentHandler) handlePaymentCaptured(data map[string]interface{}) error {
+	paymentEntity, orderID, err := webhookPaymentEntity(data)
+	if err != nil {
+		return err
+	}
 
 	// Find payment in database and update status
-	var payment order.Payment
-	result := h.db.Where("payment_provider_id = ?", orderID).First(&payment)
+	var paymentRecord order.Payment
+	result := h.db.Where("payment_provider_id = ?", orderID).First(&paymentRecord)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil // Payment not found, might be from different system
+	}
 	if result.Error != nil {
-		return // Payment not found, might be from different system
+		return fmt.Errorf("failed to get payment: %w", result.Error)
 	}
+	alreadyPaid := paymentRecord.Status == order.PaymentStatusPaid
+
+	gatewayResponse := h.structToJSON(paymentEntity)
+	fee, tax, _ := payment.ParseGatewayFees(gatewayResponse)
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		// Update payment status
+		if err := tx.Model(&paymentRecord).Updates(map[string]interface{}{
+			"status":           order.PaymentStatusPaid,
+			"gateway_response": gatewayResponse,
+			"gateway_fee":      fee,
+			"gateway_tax":      tax,
+			"processed_at":     time.Now().UTC(),
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update payment: %w", err)
+		}
 
-	// Update payment status
-	h.db.Model(&payment).Updates(map[string]interface{}{
-		"status":           order.PaymentStatusPaid,
-		"gateway_response": h.structToJSON(paymentEntity),
-		"processed_at":     time.Now().UTC(),
+		// Update order status
+		if err := tx.Model(&order.Order{}).Where("id = ?", paymentRecord.OrderID).Updates(map[string]interface{}{
+			"status":         order.OrderStatusConfirmed,
+			"payment_status": order.PaymentStatusPaid,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		return nil
 	})
+	if err != nil {
+		return err
+	}
 
-	// Update order status
-	h.db.Model(&order.Order{}).Where("id = ?", payment.OrderID).Updates(map[string]interface{}{
-		"status":         order.OrderStatusConfirmed,
-		"payment_status": order.PaymentStatusPaid,
-	})
-}
+	// Count the payment once, not again when the client has already verified it
+	if !alreadyPaid {
+		metrics.RecordPayment(payment.ProviderRazorpay, true)
+	}
 
-func (h *PaymentHandler) handlePaymentFailed(data map[string]interface{}) {
-	payload := data["payload"].(map[string]interface{})
-	paymentEntity := payload["payment"].(map[string]interface{})["entity"].(map[string]interface{})
+	if err := order.SplitFulfillment(h.db, h.config, paymentRecord.OrderID); err != nil {
+		log.Printf("Failed to split order %d into fulfillment groups: %v", paymentRecord.OrderID, err)
+	}
+	var fulfilledItems []uint
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		fulfilledItems, err = inventory.FulfillOrderReservations(tx, paymentRecord.OrderID)
+		return err
+	}); err != nil {
+		log.Printf("Failed to fulfill stock reservations for order %d: %v", paymentRecord.OrderID, err)
+	} else {
+		inventory.CheckStockAlerts(h.db, h.config, fulfilledItems)
+	}
+	return nil
+}
 
-	orderID := paymentEntity["order_id"].(string)
+func (h *PaymentHandler) handlePaymentFailed(data map[string]interface{}) error {
+	_, orderID, err := webhookPaymentEntity(data)
+	if err != nil {
+		return err
+	}
 
-	var payment order.Payment
-	result := h.db.Where("payment_provider_id = ?", orderID).First(&payment)
+	var paymentRecord order.Payment
+	result := h.db.Where("payment_provider_id = ?", orderID).First(&paymentRecord)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil
+	}
 	if result.Error != nil {
-		return
+		return fmt.Errorf("failed to get payment: %w", result.Error)
 	}
+	alreadyFailed := paymentRecord.Status == order.PaymentStatusFailed
 
-	h.db.Model(&payment).Updates(map[string]interface{}{
-		"status": order.PaymentStatusFailed,
-	})
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&paymentRecord).Updates(map[string]interface{}{
+			"status": order.PaymentStatusFailed,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update payment: %w", err)
+		}
 
-	h.db.Model(&order.Order{}).Where("id = ?", payment.OrderID).Updates(map[string]interface{}{
-		"status":         order.OrderStatusPending,
-		"payment_status": order.PaymentStatusFailed,
+		if err := tx.Model(&order.Order{}).Where("id = ?", paymentRecord.OrderID).Updates(map[string]interface{}{
+			"status":         order.OrderStatusPending,
+			"payment_status": order.PaymentStatusFailed,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	if !alreadyFailed {
+		metrics.RecordPayment(payment.ProviderRazorpay, false)
+	}
+	return nil
 }
 
-func (h *PaymentHandler) handleOrderPaid(data map[string]interface{}) {
+func (h *PaymentHandler) handleOrderPaid(data map[string]interface{}) error {
 	// Handle when entire order is paid (for subscription/recurring payments)
 	// Implementation depends on your business requirements
-}
-
-// verifyWebhookSignature verifies Razorpay webhook signature
-func (h *PaymentHandler) verifyWebhookSignature(body, signature string) bool {
-	if h.config.External.Razorpay.WebhookSecret == "" {
-		// If webhook secret not configured, skip verification in development
-		return h.config.IsDevelopment()
-	}
-
-	mac := hmac.New(sha256.New, []byte(h.config.External.Razorpay.WebhookSecret))
-	mac.Write([]byte(body))
-	expectedSignature := hex.EncodeToString(mac.Sum(nil))
-	return hmac.Equal([]byte(signature), []byte(expectedSignature))
+	return nil
 }
 
 // structToJSON converts struct to JSON string
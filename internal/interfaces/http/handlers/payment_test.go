@@ -0,0 +1,200 @@
+// internal/interfaces/http/handlers/payment_test.go
+package handlers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/inventory"
+	"github.com/your-org/ecommerce-backend/internal/domain/order"
+	"github.com/your-org/ecommerce-backend/internal/domain/payment"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+	"gorm.io/gorm"
+)
+
+const testRazorpayWebhookSecret = "whsec_test"
+
+// newWebhookTestHandler returns a payment handler routed at /webhooks/razorpay, with
+// an order awaiting payment through Razorpay order order_123
+func newWebhookTestHandler(t *testing.T, redisClient *redis.Client) (*gin.Engine, *gorm.DB, *order.Order) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	db := testutil.NewDB(t, &order.Order{}, &order.OrderItem{}, &order.Payment{}, &order.OrderStatusHistory{},
+		&inventory.InventoryItem{}, &inventory.StockReservation{}, &payment.PaymentWebhookEvent{})
+
+	cfg := &config.Config{}
+	cfg.External.Razorpay.WebhookSecret = testRazorpayWebhookSecret
+	h := NewPaymentHandler(db, redisClient, cfg)
+
+	orderRecord := &order.Order{OrderNumber: "ORD-1", Email: "buyer@example.com", Status: order.OrderStatusPaymentProcessing,
+		PaymentStatus: order.PaymentStatusProcessing, SubtotalAmount: 5000, TotalAmount: 5000}
+	if err := db.Create(orderRecord).Error; err != nil {
+		t.Fatal(err)
+	}
+	paymentRecord := order.Payment{OrderID: orderRecord.ID, PaymentMethod: "razorpay", PaymentProviderID: "order_123",
+		Amount: 5000, Status: order.PaymentStatusProcessing, Gateway: payment.ProviderRazorpay}
+	if err := db.Create(&paymentRecord).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	router.POST("/webhooks/razorpay", h.WebhookHandler)
+	return router, db, orderRecord
+}
+
+// deliverWebhook posts a Razorpay webhook body with the given signature
+func deliverWebhook(router *gin.Engine, body []byte, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/razorpay", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set("X-Razorpay-Signature", signature)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func signWebhook(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(testRazorpayWebhookSecret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func paymentCapturedBody(eventID string) []byte {
+	body, _ := json.Marshal(map[string]interface{}{
+		"id":    eventID,
+		"event": "payment.captured",
+		"payload": map[string]interface{}{
+			"payment": map[string]interface{}{
+				"entity": map[string]interface{}{"id": "pay_123", "order_id": "order_123", "amount": 5000, "status": "captured"},
+			},
+		},
+	})
+	return body
+}
+
+func TestWebhookDuplicatePaymentCaptured(t *testing.T) {
+	redisClient, _ := testutil.NewRedis(t)
+	router, db, orderRecord := newWebhookTestHandler(t, redisClient)
+	body := paymentCapturedBody("evt_1")
+
+	if w := deliverWebhook(router, body, signWebhook(body)); w.Code != http.StatusOK {
+		t.Fatalf("first delivery status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var first order.Payment
+	db.Where("order_id = ?", orderRecord.ID).First(&first)
+	if first.Status != order.PaymentStatusPaid || first.ProcessedAt == nil {
+		t.Fatalf("payment after first delivery = %s, want %s", first.Status, order.PaymentStatusPaid)
+	}
+	var confirmed order.Order
+	db.First(&confirmed, orderRecord.ID)
+	if confirmed.Status != order.OrderStatusConfirmed || confirmed.PaymentStatus != order.PaymentStatusPaid {
+		t.Fatalf("order after first delivery = %s/%s, want %s/%s", confirmed.Status, confirmed.PaymentStatus,
+			order.OrderStatusConfirmed, order.PaymentStatusPaid)
+	}
+
+	w := deliverWebhook(router, body, signWebhook(body))
+	if w.Code != http.StatusOK || !bytes.Contains(w.Body.Bytes(), []byte("duplicate")) {
+		t.Fatalf("redelivery = %d %s, want 200 duplicate", w.Code, w.Body)
+	}
+
+	// The redelivery changed nothing
+	var second order.Payment
+	db.Where("order_id = ?", orderRecord.ID).First(&second)
+	if !second.ProcessedAt.Equal(*first.ProcessedAt) || !second.UpdatedAt.Equal(first.UpdatedAt) {
+		t.Errorf("payment was updated again by the redelivery")
+	}
+	var unchanged order.Order
+	db.First(&unchanged, orderRecord.ID)
+	if !unchanged.UpdatedAt.Equal(confirmed.UpdatedAt) {
+		t.Errorf("order was updated again by the redelivery")
+	}
+
+	var statuses []string
+	db.Model(&payment.PaymentWebhookEvent{}).Order("id ASC").Pluck("status", &statuses)
+	if len(statuses) != 2 || statuses[0] != payment.WebhookStatusProcessed || statuses[1] != payment.WebhookStatusDuplicate {
+		t.Errorf("logged statuses = %v, want [%s %s]", statuses, payment.WebhookStatusProcessed, payment.WebhookStatusDuplicate)
+	}
+}
+
+func TestWebhookWithoutEventStore(t *testing.T) {
+	router, db, orderRecord := newWebhookTestHandler(t, nil)
+	body := paymentCapturedBody("evt_1")
+
+	// Without Redis the event cannot be deduplicated, so it is refused for redelivery
+	if w := deliverWebhook(router, body, signWebhook(body)); w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	var unchanged order.Order
+	db.First(&unchanged, orderRecord.ID)
+	if unchanged.PaymentStatus != order.PaymentStatusProcessing {
+		t.Errorf("payment status = %s, want it left at %s", unchanged.PaymentStatus, order.PaymentStatusProcessing)
+	}
+}
+
+func TestWebhookDeliveriesAreLogged(t *testing.T) {
+	redisClient, _ := testutil.NewRedis(t)
+	router, db, orderRecord := newWebhookTestHandler(t, redisClient)
+
+	forged := paymentCapturedBody("evt_forged")
+	if w := deliverWebhook(router, forged, signWebhook([]byte("something else"))); w.Code != http.StatusUnauthorized {
+		t.Fatalf("forged delivery status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	unsigned := paymentCapturedBody("evt_unsigned")
+	if w := deliverWebhook(router, unsigned, ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("unsigned delivery status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	var untouched order.Order
+	db.First(&untouched, orderRecord.ID)
+	if untouched.PaymentStatus != order.PaymentStatusProcessing {
+		t.Fatalf("payment status after rejected deliveries = %s, want %s", untouched.PaymentStatus, order.PaymentStatusProcessing)
+	}
+
+	valid := paymentCapturedBody("evt_valid")
+	if w := deliverWebhook(router, valid, signWebhook(valid)); w.Code != http.StatusOK {
+		t.Fatalf("valid delivery status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	var events []payment.PaymentWebhookEvent
+	db.Order("id ASC").Find(&events)
+	want := []struct {
+		eventID        string
+		signatureValid bool
+		status         string
+		error          string
+	}{
+		{"evt_forged", false, payment.WebhookStatusRejected, "invalid signature"},
+		{"evt_unsigned", false, payment.WebhookStatusRejected, "missing signature header"},
+		{"evt_valid", true, payment.WebhookStatusProcessed, ""},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("logged %d deliveries, want %d", len(events), len(want))
+	}
+	for i, w := range want {
+		got := events[i]
+		if got.EventID != w.eventID || got.SignatureValid != w.signatureValid || got.Status != w.status || got.Error != w.error {
+			t.Errorf("delivery %d = %s valid=%v %s %q, want %s valid=%v %s %q", i+1, got.EventID, got.SignatureValid,
+				got.Status, got.Error, w.eventID, w.signatureValid, w.status, w.error)
+		}
+		if got.Provider != payment.ProviderRazorpay || got.EventType != "payment.captured" || got.Payload == "" {
+			t.Errorf("delivery %d = %s %s with %d byte payload, want a razorpay payment.captured with its payload",
+				i+1, got.Provider, got.EventType, len(got.Payload))
+		}
+	}
+
+	// The admin filter separates them
+	invalid := false
+	listed, err := payment.NewWebhookLog(db).List(&payment.WebhookEventListRequest{SignatureValid: &invalid})
+	if err != nil || listed.Total != 2 {
+		t.Errorf("List(signature_valid=false) = %v, %v, want 2 deliveries", listed, err)
+	}
+}
@@ -0,0 +1,121 @@
+// internal/interfaces/http/handlers/policy.go
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/policy"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"gorm.io/gorm"
+)
+
+// PolicyHandler handles store policy endpoints
+type PolicyHandler struct {
+	policyService *policy.Service
+	config        *config.Config
+}
+
+// NewPolicyHandler creates a new policy handler
+func NewPolicyHandler(db *gorm.DB, cfg *config.Config) *PolicyHandler {
+	return &PolicyHandler{
+		policyService: policy.NewService(db, cfg),
+		config:        cfg,
+	}
+}
+
+// GetPolicies handles GET /policies
+func (h *PolicyHandler) GetPolicies(c *gin.Context) {
+	// Optional comma-separated filter, e.g. ?types=shipping,returns for checkout
+	var types []policy.PolicyType
+	if typesParam := c.Query("types"); typesParam != "" {
+		for _, t := range strings.Split(typesParam, ",") {
+			types = append(types, policy.PolicyType(strings.TrimSpace(t)))
+		}
+	}
+
+	policies, err := h.policyService.GetActivePolicies(types)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve policies",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Policies retrieved successfully",
+		"data":    policies,
+	})
+}
+
+// GetPolicy handles GET /policies/:type
+func (h *PolicyHandler) GetPolicy(c *gin.Context) {
+	policyType := policy.PolicyType(c.Param("type"))
+
+	storePolicy, err := h.policyService.GetActivePolicy(policyType)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Policy retrieved successfully",
+		"data":    storePolicy,
+	})
+}
+
+// AdminGetPolicies handles GET /admin/policies
+func (h *PolicyHandler) AdminGetPolicies(c *gin.Context) {
+	policies, err := h.policyService.AdminGetPolicies()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve policies",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Policies retrieved successfully",
+		"data": gin.H{
+			"policies":    policies,
+			"valid_types": policy.ValidPolicyTypes,
+		},
+	})
+}
+
+// AdminUpdatePolicy handles PUT /admin/policies/:type
+func (h *PolicyHandler) AdminUpdatePolicy(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req policy.UpdatePolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	storePolicy, err := h.policyService.UpsertPolicy(policy.PolicyType(c.Param("type")), &req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Policy updated successfully",
+		"data":    storePolicy,
+	})
+}
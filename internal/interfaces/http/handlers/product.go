@@ -2,26 +2,36 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/analytics"
 	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
 	"gorm.io/gorm"
 )
 
 // ProductHandler handles product endpoints
 type ProductHandler struct {
-	productService *product.Service
-	config         *config.Config
+	productService   *product.Service
+	searchLogService *analytics.SearchLogService
+	config           *config.Config
 }
 
 // NewProductHandler creates a new product handler
-func NewProductHandler(db *gorm.DB, cfg *config.Config) *ProductHandler {
+func NewProductHandler(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *ProductHandler {
 	return &ProductHandler{
-		productService: product.NewService(db, cfg),
-		config:         cfg,
+		productService:   product.NewService(db, redisClient, cfg),
+		searchLogService: analytics.NewSearchLogService(db, cfg),
+		config:           cfg,
 	}
 }
 
@@ -49,6 +59,7 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 	// For public endpoint, only show active products
 	isActive := true
 	req.IsActive = &isActive
+	req.WithFacets = true
 
 	response, err := h.productService.GetProducts(&req)
 	if err != nil {
@@ -97,6 +108,43 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 	})
 }
 
+// GetRelatedProducts handles GET /products/:id/related
+func (h *ProductHandler) GetRelatedProducts(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	limit := 8
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 20 {
+			limit = l
+		}
+	}
+
+	products, err := h.productService.GetRelatedProducts(uint(id), limit)
+	if err != nil {
+		if errors.Is(err, product.ErrProductNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Product not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve related products",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Related products retrieved successfully",
+		"data":    products,
+	})
+}
+
 // GetProductBySlug handles GET /products/slug/:slug
 func (h *ProductHandler) GetProductBySlug(c *gin.Context) {
 	slug := c.Param("slug")
@@ -144,15 +192,21 @@ func (h *ProductHandler) SearchProducts(c *gin.Context) {
 	// For search, only show active products
 	isActive := true
 	req.IsActive = &isActive
+	req.WithFacets = true
 
 	// Require search term
-	if req.Search == "" {
+	if strings.TrimSpace(req.Search) == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Search term is required",
 		})
 		return
 	}
 
+	// Best matches first unless another order was asked for
+	if c.Query("sort_by") == "" {
+		req.SortBy = product.SortByRelevance
+	}
+
 	response, err := h.productService.GetProducts(&req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -161,6 +215,18 @@ func (h *ProductHandler) SearchProducts(c *gin.Context) {
 		return
 	}
 
+	// Log the first page only, so paging through results isn't counted as new searches
+	if req.Page == 1 {
+		var userID *uint
+		if id, exists := middleware.GetUserIDFromContext(c); exists {
+			userID = &id
+		}
+		sessionID, _ := c.Cookie("session_id")
+		if err := h.searchLogService.LogSearch(req.Search, response.Pagination.Total, userID, sessionID); err != nil {
+			log.Printf("Failed to log search %q: %v", req.Search, err)
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Products found",
 		"data":    response,
@@ -242,8 +308,14 @@ func (h *ProductHandler) AdminCreateProduct(c *gin.Context) {
 		return
 	}
 
-	product, err := h.productService.CreateProduct(&req)
+	createdProduct, err := h.productService.CreateProduct(&req)
 	if err != nil {
+		if errors.Is(err, product.ErrPriceBelowFloor) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusConflict, gin.H{
 			"error": err.Error(),
 		})
@@ -252,7 +324,7 @@ func (h *ProductHandler) AdminCreateProduct(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Product created successfully",
-		"data":    product,
+		"data":    createdProduct,
 	})
 }
 
@@ -276,8 +348,14 @@ func (h *ProductHandler) AdminUpdateProduct(c *gin.Context) {
 		return
 	}
 
-	product, err := h.productService.UpdateProduct(uint(id), &req)
+	updatedProduct, err := h.productService.UpdateProduct(uint(id), &req)
 	if err != nil {
+		if errors.Is(err, product.ErrPriceBelowFloor) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -286,10 +364,102 @@ func (h *ProductHandler) AdminUpdateProduct(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Product updated successfully",
-		"data":    product,
+		"data":    updatedProduct,
+	})
+}
+
+// AdminBulkUpdateProducts handles POST /admin/products/bulk-update
+func (h *ProductHandler) AdminBulkUpdateProducts(c *gin.Context) {
+	var req product.BulkProductUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.productService.BulkUpdateProducts(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Products updated successfully",
+		"data":    result,
 	})
 }
 
+// AdminGenerateVariants handles POST /admin/products/:id/variants/generate
+func (h *ProductHandler) AdminGenerateVariants(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	var req product.GenerateVariantsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.productService.GenerateVariants(uint(id), req.Options, req.PriceOverrides)
+	if err != nil {
+		if errors.Is(err, product.ErrProductNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Product not found",
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Variants generated successfully",
+		"data":    result,
+	})
+}
+
+// AdminExportProducts handles GET /admin/products/export
+func (h *ProductHandler) AdminExportProducts(c *gin.Context) {
+	var req product.ProductExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("products_%s.csv", time.Now().In(h.config.GetLocation()).Format("20060102_150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Status(http.StatusOK)
+
+	if err := h.productService.ExportProductsCSV(c.Writer, &req); err != nil {
+		// Once rows have been streamed the status can no longer change
+		if !c.Writer.Written() {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to export products",
+			})
+			return
+		}
+		log.Printf("Product export aborted: %v", err)
+	}
+}
+
 // AdminDeleteProduct handles DELETE /admin/products/:id
 func (h *ProductHandler) AdminDeleteProduct(c *gin.Context) {
 	idParam := c.Param("id")
@@ -326,7 +496,8 @@ func (h *ProductHandler) AdminUpdateInventory(c *gin.Context) {
 	}
 
 	var req struct {
-		Quantity int `json:"quantity" binding:"required,min=0"`
+		Quantity    int  `json:"quantity" binding:"required,min=0"`
+		SafetyStock *int `json:"safety_stock" binding:"omitempty,min=0"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -337,7 +508,7 @@ func (h *ProductHandler) AdminUpdateInventory(c *gin.Context) {
 		return
 	}
 
-	err = h.productService.UpdateInventory(uint(id), req.Quantity)
+	err = h.productService.UpdateInventory(uint(id), req.Quantity, req.SafetyStock)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -0,0 +1,300 @@
+// internal/interfaces/http/handlers/product_questions.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+)
+
+// GetProductQuestions handles GET /products/:id/questions
+func (h *ReviewHandler) GetProductQuestions(c *gin.Context) {
+	productIDUint64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+	productID := uint(productIDUint64)
+
+	var req product.QuestionListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+	req.ProductID = &productID
+
+	var currentUserID *uint
+	if userID, exists := middleware.GetUserIDFromContext(c); exists {
+		currentUserID = &userID
+	}
+
+	response, err := h.reviewService.GetQuestions(&req, currentUserID, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve product questions",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Product questions retrieved successfully",
+		"data":    response,
+	})
+}
+
+// AskQuestion handles POST /products/:id/questions
+func (h *ReviewHandler) AskQuestion(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	var req product.AskQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	question, err := h.reviewService.AskQuestion(uint(productID), userID, &req)
+	if err != nil {
+		if errors.Is(err, product.ErrProductNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Product not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to submit question",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Question submitted successfully",
+		"data":    question,
+	})
+}
+
+// AnswerQuestion handles POST /questions/:id/answers
+func (h *ReviewHandler) AnswerQuestion(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	questionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid question ID",
+		})
+		return
+	}
+
+	var req product.AnswerQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	answer, err := h.reviewService.AnswerQuestion(uint(questionID), userID, &req)
+	if err != nil {
+		if errors.Is(err, product.ErrQuestionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Question not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to submit answer",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Answer submitted successfully",
+		"data":    answer,
+	})
+}
+
+// VoteAnswerHelpful handles POST /answers/:id/helpful
+func (h *ReviewHandler) VoteAnswerHelpful(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	answerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid answer ID",
+		})
+		return
+	}
+
+	var req product.ReviewHelpfulRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.reviewService.VoteAnswerHelpful(uint(answerID), userID, &req); err != nil {
+		if errors.Is(err, product.ErrAnswerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Answer not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to record vote",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Vote recorded successfully",
+	})
+}
+
+// AdminGetQuestions handles GET /admin/questions
+func (h *ReviewHandler) AdminGetQuestions(c *gin.Context) {
+	var req product.QuestionListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	response, err := h.reviewService.GetQuestions(&req, nil, true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve questions",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Questions retrieved successfully",
+		"data":    response,
+	})
+}
+
+// AdminModerateQuestion handles PUT /admin/questions/:id/approve
+func (h *ReviewHandler) AdminModerateQuestion(c *gin.Context) {
+	questionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid question ID",
+		})
+		return
+	}
+
+	var req product.AdminReviewActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.reviewService.AdminModerateQuestion(uint(questionID), &req); err != nil {
+		if errors.Is(err, product.ErrQuestionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Question not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update question status",
+		})
+		return
+	}
+
+	message := "Question approved successfully"
+	if req.Action == "reject" {
+		message = "Question rejected successfully"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+	})
+}
+
+// AdminModerateAnswer handles PUT /admin/answers/:id/approve
+func (h *ReviewHandler) AdminModerateAnswer(c *gin.Context) {
+	answerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid answer ID",
+		})
+		return
+	}
+
+	var req product.AdminReviewActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.reviewService.AdminModerateAnswer(uint(answerID), &req); err != nil {
+		if errors.Is(err, product.ErrAnswerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Answer not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update answer status",
+		})
+		return
+	}
+
+	message := "Answer approved successfully"
+	if req.Action == "reject" {
+		message = "Answer rejected successfully"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+	})
+}
@@ -0,0 +1,114 @@
+// internal/interfaces/http/handlers/recently_viewed.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/recentlyviewed"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"gorm.io/gorm"
+)
+
+// RecentlyViewedHandler handles recently viewed product endpoints
+type RecentlyViewedHandler struct {
+	recentlyViewedService *recentlyviewed.Service
+	config                *config.Config
+}
+
+// NewRecentlyViewedHandler creates a new recently viewed handler
+func NewRecentlyViewedHandler(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *RecentlyViewedHandler {
+	return &RecentlyViewedHandler{
+		recentlyViewedService: recentlyviewed.NewService(db, redisClient, cfg),
+		config:                cfg,
+	}
+}
+
+// GetRecentlyViewed handles GET /recently-viewed
+func (h *RecentlyViewedHandler) GetRecentlyViewed(c *gin.Context) {
+	userID, sessionID := h.getOwner(c)
+
+	list, err := h.recentlyViewedService.GetRecentlyViewed(userID, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve recently viewed products",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Recently viewed products retrieved successfully",
+		"data":    list,
+	})
+}
+
+// AddRecentlyViewed handles POST /recently-viewed/add/:id
+func (h *RecentlyViewedHandler) AddRecentlyViewed(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	userID, sessionID := h.getOwner(c)
+
+	if err := h.recentlyViewedService.AddProduct(userID, sessionID, uint(productID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	productIDs, err := h.recentlyViewedService.GetProductIDs(userID, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve recently viewed products",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Product added to recently viewed successfully",
+		"data": gin.H{
+			"product_ids": productIDs,
+			"count":       len(productIDs),
+		},
+	})
+}
+
+// ClearRecentlyViewed handles DELETE /recently-viewed
+func (h *RecentlyViewedHandler) ClearRecentlyViewed(c *gin.Context) {
+	userID, sessionID := h.getOwner(c)
+
+	if err := h.recentlyViewedService.Clear(userID, sessionID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to clear recently viewed products",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Recently viewed products cleared successfully",
+	})
+}
+
+// getOwner returns the authenticated user, or the guest session ID (creating one if needed)
+func (h *RecentlyViewedHandler) getOwner(c *gin.Context) (*uint, string) {
+	if userID, exists := middleware.GetUserIDFromContext(c); exists {
+		return &userID, ""
+	}
+
+	sessionID, err := c.Cookie("session_id")
+	if err != nil || sessionID == "" {
+		sessionID = uuid.New().String()
+		c.SetCookie("session_id", sessionID, 86400, "/", "", false, true)
+	}
+
+	return nil, sessionID
+}
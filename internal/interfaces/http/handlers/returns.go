@@ -0,0 +1,265 @@
+// internal/interfaces/http/handlers/returns.go
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/payment"
+	"github.com/your-org/ecommerce-backend/internal/domain/returns"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"github.com/your-org/ecommerce-backend/internal/pkg/i18n"
+	"gorm.io/gorm"
+)
+
+// ReturnHandler handles return request endpoints
+type ReturnHandler struct {
+	returnService *returns.Service
+	config        *config.Config
+}
+
+// NewReturnHandler creates a new return handler
+func NewReturnHandler(db *gorm.DB, cfg *config.Config) *ReturnHandler {
+	return &ReturnHandler{
+		returnService: returns.NewService(db, cfg),
+		config:        cfg,
+	}
+}
+
+// RequestReturn handles POST /orders/:id/returns
+func (h *ReturnHandler) RequestReturn(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": localize(c, i18n.MsgUserNotAuthenticated),
+		})
+		return
+	}
+
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": localize(c, i18n.MsgInvalidOrderID),
+		})
+		return
+	}
+
+	var req returns.CreateReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   localize(c, i18n.MsgInvalidRequestData),
+			"details": err.Error(),
+		})
+		return
+	}
+
+	returnRequest, err := h.returnService.CreateReturn(userID, uint(orderID), &req)
+	if err != nil {
+		h.respondError(c, err, "")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Return requested successfully",
+		"data":    returnRequest,
+	})
+}
+
+// GetOrderReturns handles GET /orders/:id/returns
+func (h *ReturnHandler) GetOrderReturns(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": localize(c, i18n.MsgUserNotAuthenticated),
+		})
+		return
+	}
+
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": localize(c, i18n.MsgInvalidOrderID),
+		})
+		return
+	}
+
+	returnRequests, err := h.returnService.GetOrderReturns(userID, uint(orderID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve return requests",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Return requests retrieved successfully",
+		"data":    returnRequests,
+	})
+}
+
+// AdminGetReturns handles GET /admin/returns
+func (h *ReturnHandler) AdminGetReturns(c *gin.Context) {
+	var req returns.ListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.returnService.ListReturns(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve return requests",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Return requests retrieved successfully",
+		"data":    result,
+	})
+}
+
+// AdminGetReturn handles GET /admin/returns/:id
+func (h *ReturnHandler) AdminGetReturn(c *gin.Context) {
+	returnID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid return request ID",
+		})
+		return
+	}
+
+	returnRequest, err := h.returnService.GetReturn(uint(returnID))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve return request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Return request retrieved successfully",
+		"data":    returnRequest,
+	})
+}
+
+// AdminApproveReturn handles PUT /admin/returns/:id/approve
+func (h *ReturnHandler) AdminApproveReturn(c *gin.Context) {
+	adminID, _ := middleware.GetUserIDFromContext(c)
+
+	returnID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid return request ID",
+		})
+		return
+	}
+
+	var req returns.ReviewReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	returnRequest, err := h.returnService.ApproveReturn(uint(returnID), adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to approve return request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Return request approved successfully",
+		"data":    returnRequest,
+	})
+}
+
+// AdminRejectReturn handles PUT /admin/returns/:id/reject
+func (h *ReturnHandler) AdminRejectReturn(c *gin.Context) {
+	adminID, _ := middleware.GetUserIDFromContext(c)
+
+	returnID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid return request ID",
+		})
+		return
+	}
+
+	var req returns.ReviewReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	returnRequest, err := h.returnService.RejectReturn(uint(returnID), adminID, &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to reject return request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Return request rejected successfully",
+		"data":    returnRequest,
+	})
+}
+
+// AdminRefundReturn handles POST /admin/returns/:id/refund
+func (h *ReturnHandler) AdminRefundReturn(c *gin.Context) {
+	adminID, _ := middleware.GetUserIDFromContext(c)
+
+	returnID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid return request ID",
+		})
+		return
+	}
+
+	returnRequest, err := h.returnService.RefundReturn(uint(returnID), adminID)
+	if err != nil {
+		h.respondError(c, err, "Failed to refund return request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Return request refunded successfully",
+		"data":    returnRequest,
+	})
+}
+
+// respondError maps return service errors to HTTP responses. When fallback is empty,
+// unrecognised errors are treated as validation failures and returned as-is.
+func (h *ReturnHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, returns.ErrReturnNotFound), errors.Is(err, returns.ErrOrderNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, returns.ErrInvalidReturnStatus),
+		errors.Is(err, returns.ErrReturnQuantityExceeded),
+		errors.Is(err, returns.ErrRefundExceedsPayment):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, returns.ErrOrderNotReturnable),
+		errors.Is(err, returns.ErrReturnWindowClosed),
+		errors.Is(err, returns.ErrReturnsDisabled),
+		errors.Is(err, returns.ErrInvalidReturnReason),
+		errors.Is(err, returns.ErrInvalidReturnItems),
+		errors.Is(err, returns.ErrNoRefundablePayment):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, payment.ErrProviderNotConfigured):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case fallback != "":
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
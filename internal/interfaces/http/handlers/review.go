@@ -2,6 +2,8 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
 
@@ -43,6 +45,12 @@ func (h *ReviewHandler) CreateReview(c *gin.Context) {
 
 	review, err := h.reviewService.CreateReview(userID, &req)
 	if err != nil {
+		if errors.Is(err, product.ErrAccountTooNew) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -74,6 +82,12 @@ func (h *ReviewHandler) GetReviews(c *gin.Context) {
 
 	response, err := h.reviewService.GetReviews(&req, currentUserID)
 	if err != nil {
+		if errors.Is(err, product.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to retrieve reviews",
 		})
@@ -191,6 +205,32 @@ func (h *ReviewHandler) DeleteReview(c *gin.Context) {
 	})
 }
 
+// GetReviewPrompts handles GET /reviews/prompts
+func (h *ReviewHandler) GetReviewPrompts(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	prompts, err := h.reviewService.GetReviewPrompts(userID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve review prompts",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Review prompts retrieved successfully",
+		"data":    prompts,
+	})
+}
+
 // GetProductReviews handles GET /products/:id/reviews
 func (h *ReviewHandler) GetProductReviews(c *gin.Context) {
 	idParam := c.Param("id")
@@ -224,6 +264,12 @@ func (h *ReviewHandler) GetProductReviews(c *gin.Context) {
 
 	response, err := h.reviewService.GetReviews(&req, currentUserID)
 	if err != nil {
+		if errors.Is(err, product.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to retrieve product reviews",
 		})
@@ -361,6 +407,12 @@ func (h *ReviewHandler) AdminGetReviews(c *gin.Context) {
 	// Admin can see all reviews including unapproved
 	response, err := h.reviewService.GetReviews(&req, nil)
 	if err != nil {
+		if errors.Is(err, product.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to retrieve reviews",
 		})
@@ -373,6 +425,44 @@ func (h *ReviewHandler) AdminGetReviews(c *gin.Context) {
 	})
 }
 
+// AdminExportReviews handles GET /admin/reviews/export
+func (h *ReviewHandler) AdminExportReviews(c *gin.Context) {
+	var req product.ReviewExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.reviewService.ValidateReviewExport(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if req.Format == "json" {
+		contentType = "application/json; charset=utf-8"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", "attachment; filename="+h.reviewService.ReviewExportFileName(req.Format))
+	c.Status(http.StatusOK)
+
+	if err := h.reviewService.ExportReviews(c.Writer, &req); err != nil {
+		// Once rows have been streamed the status can no longer change
+		if !c.Writer.Written() {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to export reviews",
+			})
+			return
+		}
+		log.Printf("Review export aborted: %v", err)
+	}
+}
+
 // AdminApproveReview handles PUT /admin/reviews/:id/approve
 func (h *ReviewHandler) AdminApproveReview(c *gin.Context) {
 	idParam := c.Param("id")
@@ -411,6 +501,84 @@ func (h *ReviewHandler) AdminApproveReview(c *gin.Context) {
 	})
 }
 
+// AdminReplyToReview handles POST /admin/reviews/:id/reply
+func (h *ReviewHandler) AdminReplyToReview(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	reviewID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid review ID",
+		})
+		return
+	}
+
+	var req product.AddReviewReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	reply, err := h.reviewService.AddReply(uint(reviewID), userID, &req)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, product.ErrReviewNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, product.ErrOfficialReplyNotAllowed):
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Reply posted successfully",
+		"data":    reply,
+	})
+}
+
+// AdminBulkReviewAction handles POST /admin/reviews/bulk-action
+func (h *ReviewHandler) AdminBulkReviewAction(c *gin.Context) {
+	var req product.AdminBulkReviewActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.reviewService.AdminBulkReviewAction(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	message := "Reviews approved successfully"
+	if req.Action == "reject" {
+		message = "Reviews rejected successfully"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    result,
+	})
+}
+
 // AdminGetReportedReviews handles GET /admin/reviews/reported
 func (h *ReviewHandler) AdminGetReportedReviews(c *gin.Context) {
 	var req product.ReviewListRequest
@@ -441,3 +609,106 @@ func (h *ReviewHandler) AdminGetReportedReviews(c *gin.Context) {
 		"data":    response,
 	})
 }
+
+// AdminGetReviewReports handles GET /admin/reviews/reports
+func (h *ReviewHandler) AdminGetReviewReports(c *gin.Context) {
+	var req product.ReviewReportListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	response, err := h.reviewService.GetReviewReports(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve review reports",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Review reports retrieved successfully",
+		"data":    response,
+	})
+}
+
+// AdminGetReviewWithReports handles GET /admin/reviews/:id/reports
+func (h *ReviewHandler) AdminGetReviewWithReports(c *gin.Context) {
+	reviewID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid review ID",
+		})
+		return
+	}
+
+	response, err := h.reviewService.GetReportedReview(uint(reviewID))
+	if err != nil {
+		if errors.Is(err, product.ErrReviewNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve review reports",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Review reports retrieved successfully",
+		"data":    response,
+	})
+}
+
+// AdminResolveReviewReport handles POST /admin/reviews/reports/:reportId/resolve
+func (h *ReviewHandler) AdminResolveReviewReport(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	reportID, err := strconv.ParseUint(c.Param("reportId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid report ID",
+		})
+		return
+	}
+
+	var req product.ResolveReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	response, err := h.reviewService.ResolveReport(uint(reportID), userID, &req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, product.ErrReportNotFound), errors.Is(err, product.ErrReviewNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, product.ErrReportAlreadyResolved):
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Review report resolved successfully",
+		"data":    response,
+	})
+}
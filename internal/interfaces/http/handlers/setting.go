@@ -0,0 +1,174 @@
+// internal/interfaces/http/handlers/setting.go
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/setting"
+	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
+	"gorm.io/gorm"
+)
+
+// SettingHandler handles admin store settings endpoints
+type SettingHandler struct {
+	settingService *setting.Service
+	config         *config.Config
+}
+
+// NewSettingHandler creates a new setting handler
+func NewSettingHandler(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *SettingHandler {
+	return &SettingHandler{
+		settingService: setting.NewService(db, redisClient, cfg),
+		config:         cfg,
+	}
+}
+
+// AdminGetSettings handles GET /admin/settings
+func (h *SettingHandler) AdminGetSettings(c *gin.Context) {
+	settings, err := h.settingService.GetAllSettings()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve settings",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Settings retrieved successfully",
+		"data":    settings,
+	})
+}
+
+// AdminUpdateSettings handles PUT /admin/settings
+func (h *SettingHandler) AdminUpdateSettings(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req setting.GeneralSettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	settings, err := h.settingService.UpdateGeneralSettings(&req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Settings updated successfully",
+		"data":    settings,
+	})
+}
+
+// AdminGetShippingSettings handles GET /admin/settings/shipping
+func (h *SettingHandler) AdminGetShippingSettings(c *gin.Context) {
+	settings, err := h.settingService.GetShippingSettings()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve shipping settings",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shipping settings retrieved successfully",
+		"data":    settings,
+	})
+}
+
+// AdminUpdateShippingSettings handles PUT /admin/settings/shipping
+func (h *SettingHandler) AdminUpdateShippingSettings(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req setting.ShippingSettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	settings, err := h.settingService.UpdateShippingSettings(&req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shipping settings updated successfully",
+		"data":    settings,
+	})
+}
+
+// AdminGetPaymentSettings handles GET /admin/settings/payment
+func (h *SettingHandler) AdminGetPaymentSettings(c *gin.Context) {
+	settings, err := h.settingService.GetPaymentSettings()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve payment settings",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Payment settings retrieved successfully",
+		"data":    settings,
+	})
+}
+
+// AdminUpdatePaymentSettings handles PUT /admin/settings/payment
+func (h *SettingHandler) AdminUpdatePaymentSettings(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req setting.PaymentSettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	settings, err := h.settingService.UpdatePaymentSettings(&req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Payment settings updated successfully",
+		"data":    settings,
+	})
+}
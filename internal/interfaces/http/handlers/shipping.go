@@ -0,0 +1,270 @@
+// internal/interfaces/http/handlers/shipping.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/setting"
+	"github.com/your-org/ecommerce-backend/internal/domain/shipping"
+	"gorm.io/gorm"
+)
+
+// ShippingHandler handles admin shipping method and zone endpoints
+type ShippingHandler struct {
+	shippingService *shipping.Service
+	config          *config.Config
+}
+
+// NewShippingHandler creates a new shipping handler
+func NewShippingHandler(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *ShippingHandler {
+	return &ShippingHandler{
+		shippingService: shipping.NewService(db, setting.NewService(db, redisClient, cfg)),
+		config:          cfg,
+	}
+}
+
+// AdminGetMethods handles GET /admin/shipping/methods
+func (h *ShippingHandler) AdminGetMethods(c *gin.Context) {
+	methods, err := h.shippingService.ListMethods()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve shipping methods",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shipping methods retrieved successfully",
+		"data":    methods,
+	})
+}
+
+// AdminGetMethod handles GET /admin/shipping/methods/:id
+func (h *ShippingHandler) AdminGetMethod(c *gin.Context) {
+	methodID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid shipping method ID",
+		})
+		return
+	}
+
+	method, err := h.shippingService.GetMethod(uint(methodID))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve shipping method")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shipping method retrieved successfully",
+		"data":    method,
+	})
+}
+
+// AdminCreateMethod handles POST /admin/shipping/methods
+func (h *ShippingHandler) AdminCreateMethod(c *gin.Context) {
+	var req shipping.ShippingMethodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	method, err := h.shippingService.CreateMethod(&req)
+	if err != nil {
+		h.respondError(c, err, "")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Shipping method created successfully",
+		"data":    method,
+	})
+}
+
+// AdminUpdateMethod handles PUT /admin/shipping/methods/:id
+func (h *ShippingHandler) AdminUpdateMethod(c *gin.Context) {
+	methodID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid shipping method ID",
+		})
+		return
+	}
+
+	var req shipping.ShippingMethodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	method, err := h.shippingService.UpdateMethod(uint(methodID), &req)
+	if err != nil {
+		h.respondError(c, err, "")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shipping method updated successfully",
+		"data":    method,
+	})
+}
+
+// AdminDeleteMethod handles DELETE /admin/shipping/methods/:id
+func (h *ShippingHandler) AdminDeleteMethod(c *gin.Context) {
+	methodID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid shipping method ID",
+		})
+		return
+	}
+
+	if err := h.shippingService.DeleteMethod(uint(methodID)); err != nil {
+		h.respondError(c, err, "Failed to delete shipping method")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shipping method deleted successfully",
+	})
+}
+
+// AdminGetZones handles GET /admin/shipping/zones
+func (h *ShippingHandler) AdminGetZones(c *gin.Context) {
+	zones, err := h.shippingService.ListZones()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve shipping zones",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shipping zones retrieved successfully",
+		"data":    zones,
+	})
+}
+
+// AdminGetZone handles GET /admin/shipping/zones/:id
+func (h *ShippingHandler) AdminGetZone(c *gin.Context) {
+	zoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid shipping zone ID",
+		})
+		return
+	}
+
+	zone, err := h.shippingService.GetZone(uint(zoneID))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve shipping zone")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shipping zone retrieved successfully",
+		"data":    zone,
+	})
+}
+
+// AdminCreateZone handles POST /admin/shipping/zones
+func (h *ShippingHandler) AdminCreateZone(c *gin.Context) {
+	var req shipping.ShippingZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	zone, err := h.shippingService.CreateZone(&req)
+	if err != nil {
+		h.respondError(c, err, "")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Shipping zone created successfully",
+		"data":    zone,
+	})
+}
+
+// AdminUpdateZone handles PUT /admin/shipping/zones/:id
+func (h *ShippingHandler) AdminUpdateZone(c *gin.Context) {
+	zoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid shipping zone ID",
+		})
+		return
+	}
+
+	var req shipping.ShippingZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	zone, err := h.shippingService.UpdateZone(uint(zoneID), &req)
+	if err != nil {
+		h.respondError(c, err, "")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shipping zone updated successfully",
+		"data":    zone,
+	})
+}
+
+// AdminDeleteZone handles DELETE /admin/shipping/zones/:id
+func (h *ShippingHandler) AdminDeleteZone(c *gin.Context) {
+	zoneID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid shipping zone ID",
+		})
+		return
+	}
+
+	if err := h.shippingService.DeleteZone(uint(zoneID)); err != nil {
+		h.respondError(c, err, "Failed to delete shipping zone")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shipping zone deleted successfully",
+	})
+}
+
+// respondError maps shipping service errors to HTTP responses. When fallback is empty,
+// unrecognised errors are treated as validation failures and returned as-is.
+func (h *ShippingHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, shipping.ErrMethodNotFound), errors.Is(err, shipping.ErrZoneNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, shipping.ErrMethodCodeExists),
+		errors.Is(err, shipping.ErrZoneNameExists),
+		errors.Is(err, shipping.ErrZoneInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case fallback != "":
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
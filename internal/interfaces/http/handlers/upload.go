@@ -2,6 +2,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"path/filepath"
@@ -9,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"github.com/your-org/ecommerce-backend/internal/domain/upload"
 	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
@@ -22,9 +24,9 @@ type UploadHandler struct {
 }
 
 // NewUploadHandler creates a new upload handler
-func NewUploadHandler(db *gorm.DB, cfg *config.Config) *UploadHandler {
+func NewUploadHandler(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *UploadHandler {
 	return &UploadHandler{
-		uploadService: upload.NewService(db, cfg),
+		uploadService: upload.NewService(db, redisClient, cfg),
 		config:        cfg,
 	}
 }
@@ -84,6 +86,14 @@ func (h *UploadHandler) UploadImage(c *gin.Context) {
 		return
 	}
 
+	if uploadedImage.Deduplicated {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Identical image already uploaded",
+			"data":    uploadedImage,
+		})
+		return
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Image uploaded successfully",
 		"data":    uploadedImage,
@@ -120,7 +130,7 @@ func (h *UploadHandler) UploadMultipleImages(c *gin.Context) {
 	}
 
 	// Limit number of files
-	maxFiles := 20
+	maxFiles := h.config.Upload.BulkMaxFiles
 	if len(files) > maxFiles {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": fmt.Sprintf("Too many files. Maximum %d files allowed", maxFiles),
@@ -140,18 +150,40 @@ func (h *UploadHandler) UploadMultipleImages(c *gin.Context) {
 		UploadedBy:  userID,
 	}
 
-	// Upload the images
-	result, err := h.uploadService.BulkUploadImages(req)
+	// Files are processed in the background; progress is polled via the job endpoint
+	job, err := h.uploadService.StartBulkUpload(req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"message": "Bulk upload completed",
-		"data":    result,
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Bulk upload queued",
+		"data":    job,
+	})
+}
+
+// GetBulkUploadJob handles GET /admin/uploads/jobs/:id
+func (h *UploadHandler) GetBulkUploadJob(c *gin.Context) {
+	job, err := h.uploadService.GetBulkUploadJob(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, upload.ErrBulkJobNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Bulk upload job not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve bulk upload job",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Bulk upload job retrieved successfully",
+		"data":    job,
 	})
 }
 
@@ -345,9 +377,10 @@ func (h *UploadHandler) OptimizeImage(c *gin.Context) {
 	}
 
 	var req struct {
-		Quality int `json:"quality" binding:"min=1,max=100"`
-		Width   int `json:"width,omitempty"`
-		Height  int `json:"height,omitempty"`
+		Quality int    `json:"quality" binding:"min=1,max=100"`
+		Width   int    `json:"width,omitempty"`
+		Height  int    `json:"height,omitempty"`
+		Format  string `json:"format,omitempty"` // jpeg, png, webp
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -362,6 +395,7 @@ func (h *UploadHandler) OptimizeImage(c *gin.Context) {
 		Quality: req.Quality,
 		Width:   req.Width,
 		Height:  req.Height,
+		Format:  req.Format,
 	}
 
 	result, err := h.uploadService.OptimizeImage(uint(imageID), userID, optimizeReq)
@@ -372,6 +406,14 @@ func (h *UploadHandler) OptimizeImage(c *gin.Context) {
 		return
 	}
 
+	if result.ProcessingStatus == upload.ProcessingStatusPending {
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "Image optimization queued",
+			"data":    result,
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Image optimized successfully",
 		"data":    result,
@@ -427,7 +469,10 @@ func (h *UploadHandler) GetUploadConfig(c *gin.Context) {
 		"thumbnail_height":     h.config.Upload.ThumbnailHeight,
 		"storage_provider":     h.config.External.Storage.Provider,
 		"supported_categories": []string{"product", "category", "brand", "user", "general"},
-		"max_bulk_files":       20,
+		"max_bulk_files":       h.config.Upload.BulkMaxFiles,
+		"async_processing":     h.config.Upload.AsyncProcessing,
+		"strip_exif":           h.config.Upload.StripEXIF,
+		"output_formats":       upload.SupportedOutputFormats,
 	}
 
 	c.JSON(http.StatusOK, gin.H{
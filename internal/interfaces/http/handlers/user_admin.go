@@ -2,11 +2,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/customer"
 	"github.com/your-org/ecommerce-backend/internal/domain/user"
 	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
 	"gorm.io/gorm"
@@ -14,15 +17,17 @@ import (
 
 // UserAdminHandler handles admin user management endpoints
 type UserAdminHandler struct {
-	adminService *user.AdminService
-	config       *config.Config
+	adminService    *user.AdminService
+	customerService *customer.Service
+	config          *config.Config
 }
 
 // NewUserAdminHandler creates a new user admin handler
-func NewUserAdminHandler(db *gorm.DB, cfg *config.Config) *UserAdminHandler {
+func NewUserAdminHandler(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *UserAdminHandler {
 	return &UserAdminHandler{
-		adminService: user.NewAdminService(db, cfg),
-		config:       cfg,
+		adminService:    user.NewAdminService(db, redisClient, cfg),
+		customerService: customer.NewService(db, redisClient, cfg),
+		config:          cfg,
 	}
 }
 
@@ -84,6 +89,46 @@ func (h *UserAdminHandler) GetUser(c *gin.Context) {
 	})
 }
 
+// GetCustomerProfile handles GET /admin/users/:id/profile
+func (h *UserAdminHandler) GetCustomerProfile(c *gin.Context) {
+	adminID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	idParam := c.Param("id")
+	userID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	profile, err := h.customerService.GetProfile(uint(userID), adminID)
+	if err != nil {
+		if errors.Is(err, customer.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve customer profile",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Customer profile retrieved successfully",
+		"data":    profile,
+	})
+}
+
 // UpdateUserStatus handles PUT /admin/users/:id/status
 func (h *UserAdminHandler) UpdateUserStatus(c *gin.Context) {
 	adminID, exists := middleware.GetUserIDFromContext(c)
@@ -217,3 +262,130 @@ func (h *UserAdminHandler) ExportUsers(c *gin.Context) {
 
 	c.Data(http.StatusOK, contentType, data)
 }
+
+// ExportUserData handles GET /admin/users/:id/data-export
+func (h *UserAdminHandler) ExportUserData(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	data, filename, err := h.adminService.ExportUserData(uint(userID))
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to export user data: " + err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", "application/json")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Content-Length", strconv.Itoa(len(data)))
+
+	c.Data(http.StatusOK, "application/json", data)
+}
+
+// AnonymizeUser handles DELETE /admin/users/:id
+func (h *UserAdminHandler) AnonymizeUser(c *gin.Context) {
+	adminID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Admin not authenticated",
+		})
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	if err := h.adminService.AnonymizeUser(uint(userID), adminID); err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		case errors.Is(err, user.ErrCannotAnonymize):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to anonymize user"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User data anonymized successfully",
+	})
+}
+
+// ImpersonateUser handles POST /admin/users/:id/impersonate
+func (h *UserAdminHandler) ImpersonateUser(c *gin.Context) {
+	adminID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Admin not authenticated",
+		})
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	response, err := h.adminService.ImpersonateUser(uint(userID), adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		case errors.Is(err, user.ErrCannotImpersonate):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to impersonate user"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Impersonation token issued successfully",
+		"data":    response,
+	})
+}
+
+// UnlockUser handles POST /admin/users/:id/unlock
+func (h *UserAdminHandler) UnlockUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	if err := h.adminService.UnlockUser(uint(userID)); err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to unlock user",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User unlocked successfully",
+	})
+}
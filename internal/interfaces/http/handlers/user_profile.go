@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"github.com/your-org/ecommerce-backend/internal/domain/user"
 	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
@@ -21,9 +22,9 @@ type UserProfileHandler struct {
 }
 
 // NewUserProfileHandler creates a new user profile handler
-func NewUserProfileHandler(db *gorm.DB, cfg *config.Config) *UserProfileHandler {
+func NewUserProfileHandler(db *gorm.DB, redisClient *redis.Client, cfg *config.Config) *UserProfileHandler {
 	return &UserProfileHandler{
-		userService: user.NewService(db, cfg),
+		userService: user.NewService(db, redisClient, cfg),
 		config:      cfg,
 		db:          db,
 	}
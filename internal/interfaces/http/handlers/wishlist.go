@@ -2,6 +2,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -349,3 +350,77 @@ func (h *WishlistHandler) GetWishlistSummary(c *gin.Context) {
 		"data":    summary,
 	})
 }
+
+// ShareWishlist handles POST /wishlist/share
+func (h *WishlistHandler) ShareWishlist(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	share, err := h.wishlistService.ShareWishlist(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create share link",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Wishlist share link created successfully",
+		"data":    share,
+	})
+}
+
+// RevokeWishlistShare handles DELETE /wishlist/share
+func (h *WishlistHandler) RevokeWishlistShare(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.wishlistService.RevokeWishlistShare(userID); err != nil {
+		if errors.Is(err, wishlist.ErrShareNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to revoke share link",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Wishlist share link revoked successfully",
+	})
+}
+
+// GetSharedWishlist handles GET /wishlist/shared/:token
+func (h *WishlistHandler) GetSharedWishlist(c *gin.Context) {
+	shared, err := h.wishlistService.GetSharedWishlist(c.Param("token"))
+	if err != nil {
+		if errors.Is(err, wishlist.ErrShareNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve shared wishlist",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shared wishlist retrieved successfully",
+		"data":    shared,
+	})
+}
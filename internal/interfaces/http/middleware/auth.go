@@ -2,16 +2,19 @@
 package middleware
 
 import (
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"github.com/your-org/ecommerce-backend/internal/pkg/auth"
 )
 
-// AuthMiddleware creates JWT authentication middleware
-func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
-	jwtManager := auth.NewJWTManager(cfg)
+// AuthMiddleware creates JWT authentication middleware. Tokens revoked through the
+// Redis token store are rejected.
+func AuthMiddleware(cfg *config.Config, redisClient *redis.Client) gin.HandlerFunc {
+	jwtManager := auth.NewJWTManager(cfg).WithTokenStore(auth.NewTokenStore(redisClient))
 
 	return func(c *gin.Context) {
 		// Get Authorization header
@@ -49,6 +52,7 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 		c.Set("user_email", claims.Email)
 		c.Set("is_admin", claims.IsAdmin)
 		c.Set("token_claims", claims)
+		setImpersonation(c, claims)
 
 		c.Next()
 	}
@@ -66,7 +70,7 @@ func AdminMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		if !isAdmin.(bool) {
+		if !isAdmin.(bool) || IsImpersonatedFromContext(c) {
 			c.JSON(http.StatusForbidden, gin.H{
 				"error": "Admin access required",
 			})
@@ -79,8 +83,8 @@ func AdminMiddleware() gin.HandlerFunc {
 }
 
 // OptionalAuthMiddleware provides optional authentication
-func OptionalAuthMiddleware(cfg *config.Config) gin.HandlerFunc {
-	jwtManager := auth.NewJWTManager(cfg)
+func OptionalAuthMiddleware(cfg *config.Config, redisClient *redis.Client) gin.HandlerFunc {
+	jwtManager := auth.NewJWTManager(cfg).WithTokenStore(auth.NewTokenStore(redisClient))
 
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -110,6 +114,7 @@ func OptionalAuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 		c.Set("user_email", claims.Email)
 		c.Set("is_admin", claims.IsAdmin)
 		c.Set("token_claims", claims)
+		setImpersonation(c, claims)
 
 		c.Next()
 	}
@@ -124,6 +129,15 @@ func GetUserIDFromContext(c *gin.Context) (uint, bool) {
 	return userID.(uint), true
 }
 
+// GetTokenClaimsFromContext returns the claims of the token the request was authenticated with
+func GetTokenClaimsFromContext(c *gin.Context) (*auth.Claims, bool) {
+	claims, exists := c.Get("token_claims")
+	if !exists {
+		return nil, false
+	}
+	return claims.(*auth.Claims), true
+}
+
 // GetUserEmailFromContext extracts user email from gin context
 func GetUserEmailFromContext(c *gin.Context) (string, bool) {
 	email, exists := c.Get("user_email")
@@ -141,3 +155,45 @@ func IsAdminFromContext(c *gin.Context) bool {
 	}
 	return isAdmin.(bool)
 }
+
+// setImpersonation records the impersonating admin in the context and logs the
+// request under the admin's ID
+func setImpersonation(c *gin.Context, claims *auth.Claims) {
+	if !claims.IsImpersonation() {
+		return
+	}
+	c.Set("impersonated_by", *claims.ImpersonatedBy)
+	log.Printf("Impersonation: admin %d acting as user %d: %s %s",
+		*claims.ImpersonatedBy, claims.UserID, c.Request.Method, c.Request.URL.Path)
+}
+
+// ImpersonationGuard rejects requests made with an impersonation token, for
+// sensitive account operations such as changing the password
+func ImpersonationGuard() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if IsImpersonatedFromContext(c) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "This action is not allowed while impersonating a user",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// GetImpersonatorFromContext returns the admin ID behind an impersonation token
+func GetImpersonatorFromContext(c *gin.Context) (uint, bool) {
+	adminID, exists := c.Get("impersonated_by")
+	if !exists {
+		return 0, false
+	}
+	return adminID.(uint), true
+}
+
+// IsImpersonatedFromContext checks if the request uses an impersonation token
+func IsImpersonatedFromContext(c *gin.Context) bool {
+	_, exists := c.Get("impersonated_by")
+	return exists
+}
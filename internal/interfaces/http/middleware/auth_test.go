@@ -0,0 +1,100 @@
+// internal/interfaces/http/middleware/auth_test.go
+package middleware
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/pkg/auth"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+// newImpersonationTestRouter routes an admin page, a guarded account action and an
+// ordinary customer page behind AuthMiddleware, as the API does
+func newImpersonationTestRouter(t *testing.T) (*gin.Engine, *auth.JWTManager) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	redisClient, _ := testutil.NewRedis(t)
+	cfg := &config.Config{}
+	cfg.JWT.Secret = "test-secret"
+	cfg.JWT.AccessTokenExpiry = 15 * time.Minute
+	cfg.JWT.ImpersonationTokenExpiry = 15 * time.Minute
+
+	ok := func(c *gin.Context) {
+		adminID, _ := GetImpersonatorFromContext(c)
+		userID, _ := GetUserIDFromContext(c)
+		c.JSON(http.StatusOK, gin.H{"user_id": userID, "impersonated_by": adminID})
+	}
+	router := gin.New()
+	router.GET("/admin/users", AuthMiddleware(cfg, redisClient), AdminMiddleware(), ok)
+	router.PUT("/auth/change-password", AuthMiddleware(cfg, redisClient), ImpersonationGuard(), ok)
+	router.GET("/orders", AuthMiddleware(cfg, redisClient), ok)
+	return router, auth.NewJWTManager(cfg).WithTokenStore(auth.NewTokenStore(redisClient))
+}
+
+func requestWithToken(router *gin.Engine, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestImpersonationTokenRestrictions(t *testing.T) {
+	router, jwtManager := newImpersonationTestRouter(t)
+	adminToken, _ := jwtManager.GenerateAccessToken(9, "admin@example.com", true, "session-1")
+	impersonationToken, _ := jwtManager.GenerateImpersonationToken(5, "customer@example.com", 9)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		{"admin on admin routes", http.MethodGet, "/admin/users", adminToken, http.StatusOK},
+		{"impersonation on admin routes", http.MethodGet, "/admin/users", impersonationToken, http.StatusForbidden},
+		{"impersonation on guarded routes", http.MethodPut, "/auth/change-password", impersonationToken, http.StatusForbidden},
+		{"impersonation on customer routes", http.MethodGet, "/orders", impersonationToken, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := requestWithToken(router, tt.method, tt.path, tt.token); w.Code != tt.want {
+				t.Errorf("%s %s status = %d, want %d: %s", tt.method, tt.path, w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestImpersonatedRequestsAreLogged(t *testing.T) {
+	router, jwtManager := newImpersonationTestRouter(t)
+	impersonationToken, _ := jwtManager.GenerateImpersonationToken(5, "customer@example.com", 9)
+	customerToken, _ := jwtManager.GenerateAccessToken(5, "customer@example.com", false, "session-1")
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	w := requestWithToken(router, http.MethodGet, "/orders", impersonationToken)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"impersonated_by":9`) {
+		t.Fatalf("response = %d %s, want 200 with impersonated_by 9", w.Code, w.Body)
+	}
+	if !strings.Contains(logs.String(), "admin 9 acting as user 5: GET /orders") {
+		t.Errorf("log = %q, want the impersonating admin recorded", logs.String())
+	}
+
+	logs.Reset()
+	requestWithToken(router, http.MethodGet, "/orders", customerToken)
+	if strings.Contains(logs.String(), "Impersonation") {
+		t.Errorf("customer's own request logged as impersonation: %q", logs.String())
+	}
+}
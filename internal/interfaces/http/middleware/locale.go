@@ -0,0 +1,34 @@
+// internal/interfaces/http/middleware/locale.go
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/pkg/i18n"
+)
+
+// Locale resolves the response locale from the lang query param or the
+// Accept-Language header, falling back to the configured default
+func Locale(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.ResolveLocale(
+			c.Query("lang"),
+			c.GetHeader("Accept-Language"),
+			cfg.App.SupportedLocales,
+			cfg.App.DefaultLocale,
+		)
+
+		c.Set("locale", locale)
+		c.Header("Content-Language", locale)
+		c.Next()
+	}
+}
+
+// GetLocaleFromContext extracts the response locale from gin context
+func GetLocaleFromContext(c *gin.Context) string {
+	locale, exists := c.Get("locale")
+	if !exists {
+		return i18n.DefaultLocale
+	}
+	return locale.(string)
+}
@@ -3,6 +3,8 @@ package middleware
 import (
 	"context"
 	"fmt"
+	"log"
+	"math"
 	"net/http"
 	"strconv"
 	"time"
@@ -10,53 +12,177 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/pkg/auth"
 )
 
-// RateLimit implements rate limiting using Redis
+// RateLimitRule is a token bucket: it holds Burst requests and refills at PerMinute.
+// Signed-in users are keyed by user ID and get the User values when set.
+type RateLimitRule struct {
+	Name          string // Keeps each rule's buckets apart
+	PerMinute     int
+	Burst         int
+	UserPerMinute int
+	UserBurst     int
+}
+
+// DefaultRateLimitRule is the site-wide limit applied to every request
+func DefaultRateLimitRule(cfg *config.Config) RateLimitRule {
+	return RateLimitRule{
+		Name:          "default",
+		PerMinute:     cfg.Security.RateLimitPerMinute,
+		Burst:         cfg.Security.RateLimitBurst,
+		UserPerMinute: cfg.Security.RateLimitUserPerMinute,
+		UserBurst:     cfg.Security.RateLimitUserBurst,
+	}
+}
+
+// AuthRateLimitRule is the stricter limit for login, registration and password resets
+func AuthRateLimitRule(cfg *config.Config) RateLimitRule {
+	return RateLimitRule{
+		Name:      "auth",
+		PerMinute: cfg.Security.RateLimitAuthPerMinute,
+		Burst:     cfg.Security.RateLimitAuthBurst,
+	}
+}
+
+// SearchRateLimitRule is the stricter limit for product search
+func SearchRateLimitRule(cfg *config.Config) RateLimitRule {
+	return RateLimitRule{
+		Name:          "search",
+		PerMinute:     cfg.Security.RateLimitSearchPerMinute,
+		Burst:         cfg.Security.RateLimitSearchBurst,
+		UserPerMinute: cfg.Security.RateLimitSearchPerMinute * 2,
+		UserBurst:     cfg.Security.RateLimitSearchBurst * 2,
+	}
+}
+
+// OrderLookupRateLimitRule limits guest order lookups, which would otherwise allow
+// guessing order numbers and emails. It uses the auth limits.
+func OrderLookupRateLimitRule(cfg *config.Config) RateLimitRule {
+	return RateLimitRule{
+		Name:      "order-lookup",
+		PerMinute: cfg.Security.RateLimitAuthPerMinute,
+		Burst:     cfg.Security.RateLimitAuthBurst,
+	}
+}
+
+// tokenBucketScript refills the bucket for the time since it was last touched, then
+// takes a token if one is available. Returns {allowed, tokens left}; tokens are
+// returned as a string because Redis truncates Lua numbers to integers.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RateLimit applies the site-wide rate limit using Redis
 func RateLimit(cfg *config.Config, redisClient *redis.Client) gin.HandlerFunc {
+	return RateLimitWithRule(cfg, redisClient, DefaultRateLimitRule(cfg))
+}
+
+// RateLimitWithRule limits requests with a token bucket per client IP, or per user
+// for requests with a valid, unrevoked access token. It adds a route's own limit on top of the
+// site-wide one, e.g. group.Use(middleware.RateLimitWithRule(cfg, rdb, rule)).
+func RateLimitWithRule(cfg *config.Config, redisClient *redis.Client, rule RateLimitRule) gin.HandlerFunc {
+	// Revoked tokens must not keep getting the signed-in user's higher limits
+	jwtManager := auth.NewJWTManager(cfg).WithTokenStore(auth.NewTokenStore(redisClient))
+
 	return func(c *gin.Context) {
-		// Get client IP
-		clientIP := c.ClientIP()
+		if redisClient == nil {
+			c.Next()
+			return
+		}
 
-		// Create rate limit key
-		key := fmt.Sprintf("rate_limit:%s", clientIP)
+		perMinute, burst := rule.PerMinute, rule.Burst
+		key := fmt.Sprintf("rate_limit:%s:ip:%s", rule.Name, c.ClientIP())
+		if userID, ok := rateLimitUserID(c, jwtManager); ok {
+			key = fmt.Sprintf("rate_limit:%s:user:%d", rule.Name, userID)
+			if rule.UserPerMinute > 0 {
+				perMinute, burst = rule.UserPerMinute, rule.UserBurst
+			}
+		}
+		if perMinute <= 0 {
+			c.Next()
+			return
+		}
+		if burst <= 0 {
+			burst = perMinute
+		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 		defer cancel()
 
-		// Get current count
-		current, err := redisClient.Get(ctx, key).Int()
-		if err != nil && err.Error() != "redis: nil" {
+		rate := float64(perMinute) / 60 // Tokens per second
+		result, err := tokenBucketScript.Run(ctx, redisClient, []string{key},
+			rate, burst, time.Now().UnixMilli()).Slice()
+		if err != nil || len(result) != 2 {
 			// If Redis is down, allow the request
+			log.Printf("Rate limit check failed for %s: %v", key, err)
 			c.Next()
 			return
 		}
 
-		// Check if limit exceeded
-		if current >= cfg.Security.RateLimitPerMinute {
+		allowed, _ := result[0].(int64)
+		tokensLeft, _ := result[1].(string)
+		tokens, _ := strconv.ParseFloat(tokensLeft, 64)
+
+		// Reset is when the bucket will be full again
+		untilFull := time.Duration((float64(burst) - tokens) / rate * float64(time.Second))
+		c.Header("X-RateLimit-Limit", strconv.Itoa(burst))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(int(math.Floor(tokens))))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(untilFull).Unix(), 10))
+
+		if allowed != 1 {
+			retryAfter := int(math.Ceil((1 - tokens) / rate))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
 			c.JSON(http.StatusTooManyRequests, gin.H{
 				"error":       "Rate limit exceeded",
-				"retry_after": 60,
+				"retry_after": retryAfter,
 			})
 			c.Abort()
 			return
 		}
 
-		// Increment counter
-		pipe := redisClient.Pipeline()
-		pipe.Incr(ctx, key)
-		pipe.Expire(ctx, key, time.Minute)
-		_, err = pipe.Exec(ctx)
-		if err != nil {
-			// If Redis operation fails, log but allow request
-			// In production, you might want to handle this differently
-		}
+		c.Next()
+	}
+}
 
-		// Add rate limit headers
-		c.Header("X-RateLimit-Limit", strconv.Itoa(cfg.Security.RateLimitPerMinute))
-		c.Header("X-RateLimit-Remaining", strconv.Itoa(cfg.Security.RateLimitPerMinute-current-1))
-		c.Header("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+// rateLimitUserID returns the signed-in user, from the auth middleware when it has
+// already run or from the access token otherwise
+func rateLimitUserID(c *gin.Context, jwtManager *auth.JWTManager) (uint, bool) {
+	if userID, ok := GetUserIDFromContext(c); ok {
+		return userID, true
+	}
 
-		c.Next()
+	tokenString := auth.ExtractTokenFromHeader(c.GetHeader("Authorization"))
+	if tokenString == "" {
+		return 0, false
+	}
+	claims, err := jwtManager.ValidateAccessToken(tokenString)
+	if err != nil {
+		return 0, false
 	}
+	return claims.UserID, true
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/domain/product"
 	"github.com/your-org/ecommerce-backend/internal/interfaces/http/handlers"
 	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
 	"gorm.io/gorm"
@@ -22,6 +23,8 @@ func SetupRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client, cf
 	SetupPaymentRoutes(rg, db, redisClient, cfg)
 	SetupAdminRoutes(rg, db, redisClient, cfg)
 	SetupInventoryRoutes(rg, db, redisClient, cfg)
+	SetupPolicyRoutes(rg, db, redisClient, cfg)
+	SetupReviewRoutes(rg, db, redisClient, cfg)
 
 }
 
@@ -39,7 +42,7 @@ func SetupInventoryRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.C
 
 	// Protected inventory endpoints (require authentication)
 	inventoryAuth := rg.Group("/inventory")
-	inventoryAuth.Use(middleware.AuthMiddleware(cfg))
+	inventoryAuth.Use(middleware.AuthMiddleware(cfg, redisClient))
 	{
 		inventoryAuth.POST("/reserve", inventoryHandler.ReserveStock)
 		inventoryAuth.POST("/release", inventoryHandler.ReleaseReservation)
@@ -47,31 +50,104 @@ func SetupInventoryRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.C
 	}
 }
 
+// SetupPolicyRoutes sets up public store policy routes
+func SetupPolicyRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client, cfg *config.Config) {
+	policyHandler := handlers.NewPolicyHandler(db, cfg)
+
+	policies := rg.Group("/policies")
+	{
+		policies.GET("", policyHandler.GetPolicies)     // GET /policies?types=shipping,returns
+		policies.GET("/:type", policyHandler.GetPolicy) // GET /policies/:type
+	}
+}
+
+// SetupReviewRoutes sets up product review routes
+func SetupReviewRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client, cfg *config.Config) {
+	reviewHandler := handlers.NewReviewHandler(product.NewReviewService(db, redisClient, cfg))
+
+	// Public review endpoints
+	reviews := rg.Group("/reviews")
+	reviews.Use(middleware.OptionalAuthMiddleware(cfg, redisClient))
+	{
+		reviews.GET("", reviewHandler.GetReviews)
+		reviews.GET("/:id", reviewHandler.GetReview)
+	}
+
+	productReviews := rg.Group("/products/:id/reviews")
+	productReviews.Use(middleware.OptionalAuthMiddleware(cfg, redisClient))
+	{
+		productReviews.GET("", reviewHandler.GetProductReviews)
+		productReviews.GET("/summary", reviewHandler.GetProductReviewSummary)
+	}
+
+	// Protected review endpoints
+	reviewsAuth := rg.Group("/reviews")
+	reviewsAuth.Use(middleware.AuthMiddleware(cfg, redisClient))
+	{
+		reviewsAuth.GET("/prompts", reviewHandler.GetReviewPrompts)
+		reviewsAuth.POST("", reviewHandler.CreateReview)
+		reviewsAuth.PUT("/:id", reviewHandler.UpdateReview)
+		reviewsAuth.DELETE("/:id", reviewHandler.DeleteReview)
+		reviewsAuth.POST("/:id/helpful", reviewHandler.VoteHelpful)
+		reviewsAuth.POST("/:id/report", reviewHandler.ReportReview)
+	}
+
+	// Product Q&A
+	productQuestions := rg.Group("/products/:id/questions")
+	productQuestions.Use(middleware.OptionalAuthMiddleware(cfg, redisClient))
+	{
+		productQuestions.GET("", reviewHandler.GetProductQuestions)
+	}
+
+	productQuestionsAuth := rg.Group("/products/:id/questions")
+	productQuestionsAuth.Use(middleware.AuthMiddleware(cfg, redisClient))
+	{
+		productQuestionsAuth.POST("", reviewHandler.AskQuestion)
+	}
+
+	questionsAuth := rg.Group("")
+	questionsAuth.Use(middleware.AuthMiddleware(cfg, redisClient))
+	{
+		questionsAuth.POST("/questions/:id/answers", reviewHandler.AnswerQuestion)
+		questionsAuth.POST("/answers/:id/helpful", reviewHandler.VoteAnswerHelpful)
+	}
+}
+
 // SetupAuthRoutes sets up authentication related routes
 func SetupAuthRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client, cfg *config.Config) {
 	authHandler := handlers.NewAuthHandler(db, redisClient, cfg)
 
+	// Stricter limit on endpoints that guess credentials or send email
+	authLimit := middleware.RateLimitWithRule(cfg, redisClient, middleware.AuthRateLimitRule(cfg))
+
 	auth := rg.Group("/auth")
 	{
 		// Public auth endpoints
-		auth.POST("/register", authHandler.Register)
-		auth.POST("/login", authHandler.Login)
+		auth.POST("/register", authLimit, authHandler.Register)
+		auth.POST("/login", authLimit, authHandler.Login)
 		auth.POST("/refresh", authHandler.RefreshToken)
-		auth.POST("/forgot-password", authHandler.ForgotPassword)
-		auth.POST("/reset-password", authHandler.ResetPassword)
+		auth.POST("/forgot-password", authLimit, authHandler.ForgotPassword)
+		auth.POST("/reset-password", authLimit, authHandler.ResetPassword)
 		auth.GET("/verify-email", authHandler.VerifyEmail)
-		auth.POST("/resend-verification", authHandler.ResendVerification)
+		auth.GET("/confirm-email-change", authHandler.ConfirmEmailChange)
+		auth.POST("/resend-verification", authLimit, authHandler.ResendVerification)
 
 		// Protected auth endpoints
 		protected := auth.Group("")
-		protected.Use(middleware.AuthMiddleware(cfg))
+		protected.Use(middleware.AuthMiddleware(cfg, redisClient))
 		{
 			protected.POST("/logout", authHandler.Logout)
+			protected.POST("/logout-all", middleware.ImpersonationGuard(), authHandler.LogoutAll)
+			protected.POST("/change-email", middleware.ImpersonationGuard(), authHandler.ChangeEmail)
+			protected.GET("/sessions", authHandler.GetSessions)
+			protected.DELETE("/sessions/:id", middleware.ImpersonationGuard(), authHandler.RevokeSession)
 			protected.GET("/profile", authHandler.GetProfile)
 			protected.PUT("/profile", authHandler.UpdateProfile)
-			protected.PUT("/change-password", authHandler.ChangePassword)
+			protected.PUT("/change-password", middleware.ImpersonationGuard(), authHandler.ChangePassword)
 			protected.GET("/me", authHandler.GetCurrentUser)
 			protected.GET("/validate", authHandler.ValidateToken)
+			protected.POST("/2fa/enable", middleware.ImpersonationGuard(), authHandler.EnableTwoFactor)
+			protected.POST("/2fa/verify", middleware.ImpersonationGuard(), authHandler.VerifyTwoFactor)
 		}
 	}
 }
@@ -79,9 +155,9 @@ func SetupAuthRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client
 // SetupUserRoutes sets up user related routes
 func SetupUserRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client, cfg *config.Config) {
 	userAddressHandler := handlers.NewUserAddressHandler(db, cfg)
-	userProfileHandler := handlers.NewUserProfileHandler(db, cfg)
+	userProfileHandler := handlers.NewUserProfileHandler(db, redisClient, cfg)
 	users := rg.Group("/users")
-	users.Use(middleware.AuthMiddleware(cfg)) // All user routes require authentication
+	users.Use(middleware.AuthMiddleware(cfg, redisClient)) // All user routes require authentication
 	{
 		addresses := users.Group("/addresses")
 		{
@@ -96,7 +172,7 @@ func SetupUserRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client
 		users.PUT("/profile", userProfileHandler.UpdateProfile)
 		users.GET("/account", userProfileHandler.GetAccount)
 		users.GET("/dashboard", userProfileHandler.GetDashboard)
-		users.PUT("/change-password", userProfileHandler.ChangePassword)
+		users.PUT("/change-password", middleware.ImpersonationGuard(), userProfileHandler.ChangePassword)
 
 		users.GET("/orders", func(c *gin.Context) {
 			c.Redirect(http.StatusMovedPermanently, "/api/v1/orders")
@@ -106,17 +182,19 @@ func SetupUserRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client
 
 // SetupProductRoutes sets up product related routes
 func SetupProductRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client, cfg *config.Config) {
-	productHandler := handlers.NewProductHandler(db, cfg)
+	productHandler := handlers.NewProductHandler(db, redisClient, cfg)
 	categoryHandler := handlers.NewCategoryHandler(db, cfg)
+	brandHandler := handlers.NewBrandHandler(db, cfg)
 
 	products := rg.Group("/products")
-	products.Use(middleware.OptionalAuthMiddleware(cfg)) // Optional auth for personalization
+	products.Use(middleware.OptionalAuthMiddleware(cfg, redisClient)) // Optional auth for personalization
 	{
 		// Product endpoints
 		products.GET("", productHandler.GetProducts)
 		products.GET("/:id", productHandler.GetProduct)
+		products.GET("/:id/related", productHandler.GetRelatedProducts)
 		products.GET("/slug/:slug", productHandler.GetProductBySlug)
-		products.GET("/search", productHandler.SearchProducts)
+		products.GET("/search", middleware.RateLimitWithRule(cfg, redisClient, middleware.SearchRateLimitRule(cfg)), productHandler.SearchProducts)
 
 		// Category endpoints
 		categories := products.Group("/categories")
@@ -129,14 +207,13 @@ func SetupProductRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Cli
 			categories.GET("/:id/subcategories", categoryHandler.GetSubcategories)
 		}
 
-		// Brand endpoints (placeholder for future implementation)
-		products.GET("/brands", func(c *gin.Context) {
-			c.JSON(200, gin.H{"message": "List brands endpoint - Coming soon"})
-		})
-
-		products.GET("/brands/:id", func(c *gin.Context) {
-			c.JSON(200, gin.H{"message": "Get brand endpoint - Coming soon"})
-		})
+		// Brand endpoints
+		brands := products.Group("/brands")
+		{
+			brands.GET("", brandHandler.GetBrands)                 // GET /products/brands?page=1&limit=20
+			brands.GET("/:id", brandHandler.GetBrand)              // GET /products/brands/:id
+			brands.GET("/slug/:slug", brandHandler.GetBrandBySlug) // GET /products/brands/slug/:slug
+		}
 	}
 }
 
@@ -147,44 +224,71 @@ func SetupOrderRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Clien
 	checkoutHandler := handlers.NewCheckoutHandler(db, redisClient, cfg)
 	wishlistHandler := handlers.NewWishlistHandler(db, redisClient, cfg)
 	invoiceHandler := handlers.NewInvoiceHandler(db, cfg)
+	compareHandler := handlers.NewCompareHandler(db, redisClient, cfg)
+	recentlyViewedHandler := handlers.NewRecentlyViewedHandler(db, redisClient, cfg)
+	downloadHandler := handlers.NewDownloadHandler(db, cfg)
+	returnHandler := handlers.NewReturnHandler(db, cfg)
+
+	// Guest order lookup - the order number and email authorize it
+	rg.POST("/orders/track", middleware.RateLimitWithRule(cfg, redisClient, middleware.OrderLookupRateLimitRule(cfg)), orderHandler.LookupOrder)
 
 	// Order routes - require authentication
 	orders := rg.Group("/orders")
-	orders.Use(middleware.AuthMiddleware(cfg))
+	orders.Use(middleware.AuthMiddleware(cfg, redisClient))
 	{
 		// User order endpoints
 		orders.POST("", orderHandler.CreateOrder)                         // Create order from cart
 		orders.GET("", orderHandler.GetOrders)                            // Get user's orders
+		orders.GET("/status-counts", orderHandler.GetOrderStatusCounts)   // Dashboard counts by status
 		orders.GET("/:id", orderHandler.GetOrder)                         // Get specific order
 		orders.GET("/number/:orderNumber", orderHandler.GetOrderByNumber) // Get order by number
 		orders.PUT("/:id/cancel", orderHandler.CancelOrder)               // Cancel order
+		orders.PUT("/:id/shipping-address", orderHandler.UpdateShippingAddress)
 		orders.GET("/:id/track", orderHandler.TrackOrder)
 		orders.GET("/:id/invoice", invoiceHandler.GenerateInvoice) // Track order
+		orders.GET("/:id/packing-slip", invoiceHandler.GeneratePackingSlip)
+		orders.GET("/:id/downloads", downloadHandler.GetOrderDownloads)
+		orders.POST("/:id/returns", returnHandler.RequestReturn)  // Request a return
+		orders.GET("/:id/returns", returnHandler.GetOrderReturns) // Return requests for the order
 	}
 
+	// Digital download links - the token authorizes the download
+	rg.GET("/downloads/:token", downloadHandler.Download)
+
 	// Cart routes (can work with guest sessions or authenticated users)
 	cart := rg.Group("/cart")
-	cart.Use(middleware.OptionalAuthMiddleware(cfg))
+	cart.Use(middleware.OptionalAuthMiddleware(cfg, redisClient))
 	{
 		cart.GET("", cartHandler.GetCart)
 		cart.POST("/items", cartHandler.AddToCart)
+		cart.POST("/items/bulk", cartHandler.BulkAddToCart)
 		cart.PUT("/items/:id", cartHandler.UpdateCartItem)
 		cart.DELETE("/items/:id", cartHandler.RemoveFromCart)
+		cart.POST("/items/:id/save-for-later", cartHandler.SaveForLater)
+		cart.GET("/saved", cartHandler.GetSavedItems)
+		cart.POST("/saved/:id/move-to-cart", cartHandler.MoveSavedToCart)
+		cart.DELETE("/saved/:id", cartHandler.RemoveSavedItem)
 		cart.DELETE("", cartHandler.ClearCart)
 		cart.GET("/count", cartHandler.GetCartCount)
 		cart.POST("/validate", cartHandler.ValidateCart)
 
 		// Cart merge endpoint (requires authentication)
 		cartAuth := cart.Group("")
-		cartAuth.Use(middleware.AuthMiddleware(cfg))
+		cartAuth.Use(middleware.AuthMiddleware(cfg, redisClient))
 		{
 			cartAuth.POST("/merge", cartHandler.MergeGuestCart)
 		}
 	}
 
+	// Shipping estimate for product page widgets - no address or cart required
+	shipping := rg.Group("/shipping")
+	{
+		shipping.POST("/estimate", checkoutHandler.EstimateShipping)
+	}
+
 	// Checkout routes require authentication
 	checkout := rg.Group("/checkout")
-	checkout.Use(middleware.AuthMiddleware(cfg))
+	checkout.Use(middleware.AuthMiddleware(cfg, redisClient))
 	{
 		// Main checkout endpoint
 		checkout.GET("/summary", checkoutHandler.GetCheckoutSummary)
@@ -197,6 +301,9 @@ func SetupOrderRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Clien
 		// Tax calculation
 		checkout.POST("/calculate-tax", checkoutHandler.GetTaxCalculation)
 
+		// Checkout add-ons
+		checkout.POST("/calculate-add-ons", checkoutHandler.CalculateAddOns)
+
 		// Coupon management
 		checkout.POST("/apply-coupon", checkoutHandler.ApplyCoupon)
 		checkout.POST("/remove-coupon", checkoutHandler.RemoveCoupon)
@@ -210,9 +317,12 @@ func SetupOrderRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Clien
 		})
 	}
 
+	// Shared wishlists are public and read-only
+	rg.GET("/wishlist/shared/:token", wishlistHandler.GetSharedWishlist)
+
 	// Wishlist routes
 	wishlist := rg.Group("/wishlist")
-	wishlist.Use(middleware.AuthMiddleware(cfg))
+	wishlist.Use(middleware.AuthMiddleware(cfg, redisClient))
 	{
 		// Basic wishlist operations
 		wishlist.GET("", wishlistHandler.GetWishlist)
@@ -230,59 +340,36 @@ func SetupOrderRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Clien
 
 		// Utility endpoints
 		wishlist.GET("/check/:id", wishlistHandler.CheckItemInWishlist)
+
+		// Sharing
+		wishlist.POST("/share", wishlistHandler.ShareWishlist)
+		wishlist.DELETE("/share", wishlistHandler.RevokeWishlistShare)
 	}
 
-	// Compare products (placeholder for future implementation)
+	// Compare products - persisted for logged-in users, session-based for guests
 	compare := rg.Group("/compare")
-	compare.Use(middleware.OptionalAuthMiddleware(cfg))
+	compare.Use(middleware.OptionalAuthMiddleware(cfg, redisClient))
 	{
-		compare.GET("", func(c *gin.Context) {
-			c.JSON(200, gin.H{
-				"message": "Product comparison endpoint - Coming soon",
-				"data": gin.H{
-					"products":          []gin.H{},
-					"max_compare_items": 4,
-					"comparison_attributes": []string{
-						"price", "rating", "features", "specifications",
-					},
-				},
-			})
-		})
-
-		compare.POST("/add/:id", func(c *gin.Context) {
-			c.JSON(200, gin.H{"message": "Add to comparison endpoint - Coming soon"})
-		})
-
-		compare.DELETE("/remove/:id", func(c *gin.Context) {
-			c.JSON(200, gin.H{"message": "Remove from comparison endpoint - Coming soon"})
-		})
+		compare.GET("", compareHandler.GetCompareList)
+		compare.POST("/add/:id", compareHandler.AddToCompare)
+		compare.DELETE("/remove/:id", compareHandler.RemoveFromCompare)
+		compare.DELETE("", compareHandler.ClearCompare)
+	}
 
-		compare.DELETE("", func(c *gin.Context) {
-			c.JSON(200, gin.H{"message": "Clear comparison endpoint - Coming soon"})
-		})
+	// Compare merge endpoint (requires authentication)
+	compareAuth := rg.Group("/compare")
+	compareAuth.Use(middleware.AuthMiddleware(cfg, redisClient))
+	{
+		compareAuth.POST("/merge", compareHandler.MergeGuestCompare)
 	}
 
-	// Recently viewed products (placeholder for future implementation)
+	// Recently viewed products - keyed by user, or by session for guests
 	recentlyViewed := rg.Group("/recently-viewed")
-	recentlyViewed.Use(middleware.OptionalAuthMiddleware(cfg))
+	recentlyViewed.Use(middleware.OptionalAuthMiddleware(cfg, redisClient))
 	{
-		recentlyViewed.GET("", func(c *gin.Context) {
-			c.JSON(200, gin.H{
-				"message": "Recently viewed products endpoint - Coming soon",
-				"data": gin.H{
-					"products":  []gin.H{},
-					"max_items": 10,
-				},
-			})
-		})
-
-		recentlyViewed.POST("/add/:id", func(c *gin.Context) {
-			c.JSON(200, gin.H{"message": "Add to recently viewed endpoint - Coming soon"})
-		})
-
-		recentlyViewed.DELETE("", func(c *gin.Context) {
-			c.JSON(200, gin.H{"message": "Clear recently viewed endpoint - Coming soon"})
-		})
+		recentlyViewed.GET("", recentlyViewedHandler.GetRecentlyViewed)
+		recentlyViewed.POST("/add/:id", recentlyViewedHandler.AddRecentlyViewed)
+		recentlyViewed.DELETE("", recentlyViewedHandler.ClearRecentlyViewed)
 	}
 }
 
@@ -292,13 +379,14 @@ func SetupPaymentRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Cli
 
 	// Payment routes - require authentication
 	payment := rg.Group("/payment")
-	payment.Use(middleware.AuthMiddleware(cfg))
+	payment.Use(middleware.AuthMiddleware(cfg, redisClient))
 	{
 		// Payment initiation and verification
 		payment.POST("/initiate", paymentHandler.InitiatePayment)
 		payment.POST("/verify", paymentHandler.VerifyPayment)
 		payment.POST("/failure", paymentHandler.HandlePaymentFailure)
 		payment.GET("/status/:orderId", paymentHandler.GetPaymentStatus)
+		payment.POST("/resend-link/:orderId", paymentHandler.ResendPaymentLink)
 		payment.GET("/methods", paymentHandler.GetPaymentMethods)
 	}
 
@@ -306,24 +394,33 @@ func SetupPaymentRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Cli
 	webhooks := rg.Group("/webhooks")
 	{
 		webhooks.POST("/razorpay", paymentHandler.RazorpayWebhook)
-		// Future: webhooks.POST("/stripe", paymentHandler.StripeWebhook)
+		webhooks.POST("/stripe", paymentHandler.StripeWebhook)
 	}
 }
 
 // SetupAdminRoutes sets up admin related routes
 func SetupAdminRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client, cfg *config.Config) {
-	productHandler := handlers.NewProductHandler(db, cfg)
+	productHandler := handlers.NewProductHandler(db, redisClient, cfg)
 	categoryHandler := handlers.NewCategoryHandler(db, cfg)
 	orderHandler := handlers.NewOrderHandler(db, redisClient, cfg)
 	paymentHandler := handlers.NewPaymentHandler(db, redisClient, cfg)
-	uploadHandler := handlers.NewUploadHandler(db, cfg)
+	uploadHandler := handlers.NewUploadHandler(db, redisClient, cfg)
 	inventoryHandler := handlers.NewInventoryHandler(db, cfg)
-	userAdminHandler := handlers.NewUserAdminHandler(db, cfg)
-	analyticsHandler := handlers.NewAnalyticsHandler(db, cfg)
+	userAdminHandler := handlers.NewUserAdminHandler(db, redisClient, cfg)
+	analyticsHandler := handlers.NewAnalyticsHandler(db, redisClient, cfg)
+	policyHandler := handlers.NewPolicyHandler(db, cfg)
+	reviewHandler := handlers.NewReviewHandler(product.NewReviewService(db, redisClient, cfg))
+	couponHandler := handlers.NewCouponHandler(db, cfg)
+	brandHandler := handlers.NewBrandHandler(db, cfg)
+	settingHandler := handlers.NewSettingHandler(db, redisClient, cfg)
+	shippingHandler := handlers.NewShippingHandler(db, redisClient, cfg)
+	emailTemplateHandler := handlers.NewEmailTemplateHandler(db, cfg)
+	invoiceHandler := handlers.NewInvoiceHandler(db, cfg)
+	returnHandler := handlers.NewReturnHandler(db, cfg)
 
 	admin := rg.Group("/admin")
-	admin.Use(middleware.AuthMiddleware(cfg)) // Require authentication
-	admin.Use(middleware.AdminMiddleware())   // Require admin privileges
+	admin.Use(middleware.AuthMiddleware(cfg, redisClient)) // Require authentication
+	admin.Use(middleware.AdminMiddleware())                // Require admin privileges
 	{
 		// Product management
 		products := admin.Group("/products")
@@ -334,11 +431,10 @@ func SetupAdminRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Clien
 			products.PUT("/:id", productHandler.AdminUpdateProduct)
 			products.DELETE("/:id", productHandler.AdminDeleteProduct)
 			products.PUT("/:id/inventory", productHandler.AdminUpdateInventory)
+			products.POST("/:id/variants/generate", productHandler.AdminGenerateVariants)
 
 			// Product bulk operations
-			products.POST("/bulk-update", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Bulk update products endpoint - Coming soon"})
-			})
+			products.POST("/bulk-update", productHandler.AdminBulkUpdateProducts)
 
 			products.POST("/bulk-delete", func(c *gin.Context) {
 				c.JSON(200, gin.H{"message": "Bulk delete products endpoint - Coming soon"})
@@ -348,9 +444,7 @@ func SetupAdminRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Clien
 				c.JSON(200, gin.H{"message": "Import products endpoint - Coming soon"})
 			})
 
-			products.GET("/export", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Export products endpoint - Coming soon"})
-			})
+			products.GET("/export", productHandler.AdminExportProducts)
 		}
 
 		warehouses := admin.Group("/warehouses")
@@ -362,8 +456,11 @@ func SetupAdminRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Clien
 		inventory := admin.Group("/inventory")
 		{
 			inventory.GET("/:productId/:warehouseId", inventoryHandler.GetInventoryItem)
+			inventory.PUT("/:productId/:warehouseId/safety-stock", inventoryHandler.SetSafetyStock)
+			inventory.GET("/:productId/:warehouseId/movements", inventoryHandler.GetMovementHistory)
 			inventory.POST("", inventoryHandler.CreateOrUpdateInventoryItem)
 			inventory.POST("/movements", inventoryHandler.RecordStockMovement)
+			inventory.PUT("/reorder-levels", inventoryHandler.BulkUpdateReorderLevels)
 		}
 
 		// Category management
@@ -377,6 +474,7 @@ func SetupAdminRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Clien
 			categories.DELETE("/:id", categoryHandler.AdminDeleteCategory)
 
 			// Category bulk operations
+			categories.POST("/:id/products", categoryHandler.AdminBulkAssignProducts)
 			categories.POST("/reorder", func(c *gin.Context) {
 				c.JSON(200, gin.H{"message": "Reorder categories endpoint - Coming soon"})
 			})
@@ -385,13 +483,30 @@ func SetupAdminRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Clien
 		// Order management
 		orders := admin.Group("/orders")
 		{
-			orders.GET("", orderHandler.AdminGetOrders)                    // List all orders
-			orders.GET("/stats", orderHandler.AdminGetOrderStats)          // Order statistics
-			orders.GET("/export", orderHandler.AdminExportOrders)          // Export orders
+			orders.GET("", orderHandler.AdminGetOrders)                     // List all orders
+			orders.GET("/stats", orderHandler.AdminGetOrderStats)           // Order statistics
+			orders.GET("/export", orderHandler.AdminExportOrders)           // Export orders
+			orders.GET("/address-holds", orderHandler.AdminGetAddressHolds) // Orders awaiting address verification
+
+			// Scheduled exports
+			orders.GET("/export-schedules", orderHandler.AdminGetExportSchedules)
+			orders.POST("/export-schedules", orderHandler.AdminCreateExportSchedule)
+			orders.PUT("/export-schedules/:id", orderHandler.AdminUpdateExportSchedule)
+			orders.DELETE("/export-schedules/:id", orderHandler.AdminDeleteExportSchedule)
+			orders.POST("/export-schedules/:id/run", orderHandler.AdminRunExportSchedule)
+			orders.GET("/export-schedules/:id/runs", orderHandler.AdminGetExportRuns)
+			orders.GET("/export-runs/:id/download", orderHandler.AdminDownloadExportRun)
+
 			orders.GET("/:id", orderHandler.AdminGetOrder)                 // Get specific order
 			orders.PUT("/:id/status", orderHandler.AdminUpdateOrderStatus) // Update order status
 			orders.PUT("/:id/cancel", orderHandler.AdminCancelOrder)       // Cancel order
 			orders.POST("/:id/refund", orderHandler.AdminRefundOrder)      // Process refund
+			orders.GET("/:id/packing-slip", invoiceHandler.AdminGeneratePackingSlip)
+			orders.PUT("/:id/shipping-address", orderHandler.AdminUpdateShippingAddress)
+			orders.POST("/:id/verify-address", orderHandler.AdminVerifyAddress)
+			orders.POST("/:id/reset-payment-attempts", orderHandler.AdminResetPaymentAttempts)
+			orders.GET("/:id/fulfillment-groups", orderHandler.AdminGetFulfillmentGroups)
+			orders.PUT("/:id/fulfillment-groups/:groupId", orderHandler.AdminUpdateFulfillmentGroup)
 
 			// Bulk operations
 			orders.POST("/bulk-update", func(c *gin.Context) {
@@ -403,80 +518,141 @@ func SetupAdminRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Clien
 			})
 		}
 
+		// Return requests
+		returnsAdmin := admin.Group("/returns")
+		{
+			returnsAdmin.GET("", returnHandler.AdminGetReturns)                // GET /admin/returns
+			returnsAdmin.GET("/:id", returnHandler.AdminGetReturn)             // GET /admin/returns/:id
+			returnsAdmin.PUT("/:id/approve", returnHandler.AdminApproveReturn) // PUT /admin/returns/:id/approve
+			returnsAdmin.PUT("/:id/reject", returnHandler.AdminRejectReturn)   // PUT /admin/returns/:id/reject
+			returnsAdmin.POST("/:id/refund", returnHandler.AdminRefundReturn)  // POST /admin/returns/:id/refund
+		}
+
 		// Payment management
 		payments := admin.Group("/payments")
 		{
 			payments.GET("", paymentHandler.AdminGetPayments)
 			payments.POST("/:paymentId/refund", paymentHandler.AdminRefundPayment)
 			payments.GET("/stats", paymentHandler.AdminGetPaymentStats)
+			payments.GET("/settlements", paymentHandler.AdminGetSettlementReport)
+			payments.GET("/settlements/:orderId", paymentHandler.AdminGetOrderSettlement)
+			payments.GET("/webhooks", paymentHandler.AdminGetWebhookEvents)
+			payments.GET("/webhooks/:eventId", paymentHandler.AdminGetWebhookEvent)
 		}
 
 		// User management
 		users := admin.Group("/users")
 		{
-			users.GET("", userAdminHandler.GetUsers)                    // GET /admin/users
-			users.GET("/export", userAdminHandler.ExportUsers)          // GET /admin/users/export
-			users.GET("/:id", userAdminHandler.GetUser)                 // GET /admin/users/:id
-			users.PUT("/:id/status", userAdminHandler.UpdateUserStatus) // PUT /admin/users/:id/status
-			users.PUT("/:id/admin", userAdminHandler.ToggleUserAdmin)   // PUT /admin/users/:id/admin
+			users.GET("", userAdminHandler.GetUsers)                         // GET /admin/users
+			users.GET("/export", userAdminHandler.ExportUsers)               // GET /admin/users/export
+			users.GET("/:id", userAdminHandler.GetUser)                      // GET /admin/users/:id
+			users.GET("/:id/profile", userAdminHandler.GetCustomerProfile)   // GET /admin/users/:id/profile
+			users.PUT("/:id/status", userAdminHandler.UpdateUserStatus)      // PUT /admin/users/:id/status
+			users.PUT("/:id/admin", userAdminHandler.ToggleUserAdmin)        // PUT /admin/users/:id/admin
+			users.POST("/:id/impersonate", userAdminHandler.ImpersonateUser) // POST /admin/users/:id/impersonate
+			users.POST("/:id/unlock", userAdminHandler.UnlockUser)           // POST /admin/users/:id/unlock
+			users.GET("/:id/data-export", userAdminHandler.ExportUserData)   // GET /admin/users/:id/data-export
+			users.DELETE("/:id", userAdminHandler.AnonymizeUser)             // DELETE /admin/users/:id
 		}
 
-		// Brand management (placeholder)
+		// Brand management
 		brands := admin.Group("/brands")
 		{
-			brands.GET("", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Admin list brands endpoint - Coming soon"})
-			})
-
-			brands.POST("", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Admin create brand endpoint - Coming soon"})
-			})
-
-			brands.PUT("/:id", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Admin update brand endpoint - Coming soon"})
-			})
-
-			brands.DELETE("/:id", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Admin delete brand endpoint - Coming soon"})
-			})
+			brands.GET("", brandHandler.AdminGetBrands)                           // GET /admin/brands
+			brands.POST("", brandHandler.AdminCreateBrand)                        // POST /admin/brands
+			brands.GET("/:id", brandHandler.AdminGetBrand)                        // GET /admin/brands/:id
+			brands.PUT("/:id", brandHandler.AdminUpdateBrand)                     // PUT /admin/brands/:id
+			brands.DELETE("/:id", brandHandler.AdminDeleteBrand)                  // DELETE /admin/brands/:id?detach_products=true
+			brands.POST("/:id/products", brandHandler.AdminAssignBrandProducts)   // POST /admin/brands/:id/products
+			brands.DELETE("/:id/products", brandHandler.AdminRemoveBrandProducts) // DELETE /admin/brands/:id/products
 		}
 
 		// Analytics and reporting
 		analytics := admin.Group("/analytics")
 		{
-			analytics.GET("/dashboard", analyticsHandler.GetDashboard) // GET /admin/analytics/dashboard
-			analytics.GET("/sales", analyticsHandler.GetSales)         // GET /admin/analytics/sales
-			analytics.GET("/products", analyticsHandler.GetProducts)   // GET /admin/analytics/products
-			analytics.GET("/customers", analyticsHandler.GetCustomers) // GET /admin/analytics/customers
-			analytics.GET("/revenue", analyticsHandler.GetRevenue)     // GET /admin/analytics/revenue
+			analytics.GET("/dashboard", analyticsHandler.GetDashboard)        // GET /admin/analytics/dashboard
+			analytics.GET("/sales", analyticsHandler.GetSales)                // GET /admin/analytics/sales
+			analytics.GET("/sales/peak-times", analyticsHandler.GetPeakTimes) // GET /admin/analytics/sales/peak-times
+			analytics.GET("/products", analyticsHandler.GetProducts)          // GET /admin/analytics/products
+			analytics.GET("/customers", analyticsHandler.GetCustomers)        // GET /admin/analytics/customers
+			analytics.GET("/revenue", analyticsHandler.GetRevenue)            // GET /admin/analytics/revenue
+
+			analytics.GET("/abandoned-carts", analyticsHandler.GetAbandonedCarts) // GET /admin/analytics/abandoned-carts
+			analytics.GET("/search-terms", analyticsHandler.GetSearchTerms)       // GET /admin/analytics/search-terms
+
+			// Revenue targets
+			analytics.GET("/revenue/targets", analyticsHandler.GetRevenueTargets)    // GET /admin/analytics/revenue/targets
+			analytics.PUT("/revenue/targets", analyticsHandler.UpdateRevenueTargets) // PUT /admin/analytics/revenue/targets
+
+			// Report exports (format=csv|xlsx)
+			analytics.GET("/sales/export", analyticsHandler.ExportSales)     // GET /admin/analytics/sales/export
+			analytics.GET("/revenue/export", analyticsHandler.ExportRevenue) // GET /admin/analytics/revenue/export
 		}
 
-		// Settings and configuration
-		settings := admin.Group("/settings")
+		// Store policies (shipping, returns, privacy, terms, faq)
+		policies := admin.Group("/policies")
 		{
-			settings.GET("", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Get admin settings endpoint - Coming soon"})
-			})
+			policies.GET("", policyHandler.AdminGetPolicies)        // GET /admin/policies
+			policies.PUT("/:type", policyHandler.AdminUpdatePolicy) // PUT /admin/policies/:type
+		}
 
-			settings.PUT("", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Update admin settings endpoint - Coming soon"})
-			})
+		// Review moderation
+		reviews := admin.Group("/reviews")
+		{
+			reviews.GET("", reviewHandler.AdminGetReviews)
+			reviews.GET("/reported", reviewHandler.AdminGetReportedReviews)
+			reviews.GET("/reports", reviewHandler.AdminGetReviewReports)
+			reviews.POST("/reports/:reportId/resolve", reviewHandler.AdminResolveReviewReport)
+			reviews.GET("/:id/reports", reviewHandler.AdminGetReviewWithReports)
+			reviews.GET("/export", reviewHandler.AdminExportReviews)
+			reviews.PUT("/:id/approve", reviewHandler.AdminApproveReview)
+			reviews.POST("/:id/reply", reviewHandler.AdminReplyToReview)
+			reviews.POST("/bulk-action", reviewHandler.AdminBulkReviewAction)
+		}
 
-			settings.GET("/shipping", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Get shipping settings endpoint - Coming soon"})
-			})
+		// Product Q&A moderation
+		questions := admin.Group("/questions")
+		{
+			questions.GET("", reviewHandler.AdminGetQuestions)                 // GET /admin/questions?pending=true
+			questions.PUT("/:id/approve", reviewHandler.AdminModerateQuestion) // PUT /admin/questions/:id/approve
+		}
+		admin.PUT("/answers/:id/approve", reviewHandler.AdminModerateAnswer) // PUT /admin/answers/:id/approve
 
-			settings.PUT("/shipping", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Update shipping settings endpoint - Coming soon"})
-			})
+		// Email templates overriding the built-in copy
+		emailTemplates := admin.Group("/email-templates")
+		{
+			emailTemplates.GET("", emailTemplateHandler.AdminGetTemplates)             // GET /admin/email-templates
+			emailTemplates.POST("", emailTemplateHandler.AdminCreateTemplate)          // POST /admin/email-templates
+			emailTemplates.POST("/preview", emailTemplateHandler.AdminPreviewTemplate) // POST /admin/email-templates/preview
+			emailTemplates.GET("/:id", emailTemplateHandler.AdminGetTemplate)          // GET /admin/email-templates/:id
+			emailTemplates.PUT("/:id", emailTemplateHandler.AdminUpdateTemplate)       // PUT /admin/email-templates/:id
+			emailTemplates.DELETE("/:id", emailTemplateHandler.AdminDeleteTemplate)    // DELETE /admin/email-templates/:id
+		}
 
-			settings.GET("/payment", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Get payment settings endpoint - Coming soon"})
-			})
+		// Settings and configuration
+		settings := admin.Group("/settings")
+		{
+			settings.GET("", settingHandler.AdminGetSettings)                     // GET /admin/settings
+			settings.PUT("", settingHandler.AdminUpdateSettings)                  // PUT /admin/settings
+			settings.GET("/shipping", settingHandler.AdminGetShippingSettings)    // GET /admin/settings/shipping
+			settings.PUT("/shipping", settingHandler.AdminUpdateShippingSettings) // PUT /admin/settings/shipping
+			settings.GET("/payment", settingHandler.AdminGetPaymentSettings)      // GET /admin/settings/payment
+			settings.PUT("/payment", settingHandler.AdminUpdatePaymentSettings)   // PUT /admin/settings/payment
+		}
 
-			settings.PUT("/payment", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Update payment settings endpoint - Coming soon"})
-			})
+		// Shipping methods, zones and rates
+		shippingAdmin := admin.Group("/shipping")
+		{
+			shippingAdmin.GET("/methods", shippingHandler.AdminGetMethods)          // GET /admin/shipping/methods
+			shippingAdmin.POST("/methods", shippingHandler.AdminCreateMethod)       // POST /admin/shipping/methods
+			shippingAdmin.GET("/methods/:id", shippingHandler.AdminGetMethod)       // GET /admin/shipping/methods/:id
+			shippingAdmin.PUT("/methods/:id", shippingHandler.AdminUpdateMethod)    // PUT /admin/shipping/methods/:id
+			shippingAdmin.DELETE("/methods/:id", shippingHandler.AdminDeleteMethod) // DELETE /admin/shipping/methods/:id
+			shippingAdmin.GET("/zones", shippingHandler.AdminGetZones)              // GET /admin/shipping/zones
+			shippingAdmin.POST("/zones", shippingHandler.AdminCreateZone)           // POST /admin/shipping/zones
+			shippingAdmin.GET("/zones/:id", shippingHandler.AdminGetZone)           // GET /admin/shipping/zones/:id
+			shippingAdmin.PUT("/zones/:id", shippingHandler.AdminUpdateZone)        // PUT /admin/shipping/zones/:id
+			shippingAdmin.DELETE("/zones/:id", shippingHandler.AdminDeleteZone)     // DELETE /admin/shipping/zones/:id
 		}
 
 		// File upload management
@@ -485,6 +661,7 @@ func SetupAdminRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Clien
 			// Image upload operations
 			uploads.POST("/image", uploadHandler.UploadImage)
 			uploads.POST("/bulk-upload", uploadHandler.UploadMultipleImages)
+			uploads.GET("/jobs/:id", uploadHandler.GetBulkUploadJob)
 			uploads.GET("/images", uploadHandler.GetImages)
 			uploads.GET("/image/:id", uploadHandler.GetImage)
 			uploads.PUT("/image/:id", uploadHandler.UpdateImage)
@@ -499,21 +676,11 @@ func SetupAdminRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Clien
 		// Coupons and discounts
 		coupons := admin.Group("/coupons")
 		{
-			coupons.GET("", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "List coupons endpoint - Coming soon"})
-			})
-
-			coupons.POST("", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Create coupon endpoint - Coming soon"})
-			})
-
-			coupons.PUT("/:id", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Update coupon endpoint - Coming soon"})
-			})
-
-			coupons.DELETE("/:id", func(c *gin.Context) {
-				c.JSON(200, gin.H{"message": "Delete coupon endpoint - Coming soon"})
-			})
+			coupons.GET("", couponHandler.AdminGetCoupons)          // GET /admin/coupons
+			coupons.POST("", couponHandler.AdminCreateCoupon)       // POST /admin/coupons
+			coupons.GET("/:id", couponHandler.AdminGetCoupon)       // GET /admin/coupons/:id
+			coupons.PUT("/:id", couponHandler.AdminUpdateCoupon)    // PUT /admin/coupons/:id
+			coupons.DELETE("/:id", couponHandler.AdminDeleteCoupon) // DELETE /admin/coupons/:id
 		}
 	}
 	rg.GET("/uploads/*filepath", uploadHandler.ServeFile)
@@ -13,6 +13,7 @@ import (
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"github.com/your-org/ecommerce-backend/internal/interfaces/http/middleware"
 	"github.com/your-org/ecommerce-backend/internal/interfaces/http/routes"
+	"github.com/your-org/ecommerce-backend/internal/pkg/metrics"
 	"gorm.io/gorm"
 )
 
@@ -96,6 +97,18 @@ func (s *Server) setupMiddleware() {
 	// Request ID middleware
 	s.gin.Use(middleware.RequestID())
 
+	// Metrics middleware - request counts and latency by route
+	if s.config.Metrics.Enabled {
+		metrics.Default.SetNamespace(s.config.Metrics.Namespace)
+		if err := metrics.InstrumentDB(s.db); err != nil {
+			log.Printf("Failed to instrument database metrics: %v", err)
+		}
+		s.gin.Use(middleware.Metrics())
+	}
+
+	// Locale middleware - picks the language of response messages
+	s.gin.Use(middleware.Locale(s.config))
+
 	// CORS middleware
 	s.gin.Use(middleware.CORS(s.config))
 
@@ -118,6 +131,11 @@ func (s *Server) setupRoutes() {
 	s.gin.GET("/health", s.healthCheck)
 	s.gin.GET("/ready", s.readinessCheck)
 
+	// Prometheus scrape endpoint
+	if s.config.Metrics.Enabled {
+		s.gin.GET(s.config.Metrics.Path, gin.WrapH(metrics.Default.Handler()))
+	}
+
 	// API v1 routes
 	apiV1 := s.gin.Group("/api/v1")
 
@@ -3,9 +3,11 @@ package auth
 
 import (
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/your-org/ecommerce-backend/internal/config"
 )
 
@@ -15,12 +17,22 @@ type Claims struct {
 	Email     string `json:"email"`
 	IsAdmin   bool   `json:"is_admin"`
 	TokenType string `json:"token_type"` // "access" or "refresh"
+
+	// Set on impersonation tokens to the ID of the admin acting as UserID
+	ImpersonatedBy *uint `json:"impersonated_by,omitempty"`
+
+	// The user's token version at issuance; bumping it revokes older tokens
+	TokenVersion int64 `json:"token_version,omitempty"`
+
+	// Login session the token belongs to; stable across refresh token rotation
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // JWTManager handles JWT operations
 type JWTManager struct {
 	config *config.Config
+	tokens *TokenStore // Optional; enables revocation
 }
 
 // NewJWTManager creates a new JWT manager
@@ -30,16 +42,35 @@ func NewJWTManager(cfg *config.Config) *JWTManager {
 	}
 }
 
+// WithTokenStore enables revocation: issued tokens carry the user's token version
+// and validation rejects revoked tokens
+func (j *JWTManager) WithTokenStore(tokens *TokenStore) *JWTManager {
+	j.tokens = tokens
+	return j
+}
+
+// TokenStore returns the revocation store, which may be nil
+func (j *JWTManager) TokenStore() *TokenStore {
+	return j.tokens
+}
+
 // GenerateAccessToken generates a new access token
-func (j *JWTManager) GenerateAccessToken(userID uint, email string, isAdmin bool) (string, error) {
+func (j *JWTManager) GenerateAccessToken(userID uint, email string, isAdmin bool, sessionID string) (string, error) {
 	now := time.Now().UTC()
+	version, err := j.userVersion(userID)
+	if err != nil {
+		return "", err
+	}
 
 	claims := &Claims{
-		UserID:    userID,
-		Email:     email,
-		IsAdmin:   isAdmin,
-		TokenType: "access",
+		UserID:       userID,
+		Email:        email,
+		IsAdmin:      isAdmin,
+		TokenType:    "access",
+		SessionID:    sessionID,
+		TokenVersion: version,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			ExpiresAt: jwt.NewNumericDate(now.Add(j.config.JWT.AccessTokenExpiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
@@ -52,16 +83,72 @@ func (j *JWTManager) GenerateAccessToken(userID uint, email string, isAdmin bool
 	return token.SignedString([]byte(j.config.JWT.Secret))
 }
 
+// GenerateImpersonationToken generates a short-lived access token that lets an admin
+// act as userID. It never carries admin rights and no refresh token is issued for it.
+func (j *JWTManager) GenerateImpersonationToken(userID uint, email string, adminID uint) (string, error) {
+	now := time.Now().UTC()
+	version, err := j.userVersion(userID)
+	if err != nil {
+		return "", err
+	}
+
+	claims := &Claims{
+		UserID:         userID,
+		Email:          email,
+		IsAdmin:        false,
+		TokenType:      "access",
+		ImpersonatedBy: &adminID,
+		TokenVersion:   version,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(j.config.JWT.ImpersonationTokenExpiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    j.config.App.Name,
+			Subject:   fmt.Sprintf("user:%d", userID),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(j.config.JWT.Secret))
+}
+
+// userVersion returns the token version to issue a user's tokens with. If it can't
+// be read, issuing fails unless revocation checks fail open, in which case the token
+// gets version 0 and stops working once a newer version can be read again.
+func (j *JWTManager) userVersion(userID uint) (int64, error) {
+	version, err := j.tokens.UserVersion(userID)
+	if err != nil {
+		if !j.config.Security.TokenRevocationFailOpen {
+			return 0, err
+		}
+		log.Printf("Issuing token for user %d without its token version: %v", userID, err)
+	}
+	return version, nil
+}
+
+// IsImpersonation reports whether the token was issued for an admin acting as the user
+func (c *Claims) IsImpersonation() bool {
+	return c.ImpersonatedBy != nil
+}
+
 // GenerateRefreshToken generates a new refresh token
-func (j *JWTManager) GenerateRefreshToken(userID uint, email string) (string, error) {
+func (j *JWTManager) GenerateRefreshToken(userID uint, email string, sessionID string) (string, error) {
 	now := time.Now().UTC()
+	version, err := j.userVersion(userID)
+	if err != nil {
+		return "", err
+	}
 
 	claims := &Claims{
-		UserID:    userID,
-		Email:     email,
-		IsAdmin:   false, // Don't include admin status in refresh token
-		TokenType: "refresh",
+		UserID:       userID,
+		Email:        email,
+		IsAdmin:      false, // Don't include admin status in refresh token
+		TokenType:    "refresh",
+		SessionID:    sessionID,
+		TokenVersion: version,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			ExpiresAt: jwt.NewNumericDate(now.Add(j.config.JWT.RefreshTokenExpiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
@@ -98,6 +185,17 @@ func (j *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, fmt.Errorf("token type not specified")
 	}
 
+	revoked, err := j.tokens.IsRevoked(claims)
+	if err != nil {
+		if !j.config.Security.TokenRevocationFailOpen {
+			return nil, err
+		}
+		log.Printf("Accepting token for user %d without a revocation check: %v", claims.UserID, err)
+	}
+	if revoked {
+		return nil, fmt.Errorf("token has been revoked")
+	}
+
 	return claims, nil
 }
 
@@ -0,0 +1,83 @@
+// internal/pkg/auth/jwt_test.go
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+func newTestJWTManager(t *testing.T, failOpen bool) (*JWTManager, func(string)) {
+	t.Helper()
+	redisClient, mr := testutil.NewRedis(t)
+	cfg := &config.Config{}
+	cfg.JWT.Secret = "test-secret"
+	cfg.JWT.AccessTokenExpiry = 15 * time.Minute
+	cfg.Security.TokenRevocationFailOpen = failOpen
+	return NewJWTManager(cfg).WithTokenStore(NewTokenStore(redisClient)), mr.SetError
+}
+
+func TestValidateTokenRejectsRevokedTokens(t *testing.T) {
+	j, _ := newTestJWTManager(t, false)
+
+	token, _ := j.GenerateAccessToken(1, "a@example.com", false, "session-1")
+	claims, err := j.ValidateAccessToken(token)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() error = %v", err)
+	}
+	if err := j.TokenStore().Revoke(claims); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := j.ValidateAccessToken(token); err == nil {
+		t.Error("revoked token was accepted")
+	}
+
+	fresh, _ := j.GenerateAccessToken(1, "a@example.com", false, "session-2")
+	if _, err := j.ValidateAccessToken(fresh); err != nil {
+		t.Errorf("fresh token error = %v, want it accepted", err)
+	}
+
+	// Revoking all of the user's tokens catches the fresh one too, but not later ones
+	if err := j.TokenStore().RevokeAllForUser(1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := j.ValidateAccessToken(fresh); err == nil {
+		t.Error("token from before RevokeAllForUser was accepted")
+	}
+	later, _ := j.GenerateAccessToken(1, "a@example.com", false, "session-3")
+	if _, err := j.ValidateAccessToken(later); err != nil {
+		t.Errorf("token from after RevokeAllForUser error = %v, want it accepted", err)
+	}
+}
+
+func TestValidateTokenWithRedisDown(t *testing.T) {
+	tests := []struct {
+		name     string
+		failOpen bool
+	}{
+		{"fails closed by default", false},
+		{"fails open when configured", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j, setRedisError := newTestJWTManager(t, tt.failOpen)
+			token, err := j.GenerateAccessToken(1, "a@example.com", false, "session-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			setRedisError("connection refused")
+			_, err = j.ValidateAccessToken(token)
+			if accepted := err == nil; accepted != tt.failOpen {
+				t.Errorf("ValidateAccessToken() error = %v, want accepted = %v", err, tt.failOpen)
+			}
+			_, err = j.GenerateAccessToken(1, "a@example.com", false, "session-2")
+			if issued := err == nil; issued != tt.failOpen {
+				t.Errorf("GenerateAccessToken() error = %v, want issued = %v", err, tt.failOpen)
+			}
+		})
+	}
+}
@@ -2,9 +2,12 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/your-org/ecommerce-backend/internal/config"
 	"golang.org/x/crypto/bcrypt"
@@ -41,14 +44,20 @@ func (p *PasswordManager) VerifyPassword(password, hash string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 }
 
-// ValidatePassword validates password strength
+// ErrWeakPassword is returned when a password does not meet the configured policy
+var ErrWeakPassword = errors.New("password does not meet requirements")
+
+// ValidatePassword validates password strength against the configured policy
 func (p *PasswordManager) ValidatePassword(password string) error {
-	if len(password) < 8 {
-		return fmt.Errorf("password must be at least 8 characters long")
+	policy := p.config.Password
+
+	length := utf8.RuneCountInString(password)
+	if length < policy.MinLength {
+		return fmt.Errorf("%w: must be at least %d characters long", ErrWeakPassword, policy.MinLength)
 	}
 
-	if len(password) > 128 {
-		return fmt.Errorf("password must be no more than 128 characters long")
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		return fmt.Errorf("%w: must be no more than %d characters long", ErrWeakPassword, policy.MaxLength)
 	}
 
 	var (
@@ -71,17 +80,17 @@ func (p *PasswordManager) ValidatePassword(password string) error {
 		}
 	}
 
-	if !hasUpper {
-		return fmt.Errorf("password must contain at least one uppercase letter")
+	if policy.RequireUpper && !hasUpper {
+		return fmt.Errorf("%w: must contain at least one uppercase letter", ErrWeakPassword)
 	}
-	if !hasLower {
-		return fmt.Errorf("password must contain at least one lowercase letter")
+	if policy.RequireLower && !hasLower {
+		return fmt.Errorf("%w: must contain at least one lowercase letter", ErrWeakPassword)
 	}
-	if !hasNumber {
-		return fmt.Errorf("password must contain at least one number")
+	if policy.RequireDigit && !hasNumber {
+		return fmt.Errorf("%w: must contain at least one number", ErrWeakPassword)
 	}
-	if !hasSpecial {
-		return fmt.Errorf("password must contain at least one special character")
+	if policy.RequireSpecial && !hasSpecial {
+		return fmt.Errorf("%w: must contain at least one special character", ErrWeakPassword)
 	}
 
 	// Check for common patterns
@@ -94,35 +103,51 @@ func (p *PasswordManager) ValidatePassword(password string) error {
 
 // checkCommonPatterns checks for common weak password patterns
 func (p *PasswordManager) checkCommonPatterns(password string) error {
-	// Check for sequential characters (abc, 123)
-	if matched, _ := regexp.MatchString(`(abc|bcd|cde|def|efg|fgh|ghi|hij|ijk|jkl|klm|lmn|mno|nop|opq|pqr|qrs|rst|stu|tuv|uvw|vwx|wxy|xyz)`, password); matched {
-		return fmt.Errorf("password cannot contain sequential letters")
-	}
+	if p.config.Password.RejectSequences {
+		// Check for sequential characters (abc, 123)
+		if matched, _ := regexp.MatchString(`(abc|bcd|cde|def|efg|fgh|ghi|hij|ijk|jkl|klm|lmn|mno|nop|opq|pqr|qrs|rst|stu|tuv|uvw|vwx|wxy|xyz)`, password); matched {
+			return fmt.Errorf("%w: cannot contain sequential letters", ErrWeakPassword)
+		}
 
-	if matched, _ := regexp.MatchString(`(012|123|234|345|456|567|678|789)`, password); matched {
-		return fmt.Errorf("password cannot contain sequential numbers")
-	}
+		if matched, _ := regexp.MatchString(`(012|123|234|345|456|567|678|789)`, password); matched {
+			return fmt.Errorf("%w: cannot contain sequential numbers", ErrWeakPassword)
+		}
 
-	// Check for repeating characters
-	if matched, _ := regexp.MatchString(`(.)\1{2,}`, password); matched {
-		return fmt.Errorf("password cannot contain more than 2 repeating characters")
+		// Check for repeating characters
+		if hasRepeatedRun(password, 3) {
+			return fmt.Errorf("%w: cannot contain more than 2 repeating characters", ErrWeakPassword)
+		}
 	}
 
 	// Check for common weak passwords
-	commonPasswords := []string{
-		"password", "123456", "password123", "admin", "qwerty", "letmein",
-		"welcome", "monkey", "dragon", "password1", "123456789", "football",
-	}
-
-	for _, common := range commonPasswords {
-		if matched, _ := regexp.MatchString(`(?i)`+common, password); matched {
-			return fmt.Errorf("password is too common and easily guessable")
+	lower := strings.ToLower(password)
+	for _, common := range p.config.Password.Blocklist {
+		common = strings.ToLower(strings.TrimSpace(common))
+		if common != "" && strings.Contains(lower, common) {
+			return fmt.Errorf("%w: password is too common and easily guessable", ErrWeakPassword)
 		}
 	}
 
 	return nil
 }
 
+// hasRepeatedRun reports whether any character appears n or more times in a row
+func hasRepeatedRun(s string, n int) bool {
+	var prev rune
+	run := 0
+	for _, r := range s {
+		if r == prev {
+			run++
+		} else {
+			prev, run = r, 1
+		}
+		if run >= n {
+			return true
+		}
+	}
+	return false
+}
+
 // GenerateTemporaryPassword generates a secure temporary password
 func (p *PasswordManager) GenerateTemporaryPassword() (string, error) {
 	// Implementation for generating secure temporary passwords
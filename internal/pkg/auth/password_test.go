@@ -0,0 +1,61 @@
+// internal/pkg/auth/password_test.go
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+)
+
+func TestValidatePassword(t *testing.T) {
+	p := NewPasswordManager(&config.Config{
+		Password: config.PasswordConfig{
+			MinLength:       10,
+			MaxLength:       64,
+			RequireUpper:    true,
+			RequireLower:    true,
+			RequireDigit:    true,
+			RequireSpecial:  true,
+			RejectSequences: true,
+			Blocklist:       []string{"password", " Qwerty "},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		password string
+		reason   string // Empty when the password is accepted
+	}{
+		{"strong", "Tr0ub4dor&Horse", ""},
+		{"too short", "Sh0rt!", "at least 10 characters"},
+		{"too long", "Aa1!" + strings.Repeat("xy", 31), "no more than 64 characters"},
+		{"no digit", "Troubador&Horse", "one number"},
+		{"no uppercase", "tr0ub4dor&horse", "one uppercase letter"},
+		{"no special character", "Tr0ub4dorHorse", "one special character"},
+		{"blocklisted", "MyPassword!9x", "too common"},
+		{"blocklist ignores case and spaces", "Zx!9QWERTYvb", "too common"},
+		{"sequential letters", "Tr0ub4&Wxyz!Q", "sequential letters"},
+		{"sequential numbers", "Tr!ub4&X789q", "sequential numbers"},
+		{"repeated characters", "Tr0ub4&Xaaa!", "repeating characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.ValidatePassword(tt.password)
+			if tt.reason == "" {
+				if err != nil {
+					t.Fatalf("ValidatePassword() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrWeakPassword) {
+				t.Fatalf("ValidatePassword() error = %v, want ErrWeakPassword", err)
+			}
+			if !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("ValidatePassword() error = %q, want it to mention %q", err, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// internal/pkg/auth/totp_test.go
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the RFC 6238 SHA-1 test key "12345678901234567890" in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTOTPRFC6238Vectors(t *testing.T) {
+	// The RFC lists 8 digit codes; a 6 digit code is their last six digits
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, v := range vectors {
+		if !ValidateTOTP(rfc6238Secret, v.code, time.Unix(v.unix, 0)) {
+			t.Errorf("ValidateTOTP(%s) at %d = false, want true", v.code, v.unix)
+		}
+	}
+}
+
+func TestValidateTOTPSkew(t *testing.T) {
+	// 287082 is the code for the period covering 30s-59s
+	tests := []struct {
+		name string
+		unix int64
+		want bool
+	}{
+		{"same period", 45, true},
+		{"one period later", 89, true},
+		{"one period earlier", 0, true},
+		{"two periods later", 90, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateTOTP(rfc6238Secret, "287082", time.Unix(tt.unix, 0)); got != tt.want {
+				t.Errorf("ValidateTOTP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPRejectsMalformedCodes(t *testing.T) {
+	now := time.Unix(59, 0)
+	for _, code := range []string{"", "28708", "2870820", "abcdef"} {
+		if ValidateTOTP(rfc6238Secret, code, now) {
+			t.Errorf("ValidateTOTP(%q) = true, want false", code)
+		}
+	}
+	if !ValidateTOTP(rfc6238Secret, " 287082 ", now) {
+		t.Error("ValidateTOTP() should ignore surrounding spaces")
+	}
+	if ValidateTOTP("not base32!", "287082", now) {
+		t.Error("ValidateTOTP() accepted an invalid secret")
+	}
+}
+
+func TestValidateTOTPAfterRejectsUsedSteps(t *testing.T) {
+	// At 45s the current step is 1, and 287082 is its code
+	step, ok := ValidateTOTPAfter(rfc6238Secret, "287082", time.Unix(45, 0), 0)
+	if !ok || step != 1 {
+		t.Fatalf("ValidateTOTPAfter() = %d, %v, want 1, true", step, ok)
+	}
+	if _, ok := ValidateTOTPAfter(rfc6238Secret, "287082", time.Unix(45, 0), step); ok {
+		t.Error("ValidateTOTPAfter() accepted a code from a step already used")
+	}
+	// Still within drift a period later, but already used
+	if _, ok := ValidateTOTPAfter(rfc6238Secret, "287082", time.Unix(75, 0), step); ok {
+		t.Error("ValidateTOTPAfter() accepted a used code a period later")
+	}
+}
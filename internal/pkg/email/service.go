@@ -12,18 +12,21 @@ import (
 	"time"
 
 	"github.com/your-org/ecommerce-backend/internal/config"
+	"gorm.io/gorm"
 )
 
 // EmailService handles all email operations
 type EmailService struct {
+	db        *gorm.DB // Stored template overrides; may be nil
 	config    *config.Config
 	templates map[string]*template.Template
 	client    *http.Client
 }
 
 // NewEmailService creates a new email service
-func NewEmailService(cfg *config.Config) *EmailService {
+func NewEmailService(db *gorm.DB, cfg *config.Config) *EmailService {
 	service := &EmailService{
+		db:        db,
 		config:    cfg,
 		templates: make(map[string]*template.Template),
 		client: &http.Client{
@@ -60,19 +63,24 @@ func (s *EmailService) SendEmail(ctx context.Context, email *Email) error {
 	}
 }
 
-// ENHANCED: SendTemplateEmail - Generic method for sending templated emails (MATCHING EXISTING PATTERN)
+// ENHANCED: SendTemplateEmail - Generic method for sending templated emails (MATCHING EXISTING PATTERN).
+// A template stored in the database under templateName takes precedence over the built-in one.
 func (s *EmailService) SendTemplateEmail(to, subject, templateName string, data interface{}) error {
-	htmlContent, err := s.renderTemplate(templateName, data)
+	content, err := s.renderEmail(templateName, subject, data)
 	if err != nil {
 		// If template fails, send a basic email
 		log.Printf("Template render failed for %s: %v", templateName, err)
-		htmlContent = s.createBasicEmailHTML(subject, fmt.Sprintf("Email content for %s", templateName))
+		content = &RenderedEmail{
+			Subject: subject,
+			HTML:    s.createBasicEmailHTML(subject, fmt.Sprintf("Email content for %s", templateName)),
+		}
 	}
 
 	email := &Email{
 		To:          []string{to},
-		Subject:     subject,
-		HTMLContent: htmlContent,
+		Subject:     content.Subject,
+		HTMLContent: content.HTML,
+		TextContent: content.Text,
 		Type:        EmailType(templateName),
 		Data:        map[string]interface{}{"template": templateName},
 	}
@@ -92,15 +100,16 @@ func (s *EmailService) SendWelcomeEmail(ctx context.Context, userEmail, userName
 		VerificationURL: fmt.Sprintf("%s/verify-email?token=%s", s.config.External.Email.BaseURL, verificationToken),
 	}
 
-	htmlContent, err := s.renderTemplate("welcome", data)
+	content, err := s.renderEmail("welcome", fmt.Sprintf("Welcome to %s!", s.config.External.Email.FromName), data)
 	if err != nil {
 		return fmt.Errorf("failed to render welcome email template: %w", err)
 	}
 
 	email := &Email{
 		To:          []string{userEmail},
-		Subject:     fmt.Sprintf("Welcome to %s!", s.config.External.Email.FromName),
-		HTMLContent: htmlContent,
+		Subject:     content.Subject,
+		HTMLContent: content.HTML,
+		TextContent: content.Text,
 		Type:        EmailTypeWelcome,
 		Data:        map[string]interface{}{"user_name": userName},
 	}
@@ -117,15 +126,16 @@ func (s *EmailService) SendOrderConfirmationEmail(ctx context.Context, data Orde
 		data.UserEmail,
 	)
 
-	htmlContent, err := s.renderTemplate("order_confirmation", data)
+	content, err := s.renderEmail("order_confirmation", fmt.Sprintf("Order Confirmation - %s", data.OrderNumber), data)
 	if err != nil {
 		return fmt.Errorf("failed to render order confirmation template: %w", err)
 	}
 
 	email := &Email{
 		To:          []string{data.UserEmail},
-		Subject:     fmt.Sprintf("Order Confirmation - %s", data.OrderNumber),
-		HTMLContent: htmlContent,
+		Subject:     content.Subject,
+		HTMLContent: content.HTML,
+		TextContent: content.Text,
 		Type:        EmailTypeOrderConfirmation,
 		Data: map[string]interface{}{
 			"order_number": data.OrderNumber,
@@ -145,15 +155,16 @@ func (s *EmailService) SendPaymentSuccessEmail(ctx context.Context, data Payment
 		data.UserEmail,
 	)
 
-	htmlContent, err := s.renderTemplate("payment_success", data)
+	content, err := s.renderEmail("payment_success", fmt.Sprintf("Payment Successful - %s", data.OrderNumber), data)
 	if err != nil {
 		return fmt.Errorf("failed to render payment success template: %w", err)
 	}
 
 	email := &Email{
 		To:          []string{data.UserEmail},
-		Subject:     fmt.Sprintf("Payment Successful - %s", data.OrderNumber),
-		HTMLContent: htmlContent,
+		Subject:     content.Subject,
+		HTMLContent: content.HTML,
+		TextContent: content.Text,
 		Type:        EmailTypePaymentSuccess,
 		Data: map[string]interface{}{
 			"order_number":   data.OrderNumber,
@@ -174,15 +185,16 @@ func (s *EmailService) SendPaymentFailedEmail(ctx context.Context, data PaymentN
 		data.UserEmail,
 	)
 
-	htmlContent, err := s.renderTemplate("payment_failed", data)
+	content, err := s.renderEmail("payment_failed", fmt.Sprintf("Payment Failed - %s", data.OrderNumber), data)
 	if err != nil {
 		return fmt.Errorf("failed to render payment failed template: %w", err)
 	}
 
 	email := &Email{
 		To:          []string{data.UserEmail},
-		Subject:     fmt.Sprintf("Payment Failed - %s", data.OrderNumber),
-		HTMLContent: htmlContent,
+		Subject:     content.Subject,
+		HTMLContent: content.HTML,
+		TextContent: content.Text,
 		Type:        EmailTypePaymentFailed,
 		Data: map[string]interface{}{
 			"order_number": data.OrderNumber,
@@ -207,15 +219,16 @@ func (s *EmailService) SendPasswordResetEmail(ctx context.Context, userEmail, us
 		ExpiryTime: "24 hours",
 	}
 
-	htmlContent, err := s.renderTemplate("password_reset", data)
+	content, err := s.renderEmail("password_reset", "Reset Your Password", data)
 	if err != nil {
 		return fmt.Errorf("failed to render password reset template: %w", err)
 	}
 
 	email := &Email{
 		To:          []string{userEmail},
-		Subject:     "Reset Your Password",
-		HTMLContent: htmlContent,
+		Subject:     content.Subject,
+		HTMLContent: content.HTML,
+		TextContent: content.Text,
 		Type:        EmailTypePasswordReset,
 		Data:        map[string]interface{}{"user_name": userName},
 	}
@@ -232,15 +245,16 @@ func (s *EmailService) SendOrderStatusUpdateEmail(ctx context.Context, data Orde
 		data.UserEmail,
 	)
 
-	htmlContent, err := s.renderTemplate("order_status_update", data)
+	content, err := s.renderEmail("order_status_update", fmt.Sprintf("Order Update - %s", data.OrderNumber), data)
 	if err != nil {
 		return fmt.Errorf("failed to render order status update template: %w", err)
 	}
 
 	email := &Email{
 		To:          []string{data.UserEmail},
-		Subject:     fmt.Sprintf("Order Update - %s", data.OrderNumber),
-		HTMLContent: htmlContent,
+		Subject:     content.Subject,
+		HTMLContent: content.HTML,
+		TextContent: content.Text,
 		Type:        EmailTypeOrderStatusUpdate,
 		Data: map[string]interface{}{
 			"order_number": data.OrderNumber,
@@ -260,15 +274,16 @@ func (s *EmailService) SendEmailVerificationEmail(ctx context.Context, data Emai
 		data.UserEmail,
 	)
 
-	htmlContent, err := s.renderTemplate("email_verification", data)
+	content, err := s.renderEmail("email_verification", "Verify Your Email Address", data)
 	if err != nil {
 		return fmt.Errorf("failed to render email verification template: %w", err)
 	}
 
 	emailInstance := &Email{
 		To:          []string{data.UserEmail},
-		Subject:     "Verify Your Email Address",
-		HTMLContent: htmlContent,
+		Subject:     content.Subject,
+		HTMLContent: content.HTML,
+		TextContent: content.Text,
 		Type:        EmailTypeEmailVerification,
 		Data: map[string]interface{}{
 			"user_name": data.UserName,
@@ -278,6 +293,17 @@ func (s *EmailService) SendEmailVerificationEmail(ctx context.Context, data Emai
 	return s.SendEmail(ctx, emailInstance)
 }
 
+// BuiltInTemplateNames are the templates loaded from the template directory
+var BuiltInTemplateNames = []string{
+	"welcome",
+	"order_confirmation",
+	"payment_success",
+	"payment_failed",
+	"password_reset",
+	"email_verification",
+	"order_status_update",
+}
+
 // loadTemplates loads all email templates
 func (s *EmailService) loadTemplates() error {
 	templateDir := s.config.External.Email.TemplateDir
@@ -285,17 +311,7 @@ func (s *EmailService) loadTemplates() error {
 		templateDir = "./templates/emails"
 	}
 
-	templates := []string{
-		"welcome",
-		"order_confirmation",
-		"payment_success",
-		"payment_failed",
-		"password_reset",
-		"email_verification",
-		"order_status_update",
-	}
-
-	for _, name := range templates {
+	for _, name := range BuiltInTemplateNames {
 		templatePath := filepath.Join(templateDir, name+".html")
 		tmpl, err := template.ParseFiles(templatePath)
 		if err != nil {
@@ -406,15 +422,16 @@ func (s *EmailService) SendPasswordResetEmailByToken(ctx context.Context, userEm
 		ExpiryTime: "24 hours",
 	}
 
-	htmlContent, err := s.renderTemplate("password_reset", data)
+	content, err := s.renderEmail("password_reset", "Reset Your Password", data)
 	if err != nil {
 		return fmt.Errorf("failed to render password reset template: %w", err)
 	}
 
 	emailInstance := &Email{
 		To:          []string{userEmail},
-		Subject:     "Reset Your Password",
-		HTMLContent: htmlContent,
+		Subject:     content.Subject,
+		HTMLContent: content.HTML,
+		TextContent: content.Text,
 		Type:        EmailTypePasswordReset,
 		Data:        map[string]interface{}{"user_name": userName},
 	}
@@ -0,0 +1,317 @@
+// internal/pkg/email/template_store.go
+package email
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrTemplateNotFound is returned when a stored email template does not exist
+	ErrTemplateNotFound = errors.New("email template not found")
+
+	// ErrTemplateExists is returned when a template with the same name is already stored
+	ErrTemplateExists = errors.New("an email template with this name already exists")
+
+	// ErrInvalidTemplate is returned when a template does not parse or render
+	ErrInvalidTemplate = errors.New("invalid email template")
+)
+
+// Where a rendered email's content came from
+const (
+	TemplateSourceDatabase = "database"
+	TemplateSourceBuiltIn  = "built_in"
+	TemplateSourceDraft    = "draft"
+)
+
+// EmailTemplate is an operator-edited email template. An active template overrides
+// the built-in template of the same name. Subject, HTML and text bodies are Go
+// templates rendered with the email's data, e.g. {{.UserName}} or {{.OrderNumber}}.
+type EmailTemplate struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"uniqueIndex;not null;size:100" json:"name"`
+	Subject   string    `gorm:"size:255" json:"subject"` // Empty keeps the built-in subject
+	HTMLBody  string    `gorm:"type:text;not null" json:"html_body"`
+	TextBody  string    `gorm:"type:text" json:"text_body,omitempty"`
+	IsActive  bool      `gorm:"default:true" json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (EmailTemplate) TableName() string { return "email_templates" }
+
+// EmailTemplateRequest represents the request to create or update a stored template
+type EmailTemplateRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	Subject  string `json:"subject" binding:"max=255"`
+	HTMLBody string `json:"html_body" binding:"required"`
+	TextBody string `json:"text_body"`
+	IsActive *bool  `json:"is_active"` // Defaults to true
+}
+
+// EmailTemplatePreviewRequest renders a template without sending it. Without a
+// body the template currently used for Name is rendered; with one, the draft is.
+type EmailTemplatePreviewRequest struct {
+	Name     string                 `json:"name" binding:"required,max=100"`
+	Subject  string                 `json:"subject"`
+	HTMLBody string                 `json:"html_body"`
+	TextBody string                 `json:"text_body"`
+	Data     map[string]interface{} `json:"data"` // Merged over sample data
+}
+
+// EmailTemplateListResponse lists stored templates and the built-in names they can override
+type EmailTemplateListResponse struct {
+	Templates []EmailTemplate `json:"templates"`
+	BuiltIn   []string        `json:"built_in"`
+}
+
+// RenderedEmail is the rendered content of an email
+type RenderedEmail struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text,omitempty"`
+	Source  string `json:"source"`
+}
+
+// ListTemplates returns the stored templates by name
+func (s *EmailService) ListTemplates() (*EmailTemplateListResponse, error) {
+	templates := []EmailTemplate{}
+	if err := s.db.Order("name ASC").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to list email templates: %w", err)
+	}
+	return &EmailTemplateListResponse{Templates: templates, BuiltIn: BuiltInTemplateNames}, nil
+}
+
+// GetTemplate returns a stored template
+func (s *EmailService) GetTemplate(id uint) (*EmailTemplate, error) {
+	var tmpl EmailTemplate
+	if err := s.db.First(&tmpl, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get email template: %w", err)
+	}
+	return &tmpl, nil
+}
+
+// CreateTemplate stores a template after checking that it parses
+func (s *EmailService) CreateTemplate(req *EmailTemplateRequest) (*EmailTemplate, error) {
+	tmpl := EmailTemplate{IsActive: true}
+	if err := applyTemplateRequest(&tmpl, req); err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := s.db.Model(&EmailTemplate{}).Where("name = ?", tmpl.Name).Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to check email template name: %w", err)
+	}
+	if count > 0 {
+		return nil, ErrTemplateExists
+	}
+
+	// GORM skips zero values on create and reads the column default back into the
+	// struct, so an inactive template needs an explicit update
+	isActive := tmpl.IsActive
+	if err := s.db.Create(&tmpl).Error; err != nil {
+		return nil, fmt.Errorf("failed to create email template: %w", err)
+	}
+	if !isActive {
+		if err := s.db.Model(&tmpl).Update("is_active", false).Error; err != nil {
+			return nil, fmt.Errorf("failed to create email template: %w", err)
+		}
+	}
+	return &tmpl, nil
+}
+
+// UpdateTemplate replaces a stored template after checking that it parses
+func (s *EmailService) UpdateTemplate(id uint, req *EmailTemplateRequest) (*EmailTemplate, error) {
+	tmpl, err := s.GetTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyTemplateRequest(tmpl, req); err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := s.db.Model(&EmailTemplate{}).Where("name = ? AND id <> ?", tmpl.Name, id).Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to check email template name: %w", err)
+	}
+	if count > 0 {
+		return nil, ErrTemplateExists
+	}
+
+	if err := s.db.Save(tmpl).Error; err != nil {
+		return nil, fmt.Errorf("failed to update email template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// DeleteTemplate removes a stored template, restoring the built-in one
+func (s *EmailService) DeleteTemplate(id uint) error {
+	result := s.db.Delete(&EmailTemplate{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete email template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+// PreviewTemplate renders a draft, or the template currently used for a name, with
+// sample data
+func (s *EmailService) PreviewTemplate(req *EmailTemplatePreviewRequest) (*RenderedEmail, error) {
+	data := s.previewData(req.Data)
+
+	if strings.TrimSpace(req.HTMLBody) == "" {
+		return s.renderEmail(req.Name, req.Name, data)
+	}
+
+	draft := &EmailTemplate{Name: req.Name, Subject: req.Subject, HTMLBody: req.HTMLBody, TextBody: req.TextBody}
+	content, err := renderStoredTemplate(draft, req.Name, data)
+	if err != nil {
+		return nil, err
+	}
+	content.Source = TemplateSourceDraft
+	return content, nil
+}
+
+// renderEmail renders the named email, preferring an active stored template and
+// falling back to the built-in one when there is none or it fails to render.
+// defaultSubject is used unless the stored template sets its own.
+func (s *EmailService) renderEmail(name, defaultSubject string, data interface{}) (*RenderedEmail, error) {
+	if stored := s.storedTemplate(name); stored != nil {
+		content, err := renderStoredTemplate(stored, defaultSubject, data)
+		if err == nil {
+			content.Source = TemplateSourceDatabase
+			return content, nil
+		}
+		log.Printf("Stored email template %s failed to render, using built-in: %v", name, err)
+	}
+
+	html, err := s.renderTemplate(name, data)
+	if err != nil {
+		return nil, err
+	}
+	return &RenderedEmail{Subject: defaultSubject, HTML: html, Source: TemplateSourceBuiltIn}, nil
+}
+
+// storedTemplate returns the active stored template for name, or nil
+func (s *EmailService) storedTemplate(name string) *EmailTemplate {
+	if s.db == nil {
+		return nil
+	}
+
+	var tmpl EmailTemplate
+	err := s.db.Where("name = ? AND is_active = ?", name, true).First(&tmpl).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("Failed to load email template %s: %v", name, err)
+		}
+		return nil
+	}
+	return &tmpl
+}
+
+// renderStoredTemplate executes a stored template's subject, HTML and text bodies
+func renderStoredTemplate(tmpl *EmailTemplate, defaultSubject string, data interface{}) (*RenderedEmail, error) {
+	content := &RenderedEmail{Subject: defaultSubject}
+
+	if strings.TrimSpace(tmpl.Subject) != "" {
+		subject, err := executeText(tmpl.Name+"_subject", tmpl.Subject, data)
+		if err != nil {
+			return nil, err
+		}
+		content.Subject = strings.TrimSpace(subject)
+	}
+
+	htmlTmpl, err := template.New(tmpl.Name).Parse(tmpl.HTMLBody)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	var buf bytes.Buffer
+	if err := htmlTmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	content.HTML = buf.String()
+
+	if strings.TrimSpace(tmpl.TextBody) != "" {
+		if content.Text, err = executeText(tmpl.Name+"_text", tmpl.TextBody, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return content, nil
+}
+
+// executeText renders a plain-text template
+func executeText(name, body string, data interface{}) (string, error) {
+	tmpl, err := texttemplate.New(name).Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return buf.String(), nil
+}
+
+// applyTemplateRequest copies a request onto a template, checking that each part parses
+func applyTemplateRequest(tmpl *EmailTemplate, req *EmailTemplateRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTemplate)
+	}
+
+	if _, err := texttemplate.New("subject").Parse(req.Subject); err != nil {
+		return fmt.Errorf("%w: subject: %v", ErrInvalidTemplate, err)
+	}
+	if _, err := template.New("html").Parse(req.HTMLBody); err != nil {
+		return fmt.Errorf("%w: html body: %v", ErrInvalidTemplate, err)
+	}
+	if _, err := texttemplate.New("text").Parse(req.TextBody); err != nil {
+		return fmt.Errorf("%w: text body: %v", ErrInvalidTemplate, err)
+	}
+
+	tmpl.Name = name
+	tmpl.Subject = req.Subject
+	tmpl.HTMLBody = req.HTMLBody
+	tmpl.TextBody = req.TextBody
+	if req.IsActive != nil {
+		tmpl.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// previewData returns sample values for the common template fields, overlaid with
+// the caller's data
+func (s *EmailService) previewData(overrides map[string]interface{}) map[string]interface{} {
+	base := GetBaseTemplateData(s.config.External.Email.FromName, s.config.External.Email.BaseURL, "Jane Doe", "jane@example.com")
+	data := map[string]interface{}{
+		"SiteName":    base.SiteName,
+		"SiteURL":     base.SiteURL,
+		"SupportURL":  base.SupportURL,
+		"UserName":    base.UserName,
+		"UserEmail":   base.UserEmail,
+		"Year":        base.Year,
+		"OrderNumber": "ORD-10001",
+		"OrderURL":    base.SiteURL + "/orders/1",
+		"Amount":      49.99,
+		"Status":      "shipped",
+	}
+	for key, value := range overrides {
+		data[key] = value
+	}
+	return data
+}
@@ -0,0 +1,128 @@
+// internal/pkg/email/template_store_test.go
+package email
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ecommerce-backend/internal/config"
+	"github.com/your-org/ecommerce-backend/internal/testutil"
+)
+
+// newTestEmailService returns an email service whose built-in password reset
+// template is a known one-liner
+func newTestEmailService(t *testing.T) *EmailService {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "password_reset.html"), []byte("Built-in reset for {{.UserName}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{}
+	cfg.External.Email.TemplateDir = dir
+	cfg.External.Email.FromName = "Shop"
+	return NewEmailService(testutil.NewDB(t, &EmailTemplate{}), cfg)
+}
+
+func resetData() map[string]interface{} {
+	return map[string]interface{}{"SiteName": "Shop", "UserName": "<Jane>"}
+}
+
+func TestRenderEmailPrefersStoredTemplate(t *testing.T) {
+	s := newTestEmailService(t)
+
+	builtIn, err := s.renderEmail("password_reset", "Reset your password", resetData())
+	if err != nil {
+		t.Fatalf("renderEmail() error = %v", err)
+	}
+	if builtIn.Source != TemplateSourceBuiltIn || builtIn.HTML != "Built-in reset for &lt;Jane&gt;" || builtIn.Subject != "Reset your password" {
+		t.Fatalf("without a stored template = %+v, want the built-in one", builtIn)
+	}
+
+	stored, err := s.CreateTemplate(&EmailTemplateRequest{Name: "password_reset", Subject: "Reset your {{.SiteName}} password",
+		HTMLBody: "<p>Hi {{.UserName}}, reset below.</p>", TextBody: "Hi {{.UserName}}, reset below."})
+	if err != nil {
+		t.Fatalf("CreateTemplate() error = %v", err)
+	}
+
+	content, err := s.renderEmail("password_reset", "Reset your password", resetData())
+	if err != nil {
+		t.Fatalf("renderEmail() error = %v", err)
+	}
+	if content.Source != TemplateSourceDatabase || content.Subject != "Reset your Shop password" {
+		t.Errorf("with a stored template = %+v, want the stored subject", content)
+	}
+	if content.HTML != "<p>Hi &lt;Jane&gt;, reset below.</p>" || content.Text != "Hi <Jane>, reset below." {
+		t.Errorf("bodies = %q and %q, want the stored ones filled in", content.HTML, content.Text)
+	}
+
+	// Removing the override restores the built-in template
+	if err := s.DeleteTemplate(stored.ID); err != nil {
+		t.Fatalf("DeleteTemplate() error = %v", err)
+	}
+	if content, _ := s.renderEmail("password_reset", "Reset your password", resetData()); content.Source != TemplateSourceBuiltIn {
+		t.Errorf("after deleting = %s, want %s", content.Source, TemplateSourceBuiltIn)
+	}
+}
+
+func TestRenderEmailFallsBackToBuiltIn(t *testing.T) {
+	inactive := false
+	tests := []struct {
+		name string
+		req  EmailTemplateRequest
+	}{
+		{"inactive", EmailTemplateRequest{Name: "password_reset", HTMLBody: "Stored reset", IsActive: &inactive}},
+		{"fails to render", EmailTemplateRequest{Name: "password_reset", HTMLBody: `Stored {{template "missing"}}`}},
+		{"another email", EmailTemplateRequest{Name: "welcome", HTMLBody: "Stored welcome"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestEmailService(t)
+			if _, err := s.CreateTemplate(&tt.req); err != nil {
+				t.Fatalf("CreateTemplate() error = %v", err)
+			}
+
+			content, err := s.renderEmail("password_reset", "Reset your password", resetData())
+			if err != nil {
+				t.Fatalf("renderEmail() error = %v", err)
+			}
+			if content.Source != TemplateSourceBuiltIn || !strings.HasPrefix(content.HTML, "Built-in reset") {
+				t.Errorf("content = %+v, want the built-in template", content)
+			}
+		})
+	}
+}
+
+func TestSendTemplateEmailUsesStoredSubject(t *testing.T) {
+	s := newTestEmailService(t)
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	s.CreateTemplate(&EmailTemplateRequest{Name: "password_reset", Subject: "Reset your {{.SiteName}} password", HTMLBody: "Hi"})
+	if err := s.SendTemplateEmail("jane@example.com", "Reset your password", "password_reset", resetData()); err != nil {
+		t.Fatalf("SendTemplateEmail() error = %v", err)
+	}
+	if !strings.Contains(logged.String(), "Subject: Reset your Shop password") {
+		t.Errorf("sent email log = %q, want the stored subject", logged.String())
+	}
+}
+
+func TestCreateTemplateValidates(t *testing.T) {
+	s := newTestEmailService(t)
+
+	if _, err := s.CreateTemplate(&EmailTemplateRequest{Name: "welcome", HTMLBody: "Hi {{.UserName"}); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("CreateTemplate(unparseable) error = %v, want %v", err, ErrInvalidTemplate)
+	}
+	if _, err := s.CreateTemplate(&EmailTemplateRequest{Name: "welcome", HTMLBody: "Hi"}); err != nil {
+		t.Fatalf("CreateTemplate() error = %v", err)
+	}
+	if _, err := s.CreateTemplate(&EmailTemplateRequest{Name: " welcome ", HTMLBody: "Hello"}); !errors.Is(err, ErrTemplateExists) {
+		t.Errorf("CreateTemplate(duplicate) error = %v, want %v", err, ErrTemplateExists)
+	}
+}
@@ -18,6 +18,7 @@ const (
 	EmailTypePaymentFailed     EmailType = "payment_failed"
 	EmailTypeShippingUpdate    EmailType = "shipping_update"
 	EmailTypeAccountUpdate     EmailType = "account_update"
+	EmailTypeStockAlert        EmailType = "stock_alert"
 )
 
 // Email represents an email message
@@ -61,6 +62,15 @@ type OrderConfirmationData struct {
 	PaymentMethod   string      `json:"payment_method"`
 	BillingAddress  Address     `json:"billing_address"`
 	ShippingAddress Address     `json:"shipping_address"`
+	DigitalOnly     bool        `json:"digital_only"`      // No item ships, so shipping sections are omitted
+	HasDigitalItems bool        `json:"has_digital_items"` // Download links follow once payment completes
+}
+
+// DownloadLink represents a digital item download link in an email
+type DownloadLink struct {
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	ExpiresAt string `json:"expires_at"`
 }
 
 // OrderItem represents an item in the order
@@ -120,6 +130,7 @@ type OrderStatusUpdateData struct {
 	EmailTemplateData
 	OrderNumber       string `json:"order_number"`
 	Status            string `json:"status"`
+	StatusLabel       string `json:"status_label"` // Readable status, e.g. "Out for delivery"
 	StatusMessage     string `json:"status_message"`
 	TrackingNumber    string `json:"tracking_number,omitempty"`
 	TrackingURL       string `json:"tracking_url,omitempty"`
@@ -0,0 +1,114 @@
+// internal/pkg/i18n/i18n.go
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is the locale every message is guaranteed to exist in
+const DefaultLocale = "en"
+
+// Translate returns the message for id in locale, falling back to English and then
+// to the message ID itself. Args are applied with fmt.Sprintf when given.
+func Translate(locale, id string, args ...interface{}) string {
+	message, ok := catalog[NormalizeLocale(locale)][id]
+	if !ok {
+		message, ok = catalog[DefaultLocale][id]
+		if !ok {
+			message = id
+		}
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// HasLocale reports whether the catalog has messages for locale
+func HasLocale(locale string) bool {
+	_, ok := catalog[NormalizeLocale(locale)]
+	return ok
+}
+
+// NormalizeLocale reduces a language tag to its lower-case base language,
+// e.g. "es-MX" becomes "es"
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		locale = locale[:i]
+	}
+	return locale
+}
+
+// ResolveLocale picks the response locale. An explicit param wins, then the
+// Accept-Language header in preference order; only supported locales that the
+// catalog has are chosen, otherwise fallback is returned.
+func ResolveLocale(param, acceptLanguage string, supported []string, fallback string) string {
+	allowed := make(map[string]bool, len(supported))
+	for _, locale := range supported {
+		if locale = NormalizeLocale(locale); HasLocale(locale) {
+			allowed[locale] = true
+		}
+	}
+
+	if locale := NormalizeLocale(param); allowed[locale] {
+		return locale
+	}
+
+	for _, locale := range parseAcceptLanguage(acceptLanguage) {
+		if allowed[locale] {
+			return locale
+		}
+	}
+
+	if locale := NormalizeLocale(fallback); HasLocale(locale) {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// parseAcceptLanguage returns the base languages of an Accept-Language header,
+// highest quality first
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		locale := NormalizeLocale(fields[0])
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{locale: locale, quality: quality})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].quality > tags[j].quality
+	})
+
+	locales := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		locales = append(locales, tag.locale)
+	}
+	return locales
+}
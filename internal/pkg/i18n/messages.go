@@ -0,0 +1,242 @@
+// internal/pkg/i18n/messages.go
+package i18n
+
+// Message IDs
+const (
+	// Common
+	MsgUserNotAuthenticated = "auth.not_authenticated"
+	MsgAccessDenied         = "auth.access_denied"
+	MsgInvalidRequestData   = "request.invalid_data"
+	MsgInvalidQueryParams   = "request.invalid_query"
+	MsgInvalidProductID     = "product.invalid_id"
+
+	// Orders
+	MsgInvalidOrderID                    = "order.invalid_id"
+	MsgOrderNotFound                     = "order.not_found"
+	MsgOrderNumberRequired               = "order.number_required"
+	MsgOrderCreated                      = "order.created"
+	MsgOrderRetrieved                    = "order.retrieved"
+	MsgOrdersRetrieved                   = "order.list_retrieved"
+	MsgOrderCancelled                    = "order.cancelled"
+	MsgOrderShippingAddressUpdated       = "order.shipping_address_updated"
+	MsgOrderTrackingRetrieved            = "order.tracking_retrieved"
+	MsgOrderStatusUpdated                = "order.status_updated"
+	MsgOrderAddressVerified              = "order.address_verified"
+	MsgOrdersAwaitingAddressVerification = "order.awaiting_address_verification_retrieved"
+	MsgOrderPaymentAttemptsReset         = "order.payment_attempts_reset"
+	MsgOrderCountsRetrieved              = "order.counts_retrieved"
+	MsgFailedToRetrieveOrders            = "order.retrieve_failed"
+	MsgFailedToRetrieveOrderCounts       = "order.counts_retrieve_failed"
+
+	// Cart
+	MsgCartRetrieved              = "cart.retrieved"
+	MsgCartItemAdded              = "cart.item_added"
+	MsgCartItemsBulkAdded         = "cart.items_bulk_added"
+	MsgCartItemUpdated            = "cart.item_updated"
+	MsgCartItemRemoved            = "cart.item_removed"
+	MsgCartCleared                = "cart.cleared"
+	MsgCartCountRetrieved         = "cart.count_retrieved"
+	MsgCartValid                  = "cart.valid"
+	MsgCartValidationFailed       = "cart.validation_failed"
+	MsgGuestCartMerged            = "cart.guest_merged"
+	MsgCartNotFound               = "cart.not_found"
+	MsgCartItemSaved              = "cart.item_saved"
+	MsgSavedItemsRetrieved        = "cart.saved_retrieved"
+	MsgSavedItemMovedToCart       = "cart.saved_moved_to_cart"
+	MsgSavedItemRemoved           = "cart.saved_removed"
+	MsgFailedToGetSavedItems      = "cart.failed_to_get_saved"
+	MsgFailedToRetrieveCart       = "cart.retrieve_failed"
+	MsgFailedToMergeCart          = "cart.merge_failed"
+	MsgFailedToRetrieveMergedCart = "cart.merged_retrieve_failed"
+	MsgFailedToGetCartCount       = "cart.count_failed"
+	MsgFailedToClearCart          = "cart.clear_failed"
+)
+
+// catalog holds the messages of each locale, keyed by message ID. English is the
+// fallback and must contain every ID.
+var catalog = map[string]map[string]string{
+	"en": {
+		MsgUserNotAuthenticated: "User not authenticated",
+		MsgAccessDenied:         "Access denied",
+		MsgInvalidRequestData:   "Invalid request data",
+		MsgInvalidQueryParams:   "Invalid query parameters",
+		MsgInvalidProductID:     "Invalid product ID",
+
+		MsgInvalidOrderID:                    "Invalid order ID",
+		MsgOrderNotFound:                     "Order not found",
+		MsgOrderNumberRequired:               "Order number is required",
+		MsgOrderCreated:                      "Order created successfully",
+		MsgOrderRetrieved:                    "Order retrieved successfully",
+		MsgOrdersRetrieved:                   "Orders retrieved successfully",
+		MsgOrderCancelled:                    "Order cancelled successfully",
+		MsgOrderShippingAddressUpdated:       "Shipping address updated successfully",
+		MsgOrderTrackingRetrieved:            "Order tracking information retrieved successfully",
+		MsgOrderStatusUpdated:                "Order status updated successfully",
+		MsgOrderAddressVerified:              "Order address verified successfully",
+		MsgOrdersAwaitingAddressVerification: "Orders awaiting address verification retrieved successfully",
+		MsgOrderPaymentAttemptsReset:         "Payment attempts reset successfully",
+		MsgOrderCountsRetrieved:              "Order counts retrieved successfully",
+		MsgFailedToRetrieveOrders:            "Failed to retrieve orders",
+		MsgFailedToRetrieveOrderCounts:       "Failed to retrieve order counts",
+
+		MsgCartRetrieved:              "Cart retrieved successfully",
+		MsgCartItemAdded:              "Item added to cart successfully",
+		MsgCartItemsBulkAdded:         "%d of %d items added to cart",
+		MsgCartItemUpdated:            "Cart item updated successfully",
+		MsgCartItemRemoved:            "Item removed from cart successfully",
+		MsgCartCleared:                "Cart cleared successfully",
+		MsgCartCountRetrieved:         "Cart count retrieved successfully",
+		MsgCartValid:                  "Cart validation successful",
+		MsgCartValidationFailed:       "Cart validation failed",
+		MsgGuestCartMerged:            "Guest cart merged successfully",
+		MsgCartNotFound:               "Cart not found",
+		MsgCartItemSaved:              "Item saved for later successfully",
+		MsgSavedItemsRetrieved:        "Saved items retrieved successfully",
+		MsgSavedItemMovedToCart:       "Saved item moved to cart successfully",
+		MsgSavedItemRemoved:           "Saved item removed successfully",
+		MsgFailedToGetSavedItems:      "Failed to get saved items",
+		MsgFailedToRetrieveCart:       "Failed to retrieve cart",
+		MsgFailedToMergeCart:          "Failed to merge cart",
+		MsgFailedToRetrieveMergedCart: "Failed to retrieve merged cart",
+		MsgFailedToGetCartCount:       "Failed to get cart count",
+		MsgFailedToClearCart:          "Failed to clear cart",
+	},
+	"es": {
+		MsgUserNotAuthenticated: "Usuario no autenticado",
+		MsgAccessDenied:         "Acceso denegado",
+		MsgInvalidRequestData:   "Datos de solicitud no válidos",
+		MsgInvalidQueryParams:   "Parámetros de consulta no válidos",
+		MsgInvalidProductID:     "ID de producto no válido",
+
+		MsgInvalidOrderID:                    "ID de pedido no válido",
+		MsgOrderNotFound:                     "Pedido no encontrado",
+		MsgOrderNumberRequired:               "El número de pedido es obligatorio",
+		MsgOrderCreated:                      "Pedido creado correctamente",
+		MsgOrderRetrieved:                    "Pedido obtenido correctamente",
+		MsgOrdersRetrieved:                   "Pedidos obtenidos correctamente",
+		MsgOrderCancelled:                    "Pedido cancelado correctamente",
+		MsgOrderShippingAddressUpdated:       "Dirección de envío actualizada correctamente",
+		MsgOrderTrackingRetrieved:            "Información de seguimiento del pedido obtenida correctamente",
+		MsgOrderStatusUpdated:                "Estado del pedido actualizado correctamente",
+		MsgOrderAddressVerified:              "Dirección del pedido verificada correctamente",
+		MsgOrdersAwaitingAddressVerification: "Pedidos pendientes de verificación de dirección obtenidos correctamente",
+		MsgOrderPaymentAttemptsReset:         "Intentos de pago restablecidos correctamente",
+		MsgOrderCountsRetrieved:              "Recuento de pedidos obtenido correctamente",
+		MsgFailedToRetrieveOrders:            "No se pudieron obtener los pedidos",
+		MsgFailedToRetrieveOrderCounts:       "No se pudo obtener el recuento de pedidos",
+
+		MsgCartRetrieved:              "Carrito obtenido correctamente",
+		MsgCartItemAdded:              "Artículo añadido al carrito correctamente",
+		MsgCartItemsBulkAdded:         "%d de %d artículos añadidos al carrito",
+		MsgCartItemUpdated:            "Artículo del carrito actualizado correctamente",
+		MsgCartItemRemoved:            "Artículo eliminado del carrito correctamente",
+		MsgCartCleared:                "Carrito vaciado correctamente",
+		MsgCartCountRetrieved:         "Recuento del carrito obtenido correctamente",
+		MsgCartValid:                  "Validación del carrito correcta",
+		MsgCartValidationFailed:       "La validación del carrito ha fallado",
+		MsgGuestCartMerged:            "Carrito de invitado combinado correctamente",
+		MsgCartNotFound:               "Carrito no encontrado",
+		MsgCartItemSaved:              "Artículo guardado para más tarde correctamente",
+		MsgSavedItemsRetrieved:        "Artículos guardados obtenidos correctamente",
+		MsgSavedItemMovedToCart:       "Artículo guardado movido al carrito correctamente",
+		MsgSavedItemRemoved:           "Artículo guardado eliminado correctamente",
+		MsgFailedToGetSavedItems:      "No se pudieron obtener los artículos guardados",
+		MsgFailedToRetrieveCart:       "No se pudo obtener el carrito",
+		MsgFailedToMergeCart:          "No se pudo combinar el carrito",
+		MsgFailedToRetrieveMergedCart: "No se pudo obtener el carrito combinado",
+		MsgFailedToGetCartCount:       "No se pudo obtener el recuento del carrito",
+		MsgFailedToClearCart:          "No se pudo vaciar el carrito",
+	},
+	"fr": {
+		MsgUserNotAuthenticated: "Utilisateur non authentifié",
+		MsgAccessDenied:         "Accès refusé",
+		MsgInvalidRequestData:   "Données de requête invalides",
+		MsgInvalidQueryParams:   "Paramètres de requête invalides",
+		MsgInvalidProductID:     "ID de produit invalide",
+
+		MsgInvalidOrderID:                    "ID de commande invalide",
+		MsgOrderNotFound:                     "Commande introuvable",
+		MsgOrderNumberRequired:               "Le numéro de commande est obligatoire",
+		MsgOrderCreated:                      "Commande créée avec succès",
+		MsgOrderRetrieved:                    "Commande récupérée avec succès",
+		MsgOrdersRetrieved:                   "Commandes récupérées avec succès",
+		MsgOrderCancelled:                    "Commande annulée avec succès",
+		MsgOrderShippingAddressUpdated:       "Adresse de livraison mise à jour avec succès",
+		MsgOrderTrackingRetrieved:            "Informations de suivi de la commande récupérées avec succès",
+		MsgOrderStatusUpdated:                "Statut de la commande mis à jour avec succès",
+		MsgOrderAddressVerified:              "Adresse de la commande vérifiée avec succès",
+		MsgOrdersAwaitingAddressVerification: "Commandes en attente de vérification d'adresse récupérées avec succès",
+		MsgOrderPaymentAttemptsReset:         "Tentatives de paiement réinitialisées avec succès",
+		MsgOrderCountsRetrieved:              "Nombre de commandes récupéré avec succès",
+		MsgFailedToRetrieveOrders:            "Échec de la récupération des commandes",
+		MsgFailedToRetrieveOrderCounts:       "Échec de la récupération du nombre de commandes",
+
+		MsgCartRetrieved:              "Panier récupéré avec succès",
+		MsgCartItemAdded:              "Article ajouté au panier avec succès",
+		MsgCartItemsBulkAdded:         "%d articles sur %d ajoutés au panier",
+		MsgCartItemUpdated:            "Article du panier mis à jour avec succès",
+		MsgCartItemRemoved:            "Article retiré du panier avec succès",
+		MsgCartCleared:                "Panier vidé avec succès",
+		MsgCartCountRetrieved:         "Nombre d'articles du panier récupéré avec succès",
+		MsgCartValid:                  "Validation du panier réussie",
+		MsgCartValidationFailed:       "Échec de la validation du panier",
+		MsgGuestCartMerged:            "Panier invité fusionné avec succès",
+		MsgCartNotFound:               "Panier introuvable",
+		MsgCartItemSaved:              "Article mis de côté avec succès",
+		MsgSavedItemsRetrieved:        "Articles mis de côté récupérés avec succès",
+		MsgSavedItemMovedToCart:       "Article mis de côté déplacé vers le panier avec succès",
+		MsgSavedItemRemoved:           "Article mis de côté supprimé avec succès",
+		MsgFailedToGetSavedItems:      "Impossible de récupérer les articles mis de côté",
+		MsgFailedToRetrieveCart:       "Échec de la récupération du panier",
+		MsgFailedToMergeCart:          "Échec de la fusion du panier",
+		MsgFailedToRetrieveMergedCart: "Échec de la récupération du panier fusionné",
+		MsgFailedToGetCartCount:       "Échec de la récupération du nombre d'articles du panier",
+		MsgFailedToClearCart:          "Échec du vidage du panier",
+	},
+	"hi": {
+		MsgUserNotAuthenticated: "उपयोगकर्ता प्रमाणित नहीं है",
+		MsgAccessDenied:         "पहुँच अस्वीकृत",
+		MsgInvalidRequestData:   "अमान्य अनुरोध डेटा",
+		MsgInvalidQueryParams:   "अमान्य क्वेरी पैरामीटर",
+		MsgInvalidProductID:     "अमान्य उत्पाद आईडी",
+
+		MsgInvalidOrderID:                    "अमान्य ऑर्डर आईडी",
+		MsgOrderNotFound:                     "ऑर्डर नहीं मिला",
+		MsgOrderNumberRequired:               "ऑर्डर नंबर आवश्यक है",
+		MsgOrderCreated:                      "ऑर्डर सफलतापूर्वक बनाया गया",
+		MsgOrderRetrieved:                    "ऑर्डर सफलतापूर्वक प्राप्त किया गया",
+		MsgOrdersRetrieved:                   "ऑर्डर सफलतापूर्वक प्राप्त किए गए",
+		MsgOrderCancelled:                    "ऑर्डर सफलतापूर्वक रद्द किया गया",
+		MsgOrderShippingAddressUpdated:       "शिपिंग पता सफलतापूर्वक अपडेट किया गया",
+		MsgOrderTrackingRetrieved:            "ऑर्डर ट्रैकिंग जानकारी सफलतापूर्वक प्राप्त की गई",
+		MsgOrderStatusUpdated:                "ऑर्डर की स्थिति सफलतापूर्वक अपडेट की गई",
+		MsgOrderAddressVerified:              "ऑर्डर का पता सफलतापूर्वक सत्यापित किया गया",
+		MsgOrdersAwaitingAddressVerification: "पता सत्यापन की प्रतीक्षा कर रहे ऑर्डर सफलतापूर्वक प्राप्त किए गए",
+		MsgOrderPaymentAttemptsReset:         "भुगतान प्रयास सफलतापूर्वक रीसेट किए गए",
+		MsgOrderCountsRetrieved:              "ऑर्डर गणना सफलतापूर्वक प्राप्त की गई",
+		MsgFailedToRetrieveOrders:            "ऑर्डर प्राप्त करने में विफल",
+		MsgFailedToRetrieveOrderCounts:       "ऑर्डर गणना प्राप्त करने में विफल",
+
+		MsgCartRetrieved:              "कार्ट सफलतापूर्वक प्राप्त किया गया",
+		MsgCartItemAdded:              "आइटम सफलतापूर्वक कार्ट में जोड़ा गया",
+		MsgCartItemsBulkAdded:         "%d में से %d आइटम कार्ट में जोड़े गए",
+		MsgCartItemUpdated:            "कार्ट आइटम सफलतापूर्वक अपडेट किया गया",
+		MsgCartItemRemoved:            "आइटम सफलतापूर्वक कार्ट से हटाया गया",
+		MsgCartCleared:                "कार्ट सफलतापूर्वक खाली किया गया",
+		MsgCartCountRetrieved:         "कार्ट गणना सफलतापूर्वक प्राप्त की गई",
+		MsgCartValid:                  "कार्ट सत्यापन सफल रहा",
+		MsgCartValidationFailed:       "कार्ट सत्यापन विफल रहा",
+		MsgGuestCartMerged:            "अतिथि कार्ट सफलतापूर्वक मर्ज किया गया",
+		MsgCartNotFound:               "कार्ट नहीं मिला",
+		MsgCartItemSaved:              "आइटम सफलतापूर्वक बाद के लिए सहेजा गया",
+		MsgSavedItemsRetrieved:        "सहेजे गए आइटम सफलतापूर्वक प्राप्त किए गए",
+		MsgSavedItemMovedToCart:       "सहेजा गया आइटम सफलतापूर्वक कार्ट में ले जाया गया",
+		MsgSavedItemRemoved:           "सहेजा गया आइटम सफलतापूर्वक हटाया गया",
+		MsgFailedToGetSavedItems:      "सहेजे गए आइटम प्राप्त करने में विफल",
+		MsgFailedToRetrieveCart:       "कार्ट प्राप्त करने में विफल",
+		MsgFailedToMergeCart:          "कार्ट मर्ज करने में विफल",
+		MsgFailedToRetrieveMergedCart: "मर्ज किया गया कार्ट प्राप्त करने में विफल",
+		MsgFailedToGetCartCount:       "कार्ट गणना प्राप्त करने में विफल",
+		MsgFailedToClearCart:          "कार्ट खाली करने में विफल",
+	},
+}
@@ -0,0 +1,72 @@
+// internal/pkg/metrics/collectors.go
+package metrics
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Payment results recorded by PaymentsTotal
+const (
+	PaymentResultSuccess = "success"
+	PaymentResultFailure = "failure"
+)
+
+// Default is the registry served on the metrics endpoint
+var Default = NewRegistry()
+
+// Application metrics
+var (
+	HTTPRequestsTotal = Default.NewCounterVec("http_requests_total",
+		"HTTP requests by method, route and status code.", "method", "route", "status")
+
+	HTTPRequestDuration = Default.NewHistogramVec("http_request_duration_seconds",
+		"HTTP request latency in seconds by method and route.", DefaultBuckets, "method", "route")
+
+	DBQueryErrorsTotal = Default.NewCounterVec("db_query_errors_total",
+		"Database queries that returned an error, by operation and table.", "operation", "table")
+
+	PaymentsTotal = Default.NewCounterVec("payments_total",
+		"Payments settled by provider and result.", "provider", "result")
+)
+
+// RecordPayment counts a payment that succeeded or failed at the gateway
+func RecordPayment(provider string, success bool) {
+	result := PaymentResultFailure
+	if success {
+		result = PaymentResultSuccess
+	}
+	PaymentsTotal.Inc(provider, result)
+}
+
+// InstrumentDB counts failed queries on db. A missing record is not a failure.
+func InstrumentDB(db *gorm.DB) error {
+	record := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			if tx.Error == nil || errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+				return
+			}
+			table := tx.Statement.Table
+			if table == "" {
+				table = "unknown"
+			}
+			DBQueryErrorsTotal.Inc(operation, table)
+		}
+	}
+
+	callbacks := db.Callback()
+	err := errors.Join(
+		callbacks.Create().After("gorm:create").Register("metrics:create", record("create")),
+		callbacks.Query().After("gorm:query").Register("metrics:query", record("query")),
+		callbacks.Update().After("gorm:update").Register("metrics:update", record("update")),
+		callbacks.Delete().After("gorm:delete").Register("metrics:delete", record("delete")),
+		callbacks.Row().After("gorm:row").Register("metrics:row", record("row")),
+		callbacks.Raw().After("gorm:raw").Register("metrics:raw", record("raw")),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register metrics callbacks: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+// internal/pkg/metrics/metrics.go
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the latency histogram buckets in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Registry holds metrics and writes them in the Prometheus text exposition format.
+// The namespace is prefixed to every metric name when written.
+type Registry struct {
+	mu         sync.RWMutex
+	namespace  string
+	collectors []collector
+}
+
+// collector is a metric family that can write itself
+type collector interface {
+	write(w io.Writer, namespace string)
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// SetNamespace sets the prefix added to metric names, e.g. "shop" turns
+// http_requests_total into shop_http_requests_total
+func (r *Registry) SetNamespace(namespace string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.namespace = strings.Trim(namespace, "_")
+}
+
+// NewCounterVec registers a counter with the given label names
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{family: newFamily(name, help, labels), values: map[string]*counterValue{}}
+	r.register(c)
+	return c
+}
+
+// NewHistogramVec registers a histogram with the given buckets and label names
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	h := &HistogramVec{family: newFamily(name, help, labels), buckets: sorted, values: map[string]*histogramValue{}}
+	r.register(h)
+	return h
+}
+
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, c)
+}
+
+// Write writes every metric in the text exposition format
+func (r *Registry) Write(w io.Writer) {
+	r.mu.RLock()
+	namespace := r.namespace
+	collectors := append([]collector(nil), r.collectors...)
+	r.mu.RUnlock()
+
+	buf := bufio.NewWriter(w)
+	for _, c := range collectors {
+		c.write(buf, namespace)
+	}
+	buf.Flush()
+}
+
+// Handler serves the registry for Prometheus to scrape
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.Write(w)
+	})
+}
+
+// family is the name, help text and label names shared by a metric's series
+type family struct {
+	name   string
+	help   string
+	labels []string
+}
+
+func newFamily(name, help string, labels []string) family {
+	return family{name: name, help: help, labels: labels}
+}
+
+// fullName prefixes the metric name with the namespace
+func (f family) fullName(namespace string) string {
+	if namespace == "" {
+		return f.name
+	}
+	return namespace + "_" + f.name
+}
+
+// seriesKey joins label values into a map key; missing values are left empty
+func (f family) seriesKey(values []string) string {
+	parts := make([]string, len(f.labels))
+	copy(parts, values)
+	return strings.Join(parts, "\x00")
+}
+
+// labelPairs renders the series' labels, plus any extra pair such as le="0.5"
+func (f family) labelPairs(key string, extra ...string) string {
+	values := strings.Split(key, "\x00")
+	pairs := make([]string, 0, len(f.labels)+1)
+	for i, label := range f.labels {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, label, escapeLabel(value)))
+	}
+	if len(extra) == 2 {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extra[0], extra[1]))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (f family) writeHeader(w io.Writer, namespace, kind string) {
+	name := f.fullName(namespace)
+	fmt.Fprintf(w, "# HELP %s %s\n", name, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, kind)
+}
+
+// CounterVec is a counter partitioned by labels
+type CounterVec struct {
+	family
+	mu     sync.Mutex
+	values map[string]*counterValue
+}
+
+type counterValue struct {
+	value float64
+}
+
+// Inc adds one to the series with the given label values, in label order
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds a non-negative amount to the series with the given label values
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		return
+	}
+	key := c.seriesKey(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.values[key]
+	if !ok {
+		v = &counterValue{}
+		c.values[key] = v
+	}
+	v.value += delta
+}
+
+// Value returns the current value of a series
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.values[c.seriesKey(labelValues)]; ok {
+		return v.value
+	}
+	return 0
+}
+
+func (c *CounterVec) write(w io.Writer, namespace string) {
+	c.writeHeader(w, namespace, "counter")
+	name := c.fullName(namespace)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s%s %s\n", name, c.labelPairs(key), formatFloat(c.values[key].value))
+	}
+}
+
+// HistogramVec is a histogram partitioned by labels
+type HistogramVec struct {
+	family
+	buckets []float64
+	mu      sync.Mutex
+	values  map[string]*histogramValue
+}
+
+type histogramValue struct {
+	counts []uint64 // Per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// Observe records a value in the series with the given label values
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := h.seriesKey(labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	v, ok := h.values[key]
+	if !ok {
+		v = &histogramValue{counts: make([]uint64, len(h.buckets))}
+		h.values[key] = v
+	}
+	if i := sort.SearchFloat64s(h.buckets, value); i < len(h.buckets) {
+		v.counts[i]++
+	}
+	v.count++
+	v.sum += value
+}
+
+func (h *HistogramVec) write(w io.Writer, namespace string) {
+	h.writeHeader(w, namespace, "histogram")
+	name := h.fullName(namespace)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, key := range sortedKeys(h.values) {
+		v := h.values[key]
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += v.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", name, h.labelPairs(key, "le", formatFloat(bound)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", name, h.labelPairs(key, "le", "+Inf"), v.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", name, h.labelPairs(key), formatFloat(v.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", name, h.labelPairs(key), v.count)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// escapeLabel escapes a label value as the exposition format requires
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// escapeHelp escapes help text, which unlike label values keeps its quotes
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
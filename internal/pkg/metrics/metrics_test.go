@@ -0,0 +1,116 @@
+// internal/pkg/metrics/metrics_test.go
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// parse reads the registry's output with Prometheus' own text parser
+func parse(t *testing.T, r *Registry) map[string]*dto.MetricFamily {
+	t.Helper()
+	var buf bytes.Buffer
+	r.Write(&buf)
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(&buf)
+	if err != nil {
+		t.Fatalf("exposition output does not parse: %v\n%s", err, buf.String())
+	}
+	return families
+}
+
+// labels maps a metric's label pairs by name
+func labels(m *dto.Metric) map[string]string {
+	out := map[string]string{}
+	for _, pair := range m.GetLabel() {
+		out[pair.GetName()] = pair.GetValue()
+	}
+	return out
+}
+
+func TestCounterExposition(t *testing.T) {
+	r := NewRegistry()
+	r.SetNamespace("shop_")
+	c := r.NewCounterVec("requests_total", `Requests by "route".`, "method", "route")
+	c.Inc("GET", "/products")
+	c.Add(2, "GET", "/products")
+	c.Add(-1, "GET", "/products")
+	c.Inc("POST", "/orders")
+
+	family := parse(t, r)["shop_requests_total"]
+	if family == nil {
+		t.Fatal("shop_requests_total missing from output")
+	}
+	if family.GetType() != dto.MetricType_COUNTER {
+		t.Errorf("type = %v, want COUNTER", family.GetType())
+	}
+	if family.GetHelp() != `Requests by "route".` {
+		t.Errorf("help = %q", family.GetHelp())
+	}
+	if len(family.GetMetric()) != 2 {
+		t.Fatalf("got %d series, want 2", len(family.GetMetric()))
+	}
+	for _, m := range family.GetMetric() {
+		want := 1.0
+		if labels(m)["route"] == "/products" {
+			want = 3
+		}
+		if got := m.GetCounter().GetValue(); got != want {
+			t.Errorf("%v = %v, want %v", labels(m), got, want)
+		}
+	}
+}
+
+func TestHistogramExposition(t *testing.T) {
+	r := NewRegistry()
+	h := r.NewHistogramVec("latency_seconds", "Latency.", []float64{1, 0.1, 0.5}, "route")
+	for _, v := range []float64{0.05, 0.1, 0.3, 2} {
+		h.Observe(v, "/products")
+	}
+
+	family := parse(t, r)["latency_seconds"]
+	if family == nil || family.GetType() != dto.MetricType_HISTOGRAM {
+		t.Fatalf("latency_seconds missing or not a histogram: %v", family)
+	}
+	histogram := family.GetMetric()[0].GetHistogram()
+	if histogram.GetSampleCount() != 4 {
+		t.Errorf("count = %d, want 4", histogram.GetSampleCount())
+	}
+	if histogram.GetSampleSum() != 2.45 {
+		t.Errorf("sum = %v, want 2.45", histogram.GetSampleSum())
+	}
+
+	// Buckets are sorted and cumulative; a value on a bound falls in that bucket
+	want := map[float64]uint64{0.1: 2, 0.5: 3, 1: 3, math.Inf(1): 4}
+	for _, bucket := range histogram.GetBucket() {
+		if bucket.GetCumulativeCount() != want[bucket.GetUpperBound()] {
+			t.Errorf("le=%v count = %d, want %d", bucket.GetUpperBound(), bucket.GetCumulativeCount(), want[bucket.GetUpperBound()])
+		}
+	}
+	if len(histogram.GetBucket()) != len(want) {
+		t.Errorf("got %d buckets, want %d", len(histogram.GetBucket()), len(want))
+	}
+}
+
+func TestLabelEscaping(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("errors_total", "Errors with a \\ and\na newline in the help.", "message")
+	value := "bad \"quote\", back\\slash\nand newline"
+	c.Inc(value)
+
+	family := parse(t, r)["errors_total"]
+	if family == nil {
+		t.Fatal("errors_total missing from output")
+	}
+	if family.GetHelp() != "Errors with a \\ and\na newline in the help." {
+		t.Errorf("help = %q", family.GetHelp())
+	}
+	if got := labels(family.GetMetric()[0])["message"]; got != value {
+		t.Errorf("label = %q, want %q", got, value)
+	}
+}
@@ -346,10 +346,44 @@ const invoiceTemplate = `
                 <td class="label">Shipping:</td>
                 <td class="amount">${{printf "%.2f" (div (float64 .Order.ShippingAmount) 100)}}</td>
             </tr>
+            {{if gt .Order.InsuranceAmount 0}}
+            <tr>
+                <td class="label">Shipping Insurance:</td>
+                <td class="amount">${{printf "%.2f" (div (float64 .Order.InsuranceAmount) 100)}}</td>
+            </tr>
+            {{end}}
+            {{if gt .Order.SignatureFee 0}}
+            <tr>
+                <td class="label">Signature on Delivery:</td>
+                <td class="amount">${{printf "%.2f" (div (float64 .Order.SignatureFee) 100)}}</td>
+            </tr>
+            {{end}}
+            {{if gt .Order.GiftWrapFee 0}}
+            <tr>
+                <td class="label">Gift Wrapping:</td>
+                <td class="amount">${{printf "%.2f" (div (float64 .Order.GiftWrapFee) 100)}}</td>
+            </tr>
+            {{end}}
+            {{if gt .Order.IGSTAmount 0}}
+            <tr>
+                <td class="label">IGST:</td>
+                <td class="amount">${{printf "%.2f" (div (float64 .Order.IGSTAmount) 100)}}</td>
+            </tr>
+            {{else if or (gt .Order.CGSTAmount 0) (gt .Order.SGSTAmount 0)}}
+            <tr>
+                <td class="label">CGST:</td>
+                <td class="amount">${{printf "%.2f" (div (float64 .Order.CGSTAmount) 100)}}</td>
+            </tr>
+            <tr>
+                <td class="label">SGST:</td>
+                <td class="amount">${{printf "%.2f" (div (float64 .Order.SGSTAmount) 100)}}</td>
+            </tr>
+            {{else}}
             <tr>
                 <td class="label">Tax:</td>
                 <td class="amount">${{printf "%.2f" (div (float64 .Order.TaxAmount) 100)}}</td>
             </tr>
+            {{end}}
             <tr class="total-row">
                 <td class="label">Total:</td>
                 <td class="amount">${{printf "%.2f" (div (float64 .Order.TotalAmount) 100)}}</td>
@@ -359,6 +393,13 @@ const invoiceTemplate = `
 
     <div style="clear: both;"></div>
 
+    {{if .Order.GiftMessage}}
+    <div class="gift-message">
+        <div class="section-title">Gift Message:</div>
+        <p>{{.Order.GiftMessage}}</p>
+    </div>
+    {{end}}
+
     <div class="footer">
         <p>Thank you for your business!</p>
         <p>If you have any questions about this invoice, please contact us at {{.Company.Email}} or {{.Company.Phone}}</p>
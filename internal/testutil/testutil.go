@@ -0,0 +1,48 @@
+// internal/testutil/testutil.go
+package testutil
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// NewDB opens a throwaway SQLite database with the given models migrated. Writers
+// take the database lock when their transaction begins, so concurrent transactions
+// queue up the way row locks make them on Postgres.
+func NewDB(t testing.TB, models ...interface{}) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?_busy_timeout=10000&_txlock=immediate&_journal_mode=WAL",
+		filepath.Join(t.TempDir(), "test.db"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	for _, model := range models {
+		if err := db.AutoMigrate(model); err != nil {
+			t.Fatalf("failed to migrate %T: %v", model, err)
+		}
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// NewRedis starts an in-memory Redis server and returns a client for it along with
+// the server, which tests can use to move time forward
+func NewRedis(t testing.TB) (*redis.Client, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return client, server
+}